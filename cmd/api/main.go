@@ -21,6 +21,8 @@ import (
 	"github.com/onnwee/subcults/internal/livekit"
 	"github.com/onnwee/subcults/internal/middleware"
 	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/search"
+	"github.com/onnwee/subcults/internal/server"
 	"github.com/onnwee/subcults/internal/stream"
 )
 
@@ -52,12 +54,27 @@ func main() {
 	logger := middleware.NewLogger(env)
 	slog.SetDefault(logger)
 
+	// Bound handler run time; REQUEST_TIMEOUT takes a Go duration and 0 disables it
+	requestTimeout := middleware.DefaultRequestTimeout
+	if timeoutStr := os.Getenv("REQUEST_TIMEOUT"); timeoutStr != "" {
+		parsed, err := time.ParseDuration(timeoutStr)
+		if err != nil {
+			logger.Error("invalid REQUEST_TIMEOUT", "value", timeoutStr, "error", err)
+			os.Exit(1)
+		}
+		requestTimeout = parsed
+	}
+
 	// Initialize repositories
 	eventRepo := scene.NewInMemoryEventRepository()
 	sceneRepo := scene.NewInMemorySceneRepository()
 	auditRepo := audit.NewInMemoryRepository()
 	rsvpRepo := scene.NewInMemoryRSVPRepository()
 	streamRepo := stream.NewInMemorySessionRepository()
+	searchIndex := search.NewIndexer()
+
+	// Purging a scene removes its events and RSVPs, mirroring ON DELETE CASCADE
+	sceneRepo.SetCascade(eventRepo, rsvpRepo)
 
 	// Initialize Prometheus metrics
 	promRegistry := prometheus.NewRegistry()
@@ -68,6 +85,37 @@ func main() {
 	}
 	logger.Info("stream metrics registered")
 
+	// HTTP metrics are labeled by route template so raw IDs never become label values
+	httpMetrics := middleware.NewHTTPMetrics(
+		"/",
+		"/health",
+		"/healthz",
+		"/readyz",
+		"/metrics",
+		"/events",
+		"/events/upcoming",
+		"/events/{id}",
+		"/events/{id}/calendar.ics",
+		"/events/{id}/cancel",
+		"/events/{id}/checkin",
+		"/events/{id}/nearby",
+		"/events/{id}/rsvp",
+		"/events/{id}/rsvps",
+		"/events/series/{id}/cancel",
+		"/rsvps/mine",
+		"/search",
+		"/search/events",
+		"/livekit/token",
+		"/streams",
+		"/streams/{id}/end",
+		"/streams/{id}/join",
+		"/streams/{id}/leave",
+	)
+	if err := httpMetrics.Register(promRegistry); err != nil {
+		logger.Error("failed to register http metrics", "error", err)
+		os.Exit(1)
+	}
+
 	// Initialize LiveKit token service
 	// Get credentials from environment variables
 	livekitAPIKey := os.Getenv("LIVEKIT_API_KEY")
@@ -88,34 +136,81 @@ func main() {
 
 	// Initialize handlers
 	eventHandlers := api.NewEventHandlers(eventRepo, sceneRepo, auditRepo, rsvpRepo, streamRepo)
-	rsvpHandlers := api.NewRSVPHandlers(rsvpRepo, eventRepo)
+	eventHandlers.SetSearchIndex(searchIndex)
+	rsvpHandlers := api.NewRSVPHandlers(rsvpRepo, eventRepo, sceneRepo)
+	rsvpHandlers.SetStatusChangeInterval(api.DefaultRSVPStatusChangeInterval)
 	streamHandlers := api.NewStreamHandlers(streamRepo, sceneRepo, eventRepo, auditRepo, streamMetrics)
+	searchHandlers := api.NewSearchHandlers(searchIndex)
 
 	// Create HTTP server with routes
 	mux := http.NewServeMux()
 
+	// Bound request bodies on routes that accept JSON payloads
+	limitBody := middleware.MaxBodyBytes(middleware.DefaultMaxBodyBytes)
+
+	// Replay responses for retried creates that carry an Idempotency-Key
+	idempotent := middleware.Idempotency(middleware.NewInMemoryIdempotencyStore(), middleware.DefaultIdempotencyTTL)
+	createEvent := idempotent(http.HandlerFunc(eventHandlers.CreateEvent))
+
 	// Event routes
-	mux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/events", limitBody(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodPost:
-			eventHandlers.CreateEvent(w, r)
+			createEvent.ServeHTTP(w, r)
 		default:
-			ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
-			api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+			ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeMethodNotAllowed)
+			api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeMethodNotAllowed, "Method not allowed")
 		}
-	})
+	})))
 
-	mux.HandleFunc("/events/", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/events/", limitBody(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Parse path to check for special endpoints
-		// Expected patterns: /events/{id}, /events/{id}/cancel, /events/{id}/rsvp
+		// Expected patterns: /events/{id}, /events/{id}/cancel, /events/{id}/nearby, /events/{id}/calendar.ics,
+		// /events/{id}/rsvp, /events/{id}/rsvps, /events/upcoming, /events/series/{id}/cancel
 		pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/"), "/")
 		
+		// Check if this is an upcoming discovery request: /events/upcoming
+		if len(pathParts) == 1 && pathParts[0] == "upcoming" && r.Method == http.MethodGet {
+			eventHandlers.ListUpcoming(w, r)
+			return
+		}
+		
+		// Check if this is a series cancel request: /events/series/{id}/cancel
+		if len(pathParts) == 3 && pathParts[0] == "series" && pathParts[1] != "" && pathParts[2] == "cancel" && r.Method == http.MethodPost {
+			eventHandlers.CancelSeries(w, r)
+			return
+		}
+		
+		// Check if this is a calendar export request: /events/{id}/calendar.ics
+		if len(pathParts) == 2 && pathParts[0] != "" && pathParts[1] == "calendar.ics" && r.Method == http.MethodGet {
+			eventHandlers.ExportICS(w, r)
+			return
+		}
+		
+		// Check if this is a nearby events request: /events/{id}/nearby
+		if len(pathParts) == 2 && pathParts[0] != "" && pathParts[1] == "nearby" && r.Method == http.MethodGet {
+			eventHandlers.ListNearEvent(w, r)
+			return
+		}
+		
 		// Check if this is a cancel request: /events/{id}/cancel
 		if len(pathParts) == 2 && pathParts[0] != "" && pathParts[1] == "cancel" && r.Method == http.MethodPost {
 			eventHandlers.CancelEvent(w, r)
 			return
 		}
 		
+		// Check if this is a check-in request: /events/{id}/checkin
+		if len(pathParts) == 2 && pathParts[0] != "" && pathParts[1] == "checkin" && r.Method == http.MethodPost {
+			rsvpHandlers.CheckIn(w, r)
+			return
+		}
+		
+		// Check if this is an attendee list request: /events/{id}/rsvps
+		if len(pathParts) == 2 && pathParts[0] != "" && pathParts[1] == "rsvps" && r.Method == http.MethodGet {
+			rsvpHandlers.ListRSVPs(w, r)
+			return
+		}
+		
 		// Check if this is an RSVP request: /events/{id}/rsvp
 		if len(pathParts) == 2 && pathParts[0] != "" && pathParts[1] == "rsvp" {
 			switch r.Method {
@@ -124,8 +219,8 @@ func main() {
 			case http.MethodDelete:
 				rsvpHandlers.DeleteRSVP(w, r)
 			default:
-				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
-				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeMethodNotAllowed)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeMethodNotAllowed, "Method not allowed")
 			}
 			return
 		}
@@ -136,19 +231,29 @@ func main() {
 		case http.MethodPatch:
 			eventHandlers.UpdateEvent(w, r)
 		default:
-			ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
-			api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+			ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeMethodNotAllowed)
+			api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeMethodNotAllowed, "Method not allowed")
 		}
-	})
+	})))
 
 	// Search endpoints
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			searchHandlers.Search(w, r)
+		default:
+			ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeMethodNotAllowed)
+			api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeMethodNotAllowed, "Method not allowed")
+		}
+	})
+
 	mux.HandleFunc("/search/events", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
 			eventHandlers.SearchEvents(w, r)
 		default:
-			ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
-			api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+			ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeMethodNotAllowed)
+			api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeMethodNotAllowed, "Method not allowed")
 		}
 	})
 
@@ -156,8 +261,8 @@ func main() {
 	if livekitHandlers != nil {
 		mux.HandleFunc("/livekit/token", func(w http.ResponseWriter, r *http.Request) {
 			if r.Method != http.MethodPost {
-				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
-				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeMethodNotAllowed)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeMethodNotAllowed, "Method not allowed")
 				return
 			}
 			livekitHandlers.IssueToken(w, r)
@@ -165,16 +270,16 @@ func main() {
 	}
 
 	// Stream session routes
-	mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/streams", limitBody(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
-			api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+			ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeMethodNotAllowed)
+			api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeMethodNotAllowed, "Method not allowed")
 			return
 		}
 		streamHandlers.CreateStream(w, r)
-	})
+	})))
 
-	mux.HandleFunc("/streams/", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/streams/", limitBody(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Expected patterns: /streams/{id}/end, /streams/{id}/join, /streams/{id}/leave
 		pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/streams/"), "/")
 		
@@ -199,6 +304,16 @@ func main() {
 		// No other stream endpoints yet, return 404
 		ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeNotFound)
 		api.WriteError(w, ctx, http.StatusNotFound, api.ErrCodeNotFound, "The requested resource was not found")
+	})))
+
+	// The authenticated user's RSVPs
+	mux.HandleFunc("/rsvps/mine", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeMethodNotAllowed)
+			api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeMethodNotAllowed, "Method not allowed")
+			return
+		}
+		rsvpHandlers.ListMyRSVPs(w, r)
 	})
 
 	// Metrics endpoint (Prometheus) - protected with bearer token auth if configured
@@ -227,6 +342,12 @@ func main() {
 		}
 	})
 
+	// Liveness and readiness probes polled by the reverse proxy and orchestrator.
+	// Repositories are in-memory for now, so no readiness checks are registered.
+	healthHandlers := api.NewHealthHandlers()
+	mux.HandleFunc("/healthz", healthHandlers.Healthz)
+	mux.HandleFunc("/readyz", healthHandlers.Readyz)
+
 	// Placeholder root endpoint
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		// Only handle exact root path, everything else returns 404
@@ -243,41 +364,34 @@ func main() {
 		}
 	})
 
-	// Apply middleware: RequestID -> Logging
-	handler := middleware.RequestID(middleware.Logging(logger)(mux))
+	// Apply middleware: RequestID -> AccessLog -> Metrics -> Timeout
+	// Metrics supplies the route template that AccessLog records. Timeout sits
+	// innermost so timed-out requests are logged and counted with their 503.
+	// Every route answers in one write; streaming routes must be mounted outside it.
+	handler := middleware.RequestID(middleware.AccessLog(logger)(middleware.Metrics(httpMetrics)(middleware.Timeout(requestTimeout)(mux))))
 
-	server := &http.Server{
-		Addr:         ":" + port,
-		Handler:      handler,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
-	}
-
-	// Start server in goroutine
-	go func() {
-		logger.Info("starting server", "port", port)
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Error("server error", "error", err)
-			os.Exit(1)
-		}
-	}()
+	// Cancel on interrupt so the server drains in-flight requests before exit
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	// Wait for interrupt signal for graceful shutdown
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-
-	logger.Info("shutting down server...")
-
-	// Create context with timeout for shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	if err := server.Shutdown(ctx); err != nil {
-		logger.Error("server forced to shutdown", "error", err)
+	// Start background purge of soft-deleted scenes
+	purgeJob := scene.NewPurgeJob(scene.PurgeJobConfig{Logger: logger}, sceneRepo)
+	if err := purgeJob.Start(ctx); err != nil {
+		logger.Error("failed to start scene purge job", "error", err)
 		os.Exit(1)
 	}
 
-	logger.Info("server stopped")
+	serverConfig := server.Config{
+		Logger: logger,
+		OnShutdown: []func(context.Context) error{
+			func(context.Context) error {
+				purgeJob.Stop()
+				return nil
+			},
+		},
+	}
+	if err := server.RunWithConfig(ctx, handler, ":"+port, serverConfig); err != nil {
+		logger.Error("server error", "error", err)
+		os.Exit(1)
+	}
 }
@@ -10,9 +10,10 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"github.com/onnwee/subcults/internal/indexer"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/server"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -50,6 +51,11 @@ func main() {
 		logger.Error("failed to register metrics", "error", err)
 		os.Exit(1)
 	}
+	httpMetrics := middleware.NewHTTPMetrics("/internal/indexer/metrics", "/health")
+	if err := httpMetrics.Register(reg); err != nil {
+		logger.Error("failed to register http metrics", "error", err)
+		os.Exit(1)
+	}
 
 	// Create HTTP server for metrics
 	mux := http.NewServeMux()
@@ -68,38 +74,17 @@ func main() {
 		}
 	})
 
-	metricsServer := &http.Server{
-		Addr:         ":" + metricsPort,
-		Handler:      mux,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
-	}
-
-	// Start metrics server in goroutine
-	go func() {
-		logger.Info("starting metrics server", "port", metricsPort)
-		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Error("metrics server error", "error", err)
-			os.Exit(1)
-		}
-	}()
-
-	// TODO: Initialize Jetstream indexer with metrics
-
-	// Wait for interrupt signal for graceful shutdown
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	handler := middleware.RequestID(middleware.AccessLog(logger)(middleware.Metrics(httpMetrics)(mux)))
 
-	logger.Info("shutting down indexer...")
+	// TODO: Initialize Jetstream indexer with metrics, and add its Shutdown
+	// to OnShutdown so in-flight messages drain with the metrics server
 
-	// Create context with timeout for shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	// Cancel on interrupt so the metrics server drains before exit
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	if err := metricsServer.Shutdown(ctx); err != nil {
-		logger.Error("metrics server forced to shutdown", "error", err)
+	if err := server.RunWithConfig(ctx, handler, ":"+metricsPort, server.Config{Logger: logger}); err != nil {
+		logger.Error("metrics server error", "error", err)
 		os.Exit(1)
 	}
 
@@ -4,6 +4,7 @@ package alliance
 
 import (
 	"errors"
+	"sort"
 	"sync"
 	"time"
 
@@ -12,9 +13,23 @@ import (
 
 // Common errors for alliance operations.
 var (
-	ErrAllianceNotFound = errors.New("alliance not found")
+	ErrAllianceNotFound   = errors.New("alliance not found")
+	ErrSelfAlliance       = errors.New("scene cannot ally with itself")
+	ErrDuplicateAlliance  = errors.New("alliance already proposed or active between these scenes")
+	ErrAllianceNotPending = errors.New("alliance is not pending")
 )
 
+// Alliance lifecycle statuses (matches the alliances.status CHECK constraint).
+const (
+	StatusPending   = "pending"
+	StatusActive    = "active"
+	StatusRejected  = "rejected"
+	StatusDissolved = "dissolved"
+)
+
+// DefaultProposalWeight is the trust weight assigned to proposed alliances.
+const DefaultProposalWeight = 1.0
+
 // Alliance represents a trust relationship between two scenes.
 type Alliance struct {
 	ID          string   `json:"id"`
@@ -50,6 +65,28 @@ type AllianceRepository interface {
 
 	// GetByRecordKey retrieves an alliance by its AT Protocol record key.
 	GetByRecordKey(did, rkey string) (*Alliance, error)
+
+	// Propose creates a pending alliance from one scene to another.
+	// Proposals are directional until accepted by the receiving scene.
+	// Returns ErrSelfAlliance if both IDs match, or ErrDuplicateAlliance if a
+	// pending or active alliance already exists in either direction.
+	Propose(fromSceneID, toSceneID string) (*Alliance, error)
+
+	// Accept transitions a pending alliance to active, making it symmetric.
+	// Returns ErrAllianceNotPending if the alliance is not pending.
+	Accept(id string) (*Alliance, error)
+
+	// Reject transitions a pending alliance to rejected.
+	// Returns ErrAllianceNotPending if the alliance is not pending.
+	Reject(id string) (*Alliance, error)
+
+	// ListByScene returns alliances where the scene is on either side,
+	// sorted by created_at then ID.
+	ListByScene(sceneID string) ([]*Alliance, error)
+
+	// AreAllied reports whether an active alliance exists between two scenes
+	// in either direction.
+	AreAllied(sceneA, sceneB string) (bool, error)
 }
 
 // InMemoryAllianceRepository is an in-memory implementation of AllianceRepository.
@@ -168,3 +205,131 @@ func (r *InMemoryAllianceRepository) GetByRecordKey(did, rkey string) (*Alliance
 	allianceCopy := *alliance
 	return &allianceCopy, nil
 }
+
+// Propose creates a pending alliance from one scene to another.
+// A previously rejected or dissolved alliance in the same direction is reopened,
+// since (from_scene_id, to_scene_id) is unique.
+func (r *InMemoryAllianceRepository) Propose(fromSceneID, toSceneID string) (*Alliance, error) {
+	if fromSceneID == toSceneID {
+		return nil, ErrSelfAlliance
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	var reopen *Alliance
+	for _, a := range r.alliances {
+		if !sameScenePair(a, fromSceneID, toSceneID) {
+			continue
+		}
+		if a.Status == StatusPending || a.Status == StatusActive {
+			return nil, ErrDuplicateAlliance
+		}
+		if a.FromSceneID == fromSceneID {
+			reopen = a
+		}
+	}
+
+	if reopen != nil {
+		reopen.Status = StatusPending
+		reopen.UpdatedAt = now
+		allianceCopy := *reopen
+		return &allianceCopy, nil
+	}
+
+	alliance := &Alliance{
+		ID:          uuid.New().String(),
+		FromSceneID: fromSceneID,
+		ToSceneID:   toSceneID,
+		Weight:      DefaultProposalWeight,
+		Status:      StatusPending,
+		Since:       now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	r.alliances[alliance.ID] = alliance
+
+	allianceCopy := *alliance
+	return &allianceCopy, nil
+}
+
+// Accept transitions a pending alliance to active.
+func (r *InMemoryAllianceRepository) Accept(id string) (*Alliance, error) {
+	return r.resolveProposal(id, StatusActive)
+}
+
+// Reject transitions a pending alliance to rejected.
+func (r *InMemoryAllianceRepository) Reject(id string) (*Alliance, error) {
+	return r.resolveProposal(id, StatusRejected)
+}
+
+// resolveProposal moves a pending alliance to the given status.
+func (r *InMemoryAllianceRepository) resolveProposal(id, status string) (*Alliance, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	alliance, ok := r.alliances[id]
+	if !ok {
+		return nil, ErrAllianceNotFound
+	}
+	if alliance.Status != StatusPending {
+		return nil, ErrAllianceNotPending
+	}
+
+	now := time.Now()
+	alliance.Status = status
+	alliance.UpdatedAt = now
+	if status == StatusActive {
+		alliance.Since = now
+	}
+
+	allianceCopy := *alliance
+	return &allianceCopy, nil
+}
+
+// ListByScene returns alliances where the scene is on either side.
+func (r *InMemoryAllianceRepository) ListByScene(sceneID string) ([]*Alliance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]*Alliance, 0)
+	for _, a := range r.alliances {
+		if a.FromSceneID == sceneID || a.ToSceneID == sceneID {
+			allianceCopy := *a
+			results = append(results, &allianceCopy)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].CreatedAt.Equal(results[j].CreatedAt) {
+			return results[i].ID < results[j].ID
+		}
+		return results[i].CreatedAt.Before(results[j].CreatedAt)
+	})
+
+	return results, nil
+}
+
+// AreAllied reports whether an active alliance exists between two scenes.
+func (r *InMemoryAllianceRepository) AreAllied(sceneA, sceneB string) (bool, error) {
+	if sceneA == sceneB {
+		return false, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, a := range r.alliances {
+		if a.Status == StatusActive && sameScenePair(a, sceneA, sceneB) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// sameScenePair reports whether the alliance links the two scenes in either direction.
+func sameScenePair(a *Alliance, sceneA, sceneB string) bool {
+	return (a.FromSceneID == sceneA && a.ToSceneID == sceneB) ||
+		(a.FromSceneID == sceneB && a.ToSceneID == sceneA)
+}
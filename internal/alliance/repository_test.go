@@ -223,3 +223,118 @@ func TestAllianceRepository_GetByID_AfterUpsert(t *testing.T) {
 		t.Errorf("Expected weight 0.5, got %f", retrieved.Weight)
 	}
 }
+
+func TestAllianceRepository_ProposeAcceptLifecycle(t *testing.T) {
+	repo := NewInMemoryAllianceRepository()
+
+	proposal, err := repo.Propose("scene-a", "scene-b")
+	if err != nil {
+		t.Fatalf("Propose failed: %v", err)
+	}
+	if proposal.Status != StatusPending {
+		t.Errorf("Expected status %s, got %s", StatusPending, proposal.Status)
+	}
+
+	// Pending proposals do not ally the scenes
+	allied, err := repo.AreAllied("scene-a", "scene-b")
+	if err != nil {
+		t.Fatalf("AreAllied failed: %v", err)
+	}
+	if allied {
+		t.Error("Expected pending proposal not to ally scenes")
+	}
+
+	accepted, err := repo.Accept(proposal.ID)
+	if err != nil {
+		t.Fatalf("Accept failed: %v", err)
+	}
+	if accepted.Status != StatusActive {
+		t.Errorf("Expected status %s, got %s", StatusActive, accepted.Status)
+	}
+
+	// Accepted alliances are symmetric
+	for _, pair := range [][2]string{{"scene-a", "scene-b"}, {"scene-b", "scene-a"}} {
+		allied, err := repo.AreAllied(pair[0], pair[1])
+		if err != nil {
+			t.Fatalf("AreAllied failed: %v", err)
+		}
+		if !allied {
+			t.Errorf("Expected %s and %s to be allied", pair[0], pair[1])
+		}
+	}
+
+	if _, err := repo.Accept(proposal.ID); err != ErrAllianceNotPending {
+		t.Errorf("Expected ErrAllianceNotPending on second accept, got %v", err)
+	}
+
+	for _, sceneID := range []string{"scene-a", "scene-b"} {
+		alliances, err := repo.ListByScene(sceneID)
+		if err != nil {
+			t.Fatalf("ListByScene failed: %v", err)
+		}
+		if len(alliances) != 1 || alliances[0].ID != proposal.ID {
+			t.Errorf("Expected alliance %s listed for %s, got %d alliances", proposal.ID, sceneID, len(alliances))
+		}
+	}
+}
+
+func TestAllianceRepository_ProposeValidation(t *testing.T) {
+	repo := NewInMemoryAllianceRepository()
+
+	if _, err := repo.Propose("scene-a", "scene-a"); err != ErrSelfAlliance {
+		t.Errorf("Expected ErrSelfAlliance, got %v", err)
+	}
+
+	if _, err := repo.Propose("scene-a", "scene-b"); err != nil {
+		t.Fatalf("Propose failed: %v", err)
+	}
+
+	// Duplicate in either direction is rejected while pending
+	if _, err := repo.Propose("scene-a", "scene-b"); err != ErrDuplicateAlliance {
+		t.Errorf("Expected ErrDuplicateAlliance for repeat proposal, got %v", err)
+	}
+	if _, err := repo.Propose("scene-b", "scene-a"); err != ErrDuplicateAlliance {
+		t.Errorf("Expected ErrDuplicateAlliance for reverse proposal, got %v", err)
+	}
+}
+
+func TestAllianceRepository_RejectAndRepropose(t *testing.T) {
+	repo := NewInMemoryAllianceRepository()
+
+	proposal, err := repo.Propose("scene-a", "scene-b")
+	if err != nil {
+		t.Fatalf("Propose failed: %v", err)
+	}
+
+	rejected, err := repo.Reject(proposal.ID)
+	if err != nil {
+		t.Fatalf("Reject failed: %v", err)
+	}
+	if rejected.Status != StatusRejected {
+		t.Errorf("Expected status %s, got %s", StatusRejected, rejected.Status)
+	}
+
+	if _, err := repo.Reject(proposal.ID); err != ErrAllianceNotPending {
+		t.Errorf("Expected ErrAllianceNotPending on second reject, got %v", err)
+	}
+	if _, err := repo.Accept("missing"); err != ErrAllianceNotFound {
+		t.Errorf("Expected ErrAllianceNotFound, got %v", err)
+	}
+
+	// A rejected proposal can be made again and reuses the same record
+	reproposed, err := repo.Propose("scene-a", "scene-b")
+	if err != nil {
+		t.Fatalf("Re-propose failed: %v", err)
+	}
+	if reproposed.ID != proposal.ID || reproposed.Status != StatusPending {
+		t.Errorf("Expected alliance %s reopened as pending, got %s (%s)", proposal.ID, reproposed.ID, reproposed.Status)
+	}
+
+	alliances, err := repo.ListByScene("scene-a")
+	if err != nil {
+		t.Fatalf("ListByScene failed: %v", err)
+	}
+	if len(alliances) != 1 {
+		t.Errorf("Expected 1 alliance, got %d", len(alliances))
+	}
+}
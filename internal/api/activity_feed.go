@@ -0,0 +1,291 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/geo"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// Activity feed pagination limits.
+const (
+	DefaultActivityFeedLimit = 20
+	MaxActivityFeedLimit     = 100
+)
+
+// Activity feed item types.
+const (
+	ActivitySceneCreated         = "scene_created"
+	ActivitySceneUpdated         = "scene_updated"
+	ActivityPaletteChanged       = "palette_changed"
+	ActivityOwnershipTransferred = "ownership_transferred"
+	ActivityEventCreated         = "event_created"
+	ActivityEventCancelled       = "event_cancelled"
+)
+
+// feedAuditActions maps scene audit actions to feed item types and summaries.
+// Actions not listed here (e.g. location access) never appear in the feed.
+var feedAuditActions = map[string]struct {
+	itemType  string
+	summary   string
+	sensitive bool
+}{
+	"create":         {ActivitySceneCreated, "Scene created", false},
+	"update":         {ActivitySceneUpdated, "Scene details updated", false},
+	"palette_update": {ActivityPaletteChanged, "Scene palette changed", false},
+	"scene_transfer": {ActivityOwnershipTransferred, "Scene ownership transferred", true},
+}
+
+// ActivityItem is a single entry in a scene's activity feed.
+// Items never carry location data.
+type ActivityItem struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	Summary    string    `json:"summary"`
+	ActorDID   string    `json:"actor_did,omitempty"` // Only shown to members and the owner
+	SceneID    string    `json:"scene_id,omitempty"`  // Set in the following feed, which spans scenes
+	EventID    string    `json:"event_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+
+	// sensitive items are only shown to members and the owner
+	sensitive bool
+}
+
+// ActivityFeedResponse represents the response for the scene activity feed endpoint.
+type ActivityFeedResponse struct {
+	Items      []ActivityItem `json:"items"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// GetActivityFeed handles GET /scenes/{id}/activity - lists a scene's recent
+// activity newest first, combining scene changes from the audit log with the
+// scene's events. Visibility follows GetScene, so members-only scenes are
+// limited to members and the owner. Other viewers of public scenes see only
+// non-sensitive items, without actor DIDs.
+//
+// Query parameters: limit (default 20, max 100) and cursor (next_cursor from
+// the previous page).
+func (h *SceneHandlers) GetActivityFeed(w http.ResponseWriter, r *http.Request) {
+	sceneID, ok := parseEntityID(w, r, "/scenes/", "Scene")
+	if !ok {
+		return
+	}
+
+	limit, cursorTime, cursorID, ok := parseActivityFeedParams(w, r)
+	if !ok {
+		return
+	}
+
+	foundScene, err := h.repo.GetByID(r.Context(), sceneID)
+	if err != nil {
+		if err == scene.ErrSceneDeleted {
+			writeError(w, r, ErrCodeSceneDeleted, "Scene not found")
+			return
+		}
+		if err == scene.ErrSceneNotFound {
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+		return
+	}
+
+	requesterDID := middleware.GetUserDID(r.Context())
+	viewerLevel, err := h.sceneViewerLevel(r.Context(), foundScene, requesterDID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to check access permissions")
+		return
+	}
+	if !canViewScene(r.Context(), foundScene, viewerLevel) {
+		// Same response as not found to prevent enumeration
+		writeError(w, r, ErrCodeNotFound, "Scene not found")
+		return
+	}
+
+	items, err := h.collectActivity(r.Context(), sceneID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to collect scene activity", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve activity feed")
+		return
+	}
+
+	items = filterActivityForViewer(items, viewerLevel)
+	items, nextCursor := pageActivity(items, limit, cursorTime, cursorID)
+
+	response := ActivityFeedResponse{
+		Items:      items,
+		NextCursor: nextCursor,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
+
+// parseActivityFeedParams parses the limit and cursor query parameters shared
+// by activity feeds, writing a validation error and returning false if either
+// is invalid.
+func parseActivityFeedParams(w http.ResponseWriter, r *http.Request) (int, time.Time, string, bool) {
+	query := r.URL.Query()
+	limit := DefaultActivityFeedLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(strings.TrimSpace(limitStr))
+		if err != nil || parsed < 1 {
+			writeError(w, r, ErrCodeValidation, "limit must be a positive integer")
+			return 0, time.Time{}, "", false
+		}
+		if parsed > MaxActivityFeedLimit {
+			parsed = MaxActivityFeedLimit
+		}
+		limit = parsed
+	}
+
+	cursorTime, cursorID, err := parseActivityCursor(query.Get("cursor"))
+	if err != nil {
+		writeError(w, r, ErrCodeValidation, "invalid cursor")
+		return 0, time.Time{}, "", false
+	}
+	return limit, cursorTime, cursorID, true
+}
+
+// filterActivityForViewer drops sensitive items and actor identities for
+// public viewers. Members and owners see every item.
+func filterActivityForViewer(items []ActivityItem, viewerLevel string) []ActivityItem {
+	if viewerLevel != geo.ViewerPublic {
+		return items
+	}
+	public := items[:0]
+	for _, item := range items {
+		if item.sensitive {
+			continue
+		}
+		item.ActorDID = ""
+		public = append(public, item)
+	}
+	return public
+}
+
+// pageActivity sorts items newest first and returns the page after the
+// cursor position, with the cursor for the following page (empty if none).
+func pageActivity(items []ActivityItem, limit int, cursorTime time.Time, cursorID string) ([]ActivityItem, string) {
+	// Newest first, ties broken by ID descending for a stable cursor order
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].OccurredAt.Equal(items[j].OccurredAt) {
+			return items[i].OccurredAt.After(items[j].OccurredAt)
+		}
+		return items[i].ID > items[j].ID
+	})
+
+	// Skip everything up to and including the cursor position
+	if !cursorTime.IsZero() {
+		start := len(items)
+		for i, item := range items {
+			if item.OccurredAt.Before(cursorTime) || (item.OccurredAt.Equal(cursorTime) && item.ID < cursorID) {
+				start = i
+				break
+			}
+		}
+		items = items[start:]
+	}
+
+	var nextCursor string
+	if len(items) > limit {
+		items = items[:limit]
+		last := items[limit-1]
+		nextCursor = last.OccurredAt.Format(time.RFC3339Nano) + "|" + last.ID
+	}
+	return items, nextCursor
+}
+
+// collectActivity gathers unsorted feed items for a scene from the audit log
+// and the event repository. Either source is skipped when not configured.
+func (h *SceneHandlers) collectActivity(ctx context.Context, sceneID string) ([]ActivityItem, error) {
+	items := make([]ActivityItem, 0)
+
+	if h.auditRepo != nil {
+		logs, err := h.auditRepo.QueryByEntity(ctx, "scene", sceneID, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range logs {
+			items = appendAuditActivity(items, entry)
+		}
+	}
+
+	if h.eventRepo != nil {
+		events, err := h.eventRepo.ListBySceneID(ctx, sceneID, scene.EventFilter{})
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range events {
+			if event.CreatedAt != nil {
+				items = append(items, ActivityItem{
+					ID:         ActivityEventCreated + ":" + event.ID,
+					Type:       ActivityEventCreated,
+					Summary:    "New event: " + event.Title,
+					EventID:    event.ID,
+					OccurredAt: *event.CreatedAt,
+				})
+			}
+			// The cancellation reason is left out since it may mention private details
+			if event.CancelledAt != nil {
+				items = append(items, ActivityItem{
+					ID:         ActivityEventCancelled + ":" + event.ID,
+					Type:       ActivityEventCancelled,
+					Summary:    "Event cancelled: " + event.Title,
+					EventID:    event.ID,
+					OccurredAt: *event.CancelledAt,
+				})
+			}
+		}
+	}
+
+	return items, nil
+}
+
+// appendAuditActivity appends the feed item for an audit entry, if its action
+// belongs in the feed.
+func appendAuditActivity(items []ActivityItem, entry *audit.AuditLog) []ActivityItem {
+	action, ok := feedAuditActions[entry.Action]
+	if !ok {
+		return items
+	}
+	return append(items, ActivityItem{
+		ID:         entry.ID,
+		Type:       action.itemType,
+		Summary:    action.summary,
+		ActorDID:   entry.UserDID,
+		OccurredAt: entry.CreatedAt,
+		sensitive:  action.sensitive,
+	})
+}
+
+// parseActivityCursor parses an activity feed cursor of the form
+// "RFC3339Nano|ID". An empty cursor yields a zero time.
+func parseActivityCursor(cursor string) (time.Time, string, error) {
+	if cursor == "" {
+		return time.Time{}, "", nil
+	}
+	parts := strings.SplitN(cursor, "|", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return time.Time{}, "", scene.ErrInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, "", scene.ErrInvalidCursor
+	}
+	return t, parts[1], nil
+}
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+const feedSceneID = "4c1f0b7e-2d3a-4b5c-9e8f-7a6b5c4d3e2f"
+
+// seedActivityFeed seeds feedSceneID with the given visibility, owned by
+// did:plc:owner, with active member did:plc:member. The feed holds a create,
+// palette change and ownership transfer from the audit log plus one event
+// with a precise location.
+func seedActivityFeed(t *testing.T, env *testEnv, visibility string) {
+	t.Helper()
+	now := time.Now()
+	env.addScene(t, &scene.Scene{ID: feedSceneID, Name: "Feed Scene", Visibility: visibility, CreatedAt: &now, UpdatedAt: &now})
+	env.addMember(t, feedSceneID, "did:plc:member", "member", "active")
+	for _, action := range []string{"create", "access_precise_location", "palette_update", "scene_transfer"} {
+		env.logAudit(t, audit.LogEntry{
+			UserDID:    "did:plc:owner",
+			EntityType: "scene",
+			EntityID:   feedSceneID,
+			Action:     action,
+		})
+	}
+
+	createdAt := now.Add(time.Minute)
+	env.addEvent(t, &scene.Event{
+		ID:           "5d2a1c8f-3e4b-4c6d-8f9a-8b7c6d5e4f3a",
+		SceneID:      feedSceneID,
+		Title:        "Warehouse Night",
+		AllowPrecise: true,
+		PrecisePoint: &scene.Point{Lat: 40.7128, Lng: -74.0060},
+		StartsAt:     now.Add(24 * time.Hour),
+		CreatedAt:    &createdAt,
+	})
+}
+
+func getActivityFeed(handlers *SceneHandlers, viewerDID, rawQuery string) *httptest.ResponseRecorder {
+	target := "/scenes/" + feedSceneID + "/activity"
+	if rawQuery != "" {
+		target += "?" + rawQuery
+	}
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if viewerDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), viewerDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.GetActivityFeed(w, req)
+	return w
+}
+
+func decodeActivityFeed(t *testing.T, w *httptest.ResponseRecorder) ActivityFeedResponse {
+	t.Helper()
+	var resp ActivityFeedResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode feed response: %v", err)
+	}
+	return resp
+}
+
+func TestGetActivityFeed_MembersOnlyAccess(t *testing.T) {
+	env := newTestEnv()
+	seedActivityFeed(t, env, scene.VisibilityMembersOnly)
+	handlers := env.sceneHandlers()
+	handlers.SetAuditRepository(env.audit)
+	handlers.SetEventRepository(env.events)
+
+	for _, viewer := range []string{"", "did:plc:stranger"} {
+		w := getActivityFeed(handlers, viewer, "")
+		if w.Code != http.StatusNotFound {
+			t.Errorf("viewer %q: expected status 404, got %d", viewer, w.Code)
+		}
+	}
+
+	for _, viewer := range []string{"did:plc:member", "did:plc:owner"} {
+		w := getActivityFeed(handlers, viewer, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("viewer %q: expected status 200, got %d: %s", viewer, w.Code, w.Body.String())
+		}
+		resp := decodeActivityFeed(t, w)
+
+		// create, palette change, transfer and the new event; location access is never listed
+		types := make(map[string]bool)
+		for _, item := range resp.Items {
+			types[item.Type] = true
+		}
+		want := []string{ActivitySceneCreated, ActivityPaletteChanged, ActivityOwnershipTransferred, ActivityEventCreated}
+		if len(resp.Items) != len(want) {
+			t.Errorf("viewer %q: expected %d items, got %d: %+v", viewer, len(want), len(resp.Items), resp.Items)
+		}
+		for _, typ := range want {
+			if !types[typ] {
+				t.Errorf("viewer %q: expected a %s item", viewer, typ)
+			}
+		}
+		if resp.Items[0].Type != ActivityEventCreated {
+			t.Errorf("viewer %q: expected newest item first, got %s", viewer, resp.Items[0].Type)
+		}
+	}
+}
+
+func TestGetActivityFeed_PublicSceneHidesSensitiveItems(t *testing.T) {
+	env := newTestEnv()
+	seedActivityFeed(t, env, scene.VisibilityPublic)
+	handlers := env.sceneHandlers()
+	handlers.SetAuditRepository(env.audit)
+	handlers.SetEventRepository(env.events)
+
+	w := getActivityFeed(handlers, "", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	resp := decodeActivityFeed(t, w)
+
+	if len(resp.Items) != 3 {
+		t.Errorf("expected 3 non-sensitive items, got %d: %+v", len(resp.Items), resp.Items)
+	}
+	for _, item := range resp.Items {
+		if item.Type == ActivityOwnershipTransferred {
+			t.Error("expected ownership transfer to be hidden from non-members")
+		}
+		if item.ActorDID != "" {
+			t.Errorf("expected actor DID to be stripped for non-members, got %q", item.ActorDID)
+		}
+	}
+
+	// Feed items never carry location details
+	for _, field := range []string{"precise_point", "lat", "coarse_geohash"} {
+		if strings.Contains(body, field) {
+			t.Errorf("expected feed response to omit %q, got %s", field, body)
+		}
+	}
+
+	// Members of a public scene still see everything
+	resp = decodeActivityFeed(t, getActivityFeed(handlers, "did:plc:member", ""))
+	if len(resp.Items) != 4 {
+		t.Errorf("expected member to see 4 items, got %d", len(resp.Items))
+	}
+}
+
+func TestGetActivityFeed_CursorContinuation(t *testing.T) {
+	env := newTestEnv()
+	seedActivityFeed(t, env, scene.VisibilityPublic)
+	handlers := env.sceneHandlers()
+	handlers.SetAuditRepository(env.audit)
+	handlers.SetEventRepository(env.events)
+
+	full := decodeActivityFeed(t, getActivityFeed(handlers, "did:plc:owner", ""))
+	if len(full.Items) != 4 || full.NextCursor != "" {
+		t.Fatalf("expected 4 items on one page, got %d (cursor %q)", len(full.Items), full.NextCursor)
+	}
+
+	var paged []ActivityItem
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > 4 {
+			t.Fatal("pagination did not terminate")
+		}
+		query := "limit=3"
+		if cursor != "" {
+			query += "&cursor=" + url.QueryEscape(cursor)
+		}
+		w := getActivityFeed(handlers, "did:plc:owner", query)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		page := decodeActivityFeed(t, w)
+		paged = append(paged, page.Items...)
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	if len(paged) != len(full.Items) {
+		t.Fatalf("expected %d items across pages, got %d", len(full.Items), len(paged))
+	}
+	for i := range paged {
+		if paged[i].ID != full.Items[i].ID {
+			t.Errorf("item %d: expected %s, got %s", i, full.Items[i].ID, paged[i].ID)
+		}
+	}
+}
+
+func TestGetActivityFeed_InvalidParams(t *testing.T) {
+	env := newTestEnv()
+	seedActivityFeed(t, env, scene.VisibilityPublic)
+	handlers := env.sceneHandlers()
+	handlers.SetAuditRepository(env.audit)
+	handlers.SetEventRepository(env.events)
+
+	for _, query := range []string{"cursor=garbage", "limit=0", "limit=abc"} {
+		w := getActivityFeed(handlers, "", query)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("query %q: expected status 400, got %d", query, w.Code)
+		}
+	}
+}
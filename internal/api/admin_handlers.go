@@ -0,0 +1,238 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/search"
+)
+
+// MaxAdminReasonLength is the maximum length of a moderation reason in characters.
+const MaxAdminReasonLength = 500
+
+// AdminHandlers holds dependencies for platform moderation HTTP handlers.
+// Routes must be wrapped in middleware.RequireAdmin; the handlers themselves
+// bypass scene ownership and moderator checks.
+type AdminHandlers struct {
+	sceneRepo      scene.SceneRepository
+	eventRepo      scene.EventRepository
+	membershipRepo membership.MembershipRepository
+	auditRepo      audit.Repository
+
+	// searchIndex drops hidden scenes and removed events from search.
+	// Optional; nil disables search updates.
+	searchIndex *search.Indexer
+}
+
+// NewAdminHandlers creates a new AdminHandlers instance.
+func NewAdminHandlers(
+	sceneRepo scene.SceneRepository,
+	eventRepo scene.EventRepository,
+	membershipRepo membership.MembershipRepository,
+	auditRepo audit.Repository,
+) *AdminHandlers {
+	return &AdminHandlers{
+		sceneRepo:      sceneRepo,
+		eventRepo:      eventRepo,
+		membershipRepo: membershipRepo,
+		auditRepo:      auditRepo,
+	}
+}
+
+// SetSearchIndex keeps the given full-text index updated as scenes are
+// hidden and events removed.
+func (h *AdminHandlers) SetSearchIndex(index *search.Indexer) {
+	h.searchIndex = index
+}
+
+// AdminActionRequest represents the request body for admin moderation actions.
+type AdminActionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// AdminBanResponse represents the response for a platform-wide ban.
+type AdminBanResponse struct {
+	UserDID     string `json:"user_did"`
+	BannedCount int    `json:"banned_count"`
+}
+
+// ForceHideScene handles POST /admin/scenes/{id}/hide - sets a scene's
+// visibility to hidden regardless of ownership and removes it from search.
+// Soft-deleted scenes return 404. Hiding an already hidden scene is a no-op.
+func (h *AdminHandlers) ForceHideScene(w http.ResponseWriter, r *http.Request) {
+	sceneID, ok := parseEntityID(w, r, "/admin/scenes/", "Scene")
+	if !ok {
+		return
+	}
+
+	reason, ok := decodeAdminReason(w, r)
+	if !ok {
+		return
+	}
+
+	targetScene, err := h.sceneRepo.GetByID(r.Context(), sceneID)
+	if err != nil {
+		if err == scene.ErrSceneDeleted {
+			writeError(w, r, ErrCodeSceneDeleted, "Scene not found")
+			return
+		}
+		if err == scene.ErrSceneNotFound {
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+		return
+	}
+
+	if targetScene.Visibility != scene.VisibilityHidden {
+		targetScene.Visibility = scene.VisibilityHidden
+		now := time.Now()
+		targetScene.UpdatedAt = &now
+
+		if err := h.sceneRepo.Update(r.Context(), targetScene); err != nil {
+			if err == scene.ErrVersionConflict {
+				writeError(w, r, ErrCodeConflict, "Scene has been modified since it was retrieved")
+				return
+			}
+			slog.ErrorContext(r.Context(), "failed to hide scene", "error", err, "scene_id", sceneID)
+			writeError(w, r, ErrCodeInternal, "Failed to hide scene")
+			return
+		}
+
+		if h.searchIndex != nil {
+			h.searchIndex.RemoveScene(sceneID)
+		}
+		h.logAdminAction(r, "scene", sceneID, "admin_hide_scene", reason)
+	}
+
+	hidden, err := h.sceneRepo.GetByID(r.Context(), sceneID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to retrieve hidden scene", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve hidden scene")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(hidden); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
+
+// RemoveEvent handles POST /admin/events/{id}/remove - soft-deletes an event
+// regardless of scene ownership. Already deleted events return 404.
+func (h *AdminHandlers) RemoveEvent(w http.ResponseWriter, r *http.Request) {
+	eventID, ok := parseEntityID(w, r, "/admin/events/", "Event")
+	if !ok {
+		return
+	}
+
+	reason, ok := decodeAdminReason(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.eventRepo.Delete(r.Context(), eventID); err != nil {
+		if err == scene.ErrEventNotFound {
+			writeError(w, r, ErrCodeNotFound, "Event not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to remove event", "error", err, "event_id", eventID)
+		writeError(w, r, ErrCodeInternal, "Failed to remove event")
+		return
+	}
+
+	if h.searchIndex != nil {
+		h.searchIndex.RemoveEvent(eventID)
+	}
+	h.logAdminAction(r, "event", eventID, "admin_remove_event", reason)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// BanUser handles POST /admin/users/{did}/ban - bans a user from every scene
+// they hold a membership in, including as a scene admin. Memberships that are
+// already banned are left unchanged.
+func (h *AdminHandlers) BanUser(w http.ResponseWriter, r *http.Request) {
+	rawDID, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/admin/users/"), "/")
+	targetDID, err := url.PathUnescape(rawDID)
+	if err != nil || targetDID == "" {
+		writeError(w, r, ErrCodeBadRequest, "User DID is required")
+		return
+	}
+
+	reason, ok := decodeAdminReason(w, r)
+	if !ok {
+		return
+	}
+
+	memberships, err := h.membershipRepo.ListByUser(r.Context(), targetDID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to list memberships", "error", err, "user_did", targetDID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve memberships")
+		return
+	}
+
+	banned := 0
+	for _, m := range memberships {
+		if m.Status == "banned" {
+			continue
+		}
+		if err := h.membershipRepo.UpdateStatus(r.Context(), m.ID, "banned", nil); err != nil {
+			slog.ErrorContext(r.Context(), "failed to ban member", "error", err, "membership_id", m.ID)
+			writeError(w, r, ErrCodeInternal, "Failed to ban user")
+			return
+		}
+		banned++
+	}
+
+	h.logAdminAction(r, "user", targetDID, "admin_ban_user", reason)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(AdminBanResponse{UserDID: targetDID, BannedCount: banned}); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
+
+// decodeAdminReason decodes an AdminActionRequest and validates its reason.
+// On failure the error response has already been written and the caller
+// should return.
+func decodeAdminReason(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var req AdminActionRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return "", false
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		writeError(w, r, ErrCodeValidation, "reason is required")
+		return "", false
+	}
+	if utf8.RuneCountInString(reason) > MaxAdminReasonLength {
+		writeErrorf(w, r, ErrCodeValidation, "reason must not exceed %d characters", MaxAdminReasonLength)
+		return "", false
+	}
+	return reason, true
+}
+
+// logAdminAction records an admin action tagged audit.TagAdmin. Audit
+// failures are logged but do not fail the request.
+func (h *AdminHandlers) logAdminAction(r *http.Request, entityType, entityID, action, reason string) {
+	if h.auditRepo == nil {
+		return
+	}
+	if err := audit.LogAdminActionFromRequest(r, h.auditRepo, entityType, entityID, action, reason); err != nil {
+		slog.WarnContext(r.Context(), "failed to log admin action audit", "error", err, "action", action, "entity_id", entityID)
+	}
+}
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/search"
+)
+
+const (
+	adminSceneID = "5a4b3c2d-1e0f-4a9b-8c7d-6e5f4a3b2c1d"
+	adminEventID = "9f8e7d6c-5b4a-4392-8187-f6e5d4c3b2a1"
+)
+
+// seedAdminScene seeds public scene adminSceneID owned by did:plc:owner with
+// event adminEventID, and returns a search index holding the scene.
+func seedAdminScene(t *testing.T, env *testEnv) *search.Indexer {
+	t.Helper()
+	index := search.NewIndexer()
+	index.IndexScene(env.addScene(t, &scene.Scene{ID: adminSceneID, Name: "Abusive Scene", Visibility: scene.VisibilityPublic}))
+	env.addEvent(t, &scene.Event{ID: adminEventID, SceneID: adminSceneID, Title: "Abusive Event", StartsAt: time.Now().Add(24 * time.Hour)})
+	return index
+}
+
+// doAdminRequest sends a POST through RequireAdmin with did:plc:admin as the
+// only admin.
+func doAdminRequest(handler http.HandlerFunc, path, userDID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	middleware.RequireAdmin([]string{"did:plc:admin"})(handler).ServeHTTP(w, req)
+	return w
+}
+
+// assertAdminAudit checks that exactly one admin-tagged entry with the given
+// action and reason was logged for the entity.
+func assertAdminAudit(t *testing.T, repo *audit.InMemoryRepository, entityType, entityID, action, reason string) {
+	t.Helper()
+	logs, err := repo.QueryByEntity(t.Context(), entityType, entityID, 0)
+	if err != nil {
+		t.Fatalf("failed to query audit logs: %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d", len(logs))
+	}
+	entry := logs[0]
+	if entry.Action != action || entry.Tag != audit.TagAdmin || entry.Reason != reason || entry.UserDID != "did:plc:admin" {
+		t.Errorf("unexpected audit entry: %+v", entry)
+	}
+}
+
+func TestForceHideScene_Admin(t *testing.T) {
+	env := newTestEnv()
+	index := seedAdminScene(t, env)
+	handlers := env.adminHandlers()
+	handlers.SetSearchIndex(index)
+
+	w := doAdminRequest(handlers.ForceHideScene, "/admin/scenes/"+adminSceneID+"/hide", "did:plc:admin", `{"reason":"harassment reports"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var hidden scene.Scene
+	if err := json.NewDecoder(w.Body).Decode(&hidden); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if hidden.Visibility != scene.VisibilityHidden {
+		t.Errorf("expected visibility %s, got %s", scene.VisibilityHidden, hidden.Visibility)
+	}
+	if results, _ := index.Search(context.Background(), "abusive", 10); len(results) != 0 {
+		t.Errorf("expected hidden scene to be removed from search, got %d results", len(results))
+	}
+	assertAdminAudit(t, env.audit, "scene", adminSceneID, "admin_hide_scene", "harassment reports")
+
+	// Hiding again is a no-op without another audit entry
+	w = doAdminRequest(handlers.ForceHideScene, "/admin/scenes/"+adminSceneID+"/hide", "did:plc:admin", `{"reason":"again"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 on repeat, got %d", w.Code)
+	}
+	assertAdminAudit(t, env.audit, "scene", adminSceneID, "admin_hide_scene", "harassment reports")
+}
+
+func TestForceHideScene_NonAdminRejected(t *testing.T) {
+	env := newTestEnv()
+	index := seedAdminScene(t, env)
+	handlers := env.adminHandlers()
+	handlers.SetSearchIndex(index)
+
+	// Even the scene owner can't use admin routes
+	for _, did := range []string{"did:plc:owner", "did:plc:stranger"} {
+		w := doAdminRequest(handlers.ForceHideScene, "/admin/scenes/"+adminSceneID+"/hide", did, `{"reason":"spam"}`)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s: expected status 403, got %d", did, w.Code)
+		}
+	}
+
+	stored, err := env.scenes.GetByID(t.Context(), adminSceneID)
+	if err != nil {
+		t.Fatalf("failed to get scene: %v", err)
+	}
+	if stored.Visibility != scene.VisibilityPublic {
+		t.Errorf("expected scene to stay public, got %s", stored.Visibility)
+	}
+	if logs, _ := env.audit.QueryByEntity(t.Context(), "scene", adminSceneID, 0); len(logs) != 0 {
+		t.Errorf("expected no audit entries, got %d", len(logs))
+	}
+}
+
+func TestForceHideScene_DeletedScene(t *testing.T) {
+	env := newTestEnv()
+	index := seedAdminScene(t, env)
+	handlers := env.adminHandlers()
+	handlers.SetSearchIndex(index)
+	if err := env.scenes.Delete(t.Context(), adminSceneID); err != nil {
+		t.Fatalf("failed to delete scene: %v", err)
+	}
+
+	w := doAdminRequest(handlers.ForceHideScene, "/admin/scenes/"+adminSceneID+"/hide", "did:plc:admin", `{"reason":"spam"}`)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for deleted scene, got %d", w.Code)
+	}
+}
+
+func TestForceHideScene_ReasonRequired(t *testing.T) {
+	env := newTestEnv()
+	index := seedAdminScene(t, env)
+	handlers := env.adminHandlers()
+	handlers.SetSearchIndex(index)
+
+	for _, body := range []string{`{}`, `{"reason":"   "}`, `{"reason":"` + strings.Repeat("x", MaxAdminReasonLength+1) + `"}`} {
+		w := doAdminRequest(handlers.ForceHideScene, "/admin/scenes/"+adminSceneID+"/hide", "did:plc:admin", body)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	}
+}
+
+func TestRemoveEvent_Admin(t *testing.T) {
+	env := newTestEnv()
+	index := seedAdminScene(t, env)
+	handlers := env.adminHandlers()
+	handlers.SetSearchIndex(index)
+
+	w := doAdminRequest(handlers.RemoveEvent, "/admin/events/"+adminEventID+"/remove", "did:plc:admin", `{"reason":"illegal content"}`)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, err := env.events.GetByID(t.Context(), adminEventID); err != scene.ErrEventNotFound {
+		t.Errorf("expected event to be soft-deleted, got %v", err)
+	}
+	assertAdminAudit(t, env.audit, "event", adminEventID, "admin_remove_event", "illegal content")
+
+	// Already removed events are not found
+	w = doAdminRequest(handlers.RemoveEvent, "/admin/events/"+adminEventID+"/remove", "did:plc:admin", `{"reason":"again"}`)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 on repeat, got %d", w.Code)
+	}
+}
+
+func TestBanUser_Admin(t *testing.T) {
+	env := newTestEnv()
+	index := seedAdminScene(t, env)
+	handlers := env.adminHandlers()
+	handlers.SetSearchIndex(index)
+
+	for _, m := range []*membership.Membership{
+		{SceneID: "scene-1", UserDID: "did:plc:abuser", Role: "admin", Status: "active"},
+		{SceneID: "scene-2", UserDID: "did:plc:abuser", Role: "member", Status: "pending"},
+		{SceneID: "scene-3", UserDID: "did:plc:abuser", Role: "member", Status: "banned"},
+		{SceneID: "scene-1", UserDID: "did:plc:bystander", Role: "member", Status: "active"},
+	} {
+		if _, err := env.memberships.Upsert(t.Context(), m); err != nil {
+			t.Fatalf("failed to insert membership: %v", err)
+		}
+	}
+
+	w := doAdminRequest(handlers.BanUser, "/admin/users/did:plc:abuser/ban", "did:plc:admin", `{"reason":"coordinated harassment"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp AdminBanResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.BannedCount != 2 {
+		t.Errorf("expected 2 memberships banned, got %d", resp.BannedCount)
+	}
+
+	memberships, _ := env.memberships.ListByUser(t.Context(), "did:plc:abuser")
+	for _, m := range memberships {
+		if m.Status != "banned" {
+			t.Errorf("expected membership in %s to be banned, got %s", m.SceneID, m.Status)
+		}
+	}
+	bystander, _ := env.memberships.GetBySceneAndUser(t.Context(), "scene-1", "did:plc:bystander")
+	if bystander.Status != "active" {
+		t.Errorf("expected other users unaffected, got %s", bystander.Status)
+	}
+	assertAdminAudit(t, env.audit, "user", "did:plc:abuser", "admin_ban_user", "coordinated harassment")
+}
@@ -0,0 +1,234 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// Audit log pagination limits.
+const (
+	DefaultAuditLogLimit = 20
+	MaxAuditLogLimit     = 100
+)
+
+// auditAccessDenied is the uniform message for every rejected audit log
+// request, so callers can't tell a missing scene from one they may not see.
+const auditAccessDenied = "Not permitted to view these audit logs"
+
+// AuditHandlers holds dependencies for audit log HTTP handlers.
+type AuditHandlers struct {
+	auditRepo      audit.Repository
+	sceneRepo      scene.SceneRepository
+	membershipRepo membership.MembershipRepository
+	adminDIDs      map[string]bool
+}
+
+// NewAuditHandlers creates a new AuditHandlers instance.
+func NewAuditHandlers(
+	auditRepo audit.Repository,
+	sceneRepo scene.SceneRepository,
+	membershipRepo membership.MembershipRepository,
+) *AuditHandlers {
+	return &AuditHandlers{
+		auditRepo:      auditRepo,
+		sceneRepo:      sceneRepo,
+		membershipRepo: membershipRepo,
+		adminDIDs:      make(map[string]bool),
+	}
+}
+
+// SetAdminDIDs sets the platform admins allowed to read any user's audit logs.
+func (h *AuditHandlers) SetAdminDIDs(dids []string) {
+	h.adminDIDs = make(map[string]bool, len(dids))
+	for _, did := range dids {
+		h.adminDIDs[did] = true
+	}
+}
+
+// AuditLogEntry is an audit log as returned by the API. IP addresses and
+// user agents are retained for incident response but never exposed.
+type AuditLogEntry struct {
+	ID         string    `json:"id"`
+	UserDID    string    `json:"user_did"`
+	EntityType string    `json:"entity_type"`
+	EntityID   string    `json:"entity_id"`
+	Action     string    `json:"action"`
+	RequestID  string    `json:"request_id,omitempty"`
+	Tag        string    `json:"tag,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ListAuditLogsResponse represents the response for the audit log endpoints.
+type ListAuditLogsResponse struct {
+	Logs       []AuditLogEntry `json:"logs"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+// ListEntityLogs handles GET /scenes/{id}/audit-logs - lists a scene's audit
+// logs newest first. Only the scene owner and scene admins may read them;
+// everyone else, including requests for missing scenes, gets 403.
+//
+// Query parameters: limit (default 20, max 100) and cursor (next_cursor from
+// the previous page).
+func (h *AuditHandlers) ListEntityLogs(w http.ResponseWriter, r *http.Request) {
+	sceneID, ok := parseEntityID(w, r, "/scenes/", "Scene")
+	if !ok {
+		return
+	}
+
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	limit, cursorTime, cursorID, ok := parseAuditPage(w, r)
+	if !ok {
+		return
+	}
+
+	foundScene, err := h.sceneRepo.GetByID(r.Context(), sceneID)
+	if err != nil {
+		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+			writeError(w, r, ErrCodeForbidden, auditAccessDenied)
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+		return
+	}
+
+	isModerator, err := isModeratorForScene(r.Context(), h.membershipRepo, foundScene, userDID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to check moderator access", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to check access permissions")
+		return
+	}
+	if !isModerator {
+		writeError(w, r, ErrCodeForbidden, auditAccessDenied)
+		return
+	}
+
+	h.writeAuditPage(w, r, audit.AuditFilter{
+		EntityType: "scene",
+		EntityID:   sceneID,
+		CursorTime: cursorTime,
+		CursorID:   cursorID,
+	}, limit)
+}
+
+// ListUserLogs handles GET /users/{did}/audit-logs - lists audit logs of a
+// user's actions newest first. Only that user and platform admins may read
+// them; everyone else gets 403.
+//
+// Query parameters: limit (default 20, max 100) and cursor (next_cursor from
+// the previous page).
+func (h *AuditHandlers) ListUserLogs(w http.ResponseWriter, r *http.Request) {
+	rawDID, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/users/"), "/")
+	targetDID, err := url.PathUnescape(rawDID)
+	if err != nil || targetDID == "" {
+		writeError(w, r, ErrCodeBadRequest, "User DID is required")
+		return
+	}
+
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	limit, cursorTime, cursorID, ok := parseAuditPage(w, r)
+	if !ok {
+		return
+	}
+
+	if userDID != targetDID && !h.adminDIDs[userDID] {
+		writeError(w, r, ErrCodeForbidden, auditAccessDenied)
+		return
+	}
+
+	h.writeAuditPage(w, r, audit.AuditFilter{
+		UserDID:    targetDID,
+		CursorTime: cursorTime,
+		CursorID:   cursorID,
+	}, limit)
+}
+
+// parseAuditPage parses the limit and cursor query parameters. On failure the
+// error response has already been written and the caller should return.
+func parseAuditPage(w http.ResponseWriter, r *http.Request) (int, time.Time, string, bool) {
+	query := r.URL.Query()
+	limit := DefaultAuditLogLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := parseIntInRange(limitStr, "limit", 1, MaxAuditLogLimit)
+		if err != nil {
+			writeError(w, r, ErrCodeValidation, err.Error())
+			return 0, time.Time{}, "", false
+		}
+		limit = parsed
+	}
+
+	// Audit cursors share the activity feed's "RFC3339Nano|ID" format
+	cursorTime, cursorID, err := parseActivityCursor(query.Get("cursor"))
+	if err != nil {
+		writeError(w, r, ErrCodeValidation, "invalid cursor")
+		return 0, time.Time{}, "", false
+	}
+	return limit, cursorTime, cursorID, true
+}
+
+// writeAuditPage writes the page of up to limit audit logs matching filter,
+// which carries the cursor position the page resumes from.
+func (h *AuditHandlers) writeAuditPage(w http.ResponseWriter, r *http.Request, filter audit.AuditFilter, limit int) {
+	// One extra entry tells whether another page follows
+	filter.Limit = limit + 1
+	logs, err := h.auditRepo.Query(r.Context(), filter)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to query audit logs", "error", err)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve audit logs")
+		return
+	}
+
+	var nextCursor string
+	if len(logs) > limit {
+		logs = logs[:limit]
+		last := logs[limit-1]
+		nextCursor = last.CreatedAt.Format(time.RFC3339Nano) + "|" + last.ID
+	}
+
+	entries := make([]AuditLogEntry, 0, len(logs))
+	for _, entry := range logs {
+		entries = append(entries, AuditLogEntry{
+			ID:         entry.ID,
+			UserDID:    entry.UserDID,
+			EntityType: entry.EntityType,
+			EntityID:   entry.EntityID,
+			Action:     entry.Action,
+			RequestID:  entry.RequestID,
+			Tag:        entry.Tag,
+			Reason:     entry.Reason,
+			CreatedAt:  entry.CreatedAt,
+		})
+	}
+
+	response := ListAuditLogsResponse{
+		Logs:       entries,
+		NextCursor: nextCursor,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
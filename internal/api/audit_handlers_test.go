@@ -0,0 +1,193 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+const auditSceneID = "3c1f9e2a-7b4d-4e8f-a0c1-2d3e4f5a6b7c"
+
+// seedAuditScene seeds a scene owned by did:plc:owner, with scene admin
+// did:plc:admin and regular member did:plc:member, and three scene audit
+// entries logged by did:plc:owner.
+func seedAuditScene(t *testing.T, env *testEnv) {
+	t.Helper()
+	env.addScene(t, &scene.Scene{ID: auditSceneID, Name: "Audit Scene", Visibility: scene.VisibilityPublic})
+	env.addMember(t, auditSceneID, "did:plc:admin", "admin", "active")
+	env.addMember(t, auditSceneID, "did:plc:member", "member", "active")
+	for _, action := range []string{"create", "update", "palette_update"} {
+		env.logAudit(t, audit.LogEntry{
+			UserDID:    "did:plc:owner",
+			EntityType: "scene",
+			EntityID:   auditSceneID,
+			Action:     action,
+			IPAddress:  "203.0.113.7",
+		})
+	}
+}
+
+func doAuditRequest(handler http.HandlerFunc, path, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handler(w, req)
+	return w
+}
+
+func TestListEntityLogs_OwnerPaginates(t *testing.T) {
+	env := newTestEnv()
+	seedAuditScene(t, env)
+	handlers := env.auditHandlers()
+
+	var actions []string
+	cursor := ""
+	for page := 0; page < 3; page++ {
+		path := "/scenes/" + auditSceneID + "/audit-logs?limit=2"
+		if cursor != "" {
+			path += "&cursor=" + cursor
+		}
+		w := doAuditRequest(handlers.ListEntityLogs, path, "did:plc:owner")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if body := w.Body.String(); strings.Contains(body, "203.0.113.7") || strings.Contains(body, "ip_address") {
+			t.Errorf("expected IP addresses to be omitted, got %s", body)
+		}
+
+		var resp ListAuditLogsResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		for _, entry := range resp.Logs {
+			actions = append(actions, entry.Action)
+		}
+		cursor = resp.NextCursor
+		if cursor == "" {
+			break
+		}
+	}
+
+	want := []string{"palette_update", "update", "create"}
+	if len(actions) != len(want) {
+		t.Fatalf("expected actions %v, got %v", want, actions)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Errorf("expected actions %v, got %v", want, actions)
+			break
+		}
+	}
+}
+
+func TestListEntityLogs_SceneAdmin(t *testing.T) {
+	env := newTestEnv()
+	seedAuditScene(t, env)
+	handlers := env.auditHandlers()
+
+	w := doAuditRequest(handlers.ListEntityLogs, "/scenes/"+auditSceneID+"/audit-logs", "did:plc:admin")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestListEntityLogs_Forbidden(t *testing.T) {
+	env := newTestEnv()
+	seedAuditScene(t, env)
+	handlers := env.auditHandlers()
+
+	tests := []struct {
+		name    string
+		sceneID string
+		userDID string
+	}{
+		{"regular member", auditSceneID, "did:plc:member"},
+		{"stranger", auditSceneID, "did:plc:stranger"},
+		{"missing scene", "00000000-0000-4000-8000-000000000000", "did:plc:owner"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doAuditRequest(handlers.ListEntityLogs, "/scenes/"+tt.sceneID+"/audit-logs", tt.userDID)
+			if w.Code != http.StatusForbidden {
+				t.Fatalf("expected status 403, got %d", w.Code)
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Message != auditAccessDenied {
+				t.Errorf("expected uniform message %q, got %q", auditAccessDenied, errResp.Error.Message)
+			}
+		})
+	}
+}
+
+func TestListEntityLogs_RequiresAuth(t *testing.T) {
+	env := newTestEnv()
+	seedAuditScene(t, env)
+	handlers := env.auditHandlers()
+
+	w := doAuditRequest(handlers.ListEntityLogs, "/scenes/"+auditSceneID+"/audit-logs", "")
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+}
+
+func TestListUserLogs_Access(t *testing.T) {
+	env := newTestEnv()
+	seedAuditScene(t, env)
+	handlers := env.auditHandlers()
+	handlers.SetAdminDIDs([]string{"did:plc:platform-admin"})
+
+	tests := []struct {
+		name       string
+		userDID    string
+		wantStatus int
+	}{
+		{"self", "did:plc:owner", http.StatusOK},
+		{"platform admin", "did:plc:platform-admin", http.StatusOK},
+		{"scene admin", "did:plc:admin", http.StatusForbidden},
+		{"other user", "did:plc:member", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doAuditRequest(handlers.ListUserLogs, "/users/did:plc:owner/audit-logs", tt.userDID)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp ListAuditLogsResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp.Logs) != 3 {
+				t.Errorf("expected 3 logs, got %d", len(resp.Logs))
+			}
+		})
+	}
+}
+
+func TestListAuditLogs_InvalidParams(t *testing.T) {
+	env := newTestEnv()
+	seedAuditScene(t, env)
+	handlers := env.auditHandlers()
+
+	for _, query := range []string{"?limit=0", "?limit=101", "?limit=abc", "?cursor=garbage"} {
+		w := doAuditRequest(handlers.ListEntityLogs, "/scenes/"+auditSceneID+"/audit-logs"+query, "did:plc:owner")
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", query, w.Code)
+		}
+	}
+}
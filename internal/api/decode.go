@@ -0,0 +1,120 @@
+// Package api provides HTTP handlers for the Subcults API.
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// decodeError describes why a request body could not be decoded.
+// Its message is safe to return to clients.
+type decodeError struct {
+	msg string
+}
+
+func (e *decodeError) Error() string {
+	return e.msg
+}
+
+// decodeJSON decodes the request body into dst. Unlike a bare json.Decoder it
+// rejects unknown fields (so typos like "visibilty" don't pass unnoticed) and
+// trailing data after the first JSON value.
+//
+// Errors are either *decodeError with a client-facing message, or
+// *http.MaxBytesError when the body exceeds middleware.MaxBodyBytes.
+// Pass them to writeDecodeError.
+func decodeJSON(r *http.Request, dst any) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		return describeDecodeError(err)
+	}
+
+	// Anything other than EOF after the first value is trailing data
+	if err := dec.Decode(&struct{}{}); err != io.EOF {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return err
+		}
+		return &decodeError{msg: "Request body must contain a single JSON object"}
+	}
+	return nil
+}
+
+// describeDecodeError converts a json.Decoder error into a *decodeError
+// naming the offending field or position where possible.
+func describeDecodeError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return err
+	case errors.Is(err, io.EOF):
+		return &decodeError{msg: "Request body must not be empty"}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return &decodeError{msg: "Request body contains malformed JSON"}
+	case errors.As(err, &syntaxErr):
+		return &decodeError{msg: fmt.Sprintf("Request body contains malformed JSON (at position %d)", syntaxErr.Offset)}
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return &decodeError{msg: fmt.Sprintf("Request body must be %s", jsonTypeName(typeErr.Type))}
+		}
+		return &decodeError{msg: fmt.Sprintf("Field %q must be %s", typeErr.Field, jsonTypeName(typeErr.Type))}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json has no typed error for unknown fields
+		field := strings.TrimPrefix(err.Error(), "json: unknown field ")
+		return &decodeError{msg: fmt.Sprintf("Unknown field %s", field)}
+	default:
+		return &decodeError{msg: "Invalid JSON in request body"}
+	}
+}
+
+// jsonTypeName returns the JSON type expected for a Go type, with its article.
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+// writeDecodeError writes the error response for a failed request body decode.
+// Bodies cut off by middleware.MaxBodyBytes get 413 with ErrCodePayloadTooLarge
+// so clients can tell them apart from malformed JSON. Errors from decodeJSON
+// get 400 with ErrCodeValidation and a message naming the problem; any other
+// decode error gets 400 with ErrCodeBadRequest.
+func writeDecodeError(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		writeError(w, r, ErrCodePayloadTooLarge, "Request body too large")
+		return
+	}
+
+	var decodeErr *decodeError
+	if errors.As(err, &decodeErr) {
+		writeError(w, r, ErrCodeValidation, decodeErr.msg)
+		return
+	}
+
+	writeError(w, r, ErrCodeBadRequest, "Invalid JSON in request body")
+}
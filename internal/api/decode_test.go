@@ -0,0 +1,271 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// decodeTestRequest is a small request type exercising nested and typed fields.
+type decodeTestRequest struct {
+	Name    string         `json:"name"`
+	Count   int            `json:"count"`
+	Tags    []string       `json:"tags"`
+	Palette *scene.Palette `json:"palette"`
+}
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantErr     bool
+		wantMessage string
+	}{
+		{
+			name: "valid body",
+			body: `{"name":"Test","count":2,"tags":["a"]}`,
+		},
+		{
+			name: "trailing whitespace is allowed",
+			body: "{\"name\":\"Test\"}\n  ",
+		},
+		{
+			name:        "unknown field",
+			body:        `{"name":"Test","visibilty":"public"}`,
+			wantErr:     true,
+			wantMessage: `Unknown field "visibilty"`,
+		},
+		{
+			name:        "unknown nested field",
+			body:        `{"palette":{"primary":"#ff0000","primery":"#00ff00"}}`,
+			wantErr:     true,
+			wantMessage: `Unknown field "primery"`,
+		},
+		{
+			name:        "trailing object",
+			body:        `{"name":"Test"}{"name":"Again"}`,
+			wantErr:     true,
+			wantMessage: "Request body must contain a single JSON object",
+		},
+		{
+			name:        "trailing garbage",
+			body:        `{"name":"Test"} garbage`,
+			wantErr:     true,
+			wantMessage: "Request body must contain a single JSON object",
+		},
+		{
+			name:        "string for number",
+			body:        `{"count":"two"}`,
+			wantErr:     true,
+			wantMessage: `Field "count" must be a number`,
+		},
+		{
+			name:        "number for string",
+			body:        `{"name":42}`,
+			wantErr:     true,
+			wantMessage: `Field "name" must be a string`,
+		},
+		{
+			name:        "object for array",
+			body:        `{"tags":{}}`,
+			wantErr:     true,
+			wantMessage: `Field "tags" must be an array`,
+		},
+		{
+			name:        "nested type mismatch",
+			body:        `{"palette":{"primary":1}}`,
+			wantErr:     true,
+			wantMessage: `Field "palette.primary" must be a string`,
+		},
+		{
+			name:        "array instead of object",
+			body:        `[]`,
+			wantErr:     true,
+			wantMessage: "Request body must be an object",
+		},
+		{
+			name:        "empty body",
+			body:        "",
+			wantErr:     true,
+			wantMessage: "Request body must not be empty",
+		},
+		{
+			name:        "truncated JSON",
+			body:        `{"name":`,
+			wantErr:     true,
+			wantMessage: "Request body contains malformed JSON",
+		},
+		{
+			name:        "syntax error",
+			body:        `{"name" "Test"}`,
+			wantErr:     true,
+			wantMessage: "Request body contains malformed JSON (at position 9)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			var dst decodeTestRequest
+			err := decodeJSON(req, &dst)
+
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("decodeJSON() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("decodeJSON() expected error, got nil")
+			}
+			if err.Error() != tt.wantMessage {
+				t.Errorf("decodeJSON() error = %q, want %q", err.Error(), tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestDecodeJSON_PreservesMaxBytesError(t *testing.T) {
+	body := `{"name":"` + strings.Repeat("a", 64) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Body = http.MaxBytesReader(httptest.NewRecorder(), req.Body, 16)
+
+	var dst decodeTestRequest
+	err := decodeJSON(req, &dst)
+
+	w := httptest.NewRecorder()
+	writeDecodeError(w, req, err)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status %d, got %d", http.StatusRequestEntityTooLarge, w.Code)
+	}
+}
+
+// TestCreateScene_RejectsUnknownField tests that a typo in a field name is reported
+// as a validation error naming the field instead of being silently ignored.
+func TestCreateScene_RejectsUnknownField(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
+
+	body := `{"name":"Test Scene","owner_did":"did:plc:test123","coarse_geohash":"dr5regw","visibilty":"private"}`
+	req := httptest.NewRequest(http.MethodPost, "/scenes", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handlers.CreateScene(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeValidation {
+		t.Errorf("expected error code %q, got %q", ErrCodeValidation, errResp.Error.Code)
+	}
+	if !strings.Contains(errResp.Error.Message, "visibilty") {
+		t.Errorf("expected message to name the unknown field, got %q", errResp.Error.Message)
+	}
+}
+
+// TestCreateOrUpdateRSVP_RejectsTrailingData tests that trailing data after the
+// JSON body is rejected.
+func TestCreateOrUpdateRSVP_RejectsTrailingData(t *testing.T) {
+	handlers := NewRSVPHandlers(scene.NewInMemoryRSVPRepository(), scene.NewInMemoryEventRepository(), scene.NewInMemorySceneRepository())
+
+	req := httptest.NewRequest(http.MethodPost, "/events/event-1/rsvp", strings.NewReader(`{"status":"going"} {"status":"maybe"}`))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:attendee"))
+	w := httptest.NewRecorder()
+
+	handlers.CreateOrUpdateRSVP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeValidation {
+		t.Errorf("expected error code %q, got %q", ErrCodeValidation, errResp.Error.Code)
+	}
+}
+
+// TestUpdateScenePalette_RejectsUnknownField tests that the palette endpoint
+// decodes strictly like the other scene handlers.
+func TestUpdateScenePalette_RejectsUnknownField(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+	if err := repo.Insert(t.Context(), &scene.Scene{ID: "scene-1", Name: "Test Scene", OwnerDID: "did:plc:test123", CoarseGeohash: "dr5regw"}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	body := `{"palette":{"primary":"#000000"},"pallete":{}}`
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/scene-1/palette", strings.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+
+	handlers.UpdateScenePalette(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if !strings.Contains(errResp.Error.Message, "pallete") {
+		t.Errorf("expected message to name the unknown field, got %q", errResp.Error.Message)
+	}
+}
+
+// TestUpdateEvent_RejectsUnknownField tests that event updates decode strictly,
+// so a field that cannot be patched is reported rather than silently dropped.
+func TestUpdateEvent_RejectsUnknownField(t *testing.T) {
+	env := newTestEnv()
+	testScene := env.addScene(t, &scene.Scene{OwnerDID: "did:plc:test123"})
+	event := env.addEvent(t, &scene.Event{SceneID: testScene.ID, Title: "Original Title", StartsAt: time.Now().Add(24 * time.Hour)})
+	handlers := env.eventHandlers()
+
+	body := `{"title":"Updated Title","capacity":50}`
+	req := httptest.NewRequest(http.MethodPatch, "/events/"+event.ID, strings.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+
+	handlers.UpdateEvent(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeValidation {
+		t.Errorf("expected error code %q, got %q", ErrCodeValidation, errResp.Error.Code)
+	}
+	if !strings.Contains(errResp.Error.Message, "capacity") {
+		t.Errorf("expected message to name the unknown field, got %q", errResp.Error.Message)
+	}
+
+	stored, err := env.events.GetByID(t.Context(), event.ID)
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	if stored.Title != "Original Title" {
+		t.Errorf("expected rejected update to leave title unchanged, got %q", stored.Title)
+	}
+}
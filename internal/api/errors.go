@@ -4,6 +4,8 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -50,12 +52,49 @@ const (
 	
 	// ErrCodeInvalidTimeRange indicates event start time is not before end time.
 	ErrCodeInvalidTimeRange = "invalid_time_range"
+
+	// ErrCodePayloadTooLarge indicates the request body exceeds the size limit.
+	ErrCodePayloadTooLarge = "payload_too_large"
+
+	// ErrCodePreconditionFailed indicates a conditional request header (e.g. If-Match) did not match.
+	ErrCodePreconditionFailed = "precondition_failed"
+
+	// ErrCodeMethodNotAllowed indicates the HTTP method is not supported for the route.
+	ErrCodeMethodNotAllowed = "method_not_allowed"
+
+	// ErrCodeTimeout indicates the request did not complete before its deadline.
+	ErrCodeTimeout = "timeout"
 )
 
+// errorStatuses maps each error code to its canonical HTTP status.
+// Every ErrCode* constant must be registered here; writeError derives the
+// status from this table so a code can't be sent with the wrong status.
+var errorStatuses = map[string]int{
+	ErrCodeValidation:         http.StatusBadRequest,
+	ErrCodeAuthFailed:         http.StatusUnauthorized,
+	ErrCodeNotFound:           http.StatusNotFound,
+	ErrCodeRateLimited:        http.StatusTooManyRequests,
+	ErrCodeInternal:           http.StatusInternalServerError,
+	ErrCodeForbidden:          http.StatusForbidden,
+	ErrCodeConflict:           http.StatusConflict,
+	ErrCodeBadRequest:         http.StatusBadRequest,
+	ErrCodeInvalidPalette:     http.StatusBadRequest,
+	ErrCodeSceneDeleted:       http.StatusNotFound,
+	ErrCodeInvalidSceneName:   http.StatusBadRequest,
+	ErrCodeDuplicateSceneName: http.StatusConflict,
+	ErrCodeInvalidTimeRange:   http.StatusBadRequest,
+	ErrCodePayloadTooLarge:    http.StatusRequestEntityTooLarge,
+	ErrCodePreconditionFailed: http.StatusPreconditionFailed,
+	ErrCodeMethodNotAllowed:   http.StatusMethodNotAllowed,
+	ErrCodeTimeout:            http.StatusServiceUnavailable,
+}
+
 // ErrorResponse represents the standard error response format.
-// All API errors return JSON in this structure: {"error": {"code": "...", "message": "..."}}
+// All API errors return JSON in this structure:
+// {"error": {"code": "...", "message": "..."}, "request_id": "..."}
 type ErrorResponse struct {
-	Error ErrorDetail `json:"error"`
+	Error     ErrorDetail `json:"error"`
+	RequestID string      `json:"request_id,omitempty"` // From the RequestID middleware, if present
 }
 
 // ErrorDetail contains the error code and human-readable message.
@@ -67,7 +106,7 @@ type ErrorDetail struct {
 // WriteError writes a standardized JSON error response.
 // It writes the appropriate HTTP status code and returns a JSON error body.
 //
-// Format: {"error": {"code": "error_code", "message": "Error description"}}
+// Format: {"error": {"code": "error_code", "message": "Error description"}, "request_id": "..."}
 //
 // The error_code will be automatically logged by the logging middleware
 // for all 4xx and 5xx responses if you call SetErrorCode on the context
@@ -94,6 +133,7 @@ func WriteError(w http.ResponseWriter, ctx context.Context, status int, code, me
 			Code:    code,
 			Message: message,
 		},
+		RequestID: middleware.GetRequestID(ctx),
 	}
 
 	// Marshal to JSON
@@ -115,27 +155,41 @@ func WriteError(w http.ResponseWriter, ctx context.Context, status int, code, me
 	}
 }
 
-// StatusCodeMapping returns the recommended HTTP status code for common error codes.
-// This is a convenience function to map error codes to HTTP status codes.
+// writeError writes a standardized JSON error response for a handler,
+// setting the error code on the request context for the logging middleware.
+// The HTTP status is the code's canonical status from errorStatuses.
+//
+// Example:
+//
+//	writeError(w, r, ErrCodeNotFound, "Scene not found")
+//	return
+func writeError(w http.ResponseWriter, r *http.Request, code, message string) {
+	ctx := middleware.SetErrorCode(r.Context(), code)
+	WriteError(w, ctx, StatusCodeMapping(code), code, message)
+}
+
+// writeContextError writes a timeout error and returns true if err comes from
+// the request context ending, either at its deadline or because the client
+// went away. These are not logged as failures: the Timeout middleware has
+// usually responded already, in which case this write is discarded.
+func writeContextError(w http.ResponseWriter, r *http.Request, err error) bool {
+	if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled) {
+		return false
+	}
+	writeError(w, r, ErrCodeTimeout, "Request timed out")
+	return true
+}
+
+// writeErrorf is like writeError but formats the message with fmt.Sprintf.
+func writeErrorf(w http.ResponseWriter, r *http.Request, code, format string, args ...any) {
+	writeError(w, r, code, fmt.Sprintf(format, args...))
+}
+
+// StatusCodeMapping returns the canonical HTTP status code for an error code.
+// Unregistered codes map to 500.
 func StatusCodeMapping(code string) int {
-	switch code {
-	case ErrCodeValidation:
-		return http.StatusBadRequest
-	case ErrCodeAuthFailed:
-		return http.StatusUnauthorized
-	case ErrCodeNotFound:
-		return http.StatusNotFound
-	case ErrCodeRateLimited:
-		return http.StatusTooManyRequests
-	case ErrCodeForbidden:
-		return http.StatusForbidden
-	case ErrCodeConflict:
-		return http.StatusConflict
-	case ErrCodeBadRequest:
-		return http.StatusBadRequest
-	case ErrCodeInternal:
-		return http.StatusInternalServerError
-	default:
-		return http.StatusInternalServerError
+	if status, ok := errorStatuses[code]; ok {
+		return status
 	}
+	return http.StatusInternalServerError
 }
@@ -234,7 +234,9 @@ func TestStatusCodeMapping(t *testing.T) {
 		{ErrCodeForbidden, http.StatusForbidden},
 		{ErrCodeConflict, http.StatusConflict},
 		{ErrCodeBadRequest, http.StatusBadRequest},
+		{ErrCodePayloadTooLarge, http.StatusRequestEntityTooLarge},
 		{ErrCodeInternal, http.StatusInternalServerError},
+		{ErrCodeTimeout, http.StatusServiceUnavailable},
 		{"unknown_code", http.StatusInternalServerError}, // default
 	}
 
@@ -380,3 +382,82 @@ func TestWriteError_SpecialCharactersInMessage(t *testing.T) {
 		t.Errorf("message not properly escaped: got %s", resp.Error.Message)
 	}
 }
+
+func TestErrorStatuses_AllCodesRegistered(t *testing.T) {
+	codes := []string{
+		ErrCodeValidation,
+		ErrCodeAuthFailed,
+		ErrCodeNotFound,
+		ErrCodeRateLimited,
+		ErrCodeInternal,
+		ErrCodeForbidden,
+		ErrCodeConflict,
+		ErrCodeBadRequest,
+		ErrCodeInvalidPalette,
+		ErrCodeSceneDeleted,
+		ErrCodeInvalidSceneName,
+		ErrCodeDuplicateSceneName,
+		ErrCodeInvalidTimeRange,
+		ErrCodePayloadTooLarge,
+		ErrCodePreconditionFailed,
+		ErrCodeMethodNotAllowed,
+		ErrCodeTimeout,
+	}
+
+	for _, code := range codes {
+		if _, ok := errorStatuses[code]; !ok {
+			t.Errorf("error code %s has no registered status", code)
+		}
+	}
+	if len(errorStatuses) != len(codes) {
+		t.Errorf("expected %d registered codes, got %d", len(codes), len(errorStatuses))
+	}
+
+	for code, status := range errorStatuses {
+		if status < 400 || status > 599 || http.StatusText(status) == "" {
+			t.Errorf("error code %s maps to invalid status %d", code, status)
+		}
+		// Only internal errors and timeouts are server-side
+		if status >= 500 && code != ErrCodeInternal && code != ErrCodeTimeout {
+			t.Errorf("error code %s unexpectedly maps to server error %d", code, status)
+		}
+	}
+}
+
+func TestWriteErrorHelper_CanonicalStatusAndRequestID(t *testing.T) {
+	handler := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeErrorf(w, r, ErrCodePreconditionFailed, "Scene %d was modified", 7)
+	}))
+
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/x", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-abc")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusPreconditionFailed {
+		t.Errorf("expected status 412, got %d", w.Code)
+	}
+
+	var resp ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response body: %v", err)
+	}
+	if resp.Error.Code != ErrCodePreconditionFailed {
+		t.Errorf("expected code %s, got %s", ErrCodePreconditionFailed, resp.Error.Code)
+	}
+	if resp.Error.Message != "Scene 7 was modified" {
+		t.Errorf("expected formatted message, got %q", resp.Error.Message)
+	}
+	if resp.RequestID != "req-abc" {
+		t.Errorf("expected request_id req-abc to be echoed, got %q", resp.RequestID)
+	}
+}
+
+func TestWriteError_OmitsRequestIDWhenAbsent(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteError(w, context.Background(), http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+
+	if strings.Contains(w.Body.String(), "request_id") {
+		t.Errorf("expected no request_id without RequestID middleware, got %s", w.Body.String())
+	}
+}
@@ -4,6 +4,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html"
 	"io"
@@ -14,10 +15,15 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/alliance"
 	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/geo"
+	"github.com/onnwee/subcults/internal/membership"
 	"github.com/onnwee/subcults/internal/middleware"
 	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/search"
 	"github.com/onnwee/subcults/internal/stream"
+	"github.com/onnwee/subcults/internal/webhook"
 )
 
 // Event title validation constraints
@@ -26,6 +32,16 @@ const (
 	MaxEventTitleLength = 80
 )
 
+// Event schedule limits
+const (
+	// DefaultMaxEventDuration is the longest an event may run unless changed
+	// with SetMaxEventDuration.
+	DefaultMaxEventDuration = 7 * 24 * time.Hour
+
+	// MaxEventStartAhead is how far in the future an event may start.
+	MaxEventStartAhead = 2 * 365 * 24 * time.Hour
+)
+
 // CreateEventRequest represents the request body for creating an event.
 type CreateEventRequest struct {
 	SceneID       string         `json:"scene_id"`
@@ -37,6 +53,24 @@ type CreateEventRequest struct {
 	Tags          []string       `json:"tags,omitempty"`
 	StartsAt      time.Time      `json:"starts_at"`
 	EndsAt        *time.Time     `json:"ends_at,omitempty"`
+	Timezone      string         `json:"timezone,omitempty"` // IANA zone name; empty means UTC
+	Capacity      int            `json:"capacity,omitempty"` // Max going RSVPs; 0 means unlimited
+
+	// Recurrence optionally expands the request into a series of events.
+	// StartsAt and EndsAt describe the first occurrence.
+	Recurrence *scene.Recurrence `json:"recurrence,omitempty"`
+
+	// RecordDID and RecordRKey optionally link the event to its AT Protocol
+	// record. Both must be given together, and a record backs a single
+	// event, so they cannot be combined with Recurrence.
+	RecordDID  *string `json:"record_did,omitempty"`
+	RecordRKey *string `json:"record_rkey,omitempty"`
+}
+
+// EventSeriesResponse represents a recurring event series and its occurrences.
+type EventSeriesResponse struct {
+	SeriesID string         `json:"series_id"`
+	Events   []*scene.Event `json:"events"`
 }
 
 // UpdateEventRequest represents the request body for updating an event.
@@ -50,6 +84,7 @@ type UpdateEventRequest struct {
 	CoarseGeohash *string        `json:"coarse_geohash,omitempty"`
 	StartsAt      *time.Time     `json:"starts_at,omitempty"`
 	EndsAt        *time.Time     `json:"ends_at,omitempty"`
+	Timezone      *string        `json:"timezone,omitempty"` // Empty string resets to UTC
 }
 
 // CancelEventRequest represents the request body for cancelling an event.
@@ -64,6 +99,31 @@ type EventHandlers struct {
 	auditRepo  audit.Repository
 	rsvpRepo   scene.RSVPRepository
 	streamRepo stream.SessionRepository
+
+	// membershipRepo resolves member access to members-only scenes when
+	// listing events. Optional; nil restricts those scenes to their owner.
+	membershipRepo membership.MembershipRepository
+
+	// allianceRepo extends members-only event visibility to members of
+	// allied scenes. Optional; nil disables cross-scene access.
+	allianceRepo alliance.AllianceRepository
+
+	// searchIndex is kept in sync with event writes. Optional; nil disables indexing.
+	searchIndex *search.Indexer
+
+	// webhooks notifies integrators of event changes. Optional; nil disables webhooks.
+	webhooks *webhook.Dispatcher
+
+	// templateRepo stores owner-managed event templates. Optional; nil
+	// disables the template endpoints.
+	templateRepo scene.TemplateRepository
+
+	// jitterRadiusMeters is the privacy jitter applied to the precise point
+	// before deriving the coarse geohash on create. Zero disables jitter.
+	jitterRadiusMeters float64
+
+	// maxEventDuration caps ends_at - starts_at; zero disables the check
+	maxEventDuration time.Duration
 }
 
 // NewEventHandlers creates a new EventHandlers instance.
@@ -74,13 +134,156 @@ func NewEventHandlers(eventRepo scene.EventRepository, sceneRepo scene.SceneRepo
 		auditRepo:  auditRepo,
 		rsvpRepo:   rsvpRepo,
 		streamRepo: streamRepo,
+
+		maxEventDuration: DefaultMaxEventDuration,
+	}
+}
+
+// SetMaxEventDuration sets the longest an event may run when it has an end
+// time. Longer events are rejected with invalid_time_range. Zero disables
+// the check.
+func (h *EventHandlers) SetMaxEventDuration(d time.Duration) {
+	h.maxEventDuration = d
+}
+
+// SetLocationJitter enables privacy jitter on event creation.
+// See SceneHandlers.SetLocationJitter for details.
+func (h *EventHandlers) SetLocationJitter(radiusMeters float64) {
+	h.jitterRadiusMeters = radiusMeters
+}
+
+// SetMembershipRepository enables member access checks for members-only scenes.
+func (h *EventHandlers) SetMembershipRepository(membershipRepo membership.MembershipRepository) {
+	h.membershipRepo = membershipRepo
+}
+
+// SetAllianceRepository lets members of allied scenes see each other's
+// members-only events. Requires a membership repository to take effect.
+func (h *EventHandlers) SetAllianceRepository(allianceRepo alliance.AllianceRepository) {
+	h.allianceRepo = allianceRepo
+}
+
+// SetSearchIndex keeps the given full-text index updated as events are
+// created and updated.
+func (h *EventHandlers) SetSearchIndex(index *search.Indexer) {
+	h.searchIndex = index
+}
+
+// SetWebhookDispatcher sends event.created and event.cancelled webhooks
+// through the given dispatcher.
+func (h *EventHandlers) SetWebhookDispatcher(dispatcher *webhook.Dispatcher) {
+	h.webhooks = dispatcher
+}
+
+// SetTemplateRepository enables event templates and creating events from them.
+func (h *EventHandlers) SetTemplateRepository(templateRepo scene.TemplateRepository) {
+	h.templateRepo = templateRepo
+}
+
+// notifyEventWebhooks dispatches eventType for each of a scene's events.
+// Precise points are never sent. Failures are logged and don't fail the request.
+func (h *EventHandlers) notifyEventWebhooks(r *http.Request, eventType, sceneID string, events ...*scene.Event) {
+	if h.webhooks == nil || len(events) == 0 {
+		return
+	}
+	s, err := h.sceneRepo.GetByID(r.Context(), sceneID)
+	if err != nil {
+		slog.WarnContext(r.Context(), "failed to load scene for webhook", "error", err, "scene_id", sceneID)
+		return
+	}
+	for _, event := range events {
+		payload := *event
+		payload.PrecisePoint = nil
+		evt := webhook.Event{
+			Type:    eventType,
+			SceneID: sceneID,
+			Public:  s.Visibility == scene.VisibilityPublic,
+			Data:    &payload,
+		}
+		if err := h.webhooks.Dispatch(evt); err != nil {
+			slog.WarnContext(r.Context(), "failed to dispatch webhook", "error", err, "event_id", event.ID, "type", eventType)
+		}
+	}
+}
+
+// sceneEventAccess reports whether the requester may see a scene's events.
+// viaAlliance is true when access comes only from membership in an allied
+// scene; callers must then withhold precise points, since the requester has
+// no relationship with the scene itself.
+func (h *EventHandlers) sceneEventAccess(ctx context.Context, s *scene.Scene, requesterDID string) (visible, viaAlliance bool, err error) {
+	viewerLevel, err := viewerLevelForScene(ctx, h.membershipRepo, s, requesterDID)
+	if err != nil {
+		return false, false, err
+	}
+	if canViewScene(ctx, s, viewerLevel) {
+		return true, false, nil
+	}
+
+	// Only members-only scenes are shared with allies; hidden scenes stay hidden
+	if s.Visibility != scene.VisibilityMembersOnly || h.allianceRepo == nil || h.membershipRepo == nil || requesterDID == "" {
+		return false, false, nil
+	}
+
+	allied, err := h.isAlliedMember(ctx, s.ID, requesterDID)
+	if err != nil {
+		return false, false, err
+	}
+	return allied, allied, nil
+}
+
+// isAlliedMember reports whether userDID owns or actively belongs to a scene
+// with an active alliance to sceneID. Users banned from sceneID never qualify.
+func (h *EventHandlers) isAlliedMember(ctx context.Context, sceneID, userDID string) (bool, error) {
+	own, err := h.membershipRepo.GetBySceneAndUser(ctx, sceneID, userDID)
+	if err != nil && err != membership.ErrMembershipNotFound {
+		return false, err
+	}
+	if err == nil && own.Status == "banned" {
+		return false, nil
+	}
+
+	alliances, err := h.allianceRepo.ListByScene(sceneID)
+	if err != nil {
+		return false, err
+	}
+	for _, a := range alliances {
+		alliedSceneID := a.ToSceneID
+		if alliedSceneID == sceneID {
+			alliedSceneID = a.FromSceneID
+		}
+		allied, err := h.allianceRepo.AreAllied(sceneID, alliedSceneID)
+		if err != nil {
+			return false, err
+		}
+		if !allied {
+			continue
+		}
+
+		m, err := h.membershipRepo.GetBySceneAndUser(ctx, alliedSceneID, userDID)
+		if err == nil && m.Status == "active" {
+			return true, nil
+		}
+		if err != nil && err != membership.ErrMembershipNotFound {
+			return false, err
+		}
+
+		alliedScene, err := h.sceneRepo.GetByID(ctx, alliedSceneID)
+		if err == nil && alliedScene.IsOwner(userDID) {
+			return true, nil
+		}
+		if err != nil && err != scene.ErrSceneNotFound && err != scene.ErrSceneDeleted {
+			return false, err
+		}
 	}
+	return false, nil
 }
 
 // EventWithRSVPCounts represents an event with aggregated RSVP counts and active stream info.
+// RSVPCounts is omitted when no RSVP repository is configured or the
+// requester may not see attendance for the event's scene.
 type EventWithRSVPCounts struct {
 	*scene.Event
-	RSVPCounts   *scene.RSVPCounts       `json:"rsvp_counts"`
+	RSVPCounts   *scene.RSVPCounts       `json:"rsvp_counts,omitempty"`
 	ActiveStream *stream.ActiveStreamInfo `json:"active_stream,omitempty"`
 }
 
@@ -114,9 +317,57 @@ func validateTimeWindow(startsAt time.Time, endsAt *time.Time) string {
 	return ""
 }
 
+// validateEventTimezone validates an IANA time zone name and returns its
+// location. Returns error message if validation fails, empty string if valid.
+func validateEventTimezone(name string) (*time.Location, string) {
+	loc, err := scene.LoadTimezone(name)
+	if err != nil {
+		return nil, "timezone must be an IANA time zone name such as America/New_York"
+	}
+	return loc, ""
+}
+
+// eventHasStarted reports whether an event has started at now. Instants are
+// compared rather than wall-clock readings, so an hour that a DST transition
+// in the event's time zone repeats or skips cannot flip the result.
+func eventHasStarted(startsAt, now time.Time) bool {
+	return !startsAt.After(now)
+}
+
+// validateEventSchedule checks the time window, then that the event starts
+// no more than MaxEventStartAhead from now and, when it has an end time,
+// lasts no longer than the configured maximum duration.
+// Returns error message if validation fails, empty string if valid.
+func (h *EventHandlers) validateEventSchedule(startsAt time.Time, endsAt *time.Time) string {
+	if errMsg := validateTimeWindow(startsAt, endsAt); errMsg != "" {
+		return errMsg
+	}
+	if startsAt.After(time.Now().Add(MaxEventStartAhead)) {
+		return "start time cannot be more than 2 years in the future"
+	}
+	if endsAt != nil && h.maxEventDuration > 0 && endsAt.Sub(startsAt) > h.maxEventDuration {
+		return "event duration cannot exceed " + formatEventDuration(h.maxEventDuration)
+	}
+	return ""
+}
+
+// formatEventDuration formats d in whole days when possible ("7 days"),
+// otherwise as a Go duration string.
+func formatEventDuration(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d == day:
+		return "1 day"
+	case d%day == 0:
+		return strconv.Itoa(int(d/day)) + " days"
+	default:
+		return d.String()
+	}
+}
+
 // isSceneOwner checks if the given userDID owns the scene.
 func (h *EventHandlers) isSceneOwner(ctx context.Context, sceneID, userDID string) (bool, error) {
-	foundScene, err := h.sceneRepo.GetByID(sceneID)
+	foundScene, err := h.sceneRepo.GetByID(ctx, sceneID)
 	if err != nil {
 		return false, err
 	}
@@ -126,16 +377,19 @@ func (h *EventHandlers) isSceneOwner(ctx context.Context, sceneID, userDID strin
 // CreateEvent handles POST /events - creates a new event.
 func (h *EventHandlers) CreateEvent(w http.ResponseWriter, r *http.Request) {
 	var req CreateEventRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+	if err := decodeJSON(r, &req); err != nil {
+		writeDecodeError(w, r, err)
 		return
 	}
+	h.createEvent(w, r, &req)
+}
 
+// createEvent validates, authorizes, and stores the event or series described
+// by req, then writes the created event or series.
+func (h *EventHandlers) createEvent(w http.ResponseWriter, r *http.Request, req *CreateEventRequest) {
 	// Validate title
 	if errMsg := validateEventTitle(req.Title); errMsg != "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, errMsg)
+		writeError(w, r, ErrCodeValidation, errMsg)
 		return
 	}
 
@@ -144,30 +398,83 @@ func (h *EventHandlers) CreateEvent(w http.ResponseWriter, r *http.Request) {
 
 	// Validate scene_id
 	if strings.TrimSpace(req.SceneID) == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "scene_id is required")
+		writeError(w, r, ErrCodeValidation, "scene_id is required")
+		return
+	}
+
+	// Derive coarse_geohash from the precise point, or validate the
+	// client-supplied one and truncate it to the default precision
+	coarseGeohash, errMsg := resolveCoarseGeohash(req.CoarseGeohash, req.PrecisePoint)
+	if errMsg != "" {
+		writeError(w, r, ErrCodeValidation, errMsg)
+		return
+	}
+	req.CoarseGeohash = coarseGeohash
+
+	// Resolve the time zone and express the times in it, so recurrence
+	// steps keep the local wall-clock time across DST transitions
+	loc, errMsg := validateEventTimezone(req.Timezone)
+	if errMsg != "" {
+		writeError(w, r, ErrCodeValidation, errMsg)
+		return
+	}
+	req.StartsAt = req.StartsAt.In(loc)
+	if req.EndsAt != nil {
+		endsAt := req.EndsAt.In(loc)
+		req.EndsAt = &endsAt
+	}
+
+	// Validate time window, duration, and how far ahead the event starts
+	if errMsg := h.validateEventSchedule(req.StartsAt, req.EndsAt); errMsg != "" {
+		writeError(w, r, ErrCodeInvalidTimeRange, errMsg)
 		return
 	}
 
-	// Validate coarse_geohash
-	if strings.TrimSpace(req.CoarseGeohash) == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "coarse_geohash is required")
+	// Validate capacity
+	if req.Capacity < 0 {
+		writeError(w, r, ErrCodeValidation, "capacity cannot be negative")
 		return
 	}
 
-	// Validate time window
-	if errMsg := validateTimeWindow(req.StartsAt, req.EndsAt); errMsg != "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInvalidTimeRange)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeInvalidTimeRange, errMsg)
+	// Validate the optional AT Protocol record reference
+	if errMsg := validateRecordRef(req.RecordDID, req.RecordRKey); errMsg != "" {
+		writeError(w, r, ErrCodeValidation, errMsg)
 		return
 	}
+	if req.RecordDID != nil && req.Recurrence != nil {
+		writeError(w, r, ErrCodeValidation, "record_did and record_rkey cannot be combined with recurrence")
+		return
+	}
+
+	// Expand recurrence into individual occurrences
+	var occurrences []scene.Occurrence
+	if req.Recurrence != nil {
+		expanded, err := req.Recurrence.Expand(req.StartsAt, req.EndsAt)
+		if err != nil {
+			writeError(w, r, ErrCodeValidation, err.Error())
+			return
+		}
+		now := time.Now()
+		latestStart := now.Add(MaxEventStartAhead)
+		for _, occ := range expanded {
+			if eventHasStarted(occ.StartsAt, now) {
+				writeError(w, r, ErrCodeValidation, "recurring event occurrences must start in the future")
+				return
+			}
+			// The first occurrence passed validateEventSchedule; later ones
+			// can still run past the start-ahead limit
+			if occ.StartsAt.After(latestStart) {
+				writeError(w, r, ErrCodeInvalidTimeRange, "recurring event occurrences cannot start more than 2 years in the future")
+				return
+			}
+		}
+		occurrences = expanded
+	}
 
 	// Get user DID from context (set by auth middleware)
 	userDID := middleware.GetUserDID(r.Context())
 	if userDID == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
-		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
 		return
 	}
 
@@ -175,23 +482,25 @@ func (h *EventHandlers) CreateEvent(w http.ResponseWriter, r *http.Request) {
 	isOwner, err := h.isSceneOwner(r.Context(), req.SceneID, userDID)
 	if err != nil {
 		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to check scene ownership", "error", err, "scene_id", req.SceneID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to verify scene ownership")
+		writeError(w, r, ErrCodeInternal, "Failed to verify scene ownership")
 		return
 	}
 	if !isOwner {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
-		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You do not have permission to create events for this scene")
+		writeError(w, r, ErrCodeForbidden, "You do not have permission to create events for this scene")
 		return
 	}
 
 	// Sanitize description to prevent HTML injection
-	req.Description = html.EscapeString(req.Description)
+	description, err := sanitizeText(req.Description)
+	if err != nil {
+		writeError(w, r, ErrCodeValidation, "description "+err.Error())
+		return
+	}
+	req.Description = description
 
 	// Sanitize tags to prevent HTML injection
 	sanitizedTags := make([]string, len(req.Tags))
@@ -199,10 +508,36 @@ func (h *EventHandlers) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		sanitizedTags[i] = html.EscapeString(tag)
 	}
 
+	if occurrences != nil {
+		h.createEventSeries(w, r, req, sanitizedTags, occurrences)
+		return
+	}
+
+	// A record can back at most one event
+	if req.RecordDID != nil {
+		_, err = h.eventRepo.GetByRecordKey(r.Context(), *req.RecordDID, *req.RecordRKey)
+		if err == nil {
+			writeError(w, r, ErrCodeConflict, "An event is already linked to this record")
+			return
+		}
+		if err != scene.ErrEventNotFound {
+			slog.ErrorContext(r.Context(), "failed to check event record key", "error", err, "record_did", *req.RecordDID)
+			writeError(w, r, ErrCodeInternal, "Failed to check for an existing event record")
+			return
+		}
+	}
+
 	// Create event
 	now := time.Now()
+	eventID := uuid.New().String()
+
+	// Optionally derive the coarse geohash from a jittered precise point
+	if h.jitterRadiusMeters > 0 && req.PrecisePoint != nil {
+		req.CoarseGeohash = jitteredCoarseGeohash(eventID, *req.PrecisePoint, h.jitterRadiusMeters, len(req.CoarseGeohash))
+	}
+
 	newEvent := &scene.Event{
-		ID:            uuid.New().String(),
+		ID:            eventID,
 		SceneID:       req.SceneID,
 		Title:         req.Title,
 		Description:   req.Description,
@@ -213,28 +548,35 @@ func (h *EventHandlers) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		Status:        "scheduled", // Default status
 		StartsAt:      req.StartsAt,
 		EndsAt:        req.EndsAt,
+		Timezone:      req.Timezone,
+		Capacity:      req.Capacity,
+		RecordDID:     req.RecordDID,
+		RecordRKey:    req.RecordRKey,
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
 
 	// Insert into repository (will automatically enforce location consent).
 	// If AllowPrecise is false, PrecisePoint will be cleared before storage.
-	if err := h.eventRepo.Insert(newEvent); err != nil {
+	if err := h.eventRepo.Insert(r.Context(), newEvent); err != nil {
 		slog.ErrorContext(r.Context(), "failed to insert event", "error", err, "event_id", newEvent.ID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to create event")
+		writeError(w, r, ErrCodeInternal, "Failed to create event")
 		return
 	}
 
 	// Retrieve the stored event to get privacy-enforced version
-	stored, err := h.eventRepo.GetByID(newEvent.ID)
+	stored, err := h.eventRepo.GetByID(r.Context(), newEvent.ID)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to retrieve created event", "error", err, "event_id", newEvent.ID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve created event")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve created event")
 		return
 	}
 
+	if h.searchIndex != nil {
+		h.searchIndex.IndexEvent(stored)
+	}
+	h.notifyEventWebhooks(r, webhook.EventCreated, stored.SceneID, stored)
+
 	// Return created event
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -244,43 +586,97 @@ func (h *EventHandlers) CreateEvent(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// createEventSeries inserts one event per occurrence under a shared series ID
+// and writes the created series. The request must already be validated and authorized.
+func (h *EventHandlers) createEventSeries(w http.ResponseWriter, r *http.Request, req *CreateEventRequest, tags []string, occurrences []scene.Occurrence) {
+	now := time.Now()
+	seriesID := uuid.New().String()
+
+	// Jitter once per series so repeated occurrences can't be averaged
+	// to recover the precise point
+	coarseGeohash := req.CoarseGeohash
+	if h.jitterRadiusMeters > 0 && req.PrecisePoint != nil {
+		coarseGeohash = jitteredCoarseGeohash(seriesID, *req.PrecisePoint, h.jitterRadiusMeters, len(req.CoarseGeohash))
+	}
+
+	for _, occ := range occurrences {
+		newEvent := &scene.Event{
+			ID:            uuid.New().String(),
+			SceneID:       req.SceneID,
+			Title:         req.Title,
+			Description:   req.Description,
+			AllowPrecise:  req.AllowPrecise,
+			PrecisePoint:  req.PrecisePoint,
+			CoarseGeohash: coarseGeohash,
+			Tags:          tags,
+			Status:        "scheduled",
+			StartsAt:      occ.StartsAt,
+			EndsAt:        occ.EndsAt,
+			Timezone:      req.Timezone,
+			Capacity:      req.Capacity,
+			SeriesID:      &seriesID,
+			CreatedAt:     &now,
+			UpdatedAt:     &now,
+		}
+		if err := h.eventRepo.Insert(r.Context(), newEvent); err != nil {
+			slog.ErrorContext(r.Context(), "failed to insert event occurrence", "error", err, "event_id", newEvent.ID, "series_id", seriesID)
+			writeError(w, r, ErrCodeInternal, "Failed to create event series")
+			return
+		}
+	}
+
+	// Retrieve the stored events to get privacy-enforced versions
+	stored, err := h.eventRepo.ListBySeries(r.Context(), seriesID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to retrieve created series", "error", err, "series_id", seriesID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve created event series")
+		return
+	}
+
+	if h.searchIndex != nil {
+		for _, event := range stored {
+			h.searchIndex.IndexEvent(event)
+		}
+	}
+	h.notifyEventWebhooks(r, webhook.EventCreated, req.SceneID, stored...)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(EventSeriesResponse{SeriesID: seriesID, Events: stored}); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode event series response", "error", err)
+	}
+}
+
 // UpdateEvent handles PATCH /events/{id} - updates an existing event.
 func (h *EventHandlers) UpdateEvent(w http.ResponseWriter, r *http.Request) {
-	// Extract event ID from URL path
-	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/"), "/")
-	if len(pathParts) == 0 || pathParts[0] == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Event ID is required")
+	// Extract and validate event ID from URL path
+	eventID, ok := parseEntityID(w, r, "/events/", "Event")
+	if !ok {
 		return
 	}
-	eventID := pathParts[0]
 
 	var req UpdateEventRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+	if err := decodeJSON(r, &req); err != nil {
+		writeDecodeError(w, r, err)
 		return
 	}
 
 	// Get existing event
-	existingEvent, err := h.eventRepo.GetByID(eventID)
+	existingEvent, err := h.eventRepo.GetByID(r.Context(), eventID)
 	if err != nil {
 		if err == scene.ErrEventNotFound {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Event not found")
+			writeError(w, r, ErrCodeNotFound, "Event not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to get event", "error", err, "event_id", eventID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve event")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve event")
 		return
 	}
 
 	// Get user DID from context (set by auth middleware)
 	userDID := middleware.GetUserDID(r.Context())
 	if userDID == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
-		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
 		return
 	}
 
@@ -288,18 +684,15 @@ func (h *EventHandlers) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 	isOwner, err := h.isSceneOwner(r.Context(), existingEvent.SceneID, userDID)
 	if err != nil {
 		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to check scene ownership", "error", err, "scene_id", existingEvent.SceneID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to verify scene ownership")
+		writeError(w, r, ErrCodeInternal, "Failed to verify scene ownership")
 		return
 	}
 	if !isOwner {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
-		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You do not have permission to update this event")
+		writeError(w, r, ErrCodeForbidden, "You do not have permission to update this event")
 		return
 	}
 
@@ -309,15 +702,19 @@ func (h *EventHandlers) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 	if req.Title != nil {
 		// Validate title
 		if errMsg := validateEventTitle(*req.Title); errMsg != "" {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, errMsg)
+			writeError(w, r, ErrCodeValidation, errMsg)
 			return
 		}
 		updatedEvent.Title = sanitizeEventTitle(*req.Title)
 	}
 
 	if req.Description != nil {
-		updatedEvent.Description = html.EscapeString(*req.Description)
+		description, err := sanitizeText(*req.Description)
+		if err != nil {
+			writeError(w, r, ErrCodeValidation, "description "+err.Error())
+			return
+		}
+		updatedEvent.Description = description
 	}
 
 	if req.Tags != nil {
@@ -333,27 +730,47 @@ func (h *EventHandlers) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if req.PrecisePoint != nil {
+		// As on create, the public cell follows the new point and any
+		// client-supplied coarse_geohash is ignored
+		coarseGeohash, errMsg := resolveCoarseGeohash("", req.PrecisePoint)
+		if errMsg != "" {
+			writeError(w, r, ErrCodeValidation, errMsg)
+			return
+		}
+		if h.jitterRadiusMeters > 0 {
+			coarseGeohash = jitteredCoarseGeohash(updatedEvent.ID, *req.PrecisePoint, h.jitterRadiusMeters, len(coarseGeohash))
+		}
 		updatedEvent.PrecisePoint = req.PrecisePoint
-	}
-
-	if req.CoarseGeohash != nil {
+		updatedEvent.CoarseGeohash = coarseGeohash
+	} else if req.CoarseGeohash != nil {
 		if strings.TrimSpace(*req.CoarseGeohash) == "" {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "coarse_geohash cannot be empty")
+			writeError(w, r, ErrCodeValidation, "coarse_geohash cannot be empty")
 			return
 		}
-		updatedEvent.CoarseGeohash = *req.CoarseGeohash
+		coarseGeohash, errMsg := normalizeCoarseGeohash(*req.CoarseGeohash)
+		if errMsg != "" {
+			writeError(w, r, ErrCodeValidation, errMsg)
+			return
+		}
+		updatedEvent.CoarseGeohash = coarseGeohash
 	}
 
 	// Handle time updates with validation
 	startsAt := updatedEvent.StartsAt
 	endsAt := updatedEvent.EndsAt
 
+	if req.Timezone != nil {
+		if _, errMsg := validateEventTimezone(*req.Timezone); errMsg != "" {
+			writeError(w, r, ErrCodeValidation, errMsg)
+			return
+		}
+		updatedEvent.Timezone = *req.Timezone
+	}
+
 	if req.StartsAt != nil {
 		// Only allow updates if event is still in the future
-		if existingEvent.StartsAt.Before(time.Now()) {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Cannot update start time for past events")
+		if eventHasStarted(existingEvent.StartsAt, time.Now()) {
+			writeError(w, r, ErrCodeValidation, "Cannot update start time for past events")
 			return
 		}
 		startsAt = *req.StartsAt
@@ -363,37 +780,45 @@ func (h *EventHandlers) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 		endsAt = req.EndsAt
 	}
 
-	// Validate time window after applying updates
-	if errMsg := validateTimeWindow(startsAt, endsAt); errMsg != "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInvalidTimeRange)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeInvalidTimeRange, errMsg)
+	// Validate time window after applying updates. Duration and start limits
+	// only apply when the times change, so edits to other fields of older
+	// events keep working.
+	validateTimes := validateTimeWindow
+	if req.StartsAt != nil || req.EndsAt != nil {
+		validateTimes = h.validateEventSchedule
+	}
+	if errMsg := validateTimes(startsAt, endsAt); errMsg != "" {
+		writeError(w, r, ErrCodeInvalidTimeRange, errMsg)
 		return
 	}
 
 	updatedEvent.StartsAt = startsAt
 	updatedEvent.EndsAt = endsAt
+	updatedEvent.InLocation()
 
 	// Update timestamp
 	now := time.Now()
 	updatedEvent.UpdatedAt = &now
 
 	// Update in repository (will automatically enforce location consent)
-	if err := h.eventRepo.Update(&updatedEvent); err != nil {
+	if err := h.eventRepo.Update(r.Context(), &updatedEvent); err != nil {
 		slog.ErrorContext(r.Context(), "failed to update event", "error", err, "event_id", eventID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to update event")
+		writeError(w, r, ErrCodeInternal, "Failed to update event")
 		return
 	}
 
 	// Retrieve the stored event to get privacy-enforced version
-	stored, err := h.eventRepo.GetByID(eventID)
+	stored, err := h.eventRepo.GetByID(r.Context(), eventID)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to retrieve updated event", "error", err, "event_id", eventID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve updated event")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve updated event")
 		return
 	}
 
+	if h.searchIndex != nil {
+		h.searchIndex.IndexEvent(stored)
+	}
+
 	// Return updated event
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -405,50 +830,61 @@ func (h *EventHandlers) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 
 // GetEvent handles GET /events/{id} - retrieves an event.
 func (h *EventHandlers) GetEvent(w http.ResponseWriter, r *http.Request) {
-	// Extract event ID from URL path
-	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/"), "/")
-	if len(pathParts) == 0 || pathParts[0] == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Event ID is required")
+	// Extract and validate event ID from URL path
+	eventID, ok := parseEntityID(w, r, "/events/", "Event")
+	if !ok {
 		return
 	}
-	eventID := pathParts[0]
 
 	// Get the event
-	foundEvent, err := h.eventRepo.GetByID(eventID)
+	foundEvent, err := h.eventRepo.GetByID(r.Context(), eventID)
 	if err != nil {
 		if err == scene.ErrEventNotFound {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Event not found")
+			writeError(w, r, ErrCodeNotFound, "Event not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to get event", "error", err, "event_id", eventID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve event")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve event")
 		return
 	}
 
 	// Privacy enforcement is handled by the repository
 	// The repository automatically enforces location consent via EnforceLocationConsent()
 
-	// Get RSVP counts for the event
-	rsvpCounts, err := h.rsvpRepo.GetCountsByEvent(eventID)
+	// Get RSVP counts for the event, if the requester may see them
+	var rsvpCounts *scene.RSVPCounts
+	showCounts, err := h.canSeeRSVPCounts(r.Context(), foundEvent, middleware.GetUserDID(r.Context()))
 	if err != nil {
-		slog.ErrorContext(r.Context(), "failed to get RSVP counts", "error", err, "event_id", eventID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve RSVP counts")
+		slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "event_id", eventID)
+		writeError(w, r, ErrCodeInternal, "Failed to check access permissions")
 		return
 	}
+	if showCounts {
+		rsvpCounts, err = h.rsvpRepo.GetCountsByEvent(r.Context(), eventID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to get RSVP counts", "error", err, "event_id", eventID)
+			writeError(w, r, ErrCodeInternal, "Failed to retrieve RSVP counts")
+			return
+		}
+	}
 
 	// Get active stream for the event
 	activeStream, err := h.streamRepo.GetActiveStreamForEvent(eventID)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to get active stream", "error", err, "event_id", eventID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve active stream")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve active stream")
 		return
 	}
 
+	// Record who was served the precise location; nothing is logged when
+	// the repository stripped it for lack of consent
+	if foundEvent.PrecisePoint != nil && h.auditRepo != nil {
+		if err := audit.LogAccessFromRequest(r, h.auditRepo, "event", eventID, "access_precise_location"); err != nil {
+			slog.WarnContext(r.Context(), "failed to log precise location access", "error", err, "event_id", eventID)
+			// Continue - audit failure should not block the read
+		}
+	}
+
 	// Create response with event, RSVP counts, and active stream
 	response := EventWithRSVPCounts{
 		Event:        foundEvent,
@@ -465,24 +901,47 @@ func (h *EventHandlers) GetEvent(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// canSeeRSVPCounts reports whether requesterDID may see an event's RSVP
+// counts. Counts for public scenes are shown to everyone; for other scenes
+// only to the owner and active members. Always false without an RSVP
+// repository or when the scene no longer exists.
+func (h *EventHandlers) canSeeRSVPCounts(ctx context.Context, event *scene.Event, requesterDID string) (bool, error) {
+	if h.rsvpRepo == nil {
+		return false, nil
+	}
+
+	parentScene, err := h.sceneRepo.GetByID(ctx, event.SceneID)
+	if err != nil {
+		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+			return false, nil
+		}
+		return false, err
+	}
+	if parentScene.Visibility == scene.VisibilityPublic {
+		return true, nil
+	}
+
+	viewerLevel, err := viewerLevelForScene(ctx, h.membershipRepo, parentScene, requesterDID)
+	if err != nil {
+		return false, err
+	}
+	return viewerLevel == geo.ViewerOwner || viewerLevel == geo.ViewerMember, nil
+}
+
 // CancelEvent handles POST /events/{id}/cancel - cancels an event.
 func (h *EventHandlers) CancelEvent(w http.ResponseWriter, r *http.Request) {
 	// Extract event ID from URL path
 	// Note: The routing layer already validates this is a /events/{id}/cancel request
-	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/"), "/")
-	if len(pathParts) == 0 || pathParts[0] == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Event ID is required")
+	eventID, ok := parseEntityID(w, r, "/events/", "Event")
+	if !ok {
 		return
 	}
-	eventID := pathParts[0]
 
 	// Parse request body (optional reason)
 	var req CancelEventRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
 		// Allow empty body (io.EOF) but reject malformed JSON
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+		writeDecodeError(w, r, err)
 		return
 	}
 
@@ -493,24 +952,21 @@ func (h *EventHandlers) CancelEvent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get existing event
-	existingEvent, err := h.eventRepo.GetByID(eventID)
+	existingEvent, err := h.eventRepo.GetByID(r.Context(), eventID)
 	if err != nil {
 		if err == scene.ErrEventNotFound {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Event not found")
+			writeError(w, r, ErrCodeNotFound, "Event not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to get event", "error", err, "event_id", eventID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve event")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve event")
 		return
 	}
 
 	// Get user DID from context (set by auth middleware)
 	userDID := middleware.GetUserDID(r.Context())
 	if userDID == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
-		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
 		return
 	}
 
@@ -518,29 +974,31 @@ func (h *EventHandlers) CancelEvent(w http.ResponseWriter, r *http.Request) {
 	isOwner, err := h.isSceneOwner(r.Context(), existingEvent.SceneID, userDID)
 	if err != nil {
 		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to check scene ownership", "error", err, "scene_id", existingEvent.SceneID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to verify scene ownership")
+		writeError(w, r, ErrCodeInternal, "Failed to verify scene ownership")
 		return
 	}
 	if !isOwner {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
-		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You do not have permission to cancel this event")
+		writeError(w, r, ErrCodeForbidden, "You do not have permission to cancel this event")
 		return
 	}
 
 	// Track whether event was already cancelled for audit log decision
 	alreadyCancelled := existingEvent.Status == "cancelled" && existingEvent.CancelledAt != nil
 
+	// Events that have already started can't be cancelled
+	if !alreadyCancelled && eventHasStarted(existingEvent.StartsAt, time.Now()) {
+		writeError(w, r, ErrCodeValidation, "Cannot cancel past or ongoing events")
+		return
+	}
+
 	// Cancel the event (idempotent)
-	if err := h.eventRepo.Cancel(eventID, req.Reason); err != nil {
+	if err := h.eventRepo.Cancel(r.Context(), eventID, req.Reason); err != nil {
 		slog.ErrorContext(r.Context(), "failed to cancel event", "error", err, "event_id", eventID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to cancel event")
+		writeError(w, r, ErrCodeInternal, "Failed to cancel event")
 		return
 	}
 
@@ -553,13 +1011,15 @@ func (h *EventHandlers) CancelEvent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Retrieve the updated event
-	cancelledEvent, err := h.eventRepo.GetByID(eventID)
+	cancelledEvent, err := h.eventRepo.GetByID(r.Context(), eventID)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to retrieve cancelled event", "error", err, "event_id", eventID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve cancelled event")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve cancelled event")
 		return
 	}
+	if !alreadyCancelled {
+		h.notifyEventWebhooks(r, webhook.EventCancelled, cancelledEvent.SceneID, cancelledEvent)
+	}
 
 	// Return cancelled event
 	w.Header().Set("Content-Type", "application/json")
@@ -570,76 +1030,119 @@ func (h *EventHandlers) CancelEvent(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// SearchEventsResponse represents the response for event search with active stream info.
-type SearchEventsResponse struct {
-	Events     []*EventWithRSVPCounts `json:"events"`
-	NextCursor string                 `json:"next_cursor,omitempty"`
-}
-
-// SearchEvents handles GET /search/events - searches events by bbox and time range.
-func (h *EventHandlers) SearchEvents(w http.ResponseWriter, r *http.Request) {
-	// Parse query parameters
-	query := r.URL.Query()
-	
-	// Parse bbox (format: minLng,minLat,maxLng,maxLat)
-	bboxStr := query.Get("bbox")
-	if bboxStr == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "bbox parameter is required")
+// CancelSeries handles POST /events/series/{id}/cancel - cancels every
+// occurrence of a recurring series that hasn't started yet.
+// Occurrences already in the past are left untouched, and RSVPs are preserved.
+func (h *EventHandlers) CancelSeries(w http.ResponseWriter, r *http.Request) {
+	// Extract series ID from URL path
+	// Note: The routing layer already validates this is a /events/series/{id}/cancel request
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/series/"), "/")
+	if len(pathParts) == 0 || pathParts[0] == "" {
+		writeError(w, r, ErrCodeBadRequest, "Series ID is required")
 		return
 	}
-	
-	bboxParts := strings.Split(bboxStr, ",")
-	if len(bboxParts) != 4 {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "bbox must be in format: minLng,minLat,maxLng,maxLat")
+	seriesID := pathParts[0]
+
+	// Parse request body (optional reason)
+	var req CancelEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		writeDecodeError(w, r, err)
 		return
 	}
-	
-	// Parse and validate bbox coordinates
-	var minLng, minLat, maxLng, maxLat float64
-	var err error
-	
-	if minLng, err = parseFloat(bboxParts[0], "minLng"); err != nil {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
-		return
+	if req.Reason != nil {
+		sanitized := html.EscapeString(*req.Reason)
+		req.Reason = &sanitized
 	}
-	if minLat, err = parseFloat(bboxParts[1], "minLat"); err != nil {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
+
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
 		return
 	}
-	if maxLng, err = parseFloat(bboxParts[2], "maxLng"); err != nil {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
+
+	events, err := h.eventRepo.ListBySeries(r.Context(), seriesID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to list series events", "error", err, "series_id", seriesID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve event series")
 		return
 	}
-	if maxLat, err = parseFloat(bboxParts[3], "maxLat"); err != nil {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
+	if len(events) == 0 {
+		writeError(w, r, ErrCodeNotFound, "Event series not found")
 		return
 	}
-	
-	// Validate bbox ranges
-	if minLng < -180 || minLng > 180 || maxLng < -180 || maxLng > 180 {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "longitude must be between -180 and 180")
+
+	// All occurrences of a series belong to the same scene
+	isOwner, err := h.isSceneOwner(r.Context(), events[0].SceneID, userDID)
+	if err != nil {
+		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to check scene ownership", "error", err, "scene_id", events[0].SceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to verify scene ownership")
 		return
 	}
-	if minLat < -90 || minLat > 90 || maxLat < -90 || maxLat > 90 {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "latitude must be between -90 and 90")
+	if !isOwner {
+		writeError(w, r, ErrCodeForbidden, "You do not have permission to cancel this event series")
 		return
 	}
-	if minLng >= maxLng {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "minLng must be less than maxLng")
+
+	now := time.Now()
+	cancelledIDs := make(map[string]bool)
+	for _, event := range events {
+		if event.Status == "cancelled" || eventHasStarted(event.StartsAt, now) {
+			continue
+		}
+		if err := h.eventRepo.Cancel(r.Context(), event.ID, req.Reason); err != nil {
+			slog.ErrorContext(r.Context(), "failed to cancel event", "error", err, "event_id", event.ID, "series_id", seriesID)
+			writeError(w, r, ErrCodeInternal, "Failed to cancel event series")
+			return
+		}
+		if err := audit.LogAccessFromRequest(r, h.auditRepo, "event", event.ID, "event_cancel"); err != nil {
+			slog.ErrorContext(r.Context(), "failed to log event cancellation", "error", err, "event_id", event.ID)
+			// Don't fail the request, but log the error
+		}
+		cancelledIDs[event.ID] = true
+	}
+
+	updated, err := h.eventRepo.ListBySeries(r.Context(), seriesID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to retrieve cancelled series", "error", err, "series_id", seriesID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve event series")
 		return
 	}
-	if minLat >= maxLat {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "minLat must be less than maxLat")
+
+	var cancelled []*scene.Event
+	for _, event := range updated {
+		if cancelledIDs[event.ID] {
+			cancelled = append(cancelled, event)
+		}
+	}
+	if len(cancelled) > 0 {
+		h.notifyEventWebhooks(r, webhook.EventCancelled, cancelled[0].SceneID, cancelled...)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(EventSeriesResponse{SeriesID: seriesID, Events: updated}); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode event series response", "error", err)
+	}
+}
+
+// SearchEventsResponse represents the response for event search with active stream info.
+type SearchEventsResponse struct {
+	Events     []*EventWithRSVPCounts `json:"events"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+// SearchEvents handles GET /search/events - searches events by bbox and time range.
+func (h *EventHandlers) SearchEvents(w http.ResponseWriter, r *http.Request) {
+	// Parse query parameters
+	query := r.URL.Query()
+	
+	bbox, err := parseBbox(query.Get("bbox"))
+	if err != nil {
+		writeError(w, r, ErrCodeValidation, err.Error())
 		return
 	}
 	
@@ -648,37 +1151,32 @@ func (h *EventHandlers) SearchEvents(w http.ResponseWriter, r *http.Request) {
 	toStr := query.Get("to")
 	
 	if fromStr == "" || toStr == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "both 'from' and 'to' parameters are required")
+		writeError(w, r, ErrCodeValidation, "both 'from' and 'to' parameters are required")
 		return
 	}
 	
 	from, err := time.Parse(time.RFC3339, fromStr)
 	if err != nil {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "invalid 'from' timestamp, must be RFC3339 format")
+		writeError(w, r, ErrCodeValidation, "invalid 'from' timestamp, must be RFC3339 format")
 		return
 	}
 	
 	to, err := time.Parse(time.RFC3339, toStr)
 	if err != nil {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "invalid 'to' timestamp, must be RFC3339 format")
+		writeError(w, r, ErrCodeValidation, "invalid 'to' timestamp, must be RFC3339 format")
 		return
 	}
 	
 	// Validate time range
 	if !from.Before(to) {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInvalidTimeRange)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeInvalidTimeRange, "'from' must be before 'to'")
+		writeError(w, r, ErrCodeInvalidTimeRange, "'from' must be before 'to'")
 		return
 	}
 	
 	// Validate max window length (30 days)
 	maxWindow := 30 * 24 * time.Hour
 	if to.Sub(from) > maxWindow {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "time window cannot exceed 30 days")
+		writeError(w, r, ErrCodeValidation, "time window cannot exceed 30 days")
 		return
 	}
 	
@@ -688,8 +1186,7 @@ func (h *EventHandlers) SearchEvents(w http.ResponseWriter, r *http.Request) {
 	if limitStr != "" {
 		parsedLimit, err := parseIntInRange(limitStr, "limit", 1, 100)
 		if err != nil {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
+			writeError(w, r, ErrCodeValidation, err.Error())
 			return
 		}
 		limit = parsedLimit
@@ -698,11 +1195,13 @@ func (h *EventHandlers) SearchEvents(w http.ResponseWriter, r *http.Request) {
 	cursor := query.Get("cursor")
 	
 	// Search events
-	events, nextCursor, err := h.eventRepo.SearchByBboxAndTime(minLng, minLat, maxLng, maxLat, from, to, limit, cursor)
+	events, nextCursor, err := h.eventRepo.SearchByBboxAndTime(r.Context(), bbox.MinLng, bbox.MinLat, bbox.MaxLng, bbox.MaxLat, from, to, limit, cursor)
 	if err != nil {
+		if writeContextError(w, r, err) {
+			return
+		}
 		slog.ErrorContext(r.Context(), "failed to search events", "error", err)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to search events")
+		writeError(w, r, ErrCodeInternal, "Failed to search events")
 		return
 	}
 	
@@ -715,18 +1214,19 @@ func (h *EventHandlers) SearchEvents(w http.ResponseWriter, r *http.Request) {
 	activeStreamsMap, err := h.streamRepo.GetActiveStreamsForEvents(eventIDs)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to get active streams", "error", err)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve active streams")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve active streams")
 		return
 	}
 	
 	// Batch fetch RSVP counts to avoid N+1 queries
-	rsvpCountsMap, err := h.rsvpRepo.GetCountsForEvents(eventIDs)
-	if err != nil {
-		slog.ErrorContext(r.Context(), "failed to get RSVP counts", "error", err)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve RSVP counts")
-		return
+	rsvpCountsMap := map[string]*scene.RSVPCounts{}
+	if h.rsvpRepo != nil {
+		rsvpCountsMap, err = h.rsvpRepo.GetCountsForEvents(r.Context(), eventIDs)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to get RSVP counts", "error", err)
+			writeError(w, r, ErrCodeInternal, "Failed to retrieve RSVP counts")
+			return
+		}
 	}
 	
 	// Build response with events, RSVP counts, and active streams
@@ -752,6 +1252,468 @@ func (h *EventHandlers) SearchEvents(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ListEventsResponse represents the response for listing a scene's events.
+type ListEventsResponse struct {
+	Events []*scene.Event `json:"events"`
+}
+
+// ListEventsByScene handles GET /scenes/{id}/events - lists a scene's events.
+// Supports optional status (scheduled, cancelled, past) and from/to (RFC3339) filters.
+func (h *EventHandlers) ListEventsByScene(w http.ResponseWriter, r *http.Request) {
+	// Extract scene ID from URL path
+	// Expected pattern: /scenes/{id}/events
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
+	if len(pathParts) == 0 || pathParts[0] == "" {
+		writeError(w, r, ErrCodeBadRequest, "Scene ID is required")
+		return
+	}
+	sceneID := pathParts[0]
+
+	query := r.URL.Query()
+	filter := scene.EventFilter{Status: query.Get("status")}
+	switch filter.Status {
+	case "", scene.EventStatusFilterScheduled, scene.EventStatusFilterCancelled, scene.EventStatusFilterPast:
+	default:
+		writeError(w, r, ErrCodeValidation, "status must be one of: scheduled, cancelled, past")
+		return
+	}
+
+	if fromStr := query.Get("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			writeError(w, r, ErrCodeValidation, "invalid 'from' timestamp, must be RFC3339 format")
+			return
+		}
+		filter.From = from
+	}
+	if toStr := query.Get("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			writeError(w, r, ErrCodeValidation, "invalid 'to' timestamp, must be RFC3339 format")
+			return
+		}
+		filter.To = to
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		writeError(w, r, ErrCodeInvalidTimeRange, "'from' must be before 'to'")
+		return
+	}
+
+	// Get the scene and enforce visibility
+	foundScene, err := h.sceneRepo.GetByID(r.Context(), sceneID)
+	if err != nil {
+		if err == scene.ErrSceneDeleted {
+			writeError(w, r, ErrCodeSceneDeleted, "Scene not found")
+			return
+		}
+		if err == scene.ErrSceneNotFound {
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to get scene", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+		return
+	}
+
+	visible, viaAlliance, err := h.sceneEventAccess(r.Context(), foundScene, middleware.GetUserDID(r.Context()))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to check scene access")
+		return
+	}
+	if !visible {
+		// Return 404 to avoid revealing that the scene exists
+		writeError(w, r, ErrCodeNotFound, "Scene not found")
+		return
+	}
+
+	events, err := h.eventRepo.ListBySceneID(r.Context(), sceneID, filter)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to list events", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to list events")
+		return
+	}
+
+	// Defense in depth: never expose a precise point without consent
+	for _, event := range events {
+		event.EnforceLocationConsent()
+		if viaAlliance {
+			event.PrecisePoint = nil
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(ListEventsResponse{Events: events}); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode events response", "error", err)
+	}
+}
+
+// Upcoming event discovery limits.
+const (
+	DefaultUpcomingLimit    = 50
+	MaxUpcomingLimit        = 100
+	MaxUpcomingRadiusMeters = 100000
+)
+
+// UpcomingEventsResponse represents the response for upcoming event discovery.
+type UpcomingEventsResponse struct {
+	Events     []*EventWithRSVPCounts `json:"events"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+// ListUpcoming handles GET /events/upcoming - discovers upcoming events near a point
+// across all scenes the caller can see, with RSVP counts where the caller may
+// see them.
+// Query parameters: lat, lng, radius_m (required), limit, cursor (optional).
+func (h *EventHandlers) ListUpcoming(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if query.Get("lat") == "" || query.Get("lng") == "" || query.Get("radius_m") == "" {
+		writeError(w, r, ErrCodeValidation, "lat, lng, and radius_m parameters are required")
+		return
+	}
+
+	lat, err := parseFloat(query.Get("lat"), "lat")
+	if err != nil {
+		writeError(w, r, ErrCodeValidation, err.Error())
+		return
+	}
+	lng, err := parseFloat(query.Get("lng"), "lng")
+	if err != nil {
+		writeError(w, r, ErrCodeValidation, err.Error())
+		return
+	}
+	if lat < -90 || lat > 90 {
+		writeError(w, r, ErrCodeValidation, "latitude must be between -90 and 90")
+		return
+	}
+	if lng < -180 || lng > 180 {
+		writeError(w, r, ErrCodeValidation, "longitude must be between -180 and 180")
+		return
+	}
+
+	radius, err := parseFloat(query.Get("radius_m"), "radius_m")
+	if err != nil {
+		writeError(w, r, ErrCodeValidation, err.Error())
+		return
+	}
+	if radius <= 0 || radius > MaxUpcomingRadiusMeters {
+		writeErrorf(w, r, ErrCodeValidation, "radius_m must be greater than 0 and at most %d", MaxUpcomingRadiusMeters)
+		return
+	}
+
+	limit := DefaultUpcomingLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsedLimit, err := parseIntInRange(limitStr, "limit", 1, MaxUpcomingLimit)
+		if err != nil {
+			writeError(w, r, ErrCodeValidation, err.Error())
+			return
+		}
+		limit = parsedLimit
+	}
+
+	center := geo.Point{Lat: lat, Lng: lng}
+	events, nextCursor, err := h.visibleUpcomingNear(r.Context(), center, radius, limit, query.Get("cursor"), nil)
+	if err != nil {
+		if err == scene.ErrInvalidCursor {
+			writeError(w, r, ErrCodeValidation, "invalid cursor")
+			return
+		}
+		if writeContextError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to list upcoming events", "error", err)
+		writeError(w, r, ErrCodeInternal, "Failed to list upcoming events")
+		return
+	}
+
+	eventsWithCounts, err := h.withRSVPCounts(r.Context(), events)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to get RSVP counts", "error", err)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve RSVP counts")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(UpcomingEventsResponse{Events: eventsWithCounts, NextCursor: nextCursor}); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode upcoming events response", "error", err)
+	}
+}
+
+// ListNearEvent handles GET /events/{id}/nearby - lists other upcoming events
+// within radius_m of an event's coarse location. Distances are measured
+// between coarse cell centers, as in ListUpcoming, and the radius is inclusive.
+// Query parameters: radius_m (required), exclude_same_scene, limit, cursor (optional).
+func (h *EventHandlers) ListNearEvent(w http.ResponseWriter, r *http.Request) {
+	eventID, ok := parseEntityID(w, r, "/events/", "Event")
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	if query.Get("radius_m") == "" {
+		writeError(w, r, ErrCodeValidation, "radius_m parameter is required")
+		return
+	}
+	radius, err := parseFloat(query.Get("radius_m"), "radius_m")
+	if err != nil {
+		writeError(w, r, ErrCodeValidation, err.Error())
+		return
+	}
+	if radius <= 0 || radius > MaxUpcomingRadiusMeters {
+		writeErrorf(w, r, ErrCodeValidation, "radius_m must be greater than 0 and at most %d", MaxUpcomingRadiusMeters)
+		return
+	}
+
+	excludeSameScene := false
+	if excludeStr := query.Get("exclude_same_scene"); excludeStr != "" {
+		parsed, err := strconv.ParseBool(strings.TrimSpace(excludeStr))
+		if err != nil {
+			writeError(w, r, ErrCodeValidation, "exclude_same_scene must be true or false")
+			return
+		}
+		excludeSameScene = parsed
+	}
+
+	limit := DefaultUpcomingLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsedLimit, err := parseIntInRange(limitStr, "limit", 1, MaxUpcomingLimit)
+		if err != nil {
+			writeError(w, r, ErrCodeValidation, err.Error())
+			return
+		}
+		limit = parsedLimit
+	}
+
+	sourceEvent, err := h.eventRepo.GetByID(r.Context(), eventID)
+	if err != nil {
+		if err == scene.ErrEventNotFound {
+			writeError(w, r, ErrCodeNotFound, "Event not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to get event", "error", err, "event_id", eventID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve event")
+		return
+	}
+
+	// The source event must be visible too, or its location would leak
+	sourceScene, err := h.sceneRepo.GetByID(r.Context(), sourceEvent.SceneID)
+	if err != nil {
+		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+			writeError(w, r, ErrCodeNotFound, "Event not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to get scene", "error", err, "scene_id", sourceEvent.SceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+		return
+	}
+	visible, _, err := h.sceneEventAccess(r.Context(), sourceScene, middleware.GetUserDID(r.Context()))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", sourceScene.ID)
+		writeError(w, r, ErrCodeInternal, "Failed to check scene access")
+		return
+	}
+	if !visible {
+		// Same response as not found to prevent enumeration
+		writeError(w, r, ErrCodeNotFound, "Event not found")
+		return
+	}
+
+	center, ok := geo.Decode(sourceEvent.CoarseGeohash)
+	if !ok {
+		slog.ErrorContext(r.Context(), "event has invalid coarse geohash", "event_id", eventID)
+		writeError(w, r, ErrCodeInternal, "Failed to resolve event location")
+		return
+	}
+
+	skip := func(e *scene.Event) bool {
+		return e.ID == sourceEvent.ID || (excludeSameScene && e.SceneID == sourceEvent.SceneID)
+	}
+	events, nextCursor, err := h.visibleUpcomingNear(r.Context(), center, radius, limit, query.Get("cursor"), skip)
+	if err != nil {
+		if err == scene.ErrInvalidCursor {
+			writeError(w, r, ErrCodeValidation, "invalid cursor")
+			return
+		}
+		if writeContextError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to list nearby events", "error", err, "event_id", eventID)
+		writeError(w, r, ErrCodeInternal, "Failed to list nearby events")
+		return
+	}
+
+	eventsWithCounts, err := h.withRSVPCounts(r.Context(), events)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to get RSVP counts", "error", err, "event_id", eventID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve RSVP counts")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(UpcomingEventsResponse{Events: eventsWithCounts, NextCursor: nextCursor}); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode nearby events response", "error", err)
+	}
+}
+
+// withRSVPCounts pairs events with their RSVP counts, fetched in one batch.
+// Counts are withheld per scene as in canSeeRSVPCounts, checking each scene
+// once.
+func (h *EventHandlers) withRSVPCounts(ctx context.Context, events []*scene.Event) ([]*EventWithRSVPCounts, error) {
+	result := make([]*EventWithRSVPCounts, len(events))
+	for i, event := range events {
+		result[i] = &EventWithRSVPCounts{Event: event}
+	}
+	if h.rsvpRepo == nil || len(events) == 0 {
+		return result, nil
+	}
+
+	eventIDs := make([]string, len(events))
+	for i, event := range events {
+		eventIDs[i] = event.ID
+	}
+	counts, err := h.rsvpRepo.GetCountsForEvents(ctx, eventIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	requesterDID := middleware.GetUserDID(ctx)
+	allowedByScene := make(map[string]bool)
+	for _, item := range result {
+		allowed, checked := allowedByScene[item.SceneID]
+		if !checked {
+			allowed, err = h.canSeeRSVPCounts(ctx, item.Event, requesterDID)
+			if err != nil {
+				return nil, fmt.Errorf("check RSVP count access for scene %s: %w", item.SceneID, err)
+			}
+			allowedByScene[item.SceneID] = allowed
+		}
+		if allowed {
+			item.RSVPCounts = counts[item.ID]
+		}
+	}
+	return result, nil
+}
+
+// visibleUpcomingNear returns one page of upcoming events within
+// radiusMeters of center that the requester can see, with location privacy
+// applied. Events for which skip returns true are left out; skip may be nil.
+// Returns scene.ErrInvalidCursor for a malformed cursor.
+func (h *EventHandlers) visibleUpcomingNear(ctx context.Context, center geo.Point, radiusMeters float64, limit int, cursor string, skip func(*scene.Event) bool) ([]*scene.Event, string, error) {
+	requesterDID := middleware.GetUserDID(ctx)
+	now := time.Now()
+
+	// Cache visibility per scene so each scene is checked once per request
+	type sceneAccess struct{ visible, viaAlliance bool }
+	accessByScene := make(map[string]sceneAccess)
+	accessFor := func(sceneID string) (sceneAccess, error) {
+		if access, ok := accessByScene[sceneID]; ok {
+			return access, nil
+		}
+		s, err := h.sceneRepo.GetByID(ctx, sceneID)
+		if err != nil {
+			if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+				accessByScene[sceneID] = sceneAccess{}
+				return sceneAccess{}, nil
+			}
+			return sceneAccess{}, err
+		}
+		visible, viaAlliance, err := h.sceneEventAccess(ctx, s, requesterDID)
+		if err != nil {
+			return sceneAccess{}, err
+		}
+		access := sceneAccess{visible: visible, viaAlliance: viaAlliance}
+		accessByScene[sceneID] = access
+		return access, nil
+	}
+
+	// Fetch repository pages until the response page is full, since events in
+	// scenes the caller can't see are dropped after retrieval.
+	events := make([]*scene.Event, 0, limit)
+	var nextCursor string
+	for {
+		page, pageCursor, err := h.eventRepo.ListUpcomingNear(ctx, center, radiusMeters, now, limit, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+
+		for i, event := range page {
+			if skip != nil && skip(event) {
+				continue
+			}
+			access, err := accessFor(event.SceneID)
+			if err != nil {
+				return nil, "", fmt.Errorf("check access for scene %s: %w", event.SceneID, err)
+			}
+			if !access.visible {
+				continue
+			}
+			event.EnforceLocationConsent()
+			if access.viaAlliance {
+				event.PrecisePoint = nil
+			}
+			events = append(events, event)
+			if len(events) == limit {
+				// More results may follow if this page or the repository has any left
+				if i < len(page)-1 || pageCursor != "" {
+					nextCursor = scene.EventCursor(event)
+				}
+				break
+			}
+		}
+
+		if len(events) == limit || pageCursor == "" {
+			break
+		}
+		cursor = pageCursor
+	}
+	return events, nextCursor, nil
+}
+
+// parseBbox parses a required bbox query parameter in the format
+// minLng,minLat,maxLng,maxLat and validates its coordinate ranges.
+func parseBbox(s string) (geo.Bounds, error) {
+	if s == "" {
+		return geo.Bounds{}, errors.New("bbox parameter is required")
+	}
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return geo.Bounds{}, errors.New("bbox must be in format: minLng,minLat,maxLng,maxLat")
+	}
+
+	var b geo.Bounds
+	var err error
+	if b.MinLng, err = parseFloat(parts[0], "minLng"); err != nil {
+		return geo.Bounds{}, err
+	}
+	if b.MinLat, err = parseFloat(parts[1], "minLat"); err != nil {
+		return geo.Bounds{}, err
+	}
+	if b.MaxLng, err = parseFloat(parts[2], "maxLng"); err != nil {
+		return geo.Bounds{}, err
+	}
+	if b.MaxLat, err = parseFloat(parts[3], "maxLat"); err != nil {
+		return geo.Bounds{}, err
+	}
+
+	if b.MinLng < -180 || b.MinLng > 180 || b.MaxLng < -180 || b.MaxLng > 180 {
+		return geo.Bounds{}, errors.New("longitude must be between -180 and 180")
+	}
+	if b.MinLat < -90 || b.MinLat > 90 || b.MaxLat < -90 || b.MaxLat > 90 {
+		return geo.Bounds{}, errors.New("latitude must be between -90 and 90")
+	}
+	if b.MinLng >= b.MaxLng {
+		return geo.Bounds{}, errors.New("minLng must be less than maxLng")
+	}
+	if b.MinLat >= b.MaxLat {
+		return geo.Bounds{}, errors.New("minLat must be less than maxLat")
+	}
+	return b, nil
+}
+
 // parseFloat parses a float64 from a string with contextual error message.
 func parseFloat(s, fieldName string) (float64, error) {
 	s = strings.TrimSpace(s)
@@ -2,18 +2,28 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/alliance"
 	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/geo"
+	"github.com/onnwee/subcults/internal/membership"
 	"github.com/onnwee/subcults/internal/middleware"
 	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/search"
 	"github.com/onnwee/subcults/internal/stream"
+	"github.com/onnwee/subcults/internal/webhook"
 )
 
 // TestCreateEvent_Success tests successful event creation.
@@ -33,7 +43,7 @@ func TestCreateEvent_Success(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		CreatedAt:     &time.Time{},
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -113,6 +123,26 @@ func TestCreateEvent_InvalidTimeWindow(t *testing.T) {
 			wantCode: http.StatusBadRequest,
 			wantErr:  ErrCodeInvalidTimeRange,
 		},
+		{
+			name:     "longer than max duration",
+			startsAt: now.Add(24 * time.Hour),
+			endsAt:   func() *time.Time { t := now.Add(24*time.Hour + DefaultMaxEventDuration + time.Minute); return &t }(),
+			wantCode: http.StatusBadRequest,
+			wantErr:  ErrCodeInvalidTimeRange,
+		},
+		{
+			name:     "year-long event",
+			startsAt: now.Add(24 * time.Hour),
+			endsAt:   func() *time.Time { t := now.Add(366 * 24 * time.Hour); return &t }(),
+			wantCode: http.StatusBadRequest,
+			wantErr:  ErrCodeInvalidTimeRange,
+		},
+		{
+			name:     "start too far in the future",
+			startsAt: now.Add(MaxEventStartAhead + 24*time.Hour),
+			wantCode: http.StatusBadRequest,
+			wantErr:  ErrCodeInvalidTimeRange,
+		},
 	}
 
 	for _, tt := range tests {
@@ -131,7 +161,7 @@ func TestCreateEvent_InvalidTimeWindow(t *testing.T) {
 				OwnerDID:      "did:plc:test123",
 				CoarseGeohash: "dr5regw",
 			}
-			if err := sceneRepo.Insert(testScene); err != nil {
+			if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 				t.Fatalf("failed to insert scene: %v", err)
 			}
 
@@ -172,6 +202,66 @@ func TestCreateEvent_InvalidTimeWindow(t *testing.T) {
 	}
 }
 
+// TestCreateEvent_MaxDuration tests that events up to the maximum duration are
+// accepted and that the maximum is configurable.
+func TestCreateEvent_MaxDuration(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, auditRepo, rsvpRepo, streamRepo)
+
+	testScene := &scene.Scene{
+		ID:            uuid.New().String(),
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+	}
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	create := func(duration time.Duration) *httptest.ResponseRecorder {
+		startsAt := time.Now().Add(24 * time.Hour)
+		endsAt := startsAt.Add(duration)
+		body, _ := json.Marshal(CreateEventRequest{
+			SceneID:       testScene.ID,
+			Title:         "Festival",
+			CoarseGeohash: "dr5regw",
+			StartsAt:      startsAt,
+			EndsAt:        &endsAt,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/events", bytes.NewReader(body))
+		req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+		w := httptest.NewRecorder()
+		handlers.CreateEvent(w, req)
+		return w
+	}
+
+	if w := create(DefaultMaxEventDuration); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201 at the maximum duration, got %d: %s", w.Code, w.Body.String())
+	}
+
+	handlers.SetMaxEventDuration(24 * time.Hour)
+	w := create(25 * time.Hour)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 above a custom maximum, got %d: %s", w.Code, w.Body.String())
+	}
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Message != "event duration cannot exceed 1 day" {
+		t.Errorf("unexpected error message %q", errResp.Error.Message)
+	}
+
+	handlers.SetMaxEventDuration(0)
+	if w := create(30 * 24 * time.Hour); w.Code != http.StatusCreated {
+		t.Errorf("expected status 201 with the check disabled, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
 // TestCreateEvent_MissingCoarseGeohash tests rejection when coarse_geohash is missing.
 func TestCreateEvent_MissingCoarseGeohash(t *testing.T) {
 	eventRepo := scene.NewInMemoryEventRepository()
@@ -188,7 +278,7 @@ func TestCreateEvent_MissingCoarseGeohash(t *testing.T) {
 		OwnerDID:      "did:plc:test123",
 		CoarseGeohash: "dr5regw",
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -226,6 +316,154 @@ func TestCreateEvent_MissingCoarseGeohash(t *testing.T) {
 	}
 }
 
+// TestCreateEvent_CoarseGeohashValidation tests that over-long geohashes are
+// truncated to the default precision and invalid characters are rejected.
+func TestCreateEvent_CoarseGeohashValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		geohash     string
+		wantStatus  int
+		wantGeohash string
+	}{
+		{"truncated to default precision", "dr5regw3pf9z", http.StatusCreated, "dr5reg"},
+		{"invalid characters", "dr5rea!", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRepo := scene.NewInMemoryEventRepository()
+			sceneRepo := scene.NewInMemorySceneRepository()
+			handlers := NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository())
+
+			testScene := &scene.Scene{
+				ID:            uuid.New().String(),
+				Name:          "Test Scene",
+				OwnerDID:      "did:plc:test123",
+				CoarseGeohash: "dr5reg",
+			}
+			if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
+				t.Fatalf("failed to insert scene: %v", err)
+			}
+
+			body, err := json.Marshal(CreateEventRequest{
+				SceneID:       testScene.ID,
+				Title:         "Test Event",
+				CoarseGeohash: tt.geohash,
+				StartsAt:      time.Now().Add(24 * time.Hour),
+			})
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/events", bytes.NewReader(body))
+			req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+			w := httptest.NewRecorder()
+			handlers.CreateEvent(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusCreated {
+				var errResp ErrorResponse
+				if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+					t.Fatalf("failed to decode error response: %v", err)
+				}
+				if errResp.Error.Code != ErrCodeValidation {
+					t.Errorf("expected error code %q, got %q", ErrCodeValidation, errResp.Error.Code)
+				}
+				return
+			}
+
+			var created scene.Event
+			if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			stored, err := eventRepo.GetByID(t.Context(), created.ID)
+			if err != nil {
+				t.Fatalf("failed to get stored event: %v", err)
+			}
+			if stored.CoarseGeohash != tt.wantGeohash {
+				t.Errorf("expected stored geohash %q, got %q", tt.wantGeohash, stored.CoarseGeohash)
+			}
+		})
+	}
+}
+
+// TestCreateEvent_DispatchesWebhook tests that creating an event sends a
+// signed event.created webhook without the precise point.
+func TestCreateEvent_DispatchesWebhook(t *testing.T) {
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := webhook.Verify("s3cret", r.Header.Get(webhook.HeaderTimestamp), r.Header.Get(webhook.HeaderSignature), body, webhook.DefaultTolerance, time.Now()); err != nil {
+			t.Errorf("expected valid signature, got %v", err)
+		}
+		bodies <- body
+	}))
+	defer server.Close()
+
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository())
+
+	subs := webhook.NewInMemorySubscriptionRepository()
+	if err := subs.Insert(&webhook.Subscription{URL: server.URL, Secret: "s3cret"}); err != nil {
+		t.Fatalf("failed to insert subscription: %v", err)
+	}
+	dispatcher := webhook.NewDispatcher(subs, webhook.Config{MaxAttempts: 1})
+	handlers.SetWebhookDispatcher(dispatcher)
+
+	testScene := &scene.Scene{
+		ID:            uuid.New().String(),
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5reg",
+		Visibility:    scene.VisibilityPublic,
+	}
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	body, err := json.Marshal(CreateEventRequest{
+		SceneID:       testScene.ID,
+		Title:         "Webhook Event",
+		CoarseGeohash: "dr5reg",
+		AllowPrecise:  true,
+		PrecisePoint:  &scene.Point{Lat: 40.7128, Lng: -74.0060},
+		StartsAt:      time.Now().Add(24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/events", bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+	handlers.CreateEvent(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if err := dispatcher.Shutdown(t.Context()); err != nil {
+		t.Fatalf("failed to drain webhooks: %v", err)
+	}
+	select {
+	case delivered := <-bodies:
+		var payload webhook.Payload
+		if err := json.Unmarshal(delivered, &payload); err != nil {
+			t.Fatalf("failed to decode payload: %v", err)
+		}
+		if payload.Type != webhook.EventCreated || payload.SceneID != testScene.ID {
+			t.Errorf("unexpected payload: %+v", payload)
+		}
+		if strings.Contains(string(payload.Data), "precise_point") {
+			t.Errorf("expected webhook data to omit precise_point, got %s", payload.Data)
+		}
+	default:
+		t.Fatal("expected an event.created webhook delivery")
+	}
+}
+
 // TestCreateEvent_UnauthorizedCreate tests rejection when user doesn't own the scene.
 func TestCreateEvent_UnauthorizedCreate(t *testing.T) {
 	eventRepo := scene.NewInMemoryEventRepository()
@@ -242,7 +480,7 @@ func TestCreateEvent_UnauthorizedCreate(t *testing.T) {
 		OwnerDID:      "did:plc:owner123",
 		CoarseGeohash: "dr5regw",
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -297,7 +535,7 @@ func TestCreateEvent_PrivacyEnforcement(t *testing.T) {
 		OwnerDID:      "did:plc:test123",
 		CoarseGeohash: "dr5regw",
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -387,7 +625,7 @@ func TestCreateEvent_TitleValidation(t *testing.T) {
 				OwnerDID:      "did:plc:test123",
 				CoarseGeohash: "dr5regw",
 			}
-			if err := sceneRepo.Insert(testScene); err != nil {
+			if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 				t.Fatalf("failed to insert scene: %v", err)
 			}
 
@@ -445,7 +683,7 @@ func TestUpdateEvent_Success(t *testing.T) {
 		OwnerDID:      "did:plc:test123",
 		CoarseGeohash: "dr5regw",
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -461,7 +699,7 @@ func TestUpdateEvent_Success(t *testing.T) {
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
-	if err := eventRepo.Insert(existingEvent); err != nil {
+	if err := eventRepo.Insert(t.Context(), existingEvent); err != nil {
 		t.Fatalf("failed to insert event: %v", err)
 	}
 
@@ -518,7 +756,7 @@ func TestUpdateEvent_CannotUpdatePastEvent(t *testing.T) {
 		OwnerDID:      "did:plc:test123",
 		CoarseGeohash: "dr5regw",
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -534,7 +772,7 @@ func TestUpdateEvent_CannotUpdatePastEvent(t *testing.T) {
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
-	if err := eventRepo.Insert(existingEvent); err != nil {
+	if err := eventRepo.Insert(t.Context(), existingEvent); err != nil {
 		t.Fatalf("failed to insert event: %v", err)
 	}
 
@@ -586,7 +824,7 @@ func TestUpdateEvent_TimeWindowValidation(t *testing.T) {
 		OwnerDID:      "did:plc:test123",
 		CoarseGeohash: "dr5regw",
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -604,7 +842,7 @@ func TestUpdateEvent_TimeWindowValidation(t *testing.T) {
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
-	if err := eventRepo.Insert(existingEvent); err != nil {
+	if err := eventRepo.Insert(t.Context(), existingEvent); err != nil {
 		t.Fatalf("failed to insert event: %v", err)
 	}
 
@@ -641,6 +879,78 @@ func TestUpdateEvent_TimeWindowValidation(t *testing.T) {
 	}
 }
 
+// TestUpdateEvent_ScheduleLimits tests that updated times obey the duration
+// and start limits, while edits that leave the times alone skip them.
+func TestUpdateEvent_ScheduleLimits(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, auditRepo, rsvpRepo, streamRepo)
+
+	testScene := &scene.Scene{
+		ID:            uuid.New().String(),
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+	}
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	// Stored before the limits existed: runs for 30 days
+	startsAt := time.Now().Add(24 * time.Hour)
+	legacyEnd := startsAt.Add(30 * 24 * time.Hour)
+	existingEvent := &scene.Event{
+		ID:            uuid.New().String(),
+		SceneID:       testScene.ID,
+		Title:         "Long Residency",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      startsAt,
+		EndsAt:        &legacyEnd,
+	}
+	if err := eventRepo.Insert(t.Context(), existingEvent); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	update := func(reqBody UpdateEventRequest) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest(http.MethodPatch, "/events/"+existingEvent.ID, bytes.NewReader(body))
+		req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+		w := httptest.NewRecorder()
+		handlers.UpdateEvent(w, req)
+		return w
+	}
+
+	newTitle := "Renamed Residency"
+	if w := update(UpdateEventRequest{Title: &newTitle}); w.Code != http.StatusOK {
+		t.Fatalf("expected title-only update to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+
+	tooLong := startsAt.Add(DefaultMaxEventDuration + time.Hour)
+	farStart := time.Now().Add(MaxEventStartAhead + 24*time.Hour)
+	for name, reqBody := range map[string]UpdateEventRequest{
+		"too long":        {EndsAt: &tooLong},
+		"too far ahead":   {StartsAt: &farStart, EndsAt: func() *time.Time { t := farStart.Add(time.Hour); return &t }()},
+		"legacy too long": {StartsAt: &startsAt},
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := update(reqBody)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != ErrCodeInvalidTimeRange {
+				t.Errorf("expected error code %s, got %s", ErrCodeInvalidTimeRange, errResp.Error.Code)
+			}
+		})
+	}
+}
+
 // TestGetEvent_Success tests successful event retrieval.
 func TestGetEvent_Success(t *testing.T) {
 	eventRepo := scene.NewInMemoryEventRepository()
@@ -663,7 +973,7 @@ func TestGetEvent_Success(t *testing.T) {
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
-	if err := eventRepo.Insert(testEvent); err != nil {
+	if err := eventRepo.Insert(t.Context(), testEvent); err != nil {
 		t.Fatalf("failed to insert event: %v", err)
 	}
 
@@ -742,7 +1052,7 @@ func TestGetEvent_PrivacyEnforcement(t *testing.T) {
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
-	if err := eventRepo.Insert(testEvent); err != nil {
+	if err := eventRepo.Insert(t.Context(), testEvent); err != nil {
 		t.Fatalf("failed to insert event: %v", err)
 	}
 
@@ -765,6 +1075,61 @@ func TestGetEvent_PrivacyEnforcement(t *testing.T) {
 	}
 }
 
+// TestGetEvent_PreciseLocationAudited tests that serving a precise point
+// writes an access_precise_location audit entry and stripping it writes none.
+func TestGetEvent_PreciseLocationAudited(t *testing.T) {
+	tests := []struct {
+		name         string
+		allowPrecise bool
+		wantEntries  int
+	}{
+		{"precise point served", true, 1},
+		{"precise point stripped", false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRepo := scene.NewInMemoryEventRepository()
+			auditRepo := audit.NewInMemoryRepository()
+			handlers := NewEventHandlers(eventRepo, scene.NewInMemorySceneRepository(), auditRepo, scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository())
+
+			testEvent := &scene.Event{
+				ID:            uuid.New().String(),
+				SceneID:       uuid.New().String(),
+				Title:         "Audited Event",
+				CoarseGeohash: "dr5regw",
+				StartsAt:      time.Now().Add(24 * time.Hour),
+				AllowPrecise:  tt.allowPrecise,
+				PrecisePoint:  &scene.Point{Lat: 40.7128, Lng: -74.0060},
+			}
+			if err := eventRepo.Insert(t.Context(), testEvent); err != nil {
+				t.Fatalf("failed to insert event: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/events/"+testEvent.ID, nil)
+			req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:viewer"))
+			w := httptest.NewRecorder()
+			handlers.GetEvent(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			logs, err := auditRepo.QueryByEntity(t.Context(), "event", testEvent.ID, 0)
+			if err != nil {
+				t.Fatalf("failed to query audit logs: %v", err)
+			}
+			if len(logs) != tt.wantEntries {
+				t.Fatalf("expected %d audit entries, got %d", tt.wantEntries, len(logs))
+			}
+			for _, entry := range logs {
+				if entry.Action != "access_precise_location" || entry.UserDID != "did:plc:viewer" {
+					t.Errorf("unexpected audit entry: action=%s user=%s", entry.Action, entry.UserDID)
+				}
+			}
+		})
+	}
+}
+
 // TestCancelEvent_Success tests successful event cancellation.
 func TestCancelEvent_Success(t *testing.T) {
 	eventRepo := scene.NewInMemoryEventRepository()
@@ -782,7 +1147,7 @@ func TestCancelEvent_Success(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		CreatedAt:     &time.Time{},
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -798,7 +1163,7 @@ func TestCancelEvent_Success(t *testing.T) {
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
-	if err := eventRepo.Insert(testEvent); err != nil {
+	if err := eventRepo.Insert(t.Context(), testEvent); err != nil {
 		t.Fatalf("failed to insert event: %v", err)
 	}
 
@@ -857,7 +1222,7 @@ func TestCancelEvent_WithoutReason(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		CreatedAt:     &time.Time{},
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -873,7 +1238,7 @@ func TestCancelEvent_WithoutReason(t *testing.T) {
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
-	if err := eventRepo.Insert(testEvent); err != nil {
+	if err := eventRepo.Insert(t.Context(), testEvent); err != nil {
 		t.Fatalf("failed to insert event: %v", err)
 	}
 
@@ -926,7 +1291,7 @@ func TestCancelEvent_Unauthorized(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		CreatedAt:     &time.Time{},
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -942,7 +1307,7 @@ func TestCancelEvent_Unauthorized(t *testing.T) {
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
-	if err := eventRepo.Insert(testEvent); err != nil {
+	if err := eventRepo.Insert(t.Context(), testEvent); err != nil {
 		t.Fatalf("failed to insert event: %v", err)
 	}
 
@@ -992,7 +1357,7 @@ func TestCancelEvent_Idempotent(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		CreatedAt:     &time.Time{},
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -1008,7 +1373,7 @@ func TestCancelEvent_Idempotent(t *testing.T) {
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
-	if err := eventRepo.Insert(testEvent); err != nil {
+	if err := eventRepo.Insert(t.Context(), testEvent); err != nil {
 		t.Fatalf("failed to insert event: %v", err)
 	}
 
@@ -1081,7 +1446,7 @@ func TestCancelEvent_AuditLog(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		CreatedAt:     &time.Time{},
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -1097,7 +1462,7 @@ func TestCancelEvent_AuditLog(t *testing.T) {
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
-	if err := eventRepo.Insert(testEvent); err != nil {
+	if err := eventRepo.Insert(t.Context(), testEvent); err != nil {
 		t.Fatalf("failed to insert event: %v", err)
 	}
 
@@ -1121,7 +1486,7 @@ func TestCancelEvent_AuditLog(t *testing.T) {
 	}
 
 	// Verify audit log was created
-	logs, err := auditRepo.QueryByEntity("event", testEvent.ID, 0)
+	logs, err := auditRepo.QueryByEntity(ctx, "event", testEvent.ID, 0)
 	if err != nil {
 		t.Fatalf("failed to get audit logs: %v", err)
 	}
@@ -1157,7 +1522,7 @@ func TestCancelEvent_IdempotentNoAuditDuplicate(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		CreatedAt:     &time.Time{},
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -1173,7 +1538,7 @@ func TestCancelEvent_IdempotentNoAuditDuplicate(t *testing.T) {
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
-	if err := eventRepo.Insert(testEvent); err != nil {
+	if err := eventRepo.Insert(t.Context(), testEvent); err != nil {
 		t.Fatalf("failed to insert event: %v", err)
 	}
 
@@ -1211,7 +1576,7 @@ func TestCancelEvent_IdempotentNoAuditDuplicate(t *testing.T) {
 	}
 
 	// Verify only one audit log was created
-	logs, err := auditRepo.QueryByEntity("event", testEvent.ID, 0)
+	logs, err := auditRepo.QueryByEntity(ctx, "event", testEvent.ID, 0)
 	if err != nil {
 		t.Fatalf("failed to get audit logs: %v", err)
 	}
@@ -1221,3 +1586,1863 @@ func TestCancelEvent_IdempotentNoAuditDuplicate(t *testing.T) {
 	}
 }
 
+// TestCreateEvent_LocationJitter tests that the coarse geohash is derived from a
+// jittered precise point when jitter is enabled.
+func TestCreateEvent_LocationJitter(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, auditRepo, rsvpRepo, streamRepo)
+	handlers.SetLocationJitter(500)
+
+	testScene := &scene.Scene{
+		ID:            uuid.New().String(),
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+	}
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	reqBody := CreateEventRequest{
+		SceneID:       testScene.ID,
+		Title:         "Jittered Event",
+		AllowPrecise:  false,
+		PrecisePoint:  &scene.Point{Lat: 40.7128, Lng: -74.0060},
+		CoarseGeohash: "zzzzzz",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/events", bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+
+	handlers.CreateEvent(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var createdEvent scene.Event
+	if err := json.NewDecoder(w.Body).Decode(&createdEvent); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if createdEvent.CoarseGeohash[:4] != "dr5r" {
+		t.Errorf("expected coarse_geohash near dr5r, got %q", createdEvent.CoarseGeohash)
+	}
+	// Consent is still enforced on the precise point
+	if createdEvent.PrecisePoint != nil {
+		t.Error("expected precise_point to be cleared without consent")
+	}
+}
+
+// TestCreateEvent_PrecisePointOutOfRange tests that events with out-of-range
+// precise points are refused on create and update.
+func TestCreateEvent_PrecisePointOutOfRange(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, auditRepo, rsvpRepo, streamRepo)
+
+	testScene := &scene.Scene{
+		ID:            uuid.New().String(),
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+	}
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+	existingEvent := &scene.Event{
+		ID:            uuid.New().String(),
+		SceneID:       testScene.ID,
+		Title:         "Existing Event",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+	}
+	if err := eventRepo.Insert(t.Context(), existingEvent); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	for _, point := range []scene.Point{
+		{Lat: 999, Lng: -74.0060},
+		{Lat: 40.7128, Lng: -400},
+	} {
+		t.Run(fmt.Sprintf("create %v,%v", point.Lat, point.Lng), func(t *testing.T) {
+			body, _ := json.Marshal(CreateEventRequest{
+				SceneID:       testScene.ID,
+				Title:         "Nowhere Event",
+				AllowPrecise:  true,
+				PrecisePoint:  &point,
+				CoarseGeohash: "dr5reg",
+				StartsAt:      time.Now().Add(24 * time.Hour),
+			})
+			req := httptest.NewRequest(http.MethodPost, "/events", bytes.NewReader(body))
+			req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+			w := httptest.NewRecorder()
+
+			handlers.CreateEvent(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != ErrCodeValidation {
+				t.Errorf("expected error code %s, got %s", ErrCodeValidation, errResp.Error.Code)
+			}
+		})
+
+		t.Run(fmt.Sprintf("update %v,%v", point.Lat, point.Lng), func(t *testing.T) {
+			body, _ := json.Marshal(UpdateEventRequest{PrecisePoint: &point})
+			req := httptest.NewRequest(http.MethodPatch, "/events/"+existingEvent.ID, bytes.NewReader(body))
+			req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+			w := httptest.NewRecorder()
+
+			handlers.UpdateEvent(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestCreateEvent_CoarseGeohashFromPrecisePoint tests that a supplied precise
+// point determines the stored coarse geohash, overriding the client's value.
+func TestCreateEvent_CoarseGeohashFromPrecisePoint(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, auditRepo, rsvpRepo, streamRepo)
+
+	testScene := &scene.Scene{
+		ID:            uuid.New().String(),
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+	}
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	reqBody := CreateEventRequest{
+		SceneID:       testScene.ID,
+		Title:         "Located Event",
+		AllowPrecise:  true,
+		PrecisePoint:  &scene.Point{Lat: 40.7128, Lng: -74.0060}, // New York
+		CoarseGeohash: "gcpvj0",                                  // London
+		StartsAt:      time.Now().Add(24 * time.Hour),
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/events", bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+
+	handlers.CreateEvent(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var createdEvent scene.Event
+	if err := json.NewDecoder(w.Body).Decode(&createdEvent); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if createdEvent.CoarseGeohash != "dr5reg" {
+		t.Errorf("expected coarse_geohash dr5reg derived from precise point, got %q", createdEvent.CoarseGeohash)
+	}
+}
+
+// TestUpdateEvent_PrecisePointRecomputesCoarseGeohash tests that moving an
+// event's precise point also moves its public cell and ignores the client's.
+func TestUpdateEvent_PrecisePointRecomputesCoarseGeohash(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, auditRepo, rsvpRepo, streamRepo)
+
+	testScene := &scene.Scene{
+		ID:            uuid.New().String(),
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+	}
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	existingEvent := &scene.Event{
+		ID:            uuid.New().String(),
+		SceneID:       testScene.ID,
+		Title:         "Located Event",
+		AllowPrecise:  true,
+		PrecisePoint:  &scene.Point{Lat: 40.7128, Lng: -74.0060}, // New York
+		CoarseGeohash: "dr5reg",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+	}
+	if err := eventRepo.Insert(t.Context(), existingEvent); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	coarseGeohash := "dr5reg"
+	body, err := json.Marshal(UpdateEventRequest{
+		PrecisePoint:  &scene.Point{Lat: 51.5074, Lng: -0.1278}, // London
+		CoarseGeohash: &coarseGeohash,
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPatch, "/events/"+existingEvent.ID, bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+
+	handlers.UpdateEvent(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	stored, err := eventRepo.GetByID(t.Context(), existingEvent.ID)
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	if stored.CoarseGeohash != "gcpvj0" {
+		t.Errorf("expected coarse_geohash gcpvj0 derived from the new point, got %q", stored.CoarseGeohash)
+	}
+}
+
+// seedListEvents seeds public scene-1 with events at known offsets from now,
+// plus one event in another scene, and returns now.
+func seedListEvents(t *testing.T, env *testEnv) time.Time {
+	t.Helper()
+	env.addScene(t, &scene.Scene{ID: "scene-1", Name: "Listing Scene", Visibility: scene.VisibilityPublic})
+
+	now := time.Now().UTC().Truncate(time.Second)
+	events := []*scene.Event{
+		{ID: "past", StartsAt: now.Add(-48 * time.Hour), Status: "ended"},
+		{ID: "soon", StartsAt: now.Add(2 * time.Hour), Status: "scheduled", AllowPrecise: true, PrecisePoint: &scene.Point{Lat: 40.7128, Lng: -74.0060}},
+		{ID: "later", StartsAt: now.Add(72 * time.Hour), Status: "scheduled", AllowPrecise: false, PrecisePoint: &scene.Point{Lat: 40.7128, Lng: -74.0060}},
+		{ID: "cancelled", StartsAt: now.Add(24 * time.Hour), Status: "cancelled"},
+	}
+	for _, e := range events {
+		e.SceneID = "scene-1"
+		env.addEvent(t, e)
+	}
+	env.addEvent(t, &scene.Event{ID: "other", SceneID: "scene-2", StartsAt: now.Add(time.Hour)})
+	return now
+}
+
+// doListEventsByScene performs a ListEventsByScene request and decodes the event IDs on success.
+func doListEventsByScene(t *testing.T, handlers *EventHandlers, target, userDID string) (*httptest.ResponseRecorder, ListEventsResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.ListEventsByScene(w, req)
+
+	var resp ListEventsResponse
+	if w.Code == http.StatusOK {
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+	return w, resp
+}
+
+func eventIDs(events []*scene.Event) []string {
+	ids := make([]string, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+	}
+	return ids
+}
+
+// upcomingEventIDs returns the IDs of events from an upcoming or nearby response.
+func upcomingEventIDs(events []*EventWithRSVPCounts) []string {
+	ids := make([]string, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+	}
+	return ids
+}
+
+// TestListEventsByScene_StatusFilter tests status filtering and starts_at ordering.
+func TestListEventsByScene_StatusFilter(t *testing.T) {
+	env := newTestEnv()
+	seedListEvents(t, env)
+	handlers := env.eventHandlers()
+
+	tests := []struct {
+		name    string
+		status  string
+		wantIDs []string
+	}{
+		{"all", "", []string{"past", "soon", "cancelled", "later"}},
+		{"scheduled", "scheduled", []string{"soon", "later"}},
+		{"cancelled", "cancelled", []string{"cancelled"}},
+		{"past", "past", []string{"past"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := "/scenes/scene-1/events"
+			if tt.status != "" {
+				target += "?status=" + tt.status
+			}
+			w, resp := doListEventsByScene(t, handlers, target, "")
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			got := strings.Join(eventIDs(resp.Events), ",")
+			want := strings.Join(tt.wantIDs, ",")
+			if got != want {
+				t.Errorf("expected events [%s], got [%s]", want, got)
+			}
+		})
+	}
+}
+
+// TestListEventsByScene_WindowFilter tests from/to filtering on starts_at.
+func TestListEventsByScene_WindowFilter(t *testing.T) {
+	env := newTestEnv()
+	now := seedListEvents(t, env)
+	handlers := env.eventHandlers()
+
+	from := now.Add(time.Hour).Format(time.RFC3339)
+	to := now.Add(48 * time.Hour).Format(time.RFC3339)
+
+	tests := []struct {
+		name    string
+		query   string
+		wantIDs []string
+	}{
+		{"from and to", "?from=" + from + "&to=" + to, []string{"soon", "cancelled"}},
+		{"from only", "?from=" + from, []string{"soon", "cancelled", "later"}},
+		{"to only", "?to=" + to, []string{"past", "soon", "cancelled"}},
+		{"window with status", "?status=scheduled&from=" + from + "&to=" + to, []string{"soon"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, resp := doListEventsByScene(t, handlers, "/scenes/scene-1/events"+tt.query, "")
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			got := strings.Join(eventIDs(resp.Events), ",")
+			want := strings.Join(tt.wantIDs, ",")
+			if got != want {
+				t.Errorf("expected events [%s], got [%s]", want, got)
+			}
+		})
+	}
+}
+
+// TestListEventsByScene_InvalidParams tests query parameter validation.
+func TestListEventsByScene_InvalidParams(t *testing.T) {
+	env := newTestEnv()
+	now := seedListEvents(t, env)
+	handlers := env.eventHandlers()
+
+	tests := []struct {
+		name     string
+		query    string
+		wantCode string
+	}{
+		{"unknown status", "?status=live", ErrCodeValidation},
+		{"bad from", "?from=yesterday", ErrCodeValidation},
+		{"bad to", "?to=2024-13-01", ErrCodeValidation},
+		{"inverted window", "?from=" + now.Format(time.RFC3339) + "&to=" + now.Add(-time.Hour).Format(time.RFC3339), ErrCodeInvalidTimeRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, _ := doListEventsByScene(t, handlers, "/scenes/scene-1/events"+tt.query, "")
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != tt.wantCode {
+				t.Errorf("expected error code %s, got %s", tt.wantCode, errResp.Error.Code)
+			}
+		})
+	}
+}
+
+// TestListEventsByScene_Visibility tests that events are hidden when the caller can't see the scene.
+func TestListEventsByScene_Visibility(t *testing.T) {
+	env := newTestEnv()
+	seedListEvents(t, env)
+	handlers := env.eventHandlers()
+
+	hidden, err := env.scenes.GetByID(t.Context(), "scene-1")
+	if err != nil {
+		t.Fatalf("failed to get scene: %v", err)
+	}
+	hidden.Visibility = scene.VisibilityHidden
+	if err := env.scenes.Update(t.Context(), hidden); err != nil {
+		t.Fatalf("failed to update scene: %v", err)
+	}
+
+	w, _ := doListEventsByScene(t, handlers, "/scenes/scene-1/events", "did:plc:stranger")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for non-owner, got %d", w.Code)
+	}
+
+	w, resp := doListEventsByScene(t, handlers, "/scenes/scene-1/events", "did:plc:owner")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for owner, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(resp.Events) != 4 {
+		t.Errorf("expected 4 events for owner, got %d", len(resp.Events))
+	}
+
+	w, _ = doListEventsByScene(t, handlers, "/scenes/missing/events", "")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for missing scene, got %d", w.Code)
+	}
+}
+
+// TestListEventsByScene_PrecisePointPrivacy tests that precise points are only returned with consent.
+func TestListEventsByScene_PrecisePointPrivacy(t *testing.T) {
+	env := newTestEnv()
+	seedListEvents(t, env)
+	handlers := env.eventHandlers()
+
+	w, resp := doListEventsByScene(t, handlers, "/scenes/scene-1/events?status=scheduled", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	for _, e := range resp.Events {
+		switch e.ID {
+		case "soon":
+			if e.PrecisePoint == nil {
+				t.Errorf("expected precise point for consenting event %s", e.ID)
+			}
+		case "later":
+			if e.PrecisePoint != nil {
+				t.Errorf("expected no precise point for non-consenting event %s, got %+v", e.ID, e.PrecisePoint)
+			}
+		}
+	}
+}
+
+// TestListEventsByScene_MembersOnly tests member access when a membership repository is configured.
+func TestListEventsByScene_MembersOnly(t *testing.T) {
+	env := newTestEnv()
+	seedListEvents(t, env)
+	handlers := env.eventHandlers()
+
+	s, err := env.scenes.GetByID(t.Context(), "scene-1")
+	if err != nil {
+		t.Fatalf("failed to get scene: %v", err)
+	}
+	s.Visibility = scene.VisibilityMembersOnly
+	if err := env.scenes.Update(t.Context(), s); err != nil {
+		t.Fatalf("failed to update scene: %v", err)
+	}
+
+	// Without a membership repository only the owner can list
+	w, _ := doListEventsByScene(t, handlers, "/scenes/scene-1/events", "did:plc:member")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 without membership repository, got %d", w.Code)
+	}
+
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	if _, err := membershipRepo.Upsert(t.Context(), &membership.Membership{
+		SceneID: "scene-1",
+		UserDID: "did:plc:member",
+		Status:  "active",
+	}); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+	handlers.SetMembershipRepository(membershipRepo)
+
+	w, _ = doListEventsByScene(t, handlers, "/scenes/scene-1/events", "did:plc:member")
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200 for active member, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w, _ = doListEventsByScene(t, handlers, "/scenes/scene-1/events", "did:plc:stranger")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for non-member, got %d", w.Code)
+	}
+}
+
+// seedUpcomingEvents seeds public and hidden scenes with events at varied times and locations.
+func seedUpcomingEvents(t *testing.T, env *testEnv) {
+	t.Helper()
+	env.addScene(t, &scene.Scene{ID: "public-scene", Name: "Public", Visibility: scene.VisibilityPublic})
+	env.addScene(t, &scene.Scene{ID: "hidden-scene", Name: "Hidden", Visibility: scene.VisibilityHidden})
+
+	now := time.Now()
+	nyc := scene.Point{Lat: 40.7128, Lng: -74.0060}
+	brooklyn := scene.Point{Lat: 40.6782, Lng: -73.9442}
+	boston := scene.Point{Lat: 42.3601, Lng: -71.0589}
+	seeds := []struct {
+		id       string
+		sceneID  string
+		startsAt time.Time
+		location scene.Point
+		precise  bool
+	}{
+		{"e1", "public-scene", now.Add(1 * time.Hour), nyc, true},
+		{"e2", "hidden-scene", now.Add(2 * time.Hour), nyc, false},
+		{"e3", "public-scene", now.Add(3 * time.Hour), brooklyn, false},
+		{"e4", "hidden-scene", now.Add(4 * time.Hour), brooklyn, false},
+		{"e5", "public-scene", now.Add(5 * time.Hour), nyc, false},
+		{"e6", "public-scene", now.Add(-1 * time.Hour), nyc, false},   // already started
+		{"e7", "public-scene", now.Add(1 * time.Hour), boston, false}, // out of radius
+	}
+	for _, sd := range seeds {
+		location := sd.location
+		env.addEvent(t, &scene.Event{
+			ID:            sd.id,
+			SceneID:       sd.sceneID,
+			StartsAt:      sd.startsAt,
+			CoarseGeohash: geo.Encode(location, 7),
+			AllowPrecise:  sd.precise,
+			PrecisePoint:  &location,
+		})
+	}
+}
+
+// doListUpcoming performs a ListUpcoming request and decodes the response on success.
+func doListUpcoming(t *testing.T, handlers *EventHandlers, query string) (*httptest.ResponseRecorder, UpcomingEventsResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/events/upcoming?"+query, nil)
+	w := httptest.NewRecorder()
+	handlers.ListUpcoming(w, req)
+
+	var resp UpcomingEventsResponse
+	if w.Code == http.StatusOK {
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+	return w, resp
+}
+
+// TestListUpcoming_VisibilityAndPrivacy tests that hidden scenes are skipped and precise points require consent.
+func TestListUpcoming_VisibilityAndPrivacy(t *testing.T) {
+	env := newTestEnv()
+	seedUpcomingEvents(t, env)
+	handlers := env.eventHandlers()
+
+	w, resp := doListUpcoming(t, handlers, "lat=40.7128&lng=-74.0060&radius_m=20000")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	got := strings.Join(upcomingEventIDs(resp.Events), ",")
+	if got != "e1,e3,e5" {
+		t.Errorf("expected events [e1,e3,e5], got [%s]", got)
+	}
+	if resp.NextCursor != "" {
+		t.Errorf("expected no next cursor, got %q", resp.NextCursor)
+	}
+
+	for _, e := range resp.Events {
+		if e.ID == "e1" && e.PrecisePoint == nil {
+			t.Error("expected precise point for consenting event e1")
+		}
+		if e.ID != "e1" && e.PrecisePoint != nil {
+			t.Errorf("expected no precise point for event %s", e.ID)
+		}
+	}
+}
+
+// TestListUpcoming_Pagination tests that cursors page through visible events without gaps or duplicates.
+func TestListUpcoming_Pagination(t *testing.T) {
+	env := newTestEnv()
+	seedUpcomingEvents(t, env)
+	handlers := env.eventHandlers()
+
+	var seen []string
+	cursor := ""
+	for page := 0; page < 5; page++ {
+		query := "lat=40.7128&lng=-74.0060&radius_m=20000&limit=1"
+		if cursor != "" {
+			query += "&cursor=" + url.QueryEscape(cursor)
+		}
+		w, resp := doListUpcoming(t, handlers, query)
+		if w.Code != http.StatusOK {
+			t.Fatalf("page %d: expected status 200, got %d: %s", page, w.Code, w.Body.String())
+		}
+		seen = append(seen, upcomingEventIDs(resp.Events)...)
+		if resp.NextCursor == "" {
+			break
+		}
+		cursor = resp.NextCursor
+	}
+
+	if got := strings.Join(seen, ","); got != "e1,e3,e5" {
+		t.Errorf("expected paged events [e1,e3,e5], got [%s]", got)
+	}
+}
+
+// TestListUpcoming_InvalidParams tests query parameter validation.
+func TestListUpcoming_InvalidParams(t *testing.T) {
+	env := newTestEnv()
+	seedUpcomingEvents(t, env)
+	handlers := env.eventHandlers()
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing lat", "lng=-74&radius_m=1000"},
+		{"missing radius", "lat=40&lng=-74"},
+		{"invalid lat", "lat=abc&lng=-74&radius_m=1000"},
+		{"lat out of range", "lat=91&lng=-74&radius_m=1000"},
+		{"lng out of range", "lat=40&lng=181&radius_m=1000"},
+		{"zero radius", "lat=40&lng=-74&radius_m=0"},
+		{"radius too large", "lat=40&lng=-74&radius_m=100001"},
+		{"limit too large", "lat=40&lng=-74&radius_m=1000&limit=101"},
+		{"invalid cursor", "lat=40&lng=-74&radius_m=1000&cursor=bogus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, _ := doListUpcoming(t, handlers, tt.query)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// nearEvents holds the IDs and geometry seeded by seedNearEvents.
+type nearEvents struct {
+	sourceID       string
+	hiddenSourceID string
+	// neighborDistance is the distance between the source cell center and the
+	// center of the cell east of it, where event "neighbor" is placed.
+	neighborDistance float64
+}
+
+// seedNearEvents seeds a source event with events in the same cell, the
+// adjacent cell, a hidden scene, and far away.
+func seedNearEvents(t *testing.T, env *testEnv) nearEvents {
+	t.Helper()
+	env.addScene(t, &scene.Scene{ID: "source-scene", Name: "Source", CoarseGeohash: "dr5reg", Visibility: scene.VisibilityPublic})
+	env.addScene(t, &scene.Scene{ID: "other-scene", Name: "Other", OwnerDID: "did:plc:other", CoarseGeohash: "dr5reg", Visibility: scene.VisibilityPublic})
+	env.addScene(t, &scene.Scene{ID: "hidden-scene", Name: "Hidden", OwnerDID: "did:plc:other", CoarseGeohash: "dr5reg", Visibility: scene.VisibilityHidden})
+
+	sourceCell := "dr5reg"
+	eastCell := geo.Neighbors(sourceCell)[2]
+	sourceCenter, _ := geo.Decode(sourceCell)
+	eastCenter, _ := geo.Decode(eastCell)
+
+	seeded := nearEvents{
+		sourceID:         uuid.New().String(),
+		hiddenSourceID:   uuid.New().String(),
+		neighborDistance: geo.DistanceMeters(sourceCenter, eastCenter),
+	}
+
+	now := time.Now()
+	precise := scene.Point{Lat: sourceCenter.Lat, Lng: sourceCenter.Lng}
+	seeds := []struct {
+		id       string
+		sceneID  string
+		startsAt time.Time
+		geohash  string
+	}{
+		{seeded.sourceID, "source-scene", now.Add(1 * time.Hour), sourceCell},
+		{seeded.hiddenSourceID, "hidden-scene", now.Add(1 * time.Hour), sourceCell},
+		{"same-scene", "source-scene", now.Add(2 * time.Hour), sourceCell},
+		{"same-cell", "other-scene", now.Add(3 * time.Hour), sourceCell},
+		{"neighbor", "other-scene", now.Add(4 * time.Hour), eastCell},
+		{"hidden", "hidden-scene", now.Add(5 * time.Hour), sourceCell},
+		{"past", "other-scene", now.Add(-1 * time.Hour), sourceCell},
+		{"far", "other-scene", now.Add(6 * time.Hour), geo.Encode(geo.Point{Lat: 42.3601, Lng: -71.0589}, 6)},
+	}
+	for _, sd := range seeds {
+		point := precise
+		env.addEvent(t, &scene.Event{
+			ID:            sd.id,
+			SceneID:       sd.sceneID,
+			StartsAt:      sd.startsAt,
+			CoarseGeohash: sd.geohash,
+			PrecisePoint:  &point,
+		})
+	}
+	return seeded
+}
+
+// doListNearEvent performs a ListNearEvent request and decodes the response on success.
+func doListNearEvent(t *testing.T, handlers *EventHandlers, eventID, query string) (*httptest.ResponseRecorder, UpcomingEventsResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/events/"+eventID+"/nearby?"+query, nil)
+	w := httptest.NewRecorder()
+	handlers.ListNearEvent(w, req)
+
+	var resp UpcomingEventsResponse
+	if w.Code == http.StatusOK {
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+	return w, resp
+}
+
+// TestListNearEvent_VisibilityAndPrivacy tests that the source event, hidden
+// scenes, past events, and far events are excluded and precise points are stripped.
+func TestListNearEvent_VisibilityAndPrivacy(t *testing.T) {
+	env := newTestEnv()
+	near := seedNearEvents(t, env)
+	handlers := env.eventHandlers()
+
+	w, resp := doListNearEvent(t, handlers, near.sourceID, "radius_m=5000")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := strings.Join(upcomingEventIDs(resp.Events), ","); got != "same-scene,same-cell,neighbor" {
+		t.Errorf("expected events [same-scene,same-cell,neighbor], got [%s]", got)
+	}
+	for _, e := range resp.Events {
+		if e.PrecisePoint != nil {
+			t.Errorf("expected no precise point for non-consenting event %s", e.ID)
+		}
+	}
+}
+
+// TestListNearEvent_ExcludeSameScene tests that exclude_same_scene drops the
+// source scene's other events.
+func TestListNearEvent_ExcludeSameScene(t *testing.T) {
+	env := newTestEnv()
+	near := seedNearEvents(t, env)
+	handlers := env.eventHandlers()
+
+	w, resp := doListNearEvent(t, handlers, near.sourceID, "radius_m=5000&exclude_same_scene=true")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := strings.Join(upcomingEventIDs(resp.Events), ","); got != "same-cell,neighbor" {
+		t.Errorf("expected events [same-cell,neighbor], got [%s]", got)
+	}
+}
+
+// TestListNearEvent_RadiusBoundary tests that an event exactly radius_m away
+// is included and one just beyond it is not.
+func TestListNearEvent_RadiusBoundary(t *testing.T) {
+	env := newTestEnv()
+	near := seedNearEvents(t, env)
+	handlers := env.eventHandlers()
+
+	tests := []struct {
+		name   string
+		radius float64
+		want   string
+	}{
+		{"exactly at radius", near.neighborDistance, "same-scene,same-cell,neighbor"},
+		{"just short of neighbor", near.neighborDistance - 1, "same-scene,same-cell"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := "radius_m=" + strconv.FormatFloat(tt.radius, 'f', -1, 64)
+			w, resp := doListNearEvent(t, handlers, near.sourceID, query)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if got := strings.Join(upcomingEventIDs(resp.Events), ","); got != tt.want {
+				t.Errorf("expected events [%s], got [%s]", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestListNearEvent_SourceNotVisible tests that hidden and missing source
+// events return the same 404.
+func TestListNearEvent_SourceNotVisible(t *testing.T) {
+	env := newTestEnv()
+	near := seedNearEvents(t, env)
+	handlers := env.eventHandlers()
+
+	for _, id := range []string{near.hiddenSourceID, uuid.New().String()} {
+		w, _ := doListNearEvent(t, handlers, id, "radius_m=5000")
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404 for %s, got %d: %s", id, w.Code, w.Body.String())
+		}
+	}
+}
+
+// TestListNearEvent_InvalidParams tests query parameter validation.
+func TestListNearEvent_InvalidParams(t *testing.T) {
+	env := newTestEnv()
+	near := seedNearEvents(t, env)
+	handlers := env.eventHandlers()
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing radius", ""},
+		{"zero radius", "radius_m=0"},
+		{"radius too large", "radius_m=100001"},
+		{"invalid exclude_same_scene", "radius_m=1000&exclude_same_scene=maybe"},
+		{"limit too large", "radius_m=1000&limit=101"},
+		{"invalid cursor", "radius_m=1000&cursor=bogus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, _ := doListNearEvent(t, handlers, near.sourceID, tt.query)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestCancelEvent_PastEvent tests that events which have already started can't be cancelled.
+func TestCancelEvent_PastEvent(t *testing.T) {
+	env := newTestEnv()
+	testScene := env.addScene(t, &scene.Scene{OwnerDID: "did:plc:test123"})
+	eventID := env.addEvent(t, &scene.Event{SceneID: testScene.ID, Title: "Test Event", StartsAt: time.Now().Add(-2 * time.Hour), Status: "scheduled"}).ID
+	handlers := env.eventHandlers()
+
+	req := httptest.NewRequest(http.MethodPost, "/events/"+eventID+"/cancel", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+
+	handlers.CancelEvent(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeValidation {
+		t.Errorf("expected error code %s, got %s", ErrCodeValidation, errResp.Error.Code)
+	}
+
+	stored, err := env.events.GetByID(t.Context(), eventID)
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	if stored.Status == "cancelled" {
+		t.Error("expected past event to remain uncancelled")
+	}
+
+	logs, err := env.audit.QueryByEntity(t.Context(), "event", eventID, 0)
+	if err != nil {
+		t.Fatalf("failed to get audit logs: %v", err)
+	}
+	if len(logs) != 0 {
+		t.Errorf("expected no audit log entries, got %d", len(logs))
+	}
+}
+
+// TestCancelEvent_PreservesRSVPs tests that cancelling keeps existing RSVPs and blocks new ones.
+func TestCancelEvent_PreservesRSVPs(t *testing.T) {
+	env := newTestEnv()
+	testScene := env.addScene(t, &scene.Scene{OwnerDID: "did:plc:test123"})
+	eventID := env.addEvent(t, &scene.Event{SceneID: testScene.ID, Title: "Test Event", StartsAt: time.Now().Add(24 * time.Hour), Status: "scheduled"}).ID
+	handlers := env.eventHandlers()
+
+	for _, did := range []string{"did:plc:attendee1", "did:plc:attendee2"} {
+		env.addRSVP(t, eventID, did, "going")
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/events/"+eventID+"/cancel", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+
+	handlers.CancelEvent(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	counts, err := env.rsvps.GetCountsByEvent(t.Context(), eventID)
+	if err != nil {
+		t.Fatalf("failed to get RSVP counts: %v", err)
+	}
+	if counts.Going != 2 {
+		t.Errorf("expected 2 preserved RSVPs, got %d", counts.Going)
+	}
+
+	// New RSVPs on the cancelled event are rejected
+	rsvpHandlers := env.rsvpHandlers()
+	body, _ := json.Marshal(RSVPRequest{Status: "going"})
+	rsvpReq := httptest.NewRequest(http.MethodPost, "/events/"+eventID+"/rsvp", bytes.NewReader(body))
+	rsvpReq = rsvpReq.WithContext(middleware.SetUserDID(rsvpReq.Context(), "did:plc:latecomer"))
+	rsvpW := httptest.NewRecorder()
+
+	rsvpHandlers.CreateOrUpdateRSVP(rsvpW, rsvpReq)
+
+	if rsvpW.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d: %s", rsvpW.Code, rsvpW.Body.String())
+	}
+	var errResp ErrorResponse
+	if err := json.NewDecoder(rsvpW.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if !strings.Contains(errResp.Error.Message, "cancelled") {
+		t.Errorf("expected error message to mention cancellation, got %q", errResp.Error.Message)
+	}
+}
+
+// createSeries posts a recurring CreateEvent request and returns the recorder.
+func createSeries(t *testing.T, handlers *EventHandlers, reqBody CreateEventRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/events", bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+	handlers.CreateEvent(w, req)
+	return w
+}
+
+// TestCreateEvent_WeeklyRecurrence tests that a weekly recurrence with count 4 creates four linked events.
+func TestCreateEvent_WeeklyRecurrence(t *testing.T) {
+	env := newTestEnv()
+	sceneID := env.addScene(t, &scene.Scene{Name: "Weekly Scene", OwnerDID: "did:plc:test123"}).ID
+	handlers := env.eventHandlers()
+
+	startsAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	endsAt := startsAt.Add(4 * time.Hour)
+	w := createSeries(t, handlers, CreateEventRequest{
+		SceneID:       sceneID,
+		Title:         "Weekly Night",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      startsAt,
+		EndsAt:        &endsAt,
+		Recurrence:    &scene.Recurrence{Freq: scene.RecurrenceWeekly, Count: 4},
+	})
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp EventSeriesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.SeriesID == "" {
+		t.Fatal("expected series_id to be set")
+	}
+	if len(resp.Events) != 4 {
+		t.Fatalf("expected 4 events, got %d", len(resp.Events))
+	}
+
+	seenIDs := make(map[string]bool)
+	for i, e := range resp.Events {
+		wantStart := startsAt.AddDate(0, 0, 7*i)
+		if !e.StartsAt.Equal(wantStart) {
+			t.Errorf("event %d: starts_at = %v, want %v", i, e.StartsAt, wantStart)
+		}
+		if e.EndsAt == nil || !e.EndsAt.Equal(wantStart.Add(4*time.Hour)) {
+			t.Errorf("event %d: ends_at = %v, want %v", i, e.EndsAt, wantStart.Add(4*time.Hour))
+		}
+		if e.SeriesID == nil || *e.SeriesID != resp.SeriesID {
+			t.Errorf("event %d: series_id = %v, want %s", i, e.SeriesID, resp.SeriesID)
+		}
+		if seenIDs[e.ID] {
+			t.Errorf("event %d: duplicate event ID %s", i, e.ID)
+		}
+		seenIDs[e.ID] = true
+	}
+
+	stored, err := env.events.ListBySeries(t.Context(), resp.SeriesID)
+	if err != nil {
+		t.Fatalf("ListBySeries() error = %v", err)
+	}
+	if len(stored) != 4 {
+		t.Errorf("expected 4 stored events, got %d", len(stored))
+	}
+}
+
+// TestCreateEvent_RecurrenceValidation tests rejection of invalid recurrence rules.
+func TestCreateEvent_RecurrenceValidation(t *testing.T) {
+	env := newTestEnv()
+	sceneID := env.addScene(t, &scene.Scene{Name: "Weekly Scene", OwnerDID: "did:plc:test123"}).ID
+	handlers := env.eventHandlers()
+	// Overlapping weekly occurrences need events longer than the default cap
+	handlers.SetMaxEventDuration(0)
+
+	future := time.Now().Add(24 * time.Hour)
+	past := time.Now().Add(-24 * time.Hour)
+	longEnd := future.Add(8 * 24 * time.Hour)
+
+	tests := []struct {
+		name       string
+		startsAt   time.Time
+		endsAt     *time.Time
+		recurrence scene.Recurrence
+	}{
+		{"exceeds cap", future, nil, scene.Recurrence{Freq: scene.RecurrenceWeekly, Count: 53}},
+		{"unknown freq", future, nil, scene.Recurrence{Freq: "daily", Count: 4}},
+		{"past start", past, nil, scene.Recurrence{Freq: scene.RecurrenceWeekly, Count: 4}},
+		{"overlapping occurrences", future, &longEnd, scene.Recurrence{Freq: scene.RecurrenceWeekly, Count: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := tt.recurrence
+			w := createSeries(t, handlers, CreateEventRequest{
+				SceneID:       sceneID,
+				Title:         "Weekly Night",
+				CoarseGeohash: "dr5regw",
+				StartsAt:      tt.startsAt,
+				EndsAt:        tt.endsAt,
+				Recurrence:    &rec,
+			})
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != ErrCodeValidation {
+				t.Errorf("expected error code %s, got %s", ErrCodeValidation, errResp.Error.Code)
+			}
+		})
+	}
+}
+
+// TestCreateEvent_RecurrencePastStartAheadLimit tests that a series is
+// rejected when a later occurrence would start past MaxEventStartAhead, even
+// though the first one is within it.
+func TestCreateEvent_RecurrencePastStartAheadLimit(t *testing.T) {
+	env := newTestEnv()
+	sceneID := env.addScene(t, &scene.Scene{Name: "Weekly Scene", OwnerDID: "did:plc:test123"}).ID
+	handlers := env.eventHandlers()
+
+	tests := []struct {
+		name       string
+		startsAt   time.Time
+		recurrence scene.Recurrence
+	}{
+		{"monthly count crosses limit", time.Now().Add(24 * time.Hour), scene.Recurrence{Freq: scene.RecurrenceMonthly, Count: 52}},
+		{"last weekly occurrence crosses limit", time.Now().Add(MaxEventStartAhead - 7*24*time.Hour), scene.Recurrence{Freq: scene.RecurrenceWeekly, Count: 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := tt.recurrence
+			w := createSeries(t, handlers, CreateEventRequest{
+				SceneID:       sceneID,
+				Title:         "Far Future Night",
+				CoarseGeohash: "dr5regw",
+				StartsAt:      tt.startsAt,
+				Recurrence:    &rec,
+			})
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != ErrCodeInvalidTimeRange {
+				t.Errorf("expected error code %s, got %s", ErrCodeInvalidTimeRange, errResp.Error.Code)
+			}
+		})
+	}
+
+	events, err := env.events.ListBySceneID(t.Context(), sceneID, scene.EventFilter{})
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("expected no events to be created, got %d", len(events))
+	}
+}
+
+// TestCancelSeries tests cancelling every upcoming occurrence of a series.
+func TestCancelSeries(t *testing.T) {
+	env := newTestEnv()
+	sceneID := env.addScene(t, &scene.Scene{Name: "Weekly Scene", OwnerDID: "did:plc:test123"}).ID
+	handlers := env.eventHandlers()
+
+	w := createSeries(t, handlers, CreateEventRequest{
+		SceneID:       sceneID,
+		Title:         "Weekly Night",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+		Recurrence:    &scene.Recurrence{Freq: scene.RecurrenceWeekly, Count: 3},
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("failed to create series: %d: %s", w.Code, w.Body.String())
+	}
+	var created EventSeriesResponse
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	cancel := func(did string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/events/series/"+created.SeriesID+"/cancel", strings.NewReader(`{"reason":"Venue closed"}`))
+		req = req.WithContext(middleware.SetUserDID(req.Context(), did))
+		w := httptest.NewRecorder()
+		handlers.CancelSeries(w, req)
+		return w
+	}
+
+	// Non-owner is rejected
+	if w := cancel("did:plc:stranger"); w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for non-owner, got %d", w.Code)
+	}
+
+	w = cancel("did:plc:test123")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp EventSeriesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(resp.Events))
+	}
+	for _, e := range resp.Events {
+		if e.Status != "cancelled" {
+			t.Errorf("event %s: expected status cancelled, got %s", e.ID, e.Status)
+		}
+		if e.CancellationReason == nil || *e.CancellationReason != "Venue closed" {
+			t.Errorf("event %s: expected cancellation reason, got %v", e.ID, e.CancellationReason)
+		}
+
+		logs, err := env.audit.QueryByEntity(t.Context(), "event", e.ID, 0)
+		if err != nil {
+			t.Fatalf("failed to get audit logs: %v", err)
+		}
+		if len(logs) != 1 {
+			t.Errorf("event %s: expected 1 audit log entry, got %d", e.ID, len(logs))
+		}
+	}
+
+	// Events are cancelled, not deleted
+	stored, err := env.events.ListBySeries(t.Context(), created.SeriesID)
+	if err != nil {
+		t.Fatalf("ListBySeries() error = %v", err)
+	}
+	if len(stored) != 3 {
+		t.Errorf("expected 3 stored events, got %d", len(stored))
+	}
+
+	// Unknown series
+	req := httptest.NewRequest(http.MethodPost, "/events/series/missing/cancel", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	missing := httptest.NewRecorder()
+	handlers.CancelSeries(missing, req)
+	if missing.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown series, got %d", missing.Code)
+	}
+}
+
+// seedAlliedScenes seeds two members-only scenes plus a hidden scene, each
+// with an event, with did:plc:cross an active member of scene-a only and
+// did:plc:member-b an active member of scene-b.
+func seedAlliedScenes(t *testing.T, env *testEnv) {
+	t.Helper()
+	env.addScene(t, &scene.Scene{ID: "scene-a", Name: "Scene A", OwnerDID: "did:plc:owner-a", Visibility: scene.VisibilityMembersOnly})
+	env.addScene(t, &scene.Scene{ID: "scene-b", Name: "Scene B", OwnerDID: "did:plc:owner-b", Visibility: scene.VisibilityMembersOnly})
+	env.addScene(t, &scene.Scene{ID: "scene-h", Name: "Scene H", OwnerDID: "did:plc:owner-b", Visibility: scene.VisibilityHidden})
+
+	for _, e := range []*scene.Event{
+		{ID: "b-event", SceneID: "scene-b"},
+		{ID: "h-event", SceneID: "scene-h"},
+	} {
+		e.StartsAt = time.Now().Add(24 * time.Hour)
+		e.AllowPrecise = true
+		e.PrecisePoint = &scene.Point{Lat: 40.7128, Lng: -74.0060}
+		env.addEvent(t, e)
+	}
+
+	env.addMember(t, "scene-a", "did:plc:cross", "member", "active")
+	env.addMember(t, "scene-b", "did:plc:member-b", "member", "active")
+}
+
+// ally proposes and accepts an alliance between two scenes.
+func ally(t *testing.T, repo *alliance.InMemoryAllianceRepository, fromSceneID, toSceneID string) {
+	t.Helper()
+	proposal, err := repo.Propose(fromSceneID, toSceneID)
+	if err != nil {
+		t.Fatalf("failed to propose alliance: %v", err)
+	}
+	if _, err := repo.Accept(proposal.ID); err != nil {
+		t.Fatalf("failed to accept alliance: %v", err)
+	}
+}
+
+// TestListEventsByScene_AlliedSceneMember tests that members of an allied scene can see
+// members-only events without precise points.
+func TestListEventsByScene_AlliedSceneMember(t *testing.T) {
+	env := newTestEnv()
+	seedAlliedScenes(t, env)
+	allianceRepo := alliance.NewInMemoryAllianceRepository()
+	handlers := env.eventHandlers()
+	handlers.SetMembershipRepository(env.memberships)
+	handlers.SetAllianceRepository(allianceRepo)
+
+	// Not yet allied: cross-scene member is treated as a non-member
+	w, _ := doListEventsByScene(t, handlers, "/scenes/scene-b/events", "did:plc:cross")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 before alliance, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Pending proposals grant nothing
+	proposal, err := allianceRepo.Propose("scene-a", "scene-b")
+	if err != nil {
+		t.Fatalf("failed to propose alliance: %v", err)
+	}
+	w, _ = doListEventsByScene(t, handlers, "/scenes/scene-b/events", "did:plc:cross")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 with pending alliance, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if _, err := allianceRepo.Accept(proposal.ID); err != nil {
+		t.Fatalf("failed to accept alliance: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		userDID     string
+		wantStatus  int
+		wantPrecise bool
+	}{
+		{"allied member sees events without precise point", "did:plc:cross", http.StatusOK, false},
+		{"allied scene owner sees events without precise point", "did:plc:owner-a", http.StatusOK, false},
+		{"direct member keeps precise point", "did:plc:member-b", http.StatusOK, true},
+		{"unrelated user", "did:plc:stranger", http.StatusNotFound, false},
+		{"anonymous", "", http.StatusNotFound, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, resp := doListEventsByScene(t, handlers, "/scenes/scene-b/events", tt.userDID)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if len(resp.Events) != 1 || resp.Events[0].ID != "b-event" {
+				t.Fatalf("expected [b-event], got %v", eventIDs(resp.Events))
+			}
+			if got := resp.Events[0].PrecisePoint != nil; got != tt.wantPrecise {
+				t.Errorf("expected precise point present=%v, got %v", tt.wantPrecise, got)
+			}
+		})
+	}
+}
+
+// TestListEventsByScene_AllianceDoesNotRevealHidden tests that hidden scenes stay hidden and
+// banned users stay out even when allied.
+func TestListEventsByScene_AllianceDoesNotRevealHidden(t *testing.T) {
+	env := newTestEnv()
+	seedAlliedScenes(t, env)
+	allianceRepo := alliance.NewInMemoryAllianceRepository()
+	handlers := env.eventHandlers()
+	handlers.SetMembershipRepository(env.memberships)
+	handlers.SetAllianceRepository(allianceRepo)
+	ally(t, allianceRepo, "scene-a", "scene-b")
+	ally(t, allianceRepo, "scene-a", "scene-h")
+
+	w, _ := doListEventsByScene(t, handlers, "/scenes/scene-h/events", "did:plc:cross")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected hidden scene to return 404 despite alliance, got %d: %s", w.Code, w.Body.String())
+	}
+
+	env.addMember(t, "scene-b", "did:plc:cross", "member", "banned")
+	w, _ = doListEventsByScene(t, handlers, "/scenes/scene-b/events", "did:plc:cross")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected banned user to get 404 despite alliance, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// TestListUpcoming_AlliedSceneMember tests that upcoming discovery includes allied members-only
+// events without precise points and still skips hidden scenes.
+func TestListUpcoming_AlliedSceneMember(t *testing.T) {
+	env := newTestEnv()
+	seedAlliedScenes(t, env)
+	allianceRepo := alliance.NewInMemoryAllianceRepository()
+	handlers := env.eventHandlers()
+	handlers.SetMembershipRepository(env.memberships)
+	handlers.SetAllianceRepository(allianceRepo)
+	ally(t, allianceRepo, "scene-b", "scene-a")
+	ally(t, allianceRepo, "scene-h", "scene-a")
+
+	req := httptest.NewRequest(http.MethodGet, "/events/upcoming?lat=40.7128&lng=-74.0060&radius_m=20000", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:cross"))
+	w := httptest.NewRecorder()
+	handlers.ListUpcoming(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp UpcomingEventsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got := strings.Join(upcomingEventIDs(resp.Events), ","); got != "b-event" {
+		t.Fatalf("expected [b-event], got [%s]", got)
+	}
+	if resp.Events[0].PrecisePoint != nil {
+		t.Error("expected precise point to be withheld from allied viewer")
+	}
+}
+
+// TestListUpcoming_RSVPCounts tests that upcoming events carry batch-fetched
+// RSVP counts, including zero counts for events without RSVPs.
+func TestListUpcoming_RSVPCounts(t *testing.T) {
+	env := newTestEnv()
+	seedUpcomingEvents(t, env)
+	handlers := env.eventHandlers()
+	for _, user := range []string{"did:plc:a", "did:plc:b"} {
+		env.addRSVP(t, "e1", user, "going")
+	}
+
+	w, resp := doListUpcoming(t, handlers, "lat=40.7128&lng=-74.0060&radius_m=20000")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	for _, e := range resp.Events {
+		if e.RSVPCounts == nil {
+			t.Fatalf("expected rsvp_counts for event %s", e.ID)
+		}
+		wantGoing := 0
+		if e.ID == "e1" {
+			wantGoing = 2
+		}
+		if e.RSVPCounts.Going != wantGoing {
+			t.Errorf("event %s: expected going=%d, got %d", e.ID, wantGoing, e.RSVPCounts.Going)
+		}
+	}
+}
+
+// TestListUpcoming_RSVPCountsMembersOnly tests that allied viewers of a
+// members-only scene see its events without RSVP counts, while members see them.
+func TestListUpcoming_RSVPCountsMembersOnly(t *testing.T) {
+	env := newTestEnv()
+	seedAlliedScenes(t, env)
+	allianceRepo := alliance.NewInMemoryAllianceRepository()
+	handlers := env.eventHandlers()
+	handlers.SetMembershipRepository(env.memberships)
+	handlers.SetAllianceRepository(allianceRepo)
+	ally(t, allianceRepo, "scene-b", "scene-a")
+	env.addRSVP(t, "b-event", "did:plc:member-b", "going")
+
+	tests := []struct {
+		userDID    string
+		wantCounts bool
+	}{
+		{"did:plc:cross", false},
+		{"did:plc:member-b", true},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/events/upcoming?lat=40.7128&lng=-74.0060&radius_m=20000", nil)
+		req = req.WithContext(middleware.SetUserDID(req.Context(), tt.userDID))
+		w := httptest.NewRecorder()
+		handlers.ListUpcoming(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d: %s", tt.userDID, w.Code, w.Body.String())
+		}
+
+		var resp UpcomingEventsResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp.Events) != 1 {
+			t.Fatalf("%s: expected [b-event], got %v", tt.userDID, upcomingEventIDs(resp.Events))
+		}
+		if got := resp.Events[0].RSVPCounts != nil; got != tt.wantCounts {
+			t.Errorf("%s: expected counts shown=%v, got %+v", tt.userDID, tt.wantCounts, resp.Events[0].RSVPCounts)
+		}
+	}
+}
+
+// TestCreateEvent_IndexesForSearch tests that created events are searchable
+// while their scene is public.
+func TestCreateEvent_IndexesForSearch(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository())
+	index := search.NewIndexer()
+	handlers.SetSearchIndex(index)
+
+	testScene := &scene.Scene{
+		ID:            "search-scene",
+		Name:          "Search Scene",
+		OwnerDID:      "did:plc:owner",
+		CoarseGeohash: "dr5regw",
+		Visibility:    scene.VisibilityPublic,
+	}
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+	index.IndexScene(testScene)
+
+	body, _ := json.Marshal(CreateEventRequest{
+		SceneID:       testScene.ID,
+		Title:         "Vinyl Swap Night",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+	})
+	req := httptest.NewRequest(http.MethodPost, "/events", bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner"))
+	w := httptest.NewRecorder()
+	handlers.CreateEvent(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created scene.Event
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	results, _ := index.Search(context.Background(), "vinyl swap", 0)
+	if len(results) != 1 || results[0].Type != search.TypeEvent || results[0].ID != created.ID {
+		t.Errorf("expected created event to be searchable, got %v", results)
+	}
+}
+
+// TestCreateEvent_IdempotencyKey tests that a retried create with the same
+// Idempotency-Key replays the original response instead of creating a duplicate.
+func TestCreateEvent_IdempotencyKey(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository())
+	handler := middleware.Idempotency(middleware.NewInMemoryIdempotencyStore(), middleware.DefaultIdempotencyTTL)(http.HandlerFunc(handlers.CreateEvent))
+
+	testScene := &scene.Scene{
+		ID:            uuid.New().String(),
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5reg",
+		Visibility:    scene.VisibilityPublic,
+	}
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	body, err := json.Marshal(CreateEventRequest{
+		SceneID:       testScene.ID,
+		Title:         "Retry Event",
+		CoarseGeohash: "dr5reg",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	var responses []*httptest.ResponseRecorder
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/events", bytes.NewReader(body))
+		req.Header.Set(middleware.IdempotencyKeyHeader, "create-event-1")
+		req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("request %d: expected status 201, got %d: %s", i+1, w.Code, w.Body.String())
+		}
+		responses = append(responses, w)
+	}
+
+	if responses[0].Body.String() != responses[1].Body.String() {
+		t.Errorf("expected identical responses, got %q and %q", responses[0].Body.String(), responses[1].Body.String())
+	}
+	if responses[1].Header().Get(middleware.IdempotentReplayedHeader) != "true" {
+		t.Error("expected retried response to be marked as replayed")
+	}
+
+	events, err := eventRepo.ListBySceneID(t.Context(), testScene.ID, scene.EventFilter{})
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("expected exactly 1 event, got %d", len(events))
+	}
+}
+
+// seedRSVPCounts seeds an event in a scene with the given visibility, owned by
+// did:plc:owner with active member did:plc:member, with two going and one
+// maybe RSVP, and returns the event ID.
+func seedRSVPCounts(t *testing.T, env *testEnv, visibility string) string {
+	t.Helper()
+	testScene := env.addScene(t, &scene.Scene{Name: "Counts Scene", Visibility: visibility})
+	env.addMember(t, testScene.ID, "did:plc:member", "member", "active")
+	eventID := env.addEvent(t, &scene.Event{SceneID: testScene.ID, Title: "Counts Event", StartsAt: time.Now().Add(24 * time.Hour)}).ID
+	for user, status := range map[string]string{"did:plc:a": "going", "did:plc:b": "going", "did:plc:c": "maybe"} {
+		env.addRSVP(t, eventID, user, status)
+	}
+	return eventID
+}
+
+// getEventRSVPCounts fetches the event as viewerDID and returns its decoded
+// rsvp_counts, or nil if the field was omitted.
+func getEventRSVPCounts(t *testing.T, handlers *EventHandlers, eventID, viewerDID string) *scene.RSVPCounts {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/events/"+eventID, nil)
+	if viewerDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), viewerDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.GetEvent(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		RSVPCounts *scene.RSVPCounts `json:"rsvp_counts"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp.RSVPCounts
+}
+
+// TestGetEvent_RSVPCountsPublicScene tests that anyone sees counts for public scenes.
+func TestGetEvent_RSVPCountsPublicScene(t *testing.T) {
+	env := newTestEnv()
+	eventID := seedRSVPCounts(t, env, scene.VisibilityPublic)
+	handlers := env.eventHandlers()
+	handlers.SetMembershipRepository(env.memberships)
+
+	for _, viewer := range []string{"", "did:plc:stranger"} {
+		counts := getEventRSVPCounts(t, handlers, eventID, viewer)
+		if counts == nil {
+			t.Fatalf("viewer %q: expected rsvp_counts for a public event", viewer)
+		}
+		if counts.Going != 2 || counts.Maybe != 1 {
+			t.Errorf("viewer %q: expected going=2 maybe=1, got %+v", viewer, counts)
+		}
+	}
+}
+
+// TestGetEvent_RSVPCountsMembersOnlyScene tests that counts for members-only
+// scenes are limited to members and the owner.
+func TestGetEvent_RSVPCountsMembersOnlyScene(t *testing.T) {
+	env := newTestEnv()
+	eventID := seedRSVPCounts(t, env, scene.VisibilityMembersOnly)
+	handlers := env.eventHandlers()
+	handlers.SetMembershipRepository(env.memberships)
+
+	tests := []struct {
+		viewer    string
+		wantShown bool
+	}{
+		{"", false},
+		{"did:plc:stranger", false},
+		{"did:plc:member", true},
+		{"did:plc:owner", true},
+	}
+	for _, tt := range tests {
+		counts := getEventRSVPCounts(t, handlers, eventID, tt.viewer)
+		if (counts != nil) != tt.wantShown {
+			t.Errorf("viewer %q: expected counts shown=%v, got %+v", tt.viewer, tt.wantShown, counts)
+		}
+		if counts != nil && counts.Going != 2 {
+			t.Errorf("viewer %q: expected going=2, got %+v", tt.viewer, counts)
+		}
+	}
+}
+
+// TestGetEvent_RSVPCountsWithoutRepo tests that counts are omitted when no RSVP repo is wired.
+func TestGetEvent_RSVPCountsWithoutRepo(t *testing.T) {
+	env := newTestEnv()
+	eventID := seedRSVPCounts(t, env, scene.VisibilityPublic)
+	handlers := NewEventHandlers(env.events, env.scenes, env.audit, nil, env.streams)
+	handlers.SetMembershipRepository(env.memberships)
+
+	if counts := getEventRSVPCounts(t, handlers, eventID, "did:plc:owner"); counts != nil {
+		t.Errorf("expected rsvp_counts to be omitted, got %+v", counts)
+	}
+}
+
+// TestCreateEvent_RecordRef tests validation of the optional AT Protocol
+// record reference on event creation.
+func TestCreateEvent_RecordRef(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, auditRepo, rsvpRepo, streamRepo)
+
+	testScene := &scene.Scene{
+		ID:            uuid.New().String(),
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+	}
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	createEvent := func(reqBody CreateEventRequest) *httptest.ResponseRecorder {
+		reqBody.SceneID = testScene.ID
+		reqBody.Title = "Linked Event"
+		reqBody.CoarseGeohash = "dr5reg"
+		reqBody.StartsAt = time.Now().Add(24 * time.Hour)
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest(http.MethodPost, "/events", bytes.NewReader(body))
+		req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+		w := httptest.NewRecorder()
+		handlers.CreateEvent(w, req)
+		return w
+	}
+
+	w := createEvent(CreateEventRequest{RecordDID: ptrString("did:web:example.com"), RecordRKey: ptrString("self")})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, err := eventRepo.GetByRecordKey(t.Context(), "did:web:example.com", "self"); err != nil {
+		t.Errorf("expected created event to be found by record key, got %v", err)
+	}
+	if w := createEvent(CreateEventRequest{RecordDID: ptrString("did:web:example.com"), RecordRKey: ptrString("self")}); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for an already linked record, got %d: %s", w.Code, w.Body.String())
+	}
+
+	tests := []struct {
+		name string
+		req  CreateEventRequest
+	}{
+		{"missing rkey", CreateEventRequest{RecordDID: ptrString("did:plc:test123")}},
+		{"malformed DID", CreateEventRequest{RecordDID: ptrString("did:plc:"), RecordRKey: ptrString("self")}},
+		{"malformed rkey", CreateEventRequest{RecordDID: ptrString("did:plc:test123"), RecordRKey: ptrString("a b")}},
+		{"with recurrence", CreateEventRequest{
+			RecordDID:  ptrString("did:plc:test123"),
+			RecordRKey: ptrString("series"),
+			Recurrence: &scene.Recurrence{Freq: scene.RecurrenceWeekly, Count: 2},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := createEvent(tt.req)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != ErrCodeValidation {
+				t.Errorf("expected error code %s, got %s", ErrCodeValidation, errResp.Error.Code)
+			}
+		})
+	}
+}
+
+// TestCreateEvent_Timezone tests that event times are validated and returned in the event's time zone.
+func TestCreateEvent_Timezone(t *testing.T) {
+	env := newTestEnv()
+	sceneID := env.addScene(t, &scene.Scene{Name: "Weekly Scene", OwnerDID: "did:plc:test123"}).ID
+	handlers := env.eventHandlers()
+
+	for _, tz := range []string{"Mars/Olympus_Mons", "Local", "EST5EDT/../UTC"} {
+		t.Run("rejects "+tz, func(t *testing.T) {
+			w := createSeries(t, handlers, CreateEventRequest{
+				SceneID:       sceneID,
+				Title:         "Zoned Event",
+				CoarseGeohash: "dr5regw",
+				StartsAt:      time.Now().Add(24 * time.Hour),
+				Timezone:      tz,
+			})
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != ErrCodeValidation {
+				t.Errorf("expected error code %s, got %s", ErrCodeValidation, errResp.Error.Code)
+			}
+		})
+	}
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load zone: %v", err)
+	}
+	startsAt := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	w := createSeries(t, handlers, CreateEventRequest{
+		SceneID:       sceneID,
+		Title:         "Zoned Event",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      startsAt,
+		Timezone:      "Asia/Tokyo",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var raw map[string]any
+	if err := json.NewDecoder(w.Body).Decode(&raw); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if raw["timezone"] != "Asia/Tokyo" {
+		t.Errorf("expected timezone Asia/Tokyo, got %v", raw["timezone"])
+	}
+	if want := startsAt.In(tokyo).Format(time.RFC3339); raw["starts_at"] != want {
+		t.Errorf("expected starts_at %s with the zone's offset, got %v", want, raw["starts_at"])
+	}
+}
+
+// TestCreateEvent_RecurrenceAcrossDST tests that weekly occurrences keep their
+// local start time when the event's zone leaves daylight saving time.
+func TestCreateEvent_RecurrenceAcrossDST(t *testing.T) {
+	env := newTestEnv()
+	sceneID := env.addScene(t, &scene.Scene{Name: "Weekly Scene", OwnerDID: "did:plc:test123"}).ID
+	handlers := env.eventHandlers()
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load zone: %v", err)
+	}
+
+	// US DST ends on the first Sunday of November, within four weeks of Oct 20
+	startsAt := time.Date(time.Now().Year()+1, time.October, 20, 20, 0, 0, 0, newYork)
+	endsAt := startsAt.Add(3 * time.Hour)
+	w := createSeries(t, handlers, CreateEventRequest{
+		SceneID:       sceneID,
+		Title:         "Weekly Night",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      startsAt.UTC(),
+		EndsAt:        &endsAt,
+		Timezone:      "America/New_York",
+		Recurrence:    &scene.Recurrence{Freq: scene.RecurrenceWeekly, Count: 4},
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp EventSeriesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Events) != 4 {
+		t.Fatalf("expected 4 events, got %d", len(resp.Events))
+	}
+	for i, e := range resp.Events {
+		local := e.StartsAt.In(newYork)
+		if local.Hour() != 20 || local.Minute() != 0 {
+			t.Errorf("event %d: expected 20:00 local, got %s", i, local.Format(time.Kitchen))
+		}
+		if e.Timezone != "America/New_York" {
+			t.Errorf("event %d: expected timezone America/New_York, got %q", i, e.Timezone)
+		}
+	}
+	_, firstOffset := resp.Events[0].StartsAt.Zone()
+	_, lastOffset := resp.Events[3].StartsAt.Zone()
+	if firstOffset != -4*3600 || lastOffset != -5*3600 {
+		t.Errorf("expected offsets -04:00 then -05:00, got %d and %d", firstOffset, lastOffset)
+	}
+}
+
+// TestUpdateEvent_Timezone tests changing and validating an event's time zone.
+func TestUpdateEvent_Timezone(t *testing.T) {
+	env := newTestEnv()
+	sceneID := env.addScene(t, &scene.Scene{Name: "Weekly Scene", OwnerDID: "did:plc:test123"}).ID
+	handlers := env.eventHandlers()
+
+	startsAt := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+	existingEvent := &scene.Event{
+		ID:            uuid.New().String(),
+		SceneID:       sceneID,
+		Title:         "Zoned Event",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      startsAt,
+	}
+	if err := env.events.Insert(t.Context(), existingEvent); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	update := func(tz string) *httptest.ResponseRecorder {
+		t.Helper()
+		body, err := json.Marshal(UpdateEventRequest{Timezone: &tz})
+		if err != nil {
+			t.Fatalf("failed to marshal request: %v", err)
+		}
+		req := httptest.NewRequest(http.MethodPatch, "/events/"+existingEvent.ID, bytes.NewReader(body))
+		req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+		w := httptest.NewRecorder()
+		handlers.UpdateEvent(w, req)
+		return w
+	}
+
+	if w := update("Europe/Nowhere"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an unknown zone, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w := update("Europe/Berlin")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	stored, err := env.events.GetByID(t.Context(), existingEvent.ID)
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	if stored.Timezone != "Europe/Berlin" || stored.StartsAt.Location().String() != "Europe/Berlin" {
+		t.Errorf("expected times in Europe/Berlin, got %q / %s", stored.Timezone, stored.StartsAt.Location())
+	}
+	if !stored.StartsAt.Equal(startsAt) {
+		t.Errorf("expected the start instant to be unchanged, got %v", stored.StartsAt)
+	}
+
+	// An empty zone resets to UTC
+	if w := update(""); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if stored, _ := env.events.GetByID(t.Context(), existingEvent.ID); stored.Timezone != "" || stored.StartsAt.Location() != time.UTC {
+		t.Errorf("expected UTC after reset, got %q / %s", stored.Timezone, stored.StartsAt.Location())
+	}
+}
+
+// TestEventHasStarted_DSTFallBack tests the past-event check across the hour
+// that America/New_York repeats when daylight saving time ends.
+func TestEventHasStarted_DSTFallBack(t *testing.T) {
+	// 01:30 EDT and 01:30 EST on 2026-11-01 are an hour apart
+	firstOneThirty := time.Date(2026, time.November, 1, 5, 30, 0, 0, time.UTC)
+	secondOneThirty := time.Date(2026, time.November, 1, 6, 30, 0, 0, time.UTC)
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load zone: %v", err)
+	}
+	// 01:45 EDT: later on the wall clock than either start
+	now := time.Date(2026, time.November, 1, 5, 45, 0, 0, time.UTC).In(newYork)
+
+	if !eventHasStarted(firstOneThirty.In(newYork), now) {
+		t.Error("expected the 01:30 EDT event to have started")
+	}
+	if eventHasStarted(secondOneThirty.In(newYork), now) {
+		t.Error("expected the 01:30 EST event to still be in the future")
+	}
+}
@@ -0,0 +1,245 @@
+package api
+
+import (
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/onnwee/subcults/internal/geo"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// ICS export settings.
+const (
+	// ICSContentType is the media type of calendar exports.
+	ICSContentType = "text/calendar; charset=utf-8"
+	// DefaultICSEventDuration is used for events without an end time.
+	DefaultICSEventDuration = 2 * time.Hour
+	// icsUIDDomain qualifies event IDs so UIDs are globally unique.
+	icsUIDDomain = "subcults"
+	// icsMaxLineOctets is the RFC 5545 line length limit, excluding CRLF.
+	icsMaxLineOctets = 75
+	// icsTimeFormat is the RFC 5545 UTC date-time format.
+	icsTimeFormat = "20060102T150405Z"
+)
+
+// icsTextEscaper escapes RFC 5545 TEXT values. Newlines become literal "\n"
+// so user content can't start new properties.
+var icsTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// ExportICS serves events as an iCalendar (text/calendar) download:
+//   - GET /events/{id}/calendar.ics exports a single event
+//   - GET /scenes/{id}/calendar.ics exports a scene's upcoming events
+//
+// Locations are described by the coarse geohash cell only; precise points are
+// never included. Each event keeps the same UID across downloads so calendar
+// apps update existing entries instead of duplicating them.
+func (h *EventHandlers) ExportICS(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/scenes/") {
+		h.exportSceneICS(w, r)
+		return
+	}
+	h.exportEventICS(w, r)
+}
+
+// exportEventICS writes a calendar containing a single event.
+func (h *EventHandlers) exportEventICS(w http.ResponseWriter, r *http.Request) {
+	eventID, ok := parseEntityID(w, r, "/events/", "Event")
+	if !ok {
+		return
+	}
+
+	foundEvent, err := h.eventRepo.GetByID(r.Context(), eventID)
+	if err != nil {
+		if err == scene.ErrEventNotFound {
+			writeError(w, r, ErrCodeNotFound, "Event not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to get event", "error", err, "event_id", eventID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve event")
+		return
+	}
+
+	eventScene, err := h.sceneRepo.GetByID(r.Context(), foundEvent.SceneID)
+	if err != nil {
+		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+			writeError(w, r, ErrCodeNotFound, "Event not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to get scene", "error", err, "scene_id", foundEvent.SceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+		return
+	}
+	visible, _, err := h.sceneEventAccess(r.Context(), eventScene, middleware.GetUserDID(r.Context()))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", eventScene.ID)
+		writeError(w, r, ErrCodeInternal, "Failed to check scene access")
+		return
+	}
+	if !visible {
+		// Same response as not found to prevent enumeration
+		writeError(w, r, ErrCodeNotFound, "Event not found")
+		return
+	}
+
+	writeICS(w, r, "event-"+foundEvent.ID+".ics", "", []*scene.Event{foundEvent})
+}
+
+// exportSceneICS writes a calendar of a scene's upcoming events, including
+// cancelled ones so subscribers see the cancellation.
+func (h *EventHandlers) exportSceneICS(w http.ResponseWriter, r *http.Request) {
+	sceneID, ok := parseEntityID(w, r, "/scenes/", "Scene")
+	if !ok {
+		return
+	}
+
+	foundScene, err := h.sceneRepo.GetByID(r.Context(), sceneID)
+	if err != nil {
+		if err == scene.ErrSceneDeleted {
+			writeError(w, r, ErrCodeSceneDeleted, "Scene not found")
+			return
+		}
+		if err == scene.ErrSceneNotFound {
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to get scene", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+		return
+	}
+
+	visible, _, err := h.sceneEventAccess(r.Context(), foundScene, middleware.GetUserDID(r.Context()))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to check scene access")
+		return
+	}
+	if !visible {
+		// Return 404 to avoid revealing that the scene exists
+		writeError(w, r, ErrCodeNotFound, "Scene not found")
+		return
+	}
+
+	events, err := h.eventRepo.ListBySceneID(r.Context(), sceneID, scene.EventFilter{From: time.Now()})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to list events", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to list events")
+		return
+	}
+
+	writeICS(w, r, "scene-"+foundScene.ID+".ics", html.UnescapeString(foundScene.Name), events)
+}
+
+// writeICS writes events as a VCALENDAR attachment. calendarName is optional.
+func writeICS(w http.ResponseWriter, r *http.Request, filename, calendarName string, events []*scene.Event) {
+	body := buildICS(calendarName, events, time.Now())
+
+	w.Header().Set("Content-Type", ICSContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte(body)); err != nil {
+		slog.ErrorContext(r.Context(), "failed to write calendar response", "error", err)
+	}
+}
+
+// buildICS renders events as an RFC 5545 VCALENDAR. now is used as DTSTAMP
+// for events without timestamps.
+func buildICS(calendarName string, events []*scene.Event, now time.Time) string {
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//Subcults//Events//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	if calendarName != "" {
+		writeICSLine(&b, "X-WR-CALNAME:"+escapeICSText(calendarName))
+	}
+
+	for _, event := range events {
+		endsAt := event.StartsAt.Add(DefaultICSEventDuration)
+		if event.EndsAt != nil {
+			endsAt = *event.EndsAt
+		}
+
+		// DTSTAMP tracks the last change so re-downloads are recognized as updates
+		stamp := now
+		if event.UpdatedAt != nil {
+			stamp = *event.UpdatedAt
+		} else if event.CreatedAt != nil {
+			stamp = *event.CreatedAt
+		}
+
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, "UID:"+event.ID+"@"+icsUIDDomain)
+		writeICSLine(&b, "DTSTAMP:"+stamp.UTC().Format(icsTimeFormat))
+		writeICSLine(&b, "DTSTART:"+event.StartsAt.UTC().Format(icsTimeFormat))
+		writeICSLine(&b, "DTEND:"+endsAt.UTC().Format(icsTimeFormat))
+		writeICSLine(&b, "SUMMARY:"+escapeICSText(html.UnescapeString(event.Title)))
+		if event.Description != "" {
+			writeICSLine(&b, "DESCRIPTION:"+escapeICSText(html.UnescapeString(event.Description)))
+		}
+		if location := icsCoarseLocation(event.CoarseGeohash); location != "" {
+			writeICSLine(&b, "LOCATION:"+escapeICSText(location))
+		}
+		if event.Status == "cancelled" {
+			writeICSLine(&b, "STATUS:CANCELLED")
+		} else {
+			writeICSLine(&b, "STATUS:CONFIRMED")
+		}
+		writeICSLine(&b, "END:VEVENT")
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+// icsCoarseLocation describes the center of a geohash cell, rounded to the
+// configured coarse precision and two decimal places (about 1km), so the export never
+// reveals more than the coarse location does.
+func icsCoarseLocation(geohash string) string {
+	center, ok := geo.Decode(geo.RoundGeohash(geohash, geo.CoarsePrecision()))
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("Approximate area near %.2f, %.2f", center.Lat, center.Lng)
+}
+
+// escapeICSText escapes a value for use in an RFC 5545 TEXT property.
+func escapeICSText(s string) string {
+	return icsTextEscaper.Replace(s)
+}
+
+// writeICSLine writes a content line terminated by CRLF, folding it at 75
+// octets without splitting UTF-8 sequences.
+func writeICSLine(b *strings.Builder, line string) {
+	limit := icsMaxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isUTF8Start(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts toward the limit
+		limit = icsMaxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// isUTF8Start reports whether c begins a UTF-8 sequence.
+func isUTF8Start(c byte) bool {
+	return c&0xC0 != 0x80
+}
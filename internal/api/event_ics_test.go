@@ -0,0 +1,195 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// doExportICS performs an ExportICS request for path.
+func doExportICS(handlers *EventHandlers, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	handlers.ExportICS(w, req)
+	return w
+}
+
+// TestExportICS_SingleEvent tests the VEVENT block for one event, including
+// escaping, the default duration, and omission of the precise point.
+func TestExportICS_SingleEvent(t *testing.T) {
+	env := newTestEnv()
+	public := env.addScene(t, &scene.Scene{Name: "Night &amp; Day", CoarseGeohash: "dr5reg", Visibility: scene.VisibilityPublic})
+	handlers := env.eventHandlers()
+
+	startsAt := time.Date(2030, 6, 1, 20, 0, 0, 0, time.UTC)
+	precise := scene.Point{Lat: 40.712776, Lng: -74.005974}
+	event := &scene.Event{
+		ID:            uuid.New().String(),
+		SceneID:       public.ID,
+		Title:         "Rave &amp; Rant",
+		Description:   "Bring water; earplugs, too\nDoors at 8",
+		StartsAt:      startsAt,
+		CoarseGeohash: "dr5regw3",
+		AllowPrecise:  true,
+		PrecisePoint:  &precise,
+	}
+	if err := env.events.Insert(t.Context(), event); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	w := doExportICS(handlers, "/events/"+event.ID+"/calendar.ics")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/calendar") {
+		t.Errorf("expected text/calendar content type, got %q", ct)
+	}
+
+	body := w.Body.String()
+	for _, line := range []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:" + event.ID + "@subcults",
+		"DTSTART:20300601T200000Z",
+		"DTEND:20300601T220000Z",
+		"SUMMARY:Rave & Rant",
+		`DESCRIPTION:Bring water\; earplugs\, too\nDoors at 8`,
+		`LOCATION:Approximate area near 40.71\, -74.01`,
+		"STATUS:CONFIRMED",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	} {
+		if !strings.Contains(body, line+"\r\n") {
+			t.Errorf("expected line %q in calendar:\n%s", line, body)
+		}
+	}
+
+	// Neither the precise point nor the finer geohash may leak
+	for _, forbidden := range []string{"40.712776", "-74.005974", "40.7128", "dr5regw3"} {
+		if strings.Contains(body, forbidden) {
+			t.Errorf("expected calendar to omit %q, got:\n%s", forbidden, body)
+		}
+	}
+}
+
+// TestExportICS_StableUID tests that repeated downloads carry the same UID and DTSTAMP.
+func TestExportICS_StableUID(t *testing.T) {
+	env := newTestEnv()
+	public := env.addScene(t, &scene.Scene{Name: "Night &amp; Day", CoarseGeohash: "dr5reg", Visibility: scene.VisibilityPublic})
+	handlers := env.eventHandlers()
+
+	endsAt := time.Now().Add(26 * time.Hour)
+	event := &scene.Event{
+		ID:            uuid.New().String(),
+		SceneID:       public.ID,
+		Title:         "Repeat Show",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+		EndsAt:        &endsAt,
+		CoarseGeohash: "dr5reg",
+	}
+	if err := env.events.Insert(t.Context(), event); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	path := "/events/" + event.ID + "/calendar.ics"
+	first := doExportICS(handlers, path).Body.String()
+	second := doExportICS(handlers, path).Body.String()
+	if first != second {
+		t.Errorf("expected identical exports, got:\n%s\n---\n%s", first, second)
+	}
+	if !strings.Contains(first, "DTEND:"+endsAt.UTC().Format("20060102T150405Z")+"\r\n") {
+		t.Errorf("expected explicit end time in calendar:\n%s", first)
+	}
+}
+
+// TestExportICS_Scene tests exporting a scene's upcoming events.
+func TestExportICS_Scene(t *testing.T) {
+	env := newTestEnv()
+	public := env.addScene(t, &scene.Scene{Name: "Night &amp; Day", CoarseGeohash: "dr5reg", Visibility: scene.VisibilityPublic})
+	handlers := env.eventHandlers()
+
+	now := time.Now()
+	for _, e := range []*scene.Event{
+		{ID: "upcoming", SceneID: public.ID, Title: "Upcoming", StartsAt: now.Add(time.Hour), CoarseGeohash: "dr5reg"},
+		{ID: "cancelled", SceneID: public.ID, Title: "Cancelled", StartsAt: now.Add(2 * time.Hour), CoarseGeohash: "dr5reg", Status: "cancelled"},
+		{ID: "past", SceneID: public.ID, Title: "Past", StartsAt: now.Add(-time.Hour), CoarseGeohash: "dr5reg"},
+	} {
+		if err := env.events.Insert(t.Context(), e); err != nil {
+			t.Fatalf("failed to insert event: %v", err)
+		}
+	}
+
+	w := doExportICS(handlers, "/scenes/"+public.ID+"/calendar.ics")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+
+	if got := strings.Count(body, "BEGIN:VEVENT\r\n"); got != 2 {
+		t.Errorf("expected 2 events, got %d:\n%s", got, body)
+	}
+	if !strings.Contains(body, "X-WR-CALNAME:Night & Day\r\n") {
+		t.Errorf("expected calendar name, got:\n%s", body)
+	}
+	if !strings.Contains(body, "UID:cancelled@subcults\r\n") || !strings.Contains(body, "STATUS:CANCELLED\r\n") {
+		t.Errorf("expected cancelled event marked as cancelled, got:\n%s", body)
+	}
+	if strings.Contains(body, "UID:past@subcults") {
+		t.Errorf("expected past event to be omitted, got:\n%s", body)
+	}
+}
+
+// TestExportICS_NotVisible tests that hidden scenes and their events return 404.
+func TestExportICS_NotVisible(t *testing.T) {
+	env := newTestEnv()
+	hidden := env.addScene(t, &scene.Scene{Name: "Hidden", CoarseGeohash: "dr5reg", Visibility: scene.VisibilityHidden})
+	handlers := env.eventHandlers()
+
+	event := &scene.Event{ID: uuid.New().String(), SceneID: hidden.ID, Title: "Secret", StartsAt: time.Now().Add(time.Hour), CoarseGeohash: "dr5reg"}
+	if err := env.events.Insert(t.Context(), event); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	for _, path := range []string{
+		"/events/" + event.ID + "/calendar.ics",
+		"/events/" + uuid.New().String() + "/calendar.ics",
+		"/scenes/" + hidden.ID + "/calendar.ics",
+	} {
+		if w := doExportICS(handlers, path); w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404 for %s, got %d", path, w.Code)
+		}
+	}
+}
+
+// TestWriteICSLine_Folding tests that long lines fold at 75 octets without splitting runes.
+func TestWriteICSLine_Folding(t *testing.T) {
+	var b strings.Builder
+	writeICSLine(&b, "SUMMARY:"+strings.Repeat("é", 100))
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected folded lines, got %q", b.String())
+	}
+	var unfolded strings.Builder
+	for i, line := range lines {
+		if len(line) > 75 {
+			t.Errorf("line %d is %d octets, want at most 75", i, len(line))
+		}
+		if i > 0 {
+			if !strings.HasPrefix(line, " ") {
+				t.Errorf("continuation line %d must start with a space", i)
+			}
+			line = line[1:]
+		}
+		unfolded.WriteString(line)
+	}
+	if unfolded.String() != "SUMMARY:"+strings.Repeat("é", 100) {
+		t.Errorf("unfolded line does not match original: %q", unfolded.String())
+	}
+}
@@ -36,7 +36,7 @@ func TestGetEvent_WithActiveStream(t *testing.T) {
 		CreatedAt:     &startsAt,
 		UpdatedAt:     &startsAt,
 	}
-	if err := eventRepo.Insert(testEvent); err != nil {
+	if err := eventRepo.Insert(t.Context(), testEvent); err != nil {
 		t.Fatalf("failed to insert event: %v", err)
 	}
 
@@ -99,7 +99,7 @@ func TestGetEvent_WithEndedStream(t *testing.T) {
 		CreatedAt:     &startsAt,
 		UpdatedAt:     &startsAt,
 	}
-	if err := eventRepo.Insert(testEvent); err != nil {
+	if err := eventRepo.Insert(t.Context(), testEvent); err != nil {
 		t.Fatalf("failed to insert event: %v", err)
 	}
 
@@ -156,7 +156,7 @@ func TestGetEvent_WithNoStream(t *testing.T) {
 		CreatedAt:     &startsAt,
 		UpdatedAt:     &startsAt,
 	}
-	if err := eventRepo.Insert(testEvent); err != nil {
+	if err := eventRepo.Insert(t.Context(), testEvent); err != nil {
 		t.Fatalf("failed to insert event: %v", err)
 	}
 
@@ -208,7 +208,7 @@ func TestSearchEvents_WithActiveStreams(t *testing.T) {
 			CreatedAt:     &baseTime,
 			UpdatedAt:     &baseTime,
 		}
-		if err := eventRepo.Insert(events[i]); err != nil {
+		if err := eventRepo.Insert(t.Context(), events[i]); err != nil {
 			t.Fatalf("failed to insert event: %v", err)
 		}
 	}
@@ -309,7 +309,7 @@ func TestSearchEvents_BatchQueryPerformance(t *testing.T) {
 			CreatedAt:     &baseTime,
 			UpdatedAt:     &baseTime,
 		}
-		if err := eventRepo.Insert(event); err != nil {
+		if err := eventRepo.Insert(t.Context(), event); err != nil {
 			t.Fatalf("failed to insert event %d: %v", i, err)
 		}
 
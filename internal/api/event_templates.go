@@ -0,0 +1,368 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// MaxTemplateNameLength caps the owner-facing label of an event template.
+const MaxTemplateNameLength = 64
+
+// CreateEventTemplateRequest represents the request body for creating an event template.
+type CreateEventTemplateRequest struct {
+	Name            string   `json:"name"`
+	Title           string   `json:"title"` // May contain scene.TemplateDatePlaceholder
+	Description     string   `json:"description,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	CoarseGeohash   string   `json:"coarse_geohash,omitempty"`
+	Capacity        int      `json:"capacity,omitempty"`
+	DurationMinutes int      `json:"duration_minutes,omitempty"`
+	Timezone        string   `json:"timezone,omitempty"`
+}
+
+// EventTemplatesResponse represents the response for listing a scene's templates.
+type EventTemplatesResponse struct {
+	Templates []*scene.Template `json:"templates"`
+}
+
+// CreateEventFromTemplateRequest represents the request body for creating an
+// event from a template. Every field except starts_at is optional and, when
+// present, overrides the template's default.
+type CreateEventFromTemplateRequest struct {
+	Title         *string      `json:"title,omitempty"`
+	Description   *string      `json:"description,omitempty"`
+	Tags          []string     `json:"tags,omitempty"`
+	AllowPrecise  bool         `json:"allow_precise"`
+	PrecisePoint  *scene.Point `json:"precise_point,omitempty"`
+	CoarseGeohash *string      `json:"coarse_geohash,omitempty"`
+	StartsAt      time.Time    `json:"starts_at"`
+	EndsAt        *time.Time   `json:"ends_at,omitempty"`
+	Timezone      *string      `json:"timezone,omitempty"`
+	Capacity      *int         `json:"capacity,omitempty"`
+
+	// Recurrence optionally expands the request into a series, as on POST /events.
+	Recurrence *scene.Recurrence `json:"recurrence,omitempty"`
+}
+
+// requireSceneOwner writes an error and returns false unless userDID owns the
+// scene. Missing and deleted scenes are reported as not found.
+func (h *EventHandlers) requireSceneOwner(w http.ResponseWriter, r *http.Request, sceneID, userDID, action string) bool {
+	isOwner, err := h.isSceneOwner(r.Context(), sceneID, userDID)
+	if err != nil {
+		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
+			return false
+		}
+		slog.ErrorContext(r.Context(), "failed to check scene ownership", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to verify scene ownership")
+		return false
+	}
+	if !isOwner {
+		writeError(w, r, ErrCodeForbidden, "You do not have permission to "+action)
+		return false
+	}
+	return true
+}
+
+// getTemplate loads the template named in the URL path, writing an error and
+// returning nil if the templates feature is disabled or it doesn't exist.
+func (h *EventHandlers) getTemplate(w http.ResponseWriter, r *http.Request) *scene.Template {
+	if h.templateRepo == nil {
+		writeError(w, r, ErrCodeNotFound, "The requested resource was not found")
+		return nil
+	}
+
+	templateID, ok := parseEntityID(w, r, "/event-templates/", "Template")
+	if !ok {
+		return nil
+	}
+
+	template, err := h.templateRepo.GetByID(templateID)
+	if err != nil {
+		if err == scene.ErrTemplateNotFound {
+			writeError(w, r, ErrCodeNotFound, "Template not found")
+			return nil
+		}
+		slog.ErrorContext(r.Context(), "failed to get template", "error", err, "template_id", templateID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve template")
+		return nil
+	}
+	return template
+}
+
+// validateEventTemplate validates and sanitizes a template request into a
+// template for sceneID. Text is stored escaped as on event creation.
+// Returns error message if validation fails, empty string if valid.
+func (h *EventHandlers) validateEventTemplate(req *CreateEventTemplateRequest, sceneID string) (*scene.Template, string) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > MaxTemplateNameLength {
+		return nil, fmt.Sprintf("template name must be 1-%d characters", MaxTemplateNameLength)
+	}
+
+	// Check the title as it will read once the date is filled in
+	sampleTitle := strings.ReplaceAll(req.Title, scene.TemplateDatePlaceholder, time.DateOnly)
+	if errMsg := validateEventTitle(sampleTitle); errMsg != "" {
+		return nil, errMsg
+	}
+
+	description, err := sanitizeText(req.Description)
+	if err != nil {
+		return nil, "description " + err.Error()
+	}
+
+	var coarseGeohash string
+	if strings.TrimSpace(req.CoarseGeohash) != "" {
+		var errMsg string
+		coarseGeohash, errMsg = normalizeCoarseGeohash(req.CoarseGeohash)
+		if errMsg != "" {
+			return nil, errMsg
+		}
+	}
+
+	if req.Capacity < 0 {
+		return nil, "capacity cannot be negative"
+	}
+	if req.DurationMinutes < 0 {
+		return nil, "duration_minutes cannot be negative"
+	}
+	duration := time.Duration(req.DurationMinutes) * time.Minute
+	if h.maxEventDuration > 0 && duration > h.maxEventDuration {
+		return nil, "event duration cannot exceed " + formatEventDuration(h.maxEventDuration)
+	}
+
+	if _, errMsg := validateEventTimezone(req.Timezone); errMsg != "" {
+		return nil, errMsg
+	}
+
+	var tags []string
+	if req.Tags != nil {
+		tags = make([]string, len(req.Tags))
+		for i, tag := range req.Tags {
+			tags[i] = html.EscapeString(tag)
+		}
+	}
+
+	return &scene.Template{
+		SceneID:       sceneID,
+		Name:          html.EscapeString(name),
+		Title:         sanitizeEventTitle(req.Title),
+		Description:   description,
+		Tags:          tags,
+		CoarseGeohash: coarseGeohash,
+		Capacity:      req.Capacity,
+		DurationMins:  req.DurationMinutes,
+		Timezone:      req.Timezone,
+	}, ""
+}
+
+// CreateEventTemplate handles POST /scenes/{id}/event-templates - stores
+// default event fields for the scene (owner only).
+func (h *EventHandlers) CreateEventTemplate(w http.ResponseWriter, r *http.Request) {
+	if h.templateRepo == nil {
+		writeError(w, r, ErrCodeNotFound, "The requested resource was not found")
+		return
+	}
+
+	sceneID, ok := parseEntityID(w, r, "/scenes/", "Scene")
+	if !ok {
+		return
+	}
+
+	var req CreateEventTemplateRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+	if !h.requireSceneOwner(w, r, sceneID, userDID, "manage templates for this scene") {
+		return
+	}
+
+	template, errMsg := h.validateEventTemplate(&req, sceneID)
+	if errMsg != "" {
+		writeError(w, r, ErrCodeValidation, errMsg)
+		return
+	}
+
+	now := time.Now()
+	template.ID = uuid.New().String()
+	template.CreatedAt = &now
+	template.UpdatedAt = &now
+
+	if err := h.templateRepo.Insert(template); err != nil {
+		slog.ErrorContext(r.Context(), "failed to insert template", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to create template")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(template); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode template response", "error", err)
+	}
+}
+
+// ListEventTemplates handles GET /scenes/{id}/event-templates - lists the
+// scene's templates, oldest first (owner only).
+func (h *EventHandlers) ListEventTemplates(w http.ResponseWriter, r *http.Request) {
+	if h.templateRepo == nil {
+		writeError(w, r, ErrCodeNotFound, "The requested resource was not found")
+		return
+	}
+
+	sceneID, ok := parseEntityID(w, r, "/scenes/", "Scene")
+	if !ok {
+		return
+	}
+
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+	if !h.requireSceneOwner(w, r, sceneID, userDID, "manage templates for this scene") {
+		return
+	}
+
+	templates, err := h.templateRepo.ListByScene(sceneID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to list templates", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to list templates")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(EventTemplatesResponse{Templates: templates}); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode templates response", "error", err)
+	}
+}
+
+// DeleteEventTemplate handles DELETE /event-templates/{id} - removes a
+// template (owner only). Events already created from it are unchanged.
+func (h *EventHandlers) DeleteEventTemplate(w http.ResponseWriter, r *http.Request) {
+	template := h.getTemplate(w, r)
+	if template == nil {
+		return
+	}
+
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+	if !h.requireSceneOwner(w, r, template.SceneID, userDID, "manage templates for this scene") {
+		return
+	}
+
+	if err := h.templateRepo.Delete(template.ID); err != nil {
+		if err == scene.ErrTemplateNotFound {
+			writeError(w, r, ErrCodeNotFound, "Template not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to delete template", "error", err, "template_id", template.ID)
+		writeError(w, r, ErrCodeInternal, "Failed to delete template")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateEventFromTemplate handles POST /event-templates/{id}/events - creates
+// an event, or a series with recurrence, in the template's scene. Fields
+// missing from the request take the template's defaults; ends_at defaults to
+// starts_at plus the template duration. The merged request is then validated
+// and authorized exactly as on POST /events.
+func (h *EventHandlers) CreateEventFromTemplate(w http.ResponseWriter, r *http.Request) {
+	template := h.getTemplate(w, r)
+	if template == nil {
+		return
+	}
+
+	var req CreateEventFromTemplateRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+	if req.StartsAt.IsZero() {
+		writeError(w, r, ErrCodeValidation, "starts_at is required")
+		return
+	}
+
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+	if !h.requireSceneOwner(w, r, template.SceneID, userDID, "create events for this scene") {
+		return
+	}
+
+	// Template text is stored escaped; unescape it so createEvent's
+	// sanitization doesn't escape it twice
+	merged := CreateEventRequest{
+		SceneID:       template.SceneID,
+		Description:   html.UnescapeString(template.Description),
+		AllowPrecise:  req.AllowPrecise,
+		PrecisePoint:  req.PrecisePoint,
+		CoarseGeohash: template.CoarseGeohash,
+		StartsAt:      req.StartsAt,
+		EndsAt:        req.EndsAt,
+		Timezone:      template.Timezone,
+		Capacity:      template.Capacity,
+		Recurrence:    req.Recurrence,
+	}
+	if template.Tags != nil {
+		merged.Tags = make([]string, len(template.Tags))
+		for i, tag := range template.Tags {
+			merged.Tags[i] = html.UnescapeString(tag)
+		}
+	}
+
+	if req.Timezone != nil {
+		merged.Timezone = *req.Timezone
+	}
+	if req.Title != nil {
+		merged.Title = *req.Title
+	} else {
+		// An invalid timezone override is rejected by createEvent; until
+		// then the date is formatted in UTC
+		loc, err := scene.LoadTimezone(merged.Timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+		merged.Title = html.UnescapeString(template.EventTitle(req.StartsAt, loc))
+	}
+	if req.Description != nil {
+		merged.Description = *req.Description
+	}
+	if req.Tags != nil {
+		merged.Tags = req.Tags
+	}
+	if req.CoarseGeohash != nil {
+		merged.CoarseGeohash = *req.CoarseGeohash
+	}
+	if req.Capacity != nil {
+		merged.Capacity = *req.Capacity
+	}
+	if merged.EndsAt == nil && template.DurationMins > 0 {
+		endsAt := req.StartsAt.Add(template.Duration())
+		merged.EndsAt = &endsAt
+	}
+
+	h.createEvent(w, r, &merged)
+}
@@ -0,0 +1,361 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// templateRequest sends a request with a JSON body as userDID to handler.
+func templateRequest(t *testing.T, handler http.HandlerFunc, method, path, userDID string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatalf("failed to marshal request: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handler(w, req)
+	return w
+}
+
+// createTemplate stores a template through the API and returns it.
+func createTemplate(t *testing.T, handlers *EventHandlers, sceneID string, req CreateEventTemplateRequest) *scene.Template {
+	t.Helper()
+	w := templateRequest(t, handlers.CreateEventTemplate, http.MethodPost, "/scenes/"+sceneID+"/event-templates", "did:plc:owner", req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var tmpl scene.Template
+	if err := json.NewDecoder(w.Body).Decode(&tmpl); err != nil {
+		t.Fatalf("failed to decode template: %v", err)
+	}
+	return &tmpl
+}
+
+func TestCreateEventFromTemplate_AppliesDefaults(t *testing.T) {
+	env := newTestEnv()
+	sceneID := env.addScene(t, &scene.Scene{Name: "Template Scene"}).ID
+	handlers := env.eventHandlers()
+	handlers.SetTemplateRepository(scene.NewInMemoryTemplateRepository())
+	tmpl := createTemplate(t, handlers, sceneID, CreateEventTemplateRequest{
+		Name:            "Friday warehouse",
+		Title:           "Warehouse Night {date}",
+		Description:     "Bring earplugs & water",
+		Tags:            []string{"techno", "r&b"},
+		CoarseGeohash:   "dr5regw",
+		Capacity:        150,
+		DurationMinutes: 240,
+		Timezone:        "America/New_York",
+	})
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load zone: %v", err)
+	}
+	now := time.Now().In(newYork)
+	// 23:30 local is already the following day in UTC
+	startsAt := time.Date(now.Year(), now.Month(), now.Day()+1, 23, 30, 0, 0, newYork)
+	w := templateRequest(t, handlers.CreateEventFromTemplate, http.MethodPost, "/event-templates/"+tmpl.ID+"/events", "did:plc:owner",
+		CreateEventFromTemplateRequest{StartsAt: startsAt.UTC()})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var event scene.Event
+	if err := json.NewDecoder(w.Body).Decode(&event); err != nil {
+		t.Fatalf("failed to decode event: %v", err)
+	}
+	if want := "Warehouse Night " + startsAt.Format(time.DateOnly); event.Title != want {
+		t.Errorf("expected title %q, got %q", want, event.Title)
+	}
+	if event.SceneID != sceneID {
+		t.Errorf("expected scene %s, got %s", sceneID, event.SceneID)
+	}
+	// Stored escaped once, as on POST /events
+	if event.Description != "Bring earplugs &amp; water" {
+		t.Errorf("expected description escaped once, got %q", event.Description)
+	}
+	if len(event.Tags) != 2 || event.Tags[1] != "r&amp;b" {
+		t.Errorf("expected template tags escaped once, got %v", event.Tags)
+	}
+	if event.CoarseGeohash != "dr5reg" {
+		t.Errorf("expected template coarse_geohash, got %q", event.CoarseGeohash)
+	}
+	if event.Capacity != 150 {
+		t.Errorf("expected capacity 150, got %d", event.Capacity)
+	}
+	if event.Timezone != "America/New_York" {
+		t.Errorf("expected timezone America/New_York, got %q", event.Timezone)
+	}
+	if event.EndsAt == nil || !event.EndsAt.Equal(startsAt.Add(4*time.Hour)) {
+		t.Errorf("expected ends_at from the template duration, got %v", event.EndsAt)
+	}
+}
+
+func TestCreateEventFromTemplate_RequestOverrides(t *testing.T) {
+	env := newTestEnv()
+	sceneID := env.addScene(t, &scene.Scene{Name: "Template Scene"}).ID
+	handlers := env.eventHandlers()
+	handlers.SetTemplateRepository(scene.NewInMemoryTemplateRepository())
+	tmpl := createTemplate(t, handlers, sceneID, CreateEventTemplateRequest{
+		Name:            "Friday warehouse",
+		Title:           "Warehouse Night {date}",
+		Description:     "Template description",
+		Tags:            []string{"techno"},
+		CoarseGeohash:   "dr5regw",
+		Capacity:        150,
+		DurationMinutes: 240,
+		Timezone:        "America/New_York",
+	})
+
+	title := "Special Guest Night"
+	description := "Override description"
+	geohash := "9q8yyk"
+	capacity := 0
+	timezone := "Europe/Berlin"
+	startsAt := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	endsAt := startsAt.Add(time.Hour)
+	w := templateRequest(t, handlers.CreateEventFromTemplate, http.MethodPost, "/event-templates/"+tmpl.ID+"/events", "did:plc:owner",
+		CreateEventFromTemplateRequest{
+			Title:         &title,
+			Description:   &description,
+			Tags:          []string{"house"},
+			CoarseGeohash: &geohash,
+			StartsAt:      startsAt,
+			EndsAt:        &endsAt,
+			Timezone:      &timezone,
+			Capacity:      &capacity,
+		})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var event scene.Event
+	if err := json.NewDecoder(w.Body).Decode(&event); err != nil {
+		t.Fatalf("failed to decode event: %v", err)
+	}
+	if event.Title != title || event.Description != description {
+		t.Errorf("expected overridden text, got %q / %q", event.Title, event.Description)
+	}
+	if len(event.Tags) != 1 || event.Tags[0] != "house" {
+		t.Errorf("expected tags to replace the template's, got %v", event.Tags)
+	}
+	if event.CoarseGeohash != geohash {
+		t.Errorf("expected coarse_geohash %q, got %q", geohash, event.CoarseGeohash)
+	}
+	if event.Capacity != 0 {
+		t.Errorf("expected capacity override 0, got %d", event.Capacity)
+	}
+	if event.Timezone != timezone {
+		t.Errorf("expected timezone %q, got %q", timezone, event.Timezone)
+	}
+	if event.EndsAt == nil || !event.EndsAt.Equal(endsAt) {
+		t.Errorf("expected ends_at override %v, got %v", endsAt, event.EndsAt)
+	}
+
+	// Overrides are validated like POST /events
+	badTimezone := "Nowhere/Atlantis"
+	w = templateRequest(t, handlers.CreateEventFromTemplate, http.MethodPost, "/event-templates/"+tmpl.ID+"/events", "did:plc:owner",
+		CreateEventFromTemplateRequest{StartsAt: startsAt, Timezone: &badTimezone})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an invalid timezone override, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCreateEventFromTemplate_Recurrence(t *testing.T) {
+	env := newTestEnv()
+	sceneID := env.addScene(t, &scene.Scene{Name: "Template Scene"}).ID
+	handlers := env.eventHandlers()
+	handlers.SetTemplateRepository(scene.NewInMemoryTemplateRepository())
+	tmpl := createTemplate(t, handlers, sceneID, CreateEventTemplateRequest{
+		Name:            "Weekly",
+		Title:           "Weekly Night",
+		CoarseGeohash:   "dr5regw",
+		DurationMinutes: 180,
+	})
+
+	startsAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	w := templateRequest(t, handlers.CreateEventFromTemplate, http.MethodPost, "/event-templates/"+tmpl.ID+"/events", "did:plc:owner",
+		CreateEventFromTemplateRequest{
+			StartsAt:   startsAt,
+			Recurrence: &scene.Recurrence{Freq: scene.RecurrenceWeekly, Count: 3},
+		})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp EventSeriesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	stored, err := env.events.ListBySeries(t.Context(), resp.SeriesID)
+	if err != nil {
+		t.Fatalf("ListBySeries() error = %v", err)
+	}
+	if len(stored) != 3 {
+		t.Fatalf("expected 3 occurrences, got %d", len(stored))
+	}
+	for i, e := range stored {
+		if e.EndsAt == nil || e.EndsAt.Sub(e.StartsAt) != 3*time.Hour {
+			t.Errorf("occurrence %d: expected the template duration, got %v - %v", i, e.StartsAt, e.EndsAt)
+		}
+	}
+}
+
+func TestDeleteEventTemplate_KeepsCreatedEvents(t *testing.T) {
+	env := newTestEnv()
+	sceneID := env.addScene(t, &scene.Scene{Name: "Template Scene"}).ID
+	handlers := env.eventHandlers()
+	handlers.SetTemplateRepository(scene.NewInMemoryTemplateRepository())
+	tmpl := createTemplate(t, handlers, sceneID, CreateEventTemplateRequest{
+		Name:          "One-off",
+		Title:         "Loft Session",
+		CoarseGeohash: "dr5regw",
+		Capacity:      40,
+	})
+
+	w := templateRequest(t, handlers.CreateEventFromTemplate, http.MethodPost, "/event-templates/"+tmpl.ID+"/events", "did:plc:owner",
+		CreateEventFromTemplateRequest{StartsAt: time.Now().Add(24 * time.Hour)})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created scene.Event
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode event: %v", err)
+	}
+
+	// Only the owner may delete
+	if w := templateRequest(t, handlers.DeleteEventTemplate, http.MethodDelete, "/event-templates/"+tmpl.ID, "did:plc:intruder", nil); w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for non-owner, got %d", w.Code)
+	}
+	if w := templateRequest(t, handlers.DeleteEventTemplate, http.MethodDelete, "/event-templates/"+tmpl.ID, "did:plc:owner", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+
+	event, err := env.events.GetByID(t.Context(), created.ID)
+	if err != nil {
+		t.Fatalf("expected the event to survive template deletion: %v", err)
+	}
+	if event.Title != "Loft Session" || event.Capacity != 40 {
+		t.Errorf("expected event fields unchanged, got %q capacity %d", event.Title, event.Capacity)
+	}
+
+	if w := templateRequest(t, handlers.CreateEventFromTemplate, http.MethodPost, "/event-templates/"+tmpl.ID+"/events", "did:plc:owner",
+		CreateEventFromTemplateRequest{StartsAt: time.Now().Add(24 * time.Hour)}); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for a deleted template, got %d", w.Code)
+	}
+}
+
+func TestEventTemplates_OwnerManaged(t *testing.T) {
+	env := newTestEnv()
+	sceneID := env.addScene(t, &scene.Scene{Name: "Template Scene"}).ID
+	handlers := env.eventHandlers()
+	handlers.SetTemplateRepository(scene.NewInMemoryTemplateRepository())
+	tmpl := createTemplate(t, handlers, sceneID, CreateEventTemplateRequest{Name: "Mine", Title: "Owner Night"})
+	valid := CreateEventTemplateRequest{Name: "Theirs", Title: "Intruder Night"}
+
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		method     string
+		path       string
+		userDID    string
+		body       any
+		wantStatus int
+	}{
+		{"create unauthenticated", handlers.CreateEventTemplate, http.MethodPost, "/scenes/" + sceneID + "/event-templates", "", valid, http.StatusUnauthorized},
+		{"create as non-owner", handlers.CreateEventTemplate, http.MethodPost, "/scenes/" + sceneID + "/event-templates", "did:plc:intruder", valid, http.StatusForbidden},
+		{"create for missing scene", handlers.CreateEventTemplate, http.MethodPost, "/scenes/" + uuid.New().String() + "/event-templates", "did:plc:owner", valid, http.StatusNotFound},
+		{"list as non-owner", handlers.ListEventTemplates, http.MethodGet, "/scenes/" + sceneID + "/event-templates", "did:plc:intruder", nil, http.StatusForbidden},
+		{"instantiate as non-owner", handlers.CreateEventFromTemplate, http.MethodPost, "/event-templates/" + tmpl.ID + "/events", "did:plc:intruder", CreateEventFromTemplateRequest{StartsAt: time.Now().Add(time.Hour)}, http.StatusForbidden},
+		{"instantiate without starts_at", handlers.CreateEventFromTemplate, http.MethodPost, "/event-templates/" + tmpl.ID + "/events", "did:plc:owner", CreateEventFromTemplateRequest{}, http.StatusBadRequest},
+		{"unknown template", handlers.DeleteEventTemplate, http.MethodDelete, "/event-templates/" + uuid.New().String(), "did:plc:owner", nil, http.StatusNotFound},
+		{"invalid template ID", handlers.DeleteEventTemplate, http.MethodDelete, "/event-templates/nope", "did:plc:owner", nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := templateRequest(t, tt.handler, tt.method, tt.path, tt.userDID, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	// Templates are scene-scoped
+	other := createTemplate(t, handlers, sceneID, CreateEventTemplateRequest{Name: "Second", Title: "Second Night"})
+	w := templateRequest(t, handlers.ListEventTemplates, http.MethodGet, "/scenes/"+sceneID+"/event-templates", "did:plc:owner", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp EventTemplatesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Templates) != 2 || resp.Templates[0].ID != tmpl.ID || resp.Templates[1].ID != other.ID {
+		t.Errorf("expected the scene's two templates oldest first, got %+v", resp.Templates)
+	}
+}
+
+func TestCreateEventTemplate_Validation(t *testing.T) {
+	env := newTestEnv()
+	sceneID := env.addScene(t, &scene.Scene{Name: "Template Scene"}).ID
+	handlers := env.eventHandlers()
+	handlers.SetTemplateRepository(scene.NewInMemoryTemplateRepository())
+	handlers.SetMaxEventDuration(24 * time.Hour)
+
+	tests := []struct {
+		name string
+		req  CreateEventTemplateRequest
+	}{
+		{"missing name", CreateEventTemplateRequest{Title: "Valid Title"}},
+		{"short title", CreateEventTemplateRequest{Name: "T", Title: "ab"}},
+		{"title too long once the date is filled in", CreateEventTemplateRequest{Name: "T", Title: "{date}" + string(bytes.Repeat([]byte("a"), MaxEventTitleLength-6))}},
+		{"invalid geohash", CreateEventTemplateRequest{Name: "T", Title: "Valid Title", CoarseGeohash: "dr5!"}},
+		{"negative capacity", CreateEventTemplateRequest{Name: "T", Title: "Valid Title", Capacity: -1}},
+		{"negative duration", CreateEventTemplateRequest{Name: "T", Title: "Valid Title", DurationMinutes: -5}},
+		{"duration over the maximum", CreateEventTemplateRequest{Name: "T", Title: "Valid Title", DurationMinutes: 25 * 60}},
+		{"invalid timezone", CreateEventTemplateRequest{Name: "T", Title: "Valid Title", Timezone: "Local"}},
+		{"script in description", CreateEventTemplateRequest{Name: "T", Title: "Valid Title", Description: "<script>alert(1)</script>"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := templateRequest(t, handlers.CreateEventTemplate, http.MethodPost, "/scenes/"+sceneID+"/event-templates", "did:plc:owner", tt.req)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != ErrCodeValidation {
+				t.Errorf("expected error code %s, got %s", ErrCodeValidation, errResp.Error.Code)
+			}
+		})
+	}
+}
+
+func TestEventTemplates_Disabled(t *testing.T) {
+	handlers := NewEventHandlers(scene.NewInMemoryEventRepository(), scene.NewInMemorySceneRepository(), audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository())
+
+	w := templateRequest(t, handlers.ListEventTemplates, http.MethodGet, "/scenes/"+uuid.New().String()+"/event-templates", "did:plc:owner", nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 without a template repository, got %d", w.Code)
+	}
+}
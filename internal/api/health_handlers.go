@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultReadinessTimeout bounds how long all readiness checks may take together.
+const DefaultReadinessTimeout = 2 * time.Second
+
+// ErrNotConnected is reported by connection-based readiness checks while the
+// dependency is disconnected.
+var ErrNotConnected = errors.New("not connected")
+
+// ReadinessCheck reports whether a dependency can serve traffic.
+// It returns nil when ready and an error describing the problem otherwise.
+type ReadinessCheck func(ctx context.Context) error
+
+// ConnectionStatus is implemented by clients that track a live connection,
+// such as indexer.Client.
+type ConnectionStatus interface {
+	IsConnected() bool
+}
+
+// ConnectionCheck returns a ReadinessCheck that fails with ErrNotConnected
+// while conn is disconnected.
+func ConnectionCheck(conn ConnectionStatus) ReadinessCheck {
+	return func(ctx context.Context) error {
+		if !conn.IsConnected() {
+			return ErrNotConnected
+		}
+		return nil
+	}
+}
+
+// namedCheck pairs a readiness check with the subsystem it covers.
+type namedCheck struct {
+	name  string
+	check ReadinessCheck
+}
+
+// HealthHandlers serves liveness and readiness probes.
+type HealthHandlers struct {
+	mu      sync.RWMutex
+	checks  []namedCheck
+	timeout time.Duration
+}
+
+// NewHealthHandlers creates a new HealthHandlers instance with no readiness checks.
+func NewHealthHandlers() *HealthHandlers {
+	return &HealthHandlers{
+		timeout: DefaultReadinessTimeout,
+	}
+}
+
+// AddCheck registers a readiness check for the named subsystem.
+// Checks run in registration order on every readiness probe.
+func (h *HealthHandlers) AddCheck(name string, check ReadinessCheck) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks = append(h.checks, namedCheck{name: name, check: check})
+}
+
+// HealthStatusResponse is the body of liveness and successful readiness responses.
+type HealthStatusResponse struct {
+	Status string `json:"status"`
+}
+
+// FailedCheck describes a subsystem that failed its readiness check.
+type FailedCheck struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// NotReadyResponse is the body of a failed readiness response.
+type NotReadyResponse struct {
+	Status string        `json:"status"`
+	Failed []FailedCheck `json:"failed"`
+}
+
+// Healthz handles GET /healthz - reports liveness.
+// Always returns 200 while the process is able to serve requests.
+func (h *HealthHandlers) Healthz(w http.ResponseWriter, r *http.Request) {
+	writeHealthJSON(w, r, http.StatusOK, HealthStatusResponse{Status: "ok"})
+}
+
+// Readyz handles GET /readyz - reports readiness.
+// Returns 200 when every registered check passes, otherwise 503 with the
+// failing subsystems listed.
+func (h *HealthHandlers) Readyz(w http.ResponseWriter, r *http.Request) {
+	h.mu.RLock()
+	checks := make([]namedCheck, len(h.checks))
+	copy(checks, h.checks)
+	h.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	failed := make([]FailedCheck, 0)
+	for _, c := range checks {
+		if err := c.check(ctx); err != nil {
+			failed = append(failed, FailedCheck{Name: c.name, Error: err.Error()})
+		}
+	}
+
+	if len(failed) > 0 {
+		slog.WarnContext(r.Context(), "readiness check failed", "failed", failed)
+		writeHealthJSON(w, r, http.StatusServiceUnavailable, NotReadyResponse{Status: "not_ready", Failed: failed})
+		return
+	}
+	writeHealthJSON(w, r, http.StatusOK, HealthStatusResponse{Status: "ready"})
+}
+
+// writeHealthJSON writes a probe response. Probes are never cached.
+func writeHealthJSON(w http.ResponseWriter, r *http.Request, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
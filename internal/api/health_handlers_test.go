@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// fakeIndexer is a ConnectionStatus whose connection state can be toggled.
+type fakeIndexer struct {
+	connected atomic.Bool
+}
+
+func (f *fakeIndexer) IsConnected() bool {
+	return f.connected.Load()
+}
+
+func doReadyz(handlers *HealthHandlers) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	w := httptest.NewRecorder()
+	handlers.Readyz(w, req)
+	return w
+}
+
+func TestHealthz_AlwaysOK(t *testing.T) {
+	handlers := NewHealthHandlers()
+	handlers.AddCheck("indexer", ConnectionCheck(&fakeIndexer{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	w := httptest.NewRecorder()
+	handlers.Healthz(w, req)
+
+	// Liveness ignores dependency state
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var resp HealthStatusResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Status != "ok" {
+		t.Errorf("expected status 'ok', got %q", resp.Status)
+	}
+}
+
+func TestReadyz_TracksIndexerConnection(t *testing.T) {
+	indexer := &fakeIndexer{}
+	indexer.connected.Store(true)
+
+	handlers := NewHealthHandlers()
+	handlers.AddCheck("indexer", ConnectionCheck(indexer))
+	handlers.AddCheck("repository", func(ctx context.Context) error { return nil })
+
+	w := doReadyz(handlers)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 when connected, got %d: %s", w.Code, w.Body.String())
+	}
+	var ready HealthStatusResponse
+	if err := json.NewDecoder(w.Body).Decode(&ready); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if ready.Status != "ready" {
+		t.Errorf("expected status 'ready', got %q", ready.Status)
+	}
+
+	indexer.connected.Store(false)
+	w = doReadyz(handlers)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503 when disconnected, got %d", w.Code)
+	}
+	var notReady NotReadyResponse
+	if err := json.NewDecoder(w.Body).Decode(&notReady); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if notReady.Status != "not_ready" {
+		t.Errorf("expected status 'not_ready', got %q", notReady.Status)
+	}
+	if len(notReady.Failed) != 1 || notReady.Failed[0].Name != "indexer" || notReady.Failed[0].Error != ErrNotConnected.Error() {
+		t.Errorf("expected only the indexer to fail, got %+v", notReady.Failed)
+	}
+
+	// Reconnecting restores readiness
+	indexer.connected.Store(true)
+	if w := doReadyz(handlers); w.Code != http.StatusOK {
+		t.Errorf("expected status 200 after reconnect, got %d", w.Code)
+	}
+}
+
+func TestReadyz_ListsEveryFailingSubsystem(t *testing.T) {
+	handlers := NewHealthHandlers()
+	handlers.AddCheck("indexer", ConnectionCheck(&fakeIndexer{}))
+	handlers.AddCheck("repository", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	w := doReadyz(handlers)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d", w.Code)
+	}
+	var resp NotReadyResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Failed) != 2 {
+		t.Fatalf("expected 2 failing subsystems, got %+v", resp.Failed)
+	}
+	if resp.Failed[1].Name != "repository" || resp.Failed[1].Error != "connection refused" {
+		t.Errorf("unexpected repository failure: %+v", resp.Failed[1])
+	}
+}
+
+func TestReadyz_NoChecksIsReady(t *testing.T) {
+	if w := doReadyz(NewHealthHandlers()); w.Code != http.StatusOK {
+		t.Errorf("expected status 200 with no checks, got %d", w.Code)
+	}
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/report"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// testEnv holds the in-memory repositories behind handlers under test. Tests
+// seed it with the add helpers, then build handlers over the same
+// repositories and wire any optional dependencies themselves.
+type testEnv struct {
+	scenes      *scene.InMemorySceneRepository
+	events      *scene.InMemoryEventRepository
+	rsvps       *scene.InMemoryRSVPRepository
+	memberships *membership.InMemoryMembershipRepository
+	audit       *audit.InMemoryRepository
+	streams     *stream.InMemorySessionRepository
+}
+
+// newTestEnv creates a testEnv with empty repositories.
+func newTestEnv() *testEnv {
+	return &testEnv{
+		scenes:      scene.NewInMemorySceneRepository(),
+		events:      scene.NewInMemoryEventRepository(),
+		rsvps:       scene.NewInMemoryRSVPRepository(),
+		memberships: membership.NewInMemoryMembershipRepository(),
+		audit:       audit.NewInMemoryRepository(),
+		streams:     stream.NewInMemorySessionRepository(),
+	}
+}
+
+func (e *testEnv) eventHandlers() *EventHandlers {
+	return NewEventHandlers(e.events, e.scenes, e.audit, e.rsvps, e.streams)
+}
+
+func (e *testEnv) rsvpHandlers() *RSVPHandlers {
+	return NewRSVPHandlers(e.rsvps, e.events, e.scenes)
+}
+
+func (e *testEnv) sceneHandlers() *SceneHandlers {
+	return NewSceneHandlers(e.scenes, e.memberships, e.streams)
+}
+
+func (e *testEnv) membershipHandlers() *MembershipHandlers {
+	return NewMembershipHandlers(e.memberships, e.scenes, e.audit)
+}
+
+func (e *testEnv) auditHandlers() *AuditHandlers {
+	return NewAuditHandlers(e.audit, e.scenes, e.memberships)
+}
+
+func (e *testEnv) adminHandlers() *AdminHandlers {
+	return NewAdminHandlers(e.scenes, e.events, e.memberships, e.audit)
+}
+
+func (e *testEnv) reportHandlers() *ReportHandlers {
+	return NewReportHandlers(report.NewInMemoryReportRepository(), e.scenes, e.events, e.memberships, e.audit)
+}
+
+func (e *testEnv) postHandlers(posts post.PostRepository) *PostHandlers {
+	return NewPostHandlers(posts, e.scenes, e.memberships)
+}
+
+// addScene inserts s, defaulting an empty ID to a new UUID, Name to
+// "Test Scene", OwnerDID to did:plc:owner, and CoarseGeohash to dr5regw.
+func (e *testEnv) addScene(t *testing.T, s *scene.Scene) *scene.Scene {
+	t.Helper()
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	if s.Name == "" {
+		s.Name = "Test Scene"
+	}
+	if s.OwnerDID == "" {
+		s.OwnerDID = "did:plc:owner"
+	}
+	if s.CoarseGeohash == "" {
+		s.CoarseGeohash = "dr5regw"
+	}
+	if err := e.scenes.Insert(t.Context(), s); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+	return s
+}
+
+// addEvent inserts ev, defaulting an empty ID to a new UUID, Title to
+// "Event <ID>", and CoarseGeohash to dr5regw.
+func (e *testEnv) addEvent(t *testing.T, ev *scene.Event) *scene.Event {
+	t.Helper()
+	if ev.ID == "" {
+		ev.ID = uuid.New().String()
+	}
+	if ev.Title == "" {
+		ev.Title = "Event " + ev.ID
+	}
+	if ev.CoarseGeohash == "" {
+		ev.CoarseGeohash = "dr5regw"
+	}
+	if err := e.events.Insert(t.Context(), ev); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+	return ev
+}
+
+// addMember upserts a membership of userDID in sceneID with role and status,
+// returning its ID.
+func (e *testEnv) addMember(t *testing.T, sceneID, userDID, role, status string) string {
+	t.Helper()
+	result, err := e.memberships.Upsert(t.Context(), &membership.Membership{
+		SceneID: sceneID,
+		UserDID: userDID,
+		Role:    role,
+		Status:  status,
+	})
+	if err != nil {
+		t.Fatalf("failed to insert membership: %v", err)
+	}
+	return result.ID
+}
+
+// addRSVP upserts userID's RSVP to eventID with status.
+func (e *testEnv) addRSVP(t *testing.T, eventID, userID, status string) {
+	t.Helper()
+	if err := e.rsvps.Upsert(t.Context(), &scene.RSVP{EventID: eventID, UserID: userID, Status: status}); err != nil {
+		t.Fatalf("failed to insert RSVP: %v", err)
+	}
+}
+
+// logAudit records entry in the audit log.
+func (e *testEnv) logAudit(t *testing.T, entry audit.LogEntry) {
+	t.Helper()
+	if _, err := e.audit.LogAccess(t.Context(), entry); err != nil {
+		t.Fatalf("failed to log audit entry: %v", err)
+	}
+}
@@ -59,30 +59,26 @@ func (h *LiveKitHandlers) IssueToken(w http.ResponseWriter, r *http.Request) {
 	// Extract user DID from context (set by auth middleware)
 	userDID := middleware.GetUserDID(ctx)
 	if userDID == "" {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
-		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
 		return
 	}
 
 	// Parse request body
 	var req LiveKitTokenRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid request body")
+		writeError(w, r, ErrCodeBadRequest, "Invalid request body")
 		return
 	}
 
 	// Validate room ID
 	req.RoomID = strings.TrimSpace(req.RoomID)
 	if req.RoomID == "" {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "room_id is required")
+		writeError(w, r, ErrCodeValidation, "room_id is required")
 		return
 	}
 
 	if !validateRoomID(req.RoomID) {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "room_id contains invalid characters or exceeds maximum length")
+		writeError(w, r, ErrCodeValidation, "room_id contains invalid characters or exceeds maximum length")
 		return
 	}
 
@@ -118,8 +114,7 @@ func (h *LiveKitHandlers) IssueToken(w http.ResponseWriter, r *http.Request) {
 			"room_id", req.RoomID,
 			"user_did", userDID,
 		)
-		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to generate token")
+		writeError(w, r, ErrCodeInternal, "Failed to generate token")
 		return
 	}
 
@@ -133,7 +128,7 @@ func (h *LiveKitHandlers) IssueToken(w http.ResponseWriter, r *http.Request) {
 		RequestID:  middleware.GetRequestID(ctx),
 	}
 
-	if _, err := h.auditRepo.LogAccess(auditEntry); err != nil {
+	if _, err := h.auditRepo.LogAccess(ctx, auditEntry); err != nil {
 		// Log error but don't fail the request
 		slog.ErrorContext(ctx, "failed to log token issuance audit entry",
 			"error", err,
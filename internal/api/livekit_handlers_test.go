@@ -159,7 +159,7 @@ func TestIssueToken_WithSceneAndEvent(t *testing.T) {
 	}
 
 	// Verify audit log was created
-	entries, err := auditRepo.QueryByEntity("livekit_room", "test-room-123", 10)
+	entries, err := auditRepo.QueryByEntity(ctx, "livekit_room", "test-room-123", 10)
 	if err != nil {
 		t.Fatalf("failed to query audit entries: %v", err)
 	}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+
+	"github.com/onnwee/subcults/internal/geo"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// DefaultLocationAnonymityK is the default number of public scenes that must
+// share a coarse cell before the cell is revealed.
+const DefaultLocationAnonymityK = 3
+
+// MinAnonymizedPrecision is the coarsest geohash precision (~±78 km) a sparse
+// cell is widened to. Scenes still alone at this precision are served
+// without a coarse location.
+const MinAnonymizedPrecision = 3
+
+// locationAnonymizer coarsens scene locations in sparse areas so a lone
+// scene cannot be picked out by its cell. Cell populations are counted once
+// per request and reused.
+type locationAnonymizer struct {
+	repo   scene.SceneRepository
+	k      int
+	counts map[string]int // geohash prefix -> public scenes in the cell
+}
+
+// newLocationAnonymizer returns an anonymizer for a single request.
+func (h *SceneHandlers) newLocationAnonymizer() *locationAnonymizer {
+	return &locationAnonymizer{
+		repo:   h.repo,
+		k:      h.locationAnonymityK,
+		counts: make(map[string]int),
+	}
+}
+
+// coarseGeohash returns the location a viewer may see for a scene: the
+// stored coarse geohash truncated to the viewer's precision, widened one
+// character at a time until at least k public scenes share the cell. It
+// returns "" when even MinAnonymizedPrecision is too sparse. Owners always
+// see the stored value.
+func (a *locationAnonymizer) coarseGeohash(ctx context.Context, sc *scene.Scene, viewerLevel string) (string, error) {
+	hash := geo.RoundGeohash(sc.CoarseGeohash, geo.PrecisionForVisibility(viewerLevel))
+	if viewerLevel == geo.ViewerOwner || a.k <= 1 || len(hash) < MinAnonymizedPrecision {
+		return hash, nil
+	}
+
+	for precision := len(hash); precision >= MinAnonymizedPrecision; precision-- {
+		cell := hash[:precision]
+		count, err := a.cellPopulation(ctx, cell)
+		if err != nil {
+			return "", err
+		}
+		// Non-public scenes are not counted by the repository but still
+		// occupy the cell
+		if sc.Visibility != scene.VisibilityPublic {
+			count++
+		}
+		if count >= a.k {
+			return cell, nil
+		}
+	}
+	return "", nil
+}
+
+// cellPopulation counts the public, non-deleted scenes whose coarse cell lies
+// within the geohash cell.
+func (a *locationAnonymizer) cellPopulation(ctx context.Context, cell string) (int, error) {
+	if count, ok := a.counts[cell]; ok {
+		return count, nil
+	}
+
+	b, ok := geo.DecodeBounds(cell)
+	if !ok {
+		return 0, nil
+	}
+	scenes, err := a.repo.FindInBbox(ctx, b.MinLng, b.MinLat, b.MaxLng, b.MaxLat)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, sc := range scenes {
+		if geo.RoundGeohash(sc.CoarseGeohash, len(cell)) == cell {
+			count++
+		}
+	}
+	a.counts[cell] = count
+	return count, nil
+}
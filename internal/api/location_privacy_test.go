@@ -0,0 +1,213 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/geo"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+const (
+	isolatedSceneID = "7a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"
+	sparseSceneID   = "8b2c3d4e-5f6a-4b7c-9d8e-0f1a2b3c4d5e"
+	denseSceneID    = "9c3d4e5f-6a7b-4c8d-8e9f-1a2b3c4d5e6f"
+)
+
+// seedLocationPrivacyScenes seeds three scenes sharing dr5re, two more
+// alone in their own dr5r sub-cells, and one isolated rural scene that has
+// no neighbours even at MinAnonymizedPrecision.
+func seedLocationPrivacyScenes(t *testing.T, env *testEnv) {
+	t.Helper()
+
+	fixtures := []struct {
+		id, geohash string
+	}{
+		{denseSceneID, "dr5regw"},
+		{"a1000000-0000-4000-8000-000000000001", "dr5regy"},
+		{"a1000000-0000-4000-8000-000000000002", "dr5rekp"},
+		{sparseSceneID, "dr5r000"},
+		{"a1000000-0000-4000-8000-000000000003", "dr5r100"},
+		{isolatedSceneID, "c8vx0p0"},
+	}
+	for _, f := range fixtures {
+		env.addScene(t, &scene.Scene{ID: f.id, Name: "Scene " + f.geohash, CoarseGeohash: f.geohash, Visibility: scene.VisibilityPublic})
+	}
+}
+
+func getSceneGeohash(t *testing.T, handlers *SceneHandlers, sceneID, requesterDID string) string {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/scenes/"+sceneID, nil)
+	if requesterDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), requesterDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.GetScene(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var got scene.Scene
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return got.CoarseGeohash
+}
+
+func TestGetScene_SparseCellsCoarsened(t *testing.T) {
+	env := newTestEnv()
+	seedLocationPrivacyScenes(t, env)
+	handlers := env.sceneHandlers()
+
+	tests := []struct {
+		name         string
+		sceneID      string
+		requesterDID string
+		want         string
+	}{
+		{"dense cell at public precision", denseSceneID, "", "dr5re"},
+		{"sparse cell widened", sparseSceneID, "", "dr5r"},
+		{"isolated scene has no location", isolatedSceneID, "", ""},
+		{"owner sees isolated scene", isolatedSceneID, "did:plc:owner", "c8vx0p0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getSceneGeohash(t, handlers, tt.sceneID, tt.requesterDID); got != tt.want {
+				t.Errorf("expected coarse_geohash %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	// K is configurable
+	handlers.SetLocationAnonymityK(1)
+	if got := getSceneGeohash(t, handlers, isolatedSceneID, ""); got != "c8vx0" {
+		t.Errorf("expected public precision with K=1, got %q", got)
+	}
+}
+
+// TestGetScene_ETagTracksCellDensity tests that new neighbours narrowing a
+// sparse scene's public cell invalidate its ETag, though the scene itself was
+// not written.
+func TestGetScene_ETagTracksCellDensity(t *testing.T) {
+	env := newTestEnv()
+	seedLocationPrivacyScenes(t, env)
+	handlers := env.sceneHandlers()
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/scenes/"+sparseSceneID, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		handlers.GetScene(w, req)
+		return w
+	}
+
+	w := get("")
+	etag := w.Header().Get("ETag")
+	if etag != `"1-dr5r"` {
+		t.Fatalf("expected ETag \"1-dr5r\", got %q", etag)
+	}
+	if w = get(etag); w.Code != http.StatusNotModified {
+		t.Fatalf("expected status 304 before density change, got %d", w.Code)
+	}
+
+	for _, id := range []string{"a1000000-0000-4000-8000-000000000004", "a1000000-0000-4000-8000-000000000005"} {
+		if err := handlers.repo.Insert(t.Context(), &scene.Scene{
+			ID:            id,
+			Name:          "Neighbour " + id,
+			OwnerDID:      "did:plc:neighbour",
+			CoarseGeohash: "dr5r00x",
+			Visibility:    scene.VisibilityPublic,
+		}); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+	}
+
+	w = get(etag)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 after density change, got %d", w.Code)
+	}
+	if got := w.Header().Get("ETag"); got != `"1-dr5r0"` {
+		t.Errorf("expected ETag \"1-dr5r0\", got %q", got)
+	}
+	var got scene.Scene
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.CoarseGeohash != "dr5r0" {
+		t.Errorf("expected coarse_geohash dr5r0, got %q", got.CoarseGeohash)
+	}
+}
+
+func TestListScenes_SparseCellsCoarsened(t *testing.T) {
+	env := newTestEnv()
+	seedLocationPrivacyScenes(t, env)
+	handlers := env.sceneHandlers()
+
+	req := httptest.NewRequest(http.MethodGet, "/scenes", nil)
+	w := httptest.NewRecorder()
+	handlers.ListScenes(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ListScenesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	want := map[string]string{denseSceneID: "dr5re", sparseSceneID: "dr5r", isolatedSceneID: ""}
+	for _, sc := range resp.Scenes {
+		if hash, ok := want[sc.ID]; ok && sc.CoarseGeohash != hash {
+			t.Errorf("scene %s: expected coarse_geohash %q, got %q", sc.ID, hash, sc.CoarseGeohash)
+		}
+	}
+}
+
+func TestDiscoverScenes_OmitsIsolatedScenes(t *testing.T) {
+	env := newTestEnv()
+	seedLocationPrivacyScenes(t, env)
+	handlers := env.sceneHandlers()
+	center, _ := geo.Decode("c8vx0p0")
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/scenes/discover?lat=%f&lng=%f&radius_m=5000", center.Lat, center.Lng), nil)
+	w := httptest.NewRecorder()
+	handlers.DiscoverScenes(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp DiscoverScenesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	for _, d := range resp.Scenes {
+		t.Errorf("expected the isolated scene to be omitted, got %s at %q (%.0fm)", d.Scene.ID, d.Scene.CoarseGeohash, d.DistanceMeters)
+	}
+}
+
+func TestDiscoverScenes_MeasuresFromWidenedCell(t *testing.T) {
+	env := newTestEnv()
+	seedLocationPrivacyScenes(t, env)
+	handlers := env.sceneHandlers()
+	center, _ := geo.Decode("dr5r000")
+
+	// The sparse scene's own cell is within 100 m, but its widened dr5r
+	// cell center is kilometres away
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/scenes/discover?lat=%f&lng=%f&radius_m=100", center.Lat, center.Lng), nil)
+	w := httptest.NewRecorder()
+	handlers.DiscoverScenes(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp DiscoverScenesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	for _, d := range resp.Scenes {
+		t.Errorf("expected no scene within 100 m of a widened cell, got %s at %q", d.Scene.ID, d.Scene.CoarseGeohash)
+	}
+}
@@ -2,6 +2,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
@@ -41,8 +42,7 @@ func (h *MembershipHandlers) RequestMembership(w http.ResponseWriter, r *http.Re
 	// Extract scene ID from URL path
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
 	if len(pathParts) < 2 || pathParts[0] == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Scene ID is required")
+		writeError(w, r, ErrCodeBadRequest, "Scene ID is required")
 		return
 	}
 	sceneID := pathParts[0]
@@ -50,53 +50,50 @@ func (h *MembershipHandlers) RequestMembership(w http.ResponseWriter, r *http.Re
 	// Get authenticated user DID from context
 	userDID := middleware.GetUserDID(r.Context())
 	if userDID == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
-		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
 		return
 	}
 
 	// Verify scene exists
-	existingScene, err := h.sceneRepo.GetByID(sceneID)
+	existingScene, err := h.sceneRepo.GetByID(r.Context(), sceneID)
 	if err != nil {
 		if err == scene.ErrSceneNotFound {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve scene")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
 		return
 	}
 
 	// Check if user is already the scene owner
 	if existingScene.OwnerDID == userDID {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeConflict)
-		WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "Scene owner cannot request membership")
+		writeError(w, r, ErrCodeConflict, "Scene owner cannot request membership")
 		return
 	}
 
 	// Check for existing membership
-	existingMembership, err := h.membershipRepo.GetBySceneAndUser(sceneID, userDID)
+	existingMembership, err := h.membershipRepo.GetBySceneAndUser(r.Context(), sceneID, userDID)
 	if err == nil {
 		// Membership exists
 		if existingMembership.Status == "pending" {
 			// Duplicate pending request - return 409 Conflict
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeConflict)
-			WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "Pending membership request already exists")
+			writeError(w, r, ErrCodeConflict, "Pending membership request already exists")
 			return
 		}
 		if existingMembership.Status == "active" {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeConflict)
-			WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "User is already an active member")
+			writeError(w, r, ErrCodeConflict, "User is already an active member")
+			return
+		}
+		if existingMembership.Status == "banned" {
+			writeError(w, r, ErrCodeForbidden, "You cannot join this scene")
 			return
 		}
 		// If status is "rejected", allow creating a new request by updating the existing one
 	} else if err != membership.ErrMembershipNotFound {
 		// Unexpected error
 		slog.ErrorContext(r.Context(), "failed to check existing membership", "error", err, "scene_id", sceneID, "user_did", userDID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to check existing membership")
+		writeError(w, r, ErrCodeInternal, "Failed to check existing membership")
 		return
 	}
 
@@ -114,11 +111,10 @@ func (h *MembershipHandlers) RequestMembership(w http.ResponseWriter, r *http.Re
 		newMembership.ID = existingMembership.ID
 	}
 
-	result, err := h.membershipRepo.Upsert(newMembership)
+	result, err := h.membershipRepo.Upsert(r.Context(), newMembership)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to create membership request", "error", err, "scene_id", sceneID, "user_did", userDID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to create membership request")
+		writeError(w, r, ErrCodeInternal, "Failed to create membership request")
 		return
 	}
 
@@ -131,11 +127,10 @@ func (h *MembershipHandlers) RequestMembership(w http.ResponseWriter, r *http.Re
 	}
 
 	// Retrieve the created/updated membership to get complete data with timestamps
-	createdMembership, err := h.membershipRepo.GetByID(result.ID)
+	createdMembership, err := h.membershipRepo.GetByID(r.Context(), result.ID)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to retrieve created membership", "error", err, "membership_id", result.ID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve created membership")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve created membership")
 		return
 	}
 
@@ -153,8 +148,7 @@ func (h *MembershipHandlers) ApproveMembership(w http.ResponseWriter, r *http.Re
 	// Extract scene ID and user DID from URL path
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
 	if len(pathParts) < 4 || pathParts[0] == "" || pathParts[2] == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Scene ID and User DID are required")
+		writeError(w, r, ErrCodeBadRequest, "Scene ID and User DID are required")
 		return
 	}
 	sceneID := pathParts[0]
@@ -162,70 +156,65 @@ func (h *MembershipHandlers) ApproveMembership(w http.ResponseWriter, r *http.Re
 	// URL decode the DID
 	targetUserDID, err := url.PathUnescape(pathParts[2])
 	if err != nil {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid user DID in URL")
+		writeError(w, r, ErrCodeBadRequest, "Invalid user DID in URL")
 		return
 	}
 
 	// Get authenticated user DID from context
 	ownerDID := middleware.GetUserDID(r.Context())
 	if ownerDID == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
-		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
 		return
 	}
 
 	// Verify scene exists and user is owner
-	existingScene, err := h.sceneRepo.GetByID(sceneID)
+	existingScene, err := h.sceneRepo.GetByID(r.Context(), sceneID)
 	if err != nil {
 		if err == scene.ErrSceneNotFound {
 			// Use uniform error message to prevent enumeration
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve scene")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
 		return
 	}
 
 	// Check authorization - only owner can approve
 	if existingScene.OwnerDID != ownerDID {
 		// Use uniform error message to prevent enumeration
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
-		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "Only scene owner can approve memberships")
+		writeError(w, r, ErrCodeForbidden, "Only scene owner can approve memberships")
 		return
 	}
 
 	// Get the membership to approve
-	existingMembership, err := h.membershipRepo.GetBySceneAndUser(sceneID, targetUserDID)
+	existingMembership, err := h.membershipRepo.GetBySceneAndUser(r.Context(), sceneID, targetUserDID)
 	if err != nil {
 		if err == membership.ErrMembershipNotFound {
 			// Use uniform error message to prevent enumeration
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Membership request not found")
+			writeError(w, r, ErrCodeNotFound, "Membership request not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to retrieve membership", "error", err, "scene_id", sceneID, "user_did", targetUserDID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve membership")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve membership")
 		return
 	}
 
-	// Verify membership is in pending status
+	// Approving a non-pending membership is a no-op that returns the current state
 	if existingMembership.Status != "pending" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeConflict)
-		WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "Only pending membership requests can be approved")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(existingMembership); err != nil {
+			return
+		}
 		return
 	}
 
 	// Update status to active with current timestamp as since
 	now := time.Now()
-	if err := h.membershipRepo.UpdateStatus(existingMembership.ID, "active", &now); err != nil {
+	if err := h.membershipRepo.UpdateStatus(r.Context(), existingMembership.ID, "active", &now); err != nil {
 		slog.ErrorContext(r.Context(), "failed to approve membership", "error", err, "membership_id", existingMembership.ID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to approve membership")
+		writeError(w, r, ErrCodeInternal, "Failed to approve membership")
 		return
 	}
 
@@ -238,11 +227,10 @@ func (h *MembershipHandlers) ApproveMembership(w http.ResponseWriter, r *http.Re
 	}
 
 	// Get updated membership for response
-	updatedMembership, err := h.membershipRepo.GetByID(existingMembership.ID)
+	updatedMembership, err := h.membershipRepo.GetByID(r.Context(), existingMembership.ID)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to retrieve approved membership", "error", err, "membership_id", existingMembership.ID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve approved membership")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve approved membership")
 		return
 	}
 
@@ -260,8 +248,7 @@ func (h *MembershipHandlers) RejectMembership(w http.ResponseWriter, r *http.Req
 	// Extract scene ID and user DID from URL path
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
 	if len(pathParts) < 4 || pathParts[0] == "" || pathParts[2] == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Scene ID and User DID are required")
+		writeError(w, r, ErrCodeBadRequest, "Scene ID and User DID are required")
 		return
 	}
 	sceneID := pathParts[0]
@@ -269,69 +256,66 @@ func (h *MembershipHandlers) RejectMembership(w http.ResponseWriter, r *http.Req
 	// URL decode the DID
 	targetUserDID, err := url.PathUnescape(pathParts[2])
 	if err != nil {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid user DID in URL")
+		writeError(w, r, ErrCodeBadRequest, "Invalid user DID in URL")
 		return
 	}
 
 	// Get authenticated user DID from context
 	ownerDID := middleware.GetUserDID(r.Context())
 	if ownerDID == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
-		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
 		return
 	}
 
 	// Verify scene exists and user is owner
-	existingScene, err := h.sceneRepo.GetByID(sceneID)
+	existingScene, err := h.sceneRepo.GetByID(r.Context(), sceneID)
 	if err != nil {
 		if err == scene.ErrSceneNotFound {
 			// Use uniform error message to prevent enumeration
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve scene")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
 		return
 	}
 
 	// Check authorization - only owner can reject
 	if existingScene.OwnerDID != ownerDID {
 		// Use uniform error message to prevent enumeration
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
-		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "Only scene owner can reject memberships")
+		writeError(w, r, ErrCodeForbidden, "Only scene owner can reject memberships")
 		return
 	}
 
 	// Get the membership to reject
-	existingMembership, err := h.membershipRepo.GetBySceneAndUser(sceneID, targetUserDID)
+	existingMembership, err := h.membershipRepo.GetBySceneAndUser(r.Context(), sceneID, targetUserDID)
 	if err != nil {
 		if err == membership.ErrMembershipNotFound {
 			// Use uniform error message to prevent enumeration
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Membership request not found")
+			writeError(w, r, ErrCodeNotFound, "Membership request not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to retrieve membership", "error", err, "scene_id", sceneID, "user_did", targetUserDID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve membership")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve membership")
+		return
+	}
+
+	// Active members are removed, not rejected
+	if existingMembership.Status == "active" {
+		writeError(w, r, ErrCodeConflict, "Active members must be removed, not rejected")
 		return
 	}
 
 	// Verify membership is in pending status
 	if existingMembership.Status != "pending" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeConflict)
-		WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "Only pending membership requests can be rejected")
+		writeError(w, r, ErrCodeConflict, "Only pending membership requests can be rejected")
 		return
 	}
 
 	// Update status to rejected (without changing since timestamp)
-	if err := h.membershipRepo.UpdateStatus(existingMembership.ID, "rejected", nil); err != nil {
+	if err := h.membershipRepo.UpdateStatus(r.Context(), existingMembership.ID, "rejected", nil); err != nil {
 		slog.ErrorContext(r.Context(), "failed to reject membership", "error", err, "membership_id", existingMembership.ID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to reject membership")
+		writeError(w, r, ErrCodeInternal, "Failed to reject membership")
 		return
 	}
 
@@ -344,11 +328,10 @@ func (h *MembershipHandlers) RejectMembership(w http.ResponseWriter, r *http.Req
 	}
 
 	// Get updated membership for response
-	updatedMembership, err := h.membershipRepo.GetByID(existingMembership.ID)
+	updatedMembership, err := h.membershipRepo.GetByID(r.Context(), existingMembership.ID)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to retrieve rejected membership", "error", err, "membership_id", existingMembership.ID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve rejected membership")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve rejected membership")
 		return
 	}
 
@@ -359,3 +342,398 @@ func (h *MembershipHandlers) RejectMembership(w http.ResponseWriter, r *http.Req
 		return
 	}
 }
+
+// RemoveMember handles POST /scenes/{id}/membership/{userId}/remove
+// Removes an active member from a scene (scene owner only).
+// The membership record is deleted so the user may request membership again.
+func (h *MembershipHandlers) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	// Extract scene ID and user DID from URL path
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
+	if len(pathParts) < 4 || pathParts[0] == "" || pathParts[2] == "" {
+		writeError(w, r, ErrCodeBadRequest, "Scene ID and User DID are required")
+		return
+	}
+	sceneID := pathParts[0]
+
+	// URL decode the DID
+	targetUserDID, err := url.PathUnescape(pathParts[2])
+	if err != nil {
+		writeError(w, r, ErrCodeBadRequest, "Invalid user DID in URL")
+		return
+	}
+
+	// Get authenticated user DID from context
+	ownerDID := middleware.GetUserDID(r.Context())
+	if ownerDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	// Verify scene exists and user is owner
+	existingScene, err := h.sceneRepo.GetByID(r.Context(), sceneID)
+	if err != nil {
+		if err == scene.ErrSceneNotFound {
+			// Use uniform error message to prevent enumeration
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+		return
+	}
+
+	// Check authorization - only owner can remove members
+	if existingScene.OwnerDID != ownerDID {
+		writeError(w, r, ErrCodeForbidden, "Only scene owner can remove members")
+		return
+	}
+
+	// Get the membership to remove
+	existingMembership, err := h.membershipRepo.GetBySceneAndUser(r.Context(), sceneID, targetUserDID)
+	if err != nil {
+		if err == membership.ErrMembershipNotFound {
+			// Use uniform error message to prevent enumeration
+			writeError(w, r, ErrCodeNotFound, "Membership not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve membership", "error", err, "scene_id", sceneID, "user_did", targetUserDID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve membership")
+		return
+	}
+
+	// Pending requests are rejected, not removed
+	if existingMembership.Status != "active" {
+		writeError(w, r, ErrCodeConflict, "Only active members can be removed")
+		return
+	}
+
+	if err := h.membershipRepo.Delete(r.Context(), existingMembership.ID); err != nil {
+		slog.ErrorContext(r.Context(), "failed to remove member", "error", err, "membership_id", existingMembership.ID)
+		writeError(w, r, ErrCodeInternal, "Failed to remove member")
+		return
+	}
+
+	// Audit log the removal
+	if h.auditRepo != nil {
+		if err := audit.LogAccessFromRequest(r, h.auditRepo, "membership", existingMembership.ID, "membership_remove"); err != nil {
+			slog.WarnContext(r.Context(), "failed to log member removal audit", "error", err, "membership_id", existingMembership.ID)
+			// Continue - audit failure should not block the operation
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RequestJoin handles POST /scenes/{id}/join
+// Creates a pending membership so the authenticated user can request access to a
+// members-only scene. Idempotent: an existing pending or active membership is
+// returned unchanged. Banned users are rejected with 403. Hidden scenes return
+// the same 404 as missing scenes.
+func (h *MembershipHandlers) RequestJoin(w http.ResponseWriter, r *http.Request) {
+	// Extract scene ID from URL path
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
+	if len(pathParts) < 2 || pathParts[0] == "" {
+		writeError(w, r, ErrCodeBadRequest, "Scene ID is required")
+		return
+	}
+	sceneID := pathParts[0]
+
+	// Get authenticated user DID from context
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	// Verify scene exists
+	existingScene, err := h.sceneRepo.GetByID(r.Context(), sceneID)
+	if err != nil {
+		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+			// Use uniform error message to prevent enumeration
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+		return
+	}
+
+	if existingScene.IsOwner(userDID) {
+		writeError(w, r, ErrCodeConflict, "Scene owner cannot request membership")
+		return
+	}
+
+	switch existingScene.Visibility {
+	case scene.VisibilityMembersOnly:
+		// Join requests are the only path into members-only scenes
+	case scene.VisibilityPublic:
+		writeError(w, r, ErrCodeValidation, "Public scenes do not require a join request")
+		return
+	default:
+		// Hidden (and unknown) scenes must not reveal that they exist
+		slog.DebugContext(r.Context(), "join request for non-joinable scene", "scene_id", sceneID)
+		writeError(w, r, ErrCodeNotFound, "Scene not found")
+		return
+	}
+
+	var membershipID string
+
+	existingMembership, err := h.membershipRepo.GetBySceneAndUser(r.Context(), sceneID, userDID)
+	switch {
+	case err == nil && existingMembership.Status == "banned":
+		writeError(w, r, ErrCodeForbidden, "You cannot join this scene")
+		return
+	case err == nil && existingMembership.Status == "rejected":
+		// Reopen a previously rejected request
+		if err := h.membershipRepo.UpdateStatus(r.Context(), existingMembership.ID, "pending", nil); err != nil {
+			slog.ErrorContext(r.Context(), "failed to reopen membership request", "error", err, "membership_id", existingMembership.ID)
+			writeError(w, r, ErrCodeInternal, "Failed to create membership request")
+			return
+		}
+		membershipID = existingMembership.ID
+	case err == nil:
+		// Pending or active membership already exists - return it unchanged
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(existingMembership); err != nil {
+			return
+		}
+		return
+	case err == membership.ErrMembershipNotFound:
+		result, err := h.membershipRepo.Upsert(r.Context(), &membership.Membership{
+			SceneID:     sceneID,
+			UserDID:     userDID,
+			Role:        "member", // Default role for requests
+			Status:      "pending",
+			TrustWeight: 0.5, // Default trust weight
+		})
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to create membership request", "error", err, "scene_id", sceneID, "user_did", userDID)
+			writeError(w, r, ErrCodeInternal, "Failed to create membership request")
+			return
+		}
+		membershipID = result.ID
+	default:
+		slog.ErrorContext(r.Context(), "failed to check existing membership", "error", err, "scene_id", sceneID, "user_did", userDID)
+		writeError(w, r, ErrCodeInternal, "Failed to check existing membership")
+		return
+	}
+
+	// Audit log the join request
+	if h.auditRepo != nil {
+		if err := audit.LogAccessFromRequest(r, h.auditRepo, "membership", membershipID, "membership_request"); err != nil {
+			slog.WarnContext(r.Context(), "failed to log membership request audit", "error", err, "membership_id", membershipID)
+			// Continue - audit failure should not block the operation
+		}
+	}
+
+	pendingMembership, err := h.membershipRepo.GetByID(r.Context(), membershipID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to retrieve membership request", "error", err, "membership_id", membershipID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve membership request")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(pendingMembership); err != nil {
+		return
+	}
+}
+
+// moderationTarget parses /scenes/{id}/membership/{userId}/{action}, authenticates
+// the caller, and loads the scene. It writes an error response and returns ok=false
+// if any step fails.
+func (h *MembershipHandlers) moderationTarget(w http.ResponseWriter, r *http.Request) (targetScene *scene.Scene, callerDID, targetUserDID string, ok bool) {
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
+	if len(pathParts) < 4 || pathParts[0] == "" || pathParts[2] == "" {
+		writeError(w, r, ErrCodeBadRequest, "Scene ID and User DID are required")
+		return nil, "", "", false
+	}
+	sceneID := pathParts[0]
+
+	targetUserDID, err := url.PathUnescape(pathParts[2])
+	if err != nil {
+		writeError(w, r, ErrCodeBadRequest, "Invalid user DID in URL")
+		return nil, "", "", false
+	}
+
+	callerDID = middleware.GetUserDID(r.Context())
+	if callerDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return nil, "", "", false
+	}
+
+	targetScene, err = h.sceneRepo.GetByID(r.Context(), sceneID)
+	if err != nil {
+		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
+			return nil, "", "", false
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+		return nil, "", "", false
+	}
+
+	return targetScene, callerDID, targetUserDID, true
+}
+
+// isSceneModerator reports whether userDID may moderate the scene:
+// the owner, or an active member with the admin role.
+func (h *MembershipHandlers) isSceneModerator(ctx context.Context, s *scene.Scene, userDID string) (bool, error) {
+	return isModeratorForScene(ctx, h.membershipRepo, s, userDID)
+}
+
+// isModeratorForScene reports whether userDID may moderate the scene using the
+// given membership repository. A nil repository only admits the owner.
+func isModeratorForScene(ctx context.Context, membershipRepo membership.MembershipRepository, s *scene.Scene, userDID string) (bool, error) {
+	if s.IsOwner(userDID) {
+		return true, nil
+	}
+	if userDID == "" || membershipRepo == nil {
+		return false, nil
+	}
+	m, err := membershipRepo.GetBySceneAndUser(ctx, s.ID, userDID)
+	if err != nil {
+		if err == membership.ErrMembershipNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return m.Status == "active" && m.Role == "admin", nil
+}
+
+// BanMember handles POST /scenes/{id}/membership/{userId}/ban
+// Bans a user from a scene (scene owner or admin only). A user without a
+// membership is banned pre-emptively. Banning an already banned user is a no-op.
+func (h *MembershipHandlers) BanMember(w http.ResponseWriter, r *http.Request) {
+	targetScene, callerDID, targetUserDID, ok := h.moderationTarget(w, r)
+	if !ok {
+		return
+	}
+
+	isModerator, err := h.isSceneModerator(r.Context(), targetScene, callerDID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to check moderator access", "error", err, "scene_id", targetScene.ID)
+		writeError(w, r, ErrCodeInternal, "Failed to check access permissions")
+		return
+	}
+	if !isModerator {
+		writeError(w, r, ErrCodeForbidden, "Only scene owner or admins can ban members")
+		return
+	}
+
+	// The owner cannot be banned
+	if targetScene.IsOwner(targetUserDID) {
+		writeError(w, r, ErrCodeConflict, "Scene owner cannot be banned")
+		return
+	}
+
+	var membershipID string
+	existingMembership, err := h.membershipRepo.GetBySceneAndUser(r.Context(), targetScene.ID, targetUserDID)
+	switch {
+	case err == nil && existingMembership.Status == "banned":
+		// Already banned - return current state without a new audit entry
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(existingMembership); err != nil {
+			return
+		}
+		return
+	case err == nil:
+		if existingMembership.Role == "admin" && !targetScene.IsOwner(callerDID) {
+			writeError(w, r, ErrCodeForbidden, "Only scene owner can ban admins")
+			return
+		}
+		if err := h.membershipRepo.UpdateStatus(r.Context(), existingMembership.ID, "banned", nil); err != nil {
+			slog.ErrorContext(r.Context(), "failed to ban member", "error", err, "membership_id", existingMembership.ID)
+			writeError(w, r, ErrCodeInternal, "Failed to ban member")
+			return
+		}
+		membershipID = existingMembership.ID
+	case err == membership.ErrMembershipNotFound:
+		result, err := h.membershipRepo.Upsert(r.Context(), &membership.Membership{
+			SceneID: targetScene.ID,
+			UserDID: targetUserDID,
+			Role:    "member",
+			Status:  "banned",
+		})
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to ban user", "error", err, "scene_id", targetScene.ID, "user_did", targetUserDID)
+			writeError(w, r, ErrCodeInternal, "Failed to ban member")
+			return
+		}
+		membershipID = result.ID
+	default:
+		slog.ErrorContext(r.Context(), "failed to retrieve membership", "error", err, "scene_id", targetScene.ID, "user_did", targetUserDID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve membership")
+		return
+	}
+
+	// Audit log the ban
+	if h.auditRepo != nil {
+		if err := audit.LogAccessFromRequest(r, h.auditRepo, "membership", membershipID, "membership_ban"); err != nil {
+			slog.WarnContext(r.Context(), "failed to log membership ban audit", "error", err, "membership_id", membershipID)
+			// Continue - audit failure should not block the operation
+		}
+	}
+
+	bannedMembership, err := h.membershipRepo.GetByID(r.Context(), membershipID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to retrieve banned membership", "error", err, "membership_id", membershipID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve banned membership")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(bannedMembership); err != nil {
+		return
+	}
+}
+
+// UnbanMember handles POST /scenes/{id}/membership/{userId}/unban
+// Lifts a ban (scene owner only). The membership record is deleted so the
+// user starts over as a non-member.
+func (h *MembershipHandlers) UnbanMember(w http.ResponseWriter, r *http.Request) {
+	targetScene, callerDID, targetUserDID, ok := h.moderationTarget(w, r)
+	if !ok {
+		return
+	}
+
+	if !targetScene.IsOwner(callerDID) {
+		writeError(w, r, ErrCodeForbidden, "Only scene owner can unban members")
+		return
+	}
+
+	existingMembership, err := h.membershipRepo.GetBySceneAndUser(r.Context(), targetScene.ID, targetUserDID)
+	if err != nil {
+		if err == membership.ErrMembershipNotFound {
+			writeError(w, r, ErrCodeNotFound, "Ban not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve membership", "error", err, "scene_id", targetScene.ID, "user_did", targetUserDID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve membership")
+		return
+	}
+	if existingMembership.Status != "banned" {
+		writeError(w, r, ErrCodeNotFound, "Ban not found")
+		return
+	}
+
+	if err := h.membershipRepo.Delete(r.Context(), existingMembership.ID); err != nil {
+		slog.ErrorContext(r.Context(), "failed to unban member", "error", err, "membership_id", existingMembership.ID)
+		writeError(w, r, ErrCodeInternal, "Failed to unban member")
+		return
+	}
+
+	// Audit log the unban
+	if h.auditRepo != nil {
+		if err := audit.LogAccessFromRequest(r, h.auditRepo, "membership", existingMembership.ID, "membership_unban"); err != nil {
+			slog.WarnContext(r.Context(), "failed to log membership unban audit", "error", err, "membership_id", existingMembership.ID)
+			// Continue - audit failure should not block the operation
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -27,7 +27,7 @@ func TestRequestMembership_Success(t *testing.T) {
 		OwnerDID:      "did:plc:owner",
 		CoarseGeohash: "u4pruydqqvj",
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("Failed to insert test scene: %v", err)
 	}
 
@@ -83,7 +83,7 @@ func TestRequestMembership_DuplicatePending(t *testing.T) {
 		OwnerDID:      "did:plc:owner",
 		CoarseGeohash: "u4pruydqqvj",
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("Failed to insert test scene: %v", err)
 	}
 
@@ -95,7 +95,7 @@ func TestRequestMembership_DuplicatePending(t *testing.T) {
 		Status:      "pending",
 		TrustWeight: 0.5,
 	}
-	if _, err := membershipRepo.Upsert(initialMembership); err != nil {
+	if _, err := membershipRepo.Upsert(t.Context(), initialMembership); err != nil {
 		t.Fatalf("Failed to create initial membership: %v", err)
 	}
 
@@ -139,7 +139,7 @@ func TestRequestMembership_OwnerCannotRequest(t *testing.T) {
 		OwnerDID:      "did:plc:owner",
 		CoarseGeohash: "u4pruydqqvj",
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("Failed to insert test scene: %v", err)
 	}
 
@@ -179,7 +179,7 @@ func TestRequestMembership_RejectedCanReapply(t *testing.T) {
 		OwnerDID:      "did:plc:owner",
 		CoarseGeohash: "u4pruydqqvj",
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("Failed to insert test scene: %v", err)
 	}
 
@@ -191,7 +191,7 @@ func TestRequestMembership_RejectedCanReapply(t *testing.T) {
 		Status:      "rejected",
 		TrustWeight: 0.5,
 	}
-	if _, err := membershipRepo.Upsert(rejectedMembership); err != nil {
+	if _, err := membershipRepo.Upsert(t.Context(), rejectedMembership); err != nil {
 		t.Fatalf("Failed to create rejected membership: %v", err)
 	}
 
@@ -231,7 +231,7 @@ func TestApproveMembership_Success(t *testing.T) {
 		OwnerDID:      "did:plc:owner",
 		CoarseGeohash: "u4pruydqqvj",
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("Failed to insert test scene: %v", err)
 	}
 
@@ -243,7 +243,7 @@ func TestApproveMembership_Success(t *testing.T) {
 		Status:      "pending",
 		TrustWeight: 0.5,
 	}
-	result, err := membershipRepo.Upsert(pendingMembership)
+	result, err := membershipRepo.Upsert(t.Context(), pendingMembership)
 	if err != nil {
 		t.Fatalf("Failed to create pending membership: %v", err)
 	}
@@ -275,7 +275,7 @@ func TestApproveMembership_Success(t *testing.T) {
 	}
 
 	// Verify in repository
-	retrieved, err := membershipRepo.GetByID(result.ID)
+	retrieved, err := membershipRepo.GetByID(ctx, result.ID)
 	if err != nil {
 		t.Fatalf("Failed to retrieve membership: %v", err)
 	}
@@ -298,7 +298,7 @@ func TestApproveMembership_Unauthorized(t *testing.T) {
 		OwnerDID:      "did:plc:owner",
 		CoarseGeohash: "u4pruydqqvj",
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("Failed to insert test scene: %v", err)
 	}
 
@@ -310,7 +310,7 @@ func TestApproveMembership_Unauthorized(t *testing.T) {
 		Status:      "pending",
 		TrustWeight: 0.5,
 	}
-	if _, err := membershipRepo.Upsert(pendingMembership); err != nil {
+	if _, err := membershipRepo.Upsert(t.Context(), pendingMembership); err != nil {
 		t.Fatalf("Failed to create pending membership: %v", err)
 	}
 
@@ -350,7 +350,7 @@ func TestApproveMembership_NotPending(t *testing.T) {
 		OwnerDID:      "did:plc:owner",
 		CoarseGeohash: "u4pruydqqvj",
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("Failed to insert test scene: %v", err)
 	}
 
@@ -362,7 +362,7 @@ func TestApproveMembership_NotPending(t *testing.T) {
 		Status:      "active",
 		TrustWeight: 0.5,
 	}
-	if _, err := membershipRepo.Upsert(activeMembership); err != nil {
+	if _, err := membershipRepo.Upsert(t.Context(), activeMembership); err != nil {
 		t.Fatalf("Failed to create active membership: %v", err)
 	}
 
@@ -374,18 +374,27 @@ func TestApproveMembership_NotPending(t *testing.T) {
 	w := httptest.NewRecorder()
 	handlers.ApproveMembership(w, req)
 
-	// Verify 409 Conflict
-	if w.Code != http.StatusConflict {
-		t.Errorf("Expected status 409, got %d. Body: %s", w.Code, w.Body.String())
+	// Approving a non-pending membership is a no-op returning current state
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
 	}
 
-	var errResp ErrorResponse
-	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
-		t.Fatalf("Failed to decode error response: %v", err)
+	var current membership.Membership
+	if err := json.NewDecoder(w.Body).Decode(&current); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
 	}
 
-	if !strings.Contains(errResp.Error.Message, "pending membership") {
-		t.Errorf("Expected error about pending membership, got: %s", errResp.Error.Message)
+	if current.Status != "active" {
+		t.Errorf("Expected status 'active', got %s", current.Status)
+	}
+
+	// No transition happened, so nothing should be audited
+	logs, err := auditRepo.QueryByEntity(ctx, "membership", current.ID, 0)
+	if err != nil {
+		t.Fatalf("Failed to query audit logs: %v", err)
+	}
+	if len(logs) != 0 {
+		t.Errorf("Expected no audit logs for no-op approval, got %d", len(logs))
 	}
 }
 
@@ -402,7 +411,7 @@ func TestRejectMembership_Success(t *testing.T) {
 		OwnerDID:      "did:plc:owner",
 		CoarseGeohash: "u4pruydqqvj",
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("Failed to insert test scene: %v", err)
 	}
 
@@ -416,7 +425,7 @@ func TestRejectMembership_Success(t *testing.T) {
 		TrustWeight: 0.5,
 		Since:       now,
 	}
-	result, err := membershipRepo.Upsert(pendingMembership)
+	result, err := membershipRepo.Upsert(t.Context(), pendingMembership)
 	if err != nil {
 		t.Fatalf("Failed to create pending membership: %v", err)
 	}
@@ -449,7 +458,7 @@ func TestRejectMembership_Success(t *testing.T) {
 	}
 
 	// Verify in repository
-	retrieved, err := membershipRepo.GetByID(result.ID)
+	retrieved, err := membershipRepo.GetByID(ctx, result.ID)
 	if err != nil {
 		t.Fatalf("Failed to retrieve membership: %v", err)
 	}
@@ -472,7 +481,7 @@ func TestRejectMembership_Unauthorized(t *testing.T) {
 		OwnerDID:      "did:plc:owner",
 		CoarseGeohash: "u4pruydqqvj",
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("Failed to insert test scene: %v", err)
 	}
 
@@ -484,7 +493,7 @@ func TestRejectMembership_Unauthorized(t *testing.T) {
 		Status:      "pending",
 		TrustWeight: 0.5,
 	}
-	if _, err := membershipRepo.Upsert(pendingMembership); err != nil {
+	if _, err := membershipRepo.Upsert(t.Context(), pendingMembership); err != nil {
 		t.Fatalf("Failed to create pending membership: %v", err)
 	}
 
@@ -524,7 +533,7 @@ func TestMembershipEnumerationProtection(t *testing.T) {
 		OwnerDID:      "did:plc:owner",
 		CoarseGeohash: "u4pruydqqvj",
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("Failed to insert test scene: %v", err)
 	}
 
@@ -544,7 +553,7 @@ func TestMembershipEnumerationProtection(t *testing.T) {
 		Status:      "pending",
 		TrustWeight: 0.5,
 	}
-	if _, err := membershipRepo.Upsert(pendingMembership); err != nil {
+	if _, err := membershipRepo.Upsert(t.Context(), pendingMembership); err != nil {
 		t.Fatalf("Failed to create pending membership: %v", err)
 	}
 
@@ -578,3 +587,435 @@ func TestMembershipEnumerationProtection(t *testing.T) {
 		t.Errorf("Error codes differ: %s vs %s (potential enumeration)", err1.Error.Code, err2.Error.Code)
 	}
 }
+
+// doMembershipAction calls handler for /scenes/scene-123/membership/did:plc:requester/{action} as callerDID.
+func doMembershipAction(handler http.HandlerFunc, action, callerDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/scenes/scene-123/membership/did:plc:requester/"+action, nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), callerDID))
+	w := httptest.NewRecorder()
+	handler(w, req)
+	return w
+}
+
+func TestApproveMembership_AuditsTransition(t *testing.T) {
+	env := newTestEnv()
+	env.addScene(t, &scene.Scene{ID: "scene-123", CoarseGeohash: "u4pruydqqvj"})
+	membershipID := env.addMember(t, "scene-123", "did:plc:requester", "member", "pending")
+	handlers := env.membershipHandlers()
+
+	w := doMembershipAction(handlers.ApproveMembership, "approve", "did:plc:owner")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	// Second approval is a no-op and must not add another audit entry
+	w = doMembershipAction(handlers.ApproveMembership, "approve", "did:plc:owner")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 on repeat approval, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	logs, err := env.audit.QueryByEntity(t.Context(), "membership", membershipID, 0)
+	if err != nil {
+		t.Fatalf("Failed to query audit logs: %v", err)
+	}
+	if len(logs) != 1 || logs[0].Action != "membership_approve" {
+		t.Errorf("Expected a single membership_approve audit log, got %+v", logs)
+	}
+}
+
+func TestRejectMembership_ActiveMember(t *testing.T) {
+	env := newTestEnv()
+	env.addScene(t, &scene.Scene{ID: "scene-123", CoarseGeohash: "u4pruydqqvj"})
+	membershipID := env.addMember(t, "scene-123", "did:plc:requester", "member", "active")
+	handlers := env.membershipHandlers()
+
+	w := doMembershipAction(handlers.RejectMembership, "reject", "did:plc:owner")
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status 409, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	stored, err := env.memberships.GetByID(t.Context(), membershipID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve membership: %v", err)
+	}
+	if stored.Status != "active" {
+		t.Errorf("Expected membership to remain active, got %s", stored.Status)
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         string
+		callerDID      string
+		expectedStatus int
+		expectRemoved  bool
+	}{
+		{"owner removes active member", "active", "did:plc:owner", http.StatusNoContent, true},
+		{"non-owner forbidden", "active", "did:plc:attacker", http.StatusForbidden, false},
+		{"member cannot remove themselves", "active", "did:plc:requester", http.StatusForbidden, false},
+		{"pending request must be rejected", "pending", "did:plc:owner", http.StatusConflict, false},
+		{"unauthenticated", "active", "", http.StatusUnauthorized, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv()
+			env.addScene(t, &scene.Scene{ID: "scene-123", CoarseGeohash: "u4pruydqqvj"})
+			membershipID := env.addMember(t, "scene-123", "did:plc:requester", "member", tt.status)
+			handlers := env.membershipHandlers()
+
+			w := doMembershipAction(handlers.RemoveMember, "remove", tt.callerDID)
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			_, err := env.memberships.GetByID(t.Context(), membershipID)
+			removed := err == membership.ErrMembershipNotFound
+			if removed != tt.expectRemoved {
+				t.Errorf("Expected removed=%v, got %v (err: %v)", tt.expectRemoved, removed, err)
+			}
+
+			logs, err := env.audit.QueryByEntity(t.Context(), "membership", membershipID, 0)
+			if err != nil {
+				t.Fatalf("Failed to query audit logs: %v", err)
+			}
+			if tt.expectRemoved && (len(logs) != 1 || logs[0].Action != "membership_remove") {
+				t.Errorf("Expected a single membership_remove audit log, got %+v", logs)
+			}
+			if !tt.expectRemoved && len(logs) != 0 {
+				t.Errorf("Expected no audit logs, got %d", len(logs))
+			}
+		})
+	}
+}
+
+func TestRemoveMember_CanRequestAgain(t *testing.T) {
+	env := newTestEnv()
+	env.addScene(t, &scene.Scene{ID: "scene-123", CoarseGeohash: "u4pruydqqvj"})
+	env.addMember(t, "scene-123", "did:plc:requester", "member", "active")
+	handlers := env.membershipHandlers()
+
+	if w := doMembershipAction(handlers.RemoveMember, "remove", "did:plc:owner"); w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	req := httptest.NewRequest("POST", "/scenes/scene-123/membership/request", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:requester"))
+	w := httptest.NewRecorder()
+	handlers.RequestMembership(w, req)
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected removed member to request again with 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
+
+// doRequestJoin calls RequestJoin for sceneID as callerDID.
+func doRequestJoin(handlers *MembershipHandlers, sceneID, callerDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/scenes/"+sceneID+"/join", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), callerDID))
+	w := httptest.NewRecorder()
+	handlers.RequestJoin(w, req)
+	return w
+}
+
+// seedJoinScenes seeds one scene per visibility mode, all owned by did:plc:owner.
+func seedJoinScenes(t *testing.T, env *testEnv) {
+	t.Helper()
+	for id, visibility := range map[string]string{
+		"members-scene": scene.VisibilityMembersOnly,
+		"hidden-scene":  scene.VisibilityHidden,
+		"public-scene":  scene.VisibilityPublic,
+	} {
+		env.addScene(t, &scene.Scene{ID: id, Name: "Scene " + id, CoarseGeohash: "u4pruydqqvj", Visibility: visibility})
+	}
+}
+
+func TestRequestJoin_NewRequest(t *testing.T) {
+	env := newTestEnv()
+	seedJoinScenes(t, env)
+	handlers := env.membershipHandlers()
+
+	w := doRequestJoin(handlers, "members-scene", "did:plc:requester")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var result membership.Membership
+	if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if result.Status != "pending" || result.SceneID != "members-scene" || result.UserDID != "did:plc:requester" {
+		t.Errorf("Unexpected membership: %+v", result)
+	}
+
+	if _, err := env.memberships.GetBySceneAndUser(t.Context(), "members-scene", "did:plc:requester"); err != nil {
+		t.Errorf("Expected membership to be stored: %v", err)
+	}
+}
+
+func TestRequestJoin_Idempotent(t *testing.T) {
+	env := newTestEnv()
+	seedJoinScenes(t, env)
+	handlers := env.membershipHandlers()
+
+	first := doRequestJoin(handlers, "members-scene", "did:plc:requester")
+	if first.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", first.Code, first.Body.String())
+	}
+	var created membership.Membership
+	if err := json.NewDecoder(first.Body).Decode(&created); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	second := doRequestJoin(handlers, "members-scene", "did:plc:requester")
+	if second.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 on repeat request, got %d. Body: %s", second.Code, second.Body.String())
+	}
+	var repeated membership.Membership
+	if err := json.NewDecoder(second.Body).Decode(&repeated); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if repeated.ID != created.ID || repeated.Status != "pending" {
+		t.Errorf("Expected the existing pending membership %s, got %+v", created.ID, repeated)
+	}
+
+	memberships, err := env.memberships.ListByScene(t.Context(), "members-scene", "")
+	if err != nil {
+		t.Fatalf("ListByScene failed: %v", err)
+	}
+	if len(memberships) != 1 {
+		t.Errorf("Expected exactly 1 membership, got %d", len(memberships))
+	}
+}
+
+func TestRequestJoin_AfterRejection(t *testing.T) {
+	env := newTestEnv()
+	seedJoinScenes(t, env)
+	handlers := env.membershipHandlers()
+
+	result, err := env.memberships.Upsert(t.Context(), &membership.Membership{
+		SceneID: "members-scene",
+		UserDID: "did:plc:requester",
+		Role:    "member",
+		Status:  "rejected",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create rejected membership: %v", err)
+	}
+
+	w := doRequestJoin(handlers, "members-scene", "did:plc:requester")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	stored, err := env.memberships.GetByID(t.Context(), result.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve membership: %v", err)
+	}
+	if stored.Status != "pending" {
+		t.Errorf("Expected rejected membership to be reopened as pending, got %s", stored.Status)
+	}
+}
+
+func TestRequestJoin_Rejections(t *testing.T) {
+	tests := []struct {
+		name           string
+		sceneID        string
+		callerDID      string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"hidden scene", "hidden-scene", "did:plc:requester", http.StatusNotFound, ErrCodeNotFound},
+		{"missing scene", "missing-scene", "did:plc:requester", http.StatusNotFound, ErrCodeNotFound},
+		{"public scene", "public-scene", "did:plc:requester", http.StatusBadRequest, ErrCodeValidation},
+		{"scene owner", "members-scene", "did:plc:owner", http.StatusConflict, ErrCodeConflict},
+		{"unauthenticated", "members-scene", "", http.StatusUnauthorized, ErrCodeAuthFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv()
+			seedJoinScenes(t, env)
+			handlers := env.membershipHandlers()
+
+			w := doRequestJoin(handlers, tt.sceneID, tt.callerDID)
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("Failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != tt.expectedCode {
+				t.Errorf("Expected error code %s, got %s", tt.expectedCode, errResp.Error.Code)
+			}
+
+			if _, err := env.memberships.GetBySceneAndUser(t.Context(), tt.sceneID, tt.callerDID); err != membership.ErrMembershipNotFound {
+				t.Errorf("Expected no membership to be created, got err %v", err)
+			}
+		})
+	}
+
+	// Hidden and missing scenes must be indistinguishable
+	env := newTestEnv()
+	seedJoinScenes(t, env)
+	handlers := env.membershipHandlers()
+	hidden := doRequestJoin(handlers, "hidden-scene", "did:plc:requester")
+	missing := doRequestJoin(handlers, "missing-scene", "did:plc:requester")
+	if hidden.Body.String() != missing.Body.String() {
+		t.Errorf("Hidden scene response differs from missing scene: %s vs %s", hidden.Body.String(), missing.Body.String())
+	}
+}
+
+func TestBanMember_Authorization(t *testing.T) {
+	tests := []struct {
+		name           string
+		callerDID      string
+		callerRole     string // role of an active membership for callerDID; empty for none
+		expectedStatus int
+	}{
+		{"owner", "did:plc:owner", "", http.StatusOK},
+		{"scene admin", "did:plc:admin", "admin", http.StatusOK},
+		{"regular member", "did:plc:member", "member", http.StatusForbidden},
+		{"non-member", "did:plc:attacker", "", http.StatusForbidden},
+		{"unauthenticated", "", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv()
+			env.addScene(t, &scene.Scene{ID: "scene-123", CoarseGeohash: "u4pruydqqvj"})
+			membershipID := env.addMember(t, "scene-123", "did:plc:requester", "member", "active")
+			handlers := env.membershipHandlers()
+			if tt.callerRole != "" {
+				if _, err := env.memberships.Upsert(t.Context(), &membership.Membership{
+					SceneID: "scene-123",
+					UserDID: tt.callerDID,
+					Role:    tt.callerRole,
+					Status:  "active",
+				}); err != nil {
+					t.Fatalf("Failed to create caller membership: %v", err)
+				}
+			}
+
+			w := doMembershipAction(handlers.BanMember, "ban", tt.callerDID)
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			stored, err := env.memberships.GetByID(t.Context(), membershipID)
+			if err != nil {
+				t.Fatalf("Failed to retrieve membership: %v", err)
+			}
+			wantStatus := "active"
+			if tt.expectedStatus == http.StatusOK {
+				wantStatus = "banned"
+			}
+			if stored.Status != wantStatus {
+				t.Errorf("Expected status %s, got %s", wantStatus, stored.Status)
+			}
+
+			logs, err := env.audit.QueryByEntity(t.Context(), "membership", membershipID, 0)
+			if err != nil {
+				t.Fatalf("Failed to query audit logs: %v", err)
+			}
+			if tt.expectedStatus == http.StatusOK && (len(logs) != 1 || logs[0].Action != "membership_ban") {
+				t.Errorf("Expected a single membership_ban audit log, got %+v", logs)
+			}
+		})
+	}
+}
+
+func TestBanMember_AdminCannotBanAdmin(t *testing.T) {
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	handlers := NewMembershipHandlers(membershipRepo, sceneRepo, audit.NewInMemoryRepository())
+
+	if err := sceneRepo.Insert(t.Context(), &scene.Scene{
+		ID:            "scene-123",
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:owner",
+		CoarseGeohash: "u4pruydqqvj",
+	}); err != nil {
+		t.Fatalf("Failed to insert test scene: %v", err)
+	}
+	for _, did := range []string{"did:plc:admin", "did:plc:requester"} {
+		if _, err := membershipRepo.Upsert(t.Context(), &membership.Membership{
+			SceneID: "scene-123",
+			UserDID: did,
+			Role:    "admin",
+			Status:  "active",
+		}); err != nil {
+			t.Fatalf("Failed to create admin membership: %v", err)
+		}
+	}
+
+	w := doMembershipAction(handlers.BanMember, "ban", "did:plc:admin")
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	// The owner can ban an admin
+	w = doMembershipAction(handlers.BanMember, "ban", "did:plc:owner")
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 for owner, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestBanMember_BlocksRejoin(t *testing.T) {
+	env := newTestEnv()
+	seedJoinScenes(t, env)
+	handlers := env.membershipHandlers()
+
+	// Pre-emptive ban of a user with no membership
+	req := httptest.NewRequest("POST", "/scenes/members-scene/membership/did:plc:requester/ban", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner"))
+	w := httptest.NewRecorder()
+	handlers.BanMember(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	if w := doRequestJoin(handlers, "members-scene", "did:plc:requester"); w.Code != http.StatusForbidden {
+		t.Errorf("Expected banned user's join request to return 403, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	req = httptest.NewRequest("POST", "/scenes/members-scene/membership/request", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:requester"))
+	w = httptest.NewRecorder()
+	handlers.RequestMembership(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected banned user's membership request to return 403, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	// Banned users do not appear in member listings
+	listed, err := env.memberships.ListByScene(t.Context(), "members-scene", "")
+	if err != nil {
+		t.Fatalf("ListByScene failed: %v", err)
+	}
+	if len(listed) != 0 {
+		t.Errorf("Expected banned user to be excluded from listings, got %d memberships", len(listed))
+	}
+
+	// Unbanning is a separate owner-only action
+	req = httptest.NewRequest("POST", "/scenes/members-scene/membership/did:plc:requester/unban", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:attacker"))
+	w = httptest.NewRecorder()
+	handlers.UnbanMember(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected non-owner unban to return 403, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest("POST", "/scenes/members-scene/membership/did:plc:requester/unban", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner"))
+	w = httptest.NewRecorder()
+	handlers.UnbanMember(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	if w := doRequestJoin(handlers, "members-scene", "did:plc:requester"); w.Code != http.StatusCreated {
+		t.Errorf("Expected unbanned user to join with 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
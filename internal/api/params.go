@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// parseEntityID extracts the ID segment that follows prefix in the request path
+// (e.g. "abc" from "/scenes/abc/palette" with prefix "/scenes/") and validates
+// that it is a well-formed UUID. entity names the resource in error messages.
+//
+// A missing ID gets 400 with ErrCodeBadRequest and a malformed one gets 400 with
+// ErrCodeValidation, so clients can tell a garbage ID apart from a valid ID that
+// doesn't exist (404). On failure the error response has already been written
+// and the caller should return.
+func parseEntityID(w http.ResponseWriter, r *http.Request, prefix, entity string) (string, bool) {
+	id, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	if id == "" {
+		writeError(w, r, ErrCodeBadRequest, entity+" ID is required")
+		return "", false
+	}
+
+	// uuid.Parse also accepts braced and urn: forms; only the canonical
+	// 36-character form is a valid path ID
+	if len(id) != 36 || uuid.Validate(id) != nil {
+		writeError(w, r, ErrCodeValidation, entity+" ID must be a valid UUID")
+		return "", false
+	}
+	return id, true
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+func TestParseEntityID(t *testing.T) {
+	const validID = "3f2b8c1e-9a4d-4e6f-8b2a-1c5d7e9f0a3b"
+
+	tests := []struct {
+		name     string
+		path     string
+		wantID   string
+		wantOK   bool
+		wantCode string
+	}{
+		{"valid id", "/scenes/" + validID, validID, true, ""},
+		{"valid id with subresource", "/scenes/" + validID + "/palette", validID, true, ""},
+		{"uppercase id", "/scenes/3F2B8C1E-9A4D-4E6F-8B2A-1C5D7E9F0A3B", "3F2B8C1E-9A4D-4E6F-8B2A-1C5D7E9F0A3B", true, ""},
+		{"missing id", "/scenes/", "", false, ErrCodeBadRequest},
+		{"garbage id", "/scenes/not-a-uuid", "", false, ErrCodeValidation},
+		{"unhyphenated id", "/scenes/3f2b8c1e9a4d4e6f8b2a1c5d7e9f0a3b", "", false, ErrCodeValidation},
+		{"braced id", "/scenes/{3f2b8c1e-9a4d-4e6f-8b2a-1c5d7e9f0a3b}", "", false, ErrCodeValidation},
+		{"truncated id", "/scenes/3f2b8c1e-9a4d-4e6f-8b2a", "", false, ErrCodeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.URL.Path = tt.path
+			w := httptest.NewRecorder()
+
+			id, ok := parseEntityID(w, req, "/scenes/", "Scene")
+			if ok != tt.wantOK || id != tt.wantID {
+				t.Fatalf("parseEntityID() = (%q, %v), want (%q, %v)", id, ok, tt.wantID, tt.wantOK)
+			}
+			if tt.wantOK {
+				if w.Body.Len() != 0 {
+					t.Errorf("expected no response on success, got %q", w.Body.String())
+				}
+				return
+			}
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", w.Code)
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != tt.wantCode {
+				t.Errorf("expected error code %q, got %q", tt.wantCode, errResp.Error.Code)
+			}
+		})
+	}
+}
+
+// TestEntityIDValidation_Handlers tests that malformed IDs are rejected with 400
+// before reaching the repository, while valid but unknown IDs still get 404.
+func TestEntityIDValidation_Handlers(t *testing.T) {
+	sceneRepo := scene.NewInMemorySceneRepository()
+	eventRepo := scene.NewInMemoryEventRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	sceneHandlers := NewSceneHandlers(sceneRepo, membership.NewInMemoryMembershipRepository(), streamRepo)
+	eventHandlers := NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), streamRepo)
+
+	const missingID = "3f2b8c1e-9a4d-4e6f-8b2a-1c5d7e9f0a3b"
+
+	handlers := []struct {
+		name    string
+		method  string
+		prefix  string
+		suffix  string
+		handler http.HandlerFunc
+	}{
+		{"GetScene", http.MethodGet, "/scenes/", "", sceneHandlers.GetScene},
+		{"UpdateScene", http.MethodPatch, "/scenes/", "", sceneHandlers.UpdateScene},
+		{"DeleteScene", http.MethodDelete, "/scenes/", "", sceneHandlers.DeleteScene},
+		{"GetEvent", http.MethodGet, "/events/", "", eventHandlers.GetEvent},
+		{"UpdateEvent", http.MethodPatch, "/events/", "", eventHandlers.UpdateEvent},
+		{"CancelEvent", http.MethodPost, "/events/", "/cancel", eventHandlers.CancelEvent},
+	}
+
+	for _, h := range handlers {
+		t.Run(h.name+"/malformed", func(t *testing.T) {
+			req := httptest.NewRequest(h.method, h.prefix+"not-a-uuid"+h.suffix, strings.NewReader("{}"))
+			w := httptest.NewRecorder()
+			h.handler(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d", w.Code)
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != ErrCodeValidation {
+				t.Errorf("expected error code %q, got %q", ErrCodeValidation, errResp.Error.Code)
+			}
+		})
+
+		t.Run(h.name+"/missing", func(t *testing.T) {
+			req := httptest.NewRequest(h.method, h.prefix+missingID+h.suffix, strings.NewReader("{}"))
+			req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+			w := httptest.NewRecorder()
+			h.handler(w, req)
+
+			if w.Code != http.StatusNotFound {
+				t.Errorf("expected status 404 for valid but missing ID, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/onnwee/subcults/internal/geo"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// PostHandlers holds dependencies for post HTTP handlers.
+type PostHandlers struct {
+	postRepo       post.PostRepository
+	sceneRepo      scene.SceneRepository
+	membershipRepo membership.MembershipRepository
+}
+
+// NewPostHandlers creates a new PostHandlers instance.
+func NewPostHandlers(
+	postRepo post.PostRepository,
+	sceneRepo scene.SceneRepository,
+	membershipRepo membership.MembershipRepository,
+) *PostHandlers {
+	return &PostHandlers{
+		postRepo:       postRepo,
+		sceneRepo:      sceneRepo,
+		membershipRepo: membershipRepo,
+	}
+}
+
+// CreatePostRequest represents the request body for creating a post.
+type CreatePostRequest struct {
+	Text string `json:"text"`
+}
+
+// ListPostsResponse represents the response for listing a scene's posts.
+type ListPostsResponse struct {
+	Posts      []*post.Post `json:"posts"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+// CreatePost handles POST /scenes/{id}/posts - creates a post in a scene.
+// Only the scene owner and active members may post.
+func (h *PostHandlers) CreatePost(w http.ResponseWriter, r *http.Request) {
+	sceneID, ok := parseEntityID(w, r, "/scenes/", "Scene")
+	if !ok {
+		return
+	}
+
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	var req CreatePostRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	targetScene, viewerLevel, ok := h.visibleScene(w, r, sceneID, userDID)
+	if !ok {
+		return
+	}
+	if viewerLevel == geo.ViewerPublic {
+		writeError(w, r, ErrCodeForbidden, "Active membership is required to post in this scene")
+		return
+	}
+
+	text, err := post.NormalizeText(req.Text)
+	if err != nil {
+		writeError(w, r, ErrCodeValidation, err.Error())
+		return
+	}
+
+	newPost := &post.Post{
+		SceneID:   targetScene.ID,
+		AuthorDID: userDID,
+		Text:      text,
+	}
+	if err := h.postRepo.Insert(newPost); err != nil {
+		slog.ErrorContext(r.Context(), "failed to create post", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to create post")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(newPost); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
+
+// ListPosts handles GET /scenes/{id}/posts - lists a scene's posts newest first.
+// Visibility follows GetScene, so members-only scenes are limited to members
+// and the owner.
+//
+// Query parameters: limit (default 20, max 100) and cursor (next_cursor from
+// the previous page).
+func (h *PostHandlers) ListPosts(w http.ResponseWriter, r *http.Request) {
+	sceneID, ok := parseEntityID(w, r, "/scenes/", "Scene")
+	if !ok {
+		return
+	}
+
+	limit := post.DefaultListLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(strings.TrimSpace(limitStr))
+		if err != nil || parsed < 1 {
+			writeError(w, r, ErrCodeValidation, "limit must be a positive integer")
+			return
+		}
+		if parsed > post.MaxListLimit {
+			parsed = post.MaxListLimit
+		}
+		limit = parsed
+	}
+
+	userDID := middleware.GetUserDID(r.Context())
+	if _, _, ok := h.visibleScene(w, r, sceneID, userDID); !ok {
+		return
+	}
+
+	posts, nextCursor, err := h.postRepo.ListByScene(sceneID, limit, r.URL.Query().Get("cursor"))
+	if err != nil {
+		if errors.Is(err, post.ErrInvalidCursor) {
+			writeError(w, r, ErrCodeValidation, "invalid cursor")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to list posts", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to list posts")
+		return
+	}
+
+	response := ListPostsResponse{
+		Posts:      posts,
+		NextCursor: nextCursor,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
+
+// DeletePost handles DELETE /posts/{id} - soft-deletes a post.
+// Only the post's author and the scene owner may delete it.
+func (h *PostHandlers) DeletePost(w http.ResponseWriter, r *http.Request) {
+	postID, ok := parseEntityID(w, r, "/posts/", "Post")
+	if !ok {
+		return
+	}
+
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	existing, err := h.postRepo.GetByID(postID)
+	if err != nil {
+		if errors.Is(err, post.ErrPostNotFound) || errors.Is(err, post.ErrPostDeleted) {
+			writeError(w, r, ErrCodeNotFound, "Post not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve post", "error", err, "post_id", postID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve post")
+		return
+	}
+
+	if existing.AuthorDID != userDID {
+		postScene, err := h.sceneRepo.GetByID(r.Context(), existing.SceneID)
+		if err != nil && err != scene.ErrSceneNotFound && err != scene.ErrSceneDeleted {
+			slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", existing.SceneID)
+			writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+			return
+		}
+		if err != nil || !postScene.IsOwner(userDID) {
+			writeError(w, r, ErrCodeForbidden, "Only the author or scene owner can delete this post")
+			return
+		}
+	}
+
+	if err := h.postRepo.Delete(postID); err != nil {
+		if errors.Is(err, post.ErrPostNotFound) {
+			writeError(w, r, ErrCodeNotFound, "Post not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to delete post", "error", err, "post_id", postID)
+		writeError(w, r, ErrCodeInternal, "Failed to delete post")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// visibleScene loads a scene and resolves the requester's access level,
+// writing a 404 when the scene doesn't exist or the requester can't see it.
+// On failure the error response has already been written and the caller
+// should return.
+func (h *PostHandlers) visibleScene(w http.ResponseWriter, r *http.Request, sceneID, userDID string) (*scene.Scene, string, bool) {
+	foundScene, err := h.sceneRepo.GetByID(r.Context(), sceneID)
+	if err != nil {
+		if err == scene.ErrSceneDeleted {
+			writeError(w, r, ErrCodeSceneDeleted, "Scene not found")
+			return nil, "", false
+		}
+		if err == scene.ErrSceneNotFound {
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
+			return nil, "", false
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+		return nil, "", false
+	}
+
+	viewerLevel, err := viewerLevelForScene(r.Context(), h.membershipRepo, foundScene, userDID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to check access permissions")
+		return nil, "", false
+	}
+	if !canViewScene(r.Context(), foundScene, viewerLevel) {
+		// Same response as not found to prevent enumeration
+		writeError(w, r, ErrCodeNotFound, "Scene not found")
+		return nil, "", false
+	}
+
+	return foundScene, viewerLevel, true
+}
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+const postSceneID = "6e3b2d9a-4f5c-4d7e-9a0b-9c8d7e6f5a4b"
+
+// seedPostScene seeds postSceneID with the given visibility, owned by
+// did:plc:owner, with active member did:plc:member and pending member
+// did:plc:pending.
+func seedPostScene(t *testing.T, env *testEnv, visibility string) {
+	t.Helper()
+	env.addScene(t, &scene.Scene{ID: postSceneID, Name: "Post Scene", Visibility: visibility})
+	env.addMember(t, postSceneID, "did:plc:member", "member", "active")
+	env.addMember(t, postSceneID, "did:plc:pending", "member", "pending")
+}
+
+func doCreatePost(handlers *PostHandlers, userDID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/scenes/"+postSceneID+"/posts", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.CreatePost(w, req)
+	return w
+}
+
+func doListPosts(handlers *PostHandlers, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/scenes/"+postSceneID+"/posts", nil)
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.ListPosts(w, req)
+	return w
+}
+
+func doDeletePost(handlers *PostHandlers, userDID, postID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodDelete, "/posts/"+postID, nil)
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.DeletePost(w, req)
+	return w
+}
+
+func TestCreatePost_RequiresActiveMembership(t *testing.T) {
+	env := newTestEnv()
+	seedPostScene(t, env, scene.VisibilityPublic)
+	handlers := env.postHandlers(post.NewInMemoryPostRepository())
+
+	tests := []struct {
+		name       string
+		userDID    string
+		wantStatus int
+	}{
+		{name: "unauthenticated", userDID: "", wantStatus: http.StatusUnauthorized},
+		{name: "non-member", userDID: "did:plc:stranger", wantStatus: http.StatusForbidden},
+		{name: "pending member", userDID: "did:plc:pending", wantStatus: http.StatusForbidden},
+		{name: "active member", userDID: "did:plc:member", wantStatus: http.StatusCreated},
+		{name: "owner", userDID: "did:plc:owner", wantStatus: http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doCreatePost(handlers, tt.userDID, `{"text":"See you at the show"}`)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+
+			var created post.Post
+			if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if created.ID == "" || created.SceneID != postSceneID || created.AuthorDID != tt.userDID {
+				t.Errorf("unexpected post: %+v", created)
+			}
+		})
+	}
+}
+
+func TestCreatePost_TextValidation(t *testing.T) {
+	env := newTestEnv()
+	seedPostScene(t, env, scene.VisibilityPublic)
+	handlers := env.postHandlers(post.NewInMemoryPostRepository())
+
+	w := doCreatePost(handlers, "did:plc:member", `{"text":"hi\u0000 there\u001b\nbye"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created post.Post
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.Text != "hi there\nbye" {
+		t.Errorf("expected control characters to be stripped, got %q", created.Text)
+	}
+
+	for name, text := range map[string]string{
+		"empty":    "",
+		"too long": strings.Repeat("a", post.MaxTextLength+1),
+	} {
+		body, _ := json.Marshal(CreatePostRequest{Text: text})
+		w := doCreatePost(handlers, "did:plc:member", string(body))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", name, w.Code)
+		}
+	}
+}
+
+func TestListPosts_RespectsSceneVisibility(t *testing.T) {
+	env := newTestEnv()
+	seedPostScene(t, env, scene.VisibilityMembersOnly)
+	postRepo := post.NewInMemoryPostRepository()
+	handlers := env.postHandlers(postRepo)
+	if err := postRepo.Insert(&post.Post{SceneID: postSceneID, AuthorDID: "did:plc:member", Text: "members only"}); err != nil {
+		t.Fatalf("failed to insert post: %v", err)
+	}
+
+	for _, viewer := range []string{"", "did:plc:stranger", "did:plc:pending"} {
+		w := doListPosts(handlers, viewer)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("viewer %q: expected status 404, got %d", viewer, w.Code)
+		}
+	}
+
+	for _, viewer := range []string{"did:plc:member", "did:plc:owner"} {
+		w := doListPosts(handlers, viewer)
+		if w.Code != http.StatusOK {
+			t.Fatalf("viewer %q: expected status 200, got %d: %s", viewer, w.Code, w.Body.String())
+		}
+		var resp ListPostsResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp.Posts) != 1 {
+			t.Errorf("viewer %q: expected 1 post, got %d", viewer, len(resp.Posts))
+		}
+	}
+
+	// Non-members of a public scene can read but not post
+	env = newTestEnv()
+	seedPostScene(t, env, scene.VisibilityPublic)
+	handlers = env.postHandlers(post.NewInMemoryPostRepository())
+	if w := doListPosts(handlers, ""); w.Code != http.StatusOK {
+		t.Errorf("expected public scene posts to be listed, got %d", w.Code)
+	}
+}
+
+func TestCreatePost_HiddenSceneReturnsNotFound(t *testing.T) {
+	env := newTestEnv()
+	seedPostScene(t, env, scene.VisibilityHidden)
+	handlers := env.postHandlers(post.NewInMemoryPostRepository())
+
+	w := doCreatePost(handlers, "did:plc:stranger", `{"text":"hello"}`)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
+
+func TestDeletePost_AuthorAndOwnerOnly(t *testing.T) {
+	env := newTestEnv()
+	seedPostScene(t, env, scene.VisibilityPublic)
+	postRepo := post.NewInMemoryPostRepository()
+	handlers := env.postHandlers(postRepo)
+
+	first := &post.Post{SceneID: postSceneID, AuthorDID: "did:plc:member", Text: "first"}
+	second := &post.Post{SceneID: postSceneID, AuthorDID: "did:plc:member", Text: "second"}
+	for _, p := range []*post.Post{first, second} {
+		if err := postRepo.Insert(p); err != nil {
+			t.Fatalf("failed to insert post: %v", err)
+		}
+	}
+
+	if w := doDeletePost(handlers, "did:plc:stranger", first.ID); w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for non-author, got %d", w.Code)
+	}
+	if w := doDeletePost(handlers, "did:plc:member", first.ID); w.Code != http.StatusNoContent {
+		t.Errorf("expected status 204 for author, got %d", w.Code)
+	}
+	if w := doDeletePost(handlers, "did:plc:owner", second.ID); w.Code != http.StatusNoContent {
+		t.Errorf("expected status 204 for scene owner, got %d", w.Code)
+	}
+
+	// Deleted posts are gone from the listing and can't be deleted again
+	if w := doDeletePost(handlers, "did:plc:member", first.ID); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for deleted post, got %d", w.Code)
+	}
+	var resp ListPostsResponse
+	if err := json.NewDecoder(doListPosts(handlers, "").Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Posts) != 0 {
+		t.Errorf("expected no posts after deletion, got %d", len(resp.Posts))
+	}
+
+	if w := doDeletePost(handlers, "", second.ID); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 when unauthenticated, got %d", w.Code)
+	}
+}
@@ -0,0 +1,284 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/report"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// ReportHandlers holds dependencies for content report HTTP handlers.
+type ReportHandlers struct {
+	reportRepo     report.ReportRepository
+	sceneRepo      scene.SceneRepository
+	eventRepo      scene.EventRepository
+	membershipRepo membership.MembershipRepository
+
+	// auditRepo records filed and resolved reports. Optional; nil disables
+	// audit logging.
+	auditRepo audit.Repository
+}
+
+// NewReportHandlers creates a new ReportHandlers instance.
+func NewReportHandlers(
+	reportRepo report.ReportRepository,
+	sceneRepo scene.SceneRepository,
+	eventRepo scene.EventRepository,
+	membershipRepo membership.MembershipRepository,
+	auditRepo audit.Repository,
+) *ReportHandlers {
+	return &ReportHandlers{
+		reportRepo:     reportRepo,
+		sceneRepo:      sceneRepo,
+		eventRepo:      eventRepo,
+		membershipRepo: membershipRepo,
+		auditRepo:      auditRepo,
+	}
+}
+
+// FileReportRequest represents the request body for filing a report.
+type FileReportRequest struct {
+	EntityType string `json:"entity_type"`
+	EntityID   string `json:"entity_id"`
+	Reason     string `json:"reason"`
+}
+
+// ResolveReportRequest represents the request body for resolving a report.
+type ResolveReportRequest struct {
+	Status string `json:"status"`
+}
+
+// ListReportsResponse represents the response for listing reports.
+type ListReportsResponse struct {
+	Reports    []*report.Report `json:"reports"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// FileReport handles POST /reports - flags a scene or event for moderator
+// review. The reporter must be able to see the reported content; anything
+// else returns 404 as if it didn't exist. Each user may have one open report
+// per entity, so a repeat report returns 409 until the first is resolved.
+func (h *ReportHandlers) FileReport(w http.ResponseWriter, r *http.Request) {
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	var req FileReportRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	if !report.ValidEntityType(req.EntityType) {
+		writeError(w, r, ErrCodeValidation, "entity_type must be 'scene' or 'event'")
+		return
+	}
+	if len(req.EntityID) != 36 || uuid.Validate(req.EntityID) != nil {
+		writeError(w, r, ErrCodeValidation, "entity_id must be a valid UUID")
+		return
+	}
+	reason, err := report.NormalizeReason(req.Reason)
+	if err != nil {
+		writeError(w, r, ErrCodeValidation, err.Error())
+		return
+	}
+
+	if !h.entityVisible(w, r, req.EntityType, req.EntityID, userDID) {
+		return
+	}
+
+	newReport := &report.Report{
+		ReporterDID: userDID,
+		EntityType:  req.EntityType,
+		EntityID:    req.EntityID,
+		Reason:      reason,
+	}
+	if err := h.reportRepo.Create(newReport); err != nil {
+		if errors.Is(err, report.ErrDuplicateReport) {
+			writeError(w, r, ErrCodeConflict, "You already have an open report for this content")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to file report", "error", err, "entity_type", req.EntityType, "entity_id", req.EntityID)
+		writeError(w, r, ErrCodeInternal, "Failed to file report")
+		return
+	}
+
+	// Logged against the report rather than the reported entity so scene
+	// owners reading their audit logs can't see who reported them
+	h.logReportAudit(r, newReport.ID, "report_file")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(newReport); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
+
+// ListReports handles GET /reports - lists reports newest first for
+// moderators. Routes must be wrapped in middleware.RequireAdmin.
+//
+// Query parameters: status (open, resolved, or dismissed; default all),
+// limit (default 20, max 100) and cursor (next_cursor from the previous page).
+func (h *ReportHandlers) ListReports(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	status := query.Get("status")
+	if status != "" && !report.ValidStatus(status) {
+		writeError(w, r, ErrCodeValidation, "status must be 'open', 'resolved', or 'dismissed'")
+		return
+	}
+
+	limit := report.DefaultListLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(strings.TrimSpace(limitStr))
+		if err != nil || parsed < 1 {
+			writeError(w, r, ErrCodeValidation, "limit must be a positive integer")
+			return
+		}
+		if parsed > report.MaxListLimit {
+			parsed = report.MaxListLimit
+		}
+		limit = parsed
+	}
+
+	reports, nextCursor, err := h.reportRepo.List(status, limit, query.Get("cursor"))
+	if err != nil {
+		if errors.Is(err, report.ErrInvalidCursor) {
+			writeError(w, r, ErrCodeValidation, "invalid cursor")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to list reports", "error", err)
+		writeError(w, r, ErrCodeInternal, "Failed to list reports")
+		return
+	}
+
+	response := ListReportsResponse{
+		Reports:    reports,
+		NextCursor: nextCursor,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
+
+// ResolveReport handles POST /reports/{id}/resolve - moves an open report to
+// resolved or dismissed. Routes must be wrapped in middleware.RequireAdmin.
+// Reports that are no longer open return 409.
+func (h *ReportHandlers) ResolveReport(w http.ResponseWriter, r *http.Request) {
+	reportID, ok := parseEntityID(w, r, "/reports/", "Report")
+	if !ok {
+		return
+	}
+
+	moderatorDID := middleware.GetUserDID(r.Context())
+	if moderatorDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	var req ResolveReportRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	resolved, err := h.reportRepo.Resolve(reportID, req.Status, moderatorDID)
+	if err != nil {
+		switch {
+		case errors.Is(err, report.ErrInvalidStatus):
+			writeError(w, r, ErrCodeValidation, "status must be 'resolved' or 'dismissed'")
+		case errors.Is(err, report.ErrReportNotFound):
+			writeError(w, r, ErrCodeNotFound, "Report not found")
+		case errors.Is(err, report.ErrInvalidTransition):
+			writeError(w, r, ErrCodeConflict, "Report has already been resolved")
+		default:
+			slog.ErrorContext(r.Context(), "failed to resolve report", "error", err, "report_id", reportID)
+			writeError(w, r, ErrCodeInternal, "Failed to resolve report")
+		}
+		return
+	}
+
+	h.logReportAudit(r, reportID, "report_resolve")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resolved); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
+
+// entityVisible reports whether the requester can see the reported scene or
+// event, writing a 404 when it doesn't exist or is hidden from them. On
+// failure the error response has already been written and the caller should
+// return.
+func (h *ReportHandlers) entityVisible(w http.ResponseWriter, r *http.Request, entityType, entityID, userDID string) bool {
+	sceneID := entityID
+	if entityType == report.EntityTypeEvent {
+		event, err := h.eventRepo.GetByID(r.Context(), entityID)
+		if err != nil {
+			if err == scene.ErrEventNotFound {
+				writeError(w, r, ErrCodeNotFound, "Event not found")
+				return false
+			}
+			slog.ErrorContext(r.Context(), "failed to retrieve event", "error", err, "event_id", entityID)
+			writeError(w, r, ErrCodeInternal, "Failed to retrieve event")
+			return false
+		}
+		sceneID = event.SceneID
+	}
+
+	// Events take their visibility from the scene, and report the same 404
+	// so hidden scenes can't be probed through their events
+	notFound := "Scene not found"
+	if entityType == report.EntityTypeEvent {
+		notFound = "Event not found"
+	}
+
+	foundScene, err := h.sceneRepo.GetByID(r.Context(), sceneID)
+	if err != nil {
+		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+			writeError(w, r, ErrCodeNotFound, notFound)
+			return false
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+		return false
+	}
+
+	viewerLevel, err := viewerLevelForScene(r.Context(), h.membershipRepo, foundScene, userDID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to check access permissions")
+		return false
+	}
+	if !canViewScene(r.Context(), foundScene, viewerLevel) {
+		writeError(w, r, ErrCodeNotFound, notFound)
+		return false
+	}
+	return true
+}
+
+// logReportAudit records a report action. Audit failures are logged but do
+// not fail the request.
+func (h *ReportHandlers) logReportAudit(r *http.Request, reportID, action string) {
+	if h.auditRepo == nil {
+		return
+	}
+	if err := audit.LogAccessFromRequest(r, h.auditRepo, "report", reportID, action); err != nil {
+		slog.WarnContext(r.Context(), "failed to log report audit", "error", err, "report_id", reportID, "action", action)
+	}
+}
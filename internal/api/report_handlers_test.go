@@ -0,0 +1,223 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/report"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+const (
+	reportSceneID   = "2b3c4d5e-6f70-4812-9a3b-4c5d6e7f8091"
+	hiddenSceneID   = "2b3c4d5e-6f70-4812-9a3b-4c5d6e7f8092"
+	reportEventID   = "7d8e9fa0-b1c2-4d3e-8f4a-5b6c7d8e9fa1"
+	hiddenEventID   = "7d8e9fa0-b1c2-4d3e-8f4a-5b6c7d8e9fa2"
+	reportModerator = "did:plc:moderator"
+)
+
+// seedReportScenes seeds a public scene and a hidden scene, each holding one event.
+func seedReportScenes(t *testing.T, env *testEnv) {
+	t.Helper()
+	for id, visibility := range map[string]string{reportSceneID: scene.VisibilityPublic, hiddenSceneID: scene.VisibilityHidden} {
+		env.addScene(t, &scene.Scene{ID: id, Name: "Scene " + visibility, Visibility: visibility})
+	}
+	for eventID, sceneID := range map[string]string{reportEventID: reportSceneID, hiddenEventID: hiddenSceneID} {
+		env.addEvent(t, &scene.Event{ID: eventID, SceneID: sceneID, Title: "Event", StartsAt: time.Now().Add(time.Hour)})
+	}
+}
+
+func doFileReport(handlers *ReportHandlers, userDID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/reports", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.FileReport(w, req)
+	return w
+}
+
+// doModeratorRequest sends a request through RequireAdmin with
+// reportModerator as the only admin.
+func doModeratorRequest(handler http.HandlerFunc, method, path, userDID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	middleware.RequireAdmin([]string{reportModerator})(handler).ServeHTTP(w, req)
+	return w
+}
+
+func TestFileReport_Success(t *testing.T) {
+	env := newTestEnv()
+	seedReportScenes(t, env)
+	handlers := env.reportHandlers()
+
+	w := doFileReport(handlers, "did:plc:alice", `{"entity_type":"event","entity_id":"`+reportEventID+`","reason":"  Scam tickets\u0000  "}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var filed report.Report
+	if err := json.NewDecoder(w.Body).Decode(&filed); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if filed.Status != report.StatusOpen || filed.ReporterDID != "did:plc:alice" || filed.Reason != "Scam tickets" {
+		t.Errorf("unexpected report: %+v", filed)
+	}
+
+	logs, _ := env.audit.QueryByEntity(t.Context(), "report", filed.ID, 0)
+	if len(logs) != 1 || logs[0].Action != "report_file" {
+		t.Errorf("expected one report_file audit entry, got %+v", logs)
+	}
+	// The reported entity's own audit trail must not reveal the reporter
+	if logs, _ := env.audit.QueryByEntity(t.Context(), "event", reportEventID, 0); len(logs) != 0 {
+		t.Errorf("expected no audit entries on the reported event, got %d", len(logs))
+	}
+}
+
+func TestFileReport_DuplicateSuppressed(t *testing.T) {
+	env := newTestEnv()
+	seedReportScenes(t, env)
+	handlers := env.reportHandlers()
+	body := `{"entity_type":"scene","entity_id":"` + reportSceneID + `","reason":"spam"}`
+
+	if w := doFileReport(handlers, "did:plc:alice", body); w.Code != http.StatusCreated {
+		t.Fatalf("expected first report status 201, got %d", w.Code)
+	}
+
+	w := doFileReport(handlers, "did:plc:alice", body)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected duplicate report status 409, got %d", w.Code)
+	}
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeConflict {
+		t.Errorf("expected error code %s, got %s", ErrCodeConflict, errResp.Error.Code)
+	}
+
+	// A different user may still report the same scene
+	if w := doFileReport(handlers, "did:plc:bob", body); w.Code != http.StatusCreated {
+		t.Errorf("expected other user's report status 201, got %d", w.Code)
+	}
+}
+
+func TestFileReport_Rejections(t *testing.T) {
+	env := newTestEnv()
+	seedReportScenes(t, env)
+	handlers := env.reportHandlers()
+
+	tests := []struct {
+		name       string
+		userDID    string
+		body       string
+		wantStatus int
+	}{
+		{"unauthenticated", "", `{"entity_type":"scene","entity_id":"` + reportSceneID + `","reason":"spam"}`, http.StatusUnauthorized},
+		{"unknown entity type", "did:plc:alice", `{"entity_type":"user","entity_id":"` + reportSceneID + `","reason":"spam"}`, http.StatusBadRequest},
+		{"malformed entity id", "did:plc:alice", `{"entity_type":"scene","entity_id":"abc","reason":"spam"}`, http.StatusBadRequest},
+		{"empty reason", "did:plc:alice", `{"entity_type":"scene","entity_id":"` + reportSceneID + `","reason":" "}`, http.StatusBadRequest},
+		{"missing scene", "did:plc:alice", `{"entity_type":"scene","entity_id":"00000000-0000-4000-8000-000000000000","reason":"spam"}`, http.StatusNotFound},
+		{"hidden scene", "did:plc:alice", `{"entity_type":"scene","entity_id":"` + hiddenSceneID + `","reason":"spam"}`, http.StatusNotFound},
+		{"event in hidden scene", "did:plc:alice", `{"entity_type":"event","entity_id":"` + hiddenEventID + `","reason":"spam"}`, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := doFileReport(handlers, tt.userDID, tt.body); w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestResolveReport_Transitions(t *testing.T) {
+	env := newTestEnv()
+	seedReportScenes(t, env)
+	handlers := env.reportHandlers()
+
+	w := doFileReport(handlers, "did:plc:alice", `{"entity_type":"scene","entity_id":"`+reportSceneID+`","reason":"spam"}`)
+	var filed report.Report
+	if err := json.NewDecoder(w.Body).Decode(&filed); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	resolvePath := "/reports/" + filed.ID + "/resolve"
+
+	// Non-moderators can't resolve
+	if w := doModeratorRequest(handlers.ResolveReport, http.MethodPost, resolvePath, "did:plc:alice", `{"status":"dismissed"}`); w.Code != http.StatusForbidden {
+		t.Errorf("expected non-moderator status 403, got %d", w.Code)
+	}
+
+	// Reopening isn't a resolution
+	if w := doModeratorRequest(handlers.ResolveReport, http.MethodPost, resolvePath, reportModerator, `{"status":"open"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for open, got %d", w.Code)
+	}
+
+	w = doModeratorRequest(handlers.ResolveReport, http.MethodPost, resolvePath, reportModerator, `{"status":"resolved"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resolved report.Report
+	if err := json.NewDecoder(w.Body).Decode(&resolved); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resolved.Status != report.StatusResolved || resolved.ResolvedBy != reportModerator || resolved.ResolvedAt == nil {
+		t.Errorf("unexpected resolved report: %+v", resolved)
+	}
+
+	// Terminal reports can't move again
+	if w := doModeratorRequest(handlers.ResolveReport, http.MethodPost, resolvePath, reportModerator, `{"status":"dismissed"}`); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for resolved report, got %d", w.Code)
+	}
+
+	logs, _ := env.audit.QueryByEntity(t.Context(), "report", filed.ID, 0)
+	if len(logs) != 2 || logs[0].Action != "report_resolve" || logs[0].UserDID != reportModerator {
+		t.Errorf("expected file and resolve audit entries, got %+v", logs)
+	}
+
+	// Resolving frees the reporter to flag the scene again
+	if w := doFileReport(handlers, "did:plc:alice", `{"entity_type":"scene","entity_id":"`+reportSceneID+`","reason":"spam again"}`); w.Code != http.StatusCreated {
+		t.Errorf("expected new report after resolution, got %d", w.Code)
+	}
+}
+
+func TestListReports_Moderator(t *testing.T) {
+	env := newTestEnv()
+	seedReportScenes(t, env)
+	handlers := env.reportHandlers()
+	for _, did := range []string{"did:plc:alice", "did:plc:bob"} {
+		if w := doFileReport(handlers, did, `{"entity_type":"scene","entity_id":"`+reportSceneID+`","reason":"spam"}`); w.Code != http.StatusCreated {
+			t.Fatalf("failed to file report: %d", w.Code)
+		}
+	}
+
+	if w := doModeratorRequest(handlers.ListReports, http.MethodGet, "/reports", "did:plc:alice", ""); w.Code != http.StatusForbidden {
+		t.Errorf("expected non-moderator status 403, got %d", w.Code)
+	}
+
+	w := doModeratorRequest(handlers.ListReports, http.MethodGet, "/reports?status=open&limit=1", reportModerator, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ListReportsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Reports) != 1 || resp.NextCursor == "" {
+		t.Errorf("expected 1 report with a next cursor, got %d reports, cursor %q", len(resp.Reports), resp.NextCursor)
+	}
+
+	if w := doModeratorRequest(handlers.ListReports, http.MethodGet, "/reports?status=bogus", reportModerator, ""); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for unknown status, got %d", w.Code)
+	}
+}
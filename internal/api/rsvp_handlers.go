@@ -2,19 +2,23 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/onnwee/subcults/internal/membership"
 	"github.com/onnwee/subcults/internal/middleware"
 	"github.com/onnwee/subcults/internal/scene"
 )
 
 // RSVPRequest represents the request body for creating/updating an RSVP.
 type RSVPRequest struct {
-	Status string `json:"status"` // "going" or "maybe"
+	Status string `json:"status"` // "going" or "maybe"; "going" becomes "waitlisted" when the event is full
 }
 
 // RSVPResponse represents the response body for RSVP operations.
@@ -26,99 +30,200 @@ type RSVPResponse struct {
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
+// RSVP attendee listing limits.
+const (
+	DefaultAttendeeLimit = 100
+	MaxAttendeeLimit     = 500
+)
+
+// DefaultRSVPStatusChangeInterval is the suggested minimum time between a
+// user's RSVP status changes for one event; see SetStatusChangeInterval.
+const DefaultRSVPStatusChangeInterval = 2 * time.Second
+
+// CheckInGracePeriod is how long after an event ends organizers can still
+// check attendees in.
+const CheckInGracePeriod = time.Hour
+
+// AttendeeResponse represents a single RSVP in an owner-only attendee listing.
+type AttendeeResponse struct {
+	UserDID     string     `json:"user_did"`
+	Status      string     `json:"status"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	CheckedInAt *time.Time `json:"checked_in_at,omitempty"`
+}
+
+// CheckInRequest represents the request body for checking in an attendee.
+type CheckInRequest struct {
+	UserDID string `json:"user_did"`
+}
+
+// CheckInResponse represents the result of checking in an attendee.
+type CheckInResponse struct {
+	EventID        string     `json:"event_id"`
+	UserDID        string     `json:"user_did"`
+	CheckedInAt    *time.Time `json:"checked_in_at"`
+	CheckedInCount int        `json:"checked_in_count"`
+}
+
+// ListRSVPsResponse represents the attendee list for an event.
+type ListRSVPsResponse struct {
+	EventID   string              `json:"event_id"`
+	Attendees []*AttendeeResponse `json:"attendees"`
+}
+
+// MyRSVPEvent is the minimal event info returned with a user's own RSVPs.
+type MyRSVPEvent struct {
+	Title     string    `json:"title"`
+	StartsAt  time.Time `json:"starts_at"`
+	Status    string    `json:"status,omitempty"` // scheduled, live, ended, cancelled
+	SceneID   string    `json:"scene_id"`
+	SceneName string    `json:"scene_name,omitempty"`
+}
+
+// MyRSVPResponse represents one of the caller's RSVPs.
+// Event is nil when the event or its scene has been deleted.
+type MyRSVPResponse struct {
+	EventID   string       `json:"event_id"`
+	Status    string       `json:"status"`
+	CreatedAt *time.Time   `json:"created_at,omitempty"`
+	UpdatedAt *time.Time   `json:"updated_at,omitempty"`
+	Event     *MyRSVPEvent `json:"event"`
+}
+
+// ListMyRSVPsResponse represents the caller's RSVPs.
+type ListMyRSVPsResponse struct {
+	RSVPs []*MyRSVPResponse `json:"rsvps"`
+}
+
 // RSVPHandlers holds dependencies for RSVP HTTP handlers.
 type RSVPHandlers struct {
-	rsvpRepo  scene.RSVPRepository
-	eventRepo scene.EventRepository
+	rsvpRepo       scene.RSVPRepository
+	eventRepo      scene.EventRepository
+	sceneRepo      scene.SceneRepository
+	membershipRepo membership.MembershipRepository
+
+	// statusChangeInterval throttles status churn; zero disables the check
+	statusChangeInterval time.Duration
 }
 
 // NewRSVPHandlers creates a new RSVPHandlers instance.
-func NewRSVPHandlers(rsvpRepo scene.RSVPRepository, eventRepo scene.EventRepository) *RSVPHandlers {
+func NewRSVPHandlers(rsvpRepo scene.RSVPRepository, eventRepo scene.EventRepository, sceneRepo scene.SceneRepository) *RSVPHandlers {
 	return &RSVPHandlers{
 		rsvpRepo:  rsvpRepo,
 		eventRepo: eventRepo,
+		sceneRepo: sceneRepo,
 	}
 }
 
+// SetMembershipRepository enables ban checks so users banned from a scene
+// cannot RSVP to its events.
+func (h *RSVPHandlers) SetMembershipRepository(membershipRepo membership.MembershipRepository) {
+	h.membershipRepo = membershipRepo
+}
+
+// SetStatusChangeInterval sets the minimum time between a user's RSVP
+// status changes for an event. Faster changes are rejected with 429 so
+// rapid toggling can't spam RSVP history. Zero disables the check.
+func (h *RSVPHandlers) SetStatusChangeInterval(interval time.Duration) {
+	h.statusChangeInterval = interval
+}
+
 // CreateOrUpdateRSVP handles POST /events/{id}/rsvp - creates or updates an RSVP.
 func (h *RSVPHandlers) CreateOrUpdateRSVP(w http.ResponseWriter, r *http.Request) {
 	// Extract event ID from URL path
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/"), "/")
 	if len(pathParts) < 2 || pathParts[0] == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Event ID is required")
+		writeError(w, r, ErrCodeBadRequest, "Event ID is required")
 		return
 	}
 	eventID := pathParts[0]
 
 	// Parse request body
 	var req RSVPRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+	if err := decodeJSON(r, &req); err != nil {
+		writeDecodeError(w, r, err)
 		return
 	}
 
 	// Validate status
 	status := strings.TrimSpace(req.Status)
 	if status != "going" && status != "maybe" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "status must be 'going' or 'maybe'")
+		writeError(w, r, ErrCodeValidation, "status must be 'going' or 'maybe'")
 		return
 	}
 
 	// Get user DID from context (set by auth middleware)
 	userDID := middleware.GetUserDID(r.Context())
 	if userDID == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
-		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
 		return
 	}
 
 	// Verify event exists and is upcoming
-	existingEvent, err := h.eventRepo.GetByID(eventID)
+	existingEvent, err := h.eventRepo.GetByID(r.Context(), eventID)
 	if err != nil {
 		if err == scene.ErrEventNotFound {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Event not found")
+			writeError(w, r, ErrCodeNotFound, "Event not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to get event", "error", err, "event_id", eventID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve event")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve event")
+		return
+	}
+
+	// Cancelled events keep their existing RSVPs but accept no new ones
+	if existingEvent.Status == "cancelled" {
+		writeError(w, r, ErrCodeValidation, "Cannot RSVP to a cancelled event")
 		return
 	}
 
+	// Users banned from the scene cannot RSVP to its events
+	if h.membershipRepo != nil {
+		m, err := h.membershipRepo.GetBySceneAndUser(r.Context(), existingEvent.SceneID, userDID)
+		if err != nil && err != membership.ErrMembershipNotFound {
+			slog.ErrorContext(r.Context(), "failed to check membership", "error", err, "scene_id", existingEvent.SceneID)
+			writeError(w, r, ErrCodeInternal, "Failed to check access permissions")
+			return
+		}
+		if err == nil && m.Status == "banned" {
+			writeError(w, r, ErrCodeForbidden, "You cannot RSVP to this event")
+			return
+		}
+	}
+
 	// Validate event is strictly upcoming (starts_at > now)
 	// Business rule: RSVPs are only allowed for events that haven't started yet
 	now := time.Now()
 	if !existingEvent.StartsAt.After(now) {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Cannot RSVP to past or ongoing events")
+		writeError(w, r, ErrCodeValidation, "Cannot RSVP to past or ongoing events")
 		return
 	}
 
-	// Create or update RSVP
+	// Create or update RSVP. Once the event is full, new "going" RSVPs join
+	// the waitlist; the repository decides atomically so concurrent requests
+	// can't overfill it. Users who already hold a seat keep it.
 	rsvp := &scene.RSVP{
 		EventID: eventID,
 		UserID:  userDID,
 		Status:  status,
 	}
 
-	if err := h.rsvpRepo.Upsert(rsvp); err != nil {
+	stored, err := h.rsvpRepo.UpsertWithCapacity(r.Context(), rsvp, existingEvent.Capacity, h.statusChangeInterval)
+	if err != nil {
+		if err == scene.ErrRSVPChangeTooSoon {
+			retryAfter := int(math.Ceil(h.statusChangeInterval.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			writeError(w, r, ErrCodeRateLimited, "RSVP status changed too recently, please try again shortly")
+			return
+		}
 		slog.ErrorContext(r.Context(), "failed to upsert RSVP", "error", err, "event_id", eventID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to save RSVP")
+		writeError(w, r, ErrCodeInternal, "Failed to save RSVP")
 		return
 	}
 
-	// Retrieve the stored RSVP to get timestamps
-	stored, err := h.rsvpRepo.GetByEventAndUser(eventID, userDID)
-	if err != nil {
-		slog.ErrorContext(r.Context(), "failed to retrieve RSVP", "error", err, "event_id", eventID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve RSVP")
-		return
+	// Giving up a seat frees it for the waitlist
+	if stored.Status != "going" {
+		h.promoteFromWaitlist(r.Context(), existingEvent)
 	}
 
 	// Create response without exposing user_id (privacy requirement)
@@ -143,8 +248,7 @@ func (h *RSVPHandlers) DeleteRSVP(w http.ResponseWriter, r *http.Request) {
 	// Extract event ID from URL path
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/"), "/")
 	if len(pathParts) < 2 || pathParts[0] == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Event ID is required")
+		writeError(w, r, ErrCodeBadRequest, "Event ID is required")
 		return
 	}
 	eventID := pathParts[0]
@@ -152,22 +256,19 @@ func (h *RSVPHandlers) DeleteRSVP(w http.ResponseWriter, r *http.Request) {
 	// Get user DID from context (set by auth middleware)
 	userDID := middleware.GetUserDID(r.Context())
 	if userDID == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
-		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
 		return
 	}
 
 	// Verify event exists and is upcoming
-	existingEvent, err := h.eventRepo.GetByID(eventID)
+	existingEvent, err := h.eventRepo.GetByID(r.Context(), eventID)
 	if err != nil {
 		if err == scene.ErrEventNotFound {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Event not found")
+			writeError(w, r, ErrCodeNotFound, "Event not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to get event", "error", err, "event_id", eventID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve event")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve event")
 		return
 	}
 
@@ -175,24 +276,357 @@ func (h *RSVPHandlers) DeleteRSVP(w http.ResponseWriter, r *http.Request) {
 	// Business rule: RSVP modifications are only allowed for events that haven't started yet
 	now := time.Now()
 	if !existingEvent.StartsAt.After(now) {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Cannot modify RSVP for past or ongoing events")
+		writeError(w, r, ErrCodeValidation, "Cannot modify RSVP for past or ongoing events")
+		return
+	}
+
+	// Look up the RSVP first so a freed seat can be passed to the waitlist
+	existingRSVP, err := h.rsvpRepo.GetByEventAndUser(r.Context(), eventID, userDID)
+	if err != nil {
+		if err == scene.ErrRSVPNotFound {
+			writeError(w, r, ErrCodeNotFound, "RSVP not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to get RSVP", "error", err, "event_id", eventID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve RSVP")
 		return
 	}
 
 	// Delete RSVP
-	if err := h.rsvpRepo.Delete(eventID, userDID); err != nil {
+	if err := h.rsvpRepo.Delete(r.Context(), eventID, userDID); err != nil {
 		if err == scene.ErrRSVPNotFound {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "RSVP not found")
+			writeError(w, r, ErrCodeNotFound, "RSVP not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to delete RSVP", "error", err, "event_id", eventID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to delete RSVP")
+		writeError(w, r, ErrCodeInternal, "Failed to delete RSVP")
 		return
 	}
 
+	if existingRSVP.Status == "going" {
+		h.promoteFromWaitlist(r.Context(), existingEvent)
+	}
+
 	// Return success with no content
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// promoteFromWaitlist moves the oldest waitlisted RSVPs to "going" while the
+// event has open seats. Failures are logged rather than returned because the
+// triggering RSVP change has already been saved.
+func (h *RSVPHandlers) promoteFromWaitlist(ctx context.Context, event *scene.Event) {
+	if event.Capacity <= 0 {
+		return
+	}
+	if _, err := h.rsvpRepo.PromoteWaitlisted(ctx, event.ID, event.Capacity); err != nil {
+		slog.ErrorContext(ctx, "failed to promote waitlisted RSVPs", "error", err, "event_id", event.ID)
+	}
+}
+
+// ListRSVPs handles GET /events/{id}/rsvps - lists attendees for an event.
+// Only the owner of the event's scene may view attendee identities.
+// Query parameters: status (going, maybe, or waitlisted), limit (optional).
+func (h *RSVPHandlers) ListRSVPs(w http.ResponseWriter, r *http.Request) {
+	// Extract event ID from URL path
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/"), "/")
+	if len(pathParts) < 2 || pathParts[0] == "" {
+		writeError(w, r, ErrCodeBadRequest, "Event ID is required")
+		return
+	}
+	eventID := pathParts[0]
+
+	query := r.URL.Query()
+	status := query.Get("status")
+	if status != "" && status != "going" && status != "maybe" && status != "waitlisted" {
+		writeError(w, r, ErrCodeValidation, "status must be 'going', 'maybe', or 'waitlisted'")
+		return
+	}
+
+	limit := DefaultAttendeeLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsedLimit, err := parseIntInRange(limitStr, "limit", 1, MaxAttendeeLimit)
+		if err != nil {
+			writeError(w, r, ErrCodeValidation, err.Error())
+			return
+		}
+		limit = parsedLimit
+	}
+
+	// Get user DID from context (set by auth middleware)
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	existingEvent, err := h.eventRepo.GetByID(r.Context(), eventID)
+	if err != nil {
+		if err == scene.ErrEventNotFound {
+			writeError(w, r, ErrCodeNotFound, "Event not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to get event", "error", err, "event_id", eventID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve event")
+		return
+	}
+
+	// Only the scene owner may see attendee identities. Missing or deleted
+	// scenes get the same forbidden response so nothing is revealed to outsiders.
+	parentScene, err := h.sceneRepo.GetByID(r.Context(), existingEvent.SceneID)
+	if err != nil && err != scene.ErrSceneNotFound && err != scene.ErrSceneDeleted {
+		slog.ErrorContext(r.Context(), "failed to get scene", "error", err, "scene_id", existingEvent.SceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to verify scene ownership")
+		return
+	}
+	if parentScene == nil || !parentScene.IsOwner(userDID) {
+		writeError(w, r, ErrCodeForbidden, "You do not have permission to view attendees for this event")
+		return
+	}
+
+	rsvps, err := h.rsvpRepo.ListByEvent(r.Context(), eventID, status)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to list RSVPs", "error", err, "event_id", eventID)
+		writeError(w, r, ErrCodeInternal, "Failed to list RSVPs")
+		return
+	}
+	if len(rsvps) > limit {
+		rsvps = rsvps[:limit]
+	}
+
+	attendees := make([]*AttendeeResponse, len(rsvps))
+	for i, rsvp := range rsvps {
+		attendees[i] = &AttendeeResponse{
+			UserDID:     rsvp.UserID,
+			Status:      rsvp.Status,
+			CreatedAt:   rsvp.CreatedAt,
+			CheckedInAt: rsvp.CheckedInAt,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(ListRSVPsResponse{EventID: eventID, Attendees: attendees}); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode RSVP list response", "error", err)
+	}
+}
+
+// CheckIn handles POST /events/{id}/checkin - checks an attendee in at the
+// event. Only the scene owner or an admin may check attendees in, only
+// attendees whose RSVP is "going" can be checked in, and only between the
+// event's start and CheckInGracePeriod after it ends. Checking in an attendee
+// again is a no-op that keeps the original check-in time.
+func (h *RSVPHandlers) CheckIn(w http.ResponseWriter, r *http.Request) {
+	// Extract event ID from URL path
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/"), "/")
+	if len(pathParts) < 2 || pathParts[0] == "" {
+		writeError(w, r, ErrCodeBadRequest, "Event ID is required")
+		return
+	}
+	eventID := pathParts[0]
+
+	var req CheckInRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+	attendeeDID := strings.TrimSpace(req.UserDID)
+	if attendeeDID == "" {
+		writeError(w, r, ErrCodeValidation, "user_did is required")
+		return
+	}
+
+	// Get user DID from context (set by auth middleware)
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	existingEvent, err := h.eventRepo.GetByID(r.Context(), eventID)
+	if err != nil {
+		if err == scene.ErrEventNotFound {
+			writeError(w, r, ErrCodeNotFound, "Event not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to get event", "error", err, "event_id", eventID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve event")
+		return
+	}
+
+	// Missing or deleted scenes get the same forbidden response as ListRSVPs
+	parentScene, err := h.sceneRepo.GetByID(r.Context(), existingEvent.SceneID)
+	if err != nil && err != scene.ErrSceneNotFound && err != scene.ErrSceneDeleted {
+		slog.ErrorContext(r.Context(), "failed to get scene", "error", err, "scene_id", existingEvent.SceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to verify scene ownership")
+		return
+	}
+	isModerator := false
+	if parentScene != nil {
+		isModerator, err = isModeratorForScene(r.Context(), h.membershipRepo, parentScene, userDID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to check moderator access", "error", err, "scene_id", parentScene.ID)
+			writeError(w, r, ErrCodeInternal, "Failed to check access permissions")
+			return
+		}
+	}
+	if !isModerator {
+		writeError(w, r, ErrCodeForbidden, "You do not have permission to check in attendees for this event")
+		return
+	}
+
+	if existingEvent.Status == "cancelled" {
+		writeError(w, r, ErrCodeValidation, "Cannot check in to a cancelled event")
+		return
+	}
+	now := time.Now()
+	if opens, closes := checkInWindow(existingEvent); now.Before(opens) || now.After(closes) {
+		writeError(w, r, ErrCodeValidation, "Check-in is only open from the event start until "+CheckInGracePeriod.String()+" after it ends")
+		return
+	}
+
+	rsvp, err := h.rsvpRepo.CheckIn(r.Context(), eventID, attendeeDID)
+	if err != nil {
+		if err == scene.ErrRSVPNotFound {
+			writeError(w, r, ErrCodeNotFound, "RSVP not found")
+			return
+		}
+		if err == scene.ErrRSVPNotGoing {
+			writeError(w, r, ErrCodeValidation, "Only attendees who are going can be checked in")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to check in attendee", "error", err, "event_id", eventID)
+		writeError(w, r, ErrCodeInternal, "Failed to check in attendee")
+		return
+	}
+
+	count, err := h.rsvpRepo.GetCheckinCount(r.Context(), eventID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to get check-in count", "error", err, "event_id", eventID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve check-in count")
+		return
+	}
+
+	response := CheckInResponse{
+		EventID:        eventID,
+		UserDID:        attendeeDID,
+		CheckedInAt:    rsvp.CheckedInAt,
+		CheckedInCount: count,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode check-in response", "error", err)
+	}
+}
+
+// checkInWindow returns when check-in opens and closes for an event. Events
+// without an end time are treated as lasting DefaultICSEventDuration.
+func checkInWindow(event *scene.Event) (opens, closes time.Time) {
+	endsAt := event.StartsAt.Add(DefaultICSEventDuration)
+	if event.EndsAt != nil {
+		endsAt = *event.EndsAt
+	}
+	return event.StartsAt, endsAt.Add(CheckInGracePeriod)
+}
+
+// ListMyRSVPs handles GET /rsvps/mine - lists the authenticated user's RSVPs
+// with minimal event info, oldest RSVP first.
+// Query parameters: status (going, maybe, or waitlisted), include_inactive
+// (true to include RSVPs for cancelled or deleted events; default false).
+func (h *RSVPHandlers) ListMyRSVPs(w http.ResponseWriter, r *http.Request) {
+	// Get user DID from context (set by auth middleware)
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	query := r.URL.Query()
+	status := query.Get("status")
+	if status != "" && status != "going" && status != "maybe" && status != "waitlisted" {
+		writeError(w, r, ErrCodeValidation, "status must be 'going', 'maybe', or 'waitlisted'")
+		return
+	}
+
+	includeInactive := false
+	if includeStr := query.Get("include_inactive"); includeStr != "" {
+		parsed, err := strconv.ParseBool(strings.TrimSpace(includeStr))
+		if err != nil {
+			writeError(w, r, ErrCodeValidation, "include_inactive must be true or false")
+			return
+		}
+		includeInactive = parsed
+	}
+
+	rsvps, err := h.rsvpRepo.ListByUser(r.Context(), userDID, status)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to list user RSVPs", "error", err)
+		writeError(w, r, ErrCodeInternal, "Failed to list RSVPs")
+		return
+	}
+
+	// Scene names are shared by many RSVPs, so look each scene up once
+	sceneNames := make(map[string]string)
+	results := make([]*MyRSVPResponse, 0, len(rsvps))
+	for _, rsvp := range rsvps {
+		event, err := h.myRSVPEvent(r.Context(), rsvp.EventID, sceneNames)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to get event for RSVP", "error", err, "event_id", rsvp.EventID)
+			writeError(w, r, ErrCodeInternal, "Failed to retrieve events")
+			return
+		}
+		if !includeInactive && (event == nil || event.Status == "cancelled") {
+			continue
+		}
+		results = append(results, &MyRSVPResponse{
+			EventID:   rsvp.EventID,
+			Status:    rsvp.Status,
+			CreatedAt: rsvp.CreatedAt,
+			UpdatedAt: rsvp.UpdatedAt,
+			Event:     event,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(ListMyRSVPsResponse{RSVPs: results}); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode my RSVPs response", "error", err)
+	}
+}
+
+// myRSVPEvent returns the event summary for an RSVP, or nil if the event or
+// its scene has been deleted. sceneNames caches scene lookups by ID; deleted
+// scenes are cached as "".
+func (h *RSVPHandlers) myRSVPEvent(ctx context.Context, eventID string, sceneNames map[string]string) (*MyRSVPEvent, error) {
+	event, err := h.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		if err == scene.ErrEventNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	name, cached := sceneNames[event.SceneID]
+	if !cached {
+		parentScene, err := h.sceneRepo.GetByID(ctx, event.SceneID)
+		if err != nil && err != scene.ErrSceneNotFound && err != scene.ErrSceneDeleted {
+			return nil, err
+		}
+		if parentScene != nil {
+			name = parentScene.Name
+		}
+		sceneNames[event.SceneID] = name
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	return &MyRSVPEvent{
+		Title:     event.Title,
+		StartsAt:  event.StartsAt,
+		Status:    event.Status,
+		SceneID:   event.SceneID,
+		SceneName: name,
+	}, nil
+}
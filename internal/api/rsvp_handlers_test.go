@@ -2,12 +2,17 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/onnwee/subcults/internal/membership"
 	"github.com/onnwee/subcults/internal/middleware"
 	"github.com/onnwee/subcults/internal/scene"
 )
@@ -15,7 +20,7 @@ import (
 func TestCreateOrUpdateRSVP_Success(t *testing.T) {
 	rsvpRepo := scene.NewInMemoryRSVPRepository()
 	eventRepo := scene.NewInMemoryEventRepository()
-	handlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo, scene.NewInMemorySceneRepository())
 
 	// Create a future event
 	futureTime := time.Now().Add(24 * time.Hour)
@@ -26,7 +31,7 @@ func TestCreateOrUpdateRSVP_Success(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		StartsAt:      futureTime,
 	}
-	if err := eventRepo.Insert(event); err != nil {
+	if err := eventRepo.Insert(t.Context(), event); err != nil {
 		t.Fatalf("Failed to insert event: %v", err)
 	}
 
@@ -67,7 +72,7 @@ func TestCreateOrUpdateRSVP_Success(t *testing.T) {
 	}
 
 	// Verify RSVP was created in repository
-	stored, err := rsvpRepo.GetByEventAndUser("event-1", "did:plc:user1")
+	stored, err := rsvpRepo.GetByEventAndUser(ctx, "event-1", "did:plc:user1")
 	if err != nil {
 		t.Fatalf("Failed to get RSVP: %v", err)
 	}
@@ -79,7 +84,7 @@ func TestCreateOrUpdateRSVP_Success(t *testing.T) {
 func TestCreateOrUpdateRSVP_UpdateStatus(t *testing.T) {
 	rsvpRepo := scene.NewInMemoryRSVPRepository()
 	eventRepo := scene.NewInMemoryEventRepository()
-	handlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo, scene.NewInMemorySceneRepository())
 
 	// Create a future event
 	futureTime := time.Now().Add(24 * time.Hour)
@@ -90,7 +95,7 @@ func TestCreateOrUpdateRSVP_UpdateStatus(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		StartsAt:      futureTime,
 	}
-	if err := eventRepo.Insert(event); err != nil {
+	if err := eventRepo.Insert(t.Context(), event); err != nil {
 		t.Fatalf("Failed to insert event: %v", err)
 	}
 
@@ -100,7 +105,7 @@ func TestCreateOrUpdateRSVP_UpdateStatus(t *testing.T) {
 		UserID:  "did:plc:user1",
 		Status:  "maybe",
 	}
-	if err := rsvpRepo.Upsert(initialRSVP); err != nil {
+	if err := rsvpRepo.Upsert(t.Context(), initialRSVP); err != nil {
 		t.Fatalf("Failed to create initial RSVP: %v", err)
 	}
 
@@ -122,7 +127,7 @@ func TestCreateOrUpdateRSVP_UpdateStatus(t *testing.T) {
 	}
 
 	// Verify status was updated
-	stored, err := rsvpRepo.GetByEventAndUser("event-1", "did:plc:user1")
+	stored, err := rsvpRepo.GetByEventAndUser(ctx, "event-1", "did:plc:user1")
 	if err != nil {
 		t.Fatalf("Failed to get RSVP: %v", err)
 	}
@@ -134,7 +139,7 @@ func TestCreateOrUpdateRSVP_UpdateStatus(t *testing.T) {
 func TestCreateOrUpdateRSVP_Idempotent(t *testing.T) {
 	rsvpRepo := scene.NewInMemoryRSVPRepository()
 	eventRepo := scene.NewInMemoryEventRepository()
-	handlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo, scene.NewInMemorySceneRepository())
 
 	// Create a future event
 	futureTime := time.Now().Add(24 * time.Hour)
@@ -145,7 +150,7 @@ func TestCreateOrUpdateRSVP_Idempotent(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		StartsAt:      futureTime,
 	}
-	if err := eventRepo.Insert(event); err != nil {
+	if err := eventRepo.Insert(t.Context(), event); err != nil {
 		t.Fatalf("Failed to insert event: %v", err)
 	}
 
@@ -183,7 +188,7 @@ func TestCreateOrUpdateRSVP_Idempotent(t *testing.T) {
 	}
 
 	// Verify RSVP still exists with correct status
-	stored, err := rsvpRepo.GetByEventAndUser("event-1", "did:plc:user1")
+	stored, err := rsvpRepo.GetByEventAndUser(t.Context(), "event-1", "did:plc:user1")
 	if err != nil {
 		t.Fatalf("Failed to get RSVP: %v", err)
 	}
@@ -192,7 +197,7 @@ func TestCreateOrUpdateRSVP_Idempotent(t *testing.T) {
 	}
 
 	// Verify no duplicate was created (should still be just one RSVP)
-	counts, err := rsvpRepo.GetCountsByEvent("event-1")
+	counts, err := rsvpRepo.GetCountsByEvent(t.Context(), "event-1")
 	if err != nil {
 		t.Fatalf("Failed to get counts: %v", err)
 	}
@@ -204,7 +209,7 @@ func TestCreateOrUpdateRSVP_Idempotent(t *testing.T) {
 func TestCreateOrUpdateRSVP_InvalidStatus(t *testing.T) {
 	rsvpRepo := scene.NewInMemoryRSVPRepository()
 	eventRepo := scene.NewInMemoryEventRepository()
-	handlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo, scene.NewInMemorySceneRepository())
 
 	// Create request with invalid status
 	reqBody := RSVPRequest{Status: "invalid"}
@@ -227,7 +232,7 @@ func TestCreateOrUpdateRSVP_InvalidStatus(t *testing.T) {
 func TestCreateOrUpdateRSVP_PastEvent(t *testing.T) {
 	rsvpRepo := scene.NewInMemoryRSVPRepository()
 	eventRepo := scene.NewInMemoryEventRepository()
-	handlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo, scene.NewInMemorySceneRepository())
 
 	// Create a past event
 	pastTime := time.Now().Add(-24 * time.Hour)
@@ -238,7 +243,7 @@ func TestCreateOrUpdateRSVP_PastEvent(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		StartsAt:      pastTime,
 	}
-	if err := eventRepo.Insert(event); err != nil {
+	if err := eventRepo.Insert(t.Context(), event); err != nil {
 		t.Fatalf("Failed to insert event: %v", err)
 	}
 
@@ -263,7 +268,7 @@ func TestCreateOrUpdateRSVP_PastEvent(t *testing.T) {
 func TestCreateOrUpdateRSVP_EventNotFound(t *testing.T) {
 	rsvpRepo := scene.NewInMemoryRSVPRepository()
 	eventRepo := scene.NewInMemoryEventRepository()
-	handlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo, scene.NewInMemorySceneRepository())
 
 	// Try to RSVP to non-existent event
 	reqBody := RSVPRequest{Status: "going"}
@@ -286,7 +291,7 @@ func TestCreateOrUpdateRSVP_EventNotFound(t *testing.T) {
 func TestCreateOrUpdateRSVP_Unauthenticated(t *testing.T) {
 	rsvpRepo := scene.NewInMemoryRSVPRepository()
 	eventRepo := scene.NewInMemoryEventRepository()
-	handlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo, scene.NewInMemorySceneRepository())
 
 	// Create request without user DID in context
 	reqBody := RSVPRequest{Status: "going"}
@@ -306,7 +311,7 @@ func TestCreateOrUpdateRSVP_Unauthenticated(t *testing.T) {
 func TestDeleteRSVP_Success(t *testing.T) {
 	rsvpRepo := scene.NewInMemoryRSVPRepository()
 	eventRepo := scene.NewInMemoryEventRepository()
-	handlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo, scene.NewInMemorySceneRepository())
 
 	// Create a future event
 	futureTime := time.Now().Add(24 * time.Hour)
@@ -317,7 +322,7 @@ func TestDeleteRSVP_Success(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		StartsAt:      futureTime,
 	}
-	if err := eventRepo.Insert(event); err != nil {
+	if err := eventRepo.Insert(t.Context(), event); err != nil {
 		t.Fatalf("Failed to insert event: %v", err)
 	}
 
@@ -327,7 +332,7 @@ func TestDeleteRSVP_Success(t *testing.T) {
 		UserID:  "did:plc:user1",
 		Status:  "going",
 	}
-	if err := rsvpRepo.Upsert(rsvp); err != nil {
+	if err := rsvpRepo.Upsert(t.Context(), rsvp); err != nil {
 		t.Fatalf("Failed to create RSVP: %v", err)
 	}
 
@@ -345,7 +350,7 @@ func TestDeleteRSVP_Success(t *testing.T) {
 	}
 
 	// Verify RSVP was deleted
-	_, err := rsvpRepo.GetByEventAndUser("event-1", "did:plc:user1")
+	_, err := rsvpRepo.GetByEventAndUser(ctx, "event-1", "did:plc:user1")
 	if err != scene.ErrRSVPNotFound {
 		t.Errorf("Expected ErrRSVPNotFound, got %v", err)
 	}
@@ -354,7 +359,7 @@ func TestDeleteRSVP_Success(t *testing.T) {
 func TestDeleteRSVP_NotFound(t *testing.T) {
 	rsvpRepo := scene.NewInMemoryRSVPRepository()
 	eventRepo := scene.NewInMemoryEventRepository()
-	handlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo, scene.NewInMemorySceneRepository())
 
 	// Create a future event
 	futureTime := time.Now().Add(24 * time.Hour)
@@ -365,7 +370,7 @@ func TestDeleteRSVP_NotFound(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		StartsAt:      futureTime,
 	}
-	if err := eventRepo.Insert(event); err != nil {
+	if err := eventRepo.Insert(t.Context(), event); err != nil {
 		t.Fatalf("Failed to insert event: %v", err)
 	}
 
@@ -386,7 +391,7 @@ func TestDeleteRSVP_NotFound(t *testing.T) {
 func TestDeleteRSVP_PastEvent(t *testing.T) {
 	rsvpRepo := scene.NewInMemoryRSVPRepository()
 	eventRepo := scene.NewInMemoryEventRepository()
-	handlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo, scene.NewInMemorySceneRepository())
 
 	// Create a past event
 	pastTime := time.Now().Add(-24 * time.Hour)
@@ -397,7 +402,7 @@ func TestDeleteRSVP_PastEvent(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		StartsAt:      pastTime,
 	}
-	if err := eventRepo.Insert(event); err != nil {
+	if err := eventRepo.Insert(t.Context(), event); err != nil {
 		t.Fatalf("Failed to insert event: %v", err)
 	}
 
@@ -407,7 +412,7 @@ func TestDeleteRSVP_PastEvent(t *testing.T) {
 		UserID:  "did:plc:user1",
 		Status:  "going",
 	}
-	if err := rsvpRepo.Upsert(rsvp); err != nil {
+	if err := rsvpRepo.Upsert(t.Context(), rsvp); err != nil {
 		t.Fatalf("Failed to create RSVP: %v", err)
 	}
 
@@ -428,7 +433,7 @@ func TestDeleteRSVP_PastEvent(t *testing.T) {
 func TestDeleteRSVP_Unauthenticated(t *testing.T) {
 	rsvpRepo := scene.NewInMemoryRSVPRepository()
 	eventRepo := scene.NewInMemoryEventRepository()
-	handlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo, scene.NewInMemorySceneRepository())
 
 	// Try to delete without user DID in context
 	req := httptest.NewRequest("DELETE", "/events/event-1/rsvp", nil)
@@ -441,3 +446,774 @@ func TestDeleteRSVP_Unauthenticated(t *testing.T) {
 		t.Errorf("Expected status 401, got %d", w.Code)
 	}
 }
+
+// TestCreateOrUpdateRSVP_CancelledEvent tests that RSVPs to cancelled events are rejected.
+func TestCreateOrUpdateRSVP_CancelledEvent(t *testing.T) {
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	eventRepo := scene.NewInMemoryEventRepository()
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo, scene.NewInMemorySceneRepository())
+
+	event := &scene.Event{
+		ID:            "event-1",
+		SceneID:       "scene-1",
+		Title:         "Cancelled Event",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+		Status:        "cancelled",
+	}
+	if err := eventRepo.Insert(t.Context(), event); err != nil {
+		t.Fatalf("Failed to insert event: %v", err)
+	}
+
+	body, _ := json.Marshal(RSVPRequest{Status: "going"})
+	req := httptest.NewRequest("POST", "/events/event-1/rsvp", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:user1"))
+
+	w := httptest.NewRecorder()
+	handlers.CreateOrUpdateRSVP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", w.Code)
+	}
+
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeValidation {
+		t.Errorf("Expected error code %s, got %s", ErrCodeValidation, errResp.Error.Code)
+	}
+
+	if _, err := rsvpRepo.GetByEventAndUser(t.Context(), "event-1", "did:plc:user1"); err != scene.ErrRSVPNotFound {
+		t.Errorf("Expected no RSVP to be stored, got err=%v", err)
+	}
+}
+
+// seedAttendees seeds scene-1 with the given visibility, owned by
+// did:plc:owner, holding event-1 from startsAt to endsAt (nil for no end
+// time) with three RSVPs.
+func seedAttendees(t *testing.T, env *testEnv, visibility string, startsAt time.Time, endsAt *time.Time) {
+	t.Helper()
+	env.addScene(t, &scene.Scene{ID: "scene-1", Name: "Attendee Scene", Visibility: visibility})
+	env.addEvent(t, &scene.Event{ID: "event-1", SceneID: "scene-1", Title: "Show", StartsAt: startsAt, EndsAt: endsAt})
+	env.addRSVP(t, "event-1", "did:plc:alice", "going")
+	env.addRSVP(t, "event-1", "did:plc:bob", "maybe")
+	env.addRSVP(t, "event-1", "did:plc:carol", "going")
+}
+
+// doListRSVPs performs a ListRSVPs request as the given user.
+func doListRSVPs(handlers *RSVPHandlers, query, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/events/event-1/rsvps"+query, nil)
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.ListRSVPs(w, req)
+	return w
+}
+
+func TestListRSVPs_OwnerAccess(t *testing.T) {
+	env := newTestEnv()
+	seedAttendees(t, env, scene.VisibilityPublic, time.Now().Add(24*time.Hour), nil)
+	handlers := env.rsvpHandlers()
+
+	tests := []struct {
+		name      string
+		query     string
+		wantCount int
+	}{
+		{"all attendees", "", 3},
+		{"going only", "?status=going", 2},
+		{"maybe only", "?status=maybe", 1},
+		{"limited", "?limit=2", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doListRSVPs(handlers, tt.query, "did:plc:owner")
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var resp ListRSVPsResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.EventID != "event-1" {
+				t.Errorf("Expected event_id event-1, got %s", resp.EventID)
+			}
+			if len(resp.Attendees) != tt.wantCount {
+				t.Fatalf("Expected %d attendees, got %d", tt.wantCount, len(resp.Attendees))
+			}
+			for _, a := range resp.Attendees {
+				if !strings.HasPrefix(a.UserDID, "did:plc:") {
+					t.Errorf("Expected attendee DID, got %q", a.UserDID)
+				}
+			}
+		})
+	}
+}
+
+func TestListRSVPs_NonOwnerRejected(t *testing.T) {
+	for _, visibility := range []string{scene.VisibilityPublic, scene.VisibilityMembersOnly} {
+		t.Run(visibility, func(t *testing.T) {
+			env := newTestEnv()
+			seedAttendees(t, env, visibility, time.Now().Add(24*time.Hour), nil)
+			handlers := env.rsvpHandlers()
+
+			w := doListRSVPs(handlers, "", "did:plc:alice")
+			if w.Code != http.StatusForbidden {
+				t.Fatalf("Expected status 403, got %d", w.Code)
+			}
+			if strings.Contains(w.Body.String(), "did:plc:bob") {
+				t.Error("Response must not leak attendee identities")
+			}
+
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("Failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != ErrCodeForbidden {
+				t.Errorf("Expected error code %s, got %s", ErrCodeForbidden, errResp.Error.Code)
+			}
+		})
+	}
+}
+
+func TestListRSVPs_Validation(t *testing.T) {
+	env := newTestEnv()
+	seedAttendees(t, env, scene.VisibilityPublic, time.Now().Add(24*time.Hour), nil)
+	handlers := env.rsvpHandlers()
+
+	if w := doListRSVPs(handlers, "", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 without auth, got %d", w.Code)
+	}
+	if w := doListRSVPs(handlers, "?status=declined", "did:plc:owner"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid status, got %d", w.Code)
+	}
+	if w := doListRSVPs(handlers, "?limit=0", "did:plc:owner"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid limit, got %d", w.Code)
+	}
+}
+
+// rsvpAs posts an RSVP for event-1 as the given user and returns the decoded response.
+func rsvpAs(t *testing.T, handlers *RSVPHandlers, userDID, status string) RSVPResponse {
+	t.Helper()
+	body, _ := json.Marshal(RSVPRequest{Status: status})
+	req := httptest.NewRequest("POST", "/events/event-1/rsvp", bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	handlers.CreateOrUpdateRSVP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 for %s, got %d: %s", userDID, w.Code, w.Body.String())
+	}
+	var resp RSVPResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func TestCreateOrUpdateRSVP_WaitlistWhenFull(t *testing.T) {
+	env := newTestEnv()
+	env.addEvent(t, &scene.Event{ID: "event-1", SceneID: "scene-1", Title: "Small Show", StartsAt: time.Now().Add(24 * time.Hour), Capacity: 2})
+	handlers := env.rsvpHandlers()
+
+	wantStatuses := []struct {
+		user string
+		want string
+	}{
+		{"did:plc:user1", "going"},
+		{"did:plc:user2", "going"},
+		{"did:plc:user3", "waitlisted"},
+		{"did:plc:user4", "waitlisted"},
+	}
+	for _, ws := range wantStatuses {
+		if got := rsvpAs(t, handlers, ws.user, "going").Status; got != ws.want {
+			t.Errorf("%s: expected status %s, got %s", ws.user, ws.want, got)
+		}
+	}
+
+	// A seated user re-sending "going" keeps the seat
+	if got := rsvpAs(t, handlers, "did:plc:user1", "going").Status; got != "going" {
+		t.Errorf("Expected seated user to remain going, got %s", got)
+	}
+
+	// "maybe" is never waitlisted
+	if got := rsvpAs(t, handlers, "did:plc:user5", "maybe").Status; got != "maybe" {
+		t.Errorf("Expected maybe RSVP to stay maybe, got %s", got)
+	}
+
+	counts, err := env.rsvps.GetCountsByEvent(t.Context(), "event-1")
+	if err != nil {
+		t.Fatalf("GetCountsByEvent failed: %v", err)
+	}
+	if counts.Going != 2 || counts.Waitlisted != 2 || counts.Maybe != 1 {
+		t.Errorf("Expected counts going=2 waitlisted=2 maybe=1, got %+v", counts)
+	}
+}
+
+func TestCreateOrUpdateRSVP_UnlimitedCapacity(t *testing.T) {
+	env := newTestEnv()
+	env.addEvent(t, &scene.Event{ID: "event-1", SceneID: "scene-1", Title: "Small Show", StartsAt: time.Now().Add(24 * time.Hour), Capacity: 0})
+	handlers := env.rsvpHandlers()
+
+	for i := 1; i <= 5; i++ {
+		user := "did:plc:user" + string(rune('0'+i))
+		if got := rsvpAs(t, handlers, user, "going").Status; got != "going" {
+			t.Errorf("%s: expected going with unlimited capacity, got %s", user, got)
+		}
+	}
+}
+
+// TestCreateOrUpdateRSVP_ConcurrentGoingNeverExceedsCapacity tests that
+// concurrent "going" RSVPs, racing with seated users giving up their seats,
+// never seat more users than the event's capacity.
+func TestCreateOrUpdateRSVP_ConcurrentGoingNeverExceedsCapacity(t *testing.T) {
+	const capacity = 5
+	env := newTestEnv()
+	env.addEvent(t, &scene.Event{ID: "event-1", SceneID: "scene-1", Title: "Small Show", StartsAt: time.Now().Add(24 * time.Hour), Capacity: capacity})
+	handlers := env.rsvpHandlers()
+	for i := range capacity {
+		rsvpAs(t, handlers, fmt.Sprintf("did:plc:seated%d", i), "going")
+	}
+
+	send := func(userDID, status string) {
+		body, _ := json.Marshal(RSVPRequest{Status: status})
+		req := httptest.NewRequest("POST", "/events/event-1/rsvp", bytes.NewReader(body))
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+		w := httptest.NewRecorder()
+		handlers.CreateOrUpdateRSVP(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200 for %s, got %d: %s", userDID, w.Code, w.Body.String())
+		}
+	}
+
+	// Watch the going count while requests are in flight
+	stop := make(chan struct{})
+	watched := make(chan int)
+	go func() {
+		maxGoing := 0
+		for {
+			select {
+			case <-stop:
+				watched <- maxGoing
+				return
+			default:
+			}
+			counts, err := env.rsvps.GetCountsByEvent(context.Background(), "event-1")
+			if err == nil {
+				maxGoing = max(maxGoing, counts.Going)
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := range 40 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			send(fmt.Sprintf("did:plc:user%d", i), "going")
+		}()
+	}
+	for i := range capacity {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			send(fmt.Sprintf("did:plc:seated%d", i), "maybe")
+		}()
+	}
+	wg.Wait()
+	close(stop)
+
+	if maxGoing := <-watched; maxGoing > capacity {
+		t.Errorf("Expected at most %d going while requests raced, saw %d", capacity, maxGoing)
+	}
+	counts, err := env.rsvps.GetCountsByEvent(t.Context(), "event-1")
+	if err != nil {
+		t.Fatalf("Failed to get counts: %v", err)
+	}
+	if counts.Going != capacity {
+		t.Errorf("Expected freed seats to be refilled to %d going, got %d", capacity, counts.Going)
+	}
+	waitlisted, err := env.rsvps.ListByEvent(t.Context(), "event-1", "waitlisted")
+	if err != nil {
+		t.Fatalf("Failed to list waitlist: %v", err)
+	}
+	if len(waitlisted) != 40-capacity {
+		t.Errorf("Expected %d waitlisted, got %d", 40-capacity, len(waitlisted))
+	}
+}
+
+func TestDeleteRSVP_PromotesOldestWaitlisted(t *testing.T) {
+	env := newTestEnv()
+	env.addEvent(t, &scene.Event{ID: "event-1", SceneID: "scene-1", Title: "Small Show", StartsAt: time.Now().Add(24 * time.Hour), Capacity: 1})
+	handlers := env.rsvpHandlers()
+
+	rsvpAs(t, handlers, "did:plc:user1", "going")
+	rsvpAs(t, handlers, "did:plc:user2", "going") // waitlisted first
+	rsvpAs(t, handlers, "did:plc:user3", "going") // waitlisted second
+
+	req := httptest.NewRequest("DELETE", "/events/event-1/rsvp", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:user1"))
+	w := httptest.NewRecorder()
+	handlers.DeleteRSVP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+
+	promoted, err := env.rsvps.GetByEventAndUser(t.Context(), "event-1", "did:plc:user2")
+	if err != nil {
+		t.Fatalf("GetByEventAndUser failed: %v", err)
+	}
+	if promoted.Status != "going" {
+		t.Errorf("Expected oldest waitlisted RSVP to be promoted, got %s", promoted.Status)
+	}
+
+	stillWaiting, err := env.rsvps.GetByEventAndUser(t.Context(), "event-1", "did:plc:user3")
+	if err != nil {
+		t.Fatalf("GetByEventAndUser failed: %v", err)
+	}
+	if stillWaiting.Status != "waitlisted" {
+		t.Errorf("Expected second waitlisted RSVP to remain waitlisted, got %s", stillWaiting.Status)
+	}
+}
+
+func TestCreateOrUpdateRSVP_DowngradePromotesWaitlisted(t *testing.T) {
+	env := newTestEnv()
+	env.addEvent(t, &scene.Event{ID: "event-1", SceneID: "scene-1", Title: "Small Show", StartsAt: time.Now().Add(24 * time.Hour), Capacity: 1})
+	handlers := env.rsvpHandlers()
+
+	rsvpAs(t, handlers, "did:plc:user1", "going")
+	rsvpAs(t, handlers, "did:plc:user2", "going")
+
+	// Switching from going to maybe gives up the seat
+	if got := rsvpAs(t, handlers, "did:plc:user1", "maybe").Status; got != "maybe" {
+		t.Fatalf("Expected maybe, got %s", got)
+	}
+
+	promoted, err := env.rsvps.GetByEventAndUser(t.Context(), "event-1", "did:plc:user2")
+	if err != nil {
+		t.Fatalf("GetByEventAndUser failed: %v", err)
+	}
+	if promoted.Status != "going" {
+		t.Errorf("Expected waitlisted RSVP to be promoted, got %s", promoted.Status)
+	}
+}
+
+func TestCreateOrUpdateRSVP_BannedUser(t *testing.T) {
+	env := newTestEnv()
+	env.addEvent(t, &scene.Event{ID: "event-1", SceneID: "scene-1", Title: "Small Show", StartsAt: time.Now().Add(24 * time.Hour), Capacity: 0})
+	handlers := env.rsvpHandlers()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	handlers.SetMembershipRepository(membershipRepo)
+
+	if _, err := membershipRepo.Upsert(t.Context(), &membership.Membership{
+		SceneID: "scene-1",
+		UserDID: "did:plc:banned",
+		Role:    "member",
+		Status:  "banned",
+	}); err != nil {
+		t.Fatalf("Failed to create banned membership: %v", err)
+	}
+
+	body, _ := json.Marshal(RSVPRequest{Status: "going"})
+	req := httptest.NewRequest("POST", "/events/event-1/rsvp", bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:banned"))
+	w := httptest.NewRecorder()
+	handlers.CreateOrUpdateRSVP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("Expected status 403, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, err := env.rsvps.GetByEventAndUser(t.Context(), "event-1", "did:plc:banned"); err != scene.ErrRSVPNotFound {
+		t.Errorf("Expected no RSVP to be stored, got err %v", err)
+	}
+
+	// Users without a ban are unaffected
+	if got := rsvpAs(t, handlers, "did:plc:user1", "going").Status; got != "going" {
+		t.Errorf("Expected going, got %s", got)
+	}
+}
+
+func TestCreateOrUpdateRSVP_StatusChangeInterval(t *testing.T) {
+	env := newTestEnv()
+	env.addEvent(t, &scene.Event{ID: "event-1", SceneID: "scene-1", Title: "Small Show", StartsAt: time.Now().Add(24 * time.Hour), Capacity: 0})
+	handlers := env.rsvpHandlers()
+	handlers.SetStatusChangeInterval(DefaultRSVPStatusChangeInterval)
+
+	rsvpAs(t, handlers, "did:plc:user1", "going")
+
+	// Re-sending the same status is not a change
+	if got := rsvpAs(t, handlers, "did:plc:user1", "going").Status; got != "going" {
+		t.Errorf("Expected going, got %s", got)
+	}
+
+	body, _ := json.Marshal(RSVPRequest{Status: "maybe"})
+	req := httptest.NewRequest("POST", "/events/event-1/rsvp", bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:user1"))
+	w := httptest.NewRecorder()
+	handlers.CreateOrUpdateRSVP(w, req)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status 429, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Expected Retry-After 2, got %q", got)
+	}
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeRateLimited {
+		t.Errorf("Expected error code %s, got %s", ErrCodeRateLimited, errResp.Error.Code)
+	}
+
+	// Other users are throttled independently
+	rsvpAs(t, handlers, "did:plc:user2", "maybe")
+
+	counts, err := env.rsvps.GetCountsByEvent(t.Context(), "event-1")
+	if err != nil {
+		t.Fatalf("GetCountsByEvent failed: %v", err)
+	}
+	if counts.Going != 1 || counts.Maybe != 1 {
+		t.Errorf("Expected counts going=1 maybe=1, got %+v", counts)
+	}
+}
+
+// seedMyRSVPs seeds RSVPs from two users across an upcoming, a cancelled, a
+// deleted, and a deleted-scene event.
+func seedMyRSVPs(t *testing.T, env *testEnv) {
+	t.Helper()
+	env.addScene(t, &scene.Scene{ID: "scene-1", Name: "Basement Shows", Visibility: scene.VisibilityPublic})
+	env.addScene(t, &scene.Scene{ID: "scene-2", Name: "Gone Scene", Visibility: scene.VisibilityPublic})
+
+	startsAt := time.Now().Add(24 * time.Hour)
+	for _, e := range []*scene.Event{
+		{ID: "event-upcoming", SceneID: "scene-1", Title: "Upcoming Show", StartsAt: startsAt},
+		{ID: "event-cancelled", SceneID: "scene-1", Title: "Cancelled Show", StartsAt: startsAt, Status: "cancelled"},
+		{ID: "event-deleted", SceneID: "scene-1", Title: "Deleted Show", StartsAt: startsAt},
+		{ID: "event-orphaned", SceneID: "scene-2", Title: "Orphaned Show", StartsAt: startsAt},
+	} {
+		env.addEvent(t, e)
+	}
+	if err := env.events.Delete(t.Context(), "event-deleted"); err != nil {
+		t.Fatalf("Failed to delete event: %v", err)
+	}
+	if err := env.scenes.Delete(t.Context(), "scene-2"); err != nil {
+		t.Fatalf("Failed to delete scene: %v", err)
+	}
+
+	env.addRSVP(t, "event-upcoming", "did:plc:user1", "going")
+	env.addRSVP(t, "event-cancelled", "did:plc:user1", "maybe")
+	env.addRSVP(t, "event-deleted", "did:plc:user1", "going")
+	env.addRSVP(t, "event-orphaned", "did:plc:user1", "going")
+	env.addRSVP(t, "event-upcoming", "did:plc:user2", "maybe")
+}
+
+// doListMyRSVPs calls ListMyRSVPs as the given user; an empty DID is unauthenticated.
+func doListMyRSVPs(handlers *RSVPHandlers, query, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/rsvps/mine"+query, nil)
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.ListMyRSVPs(w, req)
+	return w
+}
+
+func TestListMyRSVPs_Filtering(t *testing.T) {
+	env := newTestEnv()
+	seedMyRSVPs(t, env)
+	handlers := env.rsvpHandlers()
+
+	tests := []struct {
+		name       string
+		query      string
+		wantEvents []string
+	}{
+		{"active only by default", "", []string{"event-upcoming"}},
+		{"include inactive", "?include_inactive=true", []string{"event-upcoming", "event-cancelled", "event-deleted", "event-orphaned"}},
+		{"going only", "?status=going&include_inactive=true", []string{"event-upcoming", "event-deleted", "event-orphaned"}},
+		{"maybe only", "?status=maybe", []string{}},
+		{"maybe including cancelled", "?status=maybe&include_inactive=1", []string{"event-cancelled"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doListMyRSVPs(handlers, tt.query, "did:plc:user1")
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var resp ListMyRSVPsResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.RSVPs == nil {
+				t.Fatal("Expected rsvps array, got null")
+			}
+			got := make([]string, len(resp.RSVPs))
+			for i, rsvp := range resp.RSVPs {
+				got[i] = rsvp.EventID
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantEvents, ",") {
+				t.Errorf("Expected events %v, got %v", tt.wantEvents, got)
+			}
+		})
+	}
+}
+
+func TestListMyRSVPs_EventInfo(t *testing.T) {
+	env := newTestEnv()
+	seedMyRSVPs(t, env)
+	handlers := env.rsvpHandlers()
+
+	w := doListMyRSVPs(handlers, "?include_inactive=true", "did:plc:user1")
+	var resp ListMyRSVPsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	byEvent := make(map[string]*MyRSVPResponse)
+	for _, rsvp := range resp.RSVPs {
+		byEvent[rsvp.EventID] = rsvp
+	}
+
+	upcoming := byEvent["event-upcoming"]
+	if upcoming == nil || upcoming.Event == nil {
+		t.Fatalf("Expected event info for upcoming RSVP, got %+v", upcoming)
+	}
+	if upcoming.Event.Title != "Upcoming Show" || upcoming.Event.SceneID != "scene-1" || upcoming.Event.SceneName != "Basement Shows" {
+		t.Errorf("Unexpected event info: %+v", upcoming.Event)
+	}
+	if upcoming.Event.StartsAt.IsZero() {
+		t.Error("Expected starts_at to be set")
+	}
+
+	if cancelled := byEvent["event-cancelled"]; cancelled == nil || cancelled.Event == nil || cancelled.Event.Status != "cancelled" {
+		t.Errorf("Expected cancelled event status, got %+v", cancelled)
+	}
+	for _, id := range []string{"event-deleted", "event-orphaned"} {
+		if rsvp := byEvent[id]; rsvp == nil || rsvp.Event != nil {
+			t.Errorf("%s: expected RSVP without event info, got %+v", id, rsvp)
+		}
+	}
+}
+
+func TestListMyRSVPs_OtherUsersNeverLeak(t *testing.T) {
+	env := newTestEnv()
+	seedMyRSVPs(t, env)
+	handlers := env.rsvpHandlers()
+
+	w := doListMyRSVPs(handlers, "?include_inactive=true", "did:plc:user2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "did:plc:user1") {
+		t.Error("Response must not contain other users' DIDs")
+	}
+
+	var resp ListMyRSVPsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.RSVPs) != 1 || resp.RSVPs[0].EventID != "event-upcoming" || resp.RSVPs[0].Status != "maybe" {
+		t.Errorf("Expected only user2's maybe RSVP, got %+v", resp.RSVPs)
+	}
+
+	// A user with no RSVPs gets an empty list
+	w = doListMyRSVPs(handlers, "", "did:plc:stranger")
+	if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != `{"rsvps":[]}` {
+		t.Errorf("Expected empty list, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestListMyRSVPs_Validation(t *testing.T) {
+	env := newTestEnv()
+	seedMyRSVPs(t, env)
+	handlers := env.rsvpHandlers()
+
+	tests := []struct {
+		name       string
+		query      string
+		userDID    string
+		wantStatus int
+		wantCode   string
+	}{
+		{"unauthenticated", "", "", http.StatusUnauthorized, ErrCodeAuthFailed},
+		{"invalid status", "?status=not_going", "did:plc:user1", http.StatusBadRequest, ErrCodeValidation},
+		{"invalid include_inactive", "?include_inactive=maybe", "did:plc:user1", http.StatusBadRequest, ErrCodeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doListMyRSVPs(handlers, tt.query, tt.userDID)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("Failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != tt.wantCode {
+				t.Errorf("Expected error code %s, got %s", tt.wantCode, errResp.Error.Code)
+			}
+		})
+	}
+}
+
+// doCheckIn performs a CheckIn request for attendeeDID as the given user.
+func doCheckIn(handlers *RSVPHandlers, userDID, attendeeDID string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(CheckInRequest{UserDID: attendeeDID})
+	req := httptest.NewRequest("POST", "/events/event-1/checkin", bytes.NewReader(body))
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.CheckIn(w, req)
+	return w
+}
+
+func TestCheckIn_Success(t *testing.T) {
+	endsAt := time.Now().Add(time.Hour)
+	env := newTestEnv()
+	seedAttendees(t, env, scene.VisibilityPublic, time.Now().Add(-time.Hour), &endsAt)
+	handlers := env.rsvpHandlers()
+
+	w := doCheckIn(handlers, "did:plc:owner", "did:plc:alice")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp CheckInResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.UserDID != "did:plc:alice" || resp.CheckedInAt == nil || resp.CheckedInCount != 1 {
+		t.Fatalf("Unexpected check-in response: %+v", resp)
+	}
+
+	// Checking in again keeps the original time and count
+	w = doCheckIn(handlers, "did:plc:owner", "did:plc:alice")
+	var again CheckInResponse
+	if err := json.NewDecoder(w.Body).Decode(&again); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if again.CheckedInCount != 1 || !again.CheckedInAt.Equal(*resp.CheckedInAt) {
+		t.Errorf("Expected repeated check-in to be a no-op, got %+v", again)
+	}
+
+	// The attendee list shows who has checked in
+	var list ListRSVPsResponse
+	if err := json.NewDecoder(doListRSVPs(handlers, "", "did:plc:owner").Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode attendee list: %v", err)
+	}
+	for _, a := range list.Attendees {
+		if (a.CheckedInAt != nil) != (a.UserDID == "did:plc:alice") {
+			t.Errorf("Unexpected checked_in_at for %s: %v", a.UserDID, a.CheckedInAt)
+		}
+	}
+}
+
+func TestCheckIn_Window(t *testing.T) {
+	now := time.Now()
+	endedRecently := now.Add(-CheckInGracePeriod / 2)
+	endedLongAgo := now.Add(-CheckInGracePeriod - time.Minute)
+
+	tests := []struct {
+		name     string
+		startsAt time.Time
+		endsAt   *time.Time
+		wantCode int
+	}{
+		{"before start", now.Add(time.Hour), nil, http.StatusBadRequest},
+		{"in progress without end time", now.Add(-time.Hour), nil, http.StatusOK},
+		{"within grace period", now.Add(-3 * time.Hour), &endedRecently, http.StatusOK},
+		{"after grace period", now.Add(-3 * time.Hour), &endedLongAgo, http.StatusBadRequest},
+		{"default duration elapsed", now.Add(-DefaultICSEventDuration - CheckInGracePeriod - time.Minute), nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv()
+			seedAttendees(t, env, scene.VisibilityPublic, tt.startsAt, tt.endsAt)
+			handlers := env.rsvpHandlers()
+
+			w := doCheckIn(handlers, "did:plc:owner", "did:plc:alice")
+			if w.Code != tt.wantCode {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if tt.wantCode == http.StatusBadRequest {
+				var errResp ErrorResponse
+				if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+					t.Fatalf("Failed to decode error response: %v", err)
+				}
+				if errResp.Error.Code != ErrCodeValidation {
+					t.Errorf("Expected error code %s, got %s", ErrCodeValidation, errResp.Error.Code)
+				}
+				if count, _ := handlers.rsvpRepo.GetCheckinCount(t.Context(), "event-1"); count != 0 {
+					t.Errorf("Expected no check-ins outside the window, got %d", count)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckIn_OnlyGoingAttendees(t *testing.T) {
+	env := newTestEnv()
+	seedAttendees(t, env, scene.VisibilityPublic, time.Now().Add(-time.Hour), nil)
+	handlers := env.rsvpHandlers()
+
+	w := doCheckIn(handlers, "did:plc:owner", "did:plc:bob")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400 for a maybe RSVP, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doCheckIn(handlers, "did:plc:owner", "did:plc:nobody"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 without an RSVP, got %d", w.Code)
+	}
+	if count, _ := handlers.rsvpRepo.GetCheckinCount(t.Context(), "event-1"); count != 0 {
+		t.Errorf("Expected no check-ins, got %d", count)
+	}
+}
+
+func TestCheckIn_ModeratorsOnly(t *testing.T) {
+	env := newTestEnv()
+	seedAttendees(t, env, scene.VisibilityPublic, time.Now().Add(-time.Hour), nil)
+	handlers := env.rsvpHandlers()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	handlers.SetMembershipRepository(membershipRepo)
+	for _, m := range []*membership.Membership{
+		{SceneID: "scene-1", UserDID: "did:plc:admin", Role: "admin", Status: "active"},
+		{SceneID: "scene-1", UserDID: "did:plc:member", Role: "member", Status: "active"},
+	} {
+		if _, err := membershipRepo.Upsert(t.Context(), m); err != nil {
+			t.Fatalf("Failed to create membership: %v", err)
+		}
+	}
+
+	if w := doCheckIn(handlers, "", "did:plc:alice"); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 without auth, got %d", w.Code)
+	}
+	for _, did := range []string{"did:plc:member", "did:plc:alice"} {
+		if w := doCheckIn(handlers, did, "did:plc:alice"); w.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403 for %s, got %d", did, w.Code)
+		}
+	}
+	if w := doCheckIn(handlers, "did:plc:admin", "did:plc:alice"); w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 for an admin, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCheckIn_CancelledEvent(t *testing.T) {
+	env := newTestEnv()
+	seedAttendees(t, env, scene.VisibilityPublic, time.Now().Add(-time.Hour), nil)
+	handlers := env.rsvpHandlers()
+	if err := handlers.eventRepo.Cancel(t.Context(), "event-1", nil); err != nil {
+		t.Fatalf("Failed to cancel event: %v", err)
+	}
+
+	if w := doCheckIn(handlers, "did:plc:owner", "did:plc:alice"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a cancelled event, got %d", w.Code)
+	}
+}
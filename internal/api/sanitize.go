@@ -0,0 +1,63 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxTextFieldLength is the maximum length in characters (runes) of free-form
+// user-supplied text such as scene and event descriptions.
+const MaxTextFieldLength = 5000
+
+// Errors returned by sanitizeText.
+var (
+	errTextNullByte = errors.New("must not contain null bytes")
+	errTextScript   = errors.New("must not contain script content")
+	errTextTooLong  = fmt.Errorf("must not exceed %d characters", MaxTextFieldLength)
+)
+
+// scriptPattern matches markup that is rejected outright rather than escaped:
+// script-capable elements, javascript: URLs, and inline event handler
+// attributes inside a tag.
+var scriptPattern = regexp.MustCompile(`(?i)<\s*/?\s*(script|iframe|object|embed|style)\b|javascript\s*:|<[^>]*\bon[a-z]+\s*=`)
+
+// sanitizeText cleans free-form user-supplied text before it is stored.
+// The text is NFC-normalized, control characters other than newlines, carriage
+// returns and tabs are stripped, and any remaining HTML is escaped.
+//
+// Returns an error suitable for an ErrCodeValidation message if the text
+// contains null bytes or script content, or exceeds MaxTextFieldLength.
+// Empty text is allowed.
+func sanitizeText(s string) (string, error) {
+	if strings.ContainsRune(s, 0) {
+		return "", errTextNullByte
+	}
+
+	s = norm.NFC.String(s)
+	if scriptPattern.MatchString(s) {
+		return "", errTextScript
+	}
+
+	s = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\r' || r == '\t' {
+			return r
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+
+	// Length is checked before escaping so entities don't count against the limit
+	if utf8.RuneCountInString(s) > MaxTextFieldLength {
+		return "", errTextTooLong
+	}
+	return html.EscapeString(s), nil
+}
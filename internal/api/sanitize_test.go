@@ -0,0 +1,141 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "benign multi-line text unchanged",
+			input: "Monthly warehouse night\n\nBring earplugs\r\n\tDoors at 9",
+			want:  "Monthly warehouse night\n\nBring earplugs\r\n\tDoors at 9",
+		},
+		{name: "empty allowed", input: "", want: ""},
+		{name: "plain html escaped", input: "<b>loud</b> & proud", want: "&lt;b&gt;loud&lt;/b&gt; &amp; proud"},
+		{name: "control characters stripped", input: "bass\x1b[31m\x7f drop", want: "bass[31m drop"},
+		{name: "unicode normalized to NFC", input: "cafe\u0301", want: "caf\u00e9"},
+		{name: "script tag", input: "hi <script>alert(1)</script>", wantErr: errTextScript},
+		{name: "script tag mixed case", input: "< ScRiPt src=//evil>", wantErr: errTextScript},
+		{name: "javascript url", input: `<a href="JavaScript:alert(1)">x</a>`, wantErr: errTextScript},
+		{name: "event handler attribute", input: `<img src=x onerror=alert(1)>`, wantErr: errTextScript},
+		{name: "null byte", input: "before\x00after", wantErr: errTextNullByte},
+		{name: "at limit", input: strings.Repeat("é", MaxTextFieldLength), want: strings.Repeat("é", MaxTextFieldLength)},
+		{name: "over limit", input: strings.Repeat("a", MaxTextFieldLength+1), wantErr: errTextTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sanitizeText(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCreateScene_DescriptionSanitization(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		wantStatus  int
+		wantDesc    string
+	}{
+		{name: "script payload", description: "<script>alert('xss')</script>", wantStatus: http.StatusBadRequest},
+		{name: "null byte", description: "late\x00night", wantStatus: http.StatusBadRequest},
+		{name: "benign multi-line", description: "Line one\nLine two", wantStatus: http.StatusCreated, wantDesc: "Line one\nLine two"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := NewSceneHandlers(scene.NewInMemorySceneRepository(), membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+
+			body, _ := json.Marshal(CreateSceneRequest{
+				Name:          "Sanitized Scene",
+				Description:   tt.description,
+				OwnerDID:      "did:plc:test123",
+				CoarseGeohash: "dr5regw",
+			})
+			req := httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+			handlers.CreateScene(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusBadRequest {
+				var errResp ErrorResponse
+				if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+					t.Fatalf("failed to decode error response: %v", err)
+				}
+				if errResp.Error.Code != ErrCodeValidation {
+					t.Errorf("expected error code %s, got %s", ErrCodeValidation, errResp.Error.Code)
+				}
+				return
+			}
+
+			var created scene.Scene
+			if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if created.Description != tt.wantDesc {
+				t.Errorf("expected description %q, got %q", tt.wantDesc, created.Description)
+			}
+		})
+	}
+}
+
+func TestCreateEvent_DescriptionSanitization(t *testing.T) {
+	sceneRepo := scene.NewInMemorySceneRepository()
+	handlers := NewEventHandlers(scene.NewInMemoryEventRepository(), sceneRepo, audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository())
+
+	testScene := &scene.Scene{
+		ID:            uuid.New().String(),
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+	}
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	for _, description := range []string{"<script>alert(1)</script>", "a\x00b"} {
+		body, _ := json.Marshal(CreateEventRequest{
+			SceneID:       testScene.ID,
+			Title:         "Test Event",
+			Description:   description,
+			CoarseGeohash: "dr5regw",
+			StartsAt:      time.Now().Add(24 * time.Hour),
+		})
+		req := httptest.NewRequest(http.MethodPost, "/events", bytes.NewReader(body))
+		req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+		w := httptest.NewRecorder()
+		handlers.CreateEvent(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("description %q: expected status 400, got %d", description, w.Code)
+		}
+	}
+}
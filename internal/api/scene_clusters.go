@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sort"
+
+	"github.com/onnwee/subcults/internal/geo"
+)
+
+// Cluster parameters for GET /scenes/clusters.
+const (
+	// DefaultClusterPrecision is the geohash length clusters are keyed by
+	// when no precision is given (~±20 km cells).
+	DefaultClusterPrecision = 4
+
+	// MaxClusterPrecision caps cluster cells at public display precision.
+	MaxClusterPrecision = geo.PublicPrecision
+)
+
+// SceneCluster is a count of scenes sharing a truncated geohash cell.
+// Centroid is the mean of the scenes' public-precision cell centers.
+type SceneCluster struct {
+	Geohash  string    `json:"geohash"`
+	Count    int       `json:"count"`
+	Centroid geo.Point `json:"centroid"`
+}
+
+// SceneClustersResponse represents the response for the scene clusters endpoint.
+type SceneClustersResponse struct {
+	Precision int            `json:"precision"`
+	Clusters  []SceneCluster `json:"clusters"`
+}
+
+// GetSceneClusters handles GET /scenes/clusters - groups public scenes in a
+// bounding box by geohash prefix for low-zoom map rendering. Requires bbox
+// (minLng,minLat,maxLng,maxLat); precision (1-5, default 4) sets the cluster
+// cell size. Clusters with fewer scenes than the location anonymity threshold
+// (SetLocationAnonymityK) are omitted, and no scene IDs or locations finer
+// than public precision are returned.
+func (h *SceneHandlers) GetSceneClusters(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	bbox, err := parseBbox(query.Get("bbox"))
+	if err != nil {
+		writeError(w, r, ErrCodeValidation, err.Error())
+		return
+	}
+
+	precision := DefaultClusterPrecision
+	if precisionStr := query.Get("precision"); precisionStr != "" {
+		precision, err = parseIntInRange(precisionStr, "precision", 1, MaxClusterPrecision)
+		if err != nil {
+			writeError(w, r, ErrCodeValidation, err.Error())
+			return
+		}
+	}
+
+	scenes, err := h.repo.FindInBbox(r.Context(), bbox.MinLng, bbox.MinLat, bbox.MaxLng, bbox.MaxLat)
+	if err != nil {
+		if writeContextError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to find scenes in bbox", "error", err)
+		writeError(w, r, ErrCodeInternal, "Failed to cluster scenes")
+		return
+	}
+
+	type accumulator struct {
+		count    int
+		lat, lng float64
+	}
+	cells := make(map[string]*accumulator)
+	for _, sc := range scenes {
+		// Centroids are built from public-precision cells, never finer data
+		center, ok := geo.Decode(geo.RoundGeohash(sc.CoarseGeohash, geo.PublicPrecision))
+		if !ok {
+			continue
+		}
+		key := geo.RoundGeohash(sc.CoarseGeohash, precision)
+		if len(key) < precision {
+			continue
+		}
+
+		acc, ok := cells[key]
+		if !ok {
+			acc = &accumulator{}
+			cells[key] = acc
+		}
+		acc.count++
+		acc.lat += center.Lat
+		acc.lng += center.Lng
+	}
+
+	clusters := make([]SceneCluster, 0, len(cells))
+	for key, acc := range cells {
+		if acc.count < h.locationAnonymityK {
+			continue
+		}
+		clusters = append(clusters, SceneCluster{
+			Geohash: key,
+			Count:   acc.count,
+			Centroid: geo.Point{
+				Lat: acc.lat / float64(acc.count),
+				Lng: acc.lng / float64(acc.count),
+			},
+		})
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Geohash < clusters[j].Geohash
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(SceneClustersResponse{Precision: precision, Clusters: clusters}); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/geo"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// seedSceneClusters seeds four public scenes in lower Manhattan, two
+// in Philadelphia, and non-public scenes in Manhattan that must never count.
+func seedSceneClusters(t *testing.T, env *testEnv) {
+	t.Helper()
+
+	fixtures := []struct {
+		point      geo.Point
+		visibility string
+	}{
+		{geo.Point{Lat: 40.7128, Lng: -74.0060}, scene.VisibilityPublic},
+		{geo.Point{Lat: 40.7233, Lng: -74.0030}, scene.VisibilityPublic},
+		{geo.Point{Lat: 40.7033, Lng: -74.0170}, scene.VisibilityPublic},
+		{geo.Point{Lat: 40.7306, Lng: -73.9866}, scene.VisibilityPublic},
+		{geo.Point{Lat: 40.7150, Lng: -74.0100}, scene.VisibilityHidden},
+		{geo.Point{Lat: 40.7160, Lng: -74.0050}, scene.VisibilityMembersOnly},
+		{geo.Point{Lat: 39.9526, Lng: -75.1652}, scene.VisibilityPublic},
+		{geo.Point{Lat: 39.9500, Lng: -75.1600}, scene.VisibilityPublic},
+	}
+	for i, f := range fixtures {
+		env.addScene(t, &scene.Scene{
+			ID:            fmt.Sprintf("scene-%d", i),
+			Name:          fmt.Sprintf("Scene %d", i),
+			AllowPrecise:  true,
+			PrecisePoint:  &scene.Point{Lat: f.point.Lat, Lng: f.point.Lng},
+			CoarseGeohash: geo.Encode(f.point, 7),
+			Visibility:    f.visibility,
+		})
+	}
+}
+
+func getSceneClusters(handlers *SceneHandlers, query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/scenes/clusters?"+query, nil)
+	w := httptest.NewRecorder()
+	handlers.GetSceneClusters(w, req)
+	return w
+}
+
+func TestGetSceneClusters_Counts(t *testing.T) {
+	env := newTestEnv()
+	seedSceneClusters(t, env)
+	handlers := env.sceneHandlers()
+
+	w := getSceneClusters(handlers, "bbox=-76,39,-73,41.5&precision=3")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp SceneClustersResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Precision != 3 {
+		t.Errorf("expected precision 3, got %d", resp.Precision)
+	}
+
+	// Philadelphia's two scenes fall below the threshold and are suppressed
+	if len(resp.Clusters) != 1 {
+		t.Fatalf("expected 1 cluster, got %+v", resp.Clusters)
+	}
+	cluster := resp.Clusters[0]
+	if cluster.Geohash != "dr5" || cluster.Count != 4 {
+		t.Errorf("expected 4 public scenes in dr5, got %d in %s", cluster.Count, cluster.Geohash)
+	}
+	if !geo.PointInGeohash(cluster.Centroid, "dr5r") {
+		t.Errorf("expected centroid in lower Manhattan, got %+v", cluster.Centroid)
+	}
+}
+
+func TestGetSceneClusters_SuppressesSparseClusters(t *testing.T) {
+	env := newTestEnv()
+	seedSceneClusters(t, env)
+	handlers := env.sceneHandlers()
+
+	tests := []struct {
+		name      string
+		query     string
+		wantCount int
+	}{
+		{"at the threshold", "bbox=-74.02,40.70,-73.98,40.735&precision=3", 4},
+		{"below the threshold", "bbox=-74.02,40.70,-74.005,40.73&precision=3", 0},
+		{"finer cells split the cluster", "bbox=-76,39,-73,41.5&precision=5", 0},
+		{"sparse area only", "bbox=-75.5,39.5,-75,40.2", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := getSceneClusters(handlers, tt.query)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			var resp SceneClustersResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			total := 0
+			for _, c := range resp.Clusters {
+				if c.Count < DefaultLocationAnonymityK {
+					t.Errorf("expected clusters below %d to be suppressed, got %+v", DefaultLocationAnonymityK, c)
+				}
+				total += c.Count
+			}
+			if total != tt.wantCount {
+				t.Errorf("expected %d clustered scenes, got %d in %+v", tt.wantCount, total, resp.Clusters)
+			}
+		})
+	}
+}
+
+func TestGetSceneClusters_InvalidParameters(t *testing.T) {
+	env := newTestEnv()
+	seedSceneClusters(t, env)
+	handlers := env.sceneHandlers()
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing bbox", "precision=3"},
+		{"malformed bbox", "bbox=-76,39,-73"},
+		{"inverted bbox", "bbox=-73,39,-76,41.5"},
+		{"precision too fine", "bbox=-76,39,-73,41.5&precision=6"},
+		{"precision zero", "bbox=-76,39,-73,41.5&precision=0"},
+		{"non-numeric precision", "bbox=-76,39,-73,41.5&precision=high"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := getSceneClusters(handlers, tt.query)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != ErrCodeValidation {
+				t.Errorf("expected error code %s, got %s", ErrCodeValidation, errResp.Error.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+const exportSceneID = "2c9d4e7f-1a3b-4c5d-8e9f-0a1b2c3d4e5f"
+
+// seedSceneExport seeds hidden scene exportSceneID owned by did:plc:owner
+// with a precise point, a palette, two events (one deleted), an admin, and a
+// banned member.
+func seedSceneExport(t *testing.T, env *testEnv) {
+	t.Helper()
+	// Exports read the scene and its events through the cascade
+	env.scenes.SetCascade(env.events, nil)
+	env.addScene(t, &scene.Scene{
+		ID:           exportSceneID,
+		Name:         "Export Scene",
+		Description:  "Bass &amp; dub", // Stored escaped, as CreateScene leaves it
+		AllowPrecise: true,
+		PrecisePoint: &scene.Point{Lat: 40.7128, Lng: -74.0060},
+		Visibility:   scene.VisibilityHidden,
+		Palette:      &scene.Palette{Primary: "#112233", Secondary: "#445566", Accent: "#778899", Background: "#ffffff", Text: "#000000"},
+	})
+
+	for _, id := range []string{"kept", "deleted"} {
+		env.addEvent(t, &scene.Event{
+			ID:           id,
+			SceneID:      exportSceneID,
+			AllowPrecise: true,
+			PrecisePoint: &scene.Point{Lat: 40.7, Lng: -74.0},
+			StartsAt:     time.Now().Add(24 * time.Hour),
+		})
+	}
+	if err := env.events.Delete(t.Context(), "deleted"); err != nil {
+		t.Fatalf("failed to delete event: %v", err)
+	}
+
+	env.addMember(t, exportSceneID, "did:plc:admin", "admin", "active")
+	env.addMember(t, exportSceneID, "did:plc:banned", "member", "banned")
+}
+
+func exportScene(handlers *SceneHandlers, sceneID, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/scenes/"+sceneID+"/export", nil)
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.ExportScene(w, req)
+	return w
+}
+
+func TestExportScene_Bundle(t *testing.T) {
+	env := newTestEnv()
+	seedSceneExport(t, env)
+	handlers := env.sceneHandlers()
+	handlers.SetEventRepository(env.events)
+	handlers.SetAuditRepository(env.audit)
+
+	w := exportScene(handlers, exportSceneID, "did:plc:owner")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Disposition"); got != `attachment; filename="scene-`+exportSceneID+`.json"` {
+		t.Errorf("unexpected Content-Disposition %q", got)
+	}
+
+	var export SceneExport
+	if err := json.NewDecoder(w.Body).Decode(&export); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if export.Version != SceneExportVersion {
+		t.Errorf("expected version %d, got %d", SceneExportVersion, export.Version)
+	}
+	if export.ExportedAt.IsZero() {
+		t.Error("expected exported_at to be set")
+	}
+
+	if export.Scene == nil || export.Scene.ID != exportSceneID {
+		t.Fatalf("expected scene %s, got %+v", exportSceneID, export.Scene)
+	}
+	if export.Scene.PrecisePoint == nil {
+		t.Error("expected the owner's export to include the scene's precise point")
+	}
+	if export.Scene.Palette == nil || export.Scene.Palette.Primary != "#112233" {
+		t.Errorf("expected the scene palette, got %+v", export.Scene.Palette)
+	}
+
+	if len(export.Events) != 1 || export.Events[0].ID != "kept" {
+		t.Fatalf("expected only the non-deleted event, got %+v", export.Events)
+	}
+	if export.Events[0].PrecisePoint == nil {
+		t.Error("expected the event's precise point")
+	}
+
+	if len(export.Members) != 2 {
+		t.Fatalf("expected 2 members, got %d", len(export.Members))
+	}
+	if export.Members[0].UserDID != "did:plc:admin" || export.Members[1].UserDID != "did:plc:banned" {
+		t.Errorf("expected admin and banned members in DID order, got %s, %s", export.Members[0].UserDID, export.Members[1].UserDID)
+	}
+
+	logs, err := env.audit.QueryByEntity(t.Context(), "scene", exportSceneID, 0)
+	if err != nil {
+		t.Fatalf("failed to query audit logs: %v", err)
+	}
+	if len(logs) != 1 || logs[0].Action != "scene_export" {
+		t.Errorf("expected one export audit entry, got %+v", logs)
+	}
+}
+
+func TestExportScene_Refused(t *testing.T) {
+	env := newTestEnv()
+	seedSceneExport(t, env)
+	handlers := env.sceneHandlers()
+	handlers.SetEventRepository(env.events)
+	handlers.SetAuditRepository(env.audit)
+
+	tests := []struct {
+		name       string
+		sceneID    string
+		userDID    string
+		wantStatus int
+		wantCode   string
+	}{
+		{"unauthenticated", exportSceneID, "", http.StatusUnauthorized, ErrCodeAuthFailed},
+		{"admin member", exportSceneID, "did:plc:admin", http.StatusForbidden, ErrCodeForbidden},
+		{"stranger", exportSceneID, "did:plc:stranger", http.StatusForbidden, ErrCodeForbidden},
+		{"unknown scene", "3d0e5f8a-2b4c-4d6e-9f0a-1b2c3d4e5f6a", "did:plc:owner", http.StatusNotFound, ErrCodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := exportScene(handlers, tt.sceneID, tt.userDID)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != tt.wantCode {
+				t.Errorf("expected error code %s, got %s", tt.wantCode, errResp.Error.Code)
+			}
+		})
+	}
+
+	if logs, _ := env.audit.QueryByEntity(t.Context(), "scene", exportSceneID, 0); len(logs) != 0 {
+		t.Errorf("expected refused exports not to be audited, got %d entries", len(logs))
+	}
+}
+
+func TestExportScene_DeletedScene(t *testing.T) {
+	env := newTestEnv()
+	seedSceneExport(t, env)
+	handlers := env.sceneHandlers()
+	handlers.SetEventRepository(env.events)
+	handlers.SetAuditRepository(env.audit)
+	if err := handlers.repo.Delete(t.Context(), exportSceneID); err != nil {
+		t.Fatalf("failed to delete scene: %v", err)
+	}
+
+	w := exportScene(handlers, exportSceneID, "did:plc:owner")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d: %s", w.Code, w.Body.String())
+	}
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeSceneDeleted {
+		t.Errorf("expected error code %s, got %s", ErrCodeSceneDeleted, errResp.Error.Code)
+	}
+}
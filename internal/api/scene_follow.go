@@ -0,0 +1,227 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// FollowResponse represents the result of following a scene.
+type FollowResponse struct {
+	SceneID        string `json:"scene_id"`
+	Following      bool   `json:"following"`
+	FollowersCount int    `json:"followers_count"`
+}
+
+// FollowScene handles POST /scenes/{id}/follow - follows a scene for activity
+// updates without joining it. Following is idempotent and grants no access
+// beyond what any public viewer has, so only scenes the requester can already
+// view may be followed. Owners follow their own scenes implicitly; nothing is
+// stored for them.
+func (h *SceneHandlers) FollowScene(w http.ResponseWriter, r *http.Request) {
+	if h.followRepo == nil {
+		writeError(w, r, ErrCodeNotFound, "The requested resource was not found")
+		return
+	}
+
+	sceneID, ok := parseEntityID(w, r, "/scenes/", "Scene")
+	if !ok {
+		return
+	}
+
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	foundScene, err := h.repo.GetByID(r.Context(), sceneID)
+	if err != nil {
+		if err == scene.ErrSceneDeleted {
+			writeError(w, r, ErrCodeSceneDeleted, "Scene not found")
+			return
+		}
+		if err == scene.ErrSceneNotFound {
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+		return
+	}
+
+	viewerLevel, err := h.sceneViewerLevel(r.Context(), foundScene, userDID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to check access permissions")
+		return
+	}
+	if !canViewScene(r.Context(), foundScene, viewerLevel) {
+		// Same response as not found to prevent enumeration
+		writeError(w, r, ErrCodeNotFound, "Scene not found")
+		return
+	}
+
+	if !foundScene.IsOwner(userDID) {
+		if _, err := h.followRepo.Follow(sceneID, userDID); err != nil {
+			slog.ErrorContext(r.Context(), "failed to follow scene", "error", err, "scene_id", sceneID)
+			writeError(w, r, ErrCodeInternal, "Failed to follow scene")
+			return
+		}
+	}
+
+	counts, err := h.followRepo.CountByScenes([]string{sceneID})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to count followers", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve follower count")
+		return
+	}
+
+	response := FollowResponse{
+		SceneID:        sceneID,
+		Following:      true,
+		FollowersCount: counts[sceneID],
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
+
+// UnfollowScene handles DELETE /scenes/{id}/follow - stops following a scene.
+// Idempotent, and allowed even if the scene has since been deleted or hidden
+// from the requester, so no scene lookup is made.
+func (h *SceneHandlers) UnfollowScene(w http.ResponseWriter, r *http.Request) {
+	if h.followRepo == nil {
+		writeError(w, r, ErrCodeNotFound, "The requested resource was not found")
+		return
+	}
+
+	sceneID, ok := parseEntityID(w, r, "/scenes/", "Scene")
+	if !ok {
+		return
+	}
+
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	if err := h.followRepo.Unfollow(sceneID, userDID); err != nil {
+		slog.ErrorContext(r.Context(), "failed to unfollow scene", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to unfollow scene")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetFollowingFeed handles GET /scenes/following/activity - the combined
+// activity feed of the scenes the requester follows or owns, newest first.
+// Each scene's items are filtered as in GetActivityFeed: scenes the requester
+// can no longer view are skipped, and followers who aren't members see only
+// non-sensitive items without actor DIDs.
+//
+// Query parameters: limit (default 20, max 100) and cursor (next_cursor from
+// the previous page).
+func (h *SceneHandlers) GetFollowingFeed(w http.ResponseWriter, r *http.Request) {
+	if h.followRepo == nil {
+		writeError(w, r, ErrCodeNotFound, "The requested resource was not found")
+		return
+	}
+
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	limit, cursorTime, cursorID, ok := parseActivityFeedParams(w, r)
+	if !ok {
+		return
+	}
+
+	scenes, err := h.followedScenes(r.Context(), userDID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to list followed scenes", "error", err)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve activity feed")
+		return
+	}
+
+	items := make([]ActivityItem, 0)
+	for _, s := range scenes {
+		viewerLevel, err := h.sceneViewerLevel(r.Context(), s, userDID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", s.ID)
+			writeError(w, r, ErrCodeInternal, "Failed to check access permissions")
+			return
+		}
+		if !canViewScene(r.Context(), s, viewerLevel) {
+			continue
+		}
+
+		sceneItems, err := h.collectActivity(r.Context(), s.ID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to collect scene activity", "error", err, "scene_id", s.ID)
+			writeError(w, r, ErrCodeInternal, "Failed to retrieve activity feed")
+			return
+		}
+		for _, item := range filterActivityForViewer(sceneItems, viewerLevel) {
+			item.SceneID = s.ID
+			items = append(items, item)
+		}
+	}
+
+	items, nextCursor := pageActivity(items, limit, cursorTime, cursorID)
+	response := ActivityFeedResponse{
+		Items:      items,
+		NextCursor: nextCursor,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
+
+// followedScenes returns the non-deleted scenes userDID owns or follows,
+// each at most once.
+func (h *SceneHandlers) followedScenes(ctx context.Context, userDID string) ([]*scene.Scene, error) {
+	owned, err := h.repo.ListByOwner(ctx, userDID)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(owned))
+	for _, s := range owned {
+		seen[s.ID] = true
+	}
+
+	follows, err := h.followRepo.ListByUser(userDID)
+	if err != nil {
+		return nil, err
+	}
+	scenes := owned
+	for _, f := range follows {
+		if seen[f.SceneID] {
+			continue
+		}
+		seen[f.SceneID] = true
+		s, err := h.repo.GetByID(ctx, f.SceneID)
+		if err != nil {
+			if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+				continue
+			}
+			return nil, err
+		}
+		scenes = append(scenes, s)
+	}
+	return scenes, nil
+}
@@ -0,0 +1,259 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// doFollowRequest sends method to /scenes/{feedSceneID}/follow as userDID.
+func doFollowRequest(handlers *SceneHandlers, method, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/scenes/"+feedSceneID+"/follow", nil)
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	if method == http.MethodDelete {
+		handlers.UnfollowScene(w, req)
+	} else {
+		handlers.FollowScene(w, req)
+	}
+	return w
+}
+
+func getFollowingFeed(handlers *SceneHandlers, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/scenes/following/activity", nil)
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.GetFollowingFeed(w, req)
+	return w
+}
+
+func decodeFollowResponse(t *testing.T, w *httptest.ResponseRecorder) FollowResponse {
+	t.Helper()
+	var resp FollowResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode follow response: %v", err)
+	}
+	return resp
+}
+
+func TestFollowScene_Idempotent(t *testing.T) {
+	env := newTestEnv()
+	seedActivityFeed(t, env, scene.VisibilityPublic)
+	handlers := env.sceneHandlers()
+	handlers.SetAuditRepository(env.audit)
+	handlers.SetEventRepository(env.events)
+	handlers.SetFollowRepository(membership.NewInMemoryFollowRepository())
+
+	for i := 0; i < 2; i++ {
+		w := doFollowRequest(handlers, http.MethodPost, "did:plc:fan")
+		if w.Code != http.StatusOK {
+			t.Fatalf("follow %d: expected status 200, got %d: %s", i+1, w.Code, w.Body.String())
+		}
+		resp := decodeFollowResponse(t, w)
+		if !resp.Following || resp.FollowersCount != 1 {
+			t.Errorf("follow %d: expected following with 1 follower, got %+v", i+1, resp)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if w := doFollowRequest(handlers, http.MethodDelete, "did:plc:fan"); w.Code != http.StatusNoContent {
+			t.Fatalf("unfollow %d: expected status 204, got %d: %s", i+1, w.Code, w.Body.String())
+		}
+	}
+	following, err := handlers.followRepo.IsFollowing(feedSceneID, "did:plc:fan")
+	if err != nil {
+		t.Fatalf("IsFollowing failed: %v", err)
+	}
+	if following {
+		t.Error("expected follow to be removed")
+	}
+}
+
+func TestFollowScene_OwnerFollowsImplicitly(t *testing.T) {
+	env := newTestEnv()
+	seedActivityFeed(t, env, scene.VisibilityPublic)
+	handlers := env.sceneHandlers()
+	handlers.SetAuditRepository(env.audit)
+	handlers.SetEventRepository(env.events)
+	handlers.SetFollowRepository(membership.NewInMemoryFollowRepository())
+
+	w := doFollowRequest(handlers, http.MethodPost, "did:plc:owner")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	resp := decodeFollowResponse(t, w)
+	if !resp.Following || resp.FollowersCount != 0 {
+		t.Errorf("expected implicit follow without a stored follower, got %+v", resp)
+	}
+
+	// The owner's feed includes their scene without following it
+	feed := decodeActivityFeed(t, getFollowingFeed(handlers, "did:plc:owner"))
+	if len(feed.Items) == 0 {
+		t.Fatal("expected owned scene activity in the following feed")
+	}
+	var sawTransfer bool
+	for _, item := range feed.Items {
+		if item.Type == ActivityOwnershipTransferred {
+			sawTransfer = true
+		}
+	}
+	if !sawTransfer {
+		t.Error("expected owner to see sensitive items")
+	}
+}
+
+func TestFollowScene_GrantsNoMembersOnlyAccess(t *testing.T) {
+	env := newTestEnv()
+	seedActivityFeed(t, env, scene.VisibilityPublic)
+	handlers := env.sceneHandlers()
+	handlers.SetAuditRepository(env.audit)
+	handlers.SetEventRepository(env.events)
+	handlers.SetFollowRepository(membership.NewInMemoryFollowRepository())
+
+	if w := doFollowRequest(handlers, http.MethodPost, "did:plc:fan"); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// The scene goes members-only after the follow
+	s, err := handlers.repo.GetByID(t.Context(), feedSceneID)
+	if err != nil {
+		t.Fatalf("failed to get scene: %v", err)
+	}
+	s.Visibility = scene.VisibilityMembersOnly
+	s.Version = 0
+	if err := handlers.repo.Update(t.Context(), s); err != nil {
+		t.Fatalf("failed to update scene: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/scenes/"+feedSceneID, nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:fan"))
+	w := httptest.NewRecorder()
+	handlers.GetScene(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected follower to get 404 for members-only scene, got %d", w.Code)
+	}
+
+	if w := getActivityFeed(handlers, "did:plc:fan", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected follower to get 404 for members-only feed, got %d", w.Code)
+	}
+
+	feed := decodeActivityFeed(t, getFollowingFeed(handlers, "did:plc:fan"))
+	if len(feed.Items) != 0 {
+		t.Errorf("expected members-only scene to drop out of the following feed, got %d items", len(feed.Items))
+	}
+
+	// Non-members can't start following a members-only scene either
+	if w := doFollowRequest(handlers, http.MethodPost, "did:plc:other"); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 following a members-only scene, got %d", w.Code)
+	}
+
+	// Unfollowing still works so the follower can clean up
+	if w := doFollowRequest(handlers, http.MethodDelete, "did:plc:fan"); w.Code != http.StatusNoContent {
+		t.Errorf("expected 204 unfollowing, got %d", w.Code)
+	}
+}
+
+func TestGetFollowingFeed_PublicViewForFollowers(t *testing.T) {
+	env := newTestEnv()
+	seedActivityFeed(t, env, scene.VisibilityPublic)
+	handlers := env.sceneHandlers()
+	handlers.SetAuditRepository(env.audit)
+	handlers.SetEventRepository(env.events)
+	handlers.SetFollowRepository(membership.NewInMemoryFollowRepository())
+
+	// Nothing followed yet
+	if feed := decodeActivityFeed(t, getFollowingFeed(handlers, "did:plc:fan")); len(feed.Items) != 0 {
+		t.Fatalf("expected empty feed before following, got %d items", len(feed.Items))
+	}
+
+	doFollowRequest(handlers, http.MethodPost, "did:plc:fan")
+	w := getFollowingFeed(handlers, "did:plc:fan")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	feed := decodeActivityFeed(t, w)
+
+	wantTypes := map[string]bool{ActivityEventCreated: true, ActivityPaletteChanged: true, ActivitySceneCreated: true}
+	if len(feed.Items) != len(wantTypes) {
+		t.Fatalf("expected %d items, got %d: %+v", len(wantTypes), len(feed.Items), feed.Items)
+	}
+	for _, item := range feed.Items {
+		if !wantTypes[item.Type] {
+			t.Errorf("unexpected item type %s for a follower", item.Type)
+		}
+		if item.ActorDID != "" {
+			t.Errorf("expected actor DID hidden from followers, got %q", item.ActorDID)
+		}
+		if item.SceneID != feedSceneID {
+			t.Errorf("expected scene_id %s, got %q", feedSceneID, item.SceneID)
+		}
+	}
+}
+
+func TestFollowScene_Errors(t *testing.T) {
+	env := newTestEnv()
+	seedActivityFeed(t, env, scene.VisibilityPublic)
+	handlers := env.sceneHandlers()
+	handlers.SetAuditRepository(env.audit)
+	handlers.SetEventRepository(env.events)
+	handlers.SetFollowRepository(membership.NewInMemoryFollowRepository())
+
+	if w := doFollowRequest(handlers, http.MethodPost, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 following unauthenticated, got %d", w.Code)
+	}
+	if w := doFollowRequest(handlers, http.MethodDelete, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 unfollowing unauthenticated, got %d", w.Code)
+	}
+	if w := getFollowingFeed(handlers, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for unauthenticated following feed, got %d", w.Code)
+	}
+
+	// Follows are disabled without a repository
+	env = newTestEnv()
+	seedActivityFeed(t, env, scene.VisibilityPublic)
+	disabled := env.sceneHandlers()
+	disabled.SetAuditRepository(env.audit)
+	disabled.SetEventRepository(env.events)
+	if w := doFollowRequest(disabled, http.MethodPost, "did:plc:fan"); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 when follows are disabled, got %d", w.Code)
+	}
+}
+
+func TestListOwnedScenes_FollowerCountSeparateFromMembers(t *testing.T) {
+	env := newTestEnv()
+	seedActivityFeed(t, env, scene.VisibilityPublic)
+	handlers := env.sceneHandlers()
+	handlers.SetAuditRepository(env.audit)
+	handlers.SetEventRepository(env.events)
+	handlers.SetFollowRepository(membership.NewInMemoryFollowRepository())
+	doFollowRequest(handlers, http.MethodPost, "did:plc:fan")
+	doFollowRequest(handlers, http.MethodPost, "did:plc:fan2")
+
+	req := httptest.NewRequest(http.MethodGet, "/scenes/owned", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner"))
+	w := httptest.NewRecorder()
+	handlers.ListOwnedScenes(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var summaries []OwnedSceneSummary
+	if err := json.NewDecoder(w.Body).Decode(&summaries); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("expected 1 scene, got %d", len(summaries))
+	}
+	if summaries[0].MembersCount != 1 || summaries[0].FollowersCount != 2 {
+		t.Errorf("expected members_count=1 followers_count=2, got %d and %d", summaries[0].MembersCount, summaries[0].FollowersCount)
+	}
+}
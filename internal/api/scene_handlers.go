@@ -4,20 +4,30 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"html"
 	"log/slog"
 	"net/http"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/audit"
 	"github.com/onnwee/subcults/internal/color"
+	"github.com/onnwee/subcults/internal/did"
+	"github.com/onnwee/subcults/internal/geo"
 	"github.com/onnwee/subcults/internal/membership"
 	"github.com/onnwee/subcults/internal/middleware"
 	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/search"
 	"github.com/onnwee/subcults/internal/stream"
+	"github.com/onnwee/subcults/internal/trust"
+	"github.com/onnwee/subcults/internal/webhook"
 )
 
 // Scene name validation constraints
@@ -41,6 +51,11 @@ type CreateSceneRequest struct {
 	Tags          []string         `json:"tags,omitempty"`
 	Visibility    string           `json:"visibility,omitempty"`
 	Palette       *scene.Palette   `json:"palette,omitempty"`
+
+	// RecordDID and RecordRKey optionally link the scene to its AT Protocol
+	// record. Both must be given together.
+	RecordDID  *string `json:"record_did,omitempty"`
+	RecordRKey *string `json:"record_rkey,omitempty"`
 }
 
 // UpdateSceneRequest represents the request body for updating a scene.
@@ -55,6 +70,14 @@ type UpdateSceneRequest struct {
 	PrecisePoint *scene.Point     `json:"precise_point,omitempty"`
 }
 
+// TransferOwnershipRequest represents the request body for transferring scene ownership.
+type TransferOwnershipRequest struct {
+	NewOwnerDID string `json:"new_owner_did"`
+	// PreviousOwnerRole is the role the current owner keeps as a member
+	// after the transfer: "admin" (default) or "member".
+	PreviousOwnerRole string `json:"previous_owner_role,omitempty"`
+}
+
 // UpdateScenePaletteRequest represents the request body for updating scene palette.
 type UpdateScenePaletteRequest struct {
 	Palette scene.Palette `json:"palette"`
@@ -65,6 +88,43 @@ type SceneHandlers struct {
 	repo           scene.SceneRepository
 	membershipRepo membership.MembershipRepository
 	streamRepo     stream.SessionRepository
+
+	// jitterRadiusMeters is the privacy jitter applied to the precise point
+	// before deriving the coarse geohash on create. Zero disables jitter.
+	jitterRadiusMeters float64
+
+	// trustStore supplies scene trust scores for discovery ranking.
+	// When nil, every scene is ranked with a trust score of zero.
+	trustStore trust.ScoreStore
+
+	// discoveryTrustWeight is the share of the blended discovery score
+	// contributed by trust; the remainder comes from proximity.
+	discoveryTrustWeight float64
+
+	// locationAnonymityK is the fewest public scenes that must share a coarse
+	// cell before it is shown to non-owners; sparser cells are coarsened.
+	locationAnonymityK int
+
+	// searchIndex is kept in sync with scene writes. Optional; nil disables indexing.
+	searchIndex *search.Indexer
+
+	// auditRepo records scene mutations. Optional; nil disables audit logging.
+	auditRepo audit.Repository
+
+	// eventRepo has the scene's events soft-deleted alongside it.
+	// Optional; nil leaves events untouched on scene deletion.
+	eventRepo scene.EventRepository
+
+	// rsvpRepo supplies RSVP totals for scene statistics.
+	// Optional; nil reports zero RSVPs.
+	rsvpRepo scene.RSVPRepository
+
+	// webhooks notifies integrators of scene changes. Optional; nil disables webhooks.
+	webhooks *webhook.Dispatcher
+
+	// followRepo tracks users following scenes without joining them.
+	// Optional; nil disables the follow endpoints and follower counts.
+	followRepo membership.FollowRepository
 }
 
 // NewSceneHandlers creates a new SceneHandlers instance.
@@ -73,9 +133,85 @@ func NewSceneHandlers(repo scene.SceneRepository, membershipRepo membership.Memb
 		repo:           repo,
 		membershipRepo: membershipRepo,
 		streamRepo:     streamRepo,
+
+		discoveryTrustWeight: DefaultDiscoveryTrustWeight,
+		locationAnonymityK:   DefaultLocationAnonymityK,
 	}
 }
 
+// SetTrustScoreStore enables trust-aware ranking for scene discovery.
+func (h *SceneHandlers) SetTrustScoreStore(store trust.ScoreStore) {
+	h.trustStore = store
+}
+
+// SetDiscoveryTrustWeight sets the weight given to trust in the blended
+// discovery ranking. Values are clamped to [0, 1]; 0 ranks purely by
+// proximity and 1 purely by trust.
+func (h *SceneHandlers) SetDiscoveryTrustWeight(weight float64) {
+	h.discoveryTrustWeight = clampUnit(weight)
+}
+
+// SetLocationAnonymityK sets how many public scenes must share a coarse cell
+// before non-owners see it, and the minimum size of map clusters. Values
+// below 1 are treated as 1, which disables coarsening.
+func (h *SceneHandlers) SetLocationAnonymityK(k int) {
+	h.locationAnonymityK = max(k, 1)
+}
+
+// SetSearchIndex keeps the given full-text index updated as scenes are
+// created, updated, and deleted.
+func (h *SceneHandlers) SetSearchIndex(index *search.Indexer) {
+	h.searchIndex = index
+}
+
+// SetAuditRepository enables audit logging of scene mutations (create, update,
+// delete, palette changes, and ownership transfers).
+func (h *SceneHandlers) SetAuditRepository(auditRepo audit.Repository) {
+	h.auditRepo = auditRepo
+}
+
+// SetEventRepository enables cascading scene deletion to the scene's events.
+// Once cascaded, the events and their RSVPs are no longer retrievable.
+func (h *SceneHandlers) SetEventRepository(eventRepo scene.EventRepository) {
+	h.eventRepo = eventRepo
+}
+
+// SetRSVPRepository enables RSVP totals in scene statistics.
+func (h *SceneHandlers) SetRSVPRepository(rsvpRepo scene.RSVPRepository) {
+	h.rsvpRepo = rsvpRepo
+}
+
+// SetWebhookDispatcher sends scene.updated webhooks through the given
+// dispatcher when scene details or palettes change.
+func (h *SceneHandlers) SetWebhookDispatcher(dispatcher *webhook.Dispatcher) {
+	h.webhooks = dispatcher
+}
+
+// SetFollowRepository enables scene follows and follower counts.
+func (h *SceneHandlers) SetFollowRepository(followRepo membership.FollowRepository) {
+	h.followRepo = followRepo
+}
+
+// SetLocationJitter enables privacy jitter on scene creation.
+// When radiusMeters is positive and a precise point is supplied, the stored
+// coarse geohash is derived from a jittered copy of that point instead of the
+// point itself. The precise point itself is not modified.
+func (h *SceneHandlers) SetLocationJitter(radiusMeters float64) {
+	h.jitterRadiusMeters = radiusMeters
+}
+
+// jitteredCoarseGeohash derives a coarse geohash from a jittered copy of p.
+// The jitter is seeded from the entity ID so repeated derivations for the
+// same entity are stable and cannot be averaged out.
+// Precision follows the resolved coarse geohash length.
+func jitteredCoarseGeohash(id string, p scene.Point, radiusMeters float64, precision int) string {
+	hasher := fnv.New64a()
+	_, _ = hasher.Write([]byte(id))
+	seed := int64(hasher.Sum64())
+
+	return geo.Encode(geo.Jitter(p, radiusMeters, seed), precision)
+}
+
 // validateSceneName validates scene name according to requirements.
 // Returns error message if validation fails, empty string if valid.
 func validateSceneName(name string) string {
@@ -111,19 +247,71 @@ func validateVisibility(visibility string) string {
 	return ""
 }
 
+// normalizeCoarseGeohash validates a client-supplied coarse geohash and
+// truncates it to geo.CoarsePrecision, so clients can't store finer cells
+// than the privacy model allows. Returns the normalized geohash and an empty
+// message, or an error message if the input is empty or has invalid characters.
+func normalizeCoarseGeohash(input string) (string, string) {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		return "", "coarse_geohash is required"
+	}
+	if !geo.IsValidGeohash(trimmed) {
+		return "", "coarse_geohash contains invalid characters"
+	}
+	return geo.RoundGeohash(trimmed, geo.CoarsePrecision()), ""
+}
+
+// validateRecordRef validates an optional AT Protocol record reference.
+// Both parts must be given or neither; the DID must be a did:plc or did:web
+// identifier and the rkey must follow the record key syntax.
+// Returns an error message if invalid, empty string if valid.
+func validateRecordRef(recordDID, recordRKey *string) string {
+	if recordDID == nil && recordRKey == nil {
+		return ""
+	}
+	if recordDID == nil || recordRKey == nil {
+		return "record_did and record_rkey must be provided together"
+	}
+	if err := did.ValidateDID(*recordDID); err != nil {
+		return "record_did must be a valid did:plc or did:web identifier"
+	}
+	if err := did.ValidateRecordKey(*recordRKey); err != nil {
+		return "record_rkey must be a valid AT Protocol record key"
+	}
+	return ""
+}
+
+// invalidPrecisePointMessage is the validation error for a precise point
+// with out-of-range, NaN, or infinite coordinates.
+const invalidPrecisePointMessage = "precise_point latitude must be between -90 and 90 and longitude between -180 and 180"
+
+// resolveCoarseGeohash returns the coarse geohash to store for a scene or
+// event. When a precise point is supplied, the geohash is derived from it at
+// geo.CoarsePrecision and any client-supplied value is ignored, so the public
+// cell always matches the private point. Otherwise the client-supplied geohash
+// is validated and normalized as in normalizeCoarseGeohash.
+func resolveCoarseGeohash(input string, precisePoint *scene.Point) (string, string) {
+	if precisePoint == nil {
+		return normalizeCoarseGeohash(input)
+	}
+	if !precisePoint.Valid() {
+		return "", invalidPrecisePointMessage
+	}
+	return geo.Encode(*precisePoint, geo.CoarsePrecision()), ""
+}
+
 // CreateScene handles POST /scenes - creates a new scene.
 func (h *SceneHandlers) CreateScene(w http.ResponseWriter, r *http.Request) {
 	var req CreateSceneRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+	if err := decodeJSON(r, &req); err != nil {
+		writeDecodeError(w, r, err)
 		return
 	}
 
 	// Validate name
 	if errMsg := validateSceneName(req.Name); errMsg != "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInvalidSceneName)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeInvalidSceneName, errMsg)
+		writeError(w, r, ErrCodeInvalidSceneName, errMsg)
 		return
 	}
 
@@ -132,22 +320,28 @@ func (h *SceneHandlers) CreateScene(w http.ResponseWriter, r *http.Request) {
 
 	// Validate owner_did
 	if strings.TrimSpace(req.OwnerDID) == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "owner_did is required")
+		writeError(w, r, ErrCodeValidation, "owner_did is required")
 		return
 	}
 
-	// Validate coarse_geohash
-	if strings.TrimSpace(req.CoarseGeohash) == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "coarse_geohash is required")
+	// Validate the optional AT Protocol record reference
+	if errMsg := validateRecordRef(req.RecordDID, req.RecordRKey); errMsg != "" {
+		writeError(w, r, ErrCodeValidation, errMsg)
 		return
 	}
 
+	// Derive coarse_geohash from the precise point, or validate the
+	// client-supplied one and truncate it to the default precision
+	coarseGeohash, errMsg := resolveCoarseGeohash(req.CoarseGeohash, req.PrecisePoint)
+	if errMsg != "" {
+		writeError(w, r, ErrCodeValidation, errMsg)
+		return
+	}
+	req.CoarseGeohash = coarseGeohash
+
 	// Validate visibility
 	if errMsg := validateVisibility(req.Visibility); errMsg != "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, errMsg)
+		writeError(w, r, ErrCodeValidation, errMsg)
 		return
 	}
 
@@ -156,22 +350,46 @@ func (h *SceneHandlers) CreateScene(w http.ResponseWriter, r *http.Request) {
 		req.Visibility = "public"
 	}
 
+	// Normalize and validate tags
+	req.Tags = scene.NormalizeTags(req.Tags)
+	if err := scene.ValidateTags(req.Tags); err != nil {
+		writeError(w, r, ErrCodeValidation, err.Error())
+		return
+	}
+
 	// Check for duplicate name
-	exists, err := h.repo.ExistsByOwnerAndName(req.OwnerDID, req.Name, "")
+	exists, err := h.repo.ExistsByOwnerAndName(r.Context(), req.OwnerDID, req.Name, "")
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to check duplicate scene name", "error", err, "owner_did", req.OwnerDID, "name", req.Name)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to check for duplicate scene name")
+		writeError(w, r, ErrCodeInternal, "Failed to check for duplicate scene name")
 		return
 	}
 	if exists {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeDuplicateSceneName)
-		WriteError(w, ctx, http.StatusConflict, ErrCodeDuplicateSceneName, "Scene with this name already exists for this owner")
+		writeError(w, r, ErrCodeDuplicateSceneName, "Scene with this name already exists for this owner")
 		return
 	}
 
+	// A record can back at most one scene
+	if req.RecordDID != nil {
+		_, err = h.repo.GetByRecordKey(r.Context(), *req.RecordDID, *req.RecordRKey)
+		if err == nil {
+			writeError(w, r, ErrCodeConflict, "A scene is already linked to this record")
+			return
+		}
+		if err != scene.ErrSceneNotFound {
+			slog.ErrorContext(r.Context(), "failed to check scene record key", "error", err, "record_did", *req.RecordDID)
+			writeError(w, r, ErrCodeInternal, "Failed to check for an existing scene record")
+			return
+		}
+	}
+
 	// Sanitize description to prevent HTML injection
-	req.Description = html.EscapeString(req.Description)
+	description, err := sanitizeText(req.Description)
+	if err != nil {
+		writeError(w, r, ErrCodeValidation, "description "+err.Error())
+		return
+	}
+	req.Description = description
 
 	// Sanitize tags to prevent HTML injection
 	sanitizedTags := make([]string, len(req.Tags))
@@ -181,8 +399,15 @@ func (h *SceneHandlers) CreateScene(w http.ResponseWriter, r *http.Request) {
 
 	// Create scene
 	now := time.Now()
+	sceneID := uuid.New().String()
+
+	// Optionally derive the coarse geohash from a jittered precise point
+	if h.jitterRadiusMeters > 0 && req.PrecisePoint != nil {
+		req.CoarseGeohash = jitteredCoarseGeohash(sceneID, *req.PrecisePoint, h.jitterRadiusMeters, len(req.CoarseGeohash))
+	}
+
 	newScene := &scene.Scene{
-		ID:            uuid.New().String(),
+		ID:            sceneID,
 		Name:          req.Name,
 		Description:   req.Description,
 		OwnerDID:      req.OwnerDID,
@@ -191,29 +416,37 @@ func (h *SceneHandlers) CreateScene(w http.ResponseWriter, r *http.Request) {
 		CoarseGeohash: req.CoarseGeohash,
 		Tags:          sanitizedTags,
 		Visibility:    req.Visibility,
-		Palette:       req.Palette,
+		RecordDID:     req.RecordDID,
+		RecordRKey:    req.RecordRKey,
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
+	if req.Palette != nil {
+		newScene.SetPalette(scene.ThemeLight, req.Palette)
+	}
 
 	// Insert into repository (will automatically enforce location consent).
 	// If AllowPrecise is false, PrecisePoint will be cleared before storage.
-	if err := h.repo.Insert(newScene); err != nil {
+	if err := h.repo.Insert(r.Context(), newScene); err != nil {
 		slog.ErrorContext(r.Context(), "failed to insert scene", "error", err, "scene_id", newScene.ID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to create scene")
+		writeError(w, r, ErrCodeInternal, "Failed to create scene")
 		return
 	}
 
 	// Retrieve the stored scene to get privacy-enforced version
-	stored, err := h.repo.GetByID(newScene.ID)
+	stored, err := h.repo.GetByID(r.Context(), newScene.ID)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to retrieve created scene", "error", err, "scene_id", newScene.ID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve created scene")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve created scene")
 		return
 	}
 
+	h.logSceneAudit(r, stored.ID, "create")
+
+	if h.searchIndex != nil {
+		h.searchIndex.IndexScene(stored)
+	}
+
 	// Return created scene
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -225,51 +458,89 @@ func (h *SceneHandlers) CreateScene(w http.ResponseWriter, r *http.Request) {
 
 // GetScene handles GET /scenes/{id} - retrieves a scene with visibility enforcement.
 func (h *SceneHandlers) GetScene(w http.ResponseWriter, r *http.Request) {
-	// Extract scene ID from URL path
-	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
-	if len(pathParts) == 0 || pathParts[0] == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Scene ID is required")
+	// Extract and validate scene ID from URL path
+	sceneID, ok := parseEntityID(w, r, "/scenes/", "Scene")
+	if !ok {
 		return
 	}
-	sceneID := pathParts[0]
 
 	// Get the scene
-	foundScene, err := h.repo.GetByID(sceneID)
+	foundScene, err := h.repo.GetByID(r.Context(), sceneID)
 	if err != nil {
 		// Handle deleted scenes with specific error code
 		if err == scene.ErrSceneDeleted {
 			slog.DebugContext(r.Context(), "scene deleted", "scene_id", sceneID)
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeSceneDeleted)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeSceneDeleted, "Scene not found")
+			writeError(w, r, ErrCodeSceneDeleted, "Scene not found")
 			return
 		}
 		// Use uniform error message to prevent timing attacks and user enumeration
 		// Same error for non-existent and forbidden resources
 		if err == scene.ErrSceneNotFound {
 			slog.DebugContext(r.Context(), "scene not found", "scene_id", sceneID)
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve scene")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+		return
+	}
+
+	h.serveScene(w, r, foundScene)
+}
+
+// GetSceneBySlug handles GET /scenes/by-slug/{owner_did}/{slug} - retrieves a
+// scene by its owner and slug for human-readable links. Visibility rules and
+// responses are the same as GetScene.
+func (h *SceneHandlers) GetSceneBySlug(w http.ResponseWriter, r *http.Request) {
+	ownerDID, slug, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/scenes/by-slug/"), "/")
+	if ownerDID == "" || slug == "" || strings.Contains(slug, "/") {
+		writeError(w, r, ErrCodeBadRequest, "Owner DID and slug are required")
+		return
+	}
+	if err := did.ValidateDID(ownerDID); err != nil {
+		writeError(w, r, ErrCodeValidation, "owner_did must be a valid did:plc or did:web identifier")
+		return
+	}
+
+	foundScene, err := h.repo.GetBySlug(r.Context(), ownerDID, slug)
+	if err != nil {
+		if err == scene.ErrSceneDeleted {
+			slog.DebugContext(r.Context(), "scene deleted", "owner_did", ownerDID, "slug", slug)
+			writeError(w, r, ErrCodeSceneDeleted, "Scene not found")
+			return
+		}
+		if err == scene.ErrSceneNotFound {
+			slog.DebugContext(r.Context(), "scene not found", "owner_did", ownerDID, "slug", slug)
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve scene by slug", "error", err, "owner_did", ownerDID, "slug", slug)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
 		return
 	}
 
+	h.serveScene(w, r, foundScene)
+}
+
+// serveScene writes a retrieved scene after enforcing visibility, coarse
+// location precision, and conditional GET for the requester.
+func (h *SceneHandlers) serveScene(w http.ResponseWriter, r *http.Request, foundScene *scene.Scene) {
+	sceneID := foundScene.ID
+
 	// Get requester DID (empty if not authenticated)
 	requesterDID := middleware.GetUserDID(r.Context())
 
-	// Check visibility permissions
-	canAccess, err := h.canAccessScene(r.Context(), foundScene, requesterDID)
+	// Determine the requester's relationship to the scene
+	viewerLevel, err := h.sceneViewerLevel(r.Context(), foundScene, requesterDID)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", sceneID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to check access permissions")
+		writeError(w, r, ErrCodeInternal, "Failed to check access permissions")
 		return
 	}
 
+	// Check visibility permissions
+	canAccess := canViewScene(r.Context(), foundScene, viewerLevel)
+
 	if !canAccess {
 		// Use uniform error message - same as "not found" to prevent enumeration
 		// Log at debug level only to avoid leaking information
@@ -278,8 +549,7 @@ func (h *SceneHandlers) GetScene(w http.ResponseWriter, r *http.Request) {
 			"visibility", foundScene.Visibility,
 			"requester_did", requesterDID,
 			"is_owner", foundScene.IsOwner(requesterDID))
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-		WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+		writeError(w, r, ErrCodeNotFound, "Scene not found")
 		return
 	}
 
@@ -289,6 +559,42 @@ func (h *SceneHandlers) GetScene(w http.ResponseWriter, r *http.Request) {
 		"visibility", foundScene.Visibility,
 		"requester_did", requesterDID)
 
+	// Re-truncate coarse location based on the requester's relationship:
+	// owners see the stored value, members see finer cells than the public.
+	// Non-owners see sparse cells widened, or no location at all.
+	foundScene.CoarseGeohash, err = h.newLocationAnonymizer().coarseGeohash(r.Context(), foundScene, viewerLevel)
+	if err != nil {
+		if writeContextError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to anonymize scene location", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+		return
+	}
+
+	// Validators are set only after the visibility check so a 304 can't
+	// reveal that a hidden scene exists. The version increments on every
+	// write, palette changes included, and the ETag also carries the
+	// anonymized cell, which can widen or narrow as nearby scenes come and go.
+	// The body still depends on who is asking, hence Vary.
+	etag := sceneETag(foundScene)
+	lastModified := sceneLastModified(foundScene)
+	w.Header().Set("ETag", etag)
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	w.Header().Set("Vary", "Authorization")
+	if notModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	// Record who was served the precise location. The repository has already
+	// stripped it without consent, so nothing is logged in that case.
+	if foundScene.PrecisePoint != nil {
+		h.logSceneAudit(r, sceneID, "access_precise_location")
+	}
+
 	// Return scene (privacy already enforced by repository)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -297,81 +603,181 @@ func (h *SceneHandlers) GetScene(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// canAccessScene checks if a user can access a scene based on visibility rules.
+// sceneViewerLevel returns the requester's access level for a scene:
+// geo.ViewerOwner, geo.ViewerMember (active membership), or geo.ViewerPublic.
+func (h *SceneHandlers) sceneViewerLevel(ctx context.Context, s *scene.Scene, requesterDID string) (string, error) {
+	return viewerLevelForScene(ctx, h.membershipRepo, s, requesterDID)
+}
+
+// viewerLevelForScene resolves the requester's access level for a scene using
+// the given membership repository. A nil repository treats every non-owner
+// as a public viewer.
+func viewerLevelForScene(ctx context.Context, membershipRepo membership.MembershipRepository, s *scene.Scene, requesterDID string) (string, error) {
+	if s.IsOwner(requesterDID) {
+		return geo.ViewerOwner, nil
+	}
+	if requesterDID == "" || membershipRepo == nil {
+		return geo.ViewerPublic, nil
+	}
+
+	m, err := membershipRepo.GetBySceneAndUser(ctx, s.ID, requesterDID)
+	if err != nil {
+		if err == membership.ErrMembershipNotFound {
+			return geo.ViewerPublic, nil
+		}
+		return "", err
+	}
+	if m.Status == "active" {
+		return geo.ViewerMember, nil
+	}
+	return geo.ViewerPublic, nil
+}
+
+// canViewScene applies visibility rules for a viewer access level.
 // Returns true if access is allowed, false otherwise.
-func (h *SceneHandlers) canAccessScene(ctx context.Context, s *scene.Scene, requesterDID string) (bool, error) {
+func canViewScene(ctx context.Context, s *scene.Scene, viewerLevel string) bool {
 	// Owner always has access
-	if s.IsOwner(requesterDID) {
-		return true, nil
+	if viewerLevel == geo.ViewerOwner {
+		return true
 	}
 
-	// Check visibility rules
 	switch s.Visibility {
 	case scene.VisibilityPublic:
 		// Public scenes are accessible to everyone
-		return true, nil
-
+		return true
 	case scene.VisibilityMembersOnly:
 		// Members-only scenes require active membership
-		if requesterDID == "" {
-			return false, nil
-		}
-		
-		// Check if requester is an active member
-		m, err := h.membershipRepo.GetBySceneAndUser(s.ID, requesterDID)
-		if err != nil {
-			// Not a member or error retrieving membership
-			if err == membership.ErrMembershipNotFound {
-				return false, nil
-			}
-			return false, err
-		}
-		
-		// Only active members can access
-		return m.Status == "active", nil
-
+		return viewerLevel == geo.ViewerMember
 	case scene.VisibilityHidden:
 		// Hidden scenes only accessible to owner (already checked above)
-		return false, nil
-
+		return false
 	default:
 		// Unknown visibility mode - deny access for safety
 		slog.WarnContext(ctx, "unknown visibility mode", "visibility", s.Visibility, "scene_id", s.ID)
-		return false, nil
+		return false
+	}
+}
+
+// canAccessScene checks if a user can access a scene based on visibility rules.
+// Returns true if access is allowed, false otherwise.
+func (h *SceneHandlers) canAccessScene(ctx context.Context, s *scene.Scene, requesterDID string) (bool, error) {
+	viewerLevel, err := h.sceneViewerLevel(ctx, s, requesterDID)
+	if err != nil {
+		return false, err
+	}
+	return canViewScene(ctx, s, viewerLevel), nil
+}
+
+// sceneETag returns the strong ETag for a scene as served: its version plus
+// the coarse geohash in s, e.g. "3-dr5re". Non-owners see a cell that also
+// depends on their access level and on how many scenes share it, which can
+// change without a write to this scene. Geohashes never contain '-'.
+func sceneETag(s *scene.Scene) string {
+	if s.CoarseGeohash == "" {
+		return `"` + strconv.Itoa(s.Version) + `"`
+	}
+	return `"` + strconv.Itoa(s.Version) + "-" + s.CoarseGeohash + `"`
+}
+
+// sceneLastModified returns when a scene was last written, falling back to
+// its creation time. Returns the zero time when neither is known.
+func sceneLastModified(s *scene.Scene) time.Time {
+	if s.UpdatedAt != nil {
+		return *s.UpdatedAt
+	}
+	if s.CreatedAt != nil {
+		return *s.CreatedAt
 	}
+	return time.Time{}
+}
+
+// notModified reports whether a conditional GET can be answered with
+// 304 Not Modified. If-None-Match takes precedence over If-Modified-Since,
+// and ETags are compared weakly as RFC 9110 requires for If-None-Match.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	ifModifiedSince := r.Header.Get("If-Modified-Since")
+	if ifModifiedSince == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	// HTTP dates have one-second resolution
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// ifMatchSatisfied reports whether an If-Match header value carries a scene
+// ETag for version. Only the version is compared, since the ETag a client
+// holds depends on the coarse geohash it was shown, not on the stored one.
+// The header may list several comma-separated ETags or be "*".
+func ifMatchSatisfied(header string, version int) bool {
+	want := strconv.Itoa(version)
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		// Strong comparison: weak or unquoted tags never match
+		if len(candidate) < 2 || candidate[0] != '"' || candidate[len(candidate)-1] != '"' {
+			continue
+		}
+		tagVersion, _, _ := strings.Cut(candidate[1:len(candidate)-1], "-")
+		if tagVersion == want {
+			return true
+		}
+	}
+	return false
 }
 
 // UpdateScene handles PATCH /scenes/{id} - updates an existing scene.
+// When an If-Match header is sent it must carry the scene's current ETag
+// (as returned by GetScene), otherwise 412 Precondition Failed is returned
+// so concurrent editors don't overwrite each other.
 func (h *SceneHandlers) UpdateScene(w http.ResponseWriter, r *http.Request) {
-	// Extract scene ID from URL path
-	// For now, we'll use a simple path parsing; in production this would use chi or similar
-	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
-	if len(pathParts) == 0 || pathParts[0] == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Scene ID is required")
+	// Extract and validate scene ID from URL path
+	sceneID, ok := parseEntityID(w, r, "/scenes/", "Scene")
+	if !ok {
 		return
 	}
-	sceneID := pathParts[0]
 
 	// Parse request body
 	var req UpdateSceneRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+	if err := decodeJSON(r, &req); err != nil {
+		writeDecodeError(w, r, err)
 		return
 	}
 
 	// Get existing scene
-	existingScene, err := h.repo.GetByID(sceneID)
+	existingScene, err := h.repo.GetByID(r.Context(), sceneID)
 	if err != nil {
+		if err == scene.ErrSceneDeleted {
+			writeError(w, r, ErrCodeSceneDeleted, "Scene not found")
+			return
+		}
 		if err == scene.ErrSceneNotFound {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve scene")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+		return
+	}
+
+	// Reject stale edits before doing any work
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch != "" && !ifMatchSatisfied(ifMatch, existingScene.Version) {
+		writeError(w, r, ErrCodePreconditionFailed, "Scene has been modified since it was retrieved")
 		return
 	}
 
@@ -379,36 +785,43 @@ func (h *SceneHandlers) UpdateScene(w http.ResponseWriter, r *http.Request) {
 	if req.Name != nil {
 		newName := *req.Name
 		if errMsg := validateSceneName(newName); errMsg != "" {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInvalidSceneName)
-			WriteError(w, ctx, http.StatusBadRequest, ErrCodeInvalidSceneName, errMsg)
+			writeError(w, r, ErrCodeInvalidSceneName, errMsg)
 			return
 		}
 		// Sanitize name after validation
 		newName = sanitizeSceneName(newName)
 		
 		// Check for duplicate name (excluding current scene)
-		exists, err := h.repo.ExistsByOwnerAndName(existingScene.OwnerDID, newName, sceneID)
+		exists, err := h.repo.ExistsByOwnerAndName(r.Context(), existingScene.OwnerDID, newName, sceneID)
 		if err != nil {
 			slog.ErrorContext(r.Context(), "failed to check duplicate scene name", "error", err, "owner_did", existingScene.OwnerDID, "name", newName, "scene_id", sceneID)
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to check for duplicate scene name")
+			writeError(w, r, ErrCodeInternal, "Failed to check for duplicate scene name")
 			return
 		}
 		if exists {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeDuplicateSceneName)
-			WriteError(w, ctx, http.StatusConflict, ErrCodeDuplicateSceneName, "Scene with this name already exists for this owner")
+			writeError(w, r, ErrCodeDuplicateSceneName, "Scene with this name already exists for this owner")
 			return
 		}
 		existingScene.Name = newName
 	}
 
 	if req.Description != nil {
-		existingScene.Description = html.EscapeString(*req.Description)
+		description, err := sanitizeText(*req.Description)
+		if err != nil {
+			writeError(w, r, ErrCodeValidation, "description "+err.Error())
+			return
+		}
+		existingScene.Description = description
 	}
 
 	if req.Tags != nil {
-		sanitizedTags := make([]string, len(req.Tags))
-		for i, tag := range req.Tags {
+		normalizedTags := scene.NormalizeTags(req.Tags)
+		if err := scene.ValidateTags(normalizedTags); err != nil {
+			writeError(w, r, ErrCodeValidation, err.Error())
+			return
+		}
+		sanitizedTags := make([]string, len(normalizedTags))
+		for i, tag := range normalizedTags {
 			sanitizedTags[i] = html.EscapeString(tag)
 		}
 		existingScene.Tags = sanitizedTags
@@ -416,15 +829,14 @@ func (h *SceneHandlers) UpdateScene(w http.ResponseWriter, r *http.Request) {
 
 	if req.Visibility != nil {
 		if errMsg := validateVisibility(*req.Visibility); errMsg != "" {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, errMsg)
+			writeError(w, r, ErrCodeValidation, errMsg)
 			return
 		}
 		existingScene.Visibility = *req.Visibility
 	}
 
 	if req.Palette != nil {
-		existingScene.Palette = req.Palette
+		existingScene.SetPalette(scene.ThemeLight, req.Palette)
 	}
 
 	if req.AllowPrecise != nil {
@@ -432,7 +844,18 @@ func (h *SceneHandlers) UpdateScene(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if req.PrecisePoint != nil {
+		// As on create, the public cell follows the new point so it never
+		// goes stale
+		coarseGeohash, errMsg := resolveCoarseGeohash("", req.PrecisePoint)
+		if errMsg != "" {
+			writeError(w, r, ErrCodeValidation, errMsg)
+			return
+		}
+		if h.jitterRadiusMeters > 0 {
+			coarseGeohash = jitteredCoarseGeohash(sceneID, *req.PrecisePoint, h.jitterRadiusMeters, len(coarseGeohash))
+		}
 		existingScene.PrecisePoint = req.PrecisePoint
+		existingScene.CoarseGeohash = coarseGeohash
 	}
 
 	// Note: Repository Update will automatically enforce location consent.
@@ -443,138 +866,347 @@ func (h *SceneHandlers) UpdateScene(w http.ResponseWriter, r *http.Request) {
 	now := time.Now()
 	existingScene.UpdatedAt = &now
 
-	// Update in repository (will enforce location consent)
-	if err := h.repo.Update(existingScene); err != nil {
+	// Update in repository (will enforce location consent).
+	// existingScene carries the version read above, so a write that raced
+	// in since then fails with ErrVersionConflict instead of being overwritten.
+	if err := h.repo.Update(r.Context(), existingScene); err != nil {
+		if err == scene.ErrVersionConflict {
+			code := ErrCodeConflict
+			if ifMatch != "" {
+				code = ErrCodePreconditionFailed
+			}
+			writeError(w, r, code, "Scene has been modified since it was retrieved")
+			return
+		}
 		slog.ErrorContext(r.Context(), "failed to update scene", "error", err, "scene_id", sceneID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to update scene")
+		writeError(w, r, ErrCodeInternal, "Failed to update scene")
 		return
 	}
 
 	// Retrieve updated scene
-	updated, err := h.repo.GetByID(sceneID)
+	updated, err := h.repo.GetByID(r.Context(), sceneID)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to retrieve updated scene", "error", err, "scene_id", sceneID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve updated scene")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve updated scene")
 		return
 	}
 
+	h.logSceneAudit(r, sceneID, "update")
+
+	if h.searchIndex != nil {
+		h.searchIndex.IndexScene(updated)
+	}
+	h.notifySceneUpdated(r, updated)
+
 	// Return updated scene
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", sceneETag(updated))
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(updated); err != nil {
 		return
 	}
 }
 
-// DeleteScene handles DELETE /scenes/{id} - soft-deletes a scene.
+// DeleteScene handles DELETE /scenes/{id} - soft-deletes a scene and, when an
+// event repository is set, the scene's events.
 func (h *SceneHandlers) DeleteScene(w http.ResponseWriter, r *http.Request) {
-	// Extract scene ID from URL path
-	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
-	if len(pathParts) == 0 || pathParts[0] == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Scene ID is required")
+	// Extract and validate scene ID from URL path
+	sceneID, ok := parseEntityID(w, r, "/scenes/", "Scene")
+	if !ok {
 		return
 	}
-	sceneID := pathParts[0]
 
 	// Soft delete the scene
-	if err := h.repo.Delete(sceneID); err != nil {
+	if err := h.repo.Delete(r.Context(), sceneID); err != nil {
 		if err == scene.ErrSceneNotFound {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
 			return
 		}
 		if err == scene.ErrSceneDeleted {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeSceneDeleted)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeSceneDeleted, "Scene not found")
+			writeError(w, r, ErrCodeSceneDeleted, "Scene not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to delete scene", "error", err, "scene_id", sceneID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to delete scene")
+		writeError(w, r, ErrCodeInternal, "Failed to delete scene")
 		return
 	}
 
+	h.logSceneAudit(r, sceneID, "delete")
+
+	if h.eventRepo != nil {
+		h.deleteSceneEvents(r.Context(), sceneID)
+	}
+
+	if h.searchIndex != nil {
+		h.searchIndex.RemoveScene(sceneID)
+	}
+
 	// Return success with no content
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// UpdateScenePalette handles PATCH /scenes/{id}/palette - updates scene color palette.
-func (h *SceneHandlers) UpdateScenePalette(w http.ResponseWriter, r *http.Request) {
+// deleteSceneEvents soft-deletes a deleted scene's events and drops them from
+// the search index. Failures are logged rather than returned since the scene
+// deletion itself has already succeeded.
+func (h *SceneHandlers) deleteSceneEvents(ctx context.Context, sceneID string) {
+	var events []*scene.Event
+	if h.searchIndex != nil {
+		var err error
+		events, err = h.eventRepo.ListBySceneID(ctx, sceneID, scene.EventFilter{})
+		if err != nil {
+			slog.WarnContext(ctx, "failed to list scene events for index removal", "error", err, "scene_id", sceneID)
+		}
+	}
+
+	count, err := h.eventRepo.DeleteBySceneID(ctx, sceneID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to delete scene events", "error", err, "scene_id", sceneID)
+		return
+	}
+	slog.InfoContext(ctx, "deleted scene events", "scene_id", sceneID, "event_count", count)
+
+	for _, event := range events {
+		h.searchIndex.RemoveEvent(event.ID)
+	}
+}
+
+// TransferOwnership handles POST /scenes/{id}/transfer - hands a scene to another member.
+// Only the current owner may transfer, and the new owner must already be an
+// active member. The previous owner stays on as an active member with the
+// requested role (admin by default) so they keep moderation rights.
+func (h *SceneHandlers) TransferOwnership(w http.ResponseWriter, r *http.Request) {
 	// Extract scene ID from URL path
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
 	if len(pathParts) < 2 || pathParts[0] == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Scene ID is required")
+		writeError(w, r, ErrCodeBadRequest, "Scene ID is required")
 		return
 	}
 	sceneID := pathParts[0]
 
-	// Parse request body
-	var req UpdateScenePaletteRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+	// Get authenticated user DID from context
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
 		return
 	}
 
-	// Get existing scene first to check ownership
-	existingScene, err := h.repo.GetByID(sceneID)
+	var req TransferOwnershipRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	newOwnerDID := strings.TrimSpace(req.NewOwnerDID)
+	if newOwnerDID == "" {
+		writeError(w, r, ErrCodeValidation, "new_owner_did is required")
+		return
+	}
+
+	previousOwnerRole := req.PreviousOwnerRole
+	if previousOwnerRole == "" {
+		previousOwnerRole = "admin"
+	}
+	if previousOwnerRole != "admin" && previousOwnerRole != "member" {
+		writeError(w, r, ErrCodeValidation, "previous_owner_role must be 'admin' or 'member'")
+		return
+	}
+
+	existingScene, err := h.repo.GetByID(r.Context(), sceneID)
 	if err != nil {
 		if err == scene.ErrSceneNotFound {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
+			return
+		}
+		if err == scene.ErrSceneDeleted {
+			writeError(w, r, ErrCodeSceneDeleted, "Scene not found")
 			return
 		}
 		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve scene")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
 		return
 	}
 
-	// Authorization: Only the owner can update the palette
-	userDID := middleware.GetUserDID(r.Context())
-	if userDID == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
-		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+	if !existingScene.IsOwner(userDID) {
+		writeError(w, r, ErrCodeForbidden, "Only the scene owner can transfer ownership")
 		return
 	}
-	if existingScene.OwnerDID != userDID {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
-		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "Forbidden: you do not own this scene")
+
+	if newOwnerDID == userDID {
+		writeError(w, r, ErrCodeValidation, "New owner must be different from the current owner")
 		return
 	}
 
+	// The new owner must already be an active member of the scene
+	targetMembership, err := h.membershipRepo.GetBySceneAndUser(r.Context(), sceneID, newOwnerDID)
+	if err != nil && err != membership.ErrMembershipNotFound {
+		slog.ErrorContext(r.Context(), "failed to retrieve membership", "error", err, "scene_id", sceneID, "user_did", newOwnerDID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve membership")
+		return
+	}
+	if err == membership.ErrMembershipNotFound || targetMembership.Status != "active" {
+		writeError(w, r, ErrCodeValidation, "New owner must be an active member of the scene")
+		return
+	}
+
+	// Scene names are unique per owner
+	exists, err := h.repo.ExistsByOwnerAndName(r.Context(), newOwnerDID, existingScene.Name, sceneID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to check duplicate scene name", "error", err, "owner_did", newOwnerDID, "name", existingScene.Name, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to check for duplicate scene name")
+		return
+	}
+	if exists {
+		writeError(w, r, ErrCodeDuplicateSceneName, "New owner already has a scene with this name")
+		return
+	}
+
+	// Keep the previous owner on as a member before handing over the scene,
+	// so a failure below never leaves them without access
+	restoreMembership, err := h.keepPreviousOwner(r.Context(), sceneID, userDID, previousOwnerRole)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to update previous owner membership", "error", err, "scene_id", sceneID, "user_did", userDID)
+		writeError(w, r, ErrCodeInternal, "Failed to transfer ownership")
+		return
+	}
+
+	// existingScene carries the version read above, so a concurrent edit
+	// fails the transfer with ErrVersionConflict instead of being overwritten
+	now := time.Now()
+	existingScene.OwnerDID = newOwnerDID
+	existingScene.UpdatedAt = &now
+	if err := h.repo.Update(r.Context(), existingScene); err != nil {
+		// The scene still belongs to the previous owner, so undo their
+		// membership change; this must run even if the request has timed out
+		if restoreErr := restoreMembership(context.WithoutCancel(r.Context())); restoreErr != nil {
+			slog.ErrorContext(r.Context(), "failed to restore previous owner membership", "error", restoreErr, "scene_id", sceneID, "user_did", userDID)
+		}
+		if err == scene.ErrVersionConflict {
+			writeError(w, r, ErrCodeConflict, "Scene has been modified since it was retrieved")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to transfer scene ownership", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to transfer ownership")
+		return
+	}
+
+	h.logSceneAudit(r, sceneID, "scene_transfer")
+
+	updated, err := h.repo.GetByID(r.Context(), sceneID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to retrieve updated scene", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve updated scene")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(updated); err != nil {
+		return
+	}
+}
+
+// logSceneAudit records a scene mutation in the audit log when an audit
+// repository is configured. Failures are logged and otherwise ignored.
+func (h *SceneHandlers) logSceneAudit(r *http.Request, sceneID, action string) {
+	if h.auditRepo == nil {
+		return
+	}
+	if err := audit.LogAccessFromRequest(r, h.auditRepo, "scene", sceneID, action); err != nil {
+		slog.WarnContext(r.Context(), "failed to log scene audit", "error", err, "scene_id", sceneID, "action", action)
+		// Continue - audit failure should not block the operation
+	}
+}
+
+// notifySceneUpdated dispatches a scene.updated webhook without the precise
+// point. Failures are logged and don't fail the request.
+func (h *SceneHandlers) notifySceneUpdated(r *http.Request, s *scene.Scene) {
+	if h.webhooks == nil {
+		return
+	}
+	payload := *s
+	payload.PrecisePoint = nil
+	evt := webhook.Event{
+		Type:    webhook.SceneUpdated,
+		SceneID: s.ID,
+		Public:  s.Visibility == scene.VisibilityPublic,
+		Data:    &payload,
+	}
+	if err := h.webhooks.Dispatch(evt); err != nil {
+		slog.WarnContext(r.Context(), "failed to dispatch webhook", "error", err, "scene_id", s.ID, "type", evt.Type)
+	}
+}
+
+// keepPreviousOwner ensures the outgoing owner has an active membership with role.
+// Owners usually have no membership record, so one is created if missing.
+// Returns a function that puts the membership back as it was, for when the
+// ownership change itself fails.
+func (h *SceneHandlers) keepPreviousOwner(ctx context.Context, sceneID, userDID, role string) (func(context.Context) error, error) {
+	existing, err := h.membershipRepo.GetBySceneAndUser(ctx, sceneID, userDID)
+	if err == membership.ErrMembershipNotFound {
+		result, err := h.membershipRepo.Upsert(ctx, &membership.Membership{
+			SceneID: sceneID,
+			UserDID: userDID,
+			Role:    role,
+			Status:  "active",
+		})
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context) error {
+			return h.membershipRepo.Delete(ctx, result.ID)
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	restore := func(ctx context.Context) error {
+		if err := h.membershipRepo.UpdateRole(ctx, existing.ID, existing.Role); err != nil {
+			return err
+		}
+		return h.membershipRepo.UpdateStatus(ctx, existing.ID, existing.Status, &existing.Since)
+	}
+	if err := h.membershipRepo.UpdateRole(ctx, existing.ID, role); err != nil {
+		return nil, err
+	}
+	if existing.Status != "active" {
+		now := time.Now()
+		if err := h.membershipRepo.UpdateStatus(ctx, existing.ID, "active", &now); err != nil {
+			return nil, errors.Join(err, restore(ctx))
+		}
+	}
+	return restore, nil
+}
+
+// validatePalette checks that every color of p is a valid hex color,
+// replacing each with its sanitized form, and that text and background meet
+// the WCAG AA contrast minimum. theme names the palette in the contrast
+// error. Returns error message if validation fails, empty string if valid.
+func validatePalette(p *scene.Palette, theme string) string {
 	// Define color fields in deterministic order for consistent validation
 	type colorField struct {
 		name  string
 		value *string // Pointer to the palette field
 	}
 	colorFields := []colorField{
-		{"primary", &req.Palette.Primary},
-		{"secondary", &req.Palette.Secondary},
-		{"accent", &req.Palette.Accent},
-		{"background", &req.Palette.Background},
-		{"text", &req.Palette.Text},
+		{"primary", &p.Primary},
+		{"secondary", &p.Secondary},
+		{"accent", &p.Accent},
+		{"background", &p.Background},
+		{"text", &p.Text},
 	}
 
 	// Validate and sanitize all color fields
 	for _, field := range colorFields {
 		// Check if field is provided
 		if strings.TrimSpace(*field.value) == "" {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInvalidPalette)
-			WriteError(w, ctx, http.StatusBadRequest, ErrCodeInvalidPalette, field.name+" color is required")
-			return
+			return field.name + " color is required"
 		}
 
 		// Sanitize to prevent script injection (also validates hex format)
 		sanitized := color.SanitizeColor(*field.value)
 		if sanitized == "" {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInvalidPalette)
-			WriteError(w, ctx, http.StatusBadRequest, ErrCodeInvalidPalette, field.name+" color: invalid hex color format, expected #RRGGBB")
-			return
+			return field.name + " color: invalid hex color format, expected #RGB or #RRGGBB"
 		}
 
 		// Update the palette with sanitized value
@@ -582,38 +1214,111 @@ func (h *SceneHandlers) UpdateScenePalette(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Validate contrast ratio between text and background (WCAG AA minimum 4.5:1)
-	ratio, err := color.ValidateContrast(req.Palette.Text, req.Palette.Background)
+	ratio, err := scene.ContrastRatio(p.Text, p.Background)
 	if err != nil {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInvalidPalette)
-		if ratio > 0 {
-			msg := fmt.Sprintf("Insufficient contrast between text and background colors (got %s:1, need 4.5:1 minimum for WCAG AA)", 
-				formatRatio(ratio))
-			WriteError(w, ctx, http.StatusBadRequest, ErrCodeInvalidPalette, msg)
-		} else {
-			WriteError(w, ctx, http.StatusBadRequest, ErrCodeInvalidPalette, err.Error())
+		return err.Error()
+	}
+	if ratio < scene.MinTextContrast {
+		return fmt.Sprintf("Insufficient text/background contrast for %s theme: contrast %s is below required %s",
+			theme, formatRatio(ratio), formatRatio(scene.MinTextContrast))
+	}
+	return ""
+}
+
+// UpdateScenePalette handles PATCH /scenes/{id}/palette - updates scene color palette.
+// Query parameter theme selects the palette to set: "light" (default, also
+// returned as the legacy palette field) or "dark". Other themes are unchanged.
+func (h *SceneHandlers) UpdateScenePalette(w http.ResponseWriter, r *http.Request) {
+	// Extract scene ID from URL path
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
+	if len(pathParts) < 2 || pathParts[0] == "" {
+		writeError(w, r, ErrCodeBadRequest, "Scene ID is required")
+		return
+	}
+	sceneID := pathParts[0]
+
+	theme := r.URL.Query().Get("theme")
+	if theme == "" {
+		theme = scene.ThemeLight
+	}
+	if !scene.ValidThemes[theme] {
+		writeError(w, r, ErrCodeValidation, "theme must be 'light' or 'dark'")
+		return
+	}
+
+	// Parse request body
+	var req UpdateScenePaletteRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	// Get existing scene first to check ownership
+	existingScene, err := h.repo.GetByID(r.Context(), sceneID)
+	if err != nil {
+		if err == scene.ErrSceneDeleted {
+			writeError(w, r, ErrCodeSceneDeleted, "Scene not found")
+			return
+		}
+		if err == scene.ErrSceneNotFound {
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
+			return
 		}
+		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
 		return
 	}
 
-	// Update palette
-	existingScene.Palette = &req.Palette
+	// Authorization: Only the owner can update the palette
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+	if existingScene.OwnerDID != userDID {
+		writeError(w, r, ErrCodeForbidden, "Forbidden: you do not own this scene")
+		return
+	}
+
+	if errMsg := validatePalette(&req.Palette, theme); errMsg != "" {
+		writeError(w, r, ErrCodeInvalidPalette, errMsg)
+		return
+	}
+
+	// Update the palette for this theme only
+	existingScene.SetPalette(theme, &req.Palette)
 
 	// Update timestamp
 	now := time.Now()
 	existingScene.UpdatedAt = &now
 
-	// Update in repository
-	if err := h.repo.Update(existingScene); err != nil {
+	// Update in repository; existingScene carries the version read above
+	if err := h.repo.Update(r.Context(), existingScene); err != nil {
+		if err == scene.ErrVersionConflict {
+			writeError(w, r, ErrCodeConflict, "Scene has been modified since it was retrieved")
+			return
+		}
 		slog.ErrorContext(r.Context(), "failed to update scene palette", "error", err, "scene_id", sceneID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to update scene palette")
+		writeError(w, r, ErrCodeInternal, "Failed to update scene palette")
+		return
+	}
+
+	// Retrieve updated scene so the response carries the new version
+	updated, err := h.repo.GetByID(r.Context(), sceneID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to retrieve updated scene", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve updated scene")
 		return
 	}
 
+	h.logSceneAudit(r, sceneID, "palette_update")
+	h.notifySceneUpdated(r, updated)
+
 	// Return updated scene
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", sceneETag(updated))
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(existingScene); err != nil {
+	if err := json.NewEncoder(w).Encode(updated); err != nil {
 		return
 	}
 }
@@ -629,6 +1334,94 @@ func formatRatio(ratio float64) string {
 	return formatted
 }
 
+// ListScenesResponse represents the response for the scene listing endpoint.
+type ListScenesResponse struct {
+	Scenes     []*scene.Scene `json:"scenes"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// ListScenes handles GET /scenes - lists scenes with cursor-based pagination.
+// Supports filtering by tag, visibility, and owner_did query parameters.
+// Hidden and soft-deleted scenes are excluded for non-owners, and members-only
+// scenes are only included for active members. Pages may therefore contain
+// fewer than limit scenes while still returning a next_cursor.
+func (h *SceneHandlers) ListScenes(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter := scene.ListFilter{
+		Tag:        query.Get("tag"),
+		Visibility: query.Get("visibility"),
+		OwnerDID:   query.Get("owner_did"),
+		ViewerDID:  middleware.GetUserDID(r.Context()),
+		Cursor:     query.Get("cursor"),
+	}
+
+	if errMsg := validateVisibility(filter.Visibility); errMsg != "" {
+		writeError(w, r, ErrCodeValidation, errMsg)
+		return
+	}
+
+	// Parse limit; values above the maximum are capped rather than rejected
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(strings.TrimSpace(limitStr))
+		if err != nil || limit < 1 {
+			writeError(w, r, ErrCodeValidation, "limit must be a positive integer")
+			return
+		}
+		if limit > scene.MaxSceneListLimit {
+			limit = scene.MaxSceneListLimit
+		}
+		filter.Limit = limit
+	}
+
+	scenes, nextCursor, err := h.repo.List(r.Context(), filter)
+	if err != nil {
+		if err == scene.ErrInvalidCursor {
+			writeError(w, r, ErrCodeValidation, "invalid cursor")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to list scenes", "error", err)
+		writeError(w, r, ErrCodeInternal, "Failed to list scenes")
+		return
+	}
+
+	// Apply per-scene visibility and coarse precision rules
+	anonymizer := h.newLocationAnonymizer()
+	visible := make([]*scene.Scene, 0, len(scenes))
+	for _, sc := range scenes {
+		viewerLevel, err := h.sceneViewerLevel(r.Context(), sc, filter.ViewerDID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", sc.ID)
+			writeError(w, r, ErrCodeInternal, "Failed to check access permissions")
+			return
+		}
+		if !canViewScene(r.Context(), sc, viewerLevel) {
+			continue
+		}
+		sc.CoarseGeohash, err = anonymizer.coarseGeohash(r.Context(), sc, viewerLevel)
+		if err != nil {
+			if writeContextError(w, r, err) {
+				return
+			}
+			slog.ErrorContext(r.Context(), "failed to anonymize scene location", "error", err, "scene_id", sc.ID)
+			writeError(w, r, ErrCodeInternal, "Failed to list scenes")
+			return
+		}
+		visible = append(visible, sc)
+	}
+
+	response := ListScenesResponse{
+		Scenes:     visible,
+		NextCursor: nextCursor,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
+
 // OwnedSceneSummary represents a summary of a scene owned by the user.
 // Used for the dashboard endpoint to provide key metrics without heavy fields.
 type OwnedSceneSummary struct {
@@ -641,6 +1434,7 @@ type OwnedSceneSummary struct {
 	CreatedAt       *time.Time     `json:"created_at,omitempty"`
 	UpdatedAt       *time.Time     `json:"updated_at,omitempty"`
 	MembersCount    int            `json:"members_count"`
+	FollowersCount  int            `json:"followers_count"`
 	HasActiveStream bool           `json:"has_active_stream"`
 }
 
@@ -649,17 +1443,15 @@ func (h *SceneHandlers) ListOwnedScenes(w http.ResponseWriter, r *http.Request)
 	// Get authenticated user DID
 	userDID := middleware.GetUserDID(r.Context())
 	if userDID == "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
-		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
 		return
 	}
 
 	// Get all scenes owned by user
-	scenes, err := h.repo.ListByOwner(userDID)
+	scenes, err := h.repo.ListByOwner(r.Context(), userDID)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to list owned scenes", "error", err, "user_did", userDID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve scenes")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scenes")
 		return
 	}
 
@@ -680,20 +1472,29 @@ func (h *SceneHandlers) ListOwnedScenes(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Batch query for membership counts (avoids N+1 query problem)
-	membershipCounts, err := h.membershipRepo.CountByScenes(sceneIDs, "active")
+	membershipCounts, err := h.membershipRepo.CountByScenes(r.Context(), sceneIDs, "active")
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to count memberships", "error", err, "user_did", userDID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve membership counts")
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve membership counts")
 		return
 	}
 
+	// Followers are counted separately from members; zero when follows are disabled
+	followerCounts := map[string]int{}
+	if h.followRepo != nil {
+		followerCounts, err = h.followRepo.CountByScenes(sceneIDs)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to count followers", "error", err, "user_did", userDID)
+			writeError(w, r, ErrCodeInternal, "Failed to retrieve follower counts")
+			return
+		}
+	}
+
 	// Batch query for active streams (avoids N+1 query problem)
 	activeStreams, err := h.streamRepo.HasActiveStreamsForScenes(sceneIDs)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to check active streams", "error", err, "user_did", userDID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to check active streams")
+		writeError(w, r, ErrCodeInternal, "Failed to check active streams")
 		return
 	}
 
@@ -710,6 +1511,7 @@ func (h *SceneHandlers) ListOwnedScenes(w http.ResponseWriter, r *http.Request)
 			CreatedAt:       sc.CreatedAt,
 			UpdatedAt:       sc.UpdatedAt,
 			MembersCount:    membershipCounts[sc.ID], // Defaults to 0 if not in map
+			FollowersCount:  followerCounts[sc.ID],
 			HasActiveStream: activeStreams[sc.ID],     // Defaults to false if not in map
 		}
 		summaries = append(summaries, summary)
@@ -723,3 +1525,288 @@ func (h *SceneHandlers) ListOwnedScenes(w http.ResponseWriter, r *http.Request)
 		return
 	}
 }
+
+// Scene discovery limits and defaults.
+const (
+	DefaultDiscoverLimit        = 20
+	MaxDiscoverLimit            = 100
+	MaxDiscoverRadiusMeters     = 100000
+	DefaultDiscoveryTrustWeight = 0.5
+)
+
+// Scene discovery sort modes.
+const (
+	DiscoverSortDistance = "distance"
+	DiscoverSortTrust    = "trust"
+	DiscoverSortBlended  = "blended"
+)
+
+// Scene discovery tag match modes.
+const (
+	DiscoverTagMatchAny = "any"
+	DiscoverTagMatchAll = "all"
+)
+
+// DiscoveredScene is a scene returned by discovery together with its ranking inputs.
+type DiscoveredScene struct {
+	*scene.Scene
+	DistanceMeters float64 `json:"distance_meters"`
+	TrustScore     float64 `json:"trust_score"`
+	RankScore      float64 `json:"rank_score"`
+}
+
+// DiscoverScenesResponse represents the response for the scene discovery endpoint.
+type DiscoverScenesResponse struct {
+	Scenes []DiscoveredScene `json:"scenes"`
+}
+
+// DiscoverScenes handles GET /scenes/discover - finds scenes near a point and
+// ranks them by proximity, trust, or a blend of both.
+// Query parameters: lat, lng, radius_m (required), sort, limit, tags, and
+// tag_match (optional). tags is a comma-separated list; with tag_match=any (the
+// default) scenes carrying at least one tag match, with tag_match=all scenes
+// must carry every tag.
+//
+// Ranking formula for sort=blended (the default):
+//
+//	proximity = 1 - distance_meters / radius_m    (0 at the edge, 1 at the center)
+//	trust     = clamp(trust_score, 0, 1)          (0 when no score is recorded)
+//	rank      = (1 - w) * proximity + w * trust
+//
+// where w is the configured discovery trust weight. sort=distance orders by
+// distance ascending and sort=trust by trust descending; every mode breaks
+// ties by distance and then scene ID. Distance is measured from the coarse
+// geohash cell center, never the precise point. Hidden scenes and scenes the
+// caller cannot access are never returned.
+func (h *SceneHandlers) DiscoverScenes(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if query.Get("lat") == "" || query.Get("lng") == "" || query.Get("radius_m") == "" {
+		writeError(w, r, ErrCodeValidation, "lat, lng, and radius_m parameters are required")
+		return
+	}
+
+	lat, err := parseFloat(query.Get("lat"), "lat")
+	if err != nil {
+		writeError(w, r, ErrCodeValidation, err.Error())
+		return
+	}
+	lng, err := parseFloat(query.Get("lng"), "lng")
+	if err != nil {
+		writeError(w, r, ErrCodeValidation, err.Error())
+		return
+	}
+	if lat < -90 || lat > 90 {
+		writeError(w, r, ErrCodeValidation, "latitude must be between -90 and 90")
+		return
+	}
+	if lng < -180 || lng > 180 {
+		writeError(w, r, ErrCodeValidation, "longitude must be between -180 and 180")
+		return
+	}
+
+	radius, err := parseFloat(query.Get("radius_m"), "radius_m")
+	if err != nil {
+		writeError(w, r, ErrCodeValidation, err.Error())
+		return
+	}
+	if radius <= 0 || radius > MaxDiscoverRadiusMeters {
+		writeErrorf(w, r, ErrCodeValidation, "radius_m must be greater than 0 and at most %d", MaxDiscoverRadiusMeters)
+		return
+	}
+
+	sortMode := query.Get("sort")
+	switch sortMode {
+	case "":
+		sortMode = DiscoverSortBlended
+	case DiscoverSortDistance, DiscoverSortTrust, DiscoverSortBlended:
+	default:
+		writeError(w, r, ErrCodeValidation, "sort must be one of: distance, trust, blended")
+		return
+	}
+
+	limit := DefaultDiscoverLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsedLimit, err := parseIntInRange(limitStr, "limit", 1, MaxDiscoverLimit)
+		if err != nil {
+			writeError(w, r, ErrCodeValidation, err.Error())
+			return
+		}
+		limit = parsedLimit
+	}
+
+	tags := scene.NormalizeTags(strings.Split(query.Get("tags"), ","))
+	if err := scene.ValidateTags(tags); err != nil {
+		writeError(w, r, ErrCodeValidation, err.Error())
+		return
+	}
+	tagMatch := query.Get("tag_match")
+	switch tagMatch {
+	case "":
+		tagMatch = DiscoverTagMatchAny
+	case DiscoverTagMatchAny, DiscoverTagMatchAll:
+	default:
+		writeError(w, r, ErrCodeValidation, "tag_match must be one of: any, all")
+		return
+	}
+
+	center := geo.Point{Lat: lat, Lng: lng}
+	requesterDID := middleware.GetUserDID(r.Context())
+
+	// Rank across every candidate in range so trust ordering is not cut off
+	// by a distance-ordered limit
+	candidates, err := h.repo.FindNearby(r.Context(), center, radius, 0)
+	if err != nil {
+		if writeContextError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to find nearby scenes", "error", err)
+		writeError(w, r, ErrCodeInternal, "Failed to discover scenes")
+		return
+	}
+
+	// Narrow the candidates to scenes matching the tag filter
+	if len(tags) > 0 {
+		tagged, err := h.repo.FindByTags(r.Context(), tags, tagMatch == DiscoverTagMatchAll, 0)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to find scenes by tags", "error", err)
+			writeError(w, r, ErrCodeInternal, "Failed to discover scenes")
+			return
+		}
+		taggedIDs := make(map[string]bool, len(tagged))
+		for _, sc := range tagged {
+			taggedIDs[sc.ID] = true
+		}
+		matching := candidates[:0]
+		for _, sc := range candidates {
+			if taggedIDs[sc.ID] {
+				matching = append(matching, sc)
+			}
+		}
+		candidates = matching
+	}
+
+	anonymizer := h.newLocationAnonymizer()
+	discovered := make([]DiscoveredScene, 0, len(candidates))
+	for _, sc := range candidates {
+		viewerLevel, err := h.sceneViewerLevel(r.Context(), sc, requesterDID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", sc.ID)
+			writeError(w, r, ErrCodeInternal, "Failed to check access permissions")
+			return
+		}
+		if sc.Visibility == scene.VisibilityHidden || !canViewScene(r.Context(), sc, viewerLevel) {
+			continue
+		}
+
+		// Sparse cells are widened, and distance is then measured from the
+		// widened cell so it cannot narrow the location back down
+		visibleHash, err := anonymizer.coarseGeohash(r.Context(), sc, viewerLevel)
+		if err != nil {
+			if writeContextError(w, r, err) {
+				return
+			}
+			slog.ErrorContext(r.Context(), "failed to anonymize scene location", "error", err, "scene_id", sc.ID)
+			writeError(w, r, ErrCodeInternal, "Failed to discover scenes")
+			return
+		}
+		if visibleHash == "" {
+			continue
+		}
+		distanceHash := sc.CoarseGeohash
+		if len(visibleHash) < len(geo.RoundGeohash(sc.CoarseGeohash, geo.PrecisionForVisibility(viewerLevel))) {
+			distanceHash = visibleHash
+		}
+		cellCenter, ok := geo.Decode(distanceHash)
+		if !ok {
+			continue
+		}
+		distance := geo.DistanceMeters(center, cellCenter)
+		if distance > radius {
+			continue
+		}
+
+		trustScore, err := h.sceneTrustScore(sc.ID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to get scene trust score", "error", err, "scene_id", sc.ID)
+			writeError(w, r, ErrCodeInternal, "Failed to discover scenes")
+			return
+		}
+
+		sc.CoarseGeohash = visibleHash
+		discovered = append(discovered, DiscoveredScene{
+			Scene:          sc,
+			DistanceMeters: distance,
+			TrustScore:     trustScore,
+			RankScore:      discoveryRankScore(sortMode, distance, radius, trustScore, h.discoveryTrustWeight),
+		})
+	}
+
+	sortDiscoveredScenes(discovered, sortMode)
+	if len(discovered) > limit {
+		discovered = discovered[:limit]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(DiscoverScenesResponse{Scenes: discovered}); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode discover scenes response", "error", err)
+	}
+}
+
+// sceneTrustScore returns the stored trust score for a scene clamped to [0, 1].
+// Scenes without a recorded score, or handlers without a trust store, score zero.
+func (h *SceneHandlers) sceneTrustScore(sceneID string) (float64, error) {
+	if h.trustStore == nil {
+		return 0, nil
+	}
+	score, err := h.trustStore.GetScore(sceneID)
+	if err != nil {
+		return 0, err
+	}
+	if score == nil {
+		return 0, nil
+	}
+	return clampUnit(score.Score), nil
+}
+
+// discoveryRankScore computes the rank score reported for a discovered scene.
+// See DiscoverScenes for the formula.
+func discoveryRankScore(sortMode string, distance, radius, trustScore, trustWeight float64) float64 {
+	proximity := clampUnit(1 - distance/radius)
+	switch sortMode {
+	case DiscoverSortDistance:
+		return proximity
+	case DiscoverSortTrust:
+		return trustScore
+	default:
+		return (1-trustWeight)*proximity + trustWeight*trustScore
+	}
+}
+
+// sortDiscoveredScenes orders discovered scenes for the given sort mode.
+// Distance mode sorts by distance ascending; trust and blended modes sort by
+// rank score descending, falling back to distance and then scene ID.
+func sortDiscoveredScenes(scenes []DiscoveredScene, sortMode string) {
+	sort.SliceStable(scenes, func(i, j int) bool {
+		a, b := scenes[i], scenes[j]
+		if sortMode != DiscoverSortDistance && a.RankScore != b.RankScore {
+			return a.RankScore > b.RankScore
+		}
+		if a.DistanceMeters != b.DistanceMeters {
+			return a.DistanceMeters < b.DistanceMeters
+		}
+		return a.ID < b.ID
+	})
+}
+
+// clampUnit restricts v to the range [0, 1].
+func clampUnit(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
@@ -2,17 +2,25 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/geo"
 	"github.com/onnwee/subcults/internal/membership"
 	"github.com/onnwee/subcults/internal/middleware"
 	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/search"
 	"github.com/onnwee/subcults/internal/stream"
+	"github.com/onnwee/subcults/internal/trust"
 )
 
 // TestCreateScene_Success tests successful scene creation.
@@ -108,6 +116,52 @@ func TestCreateScene_DefaultVisibility(t *testing.T) {
 	}
 }
 
+// TestCreateScene_BodyTooLarge tests that oversized bodies are rejected with 413,
+// distinct from malformed JSON.
+func TestCreateScene_BodyTooLarge(t *testing.T) {
+	oversized := fmt.Sprintf(`{"name":"Test Scene","owner_did":"did:plc:test123","coarse_geohash":"dr5regw","description":%q}`, strings.Repeat("a", 256))
+
+	tests := []struct {
+		name          string
+		body          string
+		contentLength int64
+		wantStatus    int
+		wantCode      string
+	}{
+		{"oversized with content length", oversized, int64(len(oversized)), http.StatusRequestEntityTooLarge, ErrCodePayloadTooLarge},
+		{"oversized without content length", oversized, -1, http.StatusRequestEntityTooLarge, ErrCodePayloadTooLarge},
+		{"malformed JSON within limit", `{"name":`, -1, http.StatusBadRequest, ErrCodeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := scene.NewInMemorySceneRepository()
+			membershipRepo := membership.NewInMemoryMembershipRepository()
+			streamRepo := stream.NewInMemorySessionRepository()
+			handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
+			handler := middleware.MaxBodyBytes(128)(http.HandlerFunc(handlers.CreateScene))
+
+			req := httptest.NewRequest(http.MethodPost, "/scenes", strings.NewReader(tt.body))
+			req.ContentLength = tt.contentLength
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != tt.wantCode {
+				t.Errorf("expected error code %q, got %q", tt.wantCode, errResp.Error.Code)
+			}
+		})
+	}
+}
+
 // TestCreateScene_PrivacyEnforcement tests that privacy is enforced on creation.
 func TestCreateScene_PrivacyEnforcement(t *testing.T) {
 	repo := scene.NewInMemorySceneRepository()
@@ -394,7 +448,7 @@ func TestUpdateScene_Success(t *testing.T) {
 	// Create a scene first
 	now := time.Now()
 	originalScene := &scene.Scene{
-		ID:            "test-scene-id",
+		ID:            "11111111-1111-4111-8111-111111111111",
 		Name:          "Original Name",
 		Description:   "Original description",
 		OwnerDID:      "did:plc:test123",
@@ -403,7 +457,7 @@ func TestUpdateScene_Success(t *testing.T) {
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
-	if err := repo.Insert(originalScene); err != nil {
+	if err := repo.Insert(t.Context(), originalScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -419,7 +473,7 @@ func TestUpdateScene_Success(t *testing.T) {
 	}
 
 	body, _ := json.Marshal(updateReq)
-	req := httptest.NewRequest(http.MethodPatch, "/scenes/test-scene-id", bytes.NewReader(body))
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/11111111-1111-4111-8111-111111111111", bytes.NewReader(body))
 	w := httptest.NewRecorder()
 
 	handlers.UpdateScene(w, req)
@@ -463,7 +517,7 @@ func TestUpdateScene_NotFound(t *testing.T) {
 	}
 
 	body, _ := json.Marshal(updateReq)
-	req := httptest.NewRequest(http.MethodPatch, "/scenes/nonexistent-id", bytes.NewReader(body))
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/99999999-9999-4999-8999-999999999992", bytes.NewReader(body))
 	w := httptest.NewRecorder()
 
 	handlers.UpdateScene(w, req)
@@ -482,6 +536,123 @@ func TestUpdateScene_NotFound(t *testing.T) {
 	}
 }
 
+// TestUpdateScene_IfMatch tests optimistic concurrency: a conditional update with
+// the ETag from GetScene succeeds and bumps the version, and a second update using
+// the now-stale ETag is rejected with 412.
+func TestUpdateScene_IfMatch(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
+
+	now := time.Now()
+	if err := repo.Insert(t.Context(), &scene.Scene{
+		ID:            "11111111-1111-4111-8111-111111111111",
+		Name:          "Original Name",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+		Visibility:    "public",
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	// Both editors fetch the same version
+	getReq := httptest.NewRequest(http.MethodGet, "/scenes/11111111-1111-4111-8111-111111111111", nil)
+	getW := httptest.NewRecorder()
+	handlers.GetScene(getW, getReq)
+	// A lone scene's cell is withheld from the public, so the ETag carries
+	// only the version; If-Match still compares against it
+	etag := getW.Header().Get("ETag")
+	if etag != `"1"` {
+		t.Fatalf("expected ETag \"1\", got %q", etag)
+	}
+
+	// First editor's conditional update succeeds
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/11111111-1111-4111-8111-111111111111", strings.NewReader(`{"name":"First Edit"}`))
+	req.Header.Set("If-Match", etag)
+	w := httptest.NewRecorder()
+	handlers.UpdateScene(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("ETag"); got != `"2-dr5regw"` {
+		t.Errorf("expected updated ETag \"2-dr5regw\", got %q", got)
+	}
+	var updated scene.Scene
+	if err := json.NewDecoder(w.Body).Decode(&updated); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if updated.Version != 2 {
+		t.Errorf("expected version 2, got %d", updated.Version)
+	}
+
+	// Second editor still holds the stale ETag
+	req = httptest.NewRequest(http.MethodPatch, "/scenes/11111111-1111-4111-8111-111111111111", strings.NewReader(`{"name":"Second Edit"}`))
+	req.Header.Set("If-Match", etag)
+	w = httptest.NewRecorder()
+	handlers.UpdateScene(w, req)
+
+	if w.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected status 412, got %d", w.Code)
+	}
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodePreconditionFailed {
+		t.Errorf("expected error code %s, got %s", ErrCodePreconditionFailed, errResp.Error.Code)
+	}
+
+	stored, err := repo.GetByID(t.Context(), "11111111-1111-4111-8111-111111111111")
+	if err != nil {
+		t.Fatalf("failed to get scene: %v", err)
+	}
+	if stored.Name != "First Edit" {
+		t.Errorf("expected stale update to be rejected, got name %q", stored.Name)
+	}
+	if stored.Version != 2 {
+		t.Errorf("expected version to stay 2, got %d", stored.Version)
+	}
+
+	// Updates without If-Match still apply and bump the version
+	req = httptest.NewRequest(http.MethodPatch, "/scenes/11111111-1111-4111-8111-111111111111", strings.NewReader(`{"name":"Unconditional"}`))
+	w = httptest.NewRecorder()
+	handlers.UpdateScene(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 without If-Match, got %d", w.Code)
+	}
+	if got := w.Header().Get("ETag"); got != `"3-dr5regw"` {
+		t.Errorf("expected ETag \"3-dr5regw\", got %q", got)
+	}
+}
+
+func TestIfMatchSatisfied(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{`"2"`, true},
+		{`"2-dr5re"`, true},
+		{`"2-dr5regw"`, true},
+		{`"1", "2"`, true},
+		{`*`, true},
+		{`"1"`, false},
+		{`"1-dr5re"`, false},
+		{`"22-dr5re"`, false},
+		{`W/"2"`, false}, // If-Match uses strong comparison
+		{`2`, false},
+		{`"`, false},
+	}
+	for _, tt := range tests {
+		if got := ifMatchSatisfied(tt.header, 2); got != tt.want {
+			t.Errorf("ifMatchSatisfied(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
 // TestUpdateScene_DuplicateName tests updating to a duplicate name.
 func TestUpdateScene_DuplicateName(t *testing.T) {
 	repo := scene.NewInMemorySceneRepository()
@@ -493,34 +664,34 @@ func TestUpdateScene_DuplicateName(t *testing.T) {
 
 	// Create first scene
 	scene1 := &scene.Scene{
-		ID:            "scene-1",
+		ID:            "00000000-0000-4000-8000-000000000001",
 		Name:          "Scene One",
 		OwnerDID:      "did:plc:test123",
 		CoarseGeohash: "dr5regw",
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
-	repo.Insert(scene1)
+	repo.Insert(t.Context(), scene1)
 
 	// Create second scene
 	scene2 := &scene.Scene{
-		ID:            "scene-2",
+		ID:            "00000000-0000-4000-8000-000000000002",
 		Name:          "Scene Two",
 		OwnerDID:      "did:plc:test123",
 		CoarseGeohash: "dr5regw",
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
-	repo.Insert(scene2)
+	repo.Insert(t.Context(), scene2)
 
-	// Try to update scene-2 to have the same name as scene-1
+	// Try to update 00000000-0000-4000-8000-000000000002 to have the same name as 00000000-0000-4000-8000-000000000001
 	newName := "Scene One"
 	updateReq := UpdateSceneRequest{
 		Name: &newName,
 	}
 
 	body, _ := json.Marshal(updateReq)
-	req := httptest.NewRequest(http.MethodPatch, "/scenes/scene-2", bytes.NewReader(body))
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/00000000-0000-4000-8000-000000000002", bytes.NewReader(body))
 	w := httptest.NewRecorder()
 
 	handlers.UpdateScene(w, req)
@@ -548,16 +719,16 @@ func TestDeleteScene_Success(t *testing.T) {
 
 	now := time.Now()
 	testScene := &scene.Scene{
-		ID:            "test-scene-id",
+		ID:            "11111111-1111-4111-8111-111111111111",
 		Name:          "Test Scene",
 		OwnerDID:      "did:plc:test123",
 		CoarseGeohash: "dr5regw",
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
-	repo.Insert(testScene)
+	repo.Insert(t.Context(), testScene)
 
-	req := httptest.NewRequest(http.MethodDelete, "/scenes/test-scene-id", nil)
+	req := httptest.NewRequest(http.MethodDelete, "/scenes/11111111-1111-4111-8111-111111111111", nil)
 	w := httptest.NewRecorder()
 
 	handlers.DeleteScene(w, req)
@@ -567,7 +738,7 @@ func TestDeleteScene_Success(t *testing.T) {
 	}
 
 	// Verify scene is soft-deleted (returns ErrSceneDeleted on get)
-	_, err := repo.GetByID("test-scene-id")
+	_, err := repo.GetByID(t.Context(), "11111111-1111-4111-8111-111111111111")
 	if err != scene.ErrSceneDeleted {
 		t.Errorf("expected scene to be soft-deleted and return ErrSceneDeleted, got: %v", err)
 	}
@@ -580,7 +751,7 @@ func TestDeleteScene_NotFound(t *testing.T) {
 	streamRepo := stream.NewInMemorySessionRepository()
 	handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
 
-	req := httptest.NewRequest(http.MethodDelete, "/scenes/nonexistent-id", nil)
+	req := httptest.NewRequest(http.MethodDelete, "/scenes/99999999-9999-4999-8999-999999999992", nil)
 	w := httptest.NewRecorder()
 
 	handlers.DeleteScene(w, req)
@@ -608,20 +779,20 @@ func TestDeleteScene_AlreadyDeleted(t *testing.T) {
 
 	now := time.Now()
 	testScene := &scene.Scene{
-		ID:            "test-scene-id",
+		ID:            "11111111-1111-4111-8111-111111111111",
 		Name:          "Test Scene",
 		OwnerDID:      "did:plc:test123",
 		CoarseGeohash: "dr5regw",
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
-	repo.Insert(testScene)
+	repo.Insert(t.Context(), testScene)
 
 	// Delete once
-	repo.Delete("test-scene-id")
+	repo.Delete(t.Context(), "11111111-1111-4111-8111-111111111111")
 
 	// Try to delete again
-	req := httptest.NewRequest(http.MethodDelete, "/scenes/test-scene-id", nil)
+	req := httptest.NewRequest(http.MethodDelete, "/scenes/11111111-1111-4111-8111-111111111111", nil)
 	w := httptest.NewRecorder()
 
 	handlers.DeleteScene(w, req)
@@ -707,7 +878,7 @@ membershipRepo := membership.NewInMemoryMembershipRepository()
 // Create a scene first
 now := time.Now()
 testScene := &scene.Scene{
-ID:            "test-scene-id",
+ID:            "11111111-1111-4111-8111-111111111111",
 Name:          "Test Scene",
 OwnerDID:      "did:plc:test123",
 CoarseGeohash: "dr5regw",
@@ -715,7 +886,7 @@ Visibility:    "public",
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(testScene); err != nil {
+if err := repo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert test scene: %v", err)
 }
 
@@ -735,7 +906,7 @@ if err != nil {
 t.Fatalf("failed to marshal request: %v", err)
 }
 
-req := httptest.NewRequest(http.MethodPatch, "/scenes/test-scene-id/palette", bytes.NewReader(body))
+req := httptest.NewRequest(http.MethodPatch, "/scenes/11111111-1111-4111-8111-111111111111/palette", bytes.NewReader(body))
 req.Header.Set("Content-Type", "application/json")
 // Add authentication context
 ctx := middleware.SetUserDID(req.Context(), "did:plc:test123")
@@ -764,6 +935,102 @@ t.Errorf("expected text color #000000, got %s", updatedScene.Palette.Text)
 }
 }
 
+// TestUpdateScenePalette_DeletedScene tests that a deleted scene's palette
+// can't be updated and reads as not found.
+func TestUpdateScenePalette_DeletedScene(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+	if err := repo.Insert(t.Context(), &scene.Scene{ID: "scene-1", Name: "Test Scene", OwnerDID: "did:plc:test123", CoarseGeohash: "dr5regw"}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+	if err := repo.Delete(t.Context(), "scene-1"); err != nil {
+		t.Fatalf("failed to delete scene: %v", err)
+	}
+
+	body := `{"palette":{"primary":"#ff0000","secondary":"#00ff00","accent":"#0000ff","background":"#ffffff","text":"#000000"}}`
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/scene-1/palette", strings.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+
+	handlers.UpdateScenePalette(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeSceneDeleted {
+		t.Errorf("expected error code %q, got %q", ErrCodeSceneDeleted, errResp.Error.Code)
+	}
+}
+
+// TestUpdateScenePalette_ReturnsStoredScene tests that the response and ETag
+// carry the version written by the update, so the client can send it back.
+func TestUpdateScenePalette_ReturnsStoredScene(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+	if err := repo.Insert(t.Context(), &scene.Scene{ID: "scene-1", Name: "Test Scene", OwnerDID: "did:plc:test123", CoarseGeohash: "dr5regw"}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	body := `{"palette":{"primary":"#ff0000","secondary":"#00ff00","accent":"#0000ff","background":"#ffffff","text":"#000000"}}`
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/scene-1/palette", strings.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+
+	handlers.UpdateScenePalette(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp scene.Scene
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	stored, err := repo.GetByID(t.Context(), "scene-1")
+	if err != nil {
+		t.Fatalf("failed to get scene: %v", err)
+	}
+	if resp.Version != stored.Version || stored.Version != 2 {
+		t.Errorf("expected response version to match stored version 2, got %d (stored %d)", resp.Version, stored.Version)
+	}
+	if got, want := w.Header().Get("ETag"), sceneETag(stored); got != want {
+		t.Errorf("expected ETag %s, got %s", want, got)
+	}
+}
+
+// TestUpdateScenePalette_VersionConflict tests that a palette update racing
+// another edit returns 409 instead of 500.
+func TestUpdateScenePalette_VersionConflict(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(racingSceneRepository{repo}, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+	if err := repo.Insert(t.Context(), &scene.Scene{ID: "scene-1", Name: "Test Scene", OwnerDID: "did:plc:test123", CoarseGeohash: "dr5regw"}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	body := `{"palette":{"primary":"#ff0000","secondary":"#00ff00","accent":"#0000ff","background":"#ffffff","text":"#000000"}}`
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/scene-1/palette", strings.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+
+	handlers.UpdateScenePalette(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d: %s", w.Code, w.Body.String())
+	}
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeConflict {
+		t.Errorf("expected error code %q, got %q", ErrCodeConflict, errResp.Error.Code)
+	}
+}
+
 // TestUpdateScenePalette_InvalidHexColor tests rejection of invalid hex colors.
 func TestUpdateScenePalette_InvalidHexColor(t *testing.T) {
 repo := scene.NewInMemorySceneRepository()
@@ -774,7 +1041,7 @@ membershipRepo := membership.NewInMemoryMembershipRepository()
 // Create a scene first
 now := time.Now()
 testScene := &scene.Scene{
-ID:            "test-scene-id",
+ID:            "11111111-1111-4111-8111-111111111111",
 Name:          "Test Scene",
 OwnerDID:      "did:plc:test123",
 CoarseGeohash: "dr5regw",
@@ -782,7 +1049,7 @@ Visibility:    "public",
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(testScene); err != nil {
+if err := repo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert test scene: %v", err)
 }
 
@@ -814,11 +1081,11 @@ Text:       "#000000",
 wantErr: "secondary color",
 },
 {
-name: "too short accent color",
+name: "four-digit accent color",
 palette: scene.Palette{
 Primary:    "#ff0000",
 Secondary:  "#00ff00",
-Accent:     "#00f",
+Accent:     "#00ff",
 Background: "#ffffff",
 Text:       "#000000",
 },
@@ -856,7 +1123,7 @@ if err != nil {
 t.Fatalf("failed to marshal request: %v", err)
 }
 
-req := httptest.NewRequest(http.MethodPatch, "/scenes/test-scene-id/palette", bytes.NewReader(body))
+req := httptest.NewRequest(http.MethodPatch, "/scenes/11111111-1111-4111-8111-111111111111/palette", bytes.NewReader(body))
 req.Header.Set("Content-Type", "application/json")
 w := httptest.NewRecorder()
 // Add authentication context
@@ -895,7 +1162,7 @@ membershipRepo := membership.NewInMemoryMembershipRepository()
 // Create a scene first
 now := time.Now()
 testScene := &scene.Scene{
-ID:            "test-scene-id",
+ID:            "11111111-1111-4111-8111-111111111111",
 Name:          "Test Scene",
 OwnerDID:      "did:plc:test123",
 CoarseGeohash: "dr5regw",
@@ -903,7 +1170,7 @@ Visibility:    "public",
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(testScene); err != nil {
+if err := repo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert test scene: %v", err)
 }
 
@@ -946,7 +1213,7 @@ if err != nil {
 t.Fatalf("failed to marshal request: %v", err)
 }
 
-req := httptest.NewRequest(http.MethodPatch, "/scenes/test-scene-id/palette", bytes.NewReader(body))
+req := httptest.NewRequest(http.MethodPatch, "/scenes/11111111-1111-4111-8111-111111111111/palette", bytes.NewReader(body))
 req.Header.Set("Content-Type", "application/json")
 // Add authentication context
 ctx := middleware.SetUserDID(req.Context(), "did:plc:test123")
@@ -985,7 +1252,7 @@ membershipRepo := membership.NewInMemoryMembershipRepository()
 // Create a scene first
 now := time.Now()
 testScene := &scene.Scene{
-ID:            "test-scene-id",
+ID:            "11111111-1111-4111-8111-111111111111",
 Name:          "Test Scene",
 OwnerDID:      "did:plc:test123",
 CoarseGeohash: "dr5regw",
@@ -993,7 +1260,7 @@ Visibility:    "public",
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(testScene); err != nil {
+if err := repo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert test scene: %v", err)
 }
 
@@ -1012,7 +1279,7 @@ if err != nil {
 t.Fatalf("failed to marshal request: %v", err)
 }
 
-req := httptest.NewRequest(http.MethodPatch, "/scenes/test-scene-id/palette", bytes.NewReader(body))
+req := httptest.NewRequest(http.MethodPatch, "/scenes/11111111-1111-4111-8111-111111111111/palette", bytes.NewReader(body))
 req.Header.Set("Content-Type", "application/json")
 // Add authentication context
 ctx := middleware.SetUserDID(req.Context(), "did:plc:test123")
@@ -1057,7 +1324,7 @@ if err != nil {
 t.Fatalf("failed to marshal request: %v", err)
 }
 
-req := httptest.NewRequest(http.MethodPatch, "/scenes/nonexistent-id/palette", bytes.NewReader(body))
+req := httptest.NewRequest(http.MethodPatch, "/scenes/99999999-9999-4999-8999-999999999992/palette", bytes.NewReader(body))
 req.Header.Set("Content-Type", "application/json")
 // Add authentication context
 ctx := middleware.SetUserDID(req.Context(), "did:plc:test123")
@@ -1090,7 +1357,7 @@ membershipRepo := membership.NewInMemoryMembershipRepository()
 // Create a scene first
 now := time.Now()
 testScene := &scene.Scene{
-ID:            "test-scene-id",
+ID:            "11111111-1111-4111-8111-111111111111",
 Name:          "Test Scene",
 OwnerDID:      "did:plc:owner",
 CoarseGeohash: "dr5regw",
@@ -1098,7 +1365,7 @@ Visibility:    "public",
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(testScene); err != nil {
+if err := repo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert test scene: %v", err)
 }
 
@@ -1117,7 +1384,7 @@ if err != nil {
 t.Fatalf("failed to marshal request: %v", err)
 }
 
-req := httptest.NewRequest(http.MethodPatch, "/scenes/test-scene-id/palette", bytes.NewReader(body))
+req := httptest.NewRequest(http.MethodPatch, "/scenes/11111111-1111-4111-8111-111111111111/palette", bytes.NewReader(body))
 req.Header.Set("Content-Type", "application/json")
 // No authentication context provided
 w := httptest.NewRecorder()
@@ -1148,7 +1415,7 @@ membershipRepo := membership.NewInMemoryMembershipRepository()
 // Create a scene owned by a different user
 now := time.Now()
 testScene := &scene.Scene{
-ID:            "test-scene-id",
+ID:            "11111111-1111-4111-8111-111111111111",
 Name:          "Test Scene",
 OwnerDID:      "did:plc:owner",
 CoarseGeohash: "dr5regw",
@@ -1156,7 +1423,7 @@ Visibility:    "public",
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(testScene); err != nil {
+if err := repo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert test scene: %v", err)
 }
 
@@ -1175,7 +1442,7 @@ if err != nil {
 t.Fatalf("failed to marshal request: %v", err)
 }
 
-req := httptest.NewRequest(http.MethodPatch, "/scenes/test-scene-id/palette", bytes.NewReader(body))
+req := httptest.NewRequest(http.MethodPatch, "/scenes/11111111-1111-4111-8111-111111111111/palette", bytes.NewReader(body))
 req.Header.Set("Content-Type", "application/json")
 // Authenticate as a different user
 ctx := middleware.SetUserDID(req.Context(), "did:plc:different-user")
@@ -1208,7 +1475,7 @@ streamRepo := stream.NewInMemorySessionRepository()
 // Create a public scene
 now := time.Now()
 testScene := &scene.Scene{
-ID:            "public-scene-id",
+ID:            "55555555-5555-4555-8555-555555555555",
 Name:          "Public Scene",
 OwnerDID:      "did:plc:owner",
 CoarseGeohash: "dr5regw",
@@ -1216,12 +1483,12 @@ Visibility:    scene.VisibilityPublic,
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(testScene); err != nil {
+if err := repo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert test scene: %v", err)
 }
 
 // Test access by unauthenticated user
-req := httptest.NewRequest(http.MethodGet, "/scenes/public-scene-id", nil)
+req := httptest.NewRequest(http.MethodGet, "/scenes/55555555-5555-4555-8555-555555555555", nil)
 w := httptest.NewRecorder()
 
 handlers.GetScene(w, req)
@@ -1235,12 +1502,12 @@ if err := json.NewDecoder(w.Body).Decode(&retrievedScene); err != nil {
 t.Fatalf("failed to decode response: %v", err)
 }
 
-if retrievedScene.ID != "public-scene-id" {
-t.Errorf("expected scene ID 'public-scene-id', got %s", retrievedScene.ID)
+if retrievedScene.ID != "55555555-5555-4555-8555-555555555555" {
+t.Errorf("expected scene ID '55555555-5555-4555-8555-555555555555', got %s", retrievedScene.ID)
 }
 
 // Test access by different authenticated user
-req2 := httptest.NewRequest(http.MethodGet, "/scenes/public-scene-id", nil)
+req2 := httptest.NewRequest(http.MethodGet, "/scenes/55555555-5555-4555-8555-555555555555", nil)
 ctx := middleware.SetUserDID(req2.Context(), "did:plc:other-user")
 req2 = req2.WithContext(ctx)
 w2 := httptest.NewRecorder()
@@ -1262,7 +1529,7 @@ streamRepo := stream.NewInMemorySessionRepository()
 // Create a members-only scene
 now := time.Now()
 testScene := &scene.Scene{
-ID:            "members-scene-id",
+ID:            "44444444-4444-4444-8444-444444444444",
 Name:          "Members Only Scene",
 OwnerDID:      "did:plc:owner",
 CoarseGeohash: "dr5regw",
@@ -1270,12 +1537,12 @@ Visibility:    scene.VisibilityMembersOnly,
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(testScene); err != nil {
+if err := repo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert test scene: %v", err)
 }
 
 // Test access by non-member (unauthenticated)
-req := httptest.NewRequest(http.MethodGet, "/scenes/members-scene-id", nil)
+req := httptest.NewRequest(http.MethodGet, "/scenes/44444444-4444-4444-8444-444444444444", nil)
 w := httptest.NewRecorder()
 
 handlers.GetScene(w, req)
@@ -1295,7 +1562,7 @@ t.Errorf("expected error code %s, got %s", ErrCodeNotFound, errResp.Error.Code)
 }
 
 // Test access by authenticated non-member
-req2 := httptest.NewRequest(http.MethodGet, "/scenes/members-scene-id", nil)
+req2 := httptest.NewRequest(http.MethodGet, "/scenes/44444444-4444-4444-8444-444444444444", nil)
 ctx := middleware.SetUserDID(req2.Context(), "did:plc:non-member")
 req2 = req2.WithContext(ctx)
 w2 := httptest.NewRecorder()
@@ -1318,7 +1585,7 @@ streamRepo := stream.NewInMemorySessionRepository()
 // Create a members-only scene
 now := time.Now()
 testScene := &scene.Scene{
-ID:            "members-scene-id",
+ID:            "44444444-4444-4444-8444-444444444444",
 Name:          "Members Only Scene",
 OwnerDID:      "did:plc:owner",
 CoarseGeohash: "dr5regw",
@@ -1326,22 +1593,22 @@ Visibility:    scene.VisibilityMembersOnly,
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(testScene); err != nil {
+if err := repo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert test scene: %v", err)
 }
 
 // Create an active membership
 activeMembership := &membership.Membership{
-SceneID: "members-scene-id",
+SceneID: "44444444-4444-4444-8444-444444444444",
 UserDID: "did:plc:active-member",
 Status:  "active",
 }
-if _, err := membershipRepo.Upsert(activeMembership); err != nil {
+if _, err := membershipRepo.Upsert(t.Context(), activeMembership); err != nil {
 t.Fatalf("failed to create membership: %v", err)
 }
 
 // Test access by active member
-req := httptest.NewRequest(http.MethodGet, "/scenes/members-scene-id", nil)
+req := httptest.NewRequest(http.MethodGet, "/scenes/44444444-4444-4444-8444-444444444444", nil)
 ctx := middleware.SetUserDID(req.Context(), "did:plc:active-member")
 req = req.WithContext(ctx)
 w := httptest.NewRecorder()
@@ -1357,8 +1624,8 @@ if err := json.NewDecoder(w.Body).Decode(&retrievedScene); err != nil {
 t.Fatalf("failed to decode response: %v", err)
 }
 
-if retrievedScene.ID != "members-scene-id" {
-t.Errorf("expected scene ID 'members-scene-id', got %s", retrievedScene.ID)
+if retrievedScene.ID != "44444444-4444-4444-8444-444444444444" {
+t.Errorf("expected scene ID '44444444-4444-4444-8444-444444444444', got %s", retrievedScene.ID)
 }
 }
 
@@ -1372,7 +1639,7 @@ streamRepo := stream.NewInMemorySessionRepository()
 // Create a members-only scene
 now := time.Now()
 testScene := &scene.Scene{
-ID:            "members-scene-id",
+ID:            "44444444-4444-4444-8444-444444444444",
 Name:          "Members Only Scene",
 OwnerDID:      "did:plc:owner",
 CoarseGeohash: "dr5regw",
@@ -1380,22 +1647,22 @@ Visibility:    scene.VisibilityMembersOnly,
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(testScene); err != nil {
+if err := repo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert test scene: %v", err)
 }
 
 // Create a pending membership
 pendingMembership := &membership.Membership{
-SceneID: "members-scene-id",
+SceneID: "44444444-4444-4444-8444-444444444444",
 UserDID: "did:plc:pending-member",
 Status:  "pending",
 }
-if _, err := membershipRepo.Upsert(pendingMembership); err != nil {
+if _, err := membershipRepo.Upsert(t.Context(), pendingMembership); err != nil {
 t.Fatalf("failed to create membership: %v", err)
 }
 
 // Test access by pending member (should be denied)
-req := httptest.NewRequest(http.MethodGet, "/scenes/members-scene-id", nil)
+req := httptest.NewRequest(http.MethodGet, "/scenes/44444444-4444-4444-8444-444444444444", nil)
 ctx := middleware.SetUserDID(req.Context(), "did:plc:pending-member")
 req = req.WithContext(ctx)
 w := httptest.NewRecorder()
@@ -1408,6 +1675,42 @@ t.Errorf("expected status 404 for pending member, got %d: %s", w.Code, w.Body.St
 }
 }
 
+// TestGetScene_MembersOnlyScene_BannedMember tests that banned users are treated like non-members.
+func TestGetScene_MembersOnlyScene_BannedMember(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
+
+	if err := repo.Insert(t.Context(), &scene.Scene{
+		ID:            "44444444-4444-4444-8444-444444444444",
+		Name:          "Members Only Scene",
+		OwnerDID:      "did:plc:owner",
+		CoarseGeohash: "dr5regw",
+		Visibility:    scene.VisibilityMembersOnly,
+	}); err != nil {
+		t.Fatalf("failed to insert test scene: %v", err)
+	}
+
+	if _, err := membershipRepo.Upsert(t.Context(), &membership.Membership{
+		SceneID: "44444444-4444-4444-8444-444444444444",
+		UserDID: "did:plc:banned-member",
+		Status:  "banned",
+	}); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/scenes/44444444-4444-4444-8444-444444444444", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:banned-member"))
+	w := httptest.NewRecorder()
+	handlers.GetScene(w, req)
+
+	// Same 404 a non-member receives
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for banned member, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
 // TestGetScene_MembersOnlyScene_Owner tests that the owner can always access their scenes.
 func TestGetScene_MembersOnlyScene_Owner(t *testing.T) {
 repo := scene.NewInMemorySceneRepository()
@@ -1418,7 +1721,7 @@ streamRepo := stream.NewInMemorySessionRepository()
 // Create a members-only scene
 now := time.Now()
 testScene := &scene.Scene{
-ID:            "members-scene-id",
+ID:            "44444444-4444-4444-8444-444444444444",
 Name:          "Members Only Scene",
 OwnerDID:      "did:plc:owner",
 CoarseGeohash: "dr5regw",
@@ -1426,12 +1729,12 @@ Visibility:    scene.VisibilityMembersOnly,
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(testScene); err != nil {
+if err := repo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert test scene: %v", err)
 }
 
 // Test access by owner
-req := httptest.NewRequest(http.MethodGet, "/scenes/members-scene-id", nil)
+req := httptest.NewRequest(http.MethodGet, "/scenes/44444444-4444-4444-8444-444444444444", nil)
 ctx := middleware.SetUserDID(req.Context(), "did:plc:owner")
 req = req.WithContext(ctx)
 w := httptest.NewRecorder()
@@ -1453,7 +1756,7 @@ streamRepo := stream.NewInMemorySessionRepository()
 // Create a hidden scene
 now := time.Now()
 testScene := &scene.Scene{
-ID:            "hidden-scene-id",
+ID:            "33333333-3333-4333-8333-333333333333",
 Name:          "Hidden Scene",
 OwnerDID:      "did:plc:owner",
 CoarseGeohash: "dr5regw",
@@ -1461,12 +1764,12 @@ Visibility:    scene.VisibilityHidden,
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(testScene); err != nil {
+if err := repo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert test scene: %v", err)
 }
 
 // Test access by owner
-req := httptest.NewRequest(http.MethodGet, "/scenes/hidden-scene-id", nil)
+req := httptest.NewRequest(http.MethodGet, "/scenes/33333333-3333-4333-8333-333333333333", nil)
 ctx := middleware.SetUserDID(req.Context(), "did:plc:owner")
 req = req.WithContext(ctx)
 w := httptest.NewRecorder()
@@ -1482,8 +1785,8 @@ if err := json.NewDecoder(w.Body).Decode(&retrievedScene); err != nil {
 t.Fatalf("failed to decode response: %v", err)
 }
 
-if retrievedScene.ID != "hidden-scene-id" {
-t.Errorf("expected scene ID 'hidden-scene-id', got %s", retrievedScene.ID)
+if retrievedScene.ID != "33333333-3333-4333-8333-333333333333" {
+t.Errorf("expected scene ID '33333333-3333-4333-8333-333333333333', got %s", retrievedScene.ID)
 }
 }
 
@@ -1497,7 +1800,7 @@ streamRepo := stream.NewInMemorySessionRepository()
 // Create a hidden scene
 now := time.Now()
 testScene := &scene.Scene{
-ID:            "hidden-scene-id",
+ID:            "33333333-3333-4333-8333-333333333333",
 Name:          "Hidden Scene",
 OwnerDID:      "did:plc:owner",
 CoarseGeohash: "dr5regw",
@@ -1505,12 +1808,12 @@ Visibility:    scene.VisibilityHidden,
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(testScene); err != nil {
+if err := repo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert test scene: %v", err)
 }
 
 // Test access by different user (should be denied with uniform error)
-req := httptest.NewRequest(http.MethodGet, "/scenes/hidden-scene-id", nil)
+req := httptest.NewRequest(http.MethodGet, "/scenes/33333333-3333-4333-8333-333333333333", nil)
 ctx := middleware.SetUserDID(req.Context(), "did:plc:other-user")
 req = req.WithContext(ctx)
 w := httptest.NewRecorder()
@@ -1532,7 +1835,7 @@ t.Errorf("expected error code %s, got %s", ErrCodeNotFound, errResp.Error.Code)
 }
 
 // Also test unauthenticated access
-req2 := httptest.NewRequest(http.MethodGet, "/scenes/hidden-scene-id", nil)
+req2 := httptest.NewRequest(http.MethodGet, "/scenes/33333333-3333-4333-8333-333333333333", nil)
 w2 := httptest.NewRecorder()
 
 handlers.GetScene(w2, req2)
@@ -1550,7 +1853,7 @@ streamRepo := stream.NewInMemorySessionRepository()
 	handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
 
 // Test access to non-existent scene
-req := httptest.NewRequest(http.MethodGet, "/scenes/non-existent-id", nil)
+req := httptest.NewRequest(http.MethodGet, "/scenes/99999999-9999-4999-8999-999999999991", nil)
 w := httptest.NewRecorder()
 
 handlers.GetScene(w, req)
@@ -1584,7 +1887,7 @@ streamRepo := stream.NewInMemorySessionRepository()
 // Create a public scene with allow_precise=false
 now := time.Now()
 testScene := &scene.Scene{
-ID:            "privacy-scene-id",
+ID:            "77777777-7777-4777-8777-777777777777",
 Name:          "Privacy Scene",
 OwnerDID:      "did:plc:owner",
 CoarseGeohash: "dr5regw",
@@ -1594,12 +1897,12 @@ PrecisePoint:  &scene.Point{Lat: 40.7128, Lng: -74.0060}, // Should be cleared
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(testScene); err != nil {
+if err := repo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert test scene: %v", err)
 }
 
 // Test that precise point is not returned
-req := httptest.NewRequest(http.MethodGet, "/scenes/privacy-scene-id", nil)
+req := httptest.NewRequest(http.MethodGet, "/scenes/77777777-7777-4777-8777-777777777777", nil)
 w := httptest.NewRecorder()
 
 handlers.GetScene(w, req)
@@ -1619,6 +1922,62 @@ t.Errorf("expected precise_point to be nil when allow_precise=false, got %+v", r
 }
 }
 
+// TestGetScene_PreciseLocationAudited tests that serving a precise point
+// writes an access_precise_location audit entry and stripping it writes none.
+func TestGetScene_PreciseLocationAudited(t *testing.T) {
+	tests := []struct {
+		name         string
+		allowPrecise bool
+		wantEntries  int
+	}{
+		{"precise point served", true, 1},
+		{"precise point stripped", false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := scene.NewInMemorySceneRepository()
+			handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+			auditRepo := audit.NewInMemoryRepository()
+			handlers.SetAuditRepository(auditRepo)
+
+			sceneID := "78787878-7878-4787-8787-787878787878"
+			if err := repo.Insert(t.Context(), &scene.Scene{
+				ID:            sceneID,
+				Name:          "Audited Scene",
+				OwnerDID:      "did:plc:owner",
+				CoarseGeohash: "dr5regw",
+				Visibility:    scene.VisibilityPublic,
+				AllowPrecise:  tt.allowPrecise,
+				PrecisePoint:  &scene.Point{Lat: 40.7128, Lng: -74.0060},
+			}); err != nil {
+				t.Fatalf("failed to insert test scene: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/scenes/"+sceneID, nil)
+			req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:viewer"))
+			w := httptest.NewRecorder()
+			handlers.GetScene(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			logs, err := auditRepo.QueryByEntity(t.Context(), "scene", sceneID, 0)
+			if err != nil {
+				t.Fatalf("failed to query audit logs: %v", err)
+			}
+			if len(logs) != tt.wantEntries {
+				t.Fatalf("expected %d audit entries, got %d", tt.wantEntries, len(logs))
+			}
+			for _, entry := range logs {
+				if entry.Action != "access_precise_location" || entry.UserDID != "did:plc:viewer" {
+					t.Errorf("unexpected audit entry: action=%s user=%s", entry.Action, entry.UserDID)
+				}
+			}
+		})
+	}
+}
+
 // TestGetScene_SoftDeleted tests that soft-deleted scenes return 404 with scene_deleted error code.
 func TestGetScene_SoftDeleted(t *testing.T) {
 repo := scene.NewInMemorySceneRepository()
@@ -1629,7 +1988,7 @@ streamRepo := stream.NewInMemorySessionRepository()
 // Create a scene
 now := time.Now()
 testScene := &scene.Scene{
-ID:            "deleted-scene-id",
+ID:            "22222222-2222-4222-8222-222222222222",
 Name:          "Deleted Scene",
 OwnerDID:      "did:plc:owner",
 CoarseGeohash: "dr5regw",
@@ -1637,17 +1996,17 @@ Visibility:    scene.VisibilityPublic,
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(testScene); err != nil {
+if err := repo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert test scene: %v", err)
 }
 
 // Soft-delete the scene
-if err := repo.Delete("deleted-scene-id"); err != nil {
+if err := repo.Delete(t.Context(), "22222222-2222-4222-8222-222222222222"); err != nil {
 t.Fatalf("failed to delete scene: %v", err)
 }
 
 // Try to get the deleted scene
-req := httptest.NewRequest(http.MethodGet, "/scenes/deleted-scene-id", nil)
+req := httptest.NewRequest(http.MethodGet, "/scenes/22222222-2222-4222-8222-222222222222", nil)
 w := httptest.NewRecorder()
 
 handlers.GetScene(w, req)
@@ -1682,7 +2041,7 @@ streamRepo := stream.NewInMemorySessionRepository()
 // Create and delete a scene
 now := time.Now()
 deletedScene := &scene.Scene{
-ID:            "deleted-scene-id",
+ID:            "22222222-2222-4222-8222-222222222222",
 Name:          "Deleted Scene",
 OwnerDID:      "did:plc:owner",
 CoarseGeohash: "dr5regw",
@@ -1690,15 +2049,15 @@ Visibility:    scene.VisibilityPublic,
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(deletedScene); err != nil {
+if err := repo.Insert(t.Context(), deletedScene); err != nil {
 t.Fatalf("failed to insert test scene: %v", err)
 }
-if err := repo.Delete("deleted-scene-id"); err != nil {
+if err := repo.Delete(t.Context(), "22222222-2222-4222-8222-222222222222"); err != nil {
 t.Fatalf("failed to delete scene: %v", err)
 }
 
 // Test deleted scene
-req1 := httptest.NewRequest(http.MethodGet, "/scenes/deleted-scene-id", nil)
+req1 := httptest.NewRequest(http.MethodGet, "/scenes/22222222-2222-4222-8222-222222222222", nil)
 w1 := httptest.NewRecorder()
 handlers.GetScene(w1, req1)
 
@@ -1716,7 +2075,7 @@ t.Errorf("expected error code %s for deleted scene, got %s", ErrCodeSceneDeleted
 }
 
 // Test non-existent scene
-req2 := httptest.NewRequest(http.MethodGet, "/scenes/never-existed-id", nil)
+req2 := httptest.NewRequest(http.MethodGet, "/scenes/99999999-9999-4999-8999-999999999990", nil)
 w2 := httptest.NewRecorder()
 handlers.GetScene(w2, req2)
 
@@ -1751,7 +2110,7 @@ now := time.Now()
 
 // Create multiple scenes
 scene1 := &scene.Scene{
-ID:            "scene-1",
+ID:            "00000000-0000-4000-8000-000000000001",
 Name:          "Scene One",
 OwnerDID:      "did:plc:owner",
 CoarseGeohash: "dr5regw",
@@ -1760,7 +2119,7 @@ CreatedAt:     &now,
 UpdatedAt:     &now,
 }
 scene2 := &scene.Scene{
-ID:            "scene-2",
+ID:            "00000000-0000-4000-8000-000000000002",
 Name:          "Scene Two",
 OwnerDID:      "did:plc:owner",
 CoarseGeohash: "dr5regw",
@@ -1769,7 +2128,7 @@ CreatedAt:     &now,
 UpdatedAt:     &now,
 }
 scene3 := &scene.Scene{
-ID:            "scene-3",
+ID:            "00000000-0000-4000-8000-000000000003",
 Name:          "Scene Three",
 OwnerDID:      "did:plc:owner",
 CoarseGeohash: "dr5regw",
@@ -1779,32 +2138,32 @@ UpdatedAt:     &now,
 }
 
 for _, s := range []*scene.Scene{scene1, scene2, scene3} {
-if err := repo.Insert(s); err != nil {
+if err := repo.Insert(t.Context(), s); err != nil {
 t.Fatalf("failed to insert scene %s: %v", s.ID, err)
 }
 }
 
-// Delete scene-2
-if err := repo.Delete("scene-2"); err != nil {
-t.Fatalf("failed to delete scene-2: %v", err)
+// Delete 00000000-0000-4000-8000-000000000002
+if err := repo.Delete(t.Context(), "00000000-0000-4000-8000-000000000002"); err != nil {
+t.Fatalf("failed to delete 00000000-0000-4000-8000-000000000002: %v", err)
 }
 
-// Verify scene-1 is still accessible
-req1 := httptest.NewRequest(http.MethodGet, "/scenes/scene-1", nil)
+// Verify 00000000-0000-4000-8000-000000000001 is still accessible
+req1 := httptest.NewRequest(http.MethodGet, "/scenes/00000000-0000-4000-8000-000000000001", nil)
 w1 := httptest.NewRecorder()
 handlers.GetScene(w1, req1)
 
 if w1.Code != http.StatusOK {
-t.Errorf("expected scene-1 to be accessible (200), got %d: %s", w1.Code, w1.Body.String())
+t.Errorf("expected 00000000-0000-4000-8000-000000000001 to be accessible (200), got %d: %s", w1.Code, w1.Body.String())
 }
 
-// Verify scene-2 is not accessible (deleted)
-req2 := httptest.NewRequest(http.MethodGet, "/scenes/scene-2", nil)
+// Verify 00000000-0000-4000-8000-000000000002 is not accessible (deleted)
+req2 := httptest.NewRequest(http.MethodGet, "/scenes/00000000-0000-4000-8000-000000000002", nil)
 w2 := httptest.NewRecorder()
 handlers.GetScene(w2, req2)
 
 if w2.Code != http.StatusNotFound {
-t.Errorf("expected scene-2 to be inaccessible (404), got %d", w2.Code)
+t.Errorf("expected 00000000-0000-4000-8000-000000000002 to be inaccessible (404), got %d", w2.Code)
 }
 
 var err2Resp ErrorResponse
@@ -1813,16 +2172,16 @@ t.Fatalf("failed to decode error response: %v", err)
 }
 
 if err2Resp.Error.Code != ErrCodeSceneDeleted {
-t.Errorf("expected error code %s for deleted scene-2, got %s", ErrCodeSceneDeleted, err2Resp.Error.Code)
+t.Errorf("expected error code %s for deleted 00000000-0000-4000-8000-000000000002, got %s", ErrCodeSceneDeleted, err2Resp.Error.Code)
 }
 
-// Verify scene-3 is still accessible
-req3 := httptest.NewRequest(http.MethodGet, "/scenes/scene-3", nil)
+// Verify 00000000-0000-4000-8000-000000000003 is still accessible
+req3 := httptest.NewRequest(http.MethodGet, "/scenes/00000000-0000-4000-8000-000000000003", nil)
 w3 := httptest.NewRecorder()
 handlers.GetScene(w3, req3)
 
 if w3.Code != http.StatusOK {
-t.Errorf("expected scene-3 to be accessible (200), got %d: %s", w3.Code, w3.Body.String())
+t.Errorf("expected 00000000-0000-4000-8000-000000000003 to be accessible (200), got %d: %s", w3.Code, w3.Body.String())
 }
 }
 
@@ -1835,19 +2194,19 @@ streamRepo := stream.NewInMemorySessionRepository()
 
 now := time.Now()
 testScene := &scene.Scene{
-ID:            "test-scene-id",
+ID:            "11111111-1111-4111-8111-111111111111",
 Name:          "Test Scene",
 OwnerDID:      "did:plc:test123",
 CoarseGeohash: "dr5regw",
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(testScene); err != nil {
+if err := repo.Insert(t.Context(), testScene); err != nil {
     t.Fatalf("failed to insert test scene: %v", err)
 }
 
 // Delete once successfully
-req1 := httptest.NewRequest(http.MethodDelete, "/scenes/test-scene-id", nil)
+req1 := httptest.NewRequest(http.MethodDelete, "/scenes/11111111-1111-4111-8111-111111111111", nil)
 w1 := httptest.NewRecorder()
 handlers.DeleteScene(w1, req1)
 
@@ -1856,7 +2215,7 @@ t.Errorf("first deletion should succeed with 204, got %d", w1.Code)
 }
 
 // Try to delete again - should return scene_deleted error code
-req2 := httptest.NewRequest(http.MethodDelete, "/scenes/test-scene-id", nil)
+req2 := httptest.NewRequest(http.MethodDelete, "/scenes/11111111-1111-4111-8111-111111111111", nil)
 w2 := httptest.NewRecorder()
 handlers.DeleteScene(w2, req2)
 
@@ -1874,6 +2233,139 @@ t.Errorf("expected error code %s when deleting already deleted scene, got %s", E
 }
 }
 
+// TestDeleteScene_PurgedSceneReturnsNotFound tests that once a deleted scene is
+// purged its tombstone is gone, so the API reports not_found instead of scene_deleted.
+func TestDeleteScene_PurgedSceneReturnsNotFound(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
+
+	now := time.Now()
+	if err := repo.Insert(t.Context(), &scene.Scene{
+		ID:            "11111111-1111-4111-8111-111111111111",
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}); err != nil {
+		t.Fatalf("failed to insert test scene: %v", err)
+	}
+	if err := repo.Delete(t.Context(), "11111111-1111-4111-8111-111111111111"); err != nil {
+		t.Fatalf("failed to delete test scene: %v", err)
+	}
+	if _, err := repo.Purge(t.Context(), time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("failed to purge scenes: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/scenes/11111111-1111-4111-8111-111111111111", nil)
+	w := httptest.NewRecorder()
+	handlers.DeleteScene(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", w.Code)
+	}
+
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeNotFound {
+		t.Errorf("expected error code %s for purged scene, got %s", ErrCodeNotFound, errResp.Error.Code)
+	}
+}
+
+// TestDeleteScene_CascadesToEvents tests that deleting a scene soft-deletes its
+// events so they, and their RSVPs, are no longer retrievable.
+func TestDeleteScene_CascadesToEvents(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	eventRepo := scene.NewInMemoryEventRepository()
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), streamRepo)
+	handlers.SetEventRepository(eventRepo)
+	eventHandlers := NewEventHandlers(eventRepo, repo, audit.NewInMemoryRepository(), rsvpRepo, streamRepo)
+
+	now := time.Now()
+	if err := repo.Insert(t.Context(), &scene.Scene{
+		ID:            "11111111-1111-4111-8111-111111111111",
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}); err != nil {
+		t.Fatalf("failed to insert test scene: %v", err)
+	}
+	for _, id := range []string{"eeeeeeee-eeee-4eee-8eee-000000000001", "eeeeeeee-eeee-4eee-8eee-000000000002"} {
+		if err := eventRepo.Insert(t.Context(), &scene.Event{
+			ID:            id,
+			SceneID:       "11111111-1111-4111-8111-111111111111",
+			Title:         "Test Event",
+			CoarseGeohash: "dr5regw",
+			StartsAt:      now.Add(24 * time.Hour),
+		}); err != nil {
+			t.Fatalf("failed to insert event: %v", err)
+		}
+	}
+	if err := rsvpRepo.Upsert(t.Context(), &scene.RSVP{EventID: "eeeeeeee-eeee-4eee-8eee-000000000001", UserID: "did:plc:attendee", Status: "going"}); err != nil {
+		t.Fatalf("failed to upsert rsvp: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/scenes/11111111-1111-4111-8111-111111111111", nil)
+	w := httptest.NewRecorder()
+	handlers.DeleteScene(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", w.Code)
+	}
+
+	for _, id := range []string{"eeeeeeee-eeee-4eee-8eee-000000000001", "eeeeeeee-eeee-4eee-8eee-000000000002"} {
+		req := httptest.NewRequest(http.MethodGet, "/events/"+id, nil)
+		w := httptest.NewRecorder()
+		eventHandlers.GetEvent(w, req)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404 for event %s after scene deletion, got %d", id, w.Code)
+		}
+	}
+
+	events, err := eventRepo.ListBySceneID(t.Context(), "11111111-1111-4111-8111-111111111111", scene.EventFilter{})
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("expected no events listed for deleted scene, got %d", len(events))
+	}
+
+	// RSVPs are reachable only through their event, which is now gone
+	rsvpHandlers := NewRSVPHandlers(rsvpRepo, eventRepo, repo)
+	req = httptest.NewRequest(http.MethodPost, "/events/eeeeeeee-eeee-4eee-8eee-000000000001/rsvp", strings.NewReader(`{"status":"maybe"}`))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:attendee"))
+	w = httptest.NewRecorder()
+	rsvpHandlers.CreateOrUpdateRSVP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for RSVP on cascaded event, got %d", w.Code)
+	}
+
+	// Restoring the scene's events makes them retrievable again
+	if err := repo.Restore(t.Context(), "11111111-1111-4111-8111-111111111111"); err != nil {
+		t.Fatalf("failed to restore scene: %v", err)
+	}
+	restored, err := eventRepo.RestoreBySceneID(t.Context(), "11111111-1111-4111-8111-111111111111")
+	if err != nil {
+		t.Fatalf("failed to restore events: %v", err)
+	}
+	if restored != 2 {
+		t.Errorf("expected 2 events restored, got %d", restored)
+	}
+	req = httptest.NewRequest(http.MethodGet, "/events/eeeeeeee-eeee-4eee-8eee-000000000001", nil)
+	w = httptest.NewRecorder()
+	eventHandlers.GetEvent(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200 for restored event, got %d", w.Code)
+	}
+}
+
 // TestRepository_DeletedSceneExcludedFromExistsByOwnerAndName tests that deleted scenes are excluded from duplicate checks.
 func TestRepository_DeletedSceneExcludedFromExistsByOwnerAndName(t *testing.T) {
 repo := scene.NewInMemorySceneRepository()
@@ -1882,22 +2374,22 @@ now := time.Now()
 
 // Create and delete a scene
 scene1 := &scene.Scene{
-ID:            "scene-1",
+ID:            "00000000-0000-4000-8000-000000000001",
 Name:          "My Scene",
 OwnerDID:      "did:plc:owner",
 CoarseGeohash: "dr5regw",
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(scene1); err != nil {
+if err := repo.Insert(t.Context(), scene1); err != nil {
 t.Fatalf("failed to insert scene: %v", err)
 }
-if err := repo.Delete("scene-1"); err != nil {
+if err := repo.Delete(t.Context(), "00000000-0000-4000-8000-000000000001"); err != nil {
 t.Fatalf("failed to delete scene: %v", err)
 }
 
 // Check if name exists (should not, since scene is deleted)
-exists, err := repo.ExistsByOwnerAndName("did:plc:owner", "My Scene", "")
+exists, err := repo.ExistsByOwnerAndName(t.Context(), "did:plc:owner", "My Scene", "")
 if err != nil {
 t.Fatalf("ExistsByOwnerAndName failed: %v", err)
 }
@@ -1908,19 +2400,19 @@ t.Error("deleted scene should not be counted in ExistsByOwnerAndName")
 
 // Create a new scene with the same name (should be allowed)
 scene2 := &scene.Scene{
-ID:            "scene-2",
+ID:            "00000000-0000-4000-8000-000000000002",
 Name:          "My Scene",
 OwnerDID:      "did:plc:owner",
 CoarseGeohash: "dr5regw",
 CreatedAt:     &now,
 UpdatedAt:     &now,
 }
-if err := repo.Insert(scene2); err != nil {
+if err := repo.Insert(t.Context(), scene2); err != nil {
 t.Fatalf("failed to insert scene with same name as deleted scene: %v", err)
 }
 
 // Now the name should exist
-exists, err = repo.ExistsByOwnerAndName("did:plc:owner", "My Scene", "")
+exists, err = repo.ExistsByOwnerAndName(t.Context(), "did:plc:owner", "My Scene", "")
 if err != nil {
 t.Fatalf("ExistsByOwnerAndName failed: %v", err)
 }
@@ -1940,7 +2432,7 @@ func TestListOwnedScenes_Success(t *testing.T) {
 
 	// Create scenes for the user
 	scene1 := &scene.Scene{
-		ID:            "scene-1",
+		ID:            "00000000-0000-4000-8000-000000000001",
 		Name:          "Scene 1",
 		Description:   "First scene",
 		OwnerDID:      userDID,
@@ -1949,7 +2441,7 @@ func TestListOwnedScenes_Success(t *testing.T) {
 		Tags:          []string{"tag1"},
 	}
 	scene2 := &scene.Scene{
-		ID:            "scene-2",
+		ID:            "00000000-0000-4000-8000-000000000002",
 		Name:          "Scene 2",
 		Description:   "Second scene",
 		OwnerDID:      userDID,
@@ -1957,45 +2449,45 @@ func TestListOwnedScenes_Success(t *testing.T) {
 		Visibility:    "private",
 	}
 
-	if err := repo.Insert(scene1); err != nil {
+	if err := repo.Insert(t.Context(), scene1); err != nil {
 		t.Fatalf("Insert scene1 failed: %v", err)
 	}
-	if err := repo.Insert(scene2); err != nil {
+	if err := repo.Insert(t.Context(), scene2); err != nil {
 		t.Fatalf("Insert scene2 failed: %v", err)
 	}
 
 	// Add memberships to scene1
 	membership1 := &membership.Membership{
 		ID:      "m1",
-		SceneID: "scene-1",
+		SceneID: "00000000-0000-4000-8000-000000000001",
 		UserDID: "did:plc:member1",
 		Status:  "active",
 	}
 	membership2 := &membership.Membership{
 		ID:      "m2",
-		SceneID: "scene-1",
+		SceneID: "00000000-0000-4000-8000-000000000001",
 		UserDID: "did:plc:member2",
 		Status:  "active",
 	}
 	membership3 := &membership.Membership{
 		ID:      "m3",
-		SceneID: "scene-1",
+		SceneID: "00000000-0000-4000-8000-000000000001",
 		UserDID: "did:plc:member3",
 		Status:  "pending", // Should not be counted
 	}
 
-	if _, err := membershipRepo.Upsert(membership1); err != nil {
+	if _, err := membershipRepo.Upsert(t.Context(), membership1); err != nil {
 		t.Fatalf("Upsert membership1 failed: %v", err)
 	}
-	if _, err := membershipRepo.Upsert(membership2); err != nil {
+	if _, err := membershipRepo.Upsert(t.Context(), membership2); err != nil {
 		t.Fatalf("Upsert membership2 failed: %v", err)
 	}
-	if _, err := membershipRepo.Upsert(membership3); err != nil {
+	if _, err := membershipRepo.Upsert(t.Context(), membership3); err != nil {
 		t.Fatalf("Upsert membership3 failed: %v", err)
 	}
 
 	// Add active stream to scene1
-	sceneID1 := "scene-1"
+	sceneID1 := "00000000-0000-4000-8000-000000000001"
 	session := &stream.Session{
 		ID:               "stream-1",
 		SceneID:          &sceneID1,
@@ -2033,14 +2525,14 @@ func TestListOwnedScenes_Success(t *testing.T) {
 	// Find scene1 in results
 	var scene1Summary *OwnedSceneSummary
 	for i := range summaries {
-		if summaries[i].ID == "scene-1" {
+		if summaries[i].ID == "00000000-0000-4000-8000-000000000001" {
 			scene1Summary = &summaries[i]
 			break
 		}
 	}
 
 	if scene1Summary == nil {
-		t.Fatal("scene-1 not found in results")
+		t.Fatal("00000000-0000-4000-8000-000000000001 not found in results")
 	}
 
 	// Verify scene1 stats
@@ -2048,20 +2540,20 @@ func TestListOwnedScenes_Success(t *testing.T) {
 		t.Errorf("expected 2 active members, got %d", scene1Summary.MembersCount)
 	}
 	if !scene1Summary.HasActiveStream {
-		t.Error("expected active stream for scene-1")
+		t.Error("expected active stream for 00000000-0000-4000-8000-000000000001")
 	}
 
 	// Find scene2 in results
 	var scene2Summary *OwnedSceneSummary
 	for i := range summaries {
-		if summaries[i].ID == "scene-2" {
+		if summaries[i].ID == "00000000-0000-4000-8000-000000000002" {
 			scene2Summary = &summaries[i]
 			break
 		}
 	}
 
 	if scene2Summary == nil {
-		t.Fatal("scene-2 not found in results")
+		t.Fatal("00000000-0000-4000-8000-000000000002 not found in results")
 	}
 
 	// Verify scene2 stats
@@ -2069,7 +2561,7 @@ func TestListOwnedScenes_Success(t *testing.T) {
 		t.Errorf("expected 0 members, got %d", scene2Summary.MembersCount)
 	}
 	if scene2Summary.HasActiveStream {
-		t.Error("expected no active stream for scene-2")
+		t.Error("expected no active stream for 00000000-0000-4000-8000-000000000002")
 	}
 }
 
@@ -2108,27 +2600,27 @@ func TestListOwnedScenes_ExcludesDeleted(t *testing.T) {
 
 	// Create scenes
 	scene1 := &scene.Scene{
-		ID:            "scene-1",
+		ID:            "00000000-0000-4000-8000-000000000001",
 		Name:          "Scene 1",
 		OwnerDID:      userDID,
 		CoarseGeohash: "dr5regw",
 	}
 	scene2 := &scene.Scene{
-		ID:            "scene-2",
+		ID:            "00000000-0000-4000-8000-000000000002",
 		Name:          "Scene 2",
 		OwnerDID:      userDID,
 		CoarseGeohash: "dr5regx",
 	}
 
-	if err := repo.Insert(scene1); err != nil {
+	if err := repo.Insert(t.Context(), scene1); err != nil {
 		t.Fatalf("Insert scene1 failed: %v", err)
 	}
-	if err := repo.Insert(scene2); err != nil {
+	if err := repo.Insert(t.Context(), scene2); err != nil {
 		t.Fatalf("Insert scene2 failed: %v", err)
 	}
 
 	// Delete scene1
-	if err := repo.Delete("scene-1"); err != nil {
+	if err := repo.Delete(t.Context(), "00000000-0000-4000-8000-000000000001"); err != nil {
 		t.Fatalf("Delete failed: %v", err)
 	}
 
@@ -2153,8 +2645,8 @@ func TestListOwnedScenes_ExcludesDeleted(t *testing.T) {
 	if len(summaries) != 1 {
 		t.Errorf("expected 1 scene, got %d", len(summaries))
 	}
-	if len(summaries) > 0 && summaries[0].ID != "scene-2" {
-		t.Errorf("expected scene-2, got %s", summaries[0].ID)
+	if len(summaries) > 0 && summaries[0].ID != "00000000-0000-4000-8000-000000000002" {
+		t.Errorf("expected 00000000-0000-4000-8000-000000000002, got %s", summaries[0].ID)
 	}
 }
 
@@ -2187,3 +2679,2057 @@ func TestListOwnedScenes_EmptyList(t *testing.T) {
 		t.Errorf("expected empty list, got %d scenes", len(summaries))
 	}
 }
+
+// TestCreateScene_LocationJitter tests that the coarse geohash is derived from a
+// jittered precise point when jitter is enabled.
+func TestCreateScene_LocationJitter(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
+	handlers.SetLocationJitter(500)
+
+	precise := scene.Point{Lat: 40.7128, Lng: -74.0060}
+	reqBody := CreateSceneRequest{
+		Name:          "Jittered Scene",
+		OwnerDID:      "did:plc:test123",
+		AllowPrecise:  true,
+		PrecisePoint:  &precise,
+		CoarseGeohash: "dr5reg",
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handlers.CreateScene(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var createdScene scene.Scene
+	if err := json.NewDecoder(w.Body).Decode(&createdScene); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	want := jitteredCoarseGeohash(createdScene.ID, precise, 500, len(reqBody.CoarseGeohash))
+	if createdScene.CoarseGeohash != want {
+		t.Errorf("expected coarse_geohash %q derived from jittered point, got %q", want, createdScene.CoarseGeohash)
+	}
+	// Precise point is stored as submitted
+	if createdScene.PrecisePoint == nil || *createdScene.PrecisePoint != precise {
+		t.Errorf("expected precise_point %v to be stored unchanged, got %v", precise, createdScene.PrecisePoint)
+	}
+}
+
+// TestCreateScene_CoarseGeohashFromPrecisePoint tests that a supplied precise
+// point determines the stored coarse geohash, overriding the client's value.
+func TestCreateScene_CoarseGeohashFromPrecisePoint(t *testing.T) {
+	tests := []struct {
+		name          string
+		allowPrecise  bool
+		coarseGeohash string
+	}{
+		{"matching client cell", true, "dr5reg"},
+		{"mismatched client cell overridden", true, "gcpvj0"},
+		{"overridden without consent", false, "gcpvj0"},
+		{"client cell omitted", true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := scene.NewInMemorySceneRepository()
+			membershipRepo := membership.NewInMemoryMembershipRepository()
+			streamRepo := stream.NewInMemorySessionRepository()
+			handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
+
+			reqBody := CreateSceneRequest{
+				Name:          "Located Scene",
+				OwnerDID:      "did:plc:test123",
+				AllowPrecise:  tt.allowPrecise,
+				PrecisePoint:  &scene.Point{Lat: 40.7128, Lng: -74.0060}, // New York
+				CoarseGeohash: tt.coarseGeohash,
+			}
+
+			body, err := json.Marshal(reqBody)
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			handlers.CreateScene(w, req)
+
+			if w.Code != http.StatusCreated {
+				t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var createdScene scene.Scene
+			if err := json.NewDecoder(w.Body).Decode(&createdScene); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if createdScene.CoarseGeohash != "dr5reg" {
+				t.Errorf("expected coarse_geohash dr5reg derived from precise point, got %q", createdScene.CoarseGeohash)
+			}
+		})
+	}
+}
+
+// TestCreateScene_PrecisePointOutOfRange tests that a precise point with
+// out-of-range coordinates is rejected instead of falling back to the client
+// geohash.
+func TestCreateScene_PrecisePointOutOfRange(t *testing.T) {
+	for _, point := range []scene.Point{
+		{Lat: 91, Lng: -74.0060},
+		{Lat: 999, Lng: -74.0060},
+		{Lat: 40.7128, Lng: -400},
+	} {
+		t.Run(fmt.Sprintf("%v,%v", point.Lat, point.Lng), func(t *testing.T) {
+			repo := scene.NewInMemorySceneRepository()
+			membershipRepo := membership.NewInMemoryMembershipRepository()
+			streamRepo := stream.NewInMemorySessionRepository()
+			handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
+
+			reqBody := CreateSceneRequest{
+				Name:          "Nowhere Scene",
+				OwnerDID:      "did:plc:test123",
+				AllowPrecise:  true,
+				PrecisePoint:  &point,
+				CoarseGeohash: "dr5reg",
+			}
+
+			body, err := json.Marshal(reqBody)
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			handlers.CreateScene(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != ErrCodeValidation {
+				t.Errorf("expected error code %s, got %s", ErrCodeValidation, errResp.Error.Code)
+			}
+		})
+	}
+}
+
+// TestUpdateScene_PrecisePointOutOfRange tests that an update with an
+// out-of-range precise point is rejected and leaves the scene unchanged.
+func TestUpdateScene_PrecisePointOutOfRange(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
+
+	sceneID := "11111111-1111-4111-8111-111111111111"
+	if err := repo.Insert(t.Context(), &scene.Scene{
+		ID:            sceneID,
+		Name:          "Located Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+		AllowPrecise:  true,
+		PrecisePoint:  &scene.Point{Lat: 40.7128, Lng: -74.0060},
+	}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	body, _ := json.Marshal(UpdateSceneRequest{PrecisePoint: &scene.Point{Lat: 999, Lng: -400}})
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/"+sceneID, bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+
+	handlers.UpdateScene(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+	stored, err := repo.GetByID(t.Context(), sceneID)
+	if err != nil {
+		t.Fatalf("failed to get scene: %v", err)
+	}
+	if stored.PrecisePoint == nil || stored.PrecisePoint.Lat != 40.7128 {
+		t.Errorf("expected precise point unchanged, got %+v", stored.PrecisePoint)
+	}
+}
+
+// TestUpdateScene_PrecisePointRecomputesCoarseGeohash tests that moving a
+// scene's precise point also moves its public cell, even when the request
+// carries no coarse geohash of its own.
+func TestUpdateScene_PrecisePointRecomputesCoarseGeohash(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
+
+	sceneID := "11111111-1111-4111-8111-111111111111"
+	if err := repo.Insert(t.Context(), &scene.Scene{
+		ID:            sceneID,
+		Name:          "Located Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5reg",
+		AllowPrecise:  true,
+		PrecisePoint:  &scene.Point{Lat: 40.7128, Lng: -74.0060}, // New York
+	}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	body, _ := json.Marshal(UpdateSceneRequest{PrecisePoint: &scene.Point{Lat: 51.5074, Lng: -0.1278}}) // London
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/"+sceneID, bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+
+	handlers.UpdateScene(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	stored, err := repo.GetByID(t.Context(), sceneID)
+	if err != nil {
+		t.Fatalf("failed to get scene: %v", err)
+	}
+	if stored.CoarseGeohash != "gcpvj0" {
+		t.Errorf("expected coarse_geohash gcpvj0 derived from the new point, got %q", stored.CoarseGeohash)
+	}
+}
+
+// TestGetScene_CoarsePrecisionByViewer tests that the returned coarse geohash
+// is truncated according to the requester's relationship to the scene.
+func TestGetScene_CoarsePrecisionByViewer(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
+	// The scene is alone in its cell; disable coarsening to test precision alone
+	handlers.SetLocationAnonymityK(1)
+
+	now := time.Now()
+	testScene := &scene.Scene{
+		ID:            "66666666-6666-4666-8666-666666666666",
+		Name:          "Precision Scene",
+		OwnerDID:      "did:plc:owner",
+		CoarseGeohash: "dr5regw",
+		Visibility:    scene.VisibilityPublic,
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}
+	if err := repo.Insert(t.Context(), testScene); err != nil {
+		t.Fatalf("failed to insert test scene: %v", err)
+	}
+
+	for _, m := range []*membership.Membership{
+		{SceneID: "66666666-6666-4666-8666-666666666666", UserDID: "did:plc:member", Status: "active"},
+		{SceneID: "66666666-6666-4666-8666-666666666666", UserDID: "did:plc:pending", Status: "pending"},
+	} {
+		if _, err := membershipRepo.Upsert(t.Context(), m); err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		requesterDID string
+		want         string
+	}{
+		{"anonymous viewer", "", "dr5re"},
+		{"authenticated non-member", "did:plc:stranger", "dr5re"},
+		{"pending member", "did:plc:pending", "dr5re"},
+		{"active member", "did:plc:member", "dr5reg"},
+		{"owner", "did:plc:owner", "dr5regw"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/scenes/66666666-6666-4666-8666-666666666666", nil)
+			if tt.requesterDID != "" {
+				req = req.WithContext(middleware.SetUserDID(req.Context(), tt.requesterDID))
+			}
+			w := httptest.NewRecorder()
+
+			handlers.GetScene(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var retrievedScene scene.Scene
+			if err := json.NewDecoder(w.Body).Decode(&retrievedScene); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if retrievedScene.CoarseGeohash != tt.want {
+				t.Errorf("expected coarse_geohash %q, got %q", tt.want, retrievedScene.CoarseGeohash)
+			}
+		})
+	}
+
+	// Stored value must be unchanged
+	stored, err := repo.GetByID(t.Context(), "66666666-6666-4666-8666-666666666666")
+	if err != nil {
+		t.Fatalf("failed to get stored scene: %v", err)
+	}
+	if stored.CoarseGeohash != "dr5regw" {
+		t.Errorf("expected stored coarse_geohash to remain 'dr5regw', got %q", stored.CoarseGeohash)
+	}
+}
+
+func TestGetScene_ConditionalGet(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
+
+	updatedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := repo.Insert(t.Context(), &scene.Scene{
+		ID:            "77777777-7777-4777-8777-777777777777",
+		Name:          "Polled Scene",
+		OwnerDID:      "did:plc:owner",
+		CoarseGeohash: "dr5regw",
+		Visibility:    scene.VisibilityPublic,
+		CreatedAt:     &updatedAt,
+		UpdatedAt:     &updatedAt,
+	}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	get := func(header, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/scenes/77777777-7777-4777-8777-777777777777", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		w := httptest.NewRecorder()
+		handlers.GetScene(w, req)
+		return w
+	}
+
+	w := get("", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	etag := w.Header().Get("ETag")
+	if etag != `"1"` {
+		t.Fatalf("expected ETag \"1\", got %q", etag)
+	}
+	lastModified := w.Header().Get("Last-Modified")
+	if lastModified != updatedAt.Format(http.TimeFormat) {
+		t.Errorf("expected Last-Modified %q, got %q", updatedAt.Format(http.TimeFormat), lastModified)
+	}
+
+	// Unchanged scene: 304 without a body
+	for _, tc := range []struct{ header, value string }{
+		{"If-None-Match", etag},
+		{"If-None-Match", `W/"1"`},
+		{"If-Modified-Since", lastModified},
+	} {
+		w = get(tc.header, tc.value)
+		if w.Code != http.StatusNotModified {
+			t.Errorf("%s %s: expected status 304, got %d", tc.header, tc.value, w.Code)
+		}
+		if w.Body.Len() != 0 {
+			t.Errorf("%s %s: expected empty body, got %q", tc.header, tc.value, w.Body.String())
+		}
+		if got := w.Header().Get("ETag"); got != etag {
+			t.Errorf("%s %s: expected ETag %q on 304, got %q", tc.header, tc.value, etag, got)
+		}
+	}
+
+	// A palette change must invalidate the cached representation
+	body := `{"palette":{"primary":"#ff0000","secondary":"#00ff00","accent":"#0000ff","background":"#ffffff","text":"#000000"}}`
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/77777777-7777-4777-8777-777777777777/palette", strings.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner"))
+	pw := httptest.NewRecorder()
+	handlers.UpdateScenePalette(pw, req)
+	if pw.Code != http.StatusOK {
+		t.Fatalf("expected palette update status 200, got %d: %s", pw.Code, pw.Body.String())
+	}
+
+	w = get("If-None-Match", etag)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 after update, got %d", w.Code)
+	}
+	if got := w.Header().Get("ETag"); got == etag || got == "" {
+		t.Errorf("expected fresh ETag after update, got %q", got)
+	}
+	var retrieved scene.Scene
+	if err := json.NewDecoder(w.Body).Decode(&retrieved); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if retrieved.Palette == nil || retrieved.Palette.Primary != "#ff0000" {
+		t.Errorf("expected updated palette, got %+v", retrieved.Palette)
+	}
+
+	w = get("If-Modified-Since", lastModified)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200 for If-Modified-Since before update, got %d", w.Code)
+	}
+}
+
+func TestGetScene_ConditionalGetHiddenScene(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
+
+	now := time.Now()
+	if err := repo.Insert(t.Context(), &scene.Scene{
+		ID:            "88888888-8888-4888-8888-888888888888",
+		Name:          "Hidden Scene",
+		OwnerDID:      "did:plc:owner",
+		CoarseGeohash: "dr5regw",
+		Visibility:    scene.VisibilityHidden,
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	// A wildcard If-None-Match must not reveal that the scene exists
+	req := httptest.NewRequest(http.MethodGet, "/scenes/88888888-8888-4888-8888-888888888888", nil)
+	req.Header.Set("If-None-Match", "*")
+	w := httptest.NewRecorder()
+	handlers.GetScene(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+	if got := w.Header().Get("ETag"); got != "" {
+		t.Errorf("expected no ETag for hidden scene, got %q", got)
+	}
+}
+
+// insertListScenes creates count public scenes with ascending creation times.
+func insertListScenes(t *testing.T, repo *scene.InMemorySceneRepository, count int) {
+	t.Helper()
+	base := time.Now()
+	for i := 0; i < count; i++ {
+		createdAt := base.Add(time.Duration(i) * time.Minute)
+		s := &scene.Scene{
+			ID:            fmt.Sprintf("list-scene-%02d", i),
+			Name:          fmt.Sprintf("List Scene %02d", i),
+			OwnerDID:      "did:plc:owner",
+			CoarseGeohash: "dr5regw",
+			Tags:          []string{"techno"},
+			Visibility:    scene.VisibilityPublic,
+			CreatedAt:     &createdAt,
+		}
+		if err := repo.Insert(t.Context(), s); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+	}
+}
+
+// TestListScenes_Empty tests that an empty repository returns an empty list.
+func TestListScenes_Empty(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+
+	req := httptest.NewRequest(http.MethodGet, "/scenes", nil)
+	w := httptest.NewRecorder()
+
+	handlers.ListScenes(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp ListScenesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Scenes == nil || len(resp.Scenes) != 0 {
+		t.Errorf("expected empty scenes array, got %v", resp.Scenes)
+	}
+	if resp.NextCursor != "" {
+		t.Errorf("expected no next_cursor, got %q", resp.NextCursor)
+	}
+}
+
+// TestListScenes_FullPageAndCursor tests a full page followed by cursor continuation.
+func TestListScenes_FullPageAndCursor(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+	insertListScenes(t, repo, 5)
+
+	// First page
+	req := httptest.NewRequest(http.MethodGet, "/scenes?limit=3", nil)
+	w := httptest.NewRecorder()
+	handlers.ListScenes(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var page1 ListScenesResponse
+	if err := json.NewDecoder(w.Body).Decode(&page1); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(page1.Scenes) != 3 {
+		t.Fatalf("expected 3 scenes on first page, got %d", len(page1.Scenes))
+	}
+	if page1.Scenes[0].ID != "list-scene-04" {
+		t.Errorf("expected newest scene first, got %s", page1.Scenes[0].ID)
+	}
+	if page1.NextCursor == "" {
+		t.Fatal("expected next_cursor on full page")
+	}
+	// Public viewers see public precision
+	if page1.Scenes[0].CoarseGeohash != "dr5re" {
+		t.Errorf("expected coarse_geohash 'dr5re', got %q", page1.Scenes[0].CoarseGeohash)
+	}
+
+	// Second page
+	req = httptest.NewRequest(http.MethodGet, "/scenes?limit=3&cursor="+url.QueryEscape(page1.NextCursor), nil)
+	w = httptest.NewRecorder()
+	handlers.ListScenes(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var page2 ListScenesResponse
+	if err := json.NewDecoder(w.Body).Decode(&page2); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(page2.Scenes) != 2 {
+		t.Fatalf("expected 2 scenes on second page, got %d", len(page2.Scenes))
+	}
+	if page2.Scenes[0].ID != "list-scene-01" || page2.Scenes[1].ID != "list-scene-00" {
+		t.Errorf("unexpected second page order: %s, %s", page2.Scenes[0].ID, page2.Scenes[1].ID)
+	}
+	if page2.NextCursor != "" {
+		t.Errorf("expected no next_cursor on last page, got %q", page2.NextCursor)
+	}
+}
+
+// TestListScenes_LimitCapped tests that limits above the maximum are capped.
+func TestListScenes_LimitCapped(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+	insertListScenes(t, repo, scene.MaxSceneListLimit+1)
+
+	req := httptest.NewRequest(http.MethodGet, "/scenes?limit=500", nil)
+	w := httptest.NewRecorder()
+	handlers.ListScenes(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ListScenesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Scenes) != scene.MaxSceneListLimit {
+		t.Errorf("expected %d scenes, got %d", scene.MaxSceneListLimit, len(resp.Scenes))
+	}
+	if resp.NextCursor == "" {
+		t.Error("expected next_cursor when more scenes remain")
+	}
+}
+
+// TestListScenes_VisibilityRules tests that hidden and members-only scenes are
+// excluded for viewers without access.
+func TestListScenes_VisibilityRules(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	handlers := NewSceneHandlers(repo, membershipRepo, stream.NewInMemorySessionRepository())
+
+	for _, s := range []*scene.Scene{
+		{ID: "public", Name: "Public", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityPublic},
+		{ID: "private", Name: "Private", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityMembersOnly},
+		{ID: "hidden", Name: "Hidden", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityHidden},
+	} {
+		if err := repo.Insert(t.Context(), s); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+	}
+	if _, err := membershipRepo.Upsert(t.Context(), &membership.Membership{SceneID: "private", UserDID: "did:plc:member", Status: "active"}); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		viewerDID string
+		wantCount int
+	}{
+		{"anonymous", "", 1},
+		{"active member", "did:plc:member", 2},
+		{"owner", "did:plc:owner", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/scenes", nil)
+			if tt.viewerDID != "" {
+				req = req.WithContext(middleware.SetUserDID(req.Context(), tt.viewerDID))
+			}
+			w := httptest.NewRecorder()
+			handlers.ListScenes(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			var resp ListScenesResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp.Scenes) != tt.wantCount {
+				t.Errorf("expected %d scenes, got %d", tt.wantCount, len(resp.Scenes))
+			}
+		})
+	}
+}
+
+// TestListScenes_InvalidParams tests validation of query parameters.
+func TestListScenes_InvalidParams(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+
+	for _, query := range []string{"limit=0", "limit=abc", "visibility=secret", "cursor=garbage"} {
+		t.Run(query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/scenes?"+query, nil)
+			w := httptest.NewRecorder()
+			handlers.ListScenes(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", w.Code)
+			}
+		})
+	}
+}
+
+// TestCreateScene_NormalizesTags tests that tags are lowercased, trimmed, and deduped.
+func TestCreateScene_NormalizesTags(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+
+	reqBody := CreateSceneRequest{
+		Name:          "Tagged Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+		Tags:          []string{"Techno", "techno", " techno ", "", "House"},
+	}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	handlers.CreateScene(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created scene.Scene
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(created.Tags) != 2 || created.Tags[0] != "techno" || created.Tags[1] != "house" {
+		t.Errorf("expected tags [techno house], got %v", created.Tags)
+	}
+}
+
+// TestCreateScene_CoarseGeohashValidation tests that over-long geohashes are
+// truncated to the default precision and invalid characters are rejected.
+func TestCreateScene_CoarseGeohashValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		geohash     string
+		wantStatus  int
+		wantGeohash string
+	}{
+		{"truncated to default precision", "dr5regw3pf9z", http.StatusCreated, "dr5reg"},
+		{"lowercased", "DR5REG", http.StatusCreated, "dr5reg"},
+		{"invalid characters", "dr5rea!", http.StatusBadRequest, ""},
+		{"whitespace only", "   ", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := scene.NewInMemorySceneRepository()
+			handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+
+			body, err := json.Marshal(CreateSceneRequest{
+				Name:          "Geohash Scene",
+				OwnerDID:      "did:plc:test123",
+				CoarseGeohash: tt.geohash,
+			})
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+			handlers.CreateScene(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusCreated {
+				var errResp ErrorResponse
+				if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+					t.Fatalf("failed to decode error response: %v", err)
+				}
+				if errResp.Error.Code != ErrCodeValidation {
+					t.Errorf("expected error code %q, got %q", ErrCodeValidation, errResp.Error.Code)
+				}
+				return
+			}
+
+			var created scene.Scene
+			if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			stored, err := repo.GetByID(t.Context(), created.ID)
+			if err != nil {
+				t.Fatalf("failed to get stored scene: %v", err)
+			}
+			if stored.CoarseGeohash != tt.wantGeohash {
+				t.Errorf("expected stored geohash %q, got %q", tt.wantGeohash, stored.CoarseGeohash)
+			}
+		})
+	}
+}
+
+// TestCreateScene_ConfiguredCoarsePrecision tests that stored geohashes follow
+// a changed geo.CoarsePrecision, both when truncated and when derived from a
+// precise point.
+func TestCreateScene_ConfiguredCoarsePrecision(t *testing.T) {
+	if err := geo.SetCoarsePrecision(5); err != nil {
+		t.Fatalf("SetCoarsePrecision failed: %v", err)
+	}
+	t.Cleanup(func() { _ = geo.SetCoarsePrecision(geo.DefaultPrecision) })
+
+	tests := []struct {
+		name         string
+		geohash      string
+		precisePoint *scene.Point
+		wantGeohash  string
+	}{
+		{"truncated", "dr5regw3pf9z", nil, "dr5re"},
+		{"derived from precise point", "", &scene.Point{Lat: 40.7128, Lng: -74.0060}, "dr5re"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := scene.NewInMemorySceneRepository()
+			handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+
+			body, err := json.Marshal(CreateSceneRequest{
+				Name:          "Precision Scene",
+				OwnerDID:      "did:plc:test123",
+				CoarseGeohash: tt.geohash,
+				PrecisePoint:  tt.precisePoint,
+			})
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+			handlers.CreateScene(w, req)
+			if w.Code != http.StatusCreated {
+				t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var created scene.Scene
+			if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			stored, err := repo.GetByID(t.Context(), created.ID)
+			if err != nil {
+				t.Fatalf("failed to get stored scene: %v", err)
+			}
+			if stored.CoarseGeohash != tt.wantGeohash {
+				t.Errorf("expected stored geohash %q, got %q", tt.wantGeohash, stored.CoarseGeohash)
+			}
+		})
+	}
+}
+
+// TestCreateScene_TooManyTags tests that exceeding the tag limit is rejected.
+func TestCreateScene_TooManyTags(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+
+	tags := make([]string, scene.MaxTags+1)
+	for i := range tags {
+		tags[i] = fmt.Sprintf("tag%d", i)
+	}
+	reqBody := CreateSceneRequest{
+		Name:          "Over Tagged Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+		Tags:          tags,
+	}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	handlers.CreateScene(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeValidation {
+		t.Errorf("expected error code %s, got %s", ErrCodeValidation, errResp.Error.Code)
+	}
+}
+
+// TestUpdateScene_TagTooLong tests that an over-length tag is rejected on update.
+func TestUpdateScene_TagTooLong(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+
+	if err := repo.Insert(t.Context(), &scene.Scene{ID: "88888888-8888-4888-8888-888888888888", Name: "Tag Scene", OwnerDID: "did:plc:test123", CoarseGeohash: "dr5regw"}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	body, err := json.Marshal(UpdateSceneRequest{Tags: []string{strings.Repeat("x", scene.MaxTagLength+1)}})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/88888888-8888-4888-8888-888888888888", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	handlers.UpdateScene(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// TestUpdateScenePalette_ContrastMessage tests that the computed ratio is surfaced in the error.
+func TestUpdateScenePalette_ContrastMessage(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
+
+	now := time.Now()
+	testScene := &scene.Scene{
+		ID:            "11111111-1111-4111-8111-111111111111",
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+		Visibility:    "public",
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}
+	if err := repo.Insert(t.Context(), testScene); err != nil {
+		t.Fatalf("failed to insert test scene: %v", err)
+	}
+
+	reqBody := UpdateScenePaletteRequest{
+		Palette: scene.Palette{
+			Primary:    "#ff0000",
+			Secondary:  "#00ff00",
+			Accent:     "#0000ff",
+			Background: "#ffffff",
+			Text:       "#cccccc",
+		},
+	}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/11111111-1111-4111-8111-111111111111/palette", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+
+	handlers.UpdateScenePalette(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if !strings.Contains(errResp.Error.Message, "contrast 1.6 is below required 4.5") {
+		t.Errorf("expected message to contain computed ratio, got %q", errResp.Error.Message)
+	}
+
+	// Palette must be unchanged after rejection
+	stored, err := repo.GetByID(t.Context(), "11111111-1111-4111-8111-111111111111")
+	if err != nil {
+		t.Fatalf("failed to get scene: %v", err)
+	}
+	if stored.Palette != nil {
+		t.Errorf("expected palette to remain unset, got %+v", stored.Palette)
+	}
+}
+
+// TestUpdateScenePalette_ShorthandHexNormalized tests that #RGB colors are accepted and stored as #RRGGBB.
+func TestUpdateScenePalette_ShorthandHexNormalized(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
+
+	now := time.Now()
+	testScene := &scene.Scene{
+		ID:            "11111111-1111-4111-8111-111111111111",
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+		Visibility:    "public",
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}
+	if err := repo.Insert(t.Context(), testScene); err != nil {
+		t.Fatalf("failed to insert test scene: %v", err)
+	}
+
+	reqBody := UpdateScenePaletteRequest{
+		Palette: scene.Palette{
+			Primary:    "#f00",
+			Secondary:  "#0f0",
+			Accent:     "#00F",
+			Background: "#fff",
+			Text:       "#000",
+		},
+	}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/11111111-1111-4111-8111-111111111111/palette", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+
+	handlers.UpdateScenePalette(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	stored, err := repo.GetByID(t.Context(), "11111111-1111-4111-8111-111111111111")
+	if err != nil {
+		t.Fatalf("failed to get scene: %v", err)
+	}
+	want := scene.Palette{
+		Primary:    "#ff0000",
+		Secondary:  "#00ff00",
+		Accent:     "#0000FF",
+		Background: "#ffffff",
+		Text:       "#000000",
+	}
+	if stored.Palette == nil || *stored.Palette != want {
+		t.Errorf("expected stored palette %+v, got %+v", want, stored.Palette)
+	}
+}
+
+// seedDiscoverScenes seeds scenes and their trust scores in trustStore around the dr5regw cell. near-low and near-high share a cell, far sits in
+// a neighbouring cell, and hidden shares the near cell but is never listed.
+// near-low is tagged techno, near-high techno and warehouse, and far house.
+// Returns the center of dr5regw.
+func seedDiscoverScenes(t *testing.T, env *testEnv, trustStore *trust.InMemoryScoreStore) geo.Point {
+	t.Helper()
+
+	scenes := []struct {
+		id         string
+		geohash    string
+		visibility string
+		trust      float64
+		tags       []string
+	}{
+		{"near-low", "dr5regw", scene.VisibilityPublic, 0.2, []string{"techno"}},
+		{"near-high", "dr5regw", scene.VisibilityPublic, 0.9, []string{"techno", "warehouse"}},
+		{"far", "dr5regy", scene.VisibilityPublic, 1.0, []string{"house"}},
+		{"hidden", "dr5regw", scene.VisibilityHidden, 1.0, []string{"techno"}},
+	}
+	for _, sc := range scenes {
+		env.addScene(t, &scene.Scene{ID: sc.id, Name: "Scene " + sc.id, CoarseGeohash: sc.geohash, Visibility: sc.visibility, Tags: sc.tags})
+		if err := trustStore.SaveScore(trust.SceneTrustScore{SceneID: sc.id, Score: sc.trust}); err != nil {
+			t.Fatalf("failed to save trust score: %v", err)
+		}
+	}
+
+	center, ok := geo.Decode("dr5regw")
+	if !ok {
+		t.Fatal("failed to decode fixture geohash")
+	}
+	return center
+}
+
+// discoverSceneIDs calls DiscoverScenes with the given query and returns the scene IDs in order.
+func discoverSceneIDs(t *testing.T, handlers *SceneHandlers, query string) []string {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/scenes/discover?"+query, nil)
+	w := httptest.NewRecorder()
+	handlers.DiscoverScenes(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp DiscoverScenesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	ids := make([]string, len(resp.Scenes))
+	for i, sc := range resp.Scenes {
+		ids[i] = sc.ID
+	}
+	return ids
+}
+
+// TestDiscoverScenes_SortModes tests ordering for each sort mode, including
+// that scenes at equal distance are ordered by trust.
+func TestDiscoverScenes_SortModes(t *testing.T) {
+	env := newTestEnv()
+	trustStore := trust.NewInMemoryScoreStore()
+	center := seedDiscoverScenes(t, env, trustStore)
+	handlers := env.sceneHandlers()
+	handlers.SetTrustScoreStore(trustStore)
+	base := fmt.Sprintf("lat=%f&lng=%f&radius_m=1000", center.Lat, center.Lng)
+
+	tests := []struct {
+		name        string
+		query       string
+		trustWeight float64
+		want        []string
+	}{
+		{"distance breaks ties by ID", base + "&sort=distance", DefaultDiscoveryTrustWeight, []string{"near-high", "near-low", "far"}},
+		{"trust", base + "&sort=trust", DefaultDiscoveryTrustWeight, []string{"far", "near-high", "near-low"}},
+		{"blended default", base, DefaultDiscoveryTrustWeight, []string{"near-high", "far", "near-low"}},
+		{"blended trust only", base + "&sort=blended", 1, []string{"far", "near-high", "near-low"}},
+		{"blended proximity only orders equal distance by ID", base + "&sort=blended", 0, []string{"near-high", "near-low", "far"}},
+		{"limit", base + "&sort=trust&limit=1", DefaultDiscoveryTrustWeight, []string{"far"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers.SetDiscoveryTrustWeight(tt.trustWeight)
+			got := discoverSceneIDs(t, handlers, tt.query)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected order %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestDiscoverScenes_TagFilter tests tag_match=all requiring every tag and
+// tag_match=any matching a single overlap.
+func TestDiscoverScenes_TagFilter(t *testing.T) {
+	env := newTestEnv()
+	trustStore := trust.NewInMemoryScoreStore()
+	center := seedDiscoverScenes(t, env, trustStore)
+	handlers := env.sceneHandlers()
+	handlers.SetTrustScoreStore(trustStore)
+	base := fmt.Sprintf("lat=%f&lng=%f&radius_m=1000&sort=distance", center.Lat, center.Lng)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"all requires every tag", base + "&tags=techno,warehouse&tag_match=all", []string{"near-high"}},
+		{"all is normalized", base + "&tags=%20Techno%20,WAREHOUSE&tag_match=all", []string{"near-high"}},
+		{"any matches a single overlap", base + "&tags=warehouse,house&tag_match=any", []string{"near-high", "far"}},
+		{"any is the default", base + "&tags=techno", []string{"near-high", "near-low"}},
+		{"no match", base + "&tags=jazz", []string{}},
+		{"empty tags apply no filter", base + "&tags=&tag_match=all", []string{"near-high", "near-low", "far"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := discoverSceneIDs(t, handlers, tt.query)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestDiscoverScenes_EqualDistanceOrderedByTrust tests that trust decides
+// between two scenes at the same distance in blended mode.
+func TestDiscoverScenes_EqualDistanceOrderedByTrust(t *testing.T) {
+	env := newTestEnv()
+	trustStore := trust.NewInMemoryScoreStore()
+	center := seedDiscoverScenes(t, env, trustStore)
+	handlers := env.sceneHandlers()
+	handlers.SetTrustScoreStore(trustStore)
+
+	got := discoverSceneIDs(t, handlers, fmt.Sprintf("lat=%f&lng=%f&radius_m=100&sort=blended", center.Lat, center.Lng))
+	want := []string{"near-high", "near-low"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected order %v, got %v", want, got)
+	}
+}
+
+// TestDiscoverScenes_ExcludesHiddenForOwner tests that hidden scenes are never
+// returned by discovery, even to their owner.
+func TestDiscoverScenes_ExcludesHiddenForOwner(t *testing.T) {
+	env := newTestEnv()
+	trustStore := trust.NewInMemoryScoreStore()
+	center := seedDiscoverScenes(t, env, trustStore)
+	handlers := env.sceneHandlers()
+	handlers.SetTrustScoreStore(trustStore)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/scenes/discover?lat=%f&lng=%f&radius_m=1000", center.Lat, center.Lng), nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner"))
+	w := httptest.NewRecorder()
+	handlers.DiscoverScenes(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp DiscoverScenesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	for _, sc := range resp.Scenes {
+		if sc.ID == "hidden" {
+			t.Error("expected hidden scene to be excluded from discovery")
+		}
+	}
+}
+
+// TestDiscoverScenes_InvalidParams tests validation of discovery query parameters.
+func TestDiscoverScenes_InvalidParams(t *testing.T) {
+	env := newTestEnv()
+	trustStore := trust.NewInMemoryScoreStore()
+	seedDiscoverScenes(t, env, trustStore)
+	handlers := env.sceneHandlers()
+	handlers.SetTrustScoreStore(trustStore)
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing radius", "lat=40.7&lng=-74.0"},
+		{"invalid lat", "lat=abc&lng=-74.0&radius_m=1000"},
+		{"lat out of range", "lat=91&lng=-74.0&radius_m=1000"},
+		{"radius too large", "lat=40.7&lng=-74.0&radius_m=100001"},
+		{"unknown sort", "lat=40.7&lng=-74.0&radius_m=1000&sort=popular"},
+		{"limit out of range", "lat=40.7&lng=-74.0&radius_m=1000&limit=0"},
+		{"unknown tag_match", "lat=40.7&lng=-74.0&radius_m=1000&tags=techno&tag_match=some"},
+		{"too many tags", "lat=40.7&lng=-74.0&radius_m=1000&tags=a,b,c,d,e,f,g,h,i,j,k"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/scenes/discover?"+tt.query, nil)
+			w := httptest.NewRecorder()
+			handlers.DiscoverScenes(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestSceneHandlers_SearchIndexLifecycle tests that scene writes keep the search index in sync.
+func TestSceneHandlers_SearchIndexLifecycle(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+	index := search.NewIndexer()
+	handlers.SetSearchIndex(index)
+
+	body, _ := json.Marshal(CreateSceneRequest{
+		Name:          "Basement Jazz",
+		OwnerDID:      "did:plc:owner",
+		CoarseGeohash: "dr5regw",
+		Tags:          []string{"bebop"},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	handlers.CreateScene(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created scene.Scene
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if results, _ := index.Search(context.Background(), "bebop", 0); len(results) != 1 || results[0].ID != created.ID {
+		t.Fatalf("expected created scene to be searchable by tag, got %v", results)
+	}
+
+	// Hiding the scene removes it from search
+	hidden := scene.VisibilityHidden
+	body, _ = json.Marshal(UpdateSceneRequest{Visibility: &hidden})
+	req = httptest.NewRequest(http.MethodPatch, "/scenes/"+created.ID, bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	handlers.UpdateScene(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if results, _ := index.Search(context.Background(), "jazz", 0); len(results) != 0 {
+		t.Errorf("expected hidden scene to be excluded from search, got %v", results)
+	}
+
+	// Making it public again and then deleting it removes it for good
+	public := scene.VisibilityPublic
+	body, _ = json.Marshal(UpdateSceneRequest{Visibility: &public})
+	req = httptest.NewRequest(http.MethodPatch, "/scenes/"+created.ID, bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	handlers.UpdateScene(w, req)
+	if results, _ := index.Search(context.Background(), "jazz", 0); len(results) != 1 {
+		t.Fatalf("expected public scene to be searchable, got %v", results)
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, "/scenes/"+created.ID, nil)
+	w = httptest.NewRecorder()
+	handlers.DeleteScene(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", w.Code)
+	}
+	if results, _ := index.Search(context.Background(), "jazz", 0); len(results) != 0 {
+		t.Errorf("expected deleted scene to be excluded from search, got %v", results)
+	}
+}
+
+// seedTransferScene seeds scene-transfer owned by did:plc:owner, with active
+// member did:plc:member and pending member did:plc:pending.
+func seedTransferScene(t *testing.T, env *testEnv) {
+	t.Helper()
+	now := time.Now()
+	env.addScene(t, &scene.Scene{ID: "scene-transfer", Name: "Transfer Scene", CreatedAt: &now, UpdatedAt: &now})
+	env.addMember(t, "scene-transfer", "did:plc:member", "member", "active")
+	env.addMember(t, "scene-transfer", "did:plc:pending", "member", "pending")
+}
+
+func doTransfer(handlers *SceneHandlers, callerDID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/scenes/scene-transfer/transfer", strings.NewReader(body))
+	if callerDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), callerDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.TransferOwnership(w, req)
+	return w
+}
+
+// TestTransferOwnership_Success tests that a transfer updates OwnerDID, keeps
+// the previous owner as an admin member, and records an audit entry.
+func TestTransferOwnership_Success(t *testing.T) {
+	env := newTestEnv()
+	seedTransferScene(t, env)
+	handlers := env.sceneHandlers()
+	handlers.SetAuditRepository(env.audit)
+
+	w := doTransfer(handlers, "did:plc:owner", `{"new_owner_did":"did:plc:member"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp scene.Scene
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.OwnerDID != "did:plc:member" {
+		t.Errorf("expected response owner did:plc:member, got %s", resp.OwnerDID)
+	}
+
+	stored, err := env.scenes.GetByID(t.Context(), "scene-transfer")
+	if err != nil {
+		t.Fatalf("failed to get scene: %v", err)
+	}
+	if stored.OwnerDID != "did:plc:member" {
+		t.Errorf("expected stored owner did:plc:member, got %s", stored.OwnerDID)
+	}
+
+	previous, err := env.memberships.GetBySceneAndUser(t.Context(), "scene-transfer", "did:plc:owner")
+	if err != nil {
+		t.Fatalf("expected previous owner membership, got error: %v", err)
+	}
+	if previous.Role != "admin" || previous.Status != "active" {
+		t.Errorf("expected previous owner to be an active admin, got role=%s status=%s", previous.Role, previous.Status)
+	}
+
+	logs, err := env.audit.QueryByEntity(t.Context(), "scene", "scene-transfer", 0)
+	if err != nil {
+		t.Fatalf("failed to query audit logs: %v", err)
+	}
+	if len(logs) != 1 || logs[0].Action != "scene_transfer" || logs[0].UserDID != "did:plc:owner" {
+		t.Errorf("expected one scene_transfer audit entry by did:plc:owner, got %+v", logs)
+	}
+
+	// The previous owner can no longer transfer
+	w = doTransfer(handlers, "did:plc:owner", `{"new_owner_did":"did:plc:member"}`)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for previous owner, got %d", w.Code)
+	}
+}
+
+// TestTransferOwnership_PreviousOwnerAsMember tests demoting the previous owner to a plain member.
+func TestTransferOwnership_PreviousOwnerAsMember(t *testing.T) {
+	env := newTestEnv()
+	seedTransferScene(t, env)
+	handlers := env.sceneHandlers()
+	handlers.SetAuditRepository(env.audit)
+
+	w := doTransfer(handlers, "did:plc:owner", `{"new_owner_did":"did:plc:member","previous_owner_role":"member"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	previous, err := env.memberships.GetBySceneAndUser(t.Context(), "scene-transfer", "did:plc:owner")
+	if err != nil {
+		t.Fatalf("expected previous owner membership, got error: %v", err)
+	}
+	if previous.Role != "member" {
+		t.Errorf("expected previous owner role member, got %s", previous.Role)
+	}
+}
+
+// TestTransferOwnership_Rejections tests owner-only enforcement and target validation.
+func TestTransferOwnership_Rejections(t *testing.T) {
+	tests := []struct {
+		name       string
+		callerDID  string
+		body       string
+		wantStatus int
+		wantCode   string
+	}{
+		{"unauthenticated", "", `{"new_owner_did":"did:plc:member"}`, http.StatusUnauthorized, ErrCodeAuthFailed},
+		{"non-owner", "did:plc:member", `{"new_owner_did":"did:plc:member"}`, http.StatusForbidden, ErrCodeForbidden},
+		{"non-member target", "did:plc:owner", `{"new_owner_did":"did:plc:stranger"}`, http.StatusBadRequest, ErrCodeValidation},
+		{"pending member target", "did:plc:owner", `{"new_owner_did":"did:plc:pending"}`, http.StatusBadRequest, ErrCodeValidation},
+		{"self transfer", "did:plc:owner", `{"new_owner_did":"did:plc:owner"}`, http.StatusBadRequest, ErrCodeValidation},
+		{"missing target", "did:plc:owner", `{}`, http.StatusBadRequest, ErrCodeValidation},
+		{"invalid previous role", "did:plc:owner", `{"new_owner_did":"did:plc:member","previous_owner_role":"owner"}`, http.StatusBadRequest, ErrCodeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv()
+			seedTransferScene(t, env)
+			handlers := env.sceneHandlers()
+			handlers.SetAuditRepository(env.audit)
+
+			w := doTransfer(handlers, tt.callerDID, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != tt.wantCode {
+				t.Errorf("expected error code %q, got %q", tt.wantCode, errResp.Error.Code)
+			}
+
+			stored, err := env.scenes.GetByID(t.Context(), "scene-transfer")
+			if err != nil {
+				t.Fatalf("failed to get scene: %v", err)
+			}
+			if stored.OwnerDID != "did:plc:owner" {
+				t.Errorf("expected owner to be unchanged, got %s", stored.OwnerDID)
+			}
+			if logs, _ := env.audit.QueryByEntity(t.Context(), "scene", "scene-transfer", 0); len(logs) != 0 {
+				t.Errorf("expected no audit entries, got %d", len(logs))
+			}
+		})
+	}
+}
+
+// TestTransferOwnership_DeletedScene tests that deleted scenes cannot be transferred.
+func TestTransferOwnership_DeletedScene(t *testing.T) {
+	env := newTestEnv()
+	seedTransferScene(t, env)
+	handlers := env.sceneHandlers()
+	handlers.SetAuditRepository(env.audit)
+	if err := env.scenes.Delete(t.Context(), "scene-transfer"); err != nil {
+		t.Fatalf("failed to delete scene: %v", err)
+	}
+
+	w := doTransfer(handlers, "did:plc:owner", `{"new_owner_did":"did:plc:member"}`)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", w.Code)
+	}
+
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeSceneDeleted {
+		t.Errorf("expected error code %q, got %q", ErrCodeSceneDeleted, errResp.Error.Code)
+	}
+}
+
+// racingSceneRepository simulates a concurrent edit that lands just before
+// every Update, so each Update fails with scene.ErrVersionConflict.
+type racingSceneRepository struct {
+	*scene.InMemorySceneRepository
+}
+
+func (r racingSceneRepository) Update(ctx context.Context, s *scene.Scene) error {
+	current, err := r.InMemorySceneRepository.GetByID(ctx, s.ID)
+	if err != nil {
+		return err
+	}
+	current.Version = 0
+	if err := r.InMemorySceneRepository.Update(ctx, current); err != nil {
+		return err
+	}
+	return r.InMemorySceneRepository.Update(ctx, s)
+}
+
+// TestTransferOwnership_VersionConflict tests that a transfer racing another
+// edit returns 409 and leaves the previous owner's membership as it was.
+func TestTransferOwnership_VersionConflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *membership.Membership // Previous owner's membership before the transfer, if any
+	}{
+		{name: "no membership"},
+		{name: "pending membership", existing: &membership.Membership{SceneID: "scene-transfer", UserDID: "did:plc:owner", Role: "member", Status: "pending"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv()
+			seedTransferScene(t, env)
+			handlers := NewSceneHandlers(racingSceneRepository{env.scenes}, env.memberships, env.streams)
+			if tt.existing != nil {
+				if _, err := env.memberships.Upsert(t.Context(), tt.existing); err != nil {
+					t.Fatalf("failed to insert membership: %v", err)
+				}
+			}
+
+			w := doTransfer(handlers, "did:plc:owner", `{"new_owner_did":"did:plc:member"}`)
+			if w.Code != http.StatusConflict {
+				t.Fatalf("expected status 409, got %d: %s", w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != ErrCodeConflict {
+				t.Errorf("expected error code %q, got %q", ErrCodeConflict, errResp.Error.Code)
+			}
+
+			stored, err := env.scenes.GetByID(t.Context(), "scene-transfer")
+			if err != nil {
+				t.Fatalf("failed to get scene: %v", err)
+			}
+			if stored.OwnerDID != "did:plc:owner" {
+				t.Errorf("expected owner to stay did:plc:owner, got %s", stored.OwnerDID)
+			}
+
+			previous, err := env.memberships.GetBySceneAndUser(t.Context(), "scene-transfer", "did:plc:owner")
+			if tt.existing == nil {
+				if err != membership.ErrMembershipNotFound {
+					t.Errorf("expected the created membership to be removed, got %+v (err %v)", previous, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected previous owner membership, got error: %v", err)
+			}
+			if previous.Role != "member" || previous.Status != "pending" {
+				t.Errorf("expected membership restored to pending member, got role=%s status=%s", previous.Role, previous.Status)
+			}
+		})
+	}
+}
+
+// TestTransferOwnership_DuplicateNameForNewOwner tests that the new owner's
+// per-owner scene name uniqueness is preserved.
+func TestTransferOwnership_DuplicateNameForNewOwner(t *testing.T) {
+	env := newTestEnv()
+	seedTransferScene(t, env)
+	handlers := env.sceneHandlers()
+	handlers.SetAuditRepository(env.audit)
+	now := time.Now()
+	if err := env.scenes.Insert(t.Context(), &scene.Scene{
+		ID:            "scene-member-own",
+		Name:          "transfer scene",
+		OwnerDID:      "did:plc:member",
+		CoarseGeohash: "dr5regw",
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	w := doTransfer(handlers, "did:plc:owner", `{"new_owner_did":"did:plc:member"}`)
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d", w.Code)
+	}
+}
+
+// TestUpdateScene_AuditEntry tests that a successful update records exactly one
+// "update" audit entry for the scene.
+func TestUpdateScene_AuditEntry(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+	handlers.SetAuditRepository(auditRepo)
+
+	const sceneID = "11111111-1111-4111-8111-111111111111"
+	const otherID = "00000000-0000-4000-8000-000000000002"
+	now := time.Now()
+	for _, id := range []string{sceneID, otherID} {
+		if err := repo.Insert(t.Context(), &scene.Scene{ID: id, Name: "Scene " + id[:4], OwnerDID: "did:plc:test123", CoarseGeohash: "dr5regw", CreatedAt: &now, UpdatedAt: &now}); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/"+sceneID, strings.NewReader(`{"description":"Updated"}`))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+	handlers.UpdateScene(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	logs, err := auditRepo.QueryByEntity(t.Context(), "scene", sceneID, 0)
+	if err != nil {
+		t.Fatalf("failed to query audit logs: %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("expected exactly 1 audit entry, got %d", len(logs))
+	}
+	if logs[0].Action != "update" || logs[0].EntityID != sceneID || logs[0].UserDID != "did:plc:test123" {
+		t.Errorf("unexpected audit entry: %+v", logs[0])
+	}
+
+	if other, _ := auditRepo.QueryByEntity(t.Context(), "scene", otherID, 0); len(other) != 0 {
+		t.Errorf("expected no audit entries for untouched scene, got %d", len(other))
+	}
+}
+
+// TestSceneMutations_AuditTrail tests that create, update, palette update, and
+// delete each leave an audit entry, giving a complete change history.
+func TestSceneMutations_AuditTrail(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+	handlers.SetAuditRepository(auditRepo)
+
+	withOwner := func(req *http.Request) *http.Request {
+		return req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	}
+
+	w := httptest.NewRecorder()
+	handlers.CreateScene(w, withOwner(httptest.NewRequest(http.MethodPost, "/scenes",
+		strings.NewReader(`{"name":"Audited Scene","owner_did":"did:plc:test123","coarse_geohash":"dr5regw"}`))))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created scene.Scene
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode created scene: %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	handlers.UpdateScene(w, withOwner(httptest.NewRequest(http.MethodPatch, "/scenes/"+created.ID,
+		strings.NewReader(`{"description":"Updated"}`))))
+	if w.Code != http.StatusOK {
+		t.Fatalf("update: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handlers.UpdateScenePalette(w, withOwner(httptest.NewRequest(http.MethodPatch, "/scenes/"+created.ID+"/palette",
+		strings.NewReader(`{"palette":{"primary":"#ff0000","secondary":"#00ff00","accent":"#0000ff","background":"#ffffff","text":"#000000"}}`))))
+	if w.Code != http.StatusOK {
+		t.Fatalf("palette: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handlers.DeleteScene(w, withOwner(httptest.NewRequest(http.MethodDelete, "/scenes/"+created.ID, nil)))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("delete: expected status 204, got %d", w.Code)
+	}
+
+	logs, err := auditRepo.QueryByEntity(t.Context(), "scene", created.ID, 0)
+	if err != nil {
+		t.Fatalf("failed to query audit logs: %v", err)
+	}
+	got := make(map[string]int)
+	for _, entry := range logs {
+		got[entry.Action]++
+	}
+	for _, action := range []string{"create", "update", "palette_update", "delete"} {
+		if got[action] != 1 {
+			t.Errorf("expected 1 %q audit entry, got %d", action, got[action])
+		}
+	}
+	if len(logs) != 4 {
+		t.Errorf("expected 4 audit entries, got %d", len(logs))
+	}
+}
+
+// failingAuditRepository is an audit repository whose writes always fail.
+type failingAuditRepository struct {
+	audit.Repository
+}
+
+func (failingAuditRepository) LogAccess(context.Context, audit.LogEntry) (*audit.AuditLog, error) {
+	return nil, errors.New("audit store unavailable")
+}
+
+// TestUpdateScene_AuditFailureDoesNotFailRequest tests that audit write errors
+// are logged and the update still succeeds.
+func TestUpdateScene_AuditFailureDoesNotFailRequest(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+	handlers.SetAuditRepository(failingAuditRepository{})
+
+	const sceneID = "11111111-1111-4111-8111-111111111111"
+	now := time.Now()
+	if err := repo.Insert(t.Context(), &scene.Scene{ID: sceneID, Name: "Scene", OwnerDID: "did:plc:test123", CoarseGeohash: "dr5regw", CreatedAt: &now, UpdatedAt: &now}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/"+sceneID, strings.NewReader(`{"description":"Updated"}`))
+	w := httptest.NewRecorder()
+	handlers.UpdateScene(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 despite audit failure, got %d: %s", w.Code, w.Body.String())
+	}
+	stored, err := repo.GetByID(t.Context(), sceneID)
+	if err != nil {
+		t.Fatalf("failed to get scene: %v", err)
+	}
+	if stored.Description != "Updated" {
+		t.Errorf("expected update to be applied, got description %q", stored.Description)
+	}
+}
+
+// TestCreateScene_IdempotencyKey tests that a retried create with the same
+// Idempotency-Key replays the original response instead of creating a duplicate.
+func TestCreateScene_IdempotencyKey(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+	handler := middleware.Idempotency(middleware.NewInMemoryIdempotencyStore(), middleware.DefaultIdempotencyTTL)(http.HandlerFunc(handlers.CreateScene))
+
+	body, err := json.Marshal(CreateSceneRequest{
+		Name:          "Retry Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	var responses []*httptest.ResponseRecorder
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body))
+		req.Header.Set(middleware.IdempotencyKeyHeader, "create-scene-1")
+		req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("request %d: expected status 201, got %d: %s", i+1, w.Code, w.Body.String())
+		}
+		responses = append(responses, w)
+	}
+
+	if responses[0].Body.String() != responses[1].Body.String() {
+		t.Errorf("expected identical responses, got %q and %q", responses[0].Body.String(), responses[1].Body.String())
+	}
+	if responses[1].Header().Get(middleware.IdempotentReplayedHeader) != "true" {
+		t.Error("expected retried response to be marked as replayed")
+	}
+
+	scenes, err := repo.ListByOwner(t.Context(), "did:plc:test123")
+	if err != nil {
+		t.Fatalf("failed to list scenes: %v", err)
+	}
+	if len(scenes) != 1 {
+		t.Errorf("expected exactly 1 scene, got %d", len(scenes))
+	}
+}
+
+// doUpdateThemePalette sends a palette update for the given theme ("" for the
+// default) as the scene owner.
+func doUpdateThemePalette(t *testing.T, handlers *SceneHandlers, sceneID, theme string, palette scene.Palette) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(UpdateScenePaletteRequest{Palette: palette})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	target := "/scenes/" + sceneID + "/palette"
+	if theme != "" {
+		target += "?theme=" + theme
+	}
+	req := httptest.NewRequest(http.MethodPatch, target, bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+	handlers.UpdateScenePalette(w, req)
+	return w
+}
+
+// TestUpdateScenePalette_DarkThemeKeepsLight tests that setting the dark
+// palette leaves the light palette and the legacy palette field unchanged.
+func TestUpdateScenePalette_DarkThemeKeepsLight(t *testing.T) {
+	env := newTestEnv()
+	sceneID := env.addScene(t, &scene.Scene{ID: "12121212-1212-4121-8121-121212121212", Name: "Themed Scene", OwnerDID: "did:plc:test123", Visibility: scene.VisibilityPublic}).ID
+	handlers := env.sceneHandlers()
+	light := scene.Palette{Primary: "#ff0000", Secondary: "#00ff00", Accent: "#0000ff", Background: "#ffffff", Text: "#000000"}
+	dark := scene.Palette{Primary: "#ff8800", Secondary: "#88ff00", Accent: "#0088ff", Background: "#111111", Text: "#eeeeee"}
+
+	if w := doUpdateThemePalette(t, handlers, sceneID, "", light); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for light palette, got %d: %s", w.Code, w.Body.String())
+	}
+	w := doUpdateThemePalette(t, handlers, sceneID, scene.ThemeDark, dark)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for dark palette, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp scene.Scene
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Palette == nil || *resp.Palette != light {
+		t.Errorf("expected legacy palette to stay light, got %+v", resp.Palette)
+	}
+
+	stored, err := env.scenes.GetByID(t.Context(), sceneID)
+	if err != nil {
+		t.Fatalf("failed to get scene: %v", err)
+	}
+	if p := stored.PaletteFor(scene.ThemeLight); p == nil || *p != light {
+		t.Errorf("expected light palette %+v, got %+v", light, p)
+	}
+	if p := stored.PaletteFor(scene.ThemeDark); p == nil || *p != dark {
+		t.Errorf("expected dark palette %+v, got %+v", dark, p)
+	}
+}
+
+// TestUpdateScenePalette_ContrastPerTheme tests that each theme's palette is
+// contrast-validated on its own.
+func TestUpdateScenePalette_ContrastPerTheme(t *testing.T) {
+	env := newTestEnv()
+	sceneID := env.addScene(t, &scene.Scene{ID: "12121212-1212-4121-8121-121212121212", Name: "Themed Scene", OwnerDID: "did:plc:test123", Visibility: scene.VisibilityPublic}).ID
+	handlers := env.sceneHandlers()
+	light := scene.Palette{Primary: "#ff0000", Secondary: "#00ff00", Accent: "#0000ff", Background: "#ffffff", Text: "#000000"}
+	lowContrastDark := scene.Palette{Primary: "#ff8800", Secondary: "#88ff00", Accent: "#0088ff", Background: "#111111", Text: "#333333"}
+
+	if w := doUpdateThemePalette(t, handlers, sceneID, scene.ThemeLight, light); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for light palette, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w := doUpdateThemePalette(t, handlers, sceneID, scene.ThemeDark, lowContrastDark)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for low-contrast dark palette, got %d: %s", w.Code, w.Body.String())
+	}
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeInvalidPalette || !strings.Contains(errResp.Error.Message, "dark theme") {
+		t.Errorf("expected invalid_palette naming the dark theme, got %s: %q", errResp.Error.Code, errResp.Error.Message)
+	}
+
+	stored, err := env.scenes.GetByID(t.Context(), sceneID)
+	if err != nil {
+		t.Fatalf("failed to get scene: %v", err)
+	}
+	if p := stored.PaletteFor(scene.ThemeDark); p != nil {
+		t.Errorf("expected no dark palette after rejection, got %+v", p)
+	}
+	if p := stored.PaletteFor(scene.ThemeLight); p == nil || *p != light {
+		t.Errorf("expected light palette unaffected, got %+v", p)
+	}
+
+	// Unknown themes are rejected
+	if w := doUpdateThemePalette(t, handlers, sceneID, "sepia", light); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for unknown theme, got %d", w.Code)
+	}
+}
+
+// TestCreateScene_RecordRef tests that a scene can be linked to its AT Protocol
+// record, that malformed references are rejected, and that a record backs at
+// most one scene.
+func TestCreateScene_RecordRef(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
+
+	createScene := func(name string, recordDID, recordRKey *string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(CreateSceneRequest{
+			Name:          name,
+			OwnerDID:      "did:plc:test123",
+			CoarseGeohash: "dr5reg",
+			RecordDID:     recordDID,
+			RecordRKey:    recordRKey,
+		})
+		if err != nil {
+			t.Fatalf("failed to marshal request: %v", err)
+		}
+		req := httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		handlers.CreateScene(w, req)
+		return w
+	}
+
+	w := createScene("Linked Scene", ptrString("did:plc:test123"), ptrString("3jzfcijpj2z2a"))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created scene.Scene
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	stored, err := repo.GetByRecordKey(t.Context(), "did:plc:test123", "3jzfcijpj2z2a")
+	if err != nil {
+		t.Fatalf("GetByRecordKey failed: %v", err)
+	}
+	if stored.ID != created.ID {
+		t.Errorf("expected record key to resolve to scene %s, got %s", created.ID, stored.ID)
+	}
+
+	if w := createScene("Second Scene", ptrString("did:plc:test123"), ptrString("3jzfcijpj2z2a")); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for an already linked record, got %d: %s", w.Code, w.Body.String())
+	}
+
+	tests := []struct {
+		name       string
+		recordDID  *string
+		recordRKey *string
+	}{
+		{"missing rkey", ptrString("did:plc:test123"), nil},
+		{"missing DID", nil, ptrString("3jzfcijpj2z2a")},
+		{"unsupported DID method", ptrString("did:key:z6Mkabc"), ptrString("3jzfcijpj2z2a")},
+		{"bare handle", ptrString("alice.example.com"), ptrString("3jzfcijpj2z2a")},
+		{"reserved rkey", ptrString("did:web:example.com"), ptrString("..")},
+		{"rkey with slash", ptrString("did:web:example.com"), ptrString("a/b")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := createScene("Invalid Record Scene", tt.recordDID, tt.recordRKey)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != ErrCodeValidation {
+				t.Errorf("expected error code %s, got %s", ErrCodeValidation, errResp.Error.Code)
+			}
+		})
+	}
+}
+
+// TestGetSceneBySlug tests retrieving scenes by owner DID and slug.
+func TestGetSceneBySlug(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+
+	for _, s := range []*scene.Scene{
+		{ID: "61111111-1111-4111-8111-111111111111", Name: "Warehouse Nights", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityPublic},
+		{ID: "62222222-2222-4222-8222-222222222222", Name: "Warehouse Nights", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityHidden},
+		{ID: "63333333-3333-4333-8333-333333333333", Name: "Closed Loft", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityPublic},
+	} {
+		if err := repo.Insert(t.Context(), s); err != nil {
+			t.Fatalf("failed to insert test scene: %v", err)
+		}
+	}
+	if err := repo.Delete(t.Context(), "63333333-3333-4333-8333-333333333333"); err != nil {
+		t.Fatalf("failed to delete test scene: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		path         string
+		requesterDID string
+		wantStatus   int
+		wantCode     string
+		wantID       string
+	}{
+		{"public scene", "/scenes/by-slug/did:plc:owner/warehouse-nights", "", http.StatusOK, "", "61111111-1111-4111-8111-111111111111"},
+		{"slug is case-insensitive", "/scenes/by-slug/did:plc:owner/Warehouse-Nights", "", http.StatusOK, "", "61111111-1111-4111-8111-111111111111"},
+		{"hidden scene for non-owner", "/scenes/by-slug/did:plc:owner/warehouse-nights-2", "did:plc:other", http.StatusNotFound, ErrCodeNotFound, ""},
+		{"hidden scene for owner", "/scenes/by-slug/did:plc:owner/warehouse-nights-2", "did:plc:owner", http.StatusOK, "", "62222222-2222-4222-8222-222222222222"},
+		{"deleted scene", "/scenes/by-slug/did:plc:owner/closed-loft", "", http.StatusNotFound, ErrCodeSceneDeleted, ""},
+		{"unknown slug", "/scenes/by-slug/did:plc:owner/nope", "", http.StatusNotFound, ErrCodeNotFound, ""},
+		{"other owner", "/scenes/by-slug/did:plc:someone/warehouse-nights", "", http.StatusNotFound, ErrCodeNotFound, ""},
+		{"invalid owner DID", "/scenes/by-slug/owner/warehouse-nights", "", http.StatusBadRequest, ErrCodeValidation, ""},
+		{"missing slug", "/scenes/by-slug/did:plc:owner", "", http.StatusBadRequest, ErrCodeBadRequest, ""},
+		{"nested slug", "/scenes/by-slug/did:plc:owner/warehouse/nights", "", http.StatusBadRequest, ErrCodeBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.requesterDID != "" {
+				req = req.WithContext(middleware.SetUserDID(req.Context(), tt.requesterDID))
+			}
+			w := httptest.NewRecorder()
+
+			handlers.GetSceneBySlug(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantCode != "" {
+				var errResp ErrorResponse
+				if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+					t.Fatalf("failed to decode error response: %v", err)
+				}
+				if errResp.Error.Code != tt.wantCode {
+					t.Errorf("expected error code %s, got %s", tt.wantCode, errResp.Error.Code)
+				}
+				return
+			}
+
+			var got scene.Scene
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.ID != tt.wantID {
+				t.Errorf("expected scene %s, got %s", tt.wantID, got.ID)
+			}
+		})
+	}
+}
+
+// TestCreateScene_Slug tests that created scenes are returned with a slug.
+func TestCreateScene_Slug(t *testing.T) {
+	handlers := NewSceneHandlers(scene.NewInMemorySceneRepository(), membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+
+	body, _ := json.Marshal(CreateSceneRequest{
+		Name:          "Bass_Dub. Collective",
+		OwnerDID:      "did:plc:owner",
+		CoarseGeohash: "dr5regw",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner"))
+	w := httptest.NewRecorder()
+
+	handlers.CreateScene(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created scene.Scene
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.Slug != "bass-dub-collective" {
+		t.Errorf("expected slug bass-dub-collective, got %q", created.Slug)
+	}
+}
+
+// TestUpdateScene_NameCollisions tests which names a scene may be renamed to
+// when other scenes of the same owner are live or soft-deleted.
+func TestUpdateScene_NameCollisions(t *testing.T) {
+	const renamingID = "71111111-1111-4111-8111-111111111111"
+
+	tests := []struct {
+		name       string
+		newName    string
+		wantStatus int
+		wantCode   string
+	}{
+		{"live scene's name rejected", "Live Scene", http.StatusConflict, ErrCodeDuplicateSceneName},
+		{"deleted scene's name allowed", "Deleted Scene", http.StatusOK, ""},
+		{"own name allowed", "My Scene", http.StatusOK, ""},
+		{"own name in another case allowed", "MY SCENE", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := scene.NewInMemorySceneRepository()
+			handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+			for _, s := range []*scene.Scene{
+				{ID: renamingID, Name: "My Scene", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw"},
+				{ID: "72222222-2222-4222-8222-222222222222", Name: "Live Scene", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw"},
+				{ID: "73333333-3333-4333-8333-333333333333", Name: "Deleted Scene", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw"},
+			} {
+				if err := repo.Insert(t.Context(), s); err != nil {
+					t.Fatalf("failed to insert scene: %v", err)
+				}
+			}
+			if err := repo.Delete(t.Context(), "73333333-3333-4333-8333-333333333333"); err != nil {
+				t.Fatalf("failed to delete scene: %v", err)
+			}
+
+			body, _ := json.Marshal(UpdateSceneRequest{Name: &tt.newName})
+			req := httptest.NewRequest(http.MethodPatch, "/scenes/"+renamingID, bytes.NewReader(body))
+			req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner"))
+			w := httptest.NewRecorder()
+
+			handlers.UpdateScene(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantCode != "" {
+				var errResp ErrorResponse
+				if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+					t.Fatalf("failed to decode error response: %v", err)
+				}
+				if errResp.Error.Code != tt.wantCode {
+					t.Errorf("expected error code %s, got %s", tt.wantCode, errResp.Error.Code)
+				}
+				return
+			}
+			updated, err := repo.GetByID(t.Context(), renamingID)
+			if err != nil {
+				t.Fatalf("failed to get scene: %v", err)
+			}
+			if updated.Name != tt.newName {
+				t.Errorf("expected name %q, got %q", tt.newName, updated.Name)
+			}
+		})
+	}
+}
+
+// TestUpdateScene_SoftDeleted tests that updating a soft-deleted scene
+// returns scene_deleted rather than an internal error.
+func TestUpdateScene_SoftDeleted(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+	const sceneID = "74444444-4444-4444-8444-444444444444"
+	if err := repo.Insert(t.Context(), &scene.Scene{ID: sceneID, Name: "Gone Scene", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw"}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+	if err := repo.Delete(t.Context(), sceneID); err != nil {
+		t.Fatalf("failed to delete scene: %v", err)
+	}
+
+	newName := "Back Again"
+	body, _ := json.Marshal(UpdateSceneRequest{Name: &newName})
+	req := httptest.NewRequest(http.MethodPatch, "/scenes/"+sceneID, bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner"))
+	w := httptest.NewRecorder()
+
+	handlers.UpdateScene(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d: %s", w.Code, w.Body.String())
+	}
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeSceneDeleted {
+		t.Errorf("expected error code %s, got %s", ErrCodeSceneDeleted, errResp.Error.Code)
+	}
+}
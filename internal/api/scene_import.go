@@ -0,0 +1,362 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/did"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// SceneImportResponse describes a scene recreated from an export bundle.
+type SceneImportResponse struct {
+	Scene          *scene.Scene `json:"scene"`
+	EventsImported int          `json:"events_imported"`
+	MembersInvited int          `json:"members_invited"`
+}
+
+// sceneImport holds the validated records to create for an import.
+type sceneImport struct {
+	scene   *scene.Scene
+	events  []*scene.Event
+	members []*membership.Membership
+}
+
+// ImportScene handles POST /scenes/import - recreates a scene from a bundle
+// produced by ExportScene. The scene, its events, and its series get new IDs
+// and the requester becomes the owner. Every field goes through the same
+// validation as scene and event creation, and the repositories enforce
+// location consent. AT Protocol record links are dropped since the records
+// still belong to the original scene.
+//
+// Members are not carried over as active: each active or pending member
+// becomes a pending invite with the member role, banned members stay banned,
+// and rejected requests are dropped. The bundle is validated in full before
+// anything is written.
+func (h *SceneHandlers) ImportScene(w http.ResponseWriter, r *http.Request) {
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	var bundle SceneExport
+	if err := decodeJSON(r, &bundle); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+	if bundle.Version != SceneExportVersion {
+		writeErrorf(w, r, ErrCodeValidation, "unsupported export version %d (expected %d)", bundle.Version, SceneExportVersion)
+		return
+	}
+	if bundle.Scene == nil {
+		writeError(w, r, ErrCodeValidation, "scene is required")
+		return
+	}
+	if len(bundle.Events) > 0 && h.eventRepo == nil {
+		writeError(w, r, ErrCodeValidation, "events cannot be imported on this server")
+		return
+	}
+
+	imported, code, errMsg := h.buildSceneImport(&bundle, userDID)
+	if errMsg != "" {
+		writeError(w, r, code, errMsg)
+		return
+	}
+
+	exists, err := h.repo.ExistsByOwnerAndName(r.Context(), userDID, imported.scene.Name, "")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to check duplicate scene name", "error", err, "owner_did", userDID, "name", imported.scene.Name)
+		writeError(w, r, ErrCodeInternal, "Failed to check for duplicate scene name")
+		return
+	}
+	if exists {
+		writeError(w, r, ErrCodeDuplicateSceneName, "Scene with this name already exists for this owner")
+		return
+	}
+
+	// Insert into repositories (will automatically enforce location consent)
+	if err := h.repo.Insert(r.Context(), imported.scene); err != nil {
+		slog.ErrorContext(r.Context(), "failed to insert imported scene", "error", err, "scene_id", imported.scene.ID)
+		writeError(w, r, ErrCodeInternal, "Failed to import scene")
+		return
+	}
+	for _, event := range imported.events {
+		if err := h.eventRepo.Insert(r.Context(), event); err != nil {
+			slog.ErrorContext(r.Context(), "failed to insert imported event", "error", err, "scene_id", imported.scene.ID, "event_id", event.ID)
+			writeError(w, r, ErrCodeInternal, "Failed to import scene events")
+			return
+		}
+	}
+	invited := 0
+	for _, m := range imported.members {
+		if _, err := h.membershipRepo.Upsert(r.Context(), m); err != nil {
+			slog.ErrorContext(r.Context(), "failed to insert imported membership", "error", err, "scene_id", imported.scene.ID)
+			writeError(w, r, ErrCodeInternal, "Failed to import scene members")
+			return
+		}
+		if m.Status == "pending" {
+			invited++
+		}
+	}
+
+	// Retrieve the stored scene to get privacy-enforced version
+	stored, err := h.repo.GetByID(r.Context(), imported.scene.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to retrieve imported scene", "error", err, "scene_id", imported.scene.ID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve imported scene")
+		return
+	}
+
+	h.logSceneAudit(r, stored.ID, "scene_import")
+
+	if h.searchIndex != nil {
+		h.searchIndex.IndexScene(stored)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(SceneImportResponse{
+		Scene:          stored,
+		EventsImported: len(imported.events),
+		MembersInvited: invited,
+	}); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
+
+// buildSceneImport validates a bundle and builds the records to create for
+// ownerDID. Stored text in the bundle is already HTML-escaped, so it is
+// unescaped before going through the creation validators and sanitizers.
+// Returns the error code and message on failure, empty message on success.
+func (h *SceneHandlers) buildSceneImport(bundle *SceneExport, ownerDID string) (*sceneImport, string, string) {
+	src := bundle.Scene
+	now := time.Now()
+	sceneID := uuid.New().String()
+
+	name := html.UnescapeString(src.Name)
+	if errMsg := validateSceneName(name); errMsg != "" {
+		return nil, ErrCodeInvalidSceneName, errMsg
+	}
+	description, err := sanitizeText(html.UnescapeString(src.Description))
+	if err != nil {
+		return nil, ErrCodeValidation, "description " + err.Error()
+	}
+	if errMsg := validateVisibility(src.Visibility); errMsg != "" {
+		return nil, ErrCodeValidation, errMsg
+	}
+	visibility := src.Visibility
+	if visibility == "" {
+		visibility = scene.VisibilityPublic
+	}
+	tags, errMsg := importTags(src.Tags)
+	if errMsg != "" {
+		return nil, ErrCodeValidation, errMsg
+	}
+
+	coarseGeohash, errMsg := resolveCoarseGeohash(src.CoarseGeohash, src.PrecisePoint)
+	if errMsg != "" {
+		return nil, ErrCodeValidation, errMsg
+	}
+	if h.jitterRadiusMeters > 0 && src.PrecisePoint != nil {
+		coarseGeohash = jitteredCoarseGeohash(sceneID, *src.PrecisePoint, h.jitterRadiusMeters, len(coarseGeohash))
+	}
+
+	newScene := &scene.Scene{
+		ID:            sceneID,
+		Name:          sanitizeSceneName(name),
+		Description:   description,
+		OwnerDID:      ownerDID,
+		AllowPrecise:  src.AllowPrecise,
+		PrecisePoint:  src.PrecisePoint,
+		CoarseGeohash: coarseGeohash,
+		Tags:          tags,
+		Visibility:    visibility,
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}
+
+	// Scenes exported before themes existed only have the legacy palette
+	palettes := make(map[string]*scene.Palette, len(src.Palettes)+1)
+	for theme, p := range src.Palettes {
+		palettes[theme] = p
+	}
+	if src.Palette != nil && palettes[scene.ThemeLight] == nil {
+		palettes[scene.ThemeLight] = src.Palette
+	}
+	themes := make([]string, 0, len(palettes))
+	for theme := range palettes {
+		themes = append(themes, theme)
+	}
+	sort.Strings(themes)
+	for _, theme := range themes {
+		if !scene.ValidThemes[theme] {
+			return nil, ErrCodeInvalidPalette, "theme must be 'light' or 'dark'"
+		}
+		if palettes[theme] == nil {
+			continue
+		}
+		p := *palettes[theme]
+		if errMsg := validatePalette(&p, theme); errMsg != "" {
+			return nil, ErrCodeInvalidPalette, errMsg
+		}
+		newScene.SetPalette(theme, &p)
+	}
+
+	imported := &sceneImport{scene: newScene}
+	seriesIDs := make(map[string]string)
+	for i, src := range bundle.Events {
+		event, errMsg := h.importEvent(src, sceneID, seriesIDs, now)
+		if errMsg != "" {
+			return nil, ErrCodeValidation, fmt.Sprintf("events[%d]: %s", i, errMsg)
+		}
+		imported.events = append(imported.events, event)
+	}
+
+	seen := make(map[string]bool)
+	for i, src := range bundle.Members {
+		if src == nil {
+			return nil, ErrCodeValidation, fmt.Sprintf("members[%d]: member is required", i)
+		}
+		if err := did.ValidateDID(src.UserDID); err != nil {
+			return nil, ErrCodeValidation, fmt.Sprintf("members[%d]: user_did must be a valid did:plc or did:web identifier", i)
+		}
+		if seen[src.UserDID] {
+			return nil, ErrCodeValidation, fmt.Sprintf("members[%d]: duplicate user_did", i)
+		}
+		seen[src.UserDID] = true
+
+		var status string
+		switch src.Status {
+		case "active", "pending":
+			status = "pending"
+		case "banned":
+			status = "banned"
+		case "rejected":
+			continue
+		default:
+			return nil, ErrCodeValidation, fmt.Sprintf("members[%d]: unknown status %q", i, src.Status)
+		}
+		if src.UserDID == ownerDID {
+			continue
+		}
+		imported.members = append(imported.members, &membership.Membership{
+			SceneID:     sceneID,
+			UserDID:     src.UserDID,
+			Role:        "member",
+			Status:      status,
+			TrustWeight: 0.5, // Default trust weight
+		})
+	}
+
+	return imported, "", ""
+}
+
+// importEvent validates an exported event and builds its replacement in
+// sceneID. seriesIDs maps exported series IDs to new ones so occurrences of
+// a series stay grouped. Returns error message if validation fails.
+func (h *SceneHandlers) importEvent(src *scene.Event, sceneID string, seriesIDs map[string]string, now time.Time) (*scene.Event, string) {
+	if src == nil {
+		return nil, "event is required"
+	}
+	title := html.UnescapeString(src.Title)
+	if errMsg := validateEventTitle(title); errMsg != "" {
+		return nil, errMsg
+	}
+	description, err := sanitizeText(html.UnescapeString(src.Description))
+	if err != nil {
+		return nil, "description " + err.Error()
+	}
+	if errMsg := validateTimeWindow(src.StartsAt, src.EndsAt); errMsg != "" {
+		return nil, errMsg
+	}
+	if _, errMsg := validateEventTimezone(src.Timezone); errMsg != "" {
+		return nil, errMsg
+	}
+	if src.Capacity < 0 {
+		return nil, "capacity cannot be negative"
+	}
+	tags, errMsg := importTags(src.Tags)
+	if errMsg != "" {
+		return nil, errMsg
+	}
+
+	event := &scene.Event{
+		ID:           uuid.New().String(),
+		SceneID:      sceneID,
+		Title:        sanitizeEventTitle(title),
+		Description:  description,
+		AllowPrecise: src.AllowPrecise,
+		PrecisePoint: src.PrecisePoint,
+		Tags:         tags,
+		StartsAt:     src.StartsAt,
+		EndsAt:       src.EndsAt,
+		Timezone:     src.Timezone,
+		Capacity:     src.Capacity,
+		CreatedAt:    &now,
+		UpdatedAt:    &now,
+	}
+	event.InLocation()
+
+	coarseGeohash, errMsg := resolveCoarseGeohash(src.CoarseGeohash, src.PrecisePoint)
+	if errMsg != "" {
+		return nil, errMsg
+	}
+	if h.jitterRadiusMeters > 0 && src.PrecisePoint != nil {
+		coarseGeohash = jitteredCoarseGeohash(event.ID, *src.PrecisePoint, h.jitterRadiusMeters, len(coarseGeohash))
+	}
+	event.CoarseGeohash = coarseGeohash
+
+	switch src.Status {
+	case "", "scheduled":
+		event.Status = "scheduled"
+	case "cancelled":
+		event.Status = "cancelled"
+		event.CancelledAt = src.CancelledAt
+		if src.CancellationReason != nil {
+			reason, err := sanitizeText(html.UnescapeString(*src.CancellationReason))
+			if err != nil {
+				return nil, "cancellation_reason " + err.Error()
+			}
+			event.CancellationReason = &reason
+		}
+	default:
+		return nil, fmt.Sprintf("unknown status %q", src.Status)
+	}
+
+	if src.SeriesID != nil {
+		seriesID, ok := seriesIDs[*src.SeriesID]
+		if !ok {
+			seriesID = uuid.New().String()
+			seriesIDs[*src.SeriesID] = seriesID
+		}
+		event.SeriesID = &seriesID
+	}
+	return event, ""
+}
+
+// importTags normalizes, validates, and HTML-escapes exported tags, which
+// are stored escaped. Returns error message if validation fails.
+func importTags(tags []string) ([]string, string) {
+	unescaped := make([]string, len(tags))
+	for i, tag := range tags {
+		unescaped[i] = html.UnescapeString(tag)
+	}
+	normalized := scene.NormalizeTags(unescaped)
+	if err := scene.ValidateTags(normalized); err != nil {
+		return nil, err.Error()
+	}
+	sanitized := make([]string, len(normalized))
+	for i, tag := range normalized {
+		sanitized[i] = html.EscapeString(tag)
+	}
+	return sanitized, ""
+}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+func importScene(handlers *SceneHandlers, body []byte, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/scenes/import", bytes.NewReader(body))
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.ImportScene(w, req)
+	return w
+}
+
+func TestImportScene_RoundTrip(t *testing.T) {
+	env := newTestEnv()
+	seedSceneExport(t, env)
+	handlers := env.sceneHandlers()
+	handlers.SetEventRepository(env.events)
+	handlers.SetAuditRepository(env.audit)
+
+	exported := exportScene(handlers, exportSceneID, "did:plc:owner")
+	if exported.Code != http.StatusOK {
+		t.Fatalf("export failed with %d: %s", exported.Code, exported.Body.String())
+	}
+
+	w := importScene(handlers, exported.Body.Bytes(), "did:plc:newowner")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp SceneImportResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.EventsImported != 1 || resp.MembersInvited != 1 {
+		t.Errorf("expected 1 event and 1 invite, got %d and %d", resp.EventsImported, resp.MembersInvited)
+	}
+
+	imported := resp.Scene
+	if imported.ID == exportSceneID {
+		t.Fatal("expected the imported scene to get a new ID")
+	}
+	if imported.OwnerDID != "did:plc:newowner" {
+		t.Errorf("expected the importer to own the scene, got %s", imported.OwnerDID)
+	}
+	if imported.Name != "Export Scene" || imported.Visibility != scene.VisibilityHidden {
+		t.Errorf("unexpected imported scene: %+v", imported)
+	}
+	if imported.Description != "Bass &amp; dub" {
+		t.Errorf("expected the description re-sanitized without double escaping, got %q", imported.Description)
+	}
+	if imported.PrecisePoint == nil || imported.Palette == nil || imported.Palette.Primary != "#112233" {
+		t.Errorf("expected precise point and palette preserved, got %+v, %+v", imported.PrecisePoint, imported.Palette)
+	}
+
+	// A second export of the new scene carries the same events
+	reexported := exportScene(handlers, imported.ID, "did:plc:newowner")
+	if reexported.Code != http.StatusOK {
+		t.Fatalf("re-export failed with %d: %s", reexported.Code, reexported.Body.String())
+	}
+	var bundle SceneExport
+	if err := json.NewDecoder(reexported.Body).Decode(&bundle); err != nil {
+		t.Fatalf("failed to decode bundle: %v", err)
+	}
+	if len(bundle.Events) != 1 {
+		t.Fatalf("expected 1 imported event, got %d", len(bundle.Events))
+	}
+	event := bundle.Events[0]
+	if event.ID == "kept" || event.SceneID != imported.ID || event.Title != "Event kept" || event.PrecisePoint == nil {
+		t.Errorf("unexpected imported event: %+v", event)
+	}
+
+	// Members come back as invites, never active
+	want := map[string]string{"did:plc:admin": "pending", "did:plc:banned": "banned"}
+	if len(bundle.Members) != len(want) {
+		t.Fatalf("expected %d members, got %d", len(want), len(bundle.Members))
+	}
+	for _, m := range bundle.Members {
+		if want[m.UserDID] != m.Status || m.Role != "member" {
+			t.Errorf("unexpected imported member %s: role %s, status %s", m.UserDID, m.Role, m.Status)
+		}
+	}
+}
+
+func TestImportScene_EnforcesLocationConsent(t *testing.T) {
+	env := newTestEnv()
+	seedSceneExport(t, env)
+	handlers := env.sceneHandlers()
+	handlers.SetEventRepository(env.events)
+	handlers.SetAuditRepository(env.audit)
+	body := `{"version": 1, "scene": {"id": "x", "name": "No Consent", "owner_did": "did:plc:someone", "allow_precise": false,
+		"precise_point": {"lat": 40.7128, "lng": -74.006}, "coarse_geohash": "dr5regw", "version": 3}, "events": [], "members": []}`
+
+	w := importScene(handlers, []byte(body), "did:plc:newowner")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp SceneImportResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Scene.PrecisePoint != nil {
+		t.Error("expected precise point dropped without consent")
+	}
+	if resp.Scene.CoarseGeohash != "dr5reg" {
+		t.Errorf("expected coarse geohash derived from the point, got %q", resp.Scene.CoarseGeohash)
+	}
+}
+
+func TestImportScene_RejectsMalformedBundle(t *testing.T) {
+	validScene := `{"name": "Imported", "owner_did": "did:plc:x", "allow_precise": false, "coarse_geohash": "dr5regw", "version": 1}`
+	bundle := func(version, scene, events, members string) string {
+		return `{"version": ` + version + `, "scene": ` + scene + `, "events": ` + events + `, "members": ` + members + `}`
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		wantCode string
+		wantMsg  string
+	}{
+		{"malformed JSON", `{"version": 1, "scene": `, ErrCodeValidation, "malformed JSON"},
+		{"unknown field", `{"version": 1, "scene": ` + validScene + `, "extra": true}`, ErrCodeValidation, "Unknown field"},
+		{"unsupported version", bundle("2", validScene, "[]", "[]"), ErrCodeValidation, "unsupported export version 2"},
+		{"missing version", `{"scene": ` + validScene + `}`, ErrCodeValidation, "unsupported export version 0"},
+		{"missing scene", bundle("1", "null", "[]", "[]"), ErrCodeValidation, "scene is required"},
+		{"invalid name", bundle("1", `{"name": "<b>", "coarse_geohash": "dr5regw"}`, "[]", "[]"), ErrCodeInvalidSceneName, ""},
+		{"invalid geohash", bundle("1", `{"name": "Imported", "coarse_geohash": "dr5!"}`, "[]", "[]"), ErrCodeValidation, "coarse_geohash"},
+		{"invalid visibility", bundle("1", `{"name": "Imported", "coarse_geohash": "dr5regw", "visibility": "secret"}`, "[]", "[]"), ErrCodeValidation, "visibility"},
+		{"invalid palette color", bundle("1", `{"name": "Imported", "coarse_geohash": "dr5regw", "palette": {"primary": "red", "secondary": "#000000", "accent": "#000000", "background": "#ffffff", "text": "#000000"}}`, "[]", "[]"), ErrCodeInvalidPalette, "primary color"},
+		{"low contrast palette", bundle("1", `{"name": "Imported", "coarse_geohash": "dr5regw", "palettes": {"dark": {"primary": "#000000", "secondary": "#000000", "accent": "#000000", "background": "#000000", "text": "#111111"}}}`, "[]", "[]"), ErrCodeInvalidPalette, "dark theme"},
+		{"unknown palette theme", bundle("1", `{"name": "Imported", "coarse_geohash": "dr5regw", "palettes": {"sepia": {"primary": "#000000", "secondary": "#000000", "accent": "#000000", "background": "#ffffff", "text": "#000000"}}}`, "[]", "[]"), ErrCodeInvalidPalette, "theme"},
+		{"invalid event title", bundle("1", validScene, `[{"title": "", "coarse_geohash": "dr5regw", "starts_at": "2030-01-01T20:00:00Z"}]`, "[]"), ErrCodeValidation, "events[0]"},
+		{"event ends before it starts", bundle("1", validScene, `[{"title": "Night", "coarse_geohash": "dr5regw", "starts_at": "2030-01-01T20:00:00Z", "ends_at": "2030-01-01T19:00:00Z"}]`, "[]"), ErrCodeValidation, "events[0]: start time"},
+		{"unknown event timezone", bundle("1", validScene, `[{"title": "Night", "coarse_geohash": "dr5regw", "starts_at": "2030-01-01T20:00:00Z", "timezone": "Moon/Tranquility"}]`, "[]"), ErrCodeValidation, "events[0]: timezone"},
+		{"unknown event status", bundle("1", validScene, `[{"title": "Night", "coarse_geohash": "dr5regw", "starts_at": "2030-01-01T20:00:00Z", "status": "live"}]`, "[]"), ErrCodeValidation, "events[0]: unknown status"},
+		{"invalid member DID", bundle("1", validScene, "[]", `[{"user_did": "not-a-did", "status": "active"}]`), ErrCodeValidation, "members[0]"},
+		{"duplicate member", bundle("1", validScene, "[]", `[{"user_did": "did:plc:a", "status": "active"}, {"user_did": "did:plc:a", "status": "pending"}]`), ErrCodeValidation, "members[1]: duplicate"},
+		{"unknown member status", bundle("1", validScene, "[]", `[{"user_did": "did:plc:a", "status": "owner"}]`), ErrCodeValidation, "members[0]: unknown status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv()
+			seedSceneExport(t, env)
+			handlers := env.sceneHandlers()
+			handlers.SetEventRepository(env.events)
+			handlers.SetAuditRepository(env.audit)
+			w := importScene(handlers, []byte(tt.body), "did:plc:newowner")
+			if w.Code != StatusCodeMapping(tt.wantCode) {
+				t.Fatalf("expected status %d, got %d: %s", StatusCodeMapping(tt.wantCode), w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != tt.wantCode {
+				t.Errorf("expected error code %s, got %s", tt.wantCode, errResp.Error.Code)
+			}
+			if !strings.Contains(errResp.Error.Message, tt.wantMsg) {
+				t.Errorf("expected message containing %q, got %q", tt.wantMsg, errResp.Error.Message)
+			}
+
+			// Nothing is written for a rejected bundle
+			if scenes, _ := handlers.repo.ListByOwner(t.Context(), "did:plc:newowner"); len(scenes) != 0 {
+				t.Errorf("expected no scene created, got %d", len(scenes))
+			}
+		})
+	}
+}
+
+func TestImportScene_Refused(t *testing.T) {
+	env := newTestEnv()
+	seedSceneExport(t, env)
+	handlers := env.sceneHandlers()
+	handlers.SetEventRepository(env.events)
+	handlers.SetAuditRepository(env.audit)
+	body := []byte(`{"version": 1, "scene": {"name": "Imported", "coarse_geohash": "dr5regw"}}`)
+
+	if w := importScene(handlers, body, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 without authentication, got %d", w.Code)
+	}
+
+	if w := importScene(handlers, body, "did:plc:newowner"); w.Code != http.StatusCreated {
+		t.Fatalf("expected first import to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+	w := importScene(handlers, body, "did:plc:newowner")
+	if w.Code != StatusCodeMapping(ErrCodeDuplicateSceneName) {
+		t.Errorf("expected duplicate name to be refused, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestImportScene_SkipsImporterMembership(t *testing.T) {
+	env := newTestEnv()
+	seedSceneExport(t, env)
+	handlers := env.sceneHandlers()
+	handlers.SetEventRepository(env.events)
+	handlers.SetAuditRepository(env.audit)
+	body := []byte(`{"version": 1, "scene": {"name": "Imported", "coarse_geohash": "dr5regw"}, "members": [
+		{"user_did": "did:plc:newowner", "status": "active"},
+		{"user_did": "did:plc:gone", "status": "rejected"}]}`)
+
+	w := importScene(handlers, body, "did:plc:newowner")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp SceneImportResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.MembersInvited != 0 {
+		t.Errorf("expected no invites, got %d", resp.MembersInvited)
+	}
+	members, err := handlers.membershipRepo.ListByScene(t.Context(), resp.Scene.ID, "")
+	if err != nil {
+		t.Fatalf("ListByScene failed: %v", err)
+	}
+	if len(members) != 0 {
+		t.Errorf("expected no memberships, got %+v", members)
+	}
+}
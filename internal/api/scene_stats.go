@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// sceneStatsAccessDenied is returned to callers who may not read scene statistics.
+const sceneStatsAccessDenied = "Only scene owner or admins can view scene statistics"
+
+// SceneStatsResponse summarizes a scene for its owner's dashboard.
+type SceneStatsResponse struct {
+	SceneID             string     `json:"scene_id"`
+	MembersCount        int        `json:"members_count"`
+	EventsCount         int        `json:"events_count"`
+	UpcomingEventsCount int        `json:"upcoming_events_count"`
+	TotalRSVPs          int        `json:"total_rsvps"`
+	NextEventStartsAt   *time.Time `json:"next_event_starts_at,omitempty"`
+}
+
+// GetSceneStats handles GET /scenes/{id}/stats - summarizes a scene's members,
+// events, and RSVPs. Only the scene owner and scene admins may read it.
+//
+// members_count counts active members. events_count counts non-deleted
+// events, including cancelled ones; upcoming_events_count and
+// next_event_starts_at only consider scheduled events that haven't started.
+// total_rsvps sums going, maybe, and waitlisted RSVPs across the counted
+// events. Event and RSVP figures are zero when the respective repository is
+// not configured.
+func (h *SceneHandlers) GetSceneStats(w http.ResponseWriter, r *http.Request) {
+	sceneID, ok := parseEntityID(w, r, "/scenes/", "Scene")
+	if !ok {
+		return
+	}
+
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	foundScene, err := h.repo.GetByID(r.Context(), sceneID)
+	if err != nil {
+		if err == scene.ErrSceneDeleted {
+			writeError(w, r, ErrCodeSceneDeleted, "Scene not found")
+			return
+		}
+		if err == scene.ErrSceneNotFound {
+			writeError(w, r, ErrCodeNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene")
+		return
+	}
+
+	isModerator, err := isModeratorForScene(r.Context(), h.membershipRepo, foundScene, userDID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to check moderator access", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to check access permissions")
+		return
+	}
+	if !isModerator {
+		writeError(w, r, ErrCodeForbidden, sceneStatsAccessDenied)
+		return
+	}
+
+	response := SceneStatsResponse{SceneID: sceneID}
+
+	memberCounts, err := h.membershipRepo.CountByScenes(r.Context(), []string{sceneID}, "active")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to count memberships", "error", err, "scene_id", sceneID)
+		writeError(w, r, ErrCodeInternal, "Failed to retrieve scene statistics")
+		return
+	}
+	response.MembersCount = memberCounts[sceneID]
+
+	if h.eventRepo != nil {
+		if err := h.addEventStats(r.Context(), &response); err != nil {
+			slog.ErrorContext(r.Context(), "failed to aggregate event statistics", "error", err, "scene_id", sceneID)
+			writeError(w, r, ErrCodeInternal, "Failed to retrieve scene statistics")
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
+
+// addEventStats fills in the event and RSVP figures of stats from the scene's
+// non-deleted events.
+func (h *SceneHandlers) addEventStats(ctx context.Context, stats *SceneStatsResponse) error {
+	events, err := h.eventRepo.ListBySceneID(ctx, stats.SceneID, scene.EventFilter{})
+	if err != nil {
+		return err
+	}
+	stats.EventsCount = len(events)
+
+	// Scheduled events come back in starts_at order, so the first is the next one
+	upcoming, err := h.eventRepo.ListBySceneID(ctx, stats.SceneID, scene.EventFilter{Status: scene.EventStatusFilterScheduled})
+	if err != nil {
+		return err
+	}
+	stats.UpcomingEventsCount = len(upcoming)
+	if len(upcoming) > 0 {
+		startsAt := upcoming[0].StartsAt
+		stats.NextEventStartsAt = &startsAt
+	}
+
+	if h.rsvpRepo == nil || len(events) == 0 {
+		return nil
+	}
+	eventIDs := make([]string, len(events))
+	for i, e := range events {
+		eventIDs[i] = e.ID
+	}
+	counts, err := h.rsvpRepo.GetCountsForEvents(ctx, eventIDs)
+	if err != nil {
+		return err
+	}
+	for _, c := range counts {
+		stats.TotalRSVPs += c.Going + c.Maybe + c.Waitlisted
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+const statsSceneID = "7b3e9c1a-4f2d-4a8b-9c6e-1d2f3a4b5c6d"
+
+// seedSceneStats seeds statsSceneID owned by did:plc:owner with an admin, one
+// active and one pending member, and events covering past, upcoming,
+// cancelled, and deleted cases. Returns the next event's start.
+func seedSceneStats(t *testing.T, env *testEnv) time.Time {
+	t.Helper()
+	env.addScene(t, &scene.Scene{ID: statsSceneID, Name: "Stats Scene", Visibility: scene.VisibilityPublic})
+	env.addMember(t, statsSceneID, "did:plc:admin", "admin", "active")
+	env.addMember(t, statsSceneID, "did:plc:member", "member", "active")
+	env.addMember(t, statsSceneID, "did:plc:pending", "member", "pending")
+
+	now := time.Now()
+	nextStart := now.Add(24 * time.Hour).Truncate(time.Second)
+	events := []struct {
+		id       string
+		startsAt time.Time
+		rsvps    []string
+	}{
+		{"past", now.Add(-24 * time.Hour), []string{"going", "maybe"}},
+		{"next", nextStart, []string{"going"}},
+		{"later", now.Add(48 * time.Hour), nil},
+		{"cancelled", now.Add(12 * time.Hour), []string{"maybe"}},
+		{"deleted", now.Add(6 * time.Hour), []string{"going", "going"}},
+	}
+	for _, e := range events {
+		env.addEvent(t, &scene.Event{ID: e.id, SceneID: statsSceneID, StartsAt: e.startsAt})
+		for i, status := range e.rsvps {
+			env.addRSVP(t, e.id, "did:plc:fan"+string(rune('a'+i)), status)
+		}
+	}
+	if err := env.events.Cancel(t.Context(), "cancelled", nil); err != nil {
+		t.Fatalf("failed to cancel event: %v", err)
+	}
+	if err := env.events.Delete(t.Context(), "deleted"); err != nil {
+		t.Fatalf("failed to delete event: %v", err)
+	}
+
+	return nextStart
+}
+
+func getSceneStats(handlers *SceneHandlers, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/scenes/"+statsSceneID+"/stats", nil)
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.GetSceneStats(w, req)
+	return w
+}
+
+func TestGetSceneStats_Aggregates(t *testing.T) {
+	env := newTestEnv()
+	nextStart := seedSceneStats(t, env)
+	handlers := env.sceneHandlers()
+	handlers.SetEventRepository(env.events)
+	handlers.SetRSVPRepository(env.rsvps)
+
+	w := getSceneStats(handlers, "did:plc:owner")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var stats SceneStatsResponse
+	if err := json.NewDecoder(w.Body).Decode(&stats); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	want := SceneStatsResponse{
+		SceneID:             statsSceneID,
+		MembersCount:        2, // admin and member; pending excluded
+		EventsCount:         4, // deleted event excluded
+		UpcomingEventsCount: 2, // next and later; past and cancelled excluded
+		TotalRSVPs:          4, // RSVPs on the deleted event excluded
+	}
+	if stats.SceneID != want.SceneID || stats.MembersCount != want.MembersCount ||
+		stats.EventsCount != want.EventsCount || stats.UpcomingEventsCount != want.UpcomingEventsCount ||
+		stats.TotalRSVPs != want.TotalRSVPs {
+		t.Errorf("expected %+v, got %+v", want, stats)
+	}
+	if stats.NextEventStartsAt == nil || !stats.NextEventStartsAt.Equal(nextStart) {
+		t.Errorf("expected next_event_starts_at %v, got %v", nextStart, stats.NextEventStartsAt)
+	}
+}
+
+func TestGetSceneStats_Access(t *testing.T) {
+	env := newTestEnv()
+	seedSceneStats(t, env)
+	handlers := env.sceneHandlers()
+	handlers.SetEventRepository(env.events)
+	handlers.SetRSVPRepository(env.rsvps)
+
+	tests := []struct {
+		name       string
+		userDID    string
+		wantStatus int
+	}{
+		{"owner", "did:plc:owner", http.StatusOK},
+		{"admin", "did:plc:admin", http.StatusOK},
+		{"member", "did:plc:member", http.StatusForbidden},
+		{"stranger", "did:plc:stranger", http.StatusForbidden},
+		{"unauthenticated", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := getSceneStats(handlers, tt.userDID); w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetSceneStats_DeletedScene(t *testing.T) {
+	env := newTestEnv()
+	seedSceneStats(t, env)
+	handlers := env.sceneHandlers()
+	handlers.SetEventRepository(env.events)
+	handlers.SetRSVPRepository(env.rsvps)
+	if err := handlers.repo.Delete(t.Context(), statsSceneID); err != nil {
+		t.Fatalf("failed to delete scene: %v", err)
+	}
+
+	if w := getSceneStats(handlers, "did:plc:owner"); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGetSceneStats_WithoutEventRepository(t *testing.T) {
+	env := newTestEnv()
+	seedSceneStats(t, env)
+	handlers := env.sceneHandlers()
+	handlers.SetEventRepository(env.events)
+	handlers.SetRSVPRepository(env.rsvps)
+	handlers.eventRepo = nil
+
+	w := getSceneStats(handlers, "did:plc:owner")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var stats SceneStatsResponse
+	if err := json.NewDecoder(w.Body).Decode(&stats); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if stats.MembersCount != 2 || stats.EventsCount != 0 || stats.TotalRSVPs != 0 || stats.NextEventStartsAt != nil {
+		t.Errorf("expected member count only, got %+v", stats)
+	}
+}
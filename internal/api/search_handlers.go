@@ -0,0 +1,94 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/onnwee/subcults/internal/search"
+)
+
+// Full-text search limits.
+const (
+	DefaultSearchLimit   = 20
+	MaxSearchLimit       = 100
+	MaxSearchQueryLength = 200
+)
+
+// SearchHandlers serves full-text search over scenes and events.
+type SearchHandlers struct {
+	index *search.Indexer
+}
+
+// NewSearchHandlers creates a new SearchHandlers instance backed by index.
+func NewSearchHandlers(index *search.Indexer) *SearchHandlers {
+	return &SearchHandlers{index: index}
+}
+
+// SearchResponse represents the response for full-text search.
+type SearchResponse struct {
+	Results []search.Result `json:"results"`
+}
+
+// Search handles GET /search - ranks public scenes and their events against q.
+// Supports optional limit (1-100, default 20) and fuzzy (true or false) parameters;
+// fuzzy=true also matches terms within a small edit distance of the query.
+// Each result carries a snippet of its best-matching public text field.
+func (h *SearchHandlers) Search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	q := strings.TrimSpace(query.Get("q"))
+	if q == "" {
+		writeError(w, r, ErrCodeValidation, "q is required")
+		return
+	}
+	if utf8.RuneCountInString(q) > MaxSearchQueryLength {
+		writeErrorf(w, r, ErrCodeValidation, "q must be at most %d characters", MaxSearchQueryLength)
+		return
+	}
+
+	limit := DefaultSearchLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsedLimit, err := parseIntInRange(limitStr, "limit", 1, MaxSearchLimit)
+		if err != nil {
+			writeError(w, r, ErrCodeValidation, err.Error())
+			return
+		}
+		limit = parsedLimit
+	}
+
+	fuzzy := false
+	if fuzzyStr := query.Get("fuzzy"); fuzzyStr != "" {
+		parsed, err := strconv.ParseBool(strings.TrimSpace(fuzzyStr))
+		if err != nil {
+			writeError(w, r, ErrCodeValidation, "fuzzy must be true or false")
+			return
+		}
+		fuzzy = parsed
+	}
+
+	var results []search.Result
+	var err error
+	if fuzzy {
+		results, err = h.index.SearchFuzzy(r.Context(), q, limit)
+	} else {
+		results, err = h.index.Search(r.Context(), q, limit)
+	}
+	if err != nil {
+		if writeContextError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to search index", "error", err)
+		writeError(w, r, ErrCodeInternal, "Failed to search")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(SearchResponse{Results: results}); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode search response", "error", err)
+	}
+}
@@ -1,16 +1,19 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/onnwee/subcults/internal/audit"
 	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/search"
 	"github.com/onnwee/subcults/internal/stream"
 )
 
@@ -84,16 +87,16 @@ func TestSearchEvents_Success(t *testing.T) {
 	}
 	
 	// Insert events
-	if err := eventRepo.Insert(event1); err != nil {
+	if err := eventRepo.Insert(t.Context(), event1); err != nil {
 		t.Fatalf("failed to insert event1: %v", err)
 	}
-	if err := eventRepo.Insert(event2); err != nil {
+	if err := eventRepo.Insert(t.Context(), event2); err != nil {
 		t.Fatalf("failed to insert event2: %v", err)
 	}
-	if err := eventRepo.Insert(event3); err != nil {
+	if err := eventRepo.Insert(t.Context(), event3); err != nil {
 		t.Fatalf("failed to insert event3: %v", err)
 	}
-	if err := eventRepo.Insert(event4); err != nil {
+	if err := eventRepo.Insert(t.Context(), event4); err != nil {
 		t.Fatalf("failed to insert event4: %v", err)
 	}
 	
@@ -335,7 +338,7 @@ func TestSearchEvents_Pagination(t *testing.T) {
 			CreatedAt:     &baseTime,
 			UpdatedAt:     &baseTime,
 		}
-		if err := eventRepo.Insert(event); err != nil {
+		if err := eventRepo.Insert(t.Context(), event); err != nil {
 			t.Fatalf("failed to insert event: %v", err)
 		}
 	}
@@ -503,3 +506,333 @@ func TestSearchEvents_EmptyResults(t *testing.T) {
 		t.Error("next_cursor should be empty when there are no more results")
 	}
 }
+
+// TestSearchEvents_ContextDeadline tests that a search whose request context
+// has expired stops and reports a timeout rather than an internal error.
+func TestSearchEvents_ContextDeadline(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, auditRepo, rsvpRepo, streamRepo)
+
+	baseTime := time.Now().Add(24 * time.Hour)
+	if err := eventRepo.Insert(t.Context(), &scene.Event{
+		ID:            uuid.New().String(),
+		SceneID:       uuid.New().String(),
+		Title:         "Event",
+		AllowPrecise:  true,
+		PrecisePoint:  &scene.Point{Lat: 40.7128, Lng: -74.0060},
+		CoarseGeohash: "dr5regw",
+		Status:        "scheduled",
+		StartsAt:      baseTime,
+	}); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	url := fmt.Sprintf("/search/events?bbox=-74.1,40.6,-73.9,40.8&from=%s&to=%s",
+		baseTime.Add(-time.Hour).Format(time.RFC3339), baseTime.Add(time.Hour).Format(time.RFC3339))
+	req := httptest.NewRequest(http.MethodGet, url, nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	handlers.SearchEvents(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d: %s", w.Code, w.Body.String())
+	}
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeTimeout {
+		t.Errorf("expected error code %s, got %s", ErrCodeTimeout, errResp.Error.Code)
+	}
+}
+
+// seedSearchIndex indexes one public scene and one of its events.
+func seedSearchIndex(index *search.Indexer) {
+	sceneID := uuid.New().String()
+	index.IndexScene(&scene.Scene{
+		ID:          sceneID,
+		Name:        "Warehouse Techno",
+		Description: "Late night techno in a converted warehouse",
+		Visibility:  scene.VisibilityPublic,
+	})
+	index.IndexEvent(&scene.Event{
+		ID:      uuid.New().String(),
+		SceneID: sceneID,
+		Title:   "Friday Session",
+	})
+}
+
+// runSearchRequest serves GET /search?<rawQuery> and decodes a 200 response.
+func runSearchRequest(t *testing.T, handlers *SearchHandlers, rawQuery string) SearchResponse {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/search?"+rawQuery, nil)
+	w := httptest.NewRecorder()
+
+	handlers.Search(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response SearchResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return response
+}
+
+// TestSearch_Fuzzy tests that a one-edit typo only matches with fuzzy=true.
+func TestSearch_Fuzzy(t *testing.T) {
+	index := search.NewIndexer()
+	seedSearchIndex(index)
+	handlers := NewSearchHandlers(index)
+
+	tests := []struct {
+		name     string
+		rawQuery string
+		wantHits int
+	}{
+		{"exact term", "q=warehouse", 1},
+		{"typo without fuzzy", "q=warehuse", 0},
+		{"typo with fuzzy=false", "q=warehuse&fuzzy=false", 0},
+		{"typo with fuzzy=true", "q=warehuse&fuzzy=true", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := runSearchRequest(t, handlers, tt.rawQuery)
+			if response.Results == nil {
+				t.Fatal("results array should not be nil, even when empty")
+			}
+			if len(response.Results) != tt.wantHits {
+				t.Fatalf("expected %d results, got %+v", tt.wantHits, response.Results)
+			}
+			if tt.wantHits > 0 && response.Results[0].Type != search.TypeScene {
+				t.Errorf("expected a scene hit, got %q", response.Results[0].Type)
+			}
+		})
+	}
+}
+
+// TestSearch_Limit tests that limit caps the number of results.
+func TestSearch_Limit(t *testing.T) {
+	index := search.NewIndexer()
+	seedSearchIndex(index)
+	handlers := NewSearchHandlers(index)
+	for i := range 3 {
+		index.IndexScene(&scene.Scene{
+			ID:         uuid.New().String(),
+			Name:       fmt.Sprintf("Techno Night %d", i),
+			Visibility: scene.VisibilityPublic,
+		})
+	}
+
+	if got := runSearchRequest(t, handlers, "q=techno").Results; len(got) != 4 {
+		t.Errorf("expected 4 results without a limit, got %d", len(got))
+	}
+	if got := runSearchRequest(t, handlers, "q=techno&limit=2").Results; len(got) != 2 {
+		t.Errorf("expected 2 results with limit=2, got %d", len(got))
+	}
+}
+
+// TestSearch_Validation tests that malformed query parameters are rejected.
+func TestSearch_Validation(t *testing.T) {
+	index := search.NewIndexer()
+	seedSearchIndex(index)
+	handlers := NewSearchHandlers(index)
+
+	for _, rawQuery := range []string{
+		"",
+		"q=%20%20",
+		"q=" + strings.Repeat("a", MaxSearchQueryLength+1),
+		"q=techno&fuzzy=maybe",
+		"q=techno&limit=0",
+		"q=techno&limit=101",
+		"q=techno&limit=abc",
+	} {
+		t.Run(rawQuery, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/search?"+rawQuery, nil)
+			w := httptest.NewRecorder()
+
+			handlers.Search(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != ErrCodeValidation {
+				t.Errorf("expected error code %s, got %s", ErrCodeValidation, errResp.Error.Code)
+			}
+		})
+	}
+}
+
+// TestSearch_ContextCanceled tests that a search whose request context is
+// done reports a timeout rather than an internal error.
+func TestSearch_ContextCanceled(t *testing.T) {
+	index := search.NewIndexer()
+	seedSearchIndex(index)
+	handlers := NewSearchHandlers(index)
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/search?q=techno", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	handlers.Search(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d: %s", w.Code, w.Body.String())
+	}
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeTimeout {
+		t.Errorf("expected error code %s, got %s", ErrCodeTimeout, errResp.Error.Code)
+	}
+}
+
+// TestSearch_Snippets tests that hits carry a snippet of the matched field
+// with highlights that locate the query term.
+func TestSearch_Snippets(t *testing.T) {
+	index := search.NewIndexer()
+	seedSearchIndex(index)
+	handlers := NewSearchHandlers(index)
+
+	tests := []struct {
+		name      string
+		rawQuery  string
+		wantField string
+		wantWord  string
+	}{
+		{"scene description", "q=converted", search.FieldDescription, "converted"},
+		{"event title", "q=friday", search.FieldTitle, "Friday"},
+		{"fuzzy match", "q=convrted&fuzzy=true", search.FieldDescription, "converted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := runSearchRequest(t, handlers, tt.rawQuery).Results
+			if len(results) != 1 {
+				t.Fatalf("expected 1 result, got %+v", results)
+			}
+			got := results[0]
+			if got.MatchedField != tt.wantField {
+				t.Errorf("expected matched_field %q, got %q", tt.wantField, got.MatchedField)
+			}
+			if len(got.Highlights) != 1 {
+				t.Fatalf("expected 1 highlight, got %+v", got.Highlights)
+			}
+			snippet := []rune(got.Snippet)
+			if word := string(snippet[got.Highlights[0].Start:got.Highlights[0].End]); word != tt.wantWord {
+				t.Errorf("expected highlight on %q, got %q in snippet %q", tt.wantWord, word, got.Snippet)
+			}
+		})
+	}
+}
+
+// TestSearch_SnippetsEscapeMarkup tests that snippets of text containing
+// markup come back HTML-escaped, with highlights counting the escaped text.
+func TestSearch_SnippetsEscapeMarkup(t *testing.T) {
+	index := search.NewIndexer()
+	seedSearchIndex(index)
+	handlers := NewSearchHandlers(index)
+	index.IndexScene(&scene.Scene{
+		ID:          uuid.New().String(),
+		Name:        "Noise Night",
+		Description: `<script>alert("x")</script> modular synth jams`,
+		Visibility:  scene.VisibilityPublic,
+	})
+
+	results := runSearchRequest(t, handlers, "q=modular").Results
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %+v", results)
+	}
+	got := results[0]
+	want := `&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; modular synth jams`
+	if got.Snippet != want {
+		t.Errorf("expected snippet %q, got %q", want, got.Snippet)
+	}
+	if strings.Contains(got.Snippet, "<") {
+		t.Errorf("expected no raw markup in snippet, got %q", got.Snippet)
+	}
+	if len(got.Highlights) != 1 {
+		t.Fatalf("expected 1 highlight, got %+v", got.Highlights)
+	}
+	snippet := []rune(got.Snippet)
+	if word := string(snippet[got.Highlights[0].Start:got.Highlights[0].End]); word != "modular" {
+		t.Errorf("expected highlight on %q, got %q in snippet %q", "modular", word, got.Snippet)
+	}
+}
+
+// TestSearch_SnippetsNeverLeakPrivateData tests that hidden scenes and their
+// events yield no results or snippets, and that precise points never appear
+// in the response.
+func TestSearch_SnippetsNeverLeakPrivateData(t *testing.T) {
+	index := search.NewIndexer()
+	seedSearchIndex(index)
+	handlers := NewSearchHandlers(index)
+
+	hiddenID := uuid.New().String()
+	index.IndexScene(&scene.Scene{
+		ID:           hiddenID,
+		Name:         "Secret Basement",
+		Description:  "Unlisted techno rave",
+		Visibility:   scene.VisibilityHidden,
+		AllowPrecise: true,
+		PrecisePoint: &scene.Point{Lat: 40.7128, Lng: -74.0060},
+	})
+	index.IndexEvent(&scene.Event{
+		ID:           uuid.New().String(),
+		SceneID:      hiddenID,
+		Title:        "Secret Afterparty",
+		AllowPrecise: true,
+		PrecisePoint: &scene.Point{Lat: 40.7128, Lng: -74.0060},
+	})
+	publicID := uuid.New().String()
+	index.IndexScene(&scene.Scene{
+		ID:           publicID,
+		Name:         "Rooftop Techno",
+		Visibility:   scene.VisibilityPublic,
+		AllowPrecise: true,
+		PrecisePoint: &scene.Point{Lat: 51.5074, Lng: -0.1278},
+	})
+
+	for _, rawQuery := range []string{"q=secret", "q=secrt&fuzzy=true", "q=unlisted", "q=techno", "q=rooftop"} {
+		t.Run(rawQuery, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/search?"+rawQuery, nil)
+			w := httptest.NewRecorder()
+
+			handlers.Search(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			body := w.Body.String()
+			for _, leaked := range []string{"Secret", "Unlisted", "precise_point", "40.7128", "51.5074", "-0.1278"} {
+				if strings.Contains(body, leaked) {
+					t.Errorf("expected response not to contain %q, got %s", leaked, body)
+				}
+			}
+
+			var response SearchResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			for _, result := range response.Results {
+				if result.SceneID == hiddenID {
+					t.Errorf("expected no hits from the hidden scene, got %+v", result)
+				}
+			}
+		})
+	}
+}
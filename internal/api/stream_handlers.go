@@ -64,16 +64,14 @@ func (h *StreamHandlers) CreateStream(w http.ResponseWriter, r *http.Request) {
 	// Extract user DID from context (set by auth middleware)
 	userDID := middleware.GetUserDID(ctx)
 	if userDID == "" {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
-		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
 		return
 	}
 
 	// Parse request body
 	var req CreateStreamRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+		writeDecodeError(w, r, err)
 		return
 	}
 
@@ -82,8 +80,7 @@ func (h *StreamHandlers) CreateStream(w http.ResponseWriter, r *http.Request) {
 	eventIDProvided := req.EventID != nil && strings.TrimSpace(*req.EventID) != ""
 	
 	if sceneIDProvided == eventIDProvided { // both true or both false
-		ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Exactly one of scene_id or event_id must be provided")
+		writeError(w, r, ErrCodeValidation, "Exactly one of scene_id or event_id must be provided")
 		return
 	}
 
@@ -103,33 +100,28 @@ func (h *StreamHandlers) CreateStream(w http.ResponseWriter, r *http.Request) {
 		isOwner, err := h.isSceneOwner(ctx, *req.SceneID, userDID)
 		if err != nil {
 			if errors.Is(err, scene.ErrSceneNotFound) {
-				ctx = middleware.SetErrorCode(ctx, ErrCodeNotFound)
-				WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+				writeError(w, r, ErrCodeNotFound, "Scene not found")
 			} else {
 				slog.ErrorContext(ctx, "failed to check scene ownership", "error", err)
-				ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
-				WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+				writeError(w, r, ErrCodeInternal, "Internal server error")
 			}
 			return
 		}
 		if !isOwner {
-			ctx = middleware.SetErrorCode(ctx, ErrCodeForbidden)
-			WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You must be the scene owner to create a stream")
+			writeError(w, r, ErrCodeForbidden, "You must be the scene owner to create a stream")
 			return
 		}
 	}
 
 	if eventIDProvided {
 		// Check if user is the event host (scene owner)
-		event, err := h.eventRepo.GetByID(*req.EventID)
+		event, err := h.eventRepo.GetByID(ctx, *req.EventID)
 		if err != nil {
 			if errors.Is(err, scene.ErrEventNotFound) {
-				ctx = middleware.SetErrorCode(ctx, ErrCodeNotFound)
-				WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Event not found")
+				writeError(w, r, ErrCodeNotFound, "Event not found")
 			} else {
 				slog.ErrorContext(ctx, "failed to get event", "error", err)
-				ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
-				WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+				writeError(w, r, ErrCodeInternal, "Internal server error")
 			}
 			return
 		}
@@ -138,18 +130,15 @@ func (h *StreamHandlers) CreateStream(w http.ResponseWriter, r *http.Request) {
 		isOwner, err := h.isSceneOwner(ctx, event.SceneID, userDID)
 		if err != nil {
 			if errors.Is(err, scene.ErrSceneNotFound) {
-				ctx = middleware.SetErrorCode(ctx, ErrCodeNotFound)
-				WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+				writeError(w, r, ErrCodeNotFound, "Scene not found")
 			} else {
 				slog.ErrorContext(ctx, "failed to check scene ownership", "error", err)
-				ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
-				WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+				writeError(w, r, ErrCodeInternal, "Internal server error")
 			}
 			return
 		}
 		if !isOwner {
-			ctx = middleware.SetErrorCode(ctx, ErrCodeForbidden)
-			WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You must be the event host to create a stream")
+			writeError(w, r, ErrCodeForbidden, "You must be the event host to create a stream")
 			return
 		}
 	}
@@ -161,8 +150,7 @@ func (h *StreamHandlers) CreateStream(w http.ResponseWriter, r *http.Request) {
 			"error", err,
 			"user_did", userDID,
 		)
-		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to create stream session")
+		writeError(w, r, ErrCodeInternal, "Failed to create stream session")
 		return
 	}
 
@@ -175,7 +163,7 @@ func (h *StreamHandlers) CreateStream(w http.ResponseWriter, r *http.Request) {
 		RequestID:  middleware.GetRequestID(ctx),
 	}
 
-	if _, err := h.auditRepo.LogAccess(auditEntry); err != nil {
+	if _, err := h.auditRepo.LogAccess(ctx, auditEntry); err != nil {
 		// Log error but don't fail the request
 		slog.ErrorContext(ctx, "failed to log stream creation audit entry",
 			"error", err,
@@ -207,8 +195,7 @@ func (h *StreamHandlers) EndStream(w http.ResponseWriter, r *http.Request) {
 	// Extract user DID from context (set by auth middleware)
 	userDID := middleware.GetUserDID(ctx)
 	if userDID == "" {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
-		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
 		return
 	}
 
@@ -216,8 +203,7 @@ func (h *StreamHandlers) EndStream(w http.ResponseWriter, r *http.Request) {
 	// Expected: /streams/{id}/end
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/streams/"), "/")
 	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "end" {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid URL path")
+		writeError(w, r, ErrCodeBadRequest, "Invalid URL path")
 		return
 	}
 	streamID := pathParts[0]
@@ -226,20 +212,17 @@ func (h *StreamHandlers) EndStream(w http.ResponseWriter, r *http.Request) {
 	session, err := h.streamRepo.GetByID(streamID)
 	if err != nil {
 		if errors.Is(err, stream.ErrStreamNotFound) {
-			ctx = middleware.SetErrorCode(ctx, ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Stream session not found")
+			writeError(w, r, ErrCodeNotFound, "Stream session not found")
 		} else {
 			slog.ErrorContext(ctx, "failed to get stream session", "error", err)
-			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
-			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+			writeError(w, r, ErrCodeInternal, "Internal server error")
 		}
 		return
 	}
 
 	// Verify that the user is the stream host
 	if session.HostDID != userDID {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeForbidden)
-		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You must be the stream host to end it")
+		writeError(w, r, ErrCodeForbidden, "You must be the stream host to end it")
 		return
 	}
 
@@ -251,8 +234,7 @@ func (h *StreamHandlers) EndStream(w http.ResponseWriter, r *http.Request) {
 			"stream_id", streamID,
 			"user_did", userDID,
 		)
-		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to end stream session")
+		writeError(w, r, ErrCodeInternal, "Failed to end stream session")
 		return
 	}
 
@@ -265,7 +247,7 @@ func (h *StreamHandlers) EndStream(w http.ResponseWriter, r *http.Request) {
 		RequestID:  middleware.GetRequestID(ctx),
 	}
 
-	if _, err := h.auditRepo.LogAccess(auditEntry); err != nil {
+	if _, err := h.auditRepo.LogAccess(ctx, auditEntry); err != nil {
 		// Log error but don't fail the request
 		slog.ErrorContext(ctx, "failed to log stream ending audit entry",
 			"error", err,
@@ -292,7 +274,7 @@ func (h *StreamHandlers) EndStream(w http.ResponseWriter, r *http.Request) {
 
 // isSceneOwner checks if the given userDID owns the scene.
 func (h *StreamHandlers) isSceneOwner(ctx context.Context, sceneID, userDID string) (bool, error) {
-	foundScene, err := h.sceneRepo.GetByID(sceneID)
+	foundScene, err := h.sceneRepo.GetByID(ctx, sceneID)
 	if err != nil {
 		return false, err
 	}
@@ -311,8 +293,7 @@ func (h *StreamHandlers) JoinStream(w http.ResponseWriter, r *http.Request) {
 	// Extract user DID from context (set by auth middleware)
 	userDID := middleware.GetUserDID(ctx)
 	if userDID == "" {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
-		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
 		return
 	}
 
@@ -320,8 +301,7 @@ func (h *StreamHandlers) JoinStream(w http.ResponseWriter, r *http.Request) {
 	// Expected: /streams/{id}/join
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/streams/"), "/")
 	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "join" {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid URL path")
+		writeError(w, r, ErrCodeBadRequest, "Invalid URL path")
 		return
 	}
 	streamID := pathParts[0]
@@ -330,12 +310,10 @@ func (h *StreamHandlers) JoinStream(w http.ResponseWriter, r *http.Request) {
 	session, err := h.streamRepo.GetByID(streamID)
 	if err != nil {
 		if errors.Is(err, stream.ErrStreamNotFound) {
-			ctx = middleware.SetErrorCode(ctx, ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Stream session not found")
+			writeError(w, r, ErrCodeNotFound, "Stream session not found")
 		} else {
 			slog.ErrorContext(ctx, "failed to get stream session", "error", err)
-			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
-			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+			writeError(w, r, ErrCodeInternal, "Internal server error")
 		}
 		return
 	}
@@ -356,8 +334,7 @@ func (h *StreamHandlers) JoinStream(w http.ResponseWriter, r *http.Request) {
 			"stream_id", streamID,
 			"user_did", userDID,
 		)
-		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to record join event")
+		writeError(w, r, ErrCodeInternal, "Failed to record join event")
 		return
 	}
 
@@ -404,7 +381,7 @@ func (h *StreamHandlers) JoinStream(w http.ResponseWriter, r *http.Request) {
 		RequestID:  middleware.GetRequestID(ctx),
 	}
 
-	if _, err := h.auditRepo.LogAccess(auditEntry); err != nil {
+	if _, err := h.auditRepo.LogAccess(ctx, auditEntry); err != nil {
 		// Log error but don't fail the request
 		slog.ErrorContext(ctx, "failed to log join audit entry",
 			"error", err,
@@ -448,8 +425,7 @@ func (h *StreamHandlers) LeaveStream(w http.ResponseWriter, r *http.Request) {
 	// Extract user DID from context (set by auth middleware)
 	userDID := middleware.GetUserDID(ctx)
 	if userDID == "" {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
-		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		writeError(w, r, ErrCodeAuthFailed, "Authentication required")
 		return
 	}
 
@@ -457,8 +433,7 @@ func (h *StreamHandlers) LeaveStream(w http.ResponseWriter, r *http.Request) {
 	// Expected: /streams/{id}/leave
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/streams/"), "/")
 	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "leave" {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid URL path")
+		writeError(w, r, ErrCodeBadRequest, "Invalid URL path")
 		return
 	}
 	streamID := pathParts[0]
@@ -467,12 +442,10 @@ func (h *StreamHandlers) LeaveStream(w http.ResponseWriter, r *http.Request) {
 	session, err := h.streamRepo.GetByID(streamID)
 	if err != nil {
 		if errors.Is(err, stream.ErrStreamNotFound) {
-			ctx = middleware.SetErrorCode(ctx, ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Stream session not found")
+			writeError(w, r, ErrCodeNotFound, "Stream session not found")
 		} else {
 			slog.ErrorContext(ctx, "failed to get stream session", "error", err)
-			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
-			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+			writeError(w, r, ErrCodeInternal, "Internal server error")
 		}
 		return
 	}
@@ -484,8 +457,7 @@ func (h *StreamHandlers) LeaveStream(w http.ResponseWriter, r *http.Request) {
 			"stream_id", streamID,
 			"user_did", userDID,
 		)
-		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to record leave event")
+		writeError(w, r, ErrCodeInternal, "Failed to record leave event")
 		return
 	}
 
@@ -503,7 +475,7 @@ func (h *StreamHandlers) LeaveStream(w http.ResponseWriter, r *http.Request) {
 		RequestID:  middleware.GetRequestID(ctx),
 	}
 
-	if _, err := h.auditRepo.LogAccess(auditEntry); err != nil {
+	if _, err := h.auditRepo.LogAccess(ctx, auditEntry); err != nil {
 		// Log error but don't fail the request
 		slog.ErrorContext(ctx, "failed to log leave audit entry",
 			"error", err,
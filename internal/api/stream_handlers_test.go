@@ -37,7 +37,7 @@ func TestCreateStream_Success_WithSceneID(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		CreatedAt:     &time.Time{},
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -100,7 +100,7 @@ func TestCreateStream_Success_WithEventID(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		CreatedAt:     &time.Time{},
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -113,7 +113,7 @@ func TestCreateStream_Success_WithEventID(t *testing.T) {
 		StartsAt:      startsAt,
 		Status:        "scheduled",
 	}
-	if err := eventRepo.Insert(testEvent); err != nil {
+	if err := eventRepo.Insert(t.Context(), testEvent); err != nil {
 		t.Fatalf("failed to insert event: %v", err)
 	}
 
@@ -192,7 +192,7 @@ func TestCreateStream_BothSceneAndEvent(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		CreatedAt:     &time.Time{},
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -205,7 +205,7 @@ func TestCreateStream_BothSceneAndEvent(t *testing.T) {
 		StartsAt:      startsAt,
 		Status:        "scheduled",
 	}
-	if err := eventRepo.Insert(testEvent); err != nil {
+	if err := eventRepo.Insert(t.Context(), testEvent); err != nil {
 		t.Fatalf("failed to insert event: %v", err)
 	}
 
@@ -259,7 +259,7 @@ func TestCreateStream_Forbidden_NotSceneOwner(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		CreatedAt:     &time.Time{},
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -302,7 +302,7 @@ func TestCreateStream_Forbidden_NotEventHost(t *testing.T) {
 		CoarseGeohash: "dr5regw",
 		CreatedAt:     &time.Time{},
 	}
-	if err := sceneRepo.Insert(testScene); err != nil {
+	if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 		t.Fatalf("failed to insert scene: %v", err)
 	}
 
@@ -315,7 +315,7 @@ func TestCreateStream_Forbidden_NotEventHost(t *testing.T) {
 		StartsAt:      startsAt,
 		Status:        "scheduled",
 	}
-	if err := eventRepo.Insert(testEvent); err != nil {
+	if err := eventRepo.Insert(t.Context(), testEvent); err != nil {
 		t.Fatalf("failed to insert event: %v", err)
 	}
 
@@ -502,7 +502,7 @@ OwnerDID:      "did:plc:test123",
 CoarseGeohash: "dr5regw",
 CreatedAt:     &time.Time{},
 }
-if err := sceneRepo.Insert(testScene); err != nil {
+if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert scene: %v", err)
 }
 
@@ -618,7 +618,7 @@ OwnerDID:      "did:plc:test123",
 CoarseGeohash: "dr5regw",
 CreatedAt:     &time.Time{},
 }
-if err := sceneRepo.Insert(testScene); err != nil {
+if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert scene: %v", err)
 }
 
@@ -702,7 +702,7 @@ OwnerDID:      "did:plc:test123",
 CoarseGeohash: "dr5regw",
 CreatedAt:     &time.Time{},
 }
-if err := sceneRepo.Insert(testScene); err != nil {
+if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert scene: %v", err)
 }
 
@@ -767,7 +767,7 @@ OwnerDID:      "did:plc:test123",
 CoarseGeohash: "dr5regw",
 CreatedAt:     &time.Time{},
 }
-if err := sceneRepo.Insert(testScene); err != nil {
+if err := sceneRepo.Insert(t.Context(), testScene); err != nil {
 t.Fatalf("failed to insert scene: %v", err)
 }
 
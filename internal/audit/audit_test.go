@@ -1,12 +1,17 @@
 package audit
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/onnwee/subcults/internal/middleware"
 )
 
@@ -23,7 +28,7 @@ func TestInMemoryRepository_LogAccess(t *testing.T) {
 		UserAgent:  "Mozilla/5.0",
 	}
 
-	log, err := repo.LogAccess(entry)
+	log, err := repo.LogAccess(t.Context(), entry)
 	if err != nil {
 		t.Fatalf("LogAccess() error = %v", err)
 	}
@@ -76,7 +81,7 @@ func TestInMemoryRepository_QueryByEntity(t *testing.T) {
 	}
 
 	for _, entry := range entries {
-		_, err := repo.LogAccess(entry)
+		_, err := repo.LogAccess(t.Context(), entry)
 		if err != nil {
 			t.Fatalf("LogAccess() error = %v", err)
 		}
@@ -85,7 +90,7 @@ func TestInMemoryRepository_QueryByEntity(t *testing.T) {
 	}
 
 	// Query for scene-1 logs
-	results, err := repo.QueryByEntity("scene", "scene-1", 0)
+	results, err := repo.QueryByEntity(t.Context(), "scene", "scene-1", 0)
 	if err != nil {
 		t.Fatalf("QueryByEntity() error = %v", err)
 	}
@@ -122,7 +127,7 @@ func TestInMemoryRepository_QueryByEntity_WithLimit(t *testing.T) {
 			EntityID:   "scene-1",
 			Action:     "access_precise_location",
 		}
-		_, err := repo.LogAccess(entry)
+		_, err := repo.LogAccess(t.Context(), entry)
 		if err != nil {
 			t.Fatalf("LogAccess() error = %v", err)
 		}
@@ -130,7 +135,7 @@ func TestInMemoryRepository_QueryByEntity_WithLimit(t *testing.T) {
 	}
 
 	// Query with limit=2
-	results, err := repo.QueryByEntity("scene", "scene-1", 2)
+	results, err := repo.QueryByEntity(t.Context(), "scene", "scene-1", 2)
 	if err != nil {
 		t.Fatalf("QueryByEntity() error = %v", err)
 	}
@@ -153,7 +158,7 @@ func TestInMemoryRepository_QueryByUser(t *testing.T) {
 	}
 
 	for _, entry := range entries {
-		_, err := repo.LogAccess(entry)
+		_, err := repo.LogAccess(t.Context(), entry)
 		if err != nil {
 			t.Fatalf("LogAccess() error = %v", err)
 		}
@@ -161,7 +166,7 @@ func TestInMemoryRepository_QueryByUser(t *testing.T) {
 	}
 
 	// Query for user1 logs
-	results, err := repo.QueryByUser("user1", 0)
+	results, err := repo.QueryByUser(t.Context(), "user1", 0)
 	if err != nil {
 		t.Fatalf("QueryByUser() error = %v", err)
 	}
@@ -197,7 +202,7 @@ func TestInMemoryRepository_QueryByUser_WithLimit(t *testing.T) {
 			EntityID:   "scene-1",
 			Action:     "access_precise_location",
 		}
-		_, err := repo.LogAccess(entry)
+		_, err := repo.LogAccess(t.Context(), entry)
 		if err != nil {
 			t.Fatalf("LogAccess() error = %v", err)
 		}
@@ -205,7 +210,7 @@ func TestInMemoryRepository_QueryByUser_WithLimit(t *testing.T) {
 	}
 
 	// Query with limit=3
-	results, err := repo.QueryByUser("user1", 3)
+	results, err := repo.QueryByUser(t.Context(), "user1", 3)
 	if err != nil {
 		t.Fatalf("QueryByUser() error = %v", err)
 	}
@@ -218,7 +223,7 @@ func TestInMemoryRepository_QueryByUser_WithLimit(t *testing.T) {
 func TestInMemoryRepository_QueryByEntity_NoResults(t *testing.T) {
 	repo := NewInMemoryRepository()
 
-	results, err := repo.QueryByEntity("scene", "nonexistent", 0)
+	results, err := repo.QueryByEntity(t.Context(), "scene", "nonexistent", 0)
 	if err != nil {
 		t.Fatalf("QueryByEntity() error = %v", err)
 	}
@@ -231,7 +236,7 @@ func TestInMemoryRepository_QueryByEntity_NoResults(t *testing.T) {
 func TestInMemoryRepository_QueryByUser_NoResults(t *testing.T) {
 	repo := NewInMemoryRepository()
 
-	results, err := repo.QueryByUser("nonexistent", 0)
+	results, err := repo.QueryByUser(t.Context(), "nonexistent", 0)
 	if err != nil {
 		t.Fatalf("QueryByUser() error = %v", err)
 	}
@@ -264,7 +269,7 @@ func TestLogAccess_WithContext(t *testing.T) {
 	}
 
 	// Verify the log was created with context values
-	results, err := repo.QueryByEntity("scene", "scene-123", 0)
+	results, err := repo.QueryByEntity(ctx, "scene", "scene-123", 0)
 	if err != nil {
 		t.Fatalf("QueryByEntity() error = %v", err)
 	}
@@ -314,7 +319,7 @@ func TestLogAccessFromRequest(t *testing.T) {
 	}
 
 	// Verify the log was created with request metadata
-	results, err := repo.QueryByEntity("scene", "scene-123", 0)
+	results, err := repo.QueryByEntity(t.Context(), "scene", "scene-123", 0)
 	if err != nil {
 		t.Fatalf("QueryByEntity() error = %v", err)
 	}
@@ -339,8 +344,41 @@ func TestLogAccessFromRequest(t *testing.T) {
 	}
 }
 
+func TestLogAccessFromRequest_GeneratesRequestIDWhenMissing(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	// No X-Request-ID header: middleware should generate one for the audit log
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scenes/scene-124", nil)
+	req.RemoteAddr = "192.168.1.100:12345"
+
+	handler := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := LogAccessFromRequest(r, repo, "scene", "scene-124", "access_precise_location"); err != nil {
+			t.Fatalf("LogAccessFromRequest() error = %v", err)
+		}
+	}))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	results, err := repo.QueryByEntity(t.Context(), "scene", "scene-124", 0)
+	if err != nil {
+		t.Fatalf("QueryByEntity() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 log entry, got %d", len(results))
+	}
+
+	log := results[0]
+	if _, err := uuid.Parse(log.RequestID); err != nil {
+		t.Errorf("LogAccessFromRequest() RequestID = %q, want generated UUID", log.RequestID)
+	}
+	if responseID := rr.Header().Get(middleware.RequestIDHeader); responseID != log.RequestID {
+		t.Errorf("response %s = %q, want %q", middleware.RequestIDHeader, responseID, log.RequestID)
+	}
+}
+
 func TestLogAccessFromRequest_WithXForwardedFor(t *testing.T) {
 	repo := NewInMemoryRepository()
+	useTrustedProxies(t, "192.168.0.0/16", "198.51.100.0/24", "192.0.2.0/24")
 
 	// Create a test HTTP request with X-Forwarded-For header containing multiple IPs
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/scenes/scene-123", nil)
@@ -355,7 +393,7 @@ func TestLogAccessFromRequest_WithXForwardedFor(t *testing.T) {
 		t.Fatalf("LogAccessFromRequest() error = %v", err)
 	}
 
-	results, err := repo.QueryByEntity("scene", "scene-123", 0)
+	results, err := repo.QueryByEntity(ctx, "scene", "scene-123", 0)
 	if err != nil {
 		t.Fatalf("QueryByEntity() error = %v", err)
 	}
@@ -365,9 +403,9 @@ func TestLogAccessFromRequest_WithXForwardedFor(t *testing.T) {
 	}
 
 	log := results[0]
-	// X-Forwarded-For should use first IP (original client)
+	// Trusted proxy hops are skipped, leaving the original client
 	if log.IPAddress != "203.0.113.195" {
-		t.Errorf("LogAccessFromRequest() IPAddress = %q, want 203.0.113.195 (first IP from X-Forwarded-For)", log.IPAddress)
+		t.Errorf("LogAccessFromRequest() IPAddress = %q, want 203.0.113.195 (first untrusted IP from X-Forwarded-For)", log.IPAddress)
 	}
 }
 
@@ -387,7 +425,7 @@ func TestLogAccessFromRequest_WithEmptyXForwardedFor(t *testing.T) {
 		t.Fatalf("LogAccessFromRequest() error = %v", err)
 	}
 
-	results, err := repo.QueryByEntity("scene", "scene-456", 0)
+	results, err := repo.QueryByEntity(ctx, "scene", "scene-456", 0)
 	if err != nil {
 		t.Fatalf("QueryByEntity() error = %v", err)
 	}
@@ -405,6 +443,8 @@ func TestLogAccessFromRequest_WithEmptyXForwardedFor(t *testing.T) {
 
 func TestLogAccessFromRequest_WithXRealIP(t *testing.T) {
 	repo := NewInMemoryRepository()
+	// The request arrives through a trusted load balancer
+	useTrustedProxies(t, "192.168.0.0/16")
 
 	// Create a test HTTP request with X-Real-IP header
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/scenes/scene-789", nil)
@@ -419,7 +459,7 @@ func TestLogAccessFromRequest_WithXRealIP(t *testing.T) {
 		t.Fatalf("LogAccessFromRequest() error = %v", err)
 	}
 
-	results, err := repo.QueryByEntity("scene", "scene-789", 0)
+	results, err := repo.QueryByEntity(ctx, "scene", "scene-789", 0)
 	if err != nil {
 		t.Fatalf("QueryByEntity() error = %v", err)
 	}
@@ -437,6 +477,8 @@ func TestLogAccessFromRequest_WithXRealIP(t *testing.T) {
 
 func TestLogAccessFromRequest_WithXRealIPAndPort(t *testing.T) {
 	repo := NewInMemoryRepository()
+	// The request arrives through a trusted load balancer
+	useTrustedProxies(t, "192.168.0.0/16")
 
 	// Create a test HTTP request with X-Real-IP header containing port
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/scenes/scene-890", nil)
@@ -451,7 +493,7 @@ func TestLogAccessFromRequest_WithXRealIPAndPort(t *testing.T) {
 		t.Fatalf("LogAccessFromRequest() error = %v", err)
 	}
 
-	results, err := repo.QueryByEntity("scene", "scene-890", 0)
+	results, err := repo.QueryByEntity(ctx, "scene", "scene-890", 0)
 	if err != nil {
 		t.Fatalf("QueryByEntity() error = %v", err)
 	}
@@ -469,6 +511,7 @@ func TestLogAccessFromRequest_WithXRealIPAndPort(t *testing.T) {
 
 func TestLogAccessFromRequest_WithXForwardedForAndPort(t *testing.T) {
 	repo := NewInMemoryRepository()
+	useTrustedProxies(t, "192.168.0.0/16", "198.51.100.0/24")
 
 	// Create a test HTTP request with X-Forwarded-For containing port in first IP
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/scenes/scene-891", nil)
@@ -483,7 +526,7 @@ func TestLogAccessFromRequest_WithXForwardedForAndPort(t *testing.T) {
 		t.Fatalf("LogAccessFromRequest() error = %v", err)
 	}
 
-	results, err := repo.QueryByEntity("scene", "scene-891", 0)
+	results, err := repo.QueryByEntity(ctx, "scene", "scene-891", 0)
 	if err != nil {
 		t.Fatalf("QueryByEntity() error = %v", err)
 	}
@@ -499,6 +542,155 @@ func TestLogAccessFromRequest_WithXForwardedForAndPort(t *testing.T) {
 	}
 }
 
+// useTrustedProxies configures trusted proxy CIDRs for the duration of the test.
+func useTrustedProxies(t *testing.T, cidrs ...string) {
+	t.Helper()
+	proxies := make([]net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatalf("ParseCIDR(%q) error = %v", cidr, err)
+		}
+		proxies = append(proxies, *network)
+	}
+	SetTrustedProxies(proxies)
+	t.Cleanup(func() { SetTrustedProxies(nil) })
+}
+
+func TestExtractIPAddress_TrustedProxies(t *testing.T) {
+	useTrustedProxies(t, "10.0.0.0/8", "2001:db8:ffff::/48")
+
+	tests := []struct {
+		name       string
+		xff        string
+		xRealIP    string
+		remoteAddr string
+		want       string
+	}{
+		{
+			name:       "spoofed leftmost entry is ignored",
+			xff:        "1.2.3.4, 203.0.113.7",
+			remoteAddr: "10.0.0.1:443",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "multi-hop chain skips trusted proxies",
+			xff:        "203.0.113.7, 10.1.1.1, 10.2.2.2",
+			remoteAddr: "10.0.0.1:443",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "spoofed entry behind real client is ignored",
+			xff:        "6.6.6.6, 203.0.113.7, 10.1.1.1",
+			remoteAddr: "10.0.0.1:443",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "untrusted hop with port",
+			xff:        "203.0.113.7:5555, 10.1.1.1",
+			remoteAddr: "10.0.0.1:443",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "ipv6 hops",
+			xff:        "2001:db8::1, [2001:db8:ffff::2]:443",
+			remoteAddr: "[2001:db8:ffff::1]:443",
+			want:       "2001:db8::1",
+		},
+		{
+			name:       "fully trusted chain falls back to RemoteAddr",
+			xff:        "10.1.1.1, 10.2.2.2",
+			remoteAddr: "10.0.0.1:443",
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "fully trusted chain ignores X-Real-IP",
+			xff:        "10.1.1.1",
+			xRealIP:    "198.51.100.50",
+			remoteAddr: "10.0.0.1:443",
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "unparsable hop falls back to RemoteAddr",
+			xff:        "203.0.113.7, not-an-ip, 10.1.1.1",
+			remoteAddr: "10.0.0.1:443",
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "missing header uses RemoteAddr",
+			remoteAddr: "192.168.1.100:12345",
+			want:       "192.168.1.100",
+		},
+		{
+			name:       "missing header uses ipv6 RemoteAddr",
+			remoteAddr: "[2001:db8::5]:8080",
+			want:       "2001:db8::5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.xff != "" {
+				req.Header.Set("X-Forwarded-For", tt.xff)
+			}
+			if tt.xRealIP != "" {
+				req.Header.Set("X-Real-IP", tt.xRealIP)
+			}
+			req.RemoteAddr = tt.remoteAddr
+
+			if got := extractIPAddress(req); got != tt.want {
+				t.Errorf("extractIPAddress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractIPAddress_UntrustedPeerIgnoresForwardingHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxies []string
+	}{
+		{"no trusted proxies", nil},
+		{"peer outside trusted proxies", []string{"10.0.0.0/8"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTrustedProxies(t, tt.proxies...)
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("X-Forwarded-For", "1.2.3.4, 203.0.113.7")
+			req.Header.Set("X-Real-IP", "198.51.100.50")
+			req.RemoteAddr = "192.168.1.100:12345"
+
+			// A client connecting directly controls both headers
+			if got := extractIPAddress(req); got != "192.168.1.100" {
+				t.Errorf("extractIPAddress() = %q, want 192.168.1.100", got)
+			}
+		})
+	}
+}
+
+func TestExtractIPAddress_MultipleHeaderLines(t *testing.T) {
+	useTrustedProxies(t, "10.0.0.0/8")
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Add("X-Forwarded-For", "1.2.3.4")
+	req.Header.Add("X-Forwarded-For", "203.0.113.7, 10.1.1.1")
+	req.RemoteAddr = "10.0.0.1:443"
+
+	// The last line holds the hops appended by our proxies
+	if got := extractIPAddress(req); got != "203.0.113.7" {
+		t.Errorf("extractIPAddress() = %q, want 203.0.113.7", got)
+	}
+
+	req.Header.Del("X-Forwarded-For")
+	req.Header.Add("X-Forwarded-For", "203.0.113.7")
+	req.Header.Add("X-Forwarded-For", "10.1.1.1")
+	if got := extractIPAddress(req); got != "203.0.113.7" {
+		t.Errorf("extractIPAddress() = %q, want 203.0.113.7 from the first line", got)
+	}
+}
+
 func TestInMemoryRepository_ThreadSafety(t *testing.T) {
 	repo := NewInMemoryRepository()
 	
@@ -512,7 +704,7 @@ func TestInMemoryRepository_ThreadSafety(t *testing.T) {
 				EntityID:   "scene-1",
 				Action:     "access_precise_location",
 			}
-			_, err := repo.LogAccess(entry)
+			_, err := repo.LogAccess(t.Context(), entry)
 			if err != nil {
 				t.Errorf("LogAccess() error = %v", err)
 			}
@@ -526,7 +718,7 @@ func TestInMemoryRepository_ThreadSafety(t *testing.T) {
 	}
 
 	// Verify all logs were recorded
-	results, err := repo.QueryByEntity("scene", "scene-1", 0)
+	results, err := repo.QueryByEntity(t.Context(), "scene", "scene-1", 0)
 	if err != nil {
 		t.Fatalf("QueryByEntity() error = %v", err)
 	}
@@ -661,3 +853,332 @@ func TestLogAccessFromRequest_ValidationErrors(t *testing.T) {
 		})
 	}
 }
+
+// logAt records an entry and backdates it to createdAt.
+func logAt(t *testing.T, repo *InMemoryRepository, createdAt time.Time, entityType, action string) *AuditLog {
+	t.Helper()
+	log, err := repo.LogAccess(t.Context(), LogEntry{
+		UserDID:    "did:plc:user",
+		EntityType: entityType,
+		EntityID:   entityType + "-1",
+		Action:     action,
+	})
+	if err != nil {
+		t.Fatalf("LogAccess() error = %v", err)
+	}
+	repo.mu.Lock()
+	repo.logs[log.ID].CreatedAt = createdAt
+	repo.mu.Unlock()
+	log.CreatedAt = createdAt
+	return log
+}
+
+func TestInMemoryRepository_Export(t *testing.T) {
+	repo := NewInMemoryRepository()
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var logs []*AuditLog
+	for i := 0; i < 5; i++ {
+		logs = append(logs, logAt(t, repo, base.Add(time.Duration(i)*time.Hour), "scene", "access_precise_location"))
+	}
+	logAt(t, repo, base.Add(2*time.Hour), "event", "access_coarse_location")
+
+	var buf bytes.Buffer
+	err := repo.Export(t.Context(), &buf, ExportFilter{
+		Start:      base.Add(1 * time.Hour),
+		End:        base.Add(3 * time.Hour),
+		EntityType: "scene",
+	})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	wantIDs := []string{logs[3].ID, logs[2].ID, logs[1].ID}
+	if len(lines) != len(wantIDs) {
+		t.Fatalf("Export() wrote %d lines, want %d:\n%s", len(lines), len(wantIDs), buf.String())
+	}
+	for i, line := range lines {
+		var got AuditLog
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v: %s", i, err, line)
+		}
+		if got.ID != wantIDs[i] {
+			t.Errorf("line %d ID = %s, want %s (newest first)", i, got.ID, wantIDs[i])
+		}
+		if got.EntityType != "scene" || got.Action != "access_precise_location" {
+			t.Errorf("line %d = %+v, want scene access_precise_location", i, got)
+		}
+	}
+}
+
+func TestInMemoryRepository_Export_FiltersAndBatches(t *testing.T) {
+	repo := NewInMemoryRepository()
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// More than one batch, alternating actions
+	total := exportBatchSize + 10
+	for i := 0; i < total; i++ {
+		action := "access_precise_location"
+		if i%2 == 1 {
+			action = "access_coarse_location"
+		}
+		logAt(t, repo, base.Add(time.Duration(i)*time.Second), "scene", action)
+	}
+
+	tests := []struct {
+		name   string
+		filter ExportFilter
+		want   int
+	}{
+		{"no filter", ExportFilter{}, total},
+		{"action", ExportFilter{Action: "access_coarse_location"}, total / 2},
+		{"open-ended start", ExportFilter{Start: base.Add(time.Duration(total-3) * time.Second)}, 3},
+		{"no matches", ExportFilter{EntityType: "event"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := repo.Export(t.Context(), &buf, tt.filter); err != nil {
+				t.Fatalf("Export() error = %v", err)
+			}
+
+			var count int
+			var previous time.Time
+			decoder := json.NewDecoder(&buf)
+			for decoder.More() {
+				var log AuditLog
+				if err := decoder.Decode(&log); err != nil {
+					t.Fatalf("invalid JSON line: %v", err)
+				}
+				if count > 0 && log.CreatedAt.After(previous) {
+					t.Errorf("entry %d at %v is newer than previous %v", count, log.CreatedAt, previous)
+				}
+				previous = log.CreatedAt
+				count++
+			}
+			if count != tt.want {
+				t.Errorf("Export() wrote %d entries, want %d", count, tt.want)
+			}
+		})
+	}
+}
+
+// cancelingWriter cancels its context on the first write.
+type cancelingWriter struct {
+	bytes.Buffer
+	cancel context.CancelFunc
+}
+
+func (w *cancelingWriter) Write(p []byte) (int, error) {
+	w.cancel()
+	return w.Buffer.Write(p)
+}
+
+func TestInMemoryRepository_CanceledContext(t *testing.T) {
+	repo := NewInMemoryRepository()
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < exportBatchSize+10; i++ {
+		logAt(t, repo, base.Add(time.Duration(i)*time.Second), "scene", "access_precise_location")
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := repo.LogAccess(canceled, LogEntry{EntityType: "scene", EntityID: "s1", Action: "access_precise_location"}); err != context.Canceled {
+		t.Errorf("LogAccess: expected context.Canceled, got %v", err)
+	}
+	if _, err := repo.QueryByEntity(canceled, "scene", "scene-1", 0); err != context.Canceled {
+		t.Errorf("QueryByEntity: expected context.Canceled, got %v", err)
+	}
+
+	// Canceling mid-export stops before the next batch
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := &cancelingWriter{cancel: cancel}
+	if err := repo.Export(ctx, w, ExportFilter{}); err != context.Canceled {
+		t.Fatalf("Export: expected context.Canceled, got %v", err)
+	}
+	if lines := strings.Count(w.String(), "\n"); lines != exportBatchSize {
+		t.Errorf("expected export to stop after one batch of %d entries, wrote %d", exportBatchSize, lines)
+	}
+}
+
+func TestInMemoryRepository_QueryByTimeRange(t *testing.T) {
+	repo := NewInMemoryRepository()
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var logs []*AuditLog
+	for i := 0; i < 4; i++ {
+		logs = append(logs, logAt(t, repo, base.Add(time.Duration(i)*time.Hour), "scene", "access_precise_location"))
+	}
+
+	tests := []struct {
+		name    string
+		start   time.Time
+		end     time.Time
+		limit   int
+		wantIDs []string
+	}{
+		{"bounded inclusive", base.Add(1 * time.Hour), base.Add(2 * time.Hour), 0, []string{logs[2].ID, logs[1].ID}},
+		{"open-ended start", time.Time{}, base.Add(1 * time.Hour), 0, []string{logs[1].ID, logs[0].ID}},
+		{"zero end means now", base.Add(2 * time.Hour), time.Time{}, 0, []string{logs[3].ID, logs[2].ID}},
+		{"fully open", time.Time{}, time.Time{}, 0, []string{logs[3].ID, logs[2].ID, logs[1].ID, logs[0].ID}},
+		{"limit keeps newest", time.Time{}, time.Time{}, 1, []string{logs[3].ID}},
+		{"empty range", base.Add(10 * time.Minute), base.Add(20 * time.Minute), 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := repo.QueryByTimeRange(t.Context(), tt.start, tt.end, tt.limit)
+			if err != nil {
+				t.Fatalf("QueryByTimeRange() error = %v", err)
+			}
+			if len(results) != len(tt.wantIDs) {
+				t.Fatalf("QueryByTimeRange() returned %d logs, want %d", len(results), len(tt.wantIDs))
+			}
+			for i, log := range results {
+				if log.ID != tt.wantIDs[i] {
+					t.Errorf("result %d ID = %s, want %s", i, log.ID, tt.wantIDs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestInMemoryRepository_QueryByTimeRange_InvalidRange(t *testing.T) {
+	repo := NewInMemoryRepository()
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	if _, err := repo.QueryByTimeRange(t.Context(), base.Add(time.Hour), base, 0); err != ErrInvalidTimeRange {
+		t.Errorf("QueryByTimeRange() error = %v, want %v", err, ErrInvalidTimeRange)
+	}
+	// A start in the future is after the implicit "now" end
+	if _, err := repo.QueryByTimeRange(t.Context(), time.Now().Add(time.Hour), time.Time{}, 0); err != ErrInvalidTimeRange {
+		t.Errorf("QueryByTimeRange() with future start error = %v, want %v", err, ErrInvalidTimeRange)
+	}
+}
+
+func TestInMemoryRepository_Query_CombinedFilters(t *testing.T) {
+	repo := NewInMemoryRepository()
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	entries := []struct {
+		offset     time.Duration
+		userDID    string
+		entityType string
+		entityID   string
+		action     string
+	}{
+		{0, "did:plc:alice", "scene", "scene-1", "access_precise_location"},
+		{1 * time.Hour, "did:plc:bob", "scene", "scene-1", "access_precise_location"},
+		{2 * time.Hour, "did:plc:alice", "scene", "scene-2", "access_coarse_location"},
+		{3 * time.Hour, "did:plc:alice", "event", "event-1", "access_precise_location"},
+		{4 * time.Hour, "did:plc:alice", "scene", "scene-1", "access_precise_location"},
+	}
+	var ids []string
+	for _, e := range entries {
+		log, err := repo.LogAccess(t.Context(), LogEntry{UserDID: e.userDID, EntityType: e.entityType, EntityID: e.entityID, Action: e.action})
+		if err != nil {
+			t.Fatalf("LogAccess() error = %v", err)
+		}
+		repo.mu.Lock()
+		repo.logs[log.ID].CreatedAt = base.Add(e.offset)
+		repo.mu.Unlock()
+		ids = append(ids, log.ID)
+	}
+
+	tests := []struct {
+		name    string
+		filter  AuditFilter
+		wantIDs []string
+	}{
+		{"user", AuditFilter{UserDID: "did:plc:alice"}, []string{ids[4], ids[3], ids[2], ids[0]}},
+		{"user and action", AuditFilter{UserDID: "did:plc:alice", Action: "access_precise_location"}, []string{ids[4], ids[3], ids[0]}},
+		{"entity", AuditFilter{EntityType: "scene", EntityID: "scene-1"}, []string{ids[4], ids[1], ids[0]}},
+		{"entity and time", AuditFilter{EntityType: "scene", EntityID: "scene-1", Start: base.Add(30 * time.Minute), End: base.Add(4 * time.Hour)}, []string{ids[4], ids[1]}},
+		{"user, entity type, and open-ended start", AuditFilter{UserDID: "did:plc:alice", EntityType: "scene", Start: base.Add(time.Hour)}, []string{ids[4], ids[2]}},
+		{"everything with limit", AuditFilter{UserDID: "did:plc:alice", EntityType: "scene", EntityID: "scene-1", Action: "access_precise_location", End: base.Add(5 * time.Hour), Limit: 1}, []string{ids[4]}},
+		{"no matches", AuditFilter{UserDID: "did:plc:bob", Action: "access_coarse_location"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := repo.Query(t.Context(), tt.filter)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			if len(results) != len(tt.wantIDs) {
+				t.Fatalf("Query() returned %d logs, want %d", len(results), len(tt.wantIDs))
+			}
+			for i, log := range results {
+				if log.ID != tt.wantIDs[i] {
+					t.Errorf("result %d ID = %s, want %s", i, log.ID, tt.wantIDs[i])
+				}
+			}
+		})
+	}
+
+	if _, err := repo.Query(t.Context(), AuditFilter{Start: base.Add(time.Hour), End: base}); err != ErrInvalidTimeRange {
+		t.Errorf("Query() error = %v, want %v", err, ErrInvalidTimeRange)
+	}
+}
+
+func TestInMemoryRepository_Query_CursorPagesThroughTies(t *testing.T) {
+	repo := NewInMemoryRepository()
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// Three entries share a timestamp so paging must fall back to ID order
+	offsets := []time.Duration{0, time.Hour, time.Hour, time.Hour, 2 * time.Hour}
+	for _, offset := range offsets {
+		log, err := repo.LogAccess(t.Context(), LogEntry{UserDID: "did:plc:alice", EntityType: "scene", EntityID: "scene-1", Action: "access_precise_location"})
+		if err != nil {
+			t.Fatalf("LogAccess() error = %v", err)
+		}
+		repo.mu.Lock()
+		repo.logs[log.ID].CreatedAt = base.Add(offset)
+		repo.mu.Unlock()
+	}
+
+	all, err := repo.Query(t.Context(), AuditFilter{UserDID: "did:plc:alice"})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(all) != len(offsets) {
+		t.Fatalf("Query() returned %d logs, want %d", len(all), len(offsets))
+	}
+	for i := 1; i < len(all); i++ {
+		if !logBefore(all[i], all[i-1].CreatedAt, all[i-1].ID) {
+			t.Errorf("result %d (%s, %s) is not after result %d in newest-first order", i, all[i].CreatedAt, all[i].ID, i-1)
+		}
+	}
+
+	filter := AuditFilter{UserDID: "did:plc:alice", Limit: 2}
+	var paged []string
+	for page := 0; page < len(offsets); page++ {
+		results, err := repo.Query(t.Context(), filter)
+		if err != nil {
+			t.Fatalf("Query() error = %v", err)
+		}
+		if len(results) > filter.Limit {
+			t.Fatalf("Query() returned %d logs, want at most %d", len(results), filter.Limit)
+		}
+		if len(results) == 0 {
+			break
+		}
+		for _, log := range results {
+			paged = append(paged, log.ID)
+		}
+		last := results[len(results)-1]
+		filter.CursorTime, filter.CursorID = last.CreatedAt, last.ID
+	}
+
+	if len(paged) != len(all) {
+		t.Fatalf("paging returned %d logs, want %d", len(paged), len(all))
+	}
+	for i, log := range all {
+		if paged[i] != log.ID {
+			t.Errorf("paged result %d ID = %s, want %s", i, paged[i], log.ID)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/onnwee/subcults/internal/middleware"
 )
@@ -29,6 +30,7 @@ var ValidEntityTypes = map[string]bool{
 	"admin_panel": true,
 	"post":        true,
 	"membership":  true,
+	"report":      true,
 }
 
 // ValidActions defines the allowed actions for audit logging.
@@ -44,9 +46,27 @@ var ValidActions = map[string]bool{
 	"membership_request":      true,
 	"membership_approve":      true,
 	"membership_reject":       true,
+	"membership_remove":       true,
+	"membership_ban":          true,
+	"membership_unban":        true,
 	"event_cancel":            true,
+	"scene_transfer":          true,
+	"scene_export":            true,
+	"scene_import":            true,
+	"create":                  true,
+	"update":                  true,
+	"delete":                  true,
+	"palette_update":          true,
+	"admin_hide_scene":        true,
+	"admin_remove_event":      true,
+	"admin_ban_user":          true,
+	"report_file":             true,
+	"report_resolve":          true,
 }
 
+// TagAdmin tags audit entries for actions taken by platform admins.
+const TagAdmin = "admin"
+
 // validateLogEntry validates the required fields of a log entry against whitelists.
 func validateLogEntry(entityType, entityID, action string) error {
 	if entityType == "" {
@@ -70,49 +90,100 @@ func validateLogEntry(entityType, entityID, action string) error {
 	return nil
 }
 
+// trustedProxies holds the networks of reverse proxies whose
+// X-Forwarded-For hops are skipped when extracting the client IP.
+var (
+	trustedProxiesMu sync.RWMutex
+	trustedProxies   []net.IPNet
+)
+
+// SetTrustedProxies configures the reverse proxy networks in front of the API.
+// Forwarding headers are only honored on requests from these networks, and
+// their X-Forwarded-For hops are skipped when extracting the client IP for
+// audit entries. Passing nil trusts no proxies, so RemoteAddr is always used.
+func SetTrustedProxies(proxies []net.IPNet) {
+	trustedProxiesMu.Lock()
+	defer trustedProxiesMu.Unlock()
+	trustedProxies = append([]net.IPNet(nil), proxies...)
+}
+
+// isTrustedProxy reports whether ip belongs to a configured trusted proxy network.
+func isTrustedProxy(ip net.IP) bool {
+	trustedProxiesMu.RLock()
+	defer trustedProxiesMu.RUnlock()
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// stripPort removes a port from addr if present, handling bracketed IPv6.
+func stripPort(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		// Address might not have a port
+		return addr
+	}
+	return host
+}
+
 // extractIPAddress extracts the client IP address from an HTTP request.
-// It checks X-Forwarded-For, X-Real-IP, and RemoteAddr in that order.
+//
+// Forwarding headers are only read when the host part of RemoteAddr is a
+// trusted proxy; a client connecting directly could otherwise put any address
+// it likes into them, so its RemoteAddr is returned as is.
+//
+// Behind a trusted proxy, X-Forwarded-For is walked from right to left across
+// every header line, since each proxy appends the address it received the
+// request from and only the rightmost entries are added by infrastructure we
+// control. Hops within trusted proxy networks are skipped and the first
+// untrusted address is returned; anything to its left is client-supplied and
+// may be spoofed. When the header is absent or every hop is trusted,
+// X-Real-IP and then RemoteAddr are used instead.
 // The port is stripped from the IP address to ensure compatibility with database storage.
 func extractIPAddress(r *http.Request) string {
-	// Check X-Forwarded-For header first (for proxied requests)
-	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
-		// Use the first IP in the chain, trimming whitespace per RFC 7239
-		var firstIP string
-		if idx := strings.Index(xff, ","); idx != -1 {
-			firstIP = strings.TrimSpace(xff[:idx])
-		} else {
-			firstIP = strings.TrimSpace(xff)
+	remoteIP := stripPort(r.RemoteAddr)
+	if peer := net.ParseIP(remoteIP); peer == nil || !isTrustedProxy(peer) {
+		return remoteIP
+	}
+
+	// Repeated header lines form one list, in order
+	var hops []string
+	for _, line := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(line, ",")...)
+	}
+	sawHop := false
+	for i := len(hops) - 1; i >= 0; i-- {
+		// Trim whitespace per RFC 7239 and strip any port
+		hop := stripPort(strings.TrimSpace(hops[i]))
+		if hop == "" {
+			continue
 		}
-		// Only use if non-empty after trimming, and strip port if present
-		if firstIP != "" {
-			host, _, err := net.SplitHostPort(firstIP)
-			if err != nil {
-				// IP might not have a port
-				return firstIP
-			}
-			return host
+		sawHop = true
+
+		ip := net.ParseIP(hop)
+		if ip == nil {
+			// An unparsable hop means the chain can't be trusted past this point
+			return remoteIP
 		}
-	}
-	
-	// Check X-Real-IP header
-	if xri := r.Header.Get("X-Real-IP"); xri != "" {
-		xri = strings.TrimSpace(xri)
-		// Strip port if present
-		host, _, err := net.SplitHostPort(xri)
-		if err != nil {
-			// IP might not have a port
-			return xri
+		if !isTrustedProxy(ip) {
+			return hop
 		}
-		return host
 	}
-	
-	// Fall back to RemoteAddr (strip port properly for both IPv4 and IPv6)
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		// RemoteAddr might not have a port
-		return r.RemoteAddr
+	if sawHop {
+		// Every hop is a trusted proxy
+		return remoteIP
 	}
-	return host
+
+	// Check X-Real-IP header
+	if xri := strings.TrimSpace(r.Header.Get("X-Real-IP")); xri != "" {
+		return stripPort(xri)
+	}
+
+	// Fall back to RemoteAddr (port stripped for both IPv4 and IPv6)
+	return remoteIP
 }
 
 // LogAccess is a helper function that records an access event to the audit log.
@@ -141,7 +212,7 @@ func LogAccess(ctx context.Context, repo Repository, entityType, entityID, actio
 		RequestID:  middleware.GetRequestID(ctx),
 	}
 
-	_, err := repo.LogAccess(entry)
+	_, err := repo.LogAccess(ctx, entry)
 	return err
 }
 
@@ -149,9 +220,11 @@ func LogAccess(ctx context.Context, repo Repository, entityType, entityID, actio
 // It extracts user DID, request ID, IP address, and user agent from the request/context.
 //
 // IP address extraction:
-// - Checks X-Forwarded-For header first (uses first IP from comma-separated list)
-// - Falls back to X-Real-IP header
-// - Finally uses RemoteAddr (with port stripped)
+// - Walks X-Forwarded-For from right to left, returning the first hop that is
+//   not a trusted proxy (see SetTrustedProxies)
+// - Falls back to X-Real-IP header when X-Forwarded-For is absent
+// - Finally uses RemoteAddr (with port stripped), including when every
+//   X-Forwarded-For hop is trusted
 //
 // Error handling: This function uses a fail-closed approach - if audit logging fails,
 // the error is returned to the caller. This ensures compliance requirements are met
@@ -175,6 +248,34 @@ func LogAccessFromRequest(r *http.Request, repo Repository, entityType, entityID
 		UserAgent:  r.UserAgent(),
 	}
 
-	_, err := repo.LogAccess(entry)
+	_, err := repo.LogAccess(r.Context(), entry)
+	return err
+}
+
+// LogAdminActionFromRequest records an action taken by a platform admin,
+// tagged TagAdmin with the admin's stated reason. Request metadata is
+// captured as in LogAccessFromRequest.
+func LogAdminActionFromRequest(r *http.Request, repo Repository, entityType, entityID, action, reason string) error {
+	if repo == nil {
+		return ErrNilRepository
+	}
+
+	if err := validateLogEntry(entityType, entityID, action); err != nil {
+		return err
+	}
+
+	entry := LogEntry{
+		UserDID:    middleware.GetUserDID(r.Context()),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		RequestID:  middleware.GetRequestID(r.Context()),
+		IPAddress:  extractIPAddress(r),
+		UserAgent:  r.UserAgent(),
+		Tag:        TagAdmin,
+		Reason:     reason,
+	}
+
+	_, err := repo.LogAccess(r.Context(), entry)
 	return err
 }
@@ -7,18 +7,104 @@ import (
 )
 
 // AuditLog represents a single audit event in the system.
+// JSON tags define the line format used by Repository.Export.
 type AuditLog struct {
-	ID         string
+	ID         string    `json:"id"`
+	UserDID    string    `json:"user_did"`
+	EntityType string    `json:"entity_type"`
+	EntityID   string    `json:"entity_id"`
+	Action     string    `json:"action"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	// Optional metadata
+	RequestID string `json:"request_id,omitempty"`
+	IPAddress string `json:"ip_address,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+
+	// Tag marks entries written by privileged actors (e.g. TagAdmin) and
+	// Reason records why the action was taken.
+	Tag    string `json:"tag,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// ExportFilter selects audit logs for export. Zero-valued fields do not
+// filter; Start and End are inclusive bounds on CreatedAt.
+type ExportFilter struct {
+	Start      time.Time
+	End        time.Time
+	Action     string
+	EntityType string
+}
+
+// Matches reports whether log satisfies every set field of the filter.
+func (f ExportFilter) Matches(log *AuditLog) bool {
+	if !f.Start.IsZero() && log.CreatedAt.Before(f.Start) {
+		return false
+	}
+	if !f.End.IsZero() && log.CreatedAt.After(f.End) {
+		return false
+	}
+	if f.Action != "" && log.Action != f.Action {
+		return false
+	}
+	if f.EntityType != "" && log.EntityType != f.EntityType {
+		return false
+	}
+	return true
+}
+
+// AuditFilter selects audit logs for Repository.Query. Zero-valued fields do
+// not filter, except End: a zero End means "now". Start and End are
+// inclusive bounds on CreatedAt.
+type AuditFilter struct {
 	UserDID    string
 	EntityType string
 	EntityID   string
 	Action     string
-	CreatedAt  time.Time
-	
-	// Optional metadata
-	RequestID string
-	IPAddress string
-	UserAgent string
+	Start      time.Time
+	End        time.Time
+
+	// CursorTime and CursorID resume a previous page: only entries after
+	// the entry with that CreatedAt and ID in newest-first order are
+	// returned. A zero CursorTime starts from the newest entry.
+	CursorTime time.Time
+	CursorID   string
+
+	// Limit is the maximum number of entries to return (0 = no limit).
+	Limit int
+}
+
+// matches reports whether log satisfies every set field of the filter.
+// End must already be resolved by the caller.
+func (f AuditFilter) matches(log *AuditLog) bool {
+	if f.UserDID != "" && log.UserDID != f.UserDID {
+		return false
+	}
+	if f.EntityType != "" && log.EntityType != f.EntityType {
+		return false
+	}
+	if f.EntityID != "" && log.EntityID != f.EntityID {
+		return false
+	}
+	if f.Action != "" && log.Action != f.Action {
+		return false
+	}
+	if !f.Start.IsZero() && log.CreatedAt.Before(f.Start) {
+		return false
+	}
+	if !f.CursorTime.IsZero() && !logBefore(log, f.CursorTime, f.CursorID) {
+		return false
+	}
+	return !log.CreatedAt.After(f.End)
+}
+
+// logBefore reports whether log sorts after the (createdAt, id) position in
+// newest-first order, with ties on CreatedAt broken by ID descending.
+func logBefore(log *AuditLog, createdAt time.Time, id string) bool {
+	if !log.CreatedAt.Equal(createdAt) {
+		return log.CreatedAt.Before(createdAt)
+	}
+	return log.ID < id
 }
 
 // LogEntry represents the input for creating an audit log entry.
@@ -32,4 +118,6 @@ type LogEntry struct {
 	RequestID string
 	IPAddress string
 	UserAgent string
+	Tag       string
+	Reason    string
 }
@@ -1,27 +1,57 @@
 package audit
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrInvalidTimeRange is returned when a query's start time is after its end time.
+var ErrInvalidTimeRange = errors.New("start time must not be after end time")
+
 // Repository defines the interface for audit log operations.
+// Methods return ctx.Err() once ctx is done; Export also stops between batches.
 type Repository interface {
 	// LogAccess records an access event to the audit log.
 	// Returns the created audit log entry.
-	LogAccess(entry LogEntry) (*AuditLog, error)
+	LogAccess(ctx context.Context, entry LogEntry) (*AuditLog, error)
 
 	// QueryByEntity retrieves audit logs for a specific entity, sorted by time (newest first).
 	// Limit specifies the maximum number of entries to return (0 = no limit).
-	QueryByEntity(entityType, entityID string, limit int) ([]*AuditLog, error)
+	QueryByEntity(ctx context.Context, entityType, entityID string, limit int) ([]*AuditLog, error)
 
 	// QueryByUser retrieves audit logs for a specific user, sorted by time (newest first).
 	// Limit specifies the maximum number of entries to return (0 = no limit).
-	QueryByUser(userDID string, limit int) ([]*AuditLog, error)
+	QueryByUser(ctx context.Context, userDID string, limit int) ([]*AuditLog, error)
+
+	// QueryByTimeRange retrieves audit logs created within [start, end], sorted by
+	// time (newest first). A zero start is unbounded and a zero end means now.
+	// Returns ErrInvalidTimeRange if start is after end.
+	// Limit specifies the maximum number of entries to return (0 = no limit).
+	QueryByTimeRange(ctx context.Context, start, end time.Time, limit int) ([]*AuditLog, error)
+
+	// Query retrieves audit logs matching every set field of filter, sorted by
+	// time (newest first) with ties broken by ID descending, so a page can be
+	// resumed from its last entry via filter.CursorTime and filter.CursorID.
+	// Returns ErrInvalidTimeRange if filter.Start is after the resolved end time.
+	Query(ctx context.Context, filter AuditFilter) ([]*AuditLog, error)
+
+	// Export writes audit logs matching filter to w as newline-delimited JSON,
+	// one AuditLog object per line, sorted by time (newest first).
+	Export(ctx context.Context, w io.Writer, filter ExportFilter) error
 }
 
+// exportBatchSize is the number of log positions scanned per read lock
+// during export, so large exports neither copy the whole log nor block
+// writers for the duration of a slow writer.
+const exportBatchSize = 500
+
 // InMemoryRepository is an in-memory implementation of Repository.
 // Used for testing and development. Thread-safe via RWMutex.
 type InMemoryRepository struct {
@@ -40,7 +70,11 @@ func NewInMemoryRepository() *InMemoryRepository {
 }
 
 // LogAccess records an access event to the audit log.
-func (r *InMemoryRepository) LogAccess(entry LogEntry) (*AuditLog, error) {
+func (r *InMemoryRepository) LogAccess(ctx context.Context, entry LogEntry) (*AuditLog, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	log := &AuditLog{
 		ID:         uuid.New().String(),
 		UserDID:    entry.UserDID,
@@ -51,6 +85,8 @@ func (r *InMemoryRepository) LogAccess(entry LogEntry) (*AuditLog, error) {
 		RequestID:  entry.RequestID,
 		IPAddress:  entry.IPAddress,
 		UserAgent:  entry.UserAgent,
+		Tag:        entry.Tag,
+		Reason:     entry.Reason,
 	}
 
 	r.mu.Lock()
@@ -64,7 +100,11 @@ func (r *InMemoryRepository) LogAccess(entry LogEntry) (*AuditLog, error) {
 }
 
 // QueryByEntity retrieves audit logs for a specific entity, sorted by time (newest first).
-func (r *InMemoryRepository) QueryByEntity(entityType, entityID string, limit int) ([]*AuditLog, error) {
+func (r *InMemoryRepository) QueryByEntity(ctx context.Context, entityType, entityID string, limit int) ([]*AuditLog, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -90,7 +130,11 @@ func (r *InMemoryRepository) QueryByEntity(entityType, entityID string, limit in
 }
 
 // QueryByUser retrieves audit logs for a specific user, sorted by time (newest first).
-func (r *InMemoryRepository) QueryByUser(userDID string, limit int) ([]*AuditLog, error) {
+func (r *InMemoryRepository) QueryByUser(ctx context.Context, userDID string, limit int) ([]*AuditLog, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -114,3 +158,95 @@ func (r *InMemoryRepository) QueryByUser(userDID string, limit int) ([]*AuditLog
 
 	return results, nil
 }
+
+// QueryByTimeRange retrieves audit logs created within [start, end], sorted by time (newest first).
+func (r *InMemoryRepository) QueryByTimeRange(ctx context.Context, start, end time.Time, limit int) ([]*AuditLog, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return r.Query(ctx, AuditFilter{Start: start, End: end, Limit: limit})
+}
+
+// Query retrieves audit logs matching every set field of filter, sorted by time (newest first).
+func (r *InMemoryRepository) Query(ctx context.Context, filter AuditFilter) ([]*AuditLog, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if filter.End.IsZero() {
+		filter.End = time.Now().UTC()
+	}
+	if !filter.Start.IsZero() && filter.Start.After(filter.End) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*AuditLog
+	for _, id := range r.order {
+		log := r.logs[id]
+		if !filter.matches(log) {
+			continue
+		}
+
+		// Create a copy to prevent external modification
+		logCopy := *log
+		results = append(results, &logCopy)
+	}
+
+	// Newest first, ties broken by ID descending so cursors resume exactly
+	sort.Slice(results, func(i, j int) bool {
+		return logBefore(results[j], results[i].CreatedAt, results[i].ID)
+	})
+	if filter.Limit > 0 && len(results) > filter.Limit {
+		results = results[:filter.Limit]
+	}
+
+	return results, nil
+}
+
+// Export writes audit logs matching filter to w as newline-delimited JSON,
+// sorted by time (newest first). Logs are scanned in batches so only one
+// batch of matches is held in memory, and the read lock is released while
+// writing. Entries logged after the export starts are not included.
+func (r *InMemoryRepository) Export(ctx context.Context, w io.Writer, filter ExportFilter) error {
+	encoder := json.NewEncoder(w)
+
+	r.mu.RLock()
+	end := len(r.order)
+	r.mu.RUnlock()
+
+	batch := make([]AuditLog, 0, exportBatchSize)
+	for end > 0 {
+		// Checked per batch so a long export stops soon after the caller gives up
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		start := end - exportBatchSize
+		if start < 0 {
+			start = 0
+		}
+
+		// The order slice is append-only, so positions below the snapshot are stable
+		batch = batch[:0]
+		r.mu.RLock()
+		for i := end - 1; i >= start; i-- {
+			log := r.logs[r.order[i]]
+			if filter.Matches(log) {
+				batch = append(batch, *log)
+			}
+		}
+		r.mu.RUnlock()
+
+		for i := range batch {
+			if err := encoder.Encode(&batch[i]); err != nil {
+				return err
+			}
+		}
+		end = start
+	}
+
+	return nil
+}
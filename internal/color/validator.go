@@ -12,32 +12,46 @@ import (
 	"strings"
 )
 
-// hexColorPattern matches valid hex color codes in format #RRGGBB (case insensitive).
-var hexColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+// hexColorPattern matches valid hex color codes in format #RGB or #RRGGBB (case insensitive).
+var hexColorPattern = regexp.MustCompile(`^#(?:[0-9A-Fa-f]{3}|[0-9A-Fa-f]{6})$`)
 
 // Common validation errors
 var (
-	ErrInvalidHexFormat    = errors.New("invalid hex color format, expected #RRGGBB")
+	ErrInvalidHexFormat    = errors.New("invalid hex color format, expected #RGB or #RRGGBB")
 	ErrInsufficientContrast = errors.New("insufficient contrast ratio, minimum 4.5:1 required for WCAG AA")
 )
 
-// IsValidHexColor validates that a color string is in valid #RRGGBB format.
+// IsValidHexColor validates that a color string is in valid #RGB or #RRGGBB format.
 func IsValidHexColor(color string) bool {
 	return hexColorPattern.MatchString(color)
 }
 
+// NormalizeHexColor expands a 3-digit shorthand color (#RGB) to its 6-digit
+// form (#RRGGBB). Colors already in 6-digit form are returned unchanged.
+// Returns an error if the color is not a valid hex color.
+func NormalizeHexColor(color string) (string, error) {
+	if !IsValidHexColor(color) {
+		return "", fmt.Errorf("%w: got %q", ErrInvalidHexFormat, color)
+	}
+	if len(color) == 7 {
+		return color, nil
+	}
+	return string([]byte{'#', color[1], color[1], color[2], color[2], color[3], color[3]}), nil
+}
+
 // SanitizeColor sanitizes a color string to prevent script injection.
-// Returns the original color if valid, or empty string if invalid.
+// Returns the color in normalized #RRGGBB form if valid, or empty string if invalid.
 func SanitizeColor(color string) string {
 	// HTML escape to prevent any script injection
 	sanitized := html.EscapeString(strings.TrimSpace(color))
 	
 	// Verify it's still a valid hex color after sanitization
-	if !IsValidHexColor(sanitized) {
+	normalized, err := NormalizeHexColor(sanitized)
+	if err != nil {
 		return ""
 	}
-	
-	return sanitized
+
+	return normalized
 }
 
 // ValidateHexColor validates a hex color and returns an error if invalid.
@@ -53,10 +67,11 @@ type RGB struct {
 	R, G, B uint8
 }
 
-// ParseHexColor parses a hex color string (#RRGGBB) into RGB components.
+// ParseHexColor parses a hex color string (#RGB or #RRGGBB) into RGB components.
 // Returns an error if the color is not in valid hex format.
 func ParseHexColor(hexColor string) (RGB, error) {
-	if !IsValidHexColor(hexColor) {
+	hexColor, err := NormalizeHexColor(hexColor)
+	if err != nil {
 		return RGB{}, ErrInvalidHexFormat
 	}
 	
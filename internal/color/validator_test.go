@@ -32,8 +32,33 @@ func TestIsValidHexColor(t *testing.T) {
 			want:  false,
 		},
 		{
-			name:  "too short",
+			name:  "valid shorthand hex",
 			color: "#fff",
+			want:  true,
+		},
+		{
+			name:  "valid mixed case shorthand hex",
+			color: "#0Fa",
+			want:  true,
+		},
+		{
+			name:  "too short",
+			color: "#ff",
+			want:  false,
+		},
+		{
+			name:  "four digits",
+			color: "#ffff",
+			want:  false,
+		},
+		{
+			name:  "five digits",
+			color: "#fffff",
+			want:  false,
+		},
+		{
+			name:  "invalid shorthand characters",
+			color: "#ggg",
 			want:  false,
 		},
 		{
@@ -84,6 +109,11 @@ func TestSanitizeColor(t *testing.T) {
 			color: "#ff0000",
 			want:  "#ff0000",
 		},
+		{
+			name:  "shorthand color expanded",
+			color: "#0f0",
+			want:  "#00ff00",
+		},
 		{
 			name:  "valid color with whitespace trimmed",
 			color: "  #ff0000  ",
@@ -186,6 +216,11 @@ func TestParseHexColor(t *testing.T) {
 			hex:  "#808080",
 			want: RGB{R: 128, G: 128, B: 128},
 		},
+		{
+			name: "shorthand green",
+			hex:  "#0f0",
+			want: RGB{R: 0, G: 255, B: 0},
+		},
 		{
 			name:    "invalid format",
 			hex:     "invalid",
@@ -326,3 +361,32 @@ func TestValidateContrast(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeHexColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		color   string
+		want    string
+		wantErr bool
+	}{
+		{name: "shorthand expanded", color: "#0f0", want: "#00ff00"},
+		{name: "shorthand case preserved", color: "#ABC", want: "#AABBCC"},
+		{name: "full form unchanged", color: "#123456", want: "#123456"},
+		{name: "four digits rejected", color: "#0f0f", wantErr: true},
+		{name: "five digits rejected", color: "#0f0f0", wantErr: true},
+		{name: "non-hex rejected", color: "#xyz", wantErr: true},
+		{name: "missing hash rejected", color: "0f0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeHexColor(tt.color)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeHexColor(%q) error = %v, wantErr %v", tt.color, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeHexColor(%q) = %q, want %q", tt.color, got, tt.want)
+			}
+		})
+	}
+}
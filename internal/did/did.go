@@ -0,0 +1,99 @@
+// Package did provides syntax validation for the decentralized identifiers
+// and record keys used to reference AT Protocol records.
+package did
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxDIDLength is the maximum accepted DID length, per the AT Protocol DID
+// syntax.
+const MaxDIDLength = 2048
+
+// MaxRecordKeyLength is the maximum accepted record key length, per the AT
+// Protocol record key syntax.
+const MaxRecordKeyLength = 512
+
+// Supported DID method prefixes. AT Protocol identities use only did:plc
+// and did:web.
+const (
+	PrefixPLC = "did:plc:"
+	PrefixWeb = "did:web:"
+)
+
+// ErrInvalidDID is returned when a string is not a supported DID.
+var ErrInvalidDID = errors.New("invalid DID")
+
+// ErrInvalidRecordKey is returned when a string is not a valid record key.
+var ErrInvalidRecordKey = errors.New("invalid record key")
+
+// ValidateDID checks that s is a did:plc or did:web identifier. The
+// method-specific part may contain ASCII letters, digits and ".", "_", ":",
+// "%", "-", must not be empty, and must not end in ":" or "%". Returned
+// errors wrap ErrInvalidDID.
+func ValidateDID(s string) error {
+	if len(s) > MaxDIDLength {
+		return fmt.Errorf("%w: longer than %d characters", ErrInvalidDID, MaxDIDLength)
+	}
+
+	var id string
+	switch {
+	case strings.HasPrefix(s, PrefixPLC):
+		id = s[len(PrefixPLC):]
+	case strings.HasPrefix(s, PrefixWeb):
+		id = s[len(PrefixWeb):]
+	default:
+		return fmt.Errorf("%w: must start with %q or %q", ErrInvalidDID, PrefixPLC, PrefixWeb)
+	}
+
+	if id == "" {
+		return fmt.Errorf("%w: missing method-specific identifier", ErrInvalidDID)
+	}
+	for i := 0; i < len(id); i++ {
+		if !isDIDChar(id[i]) {
+			return fmt.Errorf("%w: invalid character %q", ErrInvalidDID, id[i])
+		}
+	}
+	if last := id[len(id)-1]; last == ':' || last == '%' {
+		return fmt.Errorf("%w: must not end with %q", ErrInvalidDID, last)
+	}
+	return nil
+}
+
+// ValidateRecordKey checks that s is a valid AT Protocol record key: 1 to
+// MaxRecordKeyLength ASCII letters, digits and ".", "_", ":", "~", "-",
+// excluding the reserved keys "." and "..". Returned errors wrap
+// ErrInvalidRecordKey.
+func ValidateRecordKey(s string) error {
+	if s == "" {
+		return fmt.Errorf("%w: must not be empty", ErrInvalidRecordKey)
+	}
+	if len(s) > MaxRecordKeyLength {
+		return fmt.Errorf("%w: longer than %d characters", ErrInvalidRecordKey, MaxRecordKeyLength)
+	}
+	if s == "." || s == ".." {
+		return fmt.Errorf("%w: %q is reserved", ErrInvalidRecordKey, s)
+	}
+	for i := 0; i < len(s); i++ {
+		if !isRecordKeyChar(s[i]) {
+			return fmt.Errorf("%w: invalid character %q", ErrInvalidRecordKey, s[i])
+		}
+	}
+	return nil
+}
+
+// isDIDChar reports whether c may appear in a DID's method-specific identifier.
+func isDIDChar(c byte) bool {
+	return isAlphanumeric(c) || c == '.' || c == '_' || c == ':' || c == '%' || c == '-'
+}
+
+// isRecordKeyChar reports whether c may appear in a record key.
+func isRecordKeyChar(c byte) bool {
+	return isAlphanumeric(c) || c == '.' || c == '_' || c == ':' || c == '~' || c == '-'
+}
+
+func isAlphanumeric(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,78 @@
+package did
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateDID(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"plc", "did:plc:z72i7hdynmk6r22z27h6tvur", true},
+		{"short plc", "did:plc:alice", true},
+		{"web", "did:web:example.com", true},
+		{"web with port", "did:web:localhost%3A8080", true},
+		{"web with path", "did:web:example.com:user:alice", true},
+		{"max length", PrefixPLC + strings.Repeat("a", MaxDIDLength-len(PrefixPLC)), true},
+		{"empty", "", false},
+		{"no prefix", "alice", false},
+		{"unsupported method", "did:key:z6Mkabc", false},
+		{"uppercase scheme", "DID:plc:alice", false},
+		{"missing identifier", "did:plc:", false},
+		{"trailing colon", "did:web:example.com:", false},
+		{"trailing percent", "did:web:example.com%", false},
+		{"slash", "did:web:example.com/alice", false},
+		{"space", "did:plc:ali ce", false},
+		{"non-ascii", "did:plc:alicé", false},
+		{"too long", PrefixPLC + strings.Repeat("a", MaxDIDLength), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDID(tt.input)
+			if tt.valid && err != nil {
+				t.Errorf("ValidateDID(%q) = %v, want nil", tt.input, err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidDID) {
+				t.Errorf("ValidateDID(%q) = %v, want ErrInvalidDID", tt.input, err)
+			}
+		})
+	}
+}
+
+func TestValidateRecordKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"tid", "3jzfcijpj2z2a", true},
+		{"self", "self", true},
+		{"punctuation", "a.b_c:d~e-f", true},
+		{"dots in key", "...", true},
+		{"max length", strings.Repeat("k", MaxRecordKeyLength), true},
+		{"empty", "", false},
+		{"dot", ".", false},
+		{"dot dot", "..", false},
+		{"slash", "a/b", false},
+		{"hash", "a#b", false},
+		{"space", "a b", false},
+		{"too long", strings.Repeat("k", MaxRecordKeyLength+1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRecordKey(tt.input)
+			if tt.valid && err != nil {
+				t.Errorf("ValidateRecordKey(%q) = %v, want nil", tt.input, err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidRecordKey) {
+				t.Errorf("ValidateRecordKey(%q) = %v, want ErrInvalidRecordKey", tt.input, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package geo
+
+import "math"
+
+// EarthRadiusMeters is the mean Earth radius used for great-circle distances.
+const EarthRadiusMeters = 6371008.8
+
+// DistanceMeters returns the great-circle distance between two points in meters
+// using the Haversine formula.
+//
+// Identical points return 0. The intermediate term is clamped to [0, 1] so
+// floating point error on antipodal points cannot produce NaN.
+func DistanceMeters(a, b Point) float64 {
+	lat1 := a.Lat * math.Pi / 180
+	lat2 := b.Lat * math.Pi / 180
+	dLat := (b.Lat - a.Lat) * math.Pi / 180
+	dLng := (b.Lng - a.Lng) * math.Pi / 180
+
+	sinLat := math.Sin(dLat / 2)
+	sinLng := math.Sin(dLng / 2)
+	h := sinLat*sinLat + math.Cos(lat1)*math.Cos(lat2)*sinLng*sinLng
+
+	// Clamp to guard against rounding pushing h slightly outside [0, 1]
+	h = math.Min(1, math.Max(0, h))
+
+	return 2 * EarthRadiusMeters * math.Asin(math.Sqrt(h))
+}
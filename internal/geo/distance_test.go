@@ -0,0 +1,87 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistanceMeters(t *testing.T) {
+	tests := []struct {
+		name      string
+		a         Point
+		b         Point
+		want      float64
+		tolerance float64
+	}{
+		{
+			name:      "identical points",
+			a:         Point{Lat: 37.7749, Lng: -122.4194},
+			b:         Point{Lat: 37.7749, Lng: -122.4194},
+			want:      0,
+			tolerance: 0,
+		},
+		{
+			name:      "San Francisco to Los Angeles",
+			a:         Point{Lat: 37.7749, Lng: -122.4194},
+			b:         Point{Lat: 34.0522, Lng: -118.2437},
+			want:      559_120,
+			tolerance: 1_000,
+		},
+		{
+			name:      "London to Paris",
+			a:         Point{Lat: 51.5074, Lng: -0.1278},
+			b:         Point{Lat: 48.8566, Lng: 2.3522},
+			want:      343_560,
+			tolerance: 1_000,
+		},
+		{
+			name:      "one degree of longitude at equator",
+			a:         Point{Lat: 0, Lng: 0},
+			b:         Point{Lat: 0, Lng: 1},
+			want:      111_195,
+			tolerance: 10,
+		},
+		{
+			name:      "across antimeridian",
+			a:         Point{Lat: 0, Lng: 179.5},
+			b:         Point{Lat: 0, Lng: -179.5},
+			want:      111_195,
+			tolerance: 10,
+		},
+		{
+			name:      "antipodal points",
+			a:         Point{Lat: 0, Lng: 0},
+			b:         Point{Lat: 0, Lng: 180},
+			want:      math.Pi * EarthRadiusMeters,
+			tolerance: 1,
+		},
+		{
+			name:      "pole to pole",
+			a:         Point{Lat: 90, Lng: 0},
+			b:         Point{Lat: -90, Lng: 0},
+			want:      math.Pi * EarthRadiusMeters,
+			tolerance: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DistanceMeters(tt.a, tt.b)
+			if math.IsNaN(got) {
+				t.Fatalf("DistanceMeters(%v, %v) = NaN", tt.a, tt.b)
+			}
+			if math.Abs(got-tt.want) > tt.tolerance {
+				t.Errorf("DistanceMeters(%v, %v) = %.1f, want %.1f ± %.1f", tt.a, tt.b, got, tt.want, tt.tolerance)
+			}
+		})
+	}
+}
+
+func TestDistanceMeters_Symmetric(t *testing.T) {
+	a := Point{Lat: 40.7128, Lng: -74.0060}
+	b := Point{Lat: 51.5074, Lng: -0.1278}
+
+	if ab, ba := DistanceMeters(a, b), DistanceMeters(b, a); ab != ba {
+		t.Errorf("DistanceMeters not symmetric: a->b=%f, b->a=%f", ab, ba)
+	}
+}
@@ -1,13 +1,82 @@
 // Package geo provides geolocation utilities for privacy-preserving location handling.
 package geo
 
-import "strings"
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+)
+
+// Point represents a geographic coordinate with latitude and longitude.
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Valid reports whether the latitude is within [-90, 90] and the longitude
+// within [-180, 180]. NaN and infinite coordinates are invalid.
+func (p Point) Valid() bool {
+	// Written as positive range checks so NaN, which fails every comparison,
+	// is rejected
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lng >= -180 && p.Lng <= 180
+}
 
 // DefaultPrecision is the default geohash precision for public display.
 // A precision of 6 characters provides approximately ±0.61 km accuracy,
 // which is suitable for coarse location without pinpointing exact venues.
+// Deployments can override it with SetCoarsePrecision.
 const DefaultPrecision = 6
 
+// ErrInvalidPrecision is returned by SetCoarsePrecision for a precision
+// outside 1-MaxPrecision.
+var ErrInvalidPrecision = errors.New("geohash precision must be between 1 and 12")
+
+// coarsePrecision is the configured coarse precision; zero means unset.
+var coarsePrecision atomic.Int32
+
+// SetCoarsePrecision sets the precision stored coarse geohashes are derived
+// and truncated to. Returns ErrInvalidPrecision, leaving the current setting
+// unchanged, if precision is outside 1-MaxPrecision.
+func SetCoarsePrecision(precision int) error {
+	if precision < 1 || precision > MaxPrecision {
+		return ErrInvalidPrecision
+	}
+	coarsePrecision.Store(int32(precision))
+	return nil
+}
+
+// CoarsePrecision returns the configured coarse precision, falling back to
+// DefaultPrecision when none is set.
+func CoarsePrecision() int {
+	precision := int(coarsePrecision.Load())
+	if precision < 1 || precision > MaxPrecision {
+		return DefaultPrecision
+	}
+	return precision
+}
+
+// MaxPrecision is the maximum supported geohash precision.
+// 12 characters resolve to roughly 3.7 cm x 1.9 cm, well beyond GPS accuracy.
+const MaxPrecision = 12
+
+// base32Alphabet is the geohash base32 alphabet, indexed by 5-bit value.
+// It contains exactly the characters accepted by validGeohashChars.
+const base32Alphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Viewer access levels used to select geohash precision.
+// Higher levels see finer-grained locations.
+const (
+	ViewerPublic = "public" // Anyone, including unauthenticated users
+	ViewerMember = "member" // Active member of the scene
+	ViewerOwner  = "owner"  // Owner of the scene
+)
+
+// Precision levels returned by PrecisionForVisibility.
+const (
+	PublicPrecision = 5 // ~±2.4 km, safe for world-visible discovery
+	MemberPrecision = 6 // ~±0.61 km, same as DefaultPrecision
+)
+
 // validGeohashChars is a lookup map for valid base32 characters used in geohashes.
 // Geohash uses a custom base32 alphabet excluding 'a', 'i', 'l', and 'o'.
 var validGeohashChars = map[rune]bool{
@@ -20,6 +89,20 @@ var validGeohashChars = map[rune]bool{
 	'y': true, 'z': true,
 }
 
+// IsValidGeohash reports whether input is a non-empty geohash made only of
+// base32 geohash characters. Matching is case-insensitive.
+func IsValidGeohash(input string) bool {
+	if input == "" {
+		return false
+	}
+	for _, c := range strings.ToLower(input) {
+		if !validGeohashChars[c] {
+			return false
+		}
+	}
+	return true
+}
+
 // RoundGeohash truncates a geohash string to the specified precision for privacy.
 // It ensures coarse location display by limiting the geohash resolution.
 //
@@ -58,3 +141,202 @@ func RoundGeohash(input string, precision int) string {
 	// Truncate to precision
 	return lower[:precision]
 }
+
+// PrecisionForVisibility returns the geohash precision a viewer may see for
+// their access level (ViewerPublic, ViewerMember, or ViewerOwner).
+// Owners see the full stored value (MaxPrecision). Unknown levels fall back
+// to PublicPrecision so unrecognised callers never receive finer data.
+func PrecisionForVisibility(v string) int {
+	switch v {
+	case ViewerOwner:
+		return MaxPrecision
+	case ViewerMember:
+		return MemberPrecision
+	default:
+		return PublicPrecision
+	}
+}
+
+// Encode produces a base32 geohash for the given point at the specified precision.
+// Precision is clamped to the range 1-12.
+//
+// Returns an empty string if the point is not Valid.
+func Encode(p Point, precision int) string {
+	if !p.Valid() {
+		return ""
+	}
+
+	if precision < 1 {
+		precision = 1
+	}
+	if precision > MaxPrecision {
+		precision = MaxPrecision
+	}
+
+	latMin, latMax := -90.0, 90.0
+	lngMin, lngMax := -180.0, 180.0
+
+	hash := make([]byte, 0, precision)
+	evenBit := true // Geohash interleaves bits starting with longitude
+	bit := 0
+	idx := 0
+
+	for len(hash) < precision {
+		if evenBit {
+			mid := (lngMin + lngMax) / 2
+			if p.Lng >= mid {
+				idx = idx<<1 | 1
+				lngMin = mid
+			} else {
+				idx <<= 1
+				lngMax = mid
+			}
+		} else {
+			mid := (latMin + latMax) / 2
+			if p.Lat >= mid {
+				idx = idx<<1 | 1
+				latMin = mid
+			} else {
+				idx <<= 1
+				latMax = mid
+			}
+		}
+		evenBit = !evenBit
+
+		bit++
+		if bit == 5 {
+			hash = append(hash, base32Alphabet[idx])
+			bit = 0
+			idx = 0
+		}
+	}
+
+	return string(hash)
+}
+
+// Bounds represents the bounding box of a geohash cell.
+type Bounds struct {
+	MinLat float64
+	MinLng float64
+	MaxLat float64
+	MaxLng float64
+}
+
+// Center returns the center point of the bounding box.
+func (b Bounds) Center() Point {
+	return Point{
+		Lat: (b.MinLat + b.MaxLat) / 2,
+		Lng: (b.MinLng + b.MaxLng) / 2,
+	}
+}
+
+// DecodeBounds returns the bounding box of the cell identified by a geohash.
+// Input is case-insensitive.
+//
+// Returns false if the hash is empty or contains invalid characters.
+func DecodeBounds(hash string) (Bounds, bool) {
+	if hash == "" {
+		return Bounds{}, false
+	}
+
+	b := Bounds{MinLat: -90, MinLng: -180, MaxLat: 90, MaxLng: 180}
+	evenBit := true
+
+	for _, c := range strings.ToLower(hash) {
+		idx := strings.IndexRune(base32Alphabet, c)
+		if idx < 0 {
+			return Bounds{}, false
+		}
+		for shift := 4; shift >= 0; shift-- {
+			bitSet := (idx>>shift)&1 == 1
+			if evenBit {
+				mid := (b.MinLng + b.MaxLng) / 2
+				if bitSet {
+					b.MinLng = mid
+				} else {
+					b.MaxLng = mid
+				}
+			} else {
+				mid := (b.MinLat + b.MaxLat) / 2
+				if bitSet {
+					b.MinLat = mid
+				} else {
+					b.MaxLat = mid
+				}
+			}
+			evenBit = !evenBit
+		}
+	}
+
+	return b, true
+}
+
+// Decode returns the center point of the cell identified by a geohash.
+// Re-encoding the returned point at the hash's length yields the same hash.
+//
+// Returns false if the hash is empty or contains invalid characters.
+func Decode(hash string) (Point, bool) {
+	b, ok := DecodeBounds(hash)
+	if !ok {
+		return Point{}, false
+	}
+	return b.Center(), true
+}
+
+// neighborOffsets lists the (lat, lng) cell offsets for each neighbor
+// in the order N, NE, E, SE, S, SW, W, NW.
+var neighborOffsets = [8][2]float64{
+	{1, 0}, {1, 1}, {0, 1}, {-1, 1},
+	{-1, 0}, {-1, -1}, {0, -1}, {1, -1},
+}
+
+// PointInGeohash reports whether a point lies within the cell identified by a geohash.
+// Points exactly on the cell edge are considered inside.
+//
+// Returns false if the hash is empty or contains invalid characters.
+func PointInGeohash(p Point, hash string) bool {
+	b, ok := DecodeBounds(hash)
+	if !ok {
+		return false
+	}
+	return p.Lat >= b.MinLat && p.Lat <= b.MaxLat && p.Lng >= b.MinLng && p.Lng <= b.MaxLng
+}
+
+// Neighbors returns the cells adjacent to a geohash at the same precision,
+// in the order N, NE, E, SE, S, SW, W, NW.
+//
+// Longitude wraps at the antimeridian, so cells at ±180 have neighbors on
+// the opposite side. Cells touching a pole have no neighbors beyond it;
+// those directions are omitted, so fewer than 8 cells may be returned.
+//
+// Returns nil if the hash is empty or contains invalid characters.
+func Neighbors(hash string) []string {
+	b, ok := DecodeBounds(hash)
+	if !ok {
+		return nil
+	}
+
+	precision := len(hash)
+	height := b.MaxLat - b.MinLat
+	width := b.MaxLng - b.MinLng
+	center := b.Center()
+
+	neighbors := make([]string, 0, len(neighborOffsets))
+	for _, offset := range neighborOffsets {
+		lat := center.Lat + offset[0]*height
+		if lat > 90 || lat < -90 {
+			continue
+		}
+
+		lng := center.Lng + offset[1]*width
+		if lng > 180 {
+			lng -= 360
+		} else if lng < -180 {
+			lng += 360
+		}
+
+		neighbors = append(neighbors, Encode(Point{Lat: lat, Lng: lng}, precision))
+	}
+
+	return neighbors
+}
@@ -1,6 +1,9 @@
 package geo
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestRoundGeohash(t *testing.T) {
 	tests := []struct {
@@ -186,9 +189,387 @@ func TestRoundGeohash_Consistency(t *testing.T) {
 	}
 }
 
+func TestIsValidGeohash(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"dr5reg", true},
+		{"DR5REG", true},
+		{"9q8yyk8ytpxr", true},
+		{"", false},
+		{"dr5rea", false}, // 'a' is not in the geohash alphabet
+		{"dr5-eg", false},
+		{"dr5 eg", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsValidGeohash(tt.input); got != tt.want {
+			t.Errorf("IsValidGeohash(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
 func TestDefaultPrecision(t *testing.T) {
 	// Verify the default precision constant is 6
 	if DefaultPrecision != 6 {
 		t.Errorf("DefaultPrecision = %d, want 6", DefaultPrecision)
 	}
 }
+
+func TestSetCoarsePrecision(t *testing.T) {
+	t.Cleanup(func() { _ = SetCoarsePrecision(DefaultPrecision) })
+
+	if got := CoarsePrecision(); got != DefaultPrecision {
+		t.Errorf("CoarsePrecision() = %d before any setting, want %d", got, DefaultPrecision)
+	}
+
+	if err := SetCoarsePrecision(5); err != nil {
+		t.Fatalf("SetCoarsePrecision(5) failed: %v", err)
+	}
+	if got := RoundGeohash("dr5regw", CoarsePrecision()); got != "dr5re" {
+		t.Errorf("expected truncation to the configured precision, got %q", got)
+	}
+
+	for _, invalid := range []int{0, -1, MaxPrecision + 1} {
+		if err := SetCoarsePrecision(invalid); err != ErrInvalidPrecision {
+			t.Errorf("SetCoarsePrecision(%d) = %v, want ErrInvalidPrecision", invalid, err)
+		}
+	}
+	if got := CoarsePrecision(); got != 5 {
+		t.Errorf("expected invalid settings to leave precision at 5, got %d", got)
+	}
+}
+
+func TestPoint_Valid(t *testing.T) {
+	tests := []struct {
+		name  string
+		point Point
+		want  bool
+	}{
+		{"origin", Point{Lat: 0, Lng: 0}, true},
+		{"north pole", Point{Lat: 90, Lng: 0}, true},
+		{"south pole", Point{Lat: -90, Lng: 0}, true},
+		{"antimeridian east", Point{Lat: 0, Lng: 180}, true},
+		{"antimeridian west", Point{Lat: 0, Lng: -180}, true},
+		{"corner", Point{Lat: -90, Lng: 180}, true},
+		{"latitude just above range", Point{Lat: 90.000001, Lng: 0}, false},
+		{"latitude just below range", Point{Lat: -90.000001, Lng: 0}, false},
+		{"longitude just above range", Point{Lat: 0, Lng: 180.000001}, false},
+		{"longitude just below range", Point{Lat: 0, Lng: -180.000001}, false},
+		{"latitude far out of range", Point{Lat: 999, Lng: 0}, false},
+		{"longitude far out of range", Point{Lat: 0, Lng: -400}, false},
+		{"NaN latitude", Point{Lat: math.NaN(), Lng: 0}, false},
+		{"NaN longitude", Point{Lat: 0, Lng: math.NaN()}, false},
+		{"infinite latitude", Point{Lat: math.Inf(1), Lng: 0}, false},
+		{"infinite longitude", Point{Lat: 0, Lng: math.Inf(-1)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.point.Valid(); got != tt.want {
+				t.Errorf("Point%+v.Valid() = %v, want %v", tt.point, got, tt.want)
+			}
+			if got := Encode(tt.point, DefaultPrecision) != ""; got != tt.want {
+				t.Errorf("Encode(%+v) returned a geohash = %v, want %v", tt.point, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name      string
+		point     Point
+		precision int
+		want      string
+	}{
+		// Reference values
+		{
+			name:      "San Francisco full precision",
+			point:     Point{Lat: 37.7749, Lng: -122.4194},
+			precision: 12,
+			want:      "9q8yyk8ytpxr",
+		},
+		{
+			name:      "San Francisco default precision",
+			point:     Point{Lat: 37.7749, Lng: -122.4194},
+			precision: DefaultPrecision,
+			want:      "9q8yyk",
+		},
+		{
+			name:      "London",
+			point:     Point{Lat: 51.5074, Lng: -0.1278},
+			precision: 8,
+			want:      "gcpvj0du",
+		},
+		{
+			name:      "New York",
+			point:     Point{Lat: 40.7128, Lng: -74.0060},
+			precision: 9,
+			want:      "dr5regw3p",
+		},
+		{
+			name:      "Wikipedia reference point",
+			point:     Point{Lat: 57.64911, Lng: 10.40744},
+			precision: 11,
+			want:      "u4pruydqqvj",
+		},
+		// Boundary coordinates
+		{
+			name:      "origin",
+			point:     Point{Lat: 0, Lng: 0},
+			precision: 5,
+			want:      "s0000",
+		},
+		{
+			name:      "north-east corner",
+			point:     Point{Lat: 90, Lng: 180},
+			precision: 6,
+			want:      "zzzzzz",
+		},
+		{
+			name:      "south-west corner",
+			point:     Point{Lat: -90, Lng: -180},
+			precision: 6,
+			want:      "000000",
+		},
+		// Precision clamping
+		{
+			name:      "precision 0 clamped to 1",
+			point:     Point{Lat: 37.7749, Lng: -122.4194},
+			precision: 0,
+			want:      "9",
+		},
+		{
+			name:      "negative precision clamped to 1",
+			point:     Point{Lat: 37.7749, Lng: -122.4194},
+			precision: -3,
+			want:      "9",
+		},
+		{
+			name:      "precision above max clamped to 12",
+			point:     Point{Lat: 37.7749, Lng: -122.4194},
+			precision: 20,
+			want:      "9q8yyk8ytpxr",
+		},
+		// Out of range coordinates
+		{
+			name:      "latitude above 90",
+			point:     Point{Lat: 90.1, Lng: 0},
+			precision: 6,
+			want:      "",
+		},
+		{
+			name:      "latitude below -90",
+			point:     Point{Lat: -91, Lng: 0},
+			precision: 6,
+			want:      "",
+		},
+		{
+			name:      "longitude above 180",
+			point:     Point{Lat: 0, Lng: 180.5},
+			precision: 6,
+			want:      "",
+		},
+		{
+			name:      "longitude below -180",
+			point:     Point{Lat: 0, Lng: -181},
+			precision: 6,
+			want:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Encode(tt.point, tt.precision)
+			if got != tt.want {
+				t.Errorf("Encode(%v, %d) = %q, want %q", tt.point, tt.precision, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode_RoundTrip(t *testing.T) {
+	hashes := []string{"9q8yyk8ytpxr", "gcpvj0du", "dr5regw3p", "u4pruydqqvj", "s0000", "z", "0"}
+
+	for _, hash := range hashes {
+		t.Run(hash, func(t *testing.T) {
+			p, ok := Decode(hash)
+			if !ok {
+				t.Fatalf("Decode(%q) returned ok=false", hash)
+			}
+			if got := Encode(p, len(hash)); got != hash {
+				t.Errorf("Encode(Decode(%q)) = %q, want %q", hash, got, hash)
+			}
+		})
+	}
+}
+
+func TestDecode_ContainsOriginalPoint(t *testing.T) {
+	original := Point{Lat: 51.5074, Lng: -0.1278}
+	hash := Encode(original, 7)
+
+	b, ok := DecodeBounds(hash)
+	if !ok {
+		t.Fatalf("DecodeBounds(%q) returned ok=false", hash)
+	}
+	if original.Lat < b.MinLat || original.Lat > b.MaxLat || original.Lng < b.MinLng || original.Lng > b.MaxLng {
+		t.Errorf("bounds %+v do not contain original point %+v", b, original)
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	inputs := []string{"", "9q8a", "abc", "9q8-yk", "9q8 yk"}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			if _, ok := Decode(input); ok {
+				t.Errorf("Decode(%q) returned ok=true, want false", input)
+			}
+		})
+	}
+}
+
+func TestDecode_CaseInsensitive(t *testing.T) {
+	lower, ok := Decode("9q8yyk")
+	if !ok {
+		t.Fatal("Decode lowercase returned ok=false")
+	}
+	upper, ok := Decode("9Q8YYK")
+	if !ok {
+		t.Fatal("Decode uppercase returned ok=false")
+	}
+	if lower != upper {
+		t.Errorf("Decode case mismatch: lower=%v, upper=%v", lower, upper)
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	tests := []struct {
+		name string
+		hash string
+		want []string
+	}{
+		{
+			name: "reference cell",
+			hash: "ezs42",
+			want: []string{"ezs48", "ezs49", "ezs43", "ezs41", "ezs40", "ezefp", "ezefr", "ezefx"},
+		},
+		{
+			name: "cell touching equator and prime meridian",
+			hash: "s0000",
+			want: []string{"s0002", "s0003", "s0001", "kpbpc", "kpbpb", "7zzzz", "ebpbp", "ebpbr"},
+		},
+		{
+			name: "cell west of antimeridian wraps east",
+			hash: "xbpbp",
+			want: []string{"xbpbr", "80002", "80000", "2pbpb", "rzzzz", "rzzzy", "xbpbn", "xbpbq"},
+		},
+		{
+			name: "cell east of antimeridian wraps west",
+			hash: "8000",
+			want: []string{"8001", "8003", "8002", "2pbr", "2pbp", "rzzz", "xbpb", "xbpc"},
+		},
+		{
+			name: "cell touching north pole omits northern neighbors",
+			hash: "zzzz",
+			want: []string{"bpbp", "bpbn", "zzzy", "zzzw", "zzzx"},
+		},
+		{
+			name: "uppercase input",
+			hash: "EZS42",
+			want: []string{"ezs48", "ezs49", "ezs43", "ezs41", "ezs40", "ezefp", "ezefr", "ezefx"},
+		},
+		{
+			name: "empty input",
+			hash: "",
+			want: nil,
+		},
+		{
+			name: "invalid character",
+			hash: "ezs4a",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Neighbors(tt.hash)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Neighbors(%q) = %v, want %v", tt.hash, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Neighbors(%q)[%d] = %q, want %q", tt.hash, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNeighbors_SamePrecision(t *testing.T) {
+	hash := "9q8yyk"
+	for _, n := range Neighbors(hash) {
+		if len(n) != len(hash) {
+			t.Errorf("neighbor %q has precision %d, want %d", n, len(n), len(hash))
+		}
+		if n == hash {
+			t.Errorf("neighbor list contains the input cell %q", hash)
+		}
+	}
+}
+
+func TestPointInGeohash(t *testing.T) {
+	// Bounds of "9q8yyk" used for edge cases
+	b, ok := DecodeBounds("9q8yyk")
+	if !ok {
+		t.Fatal("DecodeBounds(9q8yyk) returned ok=false")
+	}
+
+	tests := []struct {
+		name  string
+		point Point
+		hash  string
+		want  bool
+	}{
+		{"San Francisco in its cell", Point{Lat: 37.7749, Lng: -122.4194}, "9q8yyk", true},
+		{"San Francisco in coarser cell", Point{Lat: 37.7749, Lng: -122.4194}, "9q8", true},
+		{"London not in San Francisco cell", Point{Lat: 51.5074, Lng: -0.1278}, "9q8yyk", false},
+		{"uppercase hash", Point{Lat: 37.7749, Lng: -122.4194}, "9Q8YYK", true},
+		{"south-west corner on edge", Point{Lat: b.MinLat, Lng: b.MinLng}, "9q8yyk", true},
+		{"north-east corner on edge", Point{Lat: b.MaxLat, Lng: b.MaxLng}, "9q8yyk", true},
+		{"just outside north edge", Point{Lat: b.MaxLat + 1e-6, Lng: b.MinLng}, "9q8yyk", false},
+		{"empty hash", Point{Lat: 37.7749, Lng: -122.4194}, "", false},
+		{"invalid hash", Point{Lat: 37.7749, Lng: -122.4194}, "9q8ayk", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PointInGeohash(tt.point, tt.hash); got != tt.want {
+				t.Errorf("PointInGeohash(%v, %q) = %v, want %v", tt.point, tt.hash, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrecisionForVisibility(t *testing.T) {
+	tests := []struct {
+		level string
+		want  int
+	}{
+		{ViewerPublic, 5},
+		{ViewerMember, 6},
+		{ViewerOwner, MaxPrecision},
+		{"", PublicPrecision},
+		{"unknown", PublicPrecision},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			if got := PrecisionForVisibility(tt.level); got != tt.want {
+				t.Errorf("PrecisionForVisibility(%q) = %d, want %d", tt.level, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Jitter offsets a point by a random bearing and distance within radiusMeters.
+// The offset is drawn uniformly over the disc using a deterministic RNG seeded
+// with seed, so the same inputs always produce the same output.
+//
+// A radius of 0 or less returns the input unchanged. The result is always a
+// valid coordinate: latitude is clamped to [-90, 90] near the poles and
+// longitude is wrapped into [-180, 180].
+func Jitter(p Point, radiusMeters float64, seed int64) Point {
+	if radiusMeters <= 0 {
+		return p
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	bearing := rng.Float64() * 2 * math.Pi
+	// Square root keeps the distribution uniform over the disc area
+	distance := radiusMeters * math.Sqrt(rng.Float64())
+
+	return destination(p, bearing, distance)
+}
+
+// destination returns the point reached by travelling distanceMeters from p
+// along the given bearing (radians clockwise from north) on a spherical Earth.
+func destination(p Point, bearing, distanceMeters float64) Point {
+	angular := distanceMeters / EarthRadiusMeters
+	lat1 := p.Lat * math.Pi / 180
+	lng1 := p.Lng * math.Pi / 180
+
+	sinLat2 := math.Sin(lat1)*math.Cos(angular) + math.Cos(lat1)*math.Sin(angular)*math.Cos(bearing)
+	sinLat2 = math.Min(1, math.Max(-1, sinLat2))
+	lat2 := math.Asin(sinLat2)
+	lng2 := lng1 + math.Atan2(
+		math.Sin(bearing)*math.Sin(angular)*math.Cos(lat1),
+		math.Cos(angular)-math.Sin(lat1)*sinLat2,
+	)
+
+	lat := lat2 * 180 / math.Pi
+	lng := lng2 * 180 / math.Pi
+
+	// Clamp latitude and wrap longitude into valid ranges
+	lat = math.Min(90, math.Max(-90, lat))
+	lng = math.Mod(lng+540, 360) - 180
+
+	return Point{Lat: lat, Lng: lng}
+}
@@ -0,0 +1,76 @@
+package geo
+
+import "testing"
+
+func TestJitter_ZeroRadius(t *testing.T) {
+	p := Point{Lat: 37.7749, Lng: -122.4194}
+
+	for _, radius := range []float64{0, -100} {
+		if got := Jitter(p, radius, 42); got != p {
+			t.Errorf("Jitter(%v, %f, 42) = %v, want input unchanged", p, radius, got)
+		}
+	}
+}
+
+func TestJitter_Deterministic(t *testing.T) {
+	p := Point{Lat: 37.7749, Lng: -122.4194}
+
+	first := Jitter(p, 500, 42)
+	for i := 0; i < 100; i++ {
+		if got := Jitter(p, 500, 42); got != first {
+			t.Fatalf("Jitter inconsistent: first=%v, iteration %d=%v", first, i, got)
+		}
+	}
+
+	if other := Jitter(p, 500, 43); other == first {
+		t.Errorf("Jitter with different seeds produced identical output %v", other)
+	}
+}
+
+func TestJitter_WithinRadius(t *testing.T) {
+	p := Point{Lat: 37.7749, Lng: -122.4194}
+	const radius = 500.0
+
+	moved := false
+	for seed := int64(0); seed < 200; seed++ {
+		got := Jitter(p, radius, seed)
+		// Allow a small epsilon for floating point error in the spherical math
+		if d := DistanceMeters(p, got); d > radius+0.01 {
+			t.Errorf("seed %d: jittered point %v is %.2fm away, want <= %.0fm", seed, got, d, radius)
+		}
+		if got != p {
+			moved = true
+		}
+	}
+
+	if !moved {
+		t.Error("Jitter never moved the point")
+	}
+}
+
+func TestJitter_ValidCoordinates(t *testing.T) {
+	tests := []struct {
+		name  string
+		point Point
+	}{
+		{"north pole", Point{Lat: 90, Lng: 0}},
+		{"south pole", Point{Lat: -90, Lng: 0}},
+		{"near north pole", Point{Lat: 89.9999, Lng: 45}},
+		{"antimeridian east", Point{Lat: 0, Lng: 180}},
+		{"antimeridian west", Point{Lat: 0, Lng: -180}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for seed := int64(0); seed < 50; seed++ {
+				got := Jitter(tt.point, 5_000, seed)
+				if got.Lat < -90 || got.Lat > 90 {
+					t.Errorf("seed %d: latitude %f out of range", seed, got.Lat)
+				}
+				if got.Lng < -180 || got.Lng > 180 {
+					t.Errorf("seed %d: longitude %f out of range", seed, got.Lng)
+				}
+			}
+		})
+	}
+}
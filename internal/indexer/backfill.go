@@ -0,0 +1,223 @@
+// Package indexer provides filtering and processing of AT Protocol records
+// for the Subcults Jetstream indexer.
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/onnwee/subcults/internal/did"
+)
+
+// maxMSTDepth bounds the repository tree walk. Real trees are a handful of
+// levels deep; anything deeper is treated as malformed.
+const maxMSTDepth = 128
+
+// tidAlphabet is the base32-sortable alphabet of AT Protocol TIDs.
+const tidAlphabet = "234567abcdefghijklmnopqrstuvwxyz"
+
+// Stats counts the records handled by a backfill. Records outside the
+// app.subcult.* namespace are not counted.
+type Stats struct {
+	Created int // Records that created a scene, or recreated a deleted one
+	Updated int // Records that updated an older copy of their scene
+	Skipped int // Records already ingested, not persisted, missing, or invalid
+}
+
+// Backfill imports the Subcults records in an AT Protocol repository export:
+// a CARv1 stream rooted at a signed commit. Each record goes through
+// processor as a create commit stamped with the repository revision's
+// timestamp, so records already ingested at that revision or later, whether
+// from the firehose or an earlier backfill, are skipped. An interrupted
+// backfill can therefore be resumed by re-running it on the same export.
+//
+// Invalid records and records missing from a partial export are skipped;
+// a malformed CAR or repository structure aborts with ErrInvalidCAR.
+func Backfill(ctx context.Context, r io.Reader, processor *Processor) (Stats, error) {
+	var stats Stats
+
+	car, err := readCAR(r)
+	if err != nil {
+		return stats, err
+	}
+	if len(car.roots) != 1 {
+		return stats, fmt.Errorf("%w: expected a single root, got %d", ErrInvalidCAR, len(car.roots))
+	}
+
+	repoDID, rev, data, err := decodeRepoCommit(car.blocks[string(car.roots[0])])
+	if err != nil {
+		return stats, err
+	}
+	timeUS, err := tidTimestamp(rev)
+	if err != nil {
+		return stats, err
+	}
+
+	err = walkMST(car.blocks, data, 0, func(key string, value cborLink) error {
+		collection, rkey, ok := strings.Cut(key, "/")
+		if !ok || !MatchesLexicon(collection) {
+			return nil
+		}
+
+		block, ok := car.blocks[string(value)]
+		if !ok {
+			processor.logger.Warn("backfill record block missing", "did", repoDID, "key", key)
+			stats.Skipped++
+			return nil
+		}
+		payload, err := recordJSON(block)
+		if err == nil {
+			err = validateRecord(collection, payload)
+		}
+		if err != nil {
+			processor.logger.Warn("skipping invalid backfill record", "did", repoDID, "key", key, "error", err)
+			stats.Skipped++
+			return nil
+		}
+
+		outcome, err := processor.process(ctx, &Commit{
+			DID:        repoDID,
+			TimeUS:     timeUS,
+			Collection: collection,
+			RKey:       rkey,
+			Operation:  OperationCreate,
+			Rev:        rev,
+			CID:        formatCID(value),
+			Record:     payload,
+		})
+		if err != nil {
+			if errors.Is(err, ErrMalformedJSON) || errors.Is(err, ErrMissingField) || errors.Is(err, ErrInvalidFieldType) {
+				processor.logger.Warn("skipping invalid backfill record", "did", repoDID, "key", key, "error", err)
+				stats.Skipped++
+				return nil
+			}
+			return err
+		}
+
+		switch outcome {
+		case outcomeCreated:
+			stats.Created++
+		case outcomeUpdated:
+			stats.Updated++
+		default:
+			stats.Skipped++
+		}
+		return nil
+	})
+	return stats, err
+}
+
+// decodeRepoCommit extracts the repository DID, revision, and data root from
+// a repository commit block.
+func decodeRepoCommit(block []byte) (string, string, cborLink, error) {
+	if block == nil {
+		return "", "", nil, fmt.Errorf("%w: root commit block missing", ErrInvalidCAR)
+	}
+	decoded, err := decodeCBOR(block)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("%w: commit: %v", ErrInvalidCAR, err)
+	}
+	commit, ok := decoded.(map[string]any)
+	if !ok {
+		return "", "", nil, fmt.Errorf("%w: commit is not a map", ErrInvalidCAR)
+	}
+
+	repoDID, _ := commit["did"].(string)
+	if err := did.ValidateDID(repoDID); err != nil {
+		return "", "", nil, fmt.Errorf("%w: commit: %v", ErrInvalidCAR, err)
+	}
+	rev, _ := commit["rev"].(string)
+	data, ok := commit["data"].(cborLink)
+	if !ok {
+		return "", "", nil, fmt.Errorf("%w: commit has no data root", ErrInvalidCAR)
+	}
+	return repoDID, rev, data, nil
+}
+
+// walkMST visits every (key, record CID) entry of the repository Merkle
+// Search Tree rooted at node, in key order.
+func walkMST(blocks map[string][]byte, node cborLink, depth int, visit func(key string, value cborLink) error) error {
+	if depth > maxMSTDepth {
+		return fmt.Errorf("%w: tree too deep", ErrInvalidCAR)
+	}
+	block, ok := blocks[string(node)]
+	if !ok {
+		return fmt.Errorf("%w: tree node %s missing", ErrInvalidCAR, formatCID(node))
+	}
+	decoded, err := decodeCBOR(block)
+	if err != nil {
+		return fmt.Errorf("%w: tree node: %v", ErrInvalidCAR, err)
+	}
+	fields, ok := decoded.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%w: tree node is not a map", ErrInvalidCAR)
+	}
+
+	if left, ok := fields["l"].(cborLink); ok {
+		if err := walkMST(blocks, left, depth+1, visit); err != nil {
+			return err
+		}
+	}
+
+	entries, _ := fields["e"].([]any)
+	var prevKey string
+	for _, e := range entries {
+		entry, ok := e.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%w: tree entry is not a map", ErrInvalidCAR)
+		}
+		// Keys are prefix-compressed against the previous entry in the node
+		prefixLen, _ := entry["p"].(int64)
+		suffix, _ := entry["k"].([]byte)
+		value, ok := entry["v"].(cborLink)
+		if !ok || prefixLen < 0 || prefixLen > int64(len(prevKey)) {
+			return fmt.Errorf("%w: malformed tree entry", ErrInvalidCAR)
+		}
+		key := prevKey[:prefixLen] + string(suffix)
+		prevKey = key
+
+		if err := visit(key, value); err != nil {
+			return err
+		}
+		if right, ok := entry["t"].(cborLink); ok {
+			if err := walkMST(blocks, right, depth+1, visit); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// recordJSON converts a DAG-CBOR record block to its JSON form.
+func recordJSON(block []byte) (json.RawMessage, error) {
+	decoded, err := decodeCBOR(block)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := decoded.(map[string]any); !ok {
+		return nil, ErrMalformedCBOR
+	}
+	return json.Marshal(cborToJSON(decoded))
+}
+
+// tidTimestamp returns the microsecond timestamp encoded in a TID, the
+// 13-character identifier AT Protocol uses for repository revisions.
+func tidTimestamp(tid string) (int64, error) {
+	if len(tid) != 13 {
+		return 0, fmt.Errorf("%w: invalid revision %q", ErrInvalidCAR, tid)
+	}
+	var value uint64
+	for i := 0; i < len(tid); i++ {
+		n := strings.IndexByte(tidAlphabet, tid[i])
+		if n < 0 || (i == 0 && n >= 16) { // The top bit is always zero
+			return 0, fmt.Errorf("%w: invalid revision %q", ErrInvalidCAR, tid)
+		}
+		value = value<<5 | uint64(n)
+	}
+	// The low 10 bits are a clock identifier
+	return int64(value >> 10), nil
+}
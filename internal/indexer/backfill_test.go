@@ -0,0 +1,295 @@
+package indexer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+const backfillDID = "did:plc:backfill"
+
+// backfillRevTime is the timestamp encoded in the test repository revision.
+var backfillRevTime = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// encodeTestCBOR encodes v as DAG-CBOR. It supports the value types
+// decodeCBOR produces, plus int.
+func encodeTestCBOR(t *testing.T, v any) []byte {
+	t.Helper()
+	head := func(major byte, n uint64) []byte {
+		switch {
+		case n < 24:
+			return []byte{major<<5 | byte(n)}
+		case n <= 0xff:
+			return []byte{major<<5 | 24, byte(n)}
+		case n <= 0xffff:
+			return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(n))
+		case n <= 0xffffffff:
+			return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(n))
+		default:
+			return binary.BigEndian.AppendUint64([]byte{major<<5 | 27}, n)
+		}
+	}
+
+	switch val := v.(type) {
+	case nil:
+		return []byte{0xf6}
+	case bool:
+		if val {
+			return []byte{0xf5}
+		}
+		return []byte{0xf4}
+	case int:
+		if val < 0 {
+			return head(1, uint64(-1-val))
+		}
+		return head(0, uint64(val))
+	case string:
+		return append(head(3, uint64(len(val))), val...)
+	case []byte:
+		return append(head(2, uint64(len(val))), val...)
+	case cborLink:
+		out := append(head(6, cborTagCID), head(2, uint64(len(val)+1))...)
+		return append(append(out, 0x00), val...)
+	case []any:
+		out := head(4, uint64(len(val)))
+		for _, item := range val {
+			out = append(out, encodeTestCBOR(t, item)...)
+		}
+		return out
+	case map[string]any:
+		// DAG-CBOR sorts keys by length, then bytewise
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if len(keys[i]) != len(keys[j]) {
+				return len(keys[i]) < len(keys[j])
+			}
+			return keys[i] < keys[j]
+		})
+		out := head(5, uint64(len(val)))
+		for _, k := range keys {
+			out = append(out, encodeTestCBOR(t, k)...)
+			out = append(out, encodeTestCBOR(t, val[k])...)
+		}
+		return out
+	default:
+		t.Fatalf("unsupported CBOR test value %T", v)
+		return nil
+	}
+}
+
+// testCAR assembles a CARv1 stream from DAG-CBOR blocks.
+type testCAR struct {
+	t      *testing.T
+	blocks [][]byte // CID followed by data
+}
+
+// add encodes v as a block and returns its CID.
+func (c *testCAR) add(v any) cborLink {
+	data := encodeTestCBOR(c.t, v)
+	sum := sha256.Sum256(data)
+	cid := append([]byte{cidVersion1, 0x71, multihashSHA256, sha256.Size}, sum[:]...)
+	c.blocks = append(c.blocks, append(append([]byte(nil), cid...), data...))
+	return cborLink(cid)
+}
+
+// bytes returns the CAR stream rooted at root.
+func (c *testCAR) bytes(root cborLink) []byte {
+	header := encodeTestCBOR(c.t, map[string]any{"version": 1, "roots": []any{root}})
+	out := binary.AppendUvarint(nil, uint64(len(header)))
+	out = append(out, header...)
+	for _, block := range c.blocks {
+		out = binary.AppendUvarint(out, uint64(len(block)))
+		out = append(out, block...)
+	}
+	return out
+}
+
+// makeTID encodes a TID for the given time with clock identifier 0.
+func makeTID(ts time.Time) string {
+	value := uint64(ts.UnixMicro()) << 10
+	tid := make([]byte, 13)
+	for i := 12; i >= 0; i-- {
+		tid[i] = tidAlphabet[value&0x1f]
+		value >>= 5
+	}
+	return string(tid)
+}
+
+// buildRepoCAR builds a repository export holding two scene records, one
+// event record, and one record outside the Subcults namespace.
+func buildRepoCAR(t *testing.T) []byte {
+	t.Helper()
+	car := &testCAR{t: t}
+
+	post := car.add(map[string]any{"$type": "app.bsky.feed.post", "text": "hello"})
+	event := car.add(map[string]any{"$type": CollectionEvent, "name": "Late Set", "sceneId": "s1"})
+	scene1 := car.add(map[string]any{"$type": CollectionScene, "name": "Basement Techno", "coarseGeohash": "u33dc0x"})
+	scene2 := car.add(map[string]any{
+		"$type":        CollectionScene,
+		"name":         "Rooftop Dub",
+		"precisePoint": map[string]any{"lat": 40, "lng": -74},
+		"tags":         []any{"Dub"},
+	})
+
+	// Keys sort as bsky < event < scene; later keys share a prefix
+	root := car.add(map[string]any{
+		"l": nil,
+		"e": []any{
+			map[string]any{"p": 0, "k": []byte("app.bsky.feed.post/p1"), "v": post, "t": nil},
+			map[string]any{"p": 4, "k": []byte("subcult.event/e1"), "v": event, "t": nil},
+			map[string]any{"p": 12, "k": []byte("scene/s1"), "v": scene1, "t": nil},
+			map[string]any{"p": 19, "k": []byte("2"), "v": scene2, "t": nil},
+		},
+	})
+	commit := car.add(map[string]any{
+		"did":     backfillDID,
+		"version": 3,
+		"data":    root,
+		"rev":     makeTID(backfillRevTime),
+		"prev":    nil,
+		"sig":     []byte("signature"),
+	})
+	return car.bytes(commit)
+}
+
+func TestBackfill_ImportsSceneRecords(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	p := NewProcessor(repo, nil)
+
+	stats, err := Backfill(t.Context(), bytes.NewReader(buildRepoCAR(t)), p)
+	if err != nil {
+		t.Fatalf("Backfill failed: %v", err)
+	}
+	// The event isn't persisted yet and the bsky post isn't counted
+	if stats != (Stats{Created: 2, Skipped: 1}) {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	s1, err := repo.GetByRecordKey(t.Context(), backfillDID, "s1")
+	if err != nil {
+		t.Fatalf("GetByRecordKey(s1) failed: %v", err)
+	}
+	if s1.Name != "Basement Techno" || s1.OwnerDID != backfillDID || s1.CoarseGeohash != "u33dc0" {
+		t.Errorf("unexpected scene s1: %+v", s1)
+	}
+	if s1.UpdatedAt == nil || !s1.UpdatedAt.Equal(backfillRevTime) {
+		t.Errorf("expected updated_at from the repository revision, got %v", s1.UpdatedAt)
+	}
+
+	s2, err := repo.GetByRecordKey(t.Context(), backfillDID, "s2")
+	if err != nil {
+		t.Fatalf("GetByRecordKey(s2) failed: %v", err)
+	}
+	if s2.PrecisePoint != nil {
+		t.Error("expected precise point dropped without consent")
+	}
+	if s2.CoarseGeohash == "" {
+		t.Error("expected coarse geohash derived from the precise point")
+	}
+	if len(s2.Tags) != 1 || s2.Tags[0] != "dub" {
+		t.Errorf("expected normalized tags [dub], got %v", s2.Tags)
+	}
+}
+
+func TestBackfill_Resumable(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	p := NewProcessor(repo, nil)
+	car := buildRepoCAR(t)
+
+	if _, err := Backfill(t.Context(), bytes.NewReader(car), p); err != nil {
+		t.Fatalf("first Backfill failed: %v", err)
+	}
+	stats, err := Backfill(t.Context(), bytes.NewReader(car), p)
+	if err != nil {
+		t.Fatalf("second Backfill failed: %v", err)
+	}
+	if stats != (Stats{Skipped: 3}) {
+		t.Errorf("expected re-run to skip every record, got %+v", stats)
+	}
+}
+
+func TestBackfill_DedupesAgainstLiveIngestion(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	p := NewProcessor(repo, nil)
+
+	// s1 was ingested live before the exported revision, s2 after it
+	live := []*Commit{
+		{DID: backfillDID, RKey: "s1", TimeUS: backfillRevTime.Add(-time.Hour).UnixMicro(), Record: []byte(`{"name": "Old Name", "coarseGeohash": "u33dc0"}`)},
+		{DID: backfillDID, RKey: "s2", TimeUS: backfillRevTime.Add(time.Hour).UnixMicro(), Record: []byte(`{"name": "Live Name", "coarseGeohash": "u33dc0"}`)},
+	}
+	for _, commit := range live {
+		commit.Collection = CollectionScene
+		commit.Operation = OperationCreate
+		if err := p.Process(t.Context(), commit); err != nil {
+			t.Fatalf("Process failed: %v", err)
+		}
+	}
+
+	stats, err := Backfill(t.Context(), bytes.NewReader(buildRepoCAR(t)), p)
+	if err != nil {
+		t.Fatalf("Backfill failed: %v", err)
+	}
+	if stats != (Stats{Updated: 1, Skipped: 2}) {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	s1, _ := repo.GetByRecordKey(t.Context(), backfillDID, "s1")
+	if s1.Name != "Basement Techno" {
+		t.Errorf("expected s1 updated from the export, got %q", s1.Name)
+	}
+	s2, _ := repo.GetByRecordKey(t.Context(), backfillDID, "s2")
+	if s2.Name != "Live Name" {
+		t.Errorf("expected newer live s2 kept, got %q", s2.Name)
+	}
+}
+
+func TestBackfill_InvalidCAR(t *testing.T) {
+	valid := buildRepoCAR(t)
+
+	corrupted := append([]byte(nil), valid...)
+	corrupted[len(corrupted)-1] ^= 0xff
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"truncated", valid[:len(valid)-5]},
+		{"block does not match CID", corrupted},
+		{"garbage header", []byte{0x03, 0xff, 0xff, 0xff}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := scene.NewInMemorySceneRepository()
+			if _, err := Backfill(t.Context(), bytes.NewReader(tt.data), NewProcessor(repo, nil)); !errors.Is(err, ErrInvalidCAR) {
+				t.Errorf("expected ErrInvalidCAR, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTIDTimestamp(t *testing.T) {
+	got, err := tidTimestamp(makeTID(backfillRevTime))
+	if err != nil {
+		t.Fatalf("tidTimestamp failed: %v", err)
+	}
+	if got != backfillRevTime.UnixMicro() {
+		t.Errorf("expected %d, got %d", backfillRevTime.UnixMicro(), got)
+	}
+
+	for _, tid := range []string{"", "3jzfcijpj2z2", "zzzzzzzzzzzzz", "3jzfcijpj2z2!"} {
+		if _, err := tidTimestamp(tid); !errors.Is(err, ErrInvalidCAR) {
+			t.Errorf("tidTimestamp(%q): expected ErrInvalidCAR, got %v", tid, err)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Package indexer provides filtering and processing of AT Protocol records
+// for the Subcults Jetstream indexer.
+package indexer
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrInvalidCAR is returned when a CAR stream is malformed or a block does
+// not match its CID.
+var ErrInvalidCAR = errors.New("invalid CAR file")
+
+// Size limits for CAR sections, to bound memory on hostile input. AT Protocol
+// records are capped well below the block limit.
+const (
+	maxCARHeaderSize = 64 << 10
+	maxCARBlockSize  = 2 << 20
+)
+
+// Multiformat codes used by AT Protocol repositories.
+const (
+	cidVersion1     = 0x01
+	multihashSHA256 = 0x12
+)
+
+// base32Lower is the multibase "b" alphabet used for CID strings.
+var base32Lower = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// carFile holds the roots and blocks of a CARv1 stream. Blocks are keyed by
+// their binary CID.
+type carFile struct {
+	roots  []cborLink
+	blocks map[string][]byte
+}
+
+// readCAR reads a whole CARv1 stream, verifying each block against its CID.
+func readCAR(r io.Reader) (*carFile, error) {
+	br := bufio.NewReader(r)
+
+	header, err := readCARSection(br, maxCARHeaderSize)
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("%w: missing header", ErrInvalidCAR)
+		}
+		return nil, err
+	}
+	decoded, err := decodeCBOR(header)
+	if err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidCAR, err)
+	}
+	fields, ok := decoded.(map[string]any)
+	if !ok || fields["version"] != int64(1) {
+		return nil, fmt.Errorf("%w: unsupported header", ErrInvalidCAR)
+	}
+	rootList, _ := fields["roots"].([]any)
+	car := &carFile{blocks: make(map[string][]byte)}
+	for _, root := range rootList {
+		link, ok := root.(cborLink)
+		if !ok {
+			return nil, fmt.Errorf("%w: root is not a CID", ErrInvalidCAR)
+		}
+		car.roots = append(car.roots, link)
+	}
+
+	for {
+		section, err := readCARSection(br, maxCARBlockSize)
+		if err == io.EOF {
+			return car, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		cidLen, err := verifyBlock(section)
+		if err != nil {
+			return nil, err
+		}
+		car.blocks[string(section[:cidLen])] = section[cidLen:]
+	}
+}
+
+// readCARSection reads one varint-length-prefixed section. It returns
+// io.EOF only when the stream ends cleanly between sections.
+func readCARSection(br *bufio.Reader, maxSize uint64) ([]byte, error) {
+	size, err := binary.ReadUvarint(br)
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: section length: %v", ErrInvalidCAR, err)
+	}
+	if size == 0 || size > maxSize {
+		return nil, fmt.Errorf("%w: section length %d out of range", ErrInvalidCAR, size)
+	}
+	section := make([]byte, size)
+	if _, err := io.ReadFull(br, section); err != nil {
+		return nil, fmt.Errorf("%w: truncated section", ErrInvalidCAR)
+	}
+	return section, nil
+}
+
+// verifyBlock parses the CIDv1 at the start of section and checks its
+// SHA-256 digest against the block data that follows. It returns the CID's
+// length in bytes.
+func verifyBlock(section []byte) (int, error) {
+	r := bytes.NewReader(section)
+	version, err := binary.ReadUvarint(r)
+	if err != nil || version != cidVersion1 {
+		return 0, fmt.Errorf("%w: unsupported CID version", ErrInvalidCAR)
+	}
+	if _, err := binary.ReadUvarint(r); err != nil { // content codec
+		return 0, fmt.Errorf("%w: malformed CID", ErrInvalidCAR)
+	}
+	hashCode, err := binary.ReadUvarint(r)
+	if err != nil || hashCode != multihashSHA256 {
+		return 0, fmt.Errorf("%w: unsupported CID hash", ErrInvalidCAR)
+	}
+	digestLen, err := binary.ReadUvarint(r)
+	if err != nil || digestLen != sha256.Size || uint64(r.Len()) < digestLen {
+		return 0, fmt.Errorf("%w: malformed CID", ErrInvalidCAR)
+	}
+
+	cidLen := len(section) - r.Len() + int(digestLen)
+	digest := section[cidLen-int(digestLen) : cidLen]
+	sum := sha256.Sum256(section[cidLen:])
+	if !bytes.Equal(digest, sum[:]) {
+		return 0, fmt.Errorf("%w: block does not match its CID", ErrInvalidCAR)
+	}
+	return cidLen, nil
+}
+
+// formatCID returns the base32 multibase string form of a binary CIDv1.
+func formatCID(cid []byte) string {
+	return "b" + base32Lower.EncodeToString(cid)
+}
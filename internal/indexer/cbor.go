@@ -0,0 +1,223 @@
+// Package indexer provides filtering and processing of AT Protocol records
+// for the Subcults Jetstream indexer.
+package indexer
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// ErrMalformedCBOR is returned when a block is not valid DAG-CBOR.
+var ErrMalformedCBOR = errors.New("malformed DAG-CBOR")
+
+// cborTagCID is the CBOR tag DAG-CBOR uses for CID links.
+const cborTagCID = 42
+
+// maxCBORDepth bounds nesting so hostile blocks cannot exhaust the stack.
+const maxCBORDepth = 64
+
+// cborLink is a decoded DAG-CBOR CID link. It holds the binary CID without
+// the multibase identity prefix.
+type cborLink []byte
+
+// decodeCBOR decodes a single DAG-CBOR value filling all of data. Maps decode
+// to map[string]any, arrays to []any, integers to int64, byte strings to
+// []byte, and CID links to cborLink.
+func decodeCBOR(data []byte) (any, error) {
+	d := &cborDecoder{data: data}
+	v, err := d.value(0)
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.data) {
+		return nil, ErrMalformedCBOR
+	}
+	return v, nil
+}
+
+// cborDecoder reads DAG-CBOR values from data.
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+// head reads an item head, returning its major type and argument.
+// Indefinite lengths are rejected, as DAG-CBOR forbids them.
+func (d *cborDecoder) head() (byte, uint64, error) {
+	if d.pos >= len(d.data) {
+		return 0, 0, ErrMalformedCBOR
+	}
+	initial := d.data[d.pos]
+	d.pos++
+	major, info := initial>>5, initial&0x1f
+
+	var size int
+	switch {
+	case info < 24:
+		return major, uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, 0, ErrMalformedCBOR
+	}
+	if len(d.data)-d.pos < size {
+		return 0, 0, ErrMalformedCBOR
+	}
+	b := d.data[d.pos : d.pos+size]
+	d.pos += size
+	switch size {
+	case 1:
+		return major, uint64(b[0]), nil
+	case 2:
+		return major, uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return major, uint64(binary.BigEndian.Uint32(b)), nil
+	default:
+		return major, binary.BigEndian.Uint64(b), nil
+	}
+}
+
+// bytes reads n raw bytes.
+func (d *cborDecoder) bytes(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, ErrMalformedCBOR
+	}
+	b := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return b, nil
+}
+
+func (d *cborDecoder) value(depth int) (any, error) {
+	if depth > maxCBORDepth {
+		return nil, ErrMalformedCBOR
+	}
+	start := d.pos
+	major, arg, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case 0: // unsigned integer
+		if arg > math.MaxInt64 {
+			return nil, ErrMalformedCBOR
+		}
+		return int64(arg), nil
+	case 1: // negative integer
+		if arg > math.MaxInt64 {
+			return nil, ErrMalformedCBOR
+		}
+		return -1 - int64(arg), nil
+	case 2: // byte string
+		b, err := d.bytes(arg)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	case 3: // text string
+		b, err := d.bytes(arg)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case 4: // array
+		// Every item takes at least one byte
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, ErrMalformedCBOR
+		}
+		items := make([]any, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			item, err := d.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case 5: // map; DAG-CBOR keys are always strings
+		if arg > uint64(len(d.data)-d.pos)/2 {
+			return nil, ErrMalformedCBOR
+		}
+		m := make(map[string]any, arg)
+		for i := uint64(0); i < arg; i++ {
+			key, err := d.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			k, ok := key.(string)
+			if !ok {
+				return nil, ErrMalformedCBOR
+			}
+			if _, dup := m[k]; dup {
+				return nil, ErrMalformedCBOR
+			}
+			v, err := d.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = v
+		}
+		return m, nil
+	case 6: // tag; only CID links are allowed
+		if arg != cborTagCID {
+			return nil, ErrMalformedCBOR
+		}
+		content, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		b, ok := content.([]byte)
+		if !ok || len(b) < 2 || b[0] != 0x00 {
+			return nil, ErrMalformedCBOR
+		}
+		return cborLink(b[1:]), nil
+	default: // simple values and floats
+		info := d.data[start] & 0x1f
+		switch {
+		case info == 20:
+			return false, nil
+		case info == 21:
+			return true, nil
+		case info == 22:
+			return nil, nil
+		case info == 27:
+			return math.Float64frombits(arg), nil
+		default:
+			// DAG-CBOR encodes every float as 64-bit and has no undefined
+			return nil, ErrMalformedCBOR
+		}
+	}
+}
+
+// cborToJSON converts a decoded DAG-CBOR value to its AT Protocol JSON form:
+// byte strings become {"$bytes": base64} and links become {"$link": cid}.
+func cborToJSON(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			out[k] = cborToJSON(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = cborToJSON(item)
+		}
+		return out
+	case []byte:
+		return map[string]any{"$bytes": base64.RawStdEncoding.EncodeToString(val)}
+	case cborLink:
+		return map[string]any{"$link": formatCID(val)}
+	default:
+		return val
+	}
+}
@@ -0,0 +1,62 @@
+package indexer
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestDecodeCBOR_RoundTrip(t *testing.T) {
+	link := cborLink{cidVersion1, 0x71, multihashSHA256, 0x01, 0xab}
+	input := map[string]any{
+		"text":   "hi",
+		"count":  300,
+		"offset": -5,
+		"ok":     true,
+		"none":   nil,
+		"blob":   []byte{1, 2, 3},
+		"ref":    link,
+		"list":   []any{"a", 1},
+	}
+
+	decoded, err := decodeCBOR(encodeTestCBOR(t, input))
+	if err != nil {
+		t.Fatalf("decodeCBOR failed: %v", err)
+	}
+	out, err := json.Marshal(cborToJSON(decoded))
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+
+	want := `{"blob":{"$bytes":"AQID"},"count":300,"list":["a",1],"none":null,"offset":-5,"ok":true,"ref":{"$link":"` + formatCID(link) + `"},"text":"hi"}`
+	if string(out) != want {
+		t.Errorf("unexpected JSON:\n got %s\nwant %s", out, want)
+	}
+}
+
+func TestDecodeCBOR_Malformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"trailing bytes", []byte{0x01, 0x02}},
+		{"indefinite map", []byte{0xbf, 0xff}},
+		{"integer map key", []byte{0xa1, 0x01, 0x02}},
+		{"duplicate map key", []byte{0xa2, 0x61, 'a', 0x01, 0x61, 'a', 0x02}},
+		{"string longer than input", []byte{0x65, 'a'}},
+		{"array longer than input", []byte{0x9a, 0xff, 0xff, 0xff, 0xff}},
+		{"non-CID tag", []byte{0xc1, 0x01}},
+		{"CID without identity prefix", []byte{0xd8, 0x2a, 0x42, 0x01, 0x71}},
+		{"undefined", []byte{0xf7}},
+		{"half float", []byte{0xf9, 0x3c, 0x00}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCBOR(tt.data); !errors.Is(err, ErrMalformedCBOR) {
+				t.Errorf("expected ErrMalformedCBOR, got %v", err)
+			}
+		})
+	}
+}
@@ -4,8 +4,12 @@ package indexer
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"log/slog"
 	"math/rand"
+	"net/url"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -13,13 +17,21 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+// ErrShuttingDown is returned when a connection is attempted after Shutdown.
+var ErrShuttingDown = errors.New("jetstream client is shutting down")
+
+// closeWriteTimeout bounds how long writing the close frame may block.
+const closeWriteTimeout = time.Second
+
 // MessageHandler is a callback function for processing incoming messages.
 // The handler receives the message type and payload.
-// Return an error to signal the client should disconnect.
+// Return an error to signal the client should disconnect, or a *RecordError
+// to dead-letter just this message when Config.DeadLetter is set.
 type MessageHandler func(messageType int, payload []byte) error
 
 // Client is a resilient WebSocket client for connecting to Jetstream.
-// It automatically reconnects with exponential backoff and jitter.
+// It automatically reconnects with exponential backoff and jitter, resetting
+// the backoff only once a connection has stayed up for StabilityThreshold.
 type Client struct {
 	config  Config
 	handler MessageHandler
@@ -30,8 +42,19 @@ type Client struct {
 	conn        *websocket.Conn
 	isConnected bool
 
-	// reconnectCount tracks consecutive reconnection attempts (atomic)
+	// shuttingDown is set by Shutdown; once set, no new messages are handed
+	// to the handler. Protected by mu, which also orders inflight.Add
+	// before Shutdown's Wait.
+	shuttingDown bool
+	shutdownCh   chan struct{}
+	inflight     sync.WaitGroup
+
+	// reconnectCount tracks consecutive failed or unstable connection
+	// attempts (atomic)
 	reconnectCount int64
+
+	// lastMessageAt is the UnixNano time of the last data message (atomic)
+	lastMessageAt int64
 }
 
 // NewClient creates a new Jetstream WebSocket client with the given configuration.
@@ -43,15 +66,21 @@ func NewClient(config Config, handler MessageHandler, logger *slog.Logger) (*Cli
 	if logger == nil {
 		logger = slog.Default()
 	}
+	rng := config.Rand
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
 	return &Client{
-		config:  config,
-		handler: handler,
-		logger:  logger,
-		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		config:     config,
+		handler:    handler,
+		logger:     logger,
+		rng:        rng,
+		shutdownCh: make(chan struct{}),
 	}, nil
 }
 
-// Run starts the WebSocket client and blocks until the context is cancelled.
+// Run starts the WebSocket client and blocks until the context is cancelled
+// or Shutdown is called, in which case it returns nil.
 // It will automatically reconnect with exponential backoff on connection failures.
 func (c *Client) Run(ctx context.Context) error {
 	for {
@@ -60,54 +89,90 @@ func (c *Client) Run(ctx context.Context) error {
 			c.logger.Info("jetstream client stopping due to context cancellation")
 			c.close()
 			return ctx.Err()
+		case <-c.shutdownCh:
+			c.logger.Info("jetstream client stopped after shutdown")
+			return nil
 		default:
 		}
 
 		// Attempt to connect
 		if err := c.connect(ctx); err != nil {
-			attempt := atomic.LoadInt64(&c.reconnectCount) + 1
 			c.logger.Warn("jetstream connection failed",
 				slog.String("error", err.Error()),
-				slog.Int64("attempt", attempt))
-
-			// Schedule reconnect with backoff
-			delay := c.computeBackoff()
-			atomic.AddInt64(&c.reconnectCount, 1)
-
-			c.logger.Info("scheduling reconnect",
-				slog.Duration("delay", delay),
-				slog.Int64("attempt", atomic.LoadInt64(&c.reconnectCount)))
+				slog.Int64("attempt", atomic.LoadInt64(&c.reconnectCount)+1))
 
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(delay):
-				continue
+			if stop, err := c.waitReconnect(ctx); stop {
+				return err
 			}
+			continue
 		}
 
-		// Reset reconnect count on successful connection
-		atomic.StoreInt64(&c.reconnectCount, 0)
-
 		// Read messages until connection closes
+		connectedAt := time.Now()
 		c.readLoop(ctx)
+
+		// Only a connection that stayed up past the stability threshold
+		// resets the backoff; a flapping endpoint keeps escalating it
+		uptime := time.Since(connectedAt)
+		if uptime >= c.config.StabilityThreshold {
+			atomic.StoreInt64(&c.reconnectCount, 0)
+			continue
+		}
+		c.logger.Warn("jetstream connection dropped before becoming stable",
+			slog.Duration("uptime", uptime),
+			slog.Int64("attempt", atomic.LoadInt64(&c.reconnectCount)+1))
+
+		if stop, err := c.waitReconnect(ctx); stop {
+			return err
+		}
+	}
+}
+
+// waitReconnect sleeps for the next backoff delay and counts the attempt.
+// It returns stop=true with the error Run should return if the context is
+// cancelled or Shutdown is called while waiting.
+func (c *Client) waitReconnect(ctx context.Context) (stop bool, err error) {
+	delay := c.computeBackoff()
+	atomic.AddInt64(&c.reconnectCount, 1)
+
+	c.logger.Info("scheduling reconnect",
+		slog.Duration("delay", delay),
+		slog.Int64("attempt", atomic.LoadInt64(&c.reconnectCount)))
+
+	select {
+	case <-ctx.Done():
+		c.close()
+		return true, ctx.Err()
+	case <-c.shutdownCh:
+		return true, nil
+	case <-time.After(delay):
+		return false, nil
 	}
 }
 
 // connect establishes a WebSocket connection to the Jetstream endpoint.
 func (c *Client) connect(ctx context.Context) error {
-	c.logger.Info("connecting to jetstream", slog.String("url", c.config.URL))
+	dialURL, err := c.dialURL()
+	if err != nil {
+		return err
+	}
+	c.logger.Info("connecting to jetstream", slog.String("url", dialURL))
 
 	dialer := websocket.Dialer{
 		HandshakeTimeout: 10 * time.Second,
 	}
 
-	conn, _, err := dialer.DialContext(ctx, c.config.URL, nil)
+	conn, _, err := dialer.DialContext(ctx, dialURL, nil)
 	if err != nil {
 		return err
 	}
 
 	c.mu.Lock()
+	if c.shuttingDown {
+		c.mu.Unlock()
+		_ = conn.Close()
+		return ErrShuttingDown
+	}
 	c.conn = conn
 	c.isConnected = true
 	c.mu.Unlock()
@@ -116,8 +181,86 @@ func (c *Client) connect(ctx context.Context) error {
 	return nil
 }
 
+// dialURL returns the endpoint URL with a wantedCollections query parameter
+// per configured collection, replacing any already in the URL, and a cursor
+// query parameter when a cursor store holds a saved position.
+func (c *Client) dialURL() (string, error) {
+	var cursor int64
+	if c.config.CursorStore != nil {
+		saved, err := c.config.CursorStore.Load()
+		if err != nil {
+			return "", err
+		}
+		cursor = saved
+	}
+	if cursor <= 0 && len(c.config.WantedCollections) == 0 {
+		return c.config.URL, nil
+	}
+
+	u, err := url.Parse(c.config.URL)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	if len(c.config.WantedCollections) > 0 {
+		query.Del("wantedCollections")
+		for _, collection := range c.config.WantedCollections {
+			query.Add("wantedCollections", collection)
+		}
+	}
+	if cursor > 0 {
+		query.Set("cursor", strconv.FormatInt(cursor, 10))
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// saveCursor persists the time_us of a processed message. Messages without
+// a time_us are ignored, and save failures are logged rather than treated as
+// disconnects since the next message will retry.
+func (c *Client) saveCursor(payload []byte) {
+	if c.config.CursorStore == nil {
+		return
+	}
+
+	var frame struct {
+		TimeUS int64 `json:"time_us"`
+	}
+	if err := json.Unmarshal(payload, &frame); err != nil || frame.TimeUS <= 0 {
+		return
+	}
+
+	if err := c.config.CursorStore.Save(frame.TimeUS); err != nil {
+		c.logger.Warn("failed to save jetstream cursor",
+			slog.String("error", err.Error()))
+	}
+}
+
+// pingWriteTimeout bounds how long a single ping write may block.
+const pingWriteTimeout = 5 * time.Second
+
 // readLoop reads messages from the WebSocket connection until it closes.
+// When ReadTimeout is set, every read must complete before the deadline;
+// pongs and data messages extend it, so a server that goes silent without
+// closing the socket is detected and triggers a reconnect.
 func (c *Client) readLoop(ctx context.Context) {
+	c.mu.Lock()
+	initialConn := c.conn
+	c.mu.Unlock()
+	if initialConn == nil {
+		return
+	}
+
+	if c.config.ReadTimeout > 0 {
+		initialConn.SetPongHandler(func(string) error {
+			return initialConn.SetReadDeadline(time.Now().Add(c.config.ReadTimeout))
+		})
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go c.keepalive(ctx, initialConn, stop)
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -135,6 +278,15 @@ func (c *Client) readLoop(ctx context.Context) {
 			return
 		}
 
+		if c.config.ReadTimeout > 0 {
+			if err := conn.SetReadDeadline(time.Now().Add(c.config.ReadTimeout)); err != nil {
+				c.logger.Warn("failed to set jetstream read deadline",
+					slog.String("error", err.Error()))
+				c.close()
+				return
+			}
+		}
+
 		messageType, payload, err := conn.ReadMessage()
 		if err != nil {
 			c.logger.Warn("jetstream connection closed",
@@ -143,13 +295,125 @@ func (c *Client) readLoop(ctx context.Context) {
 			return
 		}
 
-		// Process message through handler (without logging payload content)
-		if c.handler != nil {
-			if err := c.handler(messageType, payload); err != nil {
-				c.logger.Error("message handler error",
+		atomic.StoreInt64(&c.lastMessageAt, time.Now().UnixNano())
+
+		accepted, err := c.processMessage(messageType, payload)
+		if !accepted {
+			// Shutdown started; leave the message for the next run to replay
+			return
+		}
+		if err != nil {
+			c.logger.Error("message handler error",
+				slog.String("error", err.Error()))
+			c.close()
+			return
+		}
+	}
+}
+
+// processMessage hands a message to the handler and saves its cursor,
+// tracking the work as in flight so Shutdown can wait for it. It returns
+// accepted=false without processing once Shutdown has started. Messages
+// rejected with a RecordError are dead-lettered when a sink is configured
+// and their cursor is saved so they are not replayed.
+func (c *Client) processMessage(messageType int, payload []byte) (accepted bool, err error) {
+	c.mu.Lock()
+	if c.shuttingDown {
+		c.mu.Unlock()
+		return false, nil
+	}
+	c.inflight.Add(1)
+	c.mu.Unlock()
+	defer c.inflight.Done()
+
+	// Process message through handler (without logging payload content)
+	if c.handler != nil {
+		if err := c.handler(messageType, payload); err != nil {
+			if !IsRecordError(err) || c.config.DeadLetter == nil {
+				return true, err
+			}
+			if dlErr := c.config.DeadLetter.Put(DeadLetterEntry{
+				Payload:  payload,
+				Error:    err.Error(),
+				FailedAt: time.Now(),
+			}); dlErr != nil {
+				return true, errors.Join(err, dlErr)
+			}
+			c.logger.Warn("jetstream message dead-lettered",
+				slog.String("error", err.Error()))
+		}
+	}
+
+	c.saveCursor(payload)
+	return true, nil
+}
+
+// Shutdown stops the client gracefully. It stops handing new messages to
+// the handler, waits for the in-flight handler invocation to finish or for
+// ctx to be done, then sends a normal-closure close frame and closes the
+// socket. Run returns nil once shutdown has started. Returns ctx.Err() if
+// the handler did not finish in time; the socket is closed either way.
+func (c *Client) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	if !c.shuttingDown {
+		c.shuttingDown = true
+		close(c.shutdownCh)
+	}
+	c.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		c.inflight.Wait()
+		close(drained)
+	}()
+
+	var err error
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn != nil {
+		closeFrame := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "shutting down")
+		if writeErr := c.conn.WriteControl(websocket.CloseMessage, closeFrame, time.Now().Add(closeWriteTimeout)); writeErr != nil {
+			c.logger.Warn("failed to send jetstream close frame",
+				slog.String("error", writeErr.Error()))
+		}
+		_ = c.conn.Close()
+		c.conn = nil
+	}
+	c.isConnected = false
+
+	return err
+}
+
+// keepalive runs alongside readLoop until stop is closed. It closes the
+// connection when ctx is cancelled so a blocked read returns, and sends
+// WebSocket pings on conn every PingInterval. A failed ping is logged and
+// stops pinging; the read deadline then detects the dead connection.
+func (c *Client) keepalive(ctx context.Context, conn *websocket.Conn, stop <-chan struct{}) {
+	var tick <-chan time.Time
+	if c.config.PingInterval > 0 {
+		ticker := time.NewTicker(c.config.PingInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			c.close()
+			return
+		case <-tick:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(pingWriteTimeout)); err != nil {
+				c.logger.Warn("jetstream ping failed",
 					slog.String("error", err.Error()))
-				c.close()
-				return
+				tick = nil
 			}
 		}
 	}
@@ -196,6 +460,16 @@ func (c *Client) computeBackoff() time.Duration {
 	return time.Duration(backoff)
 }
 
+// LastMessageAt returns when the client last received a data message, or the
+// zero time if none has been received. Intended for health endpoints.
+func (c *Client) LastMessageAt() time.Time {
+	nanos := atomic.LoadInt64(&c.lastMessageAt)
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
 // IsConnected returns whether the client is currently connected.
 func (c *Client) IsConnected() bool {
 	c.mu.Lock()
@@ -2,7 +2,10 @@ package indexer
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"math/rand"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -286,6 +289,48 @@ func TestClient_ComputeBackoff_WithJitter(t *testing.T) {
 	}
 }
 
+func TestClient_ComputeBackoff_SeededRandIsDeterministic(t *testing.T) {
+	const jitter = 0.5
+	newSeededClient := func() *Client {
+		config := Config{
+			URL:          "wss://test.example.com",
+			BaseDelay:    100 * time.Millisecond,
+			MaxDelay:     1 * time.Second,
+			JitterFactor: jitter,
+			Rand:         rand.New(rand.NewSource(42)),
+		}
+		client, err := NewClient(config, nil, nil)
+		if err != nil {
+			t.Fatalf("NewClient() error = %v", err)
+		}
+		return client
+	}
+
+	first := newSeededClient()
+	second := newSeededClient()
+
+	// Attempts 4 and above are capped at MaxDelay before jitter is applied
+	for attempt := int64(0); attempt < 8; attempt++ {
+		atomic.StoreInt64(&first.reconnectCount, attempt)
+		atomic.StoreInt64(&second.reconnectCount, attempt)
+
+		got := first.computeBackoff()
+		if other := second.computeBackoff(); got != other {
+			t.Errorf("attempt %d: seeded clients disagree: %v vs %v", attempt, got, other)
+		}
+
+		delay := 100 * time.Millisecond << uint(attempt)
+		if delay > time.Second {
+			delay = time.Second
+		}
+		minExpected := time.Duration(float64(delay) * (1 - jitter/2))
+		maxExpected := time.Duration(float64(delay) * (1 + jitter/2))
+		if got < minExpected || got > maxExpected {
+			t.Errorf("attempt %d: computeBackoff() = %v, want in range [%v, %v]", attempt, got, minExpected, maxExpected)
+		}
+	}
+}
+
 func TestClient_ContextCancellation(t *testing.T) {
 	ms := newMockServer(0)
 	defer ms.Close()
@@ -401,3 +446,645 @@ func TestClient_ConnectionFailure_TriggersBackoff(t *testing.T) {
 		t.Errorf("expected at least 50ms elapsed due to backoff, got %v", elapsed)
 	}
 }
+
+// flappingServer accepts connections and closes each one as soon as it
+// opens, except the stableConn-th, which it holds for holdFor first, and any
+// after it, which it holds until the client closes them. Each connection's
+// accept time is sent on the returned channel.
+func flappingServer(t *testing.T, stableConn int, holdFor time.Duration) (*httptest.Server, <-chan time.Time) {
+	t.Helper()
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	accepted := make(chan time.Time, 64)
+	var connections int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		n := int(atomic.AddInt32(&connections, 1))
+		accepted <- time.Now()
+
+		switch {
+		case n < stableConn:
+			return
+		case n == stableConn:
+			time.Sleep(holdFor)
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	return server, accepted
+}
+
+func TestClient_UnstableConnectionsKeepBackingOff(t *testing.T) {
+	const (
+		baseDelay  = 10 * time.Millisecond
+		flaps      = 4
+		stableConn = flaps + 1
+	)
+	server, accepted := flappingServer(t, stableConn, 150*time.Millisecond)
+	defer server.Close()
+
+	config := Config{
+		URL:                "ws" + strings.TrimPrefix(server.URL, "http"),
+		BaseDelay:          baseDelay,
+		MaxDelay:           time.Second,
+		JitterFactor:       0,
+		StabilityThreshold: 50 * time.Millisecond,
+	}
+
+	client, err := NewClient(config, nil, slog.Default())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	go func() {
+		_ = client.Run(ctx)
+	}()
+
+	next := func() time.Time {
+		t.Helper()
+		select {
+		case at := <-accepted:
+			return at
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for a connection")
+			return time.Time{}
+		}
+	}
+
+	// Each connection that drops immediately doubles the delay before the next
+	prev := next()
+	for i := 0; i < flaps; i++ {
+		at := next()
+		if gap, want := at.Sub(prev), baseDelay<<uint(i); gap < want {
+			t.Errorf("reconnect %d after %v, want at least %v", i+1, gap, want)
+		}
+		prev = at
+	}
+
+	// Connecting alone does not reset the backoff
+	if got := atomic.LoadInt64(&client.reconnectCount); got != flaps {
+		t.Errorf("expected reconnect count %d while the connection is new, got %d", flaps, got)
+	}
+
+	// Once a connection outlives the threshold, the next one starts fresh
+	next()
+	if got := atomic.LoadInt64(&client.reconnectCount); got != 0 {
+		t.Errorf("expected reconnect count reset after a stable connection, got %d", got)
+	}
+}
+
+// failingCursorStore is a CursorStore whose Load always fails.
+type failingCursorStore struct{}
+
+func (failingCursorStore) Load() (int64, error) { return 0, errors.New("store unavailable") }
+func (failingCursorStore) Save(int64) error     { return nil }
+
+func TestClient_DialURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		cursor      int64
+		store       bool
+		collections []string
+		want        string
+	}{
+		{
+			name: "no cursor store",
+			url:  "wss://jetstream.example.com/subscribe",
+			want: "wss://jetstream.example.com/subscribe",
+		},
+		{
+			name:  "empty cursor store",
+			url:   "wss://jetstream.example.com/subscribe",
+			store: true,
+			want:  "wss://jetstream.example.com/subscribe",
+		},
+		{
+			name:   "stored cursor",
+			url:    "wss://jetstream.example.com/subscribe",
+			cursor: 1725911162329308,
+			store:  true,
+			want:   "wss://jetstream.example.com/subscribe?cursor=1725911162329308",
+		},
+		{
+			name:   "stored cursor with existing query",
+			url:    "wss://jetstream.example.com/subscribe?wantedCollections=app.subcult.scene",
+			cursor: 1725911162329308,
+			store:  true,
+			want:   "wss://jetstream.example.com/subscribe?cursor=1725911162329308&wantedCollections=app.subcult.scene",
+		},
+		{
+			name:        "one collection",
+			url:         "wss://jetstream.example.com/subscribe",
+			collections: []string{CollectionScene},
+			want:        "wss://jetstream.example.com/subscribe?wantedCollections=app.subcult.scene",
+		},
+		{
+			name:        "multiple collections with cursor",
+			url:         "wss://jetstream.example.com/subscribe",
+			cursor:      1725911162329308,
+			store:       true,
+			collections: []string{CollectionScene, CollectionEvent, "app.subcult.*"},
+			want:        "wss://jetstream.example.com/subscribe?cursor=1725911162329308&wantedCollections=app.subcult.scene&wantedCollections=app.subcult.event&wantedCollections=app.subcult.%2A",
+		},
+		{
+			name:        "collections replace those in the URL",
+			url:         "wss://jetstream.example.com/subscribe?wantedCollections=app.bsky.feed.post",
+			collections: []string{CollectionPost},
+			want:        "wss://jetstream.example.com/subscribe?wantedCollections=app.subcult.post",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig(tt.url)
+			config.WantedCollections = tt.collections
+			if tt.store {
+				store := NewInMemoryCursorStore()
+				if tt.cursor > 0 {
+					_ = store.Save(tt.cursor)
+				}
+				config.CursorStore = store
+			}
+
+			client, err := NewClient(config, nil, nil)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			got, err := client.dialURL()
+			if err != nil {
+				t.Fatalf("dialURL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("dialURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_DialURL_LoadError(t *testing.T) {
+	config := DefaultConfig("wss://jetstream.example.com/subscribe")
+	config.CursorStore = failingCursorStore{}
+
+	client, err := NewClient(config, nil, nil)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err := client.dialURL(); err == nil {
+		t.Error("expected dialURL() to fail when the cursor cannot be loaded")
+	}
+}
+
+func TestClient_ResumesFromSavedCursor(t *testing.T) {
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+
+	var mu sync.Mutex
+	var cursors []string
+	var nextTime int64 = 1000
+
+	// Each connection sends two frames with increasing time_us, then closes
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		cursors = append(cursors, r.URL.Query().Get("cursor"))
+		mu.Unlock()
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for i := 0; i < 2; i++ {
+			timeUS := atomic.AddInt64(&nextTime, 1)
+			frame := fmt.Sprintf(`{"did":"did:plc:abc123","time_us":%d,"kind":"commit"}`, timeUS)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(frame)); err != nil {
+				return
+			}
+		}
+		// Give the client time to process before closing
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	store := NewInMemoryCursorStore()
+	config := Config{
+		URL:          "ws" + strings.TrimPrefix(server.URL, "http"),
+		BaseDelay:    5 * time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		JitterFactor: 0,
+		CursorStore:  store,
+	}
+
+	client, err := NewClient(config, func(int, []byte) error { return nil }, slog.Default())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	_ = client.Run(ctx)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(cursors) < 2 {
+		t.Fatalf("expected at least 2 connections, got %d", len(cursors))
+	}
+	if cursors[0] != "" {
+		t.Errorf("expected first connection to start at the live edge, got cursor %q", cursors[0])
+	}
+	if cursors[1] != "1002" {
+		t.Errorf("expected second connection to resume from cursor 1002, got %q", cursors[1])
+	}
+
+	saved, _ := store.Load()
+	if saved <= 1002 {
+		t.Errorf("expected saved cursor to advance past 1002, got %d", saved)
+	}
+}
+
+func TestClient_ReconnectsWhenServerGoesSilent(t *testing.T) {
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+
+	var connections int32
+	release := make(chan struct{})
+
+	// Send one frame, then go silent without reading (so pings are never
+	// answered) and without closing the socket
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		atomic.AddInt32(&connections, 1)
+
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"test":"message"}`)); err != nil {
+			return
+		}
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	config := Config{
+		URL:          "ws" + strings.TrimPrefix(server.URL, "http"),
+		BaseDelay:    5 * time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		JitterFactor: 0,
+		ReadTimeout:  50 * time.Millisecond,
+		PingInterval: 20 * time.Millisecond,
+	}
+
+	client, err := NewClient(config, nil, slog.Default())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if !client.LastMessageAt().IsZero() {
+		t.Error("expected zero LastMessageAt before any message")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	_ = client.Run(ctx)
+
+	if got := atomic.LoadInt32(&connections); got < 2 {
+		t.Errorf("expected the read timeout to trigger a reconnect, got %d connections", got)
+	}
+	if last := client.LastMessageAt(); last.Before(started) {
+		t.Errorf("expected LastMessageAt after %v, got %v", started, last)
+	}
+}
+
+func TestClient_PingsKeepQuietConnectionAlive(t *testing.T) {
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+
+	var connections int32
+
+	// Send nothing, but keep reading so pings are answered with pongs
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		atomic.AddInt32(&connections, 1)
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	config := Config{
+		URL:          "ws" + strings.TrimPrefix(server.URL, "http"),
+		BaseDelay:    5 * time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		JitterFactor: 0,
+		ReadTimeout:  60 * time.Millisecond,
+		PingInterval: 15 * time.Millisecond,
+	}
+
+	client, err := NewClient(config, nil, slog.Default())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	_ = client.Run(ctx)
+
+	if got := atomic.LoadInt32(&connections); got != 1 {
+		t.Errorf("expected pongs to keep a single connection alive, got %d connections", got)
+	}
+}
+
+// closeRecordingServer sends one frame per connection and records the close
+// code of the first close frame received from the client.
+func closeRecordingServer(t *testing.T) (*httptest.Server, <-chan int) {
+	t.Helper()
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	closeCodes := make(chan int, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"test":"message"}`)); err != nil {
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				var closeErr *websocket.CloseError
+				if errors.As(err, &closeErr) {
+					select {
+					case closeCodes <- closeErr.Code:
+					default:
+					}
+				}
+				return
+			}
+		}
+	}))
+	return server, closeCodes
+}
+
+func TestClient_Shutdown_WaitsForInFlightHandler(t *testing.T) {
+	server, closeCodes := closeRecordingServer(t)
+	defer server.Close()
+
+	started := make(chan struct{})
+	var completed int32
+	handler := func(int, []byte) error {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		atomic.StoreInt32(&completed, 1)
+		return nil
+	}
+
+	config := Config{
+		URL:          "ws" + strings.TrimPrefix(server.URL, "http"),
+		BaseDelay:    5 * time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		JitterFactor: 0,
+	}
+	client, err := NewClient(config, handler, slog.Default())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- client.Run(context.Background())
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("handler was never invoked")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Shutdown(shutdownCtx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if atomic.LoadInt32(&completed) != 1 {
+		t.Error("expected in-flight handler to complete before Shutdown returned")
+	}
+
+	select {
+	case err := <-runErr:
+		if err != nil {
+			t.Errorf("Run() error = %v, want nil after Shutdown", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after Shutdown")
+	}
+
+	select {
+	case code := <-closeCodes:
+		if code != websocket.CloseNormalClosure {
+			t.Errorf("expected close code %d, got %d", websocket.CloseNormalClosure, code)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected server to receive a close frame")
+	}
+}
+
+func TestClient_Shutdown_DeadlineExceeded(t *testing.T) {
+	server, _ := closeRecordingServer(t)
+	defer server.Close()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	handler := func(int, []byte) error {
+		close(started)
+		<-release
+		return nil
+	}
+
+	config := Config{
+		URL:          "ws" + strings.TrimPrefix(server.URL, "http"),
+		BaseDelay:    5 * time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		JitterFactor: 0,
+	}
+	client, err := NewClient(config, handler, slog.Default())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	go func() {
+		_ = client.Run(context.Background())
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("handler was never invoked")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := client.Shutdown(shutdownCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if client.IsConnected() {
+		t.Error("expected socket to be closed after Shutdown")
+	}
+}
+
+// poisonServer sends three frames per connection, the second of which is a
+// poison record, then holds the connection open until the client closes it.
+// It returns the server and a connection counter.
+func poisonServer(t *testing.T) (*httptest.Server, *int32) {
+	t.Helper()
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	var connections int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		atomic.AddInt32(&connections, 1)
+
+		for i, kind := range []string{"good", "poison", "good"} {
+			frame := fmt.Sprintf(`{"did":"did:plc:abc123","time_us":%d,"kind":%q}`, 1001+i, kind)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(frame)); err != nil {
+				return
+			}
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	return server, &connections
+}
+
+// poisonHandler counts handled frames and rejects poison frames with reject.
+func poisonHandler(handled *int32, reject func() error) MessageHandler {
+	return func(_ int, payload []byte) error {
+		atomic.AddInt32(handled, 1)
+		if strings.Contains(string(payload), `"poison"`) {
+			return reject()
+		}
+		return nil
+	}
+}
+
+func TestClient_RecordErrorDeadLettered(t *testing.T) {
+	server, connections := poisonServer(t)
+	defer server.Close()
+
+	deadLetter := NewInMemoryDeadLetter()
+	store := NewInMemoryCursorStore()
+	config := Config{
+		URL:          "ws" + strings.TrimPrefix(server.URL, "http"),
+		BaseDelay:    5 * time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		JitterFactor: 0,
+		CursorStore:  store,
+		DeadLetter:   deadLetter,
+	}
+
+	var handled int32
+	handler := poisonHandler(&handled, func() error {
+		return &RecordError{Err: ErrMissingField}
+	})
+	client, err := NewClient(config, handler, slog.Default())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_ = client.Run(ctx)
+
+	if got := atomic.LoadInt32(connections); got != 1 {
+		t.Errorf("expected the client to stay on one connection, got %d", got)
+	}
+	if got := atomic.LoadInt32(&handled); got != 3 {
+		t.Errorf("expected all 3 frames handled, got %d", got)
+	}
+
+	entries := deadLetter.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 dead-lettered message, got %d", len(entries))
+	}
+	if !strings.Contains(string(entries[0].Payload), `"poison"`) {
+		t.Errorf("expected the poison payload, got %s", entries[0].Payload)
+	}
+	if !strings.Contains(entries[0].Error, ErrMissingField.Error()) {
+		t.Errorf("expected handler error recorded, got %q", entries[0].Error)
+	}
+
+	if saved, _ := store.Load(); saved != 1003 {
+		t.Errorf("expected cursor to advance past the poison message to 1003, got %d", saved)
+	}
+}
+
+func TestClient_FatalErrorReconnects(t *testing.T) {
+	tests := []struct {
+		name       string
+		deadLetter *InMemoryDeadLetter
+		reject     func() error
+	}{
+		{"fatal error with sink", NewInMemoryDeadLetter(), func() error { return errors.New("database unavailable") }},
+		{"record error without sink", nil, func() error { return &RecordError{Err: ErrMissingField} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, connections := poisonServer(t)
+			defer server.Close()
+
+			config := Config{
+				URL:          "ws" + strings.TrimPrefix(server.URL, "http"),
+				BaseDelay:    5 * time.Millisecond,
+				MaxDelay:     10 * time.Millisecond,
+				JitterFactor: 0,
+			}
+			if tt.deadLetter != nil {
+				config.DeadLetter = tt.deadLetter
+			}
+
+			var handled int32
+			client, err := NewClient(config, poisonHandler(&handled, tt.reject), slog.Default())
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			_ = client.Run(ctx)
+
+			if got := atomic.LoadInt32(connections); got < 2 {
+				t.Errorf("expected a reconnect after the handler error, got %d connections", got)
+			}
+			if tt.deadLetter != nil && len(tt.deadLetter.Entries()) != 0 {
+				t.Errorf("expected nothing dead-lettered, got %d entries", len(tt.deadLetter.Entries()))
+			}
+		})
+	}
+}
@@ -4,22 +4,40 @@ package indexer
 
 import (
 	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
 	"time"
 )
 
 // Default values for WebSocket reconnection configuration.
 const (
-	DefaultBaseDelay    = 100 * time.Millisecond
-	DefaultMaxDelay     = 30 * time.Second
-	DefaultJitterFactor = 0.5 // 50% jitter
+	DefaultBaseDelay          = 100 * time.Millisecond
+	DefaultMaxDelay           = 30 * time.Second
+	DefaultJitterFactor       = 0.5 // 50% jitter
+	DefaultReadTimeout        = 60 * time.Second
+	DefaultPingInterval       = 20 * time.Second
+	DefaultStabilityThreshold = 30 * time.Second
 )
 
+// MaxWantedCollections is the most collections Jetstream accepts in one
+// subscription.
+const MaxWantedCollections = 100
+
+// maxNSIDLength is the maximum length of an AT Protocol NSID.
+const maxNSIDLength = 317
+
 // Configuration errors.
 var (
-	ErrEmptyURL       = errors.New("jetstream URL cannot be empty")
-	ErrInvalidDelay   = errors.New("base delay must be positive")
-	ErrInvalidMaxDelay = errors.New("max delay must be >= base delay")
-	ErrInvalidJitter  = errors.New("jitter factor must be between 0 and 1")
+	ErrEmptyURL                  = errors.New("jetstream URL cannot be empty")
+	ErrInvalidDelay              = errors.New("base delay must be positive")
+	ErrInvalidMaxDelay           = errors.New("max delay must be >= base delay")
+	ErrInvalidJitter             = errors.New("jitter factor must be between 0 and 1")
+	ErrInvalidReadTimeout        = errors.New("read timeout must not be negative")
+	ErrInvalidPingInterval       = errors.New("ping interval must be non-negative and shorter than read timeout")
+	ErrInvalidStabilityThreshold = errors.New("stability threshold must not be negative")
+	ErrInvalidCollection         = errors.New("wanted collection must be a valid NSID")
+	ErrTooManyCollections        = errors.New("too many wanted collections")
 )
 
 // Config holds configuration for the Jetstream WebSocket client.
@@ -36,6 +54,43 @@ type Config struct {
 	// JitterFactor is the fraction of delay to randomize (0.0 to 1.0).
 	// A value of 0.5 means the actual delay will be in [delay*0.75, delay*1.25].
 	JitterFactor float64
+
+	// ReadTimeout is the longest the client waits for any frame, including
+	// pongs, before treating the connection as dead and reconnecting.
+	// Zero disables the read deadline.
+	ReadTimeout time.Duration
+
+	// PingInterval is how often the client sends WebSocket pings so that a
+	// live but quiet connection keeps extending the read deadline.
+	// Zero disables pings.
+	PingInterval time.Duration
+
+	// StabilityThreshold is how long a connection must stay up before the
+	// backoff resets. A connection that drops sooner counts as another
+	// failed attempt, so a flapping endpoint keeps backing off up to
+	// MaxDelay. Zero resets the backoff on every successful connect.
+	StabilityThreshold time.Duration
+
+	// Rand is the random source for backoff jitter. Optional; nil seeds a
+	// new source from the current time. Inject a fixed-seed source for
+	// deterministic tests; the client takes ownership and it must not be
+	// used elsewhere.
+	Rand *rand.Rand
+
+	// CursorStore persists the time_us of processed messages so reconnects
+	// resume where ingestion stopped. Optional; nil always starts at the live edge.
+	CursorStore CursorStore
+
+	// DeadLetter receives messages whose handler returned a *RecordError,
+	// and the client keeps reading instead of reconnecting. Optional; nil
+	// treats every handler error as fatal.
+	DeadLetter DeadLetter
+
+	// WantedCollections limits the subscription to these collection NSIDs,
+	// sent as wantedCollections query parameters so Jetstream drops other
+	// records server-side. A trailing ".*" matches a namespace prefix, as in
+	// "app.subcult.*". Optional; empty subscribes to every collection.
+	WantedCollections []string
 }
 
 // DefaultConfig returns a Config with sensible default values.
@@ -46,6 +101,11 @@ func DefaultConfig(url string) Config {
 		BaseDelay:    DefaultBaseDelay,
 		MaxDelay:     DefaultMaxDelay,
 		JitterFactor: DefaultJitterFactor,
+		ReadTimeout:  DefaultReadTimeout,
+		PingInterval: DefaultPingInterval,
+
+		StabilityThreshold: DefaultStabilityThreshold,
+		WantedCollections:  DefaultCollections(),
 	}
 }
 
@@ -63,5 +123,88 @@ func (c Config) Validate() error {
 	if c.JitterFactor < 0 || c.JitterFactor > 1 {
 		return ErrInvalidJitter
 	}
+	if c.ReadTimeout < 0 {
+		return ErrInvalidReadTimeout
+	}
+	if c.PingInterval < 0 || (c.ReadTimeout > 0 && c.PingInterval >= c.ReadTimeout) {
+		return ErrInvalidPingInterval
+	}
+	if c.StabilityThreshold < 0 {
+		return ErrInvalidStabilityThreshold
+	}
+	if len(c.WantedCollections) > MaxWantedCollections {
+		return ErrTooManyCollections
+	}
+	for _, collection := range c.WantedCollections {
+		if !validCollectionNSID(collection) {
+			return fmt.Errorf("%w: %q", ErrInvalidCollection, collection)
+		}
+	}
 	return nil
 }
+
+// validCollectionNSID reports whether s is an NSID such as
+// "app.subcult.scene", or an NSID prefix ending in ".*". An NSID has at least
+// three dot-separated segments: domain authority segments of letters, digits,
+// and inner hyphens, followed by a name of letters and digits that starts
+// with a letter.
+func validCollectionNSID(s string) bool {
+	if len(s) > maxNSIDLength {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(s, ".*"); ok {
+		// A wildcard needs at least a two-segment authority before it
+		segments := strings.Split(prefix, ".")
+		if len(segments) < 2 {
+			return false
+		}
+		for _, segment := range segments {
+			if !validNSIDAuthoritySegment(segment) {
+				return false
+			}
+		}
+		return true
+	}
+
+	segments := strings.Split(s, ".")
+	if len(segments) < 3 {
+		return false
+	}
+	for _, segment := range segments[:len(segments)-1] {
+		if !validNSIDAuthoritySegment(segment) {
+			return false
+		}
+	}
+	name := segments[len(segments)-1]
+	if name == "" || len(name) > 63 || !isASCIILetter(name[0]) {
+		return false
+	}
+	for i := 1; i < len(name); i++ {
+		if !isASCIILetter(name[i]) && !isASCIIDigit(name[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// validNSIDAuthoritySegment reports whether s is a valid domain segment of
+// an NSID authority.
+func validNSIDAuthoritySegment(s string) bool {
+	if s == "" || len(s) > 63 || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isASCIILetter(s[i]) && !isASCIIDigit(s[i]) && s[i] != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isASCIIDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -1,6 +1,12 @@
 package indexer
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
 
 func TestConfig_Validate(t *testing.T) {
 	tests := []struct {
@@ -103,6 +109,47 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "negative read timeout",
+			config: Config{
+				URL:         "wss://test.example.com",
+				BaseDelay:   100,
+				MaxDelay:    200,
+				ReadTimeout: -1,
+			},
+			wantErr: ErrInvalidReadTimeout,
+		},
+		{
+			name: "ping interval not shorter than read timeout",
+			config: Config{
+				URL:          "wss://test.example.com",
+				BaseDelay:    100,
+				MaxDelay:     200,
+				ReadTimeout:  time.Second,
+				PingInterval: time.Second,
+			},
+			wantErr: ErrInvalidPingInterval,
+		},
+		{
+			name: "pings without read timeout",
+			config: Config{
+				URL:          "wss://test.example.com",
+				BaseDelay:    100,
+				MaxDelay:     200,
+				PingInterval: time.Second,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "negative stability threshold",
+			config: Config{
+				URL:                "wss://test.example.com",
+				BaseDelay:          100,
+				MaxDelay:           200,
+				StabilityThreshold: -time.Second,
+			},
+			wantErr: ErrInvalidStabilityThreshold,
+		},
 	}
 
 	for _, tt := range tests {
@@ -131,4 +178,64 @@ func TestDefaultConfig(t *testing.T) {
 	if config.JitterFactor != DefaultJitterFactor {
 		t.Errorf("DefaultConfig().JitterFactor = %v, want %v", config.JitterFactor, DefaultJitterFactor)
 	}
+	if config.ReadTimeout != DefaultReadTimeout {
+		t.Errorf("DefaultConfig().ReadTimeout = %v, want %v", config.ReadTimeout, DefaultReadTimeout)
+	}
+	if config.PingInterval != DefaultPingInterval {
+		t.Errorf("DefaultConfig().PingInterval = %v, want %v", config.PingInterval, DefaultPingInterval)
+	}
+	if config.StabilityThreshold != DefaultStabilityThreshold {
+		t.Errorf("DefaultConfig().StabilityThreshold = %v, want %v", config.StabilityThreshold, DefaultStabilityThreshold)
+	}
+	if !slices.Equal(config.WantedCollections, DefaultCollections()) {
+		t.Errorf("DefaultConfig().WantedCollections = %v, want %v", config.WantedCollections, DefaultCollections())
+	}
+}
+
+func TestConfig_Validate_WantedCollections(t *testing.T) {
+	tooMany := make([]string, MaxWantedCollections+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("app.subcult.record%d", i)
+	}
+
+	tests := []struct {
+		name        string
+		collections []string
+		wantErr     error
+	}{
+		{"none", nil, nil},
+		{"single", []string{CollectionScene}, nil},
+		{"multiple", []string{CollectionScene, CollectionEvent, "com.example.fooBar"}, nil},
+		{"hyphenated authority", []string{"app.sub-cult.scene"}, nil},
+		{"namespace wildcard", []string{"app.subcult.*"}, nil},
+		{"two segments", []string{"app.scene"}, ErrInvalidCollection},
+		{"empty segment", []string{"app..subcult.scene"}, ErrInvalidCollection},
+		{"trailing dot", []string{"app.subcult."}, ErrInvalidCollection},
+		{"hyphen in name", []string{"app.subcult.scene-record"}, ErrInvalidCollection},
+		{"name starts with digit", []string{"app.subcult.1scene"}, ErrInvalidCollection},
+		{"leading hyphen", []string{"app.-subcult.scene"}, ErrInvalidCollection},
+		{"bare wildcard", []string{"*"}, ErrInvalidCollection},
+		{"one-segment wildcard", []string{"app.*"}, ErrInvalidCollection},
+		{"inner wildcard", []string{"app.*.scene"}, ErrInvalidCollection},
+		{"whitespace", []string{"app.subcult.scene "}, ErrInvalidCollection},
+		{"valid then malformed", []string{CollectionScene, "not an nsid"}, ErrInvalidCollection},
+		{"too many", tooMany, ErrTooManyCollections},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig("wss://jetstream.example.com")
+			config.WantedCollections = tt.collections
+			err := config.Validate()
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("Config.Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Config.Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
 }
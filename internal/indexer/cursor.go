@@ -0,0 +1,44 @@
+// Package indexer provides filtering and processing of AT Protocol records
+// for the Subcults Jetstream indexer.
+package indexer
+
+import (
+	"sync"
+)
+
+// CursorStore persists the Jetstream cursor (the time_us of the last
+// processed event) so ingestion can resume after a reconnect or restart.
+type CursorStore interface {
+	// Load returns the saved cursor, or 0 if none has been saved.
+	Load() (int64, error)
+
+	// Save stores the latest processed cursor.
+	Save(cursor int64) error
+}
+
+// InMemoryCursorStore is an in-memory implementation of CursorStore.
+// Thread-safe via RWMutex. Cursors do not survive process restarts.
+type InMemoryCursorStore struct {
+	mu     sync.RWMutex
+	cursor int64
+}
+
+// NewInMemoryCursorStore creates a new in-memory cursor store.
+func NewInMemoryCursorStore() *InMemoryCursorStore {
+	return &InMemoryCursorStore{}
+}
+
+// Load returns the saved cursor, or 0 if none has been saved.
+func (s *InMemoryCursorStore) Load() (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cursor, nil
+}
+
+// Save stores the latest processed cursor.
+func (s *InMemoryCursorStore) Save(cursor int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursor = cursor
+	return nil
+}
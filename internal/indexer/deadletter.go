@@ -0,0 +1,76 @@
+// Package indexer provides filtering and processing of AT Protocol records
+// for the Subcults Jetstream indexer.
+package indexer
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// RecordError marks a handler error as specific to one message, such as a
+// record that fails validation. The client dead-letters such messages and
+// keeps reading when a DeadLetter sink is configured; any other handler
+// error is treated as fatal and triggers a reconnect.
+type RecordError struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RecordError) Error() string {
+	return "record error: " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// IsRecordError reports whether err is or wraps a *RecordError.
+func IsRecordError(err error) bool {
+	var recordErr *RecordError
+	return errors.As(err, &recordErr)
+}
+
+// DeadLetterEntry is a message the handler could not process.
+type DeadLetterEntry struct {
+	Payload  []byte    // Raw message payload
+	Error    string    // Handler error message
+	FailedAt time.Time // When the handler rejected the message
+}
+
+// DeadLetter stores messages rejected with a RecordError so they can be
+// inspected or replayed without blocking ingestion.
+type DeadLetter interface {
+	// Put stores a rejected message.
+	Put(entry DeadLetterEntry) error
+}
+
+// InMemoryDeadLetter is an in-memory implementation of DeadLetter.
+// Thread-safe via RWMutex. Entries do not survive process restarts.
+type InMemoryDeadLetter struct {
+	mu      sync.RWMutex
+	entries []DeadLetterEntry
+}
+
+// NewInMemoryDeadLetter creates a new in-memory dead-letter sink.
+func NewInMemoryDeadLetter() *InMemoryDeadLetter {
+	return &InMemoryDeadLetter{}
+}
+
+// Put stores a rejected message.
+func (d *InMemoryDeadLetter) Put(entry DeadLetterEntry) error {
+	entry.Payload = append([]byte(nil), entry.Payload...)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.entries = append(d.entries, entry)
+	return nil
+}
+
+// Entries returns the stored messages, oldest first.
+func (d *InMemoryDeadLetter) Entries() []DeadLetterEntry {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return append([]DeadLetterEntry(nil), d.entries...)
+}
@@ -0,0 +1,44 @@
+package indexer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIsRecordError(t *testing.T) {
+	recordErr := &RecordError{Err: ErrMissingField}
+
+	if !IsRecordError(recordErr) {
+		t.Error("expected *RecordError to be a record error")
+	}
+	if !IsRecordError(fmt.Errorf("scene: %w", recordErr)) {
+		t.Error("expected wrapped *RecordError to be a record error")
+	}
+	if IsRecordError(ErrMissingField) {
+		t.Error("expected plain errors not to be record errors")
+	}
+	if !errors.Is(recordErr, ErrMissingField) {
+		t.Error("expected RecordError to unwrap to its cause")
+	}
+}
+
+func TestInMemoryDeadLetter_CopiesPayload(t *testing.T) {
+	sink := NewInMemoryDeadLetter()
+	payload := []byte(`{"kind":"commit"}`)
+
+	if err := sink.Put(DeadLetterEntry{Payload: payload, Error: "bad record", FailedAt: time.Now()}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	// Callers may reuse the payload buffer, so the sink must keep its own copy
+	payload[0] = 'x'
+
+	entries := sink.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	if string(entries[0].Payload) != `{"kind":"commit"}` {
+		t.Errorf("expected stored payload unchanged, got %s", entries[0].Payload)
+	}
+}
@@ -0,0 +1,165 @@
+// Package indexer provides filtering and processing of AT Protocol records
+// for the Subcults Jetstream indexer.
+package indexer
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Jetstream event kinds. Only commit events carry repository records.
+const (
+	KindCommit   = "commit"
+	KindIdentity = "identity"
+	KindAccount  = "account"
+)
+
+// Commit operations reported by Jetstream.
+const (
+	OperationCreate = "create"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+// Errors for Jetstream frame decoding.
+var (
+	ErrUnknownOperation = errors.New("unknown commit operation")
+)
+
+// Commit is a decoded Jetstream commit for a single record.
+// Record is empty for delete operations.
+type Commit struct {
+	DID        string          // Repository DID that authored the commit
+	TimeUS     int64           // Jetstream event time in microseconds since epoch
+	Collection string          // Record collection (e.g., "app.subcult.scene")
+	RKey       string          // Record key within the collection
+	Operation  string          // One of OperationCreate, OperationUpdate, OperationDelete
+	Rev        string          // Repository revision
+	CID        string          // Record CID; empty for deletes
+	Record     json.RawMessage // Raw record JSON; nil for deletes
+}
+
+// jetstreamFrame mirrors the JSON shape of a Jetstream event.
+type jetstreamFrame struct {
+	DID    string           `json:"did"`
+	TimeUS int64            `json:"time_us"`
+	Kind   string           `json:"kind"`
+	Commit *jetstreamCommit `json:"commit"`
+}
+
+// jetstreamCommit mirrors the commit object nested in a Jetstream event.
+type jetstreamCommit struct {
+	Rev        string          `json:"rev"`
+	Operation  string          `json:"operation"`
+	Collection string          `json:"collection"`
+	RKey       string          `json:"rkey"`
+	Record     json.RawMessage `json:"record"`
+	CID        string          `json:"cid"`
+}
+
+// Decoder unmarshals Jetstream JSON frames into typed commits.
+type Decoder struct{}
+
+// NewDecoder creates a new Jetstream frame decoder.
+func NewDecoder() *Decoder {
+	return &Decoder{}
+}
+
+// Decode parses a Jetstream frame. It returns nil, nil for non-commit events
+// such as identity and account updates, which carry no records.
+// Create and update commits must include a record; deletes never do.
+func (d *Decoder) Decode(payload []byte) (*Commit, error) {
+	var frame jetstreamFrame
+	if err := json.Unmarshal(payload, &frame); err != nil {
+		return nil, ErrMalformedJSON
+	}
+
+	if frame.Kind != KindCommit {
+		return nil, nil
+	}
+	if frame.DID == "" || frame.Commit == nil || frame.Commit.Collection == "" || frame.Commit.RKey == "" {
+		return nil, ErrMissingField
+	}
+
+	commit := &Commit{
+		DID:        frame.DID,
+		TimeUS:     frame.TimeUS,
+		Collection: frame.Commit.Collection,
+		RKey:       frame.Commit.RKey,
+		Operation:  frame.Commit.Operation,
+		Rev:        frame.Commit.Rev,
+		CID:        frame.Commit.CID,
+	}
+
+	switch commit.Operation {
+	case OperationCreate, OperationUpdate:
+		if len(frame.Commit.Record) == 0 || string(frame.Commit.Record) == "null" {
+			return nil, ErrMissingField
+		}
+		commit.Record = frame.Commit.Record
+	case OperationDelete:
+		// Deletes identify the record by collection and rkey only
+	default:
+		return nil, ErrUnknownOperation
+	}
+
+	return commit, nil
+}
+
+// Filter selects which collections the indexer processes.
+// The zero value keeps every collection in the app.subcult.* namespace.
+type Filter struct {
+	collections map[string]bool
+}
+
+// NewFilter creates a filter that keeps only the given collections.
+// Collections outside the app.subcult.* namespace are never kept.
+func NewFilter(collections ...string) Filter {
+	f := Filter{collections: make(map[string]bool, len(collections))}
+	for _, c := range collections {
+		f.collections[c] = true
+	}
+	return f
+}
+
+// DefaultCollections returns the Subcults scene, event, and post collections.
+func DefaultCollections() []string {
+	return []string{CollectionScene, CollectionEvent, CollectionPost}
+}
+
+// DefaultFilter keeps the Subcults scene, event, and post collections.
+func DefaultFilter() Filter {
+	return NewFilter(DefaultCollections()...)
+}
+
+// Allows reports whether records in collection should be processed.
+func (f Filter) Allows(collection string) bool {
+	if !MatchesLexicon(collection) {
+		return false
+	}
+	if len(f.collections) == 0 {
+		return true
+	}
+	return f.collections[collection]
+}
+
+// ProcessMessage decodes a Jetstream frame and applies f. It returns nil, nil
+// for non-commit events and for commits in collections f does not allow.
+// Records from create and update commits are validated against their
+// collection's required fields before being returned.
+func ProcessMessage(payload []byte, f Filter) (*Commit, error) {
+	commit, err := NewDecoder().Decode(payload)
+	if err != nil {
+		return nil, err
+	}
+	if commit == nil || !f.Allows(commit.Collection) {
+		return nil, nil
+	}
+
+	if commit.Operation != OperationDelete {
+		if err := validateRecord(commit.Collection, commit.Record); err != nil {
+			return nil, err
+		}
+	}
+	return commit, nil
+}
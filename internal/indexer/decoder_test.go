@@ -0,0 +1,262 @@
+package indexer
+
+import (
+	"errors"
+	"testing"
+)
+
+const (
+	sceneCreateFrame = `{
+		"did": "did:plc:abc123",
+		"time_us": 1725911162329308,
+		"kind": "commit",
+		"commit": {
+			"rev": "3l3qo2vutsw2b",
+			"operation": "create",
+			"collection": "app.subcult.scene",
+			"rkey": "3l3qo2vuowo2b",
+			"record": {"$type": "app.subcult.scene", "name": "Underground Jazz"},
+			"cid": "bafyreidwaivazkwu67xztlmuobx35hs2lnfh3kolmgfmucldvhd3sgzcqi"
+		}
+	}`
+	eventDeleteFrame = `{
+		"did": "did:plc:abc123",
+		"time_us": 1725911162329309,
+		"kind": "commit",
+		"commit": {
+			"rev": "3l3qo2vutsw2c",
+			"operation": "delete",
+			"collection": "app.subcult.event",
+			"rkey": "3l3qo2vuowo2c"
+		}
+	}`
+	bskyPostFrame = `{
+		"did": "did:plc:xyz789",
+		"time_us": 1725911162329310,
+		"kind": "commit",
+		"commit": {
+			"rev": "3l3qo2vutsw2d",
+			"operation": "create",
+			"collection": "app.bsky.feed.post",
+			"rkey": "3l3qo2vuowo2d",
+			"record": {"$type": "app.bsky.feed.post", "text": "hello"},
+			"cid": "bafyreib2rxk3rh6kzwq"
+		}
+	}`
+	identityFrame = `{
+		"did": "did:plc:abc123",
+		"time_us": 1725911162329311,
+		"kind": "identity",
+		"identity": {"did": "did:plc:abc123", "handle": "jazz.example.com", "seq": 1}
+	}`
+)
+
+func TestDecoder_Decode(t *testing.T) {
+	decoder := NewDecoder()
+
+	t.Run("create commit", func(t *testing.T) {
+		commit, err := decoder.Decode([]byte(sceneCreateFrame))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if commit == nil {
+			t.Fatal("expected commit, got nil")
+		}
+		if commit.DID != "did:plc:abc123" {
+			t.Errorf("expected DID did:plc:abc123, got %s", commit.DID)
+		}
+		if commit.Collection != CollectionScene {
+			t.Errorf("expected collection %s, got %s", CollectionScene, commit.Collection)
+		}
+		if commit.RKey != "3l3qo2vuowo2b" {
+			t.Errorf("expected rkey 3l3qo2vuowo2b, got %s", commit.RKey)
+		}
+		if commit.Operation != OperationCreate {
+			t.Errorf("expected operation create, got %s", commit.Operation)
+		}
+		if commit.TimeUS != 1725911162329308 {
+			t.Errorf("expected time_us 1725911162329308, got %d", commit.TimeUS)
+		}
+		if len(commit.Record) == 0 {
+			t.Error("expected record to be set")
+		}
+	})
+
+	t.Run("delete commit", func(t *testing.T) {
+		commit, err := decoder.Decode([]byte(eventDeleteFrame))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if commit == nil {
+			t.Fatal("expected commit, got nil")
+		}
+		if commit.Operation != OperationDelete {
+			t.Errorf("expected operation delete, got %s", commit.Operation)
+		}
+		if commit.Record != nil {
+			t.Errorf("expected no record for delete, got %s", commit.Record)
+		}
+	})
+
+	t.Run("non-commit event", func(t *testing.T) {
+		commit, err := decoder.Decode([]byte(identityFrame))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if commit != nil {
+			t.Errorf("expected nil commit for identity event, got %+v", commit)
+		}
+	})
+}
+
+func TestDecoder_DecodeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		wantErr error
+	}{
+		{
+			name:    "malformed JSON",
+			payload: `{"did": "did:plc:abc123", "kind": "commit"`,
+			wantErr: ErrMalformedJSON,
+		},
+		{
+			name:    "missing commit object",
+			payload: `{"did": "did:plc:abc123", "kind": "commit"}`,
+			wantErr: ErrMissingField,
+		},
+		{
+			name:    "missing DID",
+			payload: `{"kind": "commit", "commit": {"operation": "delete", "collection": "app.subcult.scene", "rkey": "abc"}}`,
+			wantErr: ErrMissingField,
+		},
+		{
+			name:    "missing rkey",
+			payload: `{"did": "did:plc:abc123", "kind": "commit", "commit": {"operation": "delete", "collection": "app.subcult.scene"}}`,
+			wantErr: ErrMissingField,
+		},
+		{
+			name:    "create without record",
+			payload: `{"did": "did:plc:abc123", "kind": "commit", "commit": {"operation": "create", "collection": "app.subcult.scene", "rkey": "abc"}}`,
+			wantErr: ErrMissingField,
+		},
+		{
+			name:    "update with null record",
+			payload: `{"did": "did:plc:abc123", "kind": "commit", "commit": {"operation": "update", "collection": "app.subcult.scene", "rkey": "abc", "record": null}}`,
+			wantErr: ErrMissingField,
+		},
+		{
+			name:    "unknown operation",
+			payload: `{"did": "did:plc:abc123", "kind": "commit", "commit": {"operation": "archive", "collection": "app.subcult.scene", "rkey": "abc"}}`,
+			wantErr: ErrUnknownOperation,
+		},
+	}
+
+	decoder := NewDecoder()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commit, err := decoder.Decode([]byte(tt.payload))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if commit != nil {
+				t.Errorf("expected nil commit on error, got %+v", commit)
+			}
+		})
+	}
+}
+
+func TestFilter_Allows(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     Filter
+		collection string
+		want       bool
+	}{
+		{"default keeps scene", DefaultFilter(), CollectionScene, true},
+		{"default keeps event", DefaultFilter(), CollectionEvent, true},
+		{"default drops unknown subcult collection", DefaultFilter(), "app.subcult.custom", false},
+		{"default drops bsky", DefaultFilter(), "app.bsky.feed.post", false},
+		{"custom keeps listed collection", NewFilter(CollectionEvent), CollectionEvent, true},
+		{"custom drops unlisted collection", NewFilter(CollectionEvent), CollectionScene, false},
+		{"custom never keeps foreign namespace", NewFilter("app.bsky.feed.post"), "app.bsky.feed.post", false},
+		{"zero value keeps any subcult collection", Filter{}, "app.subcult.custom", true},
+		{"zero value drops bsky", Filter{}, "app.bsky.feed.post", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Allows(tt.collection); got != tt.want {
+				t.Errorf("Allows(%q) = %v, want %v", tt.collection, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessMessage(t *testing.T) {
+	tests := []struct {
+		name          string
+		payload       string
+		filter        Filter
+		wantCommit    bool
+		wantOperation string
+		wantErr       error
+	}{
+		{
+			name:          "scene create is kept",
+			payload:       sceneCreateFrame,
+			filter:        DefaultFilter(),
+			wantCommit:    true,
+			wantOperation: OperationCreate,
+		},
+		{
+			name:          "event delete is kept",
+			payload:       eventDeleteFrame,
+			filter:        DefaultFilter(),
+			wantCommit:    true,
+			wantOperation: OperationDelete,
+		},
+		{
+			name:    "bsky post is filtered out",
+			payload: bskyPostFrame,
+			filter:  DefaultFilter(),
+		},
+		{
+			name:    "collection excluded by filter",
+			payload: sceneCreateFrame,
+			filter:  NewFilter(CollectionEvent),
+		},
+		{
+			name:    "identity event is skipped",
+			payload: identityFrame,
+			filter:  DefaultFilter(),
+		},
+		{
+			name:    "invalid scene record",
+			payload: `{"did": "did:plc:abc123", "kind": "commit", "commit": {"operation": "create", "collection": "app.subcult.scene", "rkey": "abc", "record": {"description": "no name"}}}`,
+			filter:  DefaultFilter(),
+			wantErr: ErrMissingField,
+		},
+		{
+			name:    "malformed frame",
+			payload: `not json`,
+			filter:  DefaultFilter(),
+			wantErr: ErrMalformedJSON,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commit, err := ProcessMessage([]byte(tt.payload), tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if (commit != nil) != tt.wantCommit {
+				t.Fatalf("expected commit=%v, got %+v", tt.wantCommit, commit)
+			}
+			if commit != nil && commit.Operation != tt.wantOperation {
+				t.Errorf("expected operation %s, got %s", tt.wantOperation, commit.Operation)
+			}
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"strings"
 	"sync/atomic"
+
+	"github.com/onnwee/subcults/internal/geo"
 )
 
 // LexiconPrefix is the namespace prefix for Subcults domain records.
@@ -116,7 +118,7 @@ func (f *RecordFilter) Filter(collection string, payload []byte) FilterResult {
 	f.metrics.incMatched()
 
 	// Validate the JSON payload based on collection type
-	if err := f.validateRecord(collection, payload); err != nil {
+	if err := validateRecord(collection, payload); err != nil {
 		result.Valid = false
 		result.Error = err
 		f.metrics.incDiscarded()
@@ -129,7 +131,7 @@ func (f *RecordFilter) Filter(collection string, payload []byte) FilterResult {
 }
 
 // validateRecord validates the JSON payload based on the collection type.
-func (f *RecordFilter) validateRecord(collection string, payload []byte) error {
+func validateRecord(collection string, payload []byte) error {
 	switch collection {
 	case CollectionScene:
 		return validateSceneRecord(payload)
@@ -165,11 +167,17 @@ func validateStringField(record map[string]interface{}, fieldName string) error
 }
 
 // SceneRecord documents the expected structure of an app.subcult.scene record.
-// Note: This type is for documentation only. Validation uses map-based checking
-// to allow extra fields while enforcing required field presence and types.
+// Note: Validation uses map-based checking to allow extra fields while
+// enforcing required field presence and types; Processor decodes validated
+// records into this type.
 type SceneRecord struct {
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
+	Name          string     `json:"name"`
+	Description   string     `json:"description,omitempty"`
+	Tags          []string   `json:"tags,omitempty"`
+	Visibility    string     `json:"visibility,omitempty"` // Defaults to public
+	AllowPrecise  bool       `json:"allowPrecise,omitempty"`
+	PrecisePoint  *geo.Point `json:"precisePoint,omitempty"`
+	CoarseGeohash string     `json:"coarseGeohash,omitempty"` // Derived from PrecisePoint when set
 }
 
 // validateSceneRecord validates a scene record's required fields.
@@ -0,0 +1,251 @@
+// Package indexer provides filtering and processing of AT Protocol records
+// for the Subcults Jetstream indexer.
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onnwee/subcults/internal/geo"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/search"
+)
+
+// Processor persists decoded commits into the application repositories.
+// Records are keyed by their repository DID and rkey. Commits for
+// collections the processor does not persist are ignored.
+type Processor struct {
+	scenes scene.SceneRepository
+	events scene.EventRepository
+	index  *search.Indexer
+	logger *slog.Logger
+
+	// mu serializes commits so the staleness check and the write that
+	// follows it cannot interleave with another commit for the same record
+	mu sync.Mutex
+}
+
+// NewProcessor creates a processor that upserts scene records into scenes.
+// If logger is nil, slog.Default() is used.
+func NewProcessor(scenes scene.SceneRepository, logger *slog.Logger) *Processor {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Processor{
+		scenes: scenes,
+		logger: logger,
+	}
+}
+
+// SetEventRepository enables cascading scene deletes to the scene's events,
+// as DELETE /scenes/{id} does.
+func (p *Processor) SetEventRepository(events scene.EventRepository) {
+	p.events = events
+}
+
+// SetSearchIndex keeps the given full-text index updated as scene records
+// are created, updated, and deleted.
+func (p *Processor) SetSearchIndex(index *search.Indexer) {
+	p.index = index
+}
+
+// processOutcome reports what applying a commit did.
+type processOutcome int
+
+const (
+	outcomeSkipped processOutcome = iota
+	outcomeCreated
+	outcomeUpdated
+	outcomeDeleted
+)
+
+// Process applies a commit returned by ProcessMessage. Creates and updates
+// upsert the scene for (DID, rkey); deletes soft-delete it along with its
+// events when an event repository is set. A search index, when set, is
+// updated to match. Commits that are
+// not newer than the stored scene's last change, by commit time, are skipped
+// so out-of-order or replayed delivery cannot roll a record back or
+// resurrect it.
+func (p *Processor) Process(ctx context.Context, commit *Commit) error {
+	_, err := p.process(ctx, commit)
+	return err
+}
+
+// process applies commit and reports its outcome.
+func (p *Processor) process(ctx context.Context, commit *Commit) (processOutcome, error) {
+	if commit == nil || commit.Collection != CollectionScene {
+		return outcomeSkipped, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	commitTime := time.UnixMicro(commit.TimeUS).UTC()
+	existing, err := p.scenes.GetByRecordKey(ctx, commit.DID, commit.RKey)
+	if err != nil && err != scene.ErrSceneNotFound {
+		return outcomeSkipped, fmt.Errorf("look up scene record: %w", err)
+	}
+	if existing != nil && !commitTime.After(lastChanged(existing)) {
+		p.logger.Debug("skipping stale scene commit",
+			"did", commit.DID, "rkey", commit.RKey, "operation", commit.Operation, "time_us", commit.TimeUS)
+		return outcomeSkipped, nil
+	}
+
+	if commit.Operation == OperationDelete {
+		return p.deleteScene(ctx, existing, commitTime)
+	}
+	return p.upsertScene(ctx, commit, existing, commitTime)
+}
+
+// upsertScene maps a scene record onto existing, or onto a new scene when
+// the record is new or was deleted, and stores it.
+func (p *Processor) upsertScene(ctx context.Context, commit *Commit, existing *scene.Scene, commitTime time.Time) (processOutcome, error) {
+	var record SceneRecord
+	if err := json.Unmarshal(commit.Record, &record); err != nil {
+		return outcomeSkipped, ErrMalformedJSON
+	}
+	name := strings.TrimSpace(record.Name)
+	if name == "" {
+		return outcomeSkipped, ErrMissingField
+	}
+
+	visibility := record.Visibility
+	if visibility == "" {
+		visibility = scene.VisibilityPublic
+	}
+	if visibility != scene.VisibilityPublic && visibility != scene.VisibilityMembersOnly && visibility != scene.VisibilityHidden {
+		return outcomeSkipped, fmt.Errorf("%w: unknown visibility %q", ErrInvalidFieldType, visibility)
+	}
+
+	coarseGeohash, err := recordCoarseGeohash(record.CoarseGeohash, record.PrecisePoint)
+	if err != nil {
+		return outcomeSkipped, err
+	}
+
+	tags := scene.NormalizeTags(record.Tags)
+	if err := scene.ValidateTags(tags); err != nil {
+		return outcomeSkipped, fmt.Errorf("%w: %v", ErrInvalidFieldType, err)
+	}
+
+	// A record recreated after a delete starts over rather than inheriting
+	// the deleted scene's server-side state
+	s, outcome := existing, outcomeUpdated
+	if s == nil || s.DeletedAt != nil {
+		s, outcome = &scene.Scene{CreatedAt: &commitTime}, outcomeCreated
+	}
+	recordDID, recordRKey := commit.DID, commit.RKey
+	s.Name = name
+	s.Description = record.Description
+	s.OwnerDID = commit.DID
+	s.AllowPrecise = record.AllowPrecise
+	s.PrecisePoint = record.PrecisePoint
+	s.CoarseGeohash = coarseGeohash
+	s.Tags = tags
+	s.Visibility = visibility
+	s.UpdatedAt = &commitTime
+	s.DeletedAt = nil
+	s.RecordDID = &recordDID
+	s.RecordRKey = &recordRKey
+	s.EnforceLocationConsent()
+
+	result, err := p.scenes.Upsert(ctx, s)
+	if err != nil {
+		return outcomeSkipped, fmt.Errorf("upsert scene record: %w", err)
+	}
+	if p.index != nil {
+		s.ID = result.ID
+		p.index.IndexScene(s)
+	}
+	return outcome, nil
+}
+
+// deleteScene soft-deletes existing as of commitTime, cascading to its events
+// and dropping both from the search index. Deletes for unknown or already
+// deleted records are no-ops.
+func (p *Processor) deleteScene(ctx context.Context, existing *scene.Scene, commitTime time.Time) (processOutcome, error) {
+	if existing == nil || existing.DeletedAt != nil {
+		return outcomeSkipped, nil
+	}
+
+	if err := p.scenes.Delete(ctx, existing.ID); err != nil {
+		return outcomeSkipped, fmt.Errorf("delete scene record: %w", err)
+	}
+
+	// Restamp the commit time over the wall clock so a later recreate
+	// compares against when the delete happened, not when it was indexed
+	existing.DeletedAt = &commitTime
+	if _, err := p.scenes.Upsert(ctx, existing); err != nil {
+		return outcomeSkipped, fmt.Errorf("stamp scene record deletion: %w", err)
+	}
+
+	if p.events != nil {
+		p.deleteSceneEvents(ctx, existing.ID)
+	}
+	if p.index != nil {
+		p.index.RemoveScene(existing.ID)
+	}
+	return outcomeDeleted, nil
+}
+
+// deleteSceneEvents soft-deletes a deleted scene's events and drops them from
+// the search index. Failures are logged rather than returned since the scene
+// itself is already deleted, so a retried commit would be skipped anyway.
+func (p *Processor) deleteSceneEvents(ctx context.Context, sceneID string) {
+	var events []*scene.Event
+	if p.index != nil {
+		var err error
+		events, err = p.events.ListBySceneID(ctx, sceneID, scene.EventFilter{})
+		if err != nil {
+			p.logger.WarnContext(ctx, "failed to list scene events for index removal", "error", err, "scene_id", sceneID)
+		}
+	}
+
+	count, err := p.events.DeleteBySceneID(ctx, sceneID)
+	if err != nil {
+		p.logger.ErrorContext(ctx, "failed to delete scene events", "error", err, "scene_id", sceneID)
+		return
+	}
+	p.logger.DebugContext(ctx, "deleted scene events", "scene_id", sceneID, "event_count", count)
+
+	for _, event := range events {
+		p.index.RemoveEvent(event.ID)
+	}
+}
+
+// recordCoarseGeohash derives the coarse geohash at the configured precision
+// from the precise point when one is given, otherwise validates and
+// truncates the record's own geohash.
+func recordCoarseGeohash(coarseGeohash string, precisePoint *geo.Point) (string, error) {
+	if precisePoint != nil {
+		if !precisePoint.Valid() {
+			return "", fmt.Errorf("%w: precisePoint out of range", ErrInvalidFieldType)
+		}
+		return geo.Encode(*precisePoint, geo.CoarsePrecision()), nil
+	}
+
+	coarseGeohash = strings.TrimSpace(coarseGeohash)
+	if coarseGeohash == "" {
+		return "", ErrMissingField
+	}
+	if !geo.IsValidGeohash(coarseGeohash) {
+		return "", fmt.Errorf("%w: invalid coarseGeohash", ErrInvalidFieldType)
+	}
+	return geo.RoundGeohash(coarseGeohash, geo.CoarsePrecision()), nil
+}
+
+// lastChanged returns the latest of a scene's creation, update, and
+// deletion times.
+func lastChanged(s *scene.Scene) time.Time {
+	var latest time.Time
+	for _, t := range []*time.Time{s.CreatedAt, s.UpdatedAt, s.DeletedAt} {
+		if t != nil && t.After(latest) {
+			latest = *t
+		}
+	}
+	return latest
+}
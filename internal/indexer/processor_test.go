@@ -0,0 +1,250 @@
+package indexer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/search"
+)
+
+const (
+	testSceneDID  = "did:plc:abc123"
+	testSceneRKey = "3l3qo2vuowo2b"
+)
+
+// sceneCommit builds an app.subcult.scene commit at timeUS. record is
+// ignored for deletes.
+func sceneCommit(operation string, timeUS int64, record string) *Commit {
+	commit := &Commit{
+		DID:        testSceneDID,
+		TimeUS:     timeUS,
+		Collection: CollectionScene,
+		RKey:       testSceneRKey,
+		Operation:  operation,
+	}
+	if operation != OperationDelete {
+		commit.Record = []byte(record)
+	}
+	return commit
+}
+
+func processAll(t *testing.T, p *Processor, commits ...*Commit) {
+	t.Helper()
+	for i, commit := range commits {
+		if err := p.Process(t.Context(), commit); err != nil {
+			t.Fatalf("Process commit %d (%s) failed: %v", i+1, commit.Operation, err)
+		}
+	}
+}
+
+func TestProcessor_CreateDeleteRecreate(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	p := NewProcessor(repo, nil)
+
+	processAll(t, p,
+		sceneCommit(OperationCreate, 1000, `{"name": "Underground Jazz", "coarseGeohash": "dr5regw"}`),
+		sceneCommit(OperationDelete, 2000, ""),
+	)
+
+	deleted, err := repo.GetByRecordKey(t.Context(), testSceneDID, testSceneRKey)
+	if err != nil {
+		t.Fatalf("GetByRecordKey failed: %v", err)
+	}
+	if deleted.DeletedAt == nil {
+		t.Fatal("expected scene to be soft-deleted")
+	}
+	if _, err := repo.GetByID(t.Context(), deleted.ID); !errors.Is(err, scene.ErrSceneDeleted) {
+		t.Errorf("expected ErrSceneDeleted from GetByID, got %v", err)
+	}
+
+	processAll(t, p, sceneCommit(OperationCreate, 3000, `{"name": "Jazz Reborn", "coarseGeohash": "dr5regw"}`))
+
+	recreated, err := repo.GetByID(t.Context(), deleted.ID)
+	if err != nil {
+		t.Fatalf("expected recreated scene to be live under the same ID, got %v", err)
+	}
+	if recreated.Name != "Jazz Reborn" {
+		t.Errorf("expected name %q, got %q", "Jazz Reborn", recreated.Name)
+	}
+	if recreated.OwnerDID != testSceneDID {
+		t.Errorf("expected owner %s, got %s", testSceneDID, recreated.OwnerDID)
+	}
+	if recreated.CoarseGeohash != "dr5reg" {
+		t.Errorf("expected coarse geohash truncated to dr5reg, got %s", recreated.CoarseGeohash)
+	}
+	if recreated.Visibility != scene.VisibilityPublic {
+		t.Errorf("expected default visibility public, got %s", recreated.Visibility)
+	}
+	if recreated.CreatedAt == nil || recreated.CreatedAt.UnixMicro() != 3000 {
+		t.Errorf("expected created_at from the recreate commit, got %v", recreated.CreatedAt)
+	}
+}
+
+func TestProcessor_DeleteCascadesAndUpdatesSearchIndex(t *testing.T) {
+	scenes := scene.NewInMemorySceneRepository()
+	events := scene.NewInMemoryEventRepository()
+	index := search.NewIndexer()
+	p := NewProcessor(scenes, nil)
+	p.SetEventRepository(events)
+	p.SetSearchIndex(index)
+
+	processAll(t, p, sceneCommit(OperationCreate, 1000, `{"name": "Underground Jazz", "coarseGeohash": "dr5regw"}`))
+
+	stored, err := scenes.GetByRecordKey(t.Context(), testSceneDID, testSceneRKey)
+	if err != nil {
+		t.Fatalf("GetByRecordKey failed: %v", err)
+	}
+	results, err := index.Search(t.Context(), "jazz", 10)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != stored.ID {
+		t.Fatalf("expected created scene %s in the index, got %+v", stored.ID, results)
+	}
+
+	processAll(t, p, sceneCommit(OperationUpdate, 2000, `{"name": "Underground Blues", "coarseGeohash": "dr5regw"}`))
+	if results, _ := index.Search(t.Context(), "jazz", 10); len(results) != 0 {
+		t.Errorf("expected old name dropped from the index, got %+v", results)
+	}
+	if results, _ := index.Search(t.Context(), "blues", 10); len(results) != 1 {
+		t.Errorf("expected updated scene in the index, got %+v", results)
+	}
+
+	event := &scene.Event{ID: "event-1", SceneID: stored.ID, Title: "Blues Night", CoarseGeohash: "dr5reg"}
+	if err := events.Insert(t.Context(), event); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+	index.IndexEvent(event)
+
+	processAll(t, p, sceneCommit(OperationDelete, 3000, ""))
+
+	if _, err := scenes.GetByID(t.Context(), stored.ID); !errors.Is(err, scene.ErrSceneDeleted) {
+		t.Errorf("expected ErrSceneDeleted from GetByID, got %v", err)
+	}
+	if _, err := events.GetByID(t.Context(), event.ID); !errors.Is(err, scene.ErrEventNotFound) {
+		t.Errorf("expected the scene's event to be deleted, got %v", err)
+	}
+	if results, _ := index.Search(t.Context(), "blues", 10); len(results) != 0 {
+		t.Errorf("expected deleted scene and event removed from the index, got %+v", results)
+	}
+
+	deleted, err := scenes.GetByRecordKey(t.Context(), testSceneDID, testSceneRKey)
+	if err != nil {
+		t.Fatalf("GetByRecordKey failed: %v", err)
+	}
+	if deleted.DeletedAt == nil || deleted.DeletedAt.UnixMicro() != 3000 {
+		t.Errorf("expected deleted_at from the delete commit, got %v", deleted.DeletedAt)
+	}
+}
+
+func TestProcessor_OutOfOrderCommits(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	p := NewProcessor(repo, nil)
+
+	processAll(t, p,
+		sceneCommit(OperationCreate, 1000, `{"name": "First", "coarseGeohash": "dr5reg"}`),
+		sceneCommit(OperationUpdate, 3000, `{"name": "Newest", "coarseGeohash": "dr5reg"}`),
+		sceneCommit(OperationUpdate, 2000, `{"name": "Stale", "coarseGeohash": "dr5reg"}`),
+	)
+
+	stored, err := repo.GetByRecordKey(t.Context(), testSceneDID, testSceneRKey)
+	if err != nil {
+		t.Fatalf("GetByRecordKey failed: %v", err)
+	}
+	if stored.Name != "Newest" {
+		t.Errorf("expected stale update to be skipped, got name %q", stored.Name)
+	}
+
+	// A delayed create must not resurrect a scene deleted after it
+	processAll(t, p,
+		sceneCommit(OperationDelete, 4000, ""),
+		sceneCommit(OperationCreate, 3500, `{"name": "Resurrected", "coarseGeohash": "dr5reg"}`),
+	)
+
+	stored, err = repo.GetByRecordKey(t.Context(), testSceneDID, testSceneRKey)
+	if err != nil {
+		t.Fatalf("GetByRecordKey failed: %v", err)
+	}
+	if stored.DeletedAt == nil {
+		t.Error("expected scene to stay deleted")
+	}
+	if stored.Name != "Newest" {
+		t.Errorf("expected name %q, got %q", "Newest", stored.Name)
+	}
+}
+
+func TestProcessor_LocationConsent(t *testing.T) {
+	tests := []struct {
+		name        string
+		record      string
+		wantPrecise bool
+	}{
+		{"without consent", `{"name": "Loft", "precisePoint": {"lat": 40.7128, "lng": -74.0060}}`, false},
+		{"with consent", `{"name": "Loft", "allowPrecise": true, "precisePoint": {"lat": 40.7128, "lng": -74.0060}, "coarseGeohash": "9q8yy"}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := scene.NewInMemorySceneRepository()
+			processAll(t, NewProcessor(repo, nil), sceneCommit(OperationCreate, 1000, tt.record))
+
+			stored, err := repo.GetByRecordKey(t.Context(), testSceneDID, testSceneRKey)
+			if err != nil {
+				t.Fatalf("GetByRecordKey failed: %v", err)
+			}
+			if (stored.PrecisePoint != nil) != tt.wantPrecise {
+				t.Errorf("expected precise point stored = %v, got %v", tt.wantPrecise, stored.PrecisePoint)
+			}
+			// The coarse geohash always comes from the precise point
+			if stored.CoarseGeohash != "dr5reg" {
+				t.Errorf("expected coarse geohash dr5reg derived from the point, got %s", stored.CoarseGeohash)
+			}
+		})
+	}
+}
+
+func TestProcessor_InvalidRecords(t *testing.T) {
+	tests := []struct {
+		name    string
+		record  string
+		wantErr error
+	}{
+		{"malformed JSON", `{"name": `, ErrMalformedJSON},
+		{"blank name", `{"name": "  ", "coarseGeohash": "dr5reg"}`, ErrMissingField},
+		{"no location", `{"name": "Loft"}`, ErrMissingField},
+		{"invalid geohash", `{"name": "Loft", "coarseGeohash": "dr5!eg"}`, ErrInvalidFieldType},
+		{"point out of range", `{"name": "Loft", "precisePoint": {"lat": 91, "lng": 0}}`, ErrInvalidFieldType},
+		{"unknown visibility", `{"name": "Loft", "coarseGeohash": "dr5reg", "visibility": "secret"}`, ErrInvalidFieldType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := scene.NewInMemorySceneRepository()
+			err := NewProcessor(repo, nil).Process(t.Context(), sceneCommit(OperationCreate, 1000, tt.record))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if _, err := repo.GetByRecordKey(t.Context(), testSceneDID, testSceneRKey); !errors.Is(err, scene.ErrSceneNotFound) {
+				t.Errorf("expected no scene stored, got %v", err)
+			}
+		})
+	}
+}
+
+func TestProcessor_IgnoresOtherCollections(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	p := NewProcessor(repo, nil)
+
+	commit := sceneCommit(OperationCreate, 1000, `{"name": "Gig", "sceneId": "abc"}`)
+	commit.Collection = CollectionEvent
+	if err := p.Process(t.Context(), commit); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if err := p.Process(t.Context(), nil); err != nil {
+		t.Fatalf("Process(nil) failed: %v", err)
+	}
+	if _, err := repo.GetByRecordKey(t.Context(), testSceneDID, testSceneRKey); !errors.Is(err, scene.ErrSceneNotFound) {
+		t.Errorf("expected no scene stored, got %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+package membership_test
+
+import (
+	"testing"
+
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/membership/membershiptest"
+)
+
+func TestInMemoryMembershipRepository_Conformance(t *testing.T) {
+	membershiptest.TestMembershipRepository(t, func(t *testing.T) membership.MembershipRepository {
+		return membership.NewInMemoryMembershipRepository()
+	})
+}
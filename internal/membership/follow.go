@@ -0,0 +1,132 @@
+package membership
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Follow records a user following a scene for updates without joining it.
+// Follows are kept apart from memberships so they can never satisfy a
+// membership check: a follower has the same access as any public viewer.
+type Follow struct {
+	SceneID   string    `json:"scene_id"`
+	UserDID   string    `json:"user_did"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FollowRepository defines the interface for scene follow data operations.
+type FollowRepository interface {
+	// Follow records that userDID follows sceneID. Idempotent: following
+	// again keeps the original follow. Returns true if a follow was created.
+	Follow(sceneID, userDID string) (bool, error)
+
+	// Unfollow removes a follow. Idempotent: returns nil if the user wasn't
+	// following the scene.
+	Unfollow(sceneID, userDID string) error
+
+	// IsFollowing reports whether userDID follows sceneID.
+	IsFollowing(sceneID, userDID string) (bool, error)
+
+	// ListByUser retrieves all of a user's follows, newest first.
+	ListByUser(userDID string) ([]*Follow, error)
+
+	// CountByScenes returns a map of scene IDs to their follower counts.
+	// This is a batch operation to avoid N+1 queries.
+	CountByScenes(sceneIDs []string) (map[string]int, error)
+}
+
+// InMemoryFollowRepository is an in-memory implementation of FollowRepository.
+// Thread-safe via RWMutex.
+type InMemoryFollowRepository struct {
+	mu      sync.RWMutex
+	follows map[string]*Follow // "sceneID\x00userDID" -> Follow
+}
+
+// Compile-time check that InMemoryFollowRepository satisfies FollowRepository.
+var _ FollowRepository = (*InMemoryFollowRepository)(nil)
+
+// NewInMemoryFollowRepository creates a new in-memory follow repository.
+func NewInMemoryFollowRepository() *InMemoryFollowRepository {
+	return &InMemoryFollowRepository{
+		follows: make(map[string]*Follow),
+	}
+}
+
+// Follow records that userDID follows sceneID.
+func (r *InMemoryFollowRepository) Follow(sceneID, userDID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := makeKey(sceneID, userDID)
+	if _, exists := r.follows[key]; exists {
+		return false, nil
+	}
+	r.follows[key] = &Follow{
+		SceneID:   sceneID,
+		UserDID:   userDID,
+		CreatedAt: time.Now(),
+	}
+	return true, nil
+}
+
+// Unfollow removes a follow if present.
+func (r *InMemoryFollowRepository) Unfollow(sceneID, userDID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.follows, makeKey(sceneID, userDID))
+	return nil
+}
+
+// IsFollowing reports whether userDID follows sceneID.
+func (r *InMemoryFollowRepository) IsFollowing(sceneID, userDID string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, exists := r.follows[makeKey(sceneID, userDID)]
+	return exists, nil
+}
+
+// ListByUser retrieves all of a user's follows, newest first.
+func (r *InMemoryFollowRepository) ListByUser(userDID string) ([]*Follow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*Follow, 0)
+	for _, follow := range r.follows {
+		if follow.UserDID == userDID {
+			followCopy := *follow
+			result = append(result, &followCopy)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].SceneID < result[j].SceneID
+		}
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+// CountByScenes returns a map of scene IDs to their follower counts.
+func (r *InMemoryFollowRepository) CountByScenes(sceneIDs []string) (map[string]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sceneIDSet := make(map[string]bool, len(sceneIDs))
+	for _, id := range sceneIDs {
+		sceneIDSet[id] = true
+	}
+
+	counts := make(map[string]int)
+	for _, follow := range r.follows {
+		if sceneIDSet[follow.SceneID] {
+			counts[follow.SceneID]++
+		}
+	}
+
+	return counts, nil
+}
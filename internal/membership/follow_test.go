@@ -0,0 +1,109 @@
+package membership
+
+import (
+	"testing"
+)
+
+func TestFollowRepository_FollowIdempotent(t *testing.T) {
+	repo := NewInMemoryFollowRepository()
+
+	created, err := repo.Follow("scene-1", "did:plc:alice")
+	if err != nil {
+		t.Fatalf("Follow failed: %v", err)
+	}
+	if !created {
+		t.Error("Expected first follow to be created")
+	}
+
+	created, err = repo.Follow("scene-1", "did:plc:alice")
+	if err != nil {
+		t.Fatalf("Follow failed: %v", err)
+	}
+	if created {
+		t.Error("Expected repeated follow to be a no-op")
+	}
+
+	counts, err := repo.CountByScenes([]string{"scene-1"})
+	if err != nil {
+		t.Fatalf("CountByScenes failed: %v", err)
+	}
+	if counts["scene-1"] != 1 {
+		t.Errorf("Expected 1 follower, got %d", counts["scene-1"])
+	}
+}
+
+func TestFollowRepository_UnfollowIdempotent(t *testing.T) {
+	repo := NewInMemoryFollowRepository()
+
+	if _, err := repo.Follow("scene-1", "did:plc:alice"); err != nil {
+		t.Fatalf("Follow failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := repo.Unfollow("scene-1", "did:plc:alice"); err != nil {
+			t.Fatalf("Unfollow %d failed: %v", i+1, err)
+		}
+	}
+
+	following, err := repo.IsFollowing("scene-1", "did:plc:alice")
+	if err != nil {
+		t.Fatalf("IsFollowing failed: %v", err)
+	}
+	if following {
+		t.Error("Expected follow to be removed")
+	}
+
+	// Unfollowing a scene that was never followed is not an error
+	if err := repo.Unfollow("scene-2", "did:plc:bob"); err != nil {
+		t.Errorf("Expected unfollow of unknown follow to succeed, got %v", err)
+	}
+}
+
+func TestFollowRepository_ListByUser(t *testing.T) {
+	repo := NewInMemoryFollowRepository()
+
+	for _, f := range []struct{ scene, user string }{
+		{"scene-1", "did:plc:alice"},
+		{"scene-2", "did:plc:alice"},
+		{"scene-1", "did:plc:bob"},
+	} {
+		if _, err := repo.Follow(f.scene, f.user); err != nil {
+			t.Fatalf("Follow failed: %v", err)
+		}
+	}
+
+	follows, err := repo.ListByUser("did:plc:alice")
+	if err != nil {
+		t.Fatalf("ListByUser failed: %v", err)
+	}
+	if len(follows) != 2 {
+		t.Fatalf("Expected 2 follows, got %d", len(follows))
+	}
+	for _, f := range follows {
+		if f.UserDID != "did:plc:alice" {
+			t.Errorf("Expected only alice's follows, got %s", f.UserDID)
+		}
+	}
+	if follows[0].CreatedAt.Before(follows[1].CreatedAt) {
+		t.Error("Expected newest follow first")
+	}
+
+	counts, err := repo.CountByScenes([]string{"scene-1", "scene-2", "scene-3"})
+	if err != nil {
+		t.Fatalf("CountByScenes failed: %v", err)
+	}
+	if counts["scene-1"] != 2 || counts["scene-2"] != 1 || counts["scene-3"] != 0 {
+		t.Errorf("Unexpected follower counts: %v", counts)
+	}
+}
+
+func TestFollowRepository_NotAMembership(t *testing.T) {
+	follows := NewInMemoryFollowRepository()
+	memberships := NewInMemoryMembershipRepository()
+
+	if _, err := follows.Follow("scene-1", "did:plc:alice"); err != nil {
+		t.Fatalf("Follow failed: %v", err)
+	}
+	if _, err := memberships.GetBySceneAndUser(t.Context(), "scene-1", "did:plc:alice"); err != ErrMembershipNotFound {
+		t.Errorf("Expected following to create no membership, got %v", err)
+	}
+}
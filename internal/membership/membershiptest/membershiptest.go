@@ -0,0 +1,295 @@
+// Package membershiptest provides a conformance suite for implementations of
+// membership.MembershipRepository. A new backend passes the same suite as
+// InMemoryMembershipRepository before it is wired into the handlers:
+//
+//	func TestPostgresMembershipRepository(t *testing.T) {
+//		membershiptest.TestMembershipRepository(t, func(t *testing.T) membership.MembershipRepository {
+//			return newTestPostgresMembershipRepository(t)
+//		})
+//	}
+//
+// The suite only checks behavior documented on the interface. Each subtest
+// calls the factory for a fresh, empty repository.
+package membershiptest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/membership"
+)
+
+// TestMembershipRepository runs the MembershipRepository conformance suite.
+// newRepo must return a fresh, empty repository on every call.
+func TestMembershipRepository(t *testing.T, newRepo func(t *testing.T) membership.MembershipRepository) {
+	t.Run("UpsertByRecordKey", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		m := newMembership("scene-1", "did:plc:user1", "pending")
+		m.RecordDID = strPtr("did:plc:user1")
+		m.RecordRKey = strPtr("rkey-1")
+		first, err := repo.Upsert(ctx, m)
+		if err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+		if !first.Inserted || first.ID == "" {
+			t.Fatalf("expected first upsert to insert with an ID, got %+v", first)
+		}
+
+		m.Status = "active"
+		second, err := repo.Upsert(ctx, m)
+		if err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+		if second.Inserted || second.ID != first.ID {
+			t.Errorf("expected second upsert to update %s, got %+v", first.ID, second)
+		}
+
+		got, err := repo.GetByRecordKey(ctx, "did:plc:user1", "rkey-1")
+		if err != nil {
+			t.Fatalf("GetByRecordKey() error = %v", err)
+		}
+		if got.ID != first.ID || got.Status != "active" {
+			t.Errorf("GetByRecordKey() = %s %q, want %s %q", got.ID, got.Status, first.ID, "active")
+		}
+		if _, err := repo.GetByRecordKey(ctx, "did:plc:user1", "missing"); !errors.Is(err, membership.ErrMembershipNotFound) {
+			t.Errorf("expected ErrMembershipNotFound for an unknown record key, got %v", err)
+		}
+	})
+
+	t.Run("GetBySceneAndUser", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		result, err := repo.Upsert(ctx, newMembership("scene-1", "did:plc:user1", "active"))
+		if err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+
+		got, err := repo.GetBySceneAndUser(ctx, "scene-1", "did:plc:user1")
+		if err != nil {
+			t.Fatalf("GetBySceneAndUser() error = %v", err)
+		}
+		if got.ID != result.ID {
+			t.Errorf("GetBySceneAndUser() = %s, want %s", got.ID, result.ID)
+		}
+		if _, err := repo.GetByID(ctx, result.ID); err != nil {
+			t.Errorf("GetByID() error = %v", err)
+		}
+		if _, err := repo.GetBySceneAndUser(ctx, "scene-1", "did:plc:user2"); !errors.Is(err, membership.ErrMembershipNotFound) {
+			t.Errorf("expected ErrMembershipNotFound, got %v", err)
+		}
+		if _, err := repo.GetByID(ctx, "missing"); !errors.Is(err, membership.ErrMembershipNotFound) {
+			t.Errorf("expected ErrMembershipNotFound, got %v", err)
+		}
+	})
+
+	t.Run("UpdateStatusAndRole", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		result, err := repo.Upsert(ctx, newMembership("scene-1", "did:plc:user1", "pending"))
+		if err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+		before, err := repo.GetByID(ctx, result.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+
+		if err := repo.UpdateStatus(ctx, result.ID, "active", nil); err != nil {
+			t.Fatalf("UpdateStatus() error = %v", err)
+		}
+		if err := repo.UpdateRole(ctx, result.ID, "admin"); err != nil {
+			t.Fatalf("UpdateRole() error = %v", err)
+		}
+		got, err := repo.GetByID(ctx, result.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Status != "active" || got.Role != "admin" {
+			t.Errorf("expected active admin, got %q %q", got.Status, got.Role)
+		}
+		if !got.Since.Equal(before.Since) {
+			t.Errorf("expected a nil since to keep %v, got %v", before.Since, got.Since)
+		}
+
+		since := time.Now().Add(-time.Hour).Truncate(time.Second)
+		if err := repo.UpdateStatus(ctx, result.ID, "active", &since); err != nil {
+			t.Fatalf("UpdateStatus() error = %v", err)
+		}
+		got, err = repo.GetByID(ctx, result.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if !got.Since.Equal(since) {
+			t.Errorf("expected since %v, got %v", since, got.Since)
+		}
+
+		if err := repo.UpdateRole(ctx, "missing", "admin"); !errors.Is(err, membership.ErrMembershipNotFound) {
+			t.Errorf("expected ErrMembershipNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		m := newMembership("scene-1", "did:plc:user1", "active")
+		m.RecordDID = strPtr("did:plc:user1")
+		m.RecordRKey = strPtr("rkey-1")
+		result, err := repo.Upsert(ctx, m)
+		if err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+
+		if err := repo.Delete(ctx, result.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.GetByID(ctx, result.ID); !errors.Is(err, membership.ErrMembershipNotFound) {
+			t.Errorf("expected ErrMembershipNotFound after delete, got %v", err)
+		}
+		if _, err := repo.GetByRecordKey(ctx, "did:plc:user1", "rkey-1"); !errors.Is(err, membership.ErrMembershipNotFound) {
+			t.Errorf("expected delete to free the record key, got %v", err)
+		}
+		if err := repo.Delete(ctx, result.ID); !errors.Is(err, membership.ErrMembershipNotFound) {
+			t.Errorf("expected ErrMembershipNotFound deleting twice, got %v", err)
+		}
+	})
+
+	t.Run("ListAndCount", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		for _, m := range []*membership.Membership{
+			newMembership("scene-1", "did:plc:user1", "active"),
+			newMembership("scene-1", "did:plc:user2", "pending"),
+			newMembership("scene-1", "did:plc:user3", "banned"),
+			newMembership("scene-2", "did:plc:user1", "banned"),
+		} {
+			if _, err := repo.Upsert(ctx, m); err != nil {
+				t.Fatalf("Upsert() error = %v", err)
+			}
+		}
+
+		all, err := repo.ListByScene(ctx, "scene-1", "")
+		if err != nil {
+			t.Fatalf("ListByScene() error = %v", err)
+		}
+		if len(all) != 2 {
+			t.Errorf("expected 2 unbanned memberships, got %d", len(all))
+		}
+		banned, err := repo.ListByScene(ctx, "scene-1", "banned")
+		if err != nil {
+			t.Fatalf("ListByScene() error = %v", err)
+		}
+		if len(banned) != 1 || banned[0].UserDID != "did:plc:user3" {
+			t.Errorf("expected only user3 banned, got %+v", banned)
+		}
+
+		mine, err := repo.ListByUser(ctx, "did:plc:user1")
+		if err != nil {
+			t.Fatalf("ListByUser() error = %v", err)
+		}
+		if len(mine) != 2 {
+			t.Errorf("expected user1's memberships to include bans, got %d", len(mine))
+		}
+
+		counts, err := repo.CountByScenes(ctx, []string{"scene-1", "scene-2"}, "active")
+		if err != nil {
+			t.Fatalf("CountByScenes() error = %v", err)
+		}
+		if counts["scene-1"] != 1 || counts["scene-2"] != 0 {
+			t.Errorf("expected 1 active member in scene-1 and none in scene-2, got %v", counts)
+		}
+		counts, err = repo.CountByScenes(ctx, []string{"scene-1"}, "")
+		if err != nil {
+			t.Fatalf("CountByScenes() error = %v", err)
+		}
+		if counts["scene-1"] != 3 {
+			t.Errorf("expected an empty status to count all 3 memberships, got %d", counts["scene-1"])
+		}
+	})
+
+	t.Run("CanceledContext", func(t *testing.T) {
+		repo := newRepo(t)
+		result, err := repo.Upsert(t.Context(), newMembership("scene-1", "did:plc:user1", "active"))
+		if err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		calls := map[string]func(context.Context) error{
+			"Upsert": func(ctx context.Context) error {
+				_, err := repo.Upsert(ctx, newMembership("scene-1", "did:plc:user2", "active"))
+				return err
+			},
+			"GetByID": func(ctx context.Context) error {
+				_, err := repo.GetByID(ctx, result.ID)
+				return err
+			},
+			"GetByRecordKey": func(ctx context.Context) error {
+				_, err := repo.GetByRecordKey(ctx, "did:plc:user1", "rkey-1")
+				return err
+			},
+			"GetBySceneAndUser": func(ctx context.Context) error {
+				_, err := repo.GetBySceneAndUser(ctx, "scene-1", "did:plc:user1")
+				return err
+			},
+			"UpdateStatus": func(ctx context.Context) error {
+				return repo.UpdateStatus(ctx, result.ID, "banned", nil)
+			},
+			"UpdateRole": func(ctx context.Context) error {
+				return repo.UpdateRole(ctx, result.ID, "admin")
+			},
+			"Delete": func(ctx context.Context) error {
+				return repo.Delete(ctx, result.ID)
+			},
+			"ListByScene": func(ctx context.Context) error {
+				_, err := repo.ListByScene(ctx, "scene-1", "")
+				return err
+			},
+			"ListByUser": func(ctx context.Context) error {
+				_, err := repo.ListByUser(ctx, "did:plc:user1")
+				return err
+			},
+			"CountByScenes": func(ctx context.Context) error {
+				_, err := repo.CountByScenes(ctx, []string{"scene-1"}, "")
+				return err
+			},
+		}
+		for name, call := range calls {
+			if err := call(ctx); !errors.Is(err, context.Canceled) {
+				t.Errorf("%s: expected context.Canceled, got %v", name, err)
+			}
+		}
+
+		got, err := repo.GetByID(t.Context(), result.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Status != "active" || got.Role != "member" {
+			t.Errorf("expected canceled calls to leave the membership untouched, got %q %q", got.Status, got.Role)
+		}
+	})
+}
+
+// newMembership returns a member of sceneID with the given status.
+func newMembership(sceneID, userDID, status string) *membership.Membership {
+	return &membership.Membership{
+		SceneID:     sceneID,
+		UserDID:     userDID,
+		Role:        "member",
+		Status:      status,
+		TrustWeight: 0.5,
+	}
+}
+
+// strPtr returns a pointer to s.
+func strPtr(s string) *string {
+	return &s
+}
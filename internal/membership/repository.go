@@ -3,6 +3,7 @@
 package membership
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"time"
@@ -40,32 +41,46 @@ type UpsertResult struct {
 }
 
 // MembershipRepository defines the interface for membership data operations.
+// Methods return ctx.Err() instead of doing any work once ctx is done.
 type MembershipRepository interface {
 	// Upsert inserts a new membership or updates existing one based on (record_did, record_rkey).
 	// Returns UpsertResult indicating whether insert or update occurred.
-	Upsert(membership *Membership) (*UpsertResult, error)
+	Upsert(ctx context.Context, membership *Membership) (*UpsertResult, error)
 
 	// GetByID retrieves a membership by its UUID.
-	GetByID(id string) (*Membership, error)
+	GetByID(ctx context.Context, id string) (*Membership, error)
 
 	// GetByRecordKey retrieves a membership by its AT Protocol record key.
-	GetByRecordKey(did, rkey string) (*Membership, error)
+	GetByRecordKey(ctx context.Context, did, rkey string) (*Membership, error)
 
 	// GetBySceneAndUser retrieves a membership by scene ID and user DID.
-	GetBySceneAndUser(sceneID, userDID string) (*Membership, error)
+	GetBySceneAndUser(ctx context.Context, sceneID, userDID string) (*Membership, error)
 
 	// UpdateStatus updates the status and optional since timestamp of a membership.
 	// If since is nil, the timestamp is not updated.
-	UpdateStatus(id, status string, since *time.Time) error
+	UpdateStatus(ctx context.Context, id, status string, since *time.Time) error
+
+	// UpdateRole updates the role of a membership.
+	// Returns ErrMembershipNotFound if the membership doesn't exist.
+	UpdateRole(ctx context.Context, id, role string) error
+
+	// Delete removes a membership by its UUID.
+	// Returns ErrMembershipNotFound if the membership doesn't exist.
+	Delete(ctx context.Context, id string) error
 
 	// ListByScene retrieves all memberships for a scene, optionally filtered by status.
-	// If status is empty, returns all memberships regardless of status.
-	ListByScene(sceneID, status string) ([]*Membership, error)
+	// If status is empty, returns all memberships except banned ones; pass
+	// "banned" explicitly to list bans.
+	ListByScene(ctx context.Context, sceneID, status string) ([]*Membership, error)
 	
+	// ListByUser retrieves all of a user's memberships across scenes,
+	// including banned ones.
+	ListByUser(ctx context.Context, userDID string) ([]*Membership, error)
+
 	// CountByScenes returns a map of scene IDs to their membership counts.
 	// Only counts memberships matching the specified status (empty string matches all).
 	// This is a batch operation to avoid N+1 queries.
-	CountByScenes(sceneIDs []string, status string) (map[string]int, error)
+	CountByScenes(ctx context.Context, sceneIDs []string, status string) (map[string]int, error)
 }
 
 // InMemoryMembershipRepository is an in-memory implementation of MembershipRepository.
@@ -76,6 +91,9 @@ type InMemoryMembershipRepository struct {
 	keys        map[string]string      // "did:rkey" -> UUID
 }
 
+// Compile-time check that InMemoryMembershipRepository satisfies MembershipRepository.
+var _ MembershipRepository = (*InMemoryMembershipRepository)(nil)
+
 // NewInMemoryMembershipRepository creates a new in-memory membership repository.
 func NewInMemoryMembershipRepository() *InMemoryMembershipRepository {
 	return &InMemoryMembershipRepository{
@@ -92,7 +110,11 @@ func makeKey(did, rkey string) string {
 }
 
 // Upsert inserts a new membership or updates existing one based on (record_did, record_rkey).
-func (r *InMemoryMembershipRepository) Upsert(membership *Membership) (*UpsertResult, error) {
+func (r *InMemoryMembershipRepository) Upsert(ctx context.Context, membership *Membership) (*UpsertResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -156,7 +178,11 @@ func (r *InMemoryMembershipRepository) Upsert(membership *Membership) (*UpsertRe
 }
 
 // GetByID retrieves a membership by its UUID.
-func (r *InMemoryMembershipRepository) GetByID(id string) (*Membership, error) {
+func (r *InMemoryMembershipRepository) GetByID(ctx context.Context, id string) (*Membership, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -170,7 +196,11 @@ func (r *InMemoryMembershipRepository) GetByID(id string) (*Membership, error) {
 }
 
 // GetByRecordKey retrieves a membership by its AT Protocol record key.
-func (r *InMemoryMembershipRepository) GetByRecordKey(did, rkey string) (*Membership, error) {
+func (r *InMemoryMembershipRepository) GetByRecordKey(ctx context.Context, did, rkey string) (*Membership, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -186,7 +216,11 @@ func (r *InMemoryMembershipRepository) GetByRecordKey(did, rkey string) (*Member
 }
 
 // GetBySceneAndUser retrieves a membership by scene ID and user DID.
-func (r *InMemoryMembershipRepository) GetBySceneAndUser(sceneID, userDID string) (*Membership, error) {
+func (r *InMemoryMembershipRepository) GetBySceneAndUser(ctx context.Context, sceneID, userDID string) (*Membership, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -201,7 +235,11 @@ func (r *InMemoryMembershipRepository) GetBySceneAndUser(sceneID, userDID string
 }
 
 // UpdateStatus updates the status and optional since timestamp of a membership.
-func (r *InMemoryMembershipRepository) UpdateStatus(id, status string, since *time.Time) error {
+func (r *InMemoryMembershipRepository) UpdateStatus(ctx context.Context, id, status string, since *time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -219,15 +257,62 @@ func (r *InMemoryMembershipRepository) UpdateStatus(id, status string, since *ti
 	return nil
 }
 
+// UpdateRole updates the role of a membership.
+func (r *InMemoryMembershipRepository) UpdateRole(ctx context.Context, id, role string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	membership, ok := r.memberships[id]
+	if !ok {
+		return ErrMembershipNotFound
+	}
+
+	membership.Role = role
+	membership.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Delete removes a membership by its UUID.
+func (r *InMemoryMembershipRepository) Delete(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	membership, ok := r.memberships[id]
+	if !ok {
+		return ErrMembershipNotFound
+	}
+
+	if membership.RecordDID != nil && membership.RecordRKey != nil {
+		delete(r.keys, makeKey(*membership.RecordDID, *membership.RecordRKey))
+	}
+	delete(r.memberships, id)
+
+	return nil
+}
+
 // ListByScene retrieves all memberships for a scene, optionally filtered by status.
-func (r *InMemoryMembershipRepository) ListByScene(sceneID, status string) ([]*Membership, error) {
+// Banned memberships are only returned when status is "banned".
+func (r *InMemoryMembershipRepository) ListByScene(ctx context.Context, sceneID, status string) ([]*Membership, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	var result []*Membership
 	for _, membership := range r.memberships {
 		if membership.SceneID == sceneID {
-			if status == "" || membership.Status == status {
+			if (status == "" && membership.Status != "banned") || membership.Status == status {
 				membershipCopy := *membership
 				result = append(result, &membershipCopy)
 			}
@@ -237,10 +322,34 @@ func (r *InMemoryMembershipRepository) ListByScene(sceneID, status string) ([]*M
 	return result, nil
 }
 
+// ListByUser retrieves all of a user's memberships across scenes, including banned ones.
+func (r *InMemoryMembershipRepository) ListByUser(ctx context.Context, userDID string) ([]*Membership, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*Membership
+	for _, membership := range r.memberships {
+		if membership.UserDID == userDID {
+			membershipCopy := *membership
+			result = append(result, &membershipCopy)
+		}
+	}
+
+	return result, nil
+}
+
 // CountByScenes returns a map of scene IDs to their membership counts.
 // Only counts memberships matching the specified status (empty string matches all).
 // This is a batch operation to avoid N+1 queries.
-func (r *InMemoryMembershipRepository) CountByScenes(sceneIDs []string, status string) (map[string]int, error) {
+func (r *InMemoryMembershipRepository) CountByScenes(ctx context.Context, sceneIDs []string, status string) (map[string]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
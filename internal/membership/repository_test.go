@@ -1,6 +1,7 @@
 package membership
 
 import (
+	"context"
 	"testing"
 	"time"
 )
@@ -24,7 +25,7 @@ func TestMembershipRepository_Upsert_Insert(t *testing.T) {
 		RecordRKey:  strPtr(rkey),
 	}
 
-	result, err := repo.Upsert(membership)
+	result, err := repo.Upsert(t.Context(), membership)
 	if err != nil {
 		t.Fatalf("Upsert failed: %v", err)
 	}
@@ -38,7 +39,7 @@ func TestMembershipRepository_Upsert_Insert(t *testing.T) {
 	}
 
 	// Verify we can retrieve it
-	retrieved, err := repo.GetByRecordKey(did, rkey)
+	retrieved, err := repo.GetByRecordKey(t.Context(), did, rkey)
 	if err != nil {
 		t.Fatalf("GetByRecordKey failed: %v", err)
 	}
@@ -64,7 +65,7 @@ func TestMembershipRepository_Upsert_Update(t *testing.T) {
 		RecordRKey:  strPtr(rkey),
 	}
 
-	result1, err := repo.Upsert(membership)
+	result1, err := repo.Upsert(t.Context(), membership)
 	if err != nil {
 		t.Fatalf("First upsert failed: %v", err)
 	}
@@ -84,7 +85,7 @@ func TestMembershipRepository_Upsert_Update(t *testing.T) {
 		RecordRKey:  strPtr(rkey),
 	}
 
-	result2, err := repo.Upsert(membership2)
+	result2, err := repo.Upsert(t.Context(), membership2)
 	if err != nil {
 		t.Fatalf("Second upsert failed: %v", err)
 	}
@@ -98,7 +99,7 @@ func TestMembershipRepository_Upsert_Update(t *testing.T) {
 	}
 
 	// Verify update was persisted
-	retrieved, err := repo.GetByRecordKey(did, rkey)
+	retrieved, err := repo.GetByRecordKey(t.Context(), did, rkey)
 	if err != nil {
 		t.Fatalf("GetByRecordKey failed: %v", err)
 	}
@@ -128,13 +129,13 @@ func TestMembershipRepository_Upsert_Idempotent(t *testing.T) {
 	}
 
 	// First upsert
-	result1, err := repo.Upsert(membership)
+	result1, err := repo.Upsert(t.Context(), membership)
 	if err != nil {
 		t.Fatalf("First upsert failed: %v", err)
 	}
 
 	// Second upsert with same content
-	result2, err := repo.Upsert(membership)
+	result2, err := repo.Upsert(t.Context(), membership)
 	if err != nil {
 		t.Fatalf("Second upsert failed: %v", err)
 	}
@@ -160,7 +161,7 @@ func TestMembershipRepository_Upsert_WithoutRecordKey(t *testing.T) {
 		TrustWeight: 0.5,
 	}
 
-	result, err := repo.Upsert(membership)
+	result, err := repo.Upsert(t.Context(), membership)
 	if err != nil {
 		t.Fatalf("Upsert failed: %v", err)
 	}
@@ -170,7 +171,7 @@ func TestMembershipRepository_Upsert_WithoutRecordKey(t *testing.T) {
 	}
 
 	// Second upsert without record key should also insert
-	result2, err := repo.Upsert(membership)
+	result2, err := repo.Upsert(t.Context(), membership)
 	if err != nil {
 		t.Fatalf("Second upsert failed: %v", err)
 	}
@@ -188,7 +189,7 @@ func TestMembershipRepository_Upsert_WithoutRecordKey(t *testing.T) {
 func TestMembershipRepository_GetByRecordKey_NotFound(t *testing.T) {
 	repo := NewInMemoryMembershipRepository()
 
-	membership, err := repo.GetByRecordKey("did:plc:alice123", "nonexistent")
+	membership, err := repo.GetByRecordKey(t.Context(), "did:plc:alice123", "nonexistent")
 	if err != ErrMembershipNotFound {
 		t.Errorf("Expected ErrMembershipNotFound, got %v", err)
 	}
@@ -213,13 +214,13 @@ func TestMembershipRepository_GetByID_AfterUpsert(t *testing.T) {
 		RecordRKey:  strPtr(rkey),
 	}
 
-	result, err := repo.Upsert(membership)
+	result, err := repo.Upsert(t.Context(), membership)
 	if err != nil {
 		t.Fatalf("Upsert failed: %v", err)
 	}
 
 	// Retrieve by UUID
-	retrieved, err := repo.GetByID(result.ID)
+	retrieved, err := repo.GetByID(t.Context(), result.ID)
 	if err != nil {
 		t.Fatalf("GetByID failed: %v", err)
 	}
@@ -240,13 +241,13 @@ func TestMembershipRepository_GetBySceneAndUser(t *testing.T) {
 		TrustWeight: 0.8,
 	}
 
-	result, err := repo.Upsert(membership)
+	result, err := repo.Upsert(t.Context(), membership)
 	if err != nil {
 		t.Fatalf("Upsert failed: %v", err)
 	}
 
 	// Retrieve by scene and user
-	retrieved, err := repo.GetBySceneAndUser("scene-123", "did:plc:user456")
+	retrieved, err := repo.GetBySceneAndUser(t.Context(), "scene-123", "did:plc:user456")
 	if err != nil {
 		t.Fatalf("GetBySceneAndUser failed: %v", err)
 	}
@@ -260,12 +261,12 @@ func TestMembershipRepository_GetBySceneAndUser(t *testing.T) {
 	}
 
 	// Try with non-existent combination
-	_, err = repo.GetBySceneAndUser("scene-123", "did:plc:other")
+	_, err = repo.GetBySceneAndUser(t.Context(), "scene-123", "did:plc:other")
 	if err != ErrMembershipNotFound {
 		t.Errorf("Expected ErrMembershipNotFound, got %v", err)
 	}
 
-	_, err = repo.GetBySceneAndUser("other-scene", "did:plc:user456")
+	_, err = repo.GetBySceneAndUser(t.Context(), "other-scene", "did:plc:user456")
 	if err != ErrMembershipNotFound {
 		t.Errorf("Expected ErrMembershipNotFound, got %v", err)
 	}
@@ -282,20 +283,20 @@ func TestMembershipRepository_UpdateStatus(t *testing.T) {
 		TrustWeight: 0.5,
 	}
 
-	result, err := repo.Upsert(membership)
+	result, err := repo.Upsert(t.Context(), membership)
 	if err != nil {
 		t.Fatalf("Upsert failed: %v", err)
 	}
 
 	// Update status to active
 	now := time.Now()
-	err = repo.UpdateStatus(result.ID, "active", &now)
+	err = repo.UpdateStatus(t.Context(), result.ID, "active", &now)
 	if err != nil {
 		t.Fatalf("UpdateStatus failed: %v", err)
 	}
 
 	// Verify update
-	retrieved, err := repo.GetByID(result.ID)
+	retrieved, err := repo.GetByID(t.Context(), result.ID)
 	if err != nil {
 		t.Fatalf("GetByID failed: %v", err)
 	}
@@ -309,12 +310,12 @@ func TestMembershipRepository_UpdateStatus(t *testing.T) {
 	}
 
 	// Update status without changing since
-	err = repo.UpdateStatus(result.ID, "rejected", nil)
+	err = repo.UpdateStatus(t.Context(), result.ID, "rejected", nil)
 	if err != nil {
 		t.Fatalf("UpdateStatus failed: %v", err)
 	}
 
-	retrieved, err = repo.GetByID(result.ID)
+	retrieved, err = repo.GetByID(t.Context(), result.ID)
 	if err != nil {
 		t.Fatalf("GetByID failed: %v", err)
 	}
@@ -328,12 +329,78 @@ func TestMembershipRepository_UpdateStatus(t *testing.T) {
 	}
 
 	// Try updating non-existent membership
-	err = repo.UpdateStatus("nonexistent", "active", nil)
+	err = repo.UpdateStatus(t.Context(), "nonexistent", "active", nil)
 	if err != ErrMembershipNotFound {
 		t.Errorf("Expected ErrMembershipNotFound, got %v", err)
 	}
 }
 
+func TestMembershipRepository_UpdateRole(t *testing.T) {
+	repo := NewInMemoryMembershipRepository()
+
+	result, err := repo.Upsert(t.Context(), &Membership{
+		SceneID: "scene-123",
+		UserDID: "did:plc:user456",
+		Role:    "member",
+		Status:  "active",
+	})
+	if err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	if err := repo.UpdateRole(t.Context(), result.ID, "admin"); err != nil {
+		t.Fatalf("UpdateRole failed: %v", err)
+	}
+
+	retrieved, err := repo.GetByID(t.Context(), result.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if retrieved.Role != "admin" {
+		t.Errorf("Expected role 'admin', got %s", retrieved.Role)
+	}
+	if retrieved.Status != "active" {
+		t.Errorf("Expected status to be unchanged, got %s", retrieved.Status)
+	}
+
+	if err := repo.UpdateRole(t.Context(), "nonexistent", "admin"); err != ErrMembershipNotFound {
+		t.Errorf("Expected ErrMembershipNotFound, got %v", err)
+	}
+}
+
+func TestMembershipRepository_Delete(t *testing.T) {
+	repo := NewInMemoryMembershipRepository()
+
+	did := "did:plc:user456"
+	rkey := "membership789"
+	result, err := repo.Upsert(t.Context(), &Membership{
+		SceneID:    "scene-123",
+		UserDID:    did,
+		Role:       "member",
+		Status:     "active",
+		RecordDID:  &did,
+		RecordRKey: &rkey,
+	})
+	if err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	if err := repo.Delete(t.Context(), result.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	if _, err := repo.GetByID(t.Context(), result.ID); err != ErrMembershipNotFound {
+		t.Errorf("Expected ErrMembershipNotFound after delete, got %v", err)
+	}
+	if _, err := repo.GetByRecordKey(t.Context(), did, rkey); err != ErrMembershipNotFound {
+		t.Errorf("Expected record key to be released after delete, got %v", err)
+	}
+
+	if err := repo.Delete(t.Context(), result.ID); err != ErrMembershipNotFound {
+		t.Errorf("Expected ErrMembershipNotFound on second delete, got %v", err)
+	}
+}
+
 func TestMembershipRepository_ListByScene(t *testing.T) {
 	repo := NewInMemoryMembershipRepository()
 
@@ -370,13 +437,13 @@ func TestMembershipRepository_ListByScene(t *testing.T) {
 	}
 
 	for _, m := range memberships {
-		if _, err := repo.Upsert(m); err != nil {
+		if _, err := repo.Upsert(t.Context(), m); err != nil {
 			t.Fatalf("Upsert failed: %v", err)
 		}
 	}
 
 	// List all memberships for scene-123
-	allMembers, err := repo.ListByScene("scene-123", "")
+	allMembers, err := repo.ListByScene(t.Context(), "scene-123", "")
 	if err != nil {
 		t.Fatalf("ListByScene failed: %v", err)
 	}
@@ -386,7 +453,7 @@ func TestMembershipRepository_ListByScene(t *testing.T) {
 	}
 
 	// List only active memberships for scene-123
-	activeMembers, err := repo.ListByScene("scene-123", "active")
+	activeMembers, err := repo.ListByScene(t.Context(), "scene-123", "active")
 	if err != nil {
 		t.Fatalf("ListByScene failed: %v", err)
 	}
@@ -396,7 +463,7 @@ func TestMembershipRepository_ListByScene(t *testing.T) {
 	}
 
 	// List pending memberships for scene-123
-	pendingMembers, err := repo.ListByScene("scene-123", "pending")
+	pendingMembers, err := repo.ListByScene(t.Context(), "scene-123", "pending")
 	if err != nil {
 		t.Fatalf("ListByScene failed: %v", err)
 	}
@@ -406,7 +473,7 @@ func TestMembershipRepository_ListByScene(t *testing.T) {
 	}
 
 	// List memberships for scene-456
-	scene456Members, err := repo.ListByScene("scene-456", "")
+	scene456Members, err := repo.ListByScene(t.Context(), "scene-456", "")
 	if err != nil {
 		t.Fatalf("ListByScene failed: %v", err)
 	}
@@ -416,7 +483,7 @@ func TestMembershipRepository_ListByScene(t *testing.T) {
 	}
 
 	// List memberships for non-existent scene
-	nonExistent, err := repo.ListByScene("nonexistent", "")
+	nonExistent, err := repo.ListByScene(t.Context(), "nonexistent", "")
 	if err != nil {
 		t.Fatalf("ListByScene failed: %v", err)
 	}
@@ -426,6 +493,37 @@ func TestMembershipRepository_ListByScene(t *testing.T) {
 	}
 }
 
+func TestMembershipRepository_ListByUser(t *testing.T) {
+	repo := NewInMemoryMembershipRepository()
+
+	for _, m := range []*Membership{
+		{SceneID: "scene-1", UserDID: "did:plc:user1", Role: "member", Status: "active"},
+		{SceneID: "scene-2", UserDID: "did:plc:user1", Role: "member", Status: "banned"},
+		{SceneID: "scene-1", UserDID: "did:plc:user2", Role: "member", Status: "active"},
+	} {
+		if _, err := repo.Upsert(t.Context(), m); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+	}
+
+	result, err := repo.ListByUser(t.Context(), "did:plc:user1")
+	if err != nil {
+		t.Fatalf("ListByUser failed: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 memberships including banned, got %d", len(result))
+	}
+	for _, m := range result {
+		if m.UserDID != "did:plc:user1" {
+			t.Errorf("Expected only did:plc:user1 memberships, got %s", m.UserDID)
+		}
+	}
+
+	if result, _ := repo.ListByUser(t.Context(), "did:plc:nobody"); len(result) != 0 {
+		t.Errorf("Expected no memberships for unknown user, got %d", len(result))
+	}
+}
+
 func TestMembershipRepository_CountByScenes(t *testing.T) {
 repo := NewInMemoryMembershipRepository()
 
@@ -445,13 +543,13 @@ m4 := &Membership{ID: "m4", SceneID: scene2, UserDID: "user4", Status: "active"}
 // Scene 3: no memberships
 
 for _, m := range []*Membership{m1, m2, m3, m4} {
-if _, err := repo.Upsert(m); err != nil {
+if _, err := repo.Upsert(t.Context(), m); err != nil {
 t.Fatalf("Upsert failed: %v", err)
 }
 }
 
 // Test: Count active memberships for all scenes
-counts, err := repo.CountByScenes([]string{scene1, scene2, scene3}, "active")
+counts, err := repo.CountByScenes(t.Context(), []string{scene1, scene2, scene3}, "active")
 if err != nil {
 t.Fatalf("CountByScenes failed: %v", err)
 }
@@ -467,7 +565,7 @@ t.Errorf("Expected 0 active members for scene3, got %d", counts[scene3])
 }
 
 // Test: Count all memberships (including pending)
-allCounts, err := repo.CountByScenes([]string{scene1, scene2}, "")
+allCounts, err := repo.CountByScenes(t.Context(), []string{scene1, scene2}, "")
 if err != nil {
 t.Fatalf("CountByScenes failed: %v", err)
 }
@@ -483,7 +581,7 @@ t.Errorf("Expected 1 total member for scene2, got %d", allCounts[scene2])
 func TestMembershipRepository_CountByScenes_EmptyInput(t *testing.T) {
 repo := NewInMemoryMembershipRepository()
 
-counts, err := repo.CountByScenes([]string{}, "active")
+counts, err := repo.CountByScenes(t.Context(), []string{}, "active")
 if err != nil {
 t.Fatalf("CountByScenes failed: %v", err)
 }
@@ -492,3 +590,46 @@ if len(counts) != 0 {
 t.Errorf("Expected empty map, got %d entries", len(counts))
 }
 }
+
+func TestMembershipRepository_ListByScene_ExcludesBanned(t *testing.T) {
+	repo := NewInMemoryMembershipRepository()
+
+	for did, status := range map[string]string{
+		"did:plc:active": "active",
+		"did:plc:banned": "banned",
+	} {
+		if _, err := repo.Upsert(t.Context(), &Membership{SceneID: "scene-123", UserDID: did, Status: status}); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+	}
+
+	all, err := repo.ListByScene(t.Context(), "scene-123", "")
+	if err != nil {
+		t.Fatalf("ListByScene failed: %v", err)
+	}
+	if len(all) != 1 || all[0].UserDID != "did:plc:active" {
+		t.Errorf("Expected only the active member without a status filter, got %d memberships", len(all))
+	}
+
+	banned, err := repo.ListByScene(t.Context(), "scene-123", "banned")
+	if err != nil {
+		t.Fatalf("ListByScene failed: %v", err)
+	}
+	if len(banned) != 1 || banned[0].UserDID != "did:plc:banned" {
+		t.Errorf("Expected the banned member when filtering by banned, got %d memberships", len(banned))
+	}
+}
+
+func TestMembershipRepository_CanceledContext(t *testing.T) {
+	repo := NewInMemoryMembershipRepository()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := repo.Upsert(ctx, &Membership{SceneID: "scene-1", UserDID: "did:plc:user", Role: "member", Status: "active"}); err != context.Canceled {
+		t.Errorf("Upsert: expected context.Canceled, got %v", err)
+	}
+	if _, err := repo.ListByScene(ctx, "scene-1", ""); err != context.Canceled {
+		t.Errorf("ListByScene: expected context.Canceled, got %v", err)
+	}
+}
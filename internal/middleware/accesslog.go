@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// routeKey is the context key for the route holder installed by AccessLog.
+type routeKey struct{}
+
+// routeHolder carries the matched route template back out to AccessLog.
+type routeHolder struct {
+	route string
+}
+
+// setRoute records the matched route template for an enclosing AccessLog
+// middleware. It is a no-op when no AccessLog is installed.
+func setRoute(ctx context.Context, route string) {
+	if holder, ok := ctx.Value(routeKey{}).(*routeHolder); ok {
+		holder.route = route
+	}
+}
+
+// AccessLog is a middleware that logs one structured line per request with
+// method, route template, status, duration, response size, request ID, user
+// DID (if present), and error code (for error responses).
+//
+// Only the route template is logged, never the raw path, query string, or
+// request body, so IDs, coordinates, and payloads stay out of the logs. The
+// template is supplied by a Metrics middleware placed inside AccessLog;
+// without one every request is logged with UnmatchedRoute.
+func AccessLog(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			holder := &routeHolder{route: UnmatchedRoute}
+			ctx := context.WithValue(r.Context(), routeKey{}, holder)
+			r = r.WithContext(ctx)
+
+			// Wrap response writer to capture status, size, and context
+			rw := newResponseWriter(w, ctx)
+			next.ServeHTTP(rw, r)
+
+			// Get the final context (may have been updated by handlers)
+			finalCtx := rw.Context()
+
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("route", holder.route),
+				slog.Int("status", rw.statusCode),
+				slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+				slog.Int("size", rw.size),
+			}
+			if requestID := GetRequestID(finalCtx); requestID != "" {
+				attrs = append(attrs, slog.String("request_id", requestID))
+			}
+			if userDID := GetUserDID(finalCtx); userDID != "" {
+				attrs = append(attrs, slog.String("user_did", userDID))
+			}
+			if rw.statusCode >= 400 {
+				if errorCode := GetErrorCode(finalCtx); errorCode != "" {
+					attrs = append(attrs, slog.String("error_code", errorCode))
+				}
+			}
+
+			level := slog.LevelInfo
+			if rw.statusCode >= 500 {
+				level = slog.LevelError
+			} else if rw.statusCode >= 400 {
+				level = slog.LevelWarn
+			}
+			logger.LogAttrs(finalCtx, level, "http request", attrs...)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// accessLogEntry represents a parsed JSON access log line for testing.
+type accessLogEntry struct {
+	Level      string `json:"level"`
+	Msg        string `json:"msg"`
+	Method     string `json:"method"`
+	Route      string `json:"route"`
+	Status     int    `json:"status"`
+	DurationMS *int64 `json:"duration_ms"`
+	Size       int    `json:"size"`
+	RequestID  string `json:"request_id"`
+	UserDID    string `json:"user_did"`
+	ErrorCode  string `json:"error_code"`
+}
+
+func TestAccessLog_LogsRequestAttributes(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := newTestLogger(buf)
+	metrics := NewHTTPMetrics("/scenes/{id}/posts")
+
+	secretBody := `{"text":"secret message","precise_point":{"lat":40.7128,"lng":-74.0060}}`
+	handler := RequestID(AccessLog(logger)(Metrics(metrics)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+		UpdateResponseContext(w, SetUserDID(r.Context(), "did:plc:alice"))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"post-1"}`))
+	}))))
+
+	req := httptest.NewRequest(http.MethodPost, "/scenes/a1b2c3/posts?lat=40.7128&lng=-74.0060", strings.NewReader(secretBody))
+	req.Header.Set(RequestIDHeader, "req-123")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var entry accessLogEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log entry: %v, log: %s", err, buf.String())
+	}
+
+	if entry.Msg != "http request" {
+		t.Errorf("expected msg 'http request', got %q", entry.Msg)
+	}
+	if entry.Level != "INFO" {
+		t.Errorf("expected level INFO, got %s", entry.Level)
+	}
+	if entry.Method != http.MethodPost {
+		t.Errorf("expected method POST, got %s", entry.Method)
+	}
+	if entry.Route != "/scenes/{id}/posts" {
+		t.Errorf("expected route template /scenes/{id}/posts, got %s", entry.Route)
+	}
+	if entry.Status != http.StatusCreated {
+		t.Errorf("expected status 201, got %d", entry.Status)
+	}
+	if entry.DurationMS == nil {
+		t.Error("expected duration_ms to be logged")
+	}
+	if entry.RequestID != "req-123" {
+		t.Errorf("expected request_id req-123, got %q", entry.RequestID)
+	}
+	if entry.UserDID != "did:plc:alice" {
+		t.Errorf("expected user_did did:plc:alice, got %q", entry.UserDID)
+	}
+
+	// Bodies, raw paths, and coordinates never reach the log
+	logged := buf.String()
+	for _, forbidden := range []string{"secret message", "40.7128", "-74.0060", "a1b2c3", "post-1"} {
+		if strings.Contains(logged, forbidden) {
+			t.Errorf("expected log to omit %q, got %s", forbidden, logged)
+		}
+	}
+}
+
+func TestAccessLog_ErrorResponse(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := newTestLogger(buf)
+
+	handler := AccessLog(logger)(Metrics(NewHTTPMetrics("/events/{id}"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		UpdateResponseContext(w, SetErrorCode(r.Context(), "not_found"))
+		w.WriteHeader(http.StatusNotFound)
+	})))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/events/missing", nil))
+
+	var entry accessLogEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log entry: %v, log: %s", err, buf.String())
+	}
+	if entry.Level != "WARN" {
+		t.Errorf("expected level WARN for 4xx, got %s", entry.Level)
+	}
+	if entry.ErrorCode != "not_found" {
+		t.Errorf("expected error_code not_found, got %q", entry.ErrorCode)
+	}
+	if entry.Route != "/events/{id}" {
+		t.Errorf("expected route /events/{id}, got %s", entry.Route)
+	}
+}
+
+func TestAccessLog_WithoutMetricsUsesUnmatchedRoute(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := newTestLogger(buf)
+
+	handler := AccessLog(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/did:plc:bob", nil))
+
+	var entry accessLogEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log entry: %v, log: %s", err, buf.String())
+	}
+	if entry.Route != UnmatchedRoute {
+		t.Errorf("expected route %s, got %s", UnmatchedRoute, entry.Route)
+	}
+	if entry.Status != http.StatusOK {
+		t.Errorf("expected default status 200, got %d", entry.Status)
+	}
+}
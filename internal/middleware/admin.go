@@ -0,0 +1,39 @@
+// Package middleware provides HTTP middleware components for the API server.
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// errCodeForbidden mirrors api.ErrCodeForbidden.
+const errCodeForbidden = "forbidden"
+
+// RequireAdmin is a middleware that restricts a route to platform admins.
+// It must run after RequireAuth: the authenticated DID from GetUserDID is
+// checked against adminDIDs. Requests without a DID receive 401
+// Unauthorized and authenticated non-admins receive 403 Forbidden.
+func RequireAdmin(adminDIDs []string) func(http.Handler) http.Handler {
+	admins := make(map[string]bool, len(adminDIDs))
+	for _, did := range adminDIDs {
+		if did != "" {
+			admins[did] = true
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			did := GetUserDID(r.Context())
+			if did == "" {
+				writeAuthError(w, r, "Authentication required")
+				return
+			}
+			if !admins[did] {
+				slog.WarnContext(r.Context(), "non-admin denied access to admin route", "user_did", did)
+				writeJSONError(w, r, http.StatusForbidden, errCodeForbidden, "Admin access required")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireAdmin(t *testing.T) {
+	var called bool
+	handler := RequireAdmin([]string{"did:plc:admin"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name       string
+		did        string
+		wantStatus int
+		wantCode   string
+	}{
+		{"admin", "did:plc:admin", http.StatusNoContent, ""},
+		{"non-admin", "did:plc:user", http.StatusForbidden, errCodeForbidden},
+		{"unauthenticated", "", http.StatusUnauthorized, errCodeAuthFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = false
+			req := httptest.NewRequest(http.MethodPost, "/admin/scenes/abc/hide", nil)
+			if tt.did != "" {
+				req = req.WithContext(SetUserDID(req.Context(), tt.did))
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if called != (tt.wantCode == "") {
+				t.Errorf("expected handler called=%v, got %v", tt.wantCode == "", called)
+			}
+			if tt.wantCode == "" {
+				return
+			}
+			var body map[string]map[string]string
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode error body: %v", err)
+			}
+			if body["error"]["code"] != tt.wantCode {
+				t.Errorf("expected error code %s, got %s", tt.wantCode, body["error"]["code"])
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Package middleware provides HTTP middleware components for the API server.
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/onnwee/subcults/internal/did"
+)
+
+// errCodeAuthFailed mirrors api.ErrCodeAuthFailed; the api package imports
+// middleware, so the constant cannot be shared directly.
+const errCodeAuthFailed = "auth_failed"
+
+// bearerPrefix is the Authorization header scheme accepted by RequireAuth.
+const bearerPrefix = "Bearer "
+
+// ErrMissingToken is returned when the Authorization header has no bearer token.
+var ErrMissingToken = errors.New("missing bearer token")
+
+// TokenVerifier verifies a bearer token and returns the authenticated user's DID.
+// Implementations should return an error for invalid or expired tokens.
+type TokenVerifier interface {
+	VerifyToken(ctx context.Context, token string) (did string, err error)
+}
+
+// bearerToken extracts the bearer token from the Authorization header.
+// The scheme is matched case-insensitively per RFC 6750.
+func bearerToken(r *http.Request) (string, error) {
+	header := r.Header.Get("Authorization")
+	if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return "", ErrMissingToken
+	}
+	token := strings.TrimSpace(header[len(bearerPrefix):])
+	if token == "" {
+		return "", ErrMissingToken
+	}
+	return token, nil
+}
+
+// RequireAuth is a middleware that authenticates requests with a bearer token.
+// The token is verified via verifier and the resulting DID is stored in the
+// request context with SetUserDID. Requests with a missing, invalid, or
+// expired token, or whose token resolves to a malformed DID, receive 401
+// Unauthorized with the auth_failed error code.
+func RequireAuth(verifier TokenVerifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, err := bearerToken(r)
+			if err != nil {
+				writeAuthError(w, r, "Authentication required")
+				return
+			}
+
+			userDID, err := verifier.VerifyToken(r.Context(), token)
+			if err == nil {
+				err = did.ValidateDID(userDID)
+			}
+			if err != nil {
+				slog.DebugContext(r.Context(), "bearer token verification failed", "error", err)
+				writeAuthError(w, r, "Invalid or expired token")
+				return
+			}
+
+			ctx := SetUserDID(r.Context(), userDID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// OptionalAuth is a middleware for routes that serve both anonymous and
+// authenticated users. When a valid bearer token is present the DID is stored
+// with SetUserDID; otherwise the request continues anonymously. Missing,
+// malformed, or invalid tokens, and tokens resolving to a malformed DID, never
+// cause a 401. Any DID already in the
+// context is cleared for anonymous requests so handlers only see verified
+// identities.
+func OptionalAuth(verifier TokenVerifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userDID := ""
+			if token, err := bearerToken(r); err == nil {
+				verified, err := verifier.VerifyToken(r.Context(), token)
+				if err == nil {
+					err = did.ValidateDID(verified)
+				}
+				if err != nil {
+					slog.DebugContext(r.Context(), "ignoring unverified bearer token", "error", err)
+				} else {
+					userDID = verified
+				}
+			}
+
+			ctx := SetUserDID(r.Context(), userDID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// writeAuthError writes a 401 response in the standard API error format.
+func writeAuthError(w http.ResponseWriter, r *http.Request, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="subcults"`)
+	writeJSONError(w, r, http.StatusUnauthorized, errCodeAuthFailed, message)
+}
+
+// errorResponse is the standard API error envelope. It mirrors
+// api.ErrorResponse, which middleware cannot import.
+type errorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// writeJSONError writes an error response in the standard API error format:
+// {"error": {"code": "...", "message": "..."}, "request_id": "..."}. It mirrors
+// api.WriteError for middleware, which cannot import the api package.
+func writeJSONError(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	// Set error code for logging middleware
+	ctx := SetErrorCode(r.Context(), code)
+	UpdateResponseContext(w, ctx)
+
+	var body errorResponse
+	body.Error.Code = code
+	body.Error.Message = message
+	body.RequestID = GetRequestID(ctx)
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.ErrorContext(ctx, "failed to write error response", "error", err)
+	}
+}
@@ -0,0 +1,268 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var (
+	errStubInvalidToken = errors.New("invalid token")
+	errStubExpiredToken = errors.New("token has expired")
+)
+
+// stubToken is a token known to stubVerifier.
+type stubToken struct {
+	did       string
+	expiresAt time.Time
+}
+
+// stubVerifier is a TokenVerifier backed by a fixed set of tokens.
+type stubVerifier struct {
+	tokens map[string]stubToken
+	now    time.Time
+}
+
+func (v *stubVerifier) VerifyToken(ctx context.Context, token string) (string, error) {
+	t, ok := v.tokens[token]
+	if !ok {
+		return "", errStubInvalidToken
+	}
+	if !v.now.Before(t.expiresAt) {
+		return "", errStubExpiredToken
+	}
+	return t.did, nil
+}
+
+func newStubVerifier() *stubVerifier {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	return &stubVerifier{
+		now: now,
+		tokens: map[string]stubToken{
+			"valid-token":   {did: "did:plc:alice", expiresAt: now.Add(15 * time.Minute)},
+			"expired-token": {did: "did:plc:bob", expiresAt: now.Add(-time.Minute)},
+			"no-did-token":  {did: "", expiresAt: now.Add(15 * time.Minute)},
+			"bad-did-token": {did: "alice", expiresAt: now.Add(15 * time.Minute)},
+		},
+	}
+}
+
+func TestRequireAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		authHeader string
+		wantStatus int
+		wantDID    string
+	}{
+		{
+			name:       "valid token",
+			authHeader: "Bearer valid-token",
+			wantStatus: http.StatusOK,
+			wantDID:    "did:plc:alice",
+		},
+		{
+			name:       "case-insensitive scheme",
+			authHeader: "bearer valid-token",
+			wantStatus: http.StatusOK,
+			wantDID:    "did:plc:alice",
+		},
+		{
+			name:       "expired token",
+			authHeader: "Bearer expired-token",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "unknown token",
+			authHeader: "Bearer forged-token",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "token without DID",
+			authHeader: "Bearer no-did-token",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "token with malformed DID",
+			authHeader: "Bearer bad-did-token",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "missing header",
+			authHeader: "",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "wrong scheme",
+			authHeader: "Basic dXNlcjpwYXNz",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "empty bearer token",
+			authHeader: "Bearer   ",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+			var gotDID string
+			handler := RequireAuth(newStubVerifier())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				gotDID = GetUserDID(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+
+			if tt.wantStatus == http.StatusOK {
+				if !called {
+					t.Fatal("expected next handler to be called")
+				}
+				if gotDID != tt.wantDID {
+					t.Errorf("expected DID %q in context, got %q", tt.wantDID, gotDID)
+				}
+				return
+			}
+
+			if called {
+				t.Error("expected next handler not to be called")
+			}
+			if rr.Header().Get("WWW-Authenticate") == "" {
+				t.Error("expected WWW-Authenticate header on 401 response")
+			}
+
+			var body struct {
+				Error struct {
+					Code    string `json:"code"`
+					Message string `json:"message"`
+				} `json:"error"`
+			}
+			if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if body.Error.Code != "auth_failed" {
+				t.Errorf("expected error code auth_failed, got %q", body.Error.Code)
+			}
+		})
+	}
+}
+
+func TestRequireAuth_SetsErrorCodeForLogging(t *testing.T) {
+	inner := RequireAuth(newStubVerifier())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected next handler not to be called")
+	}))
+
+	// Wrap in a responseWriter as the logging middleware does
+	rr := httptest.NewRecorder()
+	rw := newResponseWriter(rr, context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	inner.ServeHTTP(rw, req)
+
+	if got := GetErrorCode(rw.Context()); got != errCodeAuthFailed {
+		t.Errorf("expected error code %q in response context, got %q", errCodeAuthFailed, got)
+	}
+}
+
+func TestOptionalAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		authHeader string
+		wantDID    string
+	}{
+		{name: "anonymous", authHeader: "", wantDID: ""},
+		{name: "valid token", authHeader: "Bearer valid-token", wantDID: "did:plc:alice"},
+		{name: "expired token", authHeader: "Bearer expired-token", wantDID: ""},
+		{name: "unknown token", authHeader: "Bearer forged-token", wantDID: ""},
+		{name: "malformed DID", authHeader: "Bearer bad-did-token", wantDID: ""},
+		{name: "malformed header", authHeader: "Bearer", wantDID: ""},
+		{name: "wrong scheme", authHeader: "Basic dXNlcjpwYXNz", wantDID: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+			var gotDID string
+			handler := OptionalAuth(newStubVerifier())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				gotDID = GetUserDID(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/public", nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+			}
+			if !called {
+				t.Fatal("expected next handler to be called")
+			}
+			if gotDID != tt.wantDID {
+				t.Errorf("expected DID %q in context, got %q", tt.wantDID, gotDID)
+			}
+		})
+	}
+}
+
+func TestOptionalAuth_ClearsUnverifiedDID(t *testing.T) {
+	var gotDID string
+	handler := OptionalAuth(newStubVerifier())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotDID = GetUserDID(r.Context())
+	}))
+
+	// A DID placed in context upstream must not leak through without a valid token
+	req := httptest.NewRequest(http.MethodGet, "/public", nil)
+	req = req.WithContext(SetUserDID(req.Context(), "did:plc:mallory"))
+	req.Header.Set("Authorization", "Bearer forged-token")
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if gotDID != "" {
+		t.Errorf("expected anonymous request, got DID %q", gotDID)
+	}
+}
+
+func TestRequireAuth_ErrorIncludesRequestID(t *testing.T) {
+	handler := RequestID(RequireAuth(newStubVerifier())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected next handler not to be called")
+	})))
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set(RequestIDHeader, "req-auth-123")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
+	var body errorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if body.Error.Code != errCodeAuthFailed {
+		t.Errorf("expected error code %q, got %q", errCodeAuthFailed, body.Error.Code)
+	}
+	if body.RequestID != "req-auth-123" {
+		t.Errorf("expected request_id req-auth-123, got %q", body.RequestID)
+	}
+}
@@ -0,0 +1,31 @@
+// Package middleware provides HTTP middleware components for the API server.
+package middleware
+
+import "net/http"
+
+// DefaultMaxBodyBytes is the default request body limit for create/update routes (1 MiB).
+const DefaultMaxBodyBytes int64 = 1 << 20
+
+// errCodePayloadTooLarge mirrors api.ErrCodePayloadTooLarge.
+const errCodePayloadTooLarge = "payload_too_large"
+
+// MaxBodyBytes is a middleware that bounds request body size to limit bytes.
+// Requests whose Content-Length exceeds the limit are rejected up front with
+// 413 Request Entity Too Large. Otherwise the body is wrapped in
+// http.MaxBytesReader, so reads past the limit (e.g. chunked bodies) fail with
+// *http.MaxBytesError for the handler to report as 413.
+func MaxBodyBytes(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				writeJSONError(w, r, http.StatusRequestEntityTooLarge, errCodePayloadTooLarge, "Request body too large")
+				return
+			}
+
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = http.MaxBytesReader(w, r.Body, limit)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
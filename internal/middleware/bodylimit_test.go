@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaxBodyBytes_RejectsOversizedContentLength(t *testing.T) {
+	var called bool
+	handler := MaxBodyBytes(16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(strings.Repeat("a", 17)))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected status %d, got %d", http.StatusRequestEntityTooLarge, rr.Code)
+	}
+	if called {
+		t.Error("expected next handler not to be called")
+	}
+
+	var body struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if body.Error.Code != errCodePayloadTooLarge {
+		t.Errorf("expected error code %q, got %q", errCodePayloadTooLarge, body.Error.Code)
+	}
+	if body.Error.Message == "" {
+		t.Error("expected error message")
+	}
+}
+
+func TestMaxBodyBytes_LimitsUnknownLengthBody(t *testing.T) {
+	var readErr error
+	handler := MaxBodyBytes(16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, readErr = io.ReadAll(r.Body)
+	}))
+
+	// Unknown length (e.g. chunked encoding) bypasses the Content-Length check
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(strings.Repeat("a", 17)))
+	req.ContentLength = -1
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var maxBytesErr *http.MaxBytesError
+	if !errors.As(readErr, &maxBytesErr) {
+		t.Fatalf("expected *http.MaxBytesError, got %v", readErr)
+	}
+	if maxBytesErr.Limit != 16 {
+		t.Errorf("expected limit 16, got %d", maxBytesErr.Limit)
+	}
+}
+
+func TestMaxBodyBytes_AllowsBodyWithinLimit(t *testing.T) {
+	var got string
+	handler := MaxBodyBytes(16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("unexpected read error: %v", err)
+		}
+		got = string(b)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(strings.Repeat("a", 16)))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if got != strings.Repeat("a", 16) {
+		t.Errorf("expected full body to be read, got %d bytes", len(got))
+	}
+}
+
+func TestMaxBodyBytes_ErrorIncludesRequestID(t *testing.T) {
+	handler := RequestID(MaxBodyBytes(16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected next handler not to be called")
+	})))
+
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(strings.Repeat("a", 17)))
+	req.Header.Set(RequestIDHeader, "req-body-123")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected status %d, got %d", http.StatusRequestEntityTooLarge, rr.Code)
+	}
+	var body errorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if body.RequestID != "req-body-123" {
+		t.Errorf("expected request_id req-body-123, got %q", body.RequestID)
+	}
+}
+
+func TestMaxBodyBytes_ErrorOmitsRequestIDWithoutMiddleware(t *testing.T) {
+	handler := MaxBodyBytes(16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(strings.Repeat("a", 17)))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if strings.Contains(rr.Body.String(), "request_id") {
+		t.Errorf("expected no request_id without the RequestID middleware, got %s", rr.Body.String())
+	}
+}
@@ -0,0 +1,237 @@
+// Package middleware provides HTTP middleware components for the API server.
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultCORSMethods are the methods allowed when CORSConfig.AllowedMethods is empty.
+var defaultCORSMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// defaultCORSHeaders are the request headers allowed when CORSConfig.AllowedHeaders is empty.
+var defaultCORSHeaders = []string{
+	"Authorization",
+	"Content-Type",
+	RequestIDHeader,
+}
+
+// CORSConfig defines the cross-origin resource sharing policy.
+type CORSConfig struct {
+	// AllowedOrigins lists origins permitted to make cross-origin requests.
+	// Entries may be an exact origin ("https://subcults.app"), a wildcard
+	// subdomain with or without a scheme ("https://*.subcults.app",
+	// "*.subcults.app"), or "*" to allow any origin. Matching origins are
+	// echoed back, except that origins allowed only by "*" get a literal "*"
+	// and no credentials, so any site can read public responses but never
+	// credentialed ones.
+	AllowedOrigins []string
+	// AllowedMethods lists methods permitted in preflight requests.
+	// Defaults to GET, POST, PATCH, and DELETE.
+	AllowedMethods []string
+	// AllowedHeaders lists request headers permitted in preflight requests.
+	// Defaults to Authorization, Content-Type, and X-Request-ID.
+	AllowedHeaders []string
+	// ExposedHeaders lists response headers readable by the browser.
+	ExposedHeaders []string
+	// AllowCredentials permits cookies and Authorization headers on
+	// cross-origin requests.
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache preflight results.
+	// Zero omits the Access-Control-Max-Age header.
+	MaxAge time.Duration
+}
+
+// originMatcher matches an Origin header value against a single AllowedOrigins entry.
+type originMatcher struct {
+	any    bool   // "*"
+	scheme string // required scheme, empty for any
+	exact  string // exact host[:port] match
+	suffix string // wildcard suffix including leading dot, e.g. ".subcults.app"
+}
+
+// newOriginMatcher parses an AllowedOrigins entry.
+func newOriginMatcher(pattern string) originMatcher {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if pattern == "*" {
+		return originMatcher{any: true}
+	}
+
+	var m originMatcher
+	if scheme, host, ok := strings.Cut(pattern, "://"); ok {
+		m.scheme = scheme
+		pattern = host
+	}
+	if strings.HasPrefix(pattern, "*.") {
+		m.suffix = pattern[1:]
+	} else {
+		m.exact = pattern
+	}
+	return m
+}
+
+// matches reports whether origin (scheme plus lowercased host[:port]) is allowed.
+func (m originMatcher) matches(scheme, host string) bool {
+	if m.any {
+		return true
+	}
+	if m.scheme != "" && m.scheme != scheme {
+		return false
+	}
+	if m.suffix != "" {
+		// Require at least one label before the suffix so the apex domain
+		// doesn't match a subdomain wildcard
+		return len(host) > len(m.suffix) && strings.HasSuffix(host, m.suffix)
+	}
+	return host == m.exact
+}
+
+// corsPolicy is the compiled form of CORSConfig.
+type corsPolicy struct {
+	origins          []originMatcher
+	methods          map[string]bool
+	headers          map[string]bool
+	allowMethods     string
+	allowHeaders     string
+	exposeHeaders    string
+	allowCredentials bool
+	maxAge           string
+}
+
+// newCORSPolicy compiles config, applying defaults for empty fields.
+func newCORSPolicy(config CORSConfig) *corsPolicy {
+	methods := config.AllowedMethods
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	headers := config.AllowedHeaders
+	if len(headers) == 0 {
+		headers = defaultCORSHeaders
+	}
+
+	p := &corsPolicy{
+		methods:          make(map[string]bool, len(methods)),
+		headers:          make(map[string]bool, len(headers)),
+		allowMethods:     strings.Join(methods, ", "),
+		allowHeaders:     strings.Join(headers, ", "),
+		exposeHeaders:    strings.Join(config.ExposedHeaders, ", "),
+		allowCredentials: config.AllowCredentials,
+	}
+	for _, origin := range config.AllowedOrigins {
+		p.origins = append(p.origins, newOriginMatcher(origin))
+	}
+	for _, method := range methods {
+		p.methods[strings.ToUpper(method)] = true
+	}
+	for _, header := range headers {
+		p.headers[http.CanonicalHeaderKey(header)] = true
+	}
+	if config.MaxAge > 0 {
+		p.maxAge = strconv.Itoa(int(config.MaxAge.Seconds()))
+	}
+	return p
+}
+
+// originAllowed reports whether the Origin header value is permitted, and
+// whether only a "*" entry permits it.
+func (p *corsPolicy) originAllowed(origin string) (allowed, anyOnly bool) {
+	u, err := url.Parse(origin)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return false, false
+	}
+	scheme, host := strings.ToLower(u.Scheme), strings.ToLower(u.Host)
+	for _, m := range p.origins {
+		if !m.matches(scheme, host) {
+			continue
+		}
+		if !m.any {
+			return true, false
+		}
+		allowed, anyOnly = true, true
+	}
+	return allowed, anyOnly
+}
+
+// preflightAllowed reports whether the requested method and headers are permitted.
+func (p *corsPolicy) preflightAllowed(r *http.Request) bool {
+	if !p.methods[strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))] {
+		return false
+	}
+	for _, header := range strings.Split(r.Header.Get("Access-Control-Request-Headers"), ",") {
+		header = strings.TrimSpace(header)
+		if header != "" && !p.headers[http.CanonicalHeaderKey(header)] {
+			return false
+		}
+	}
+	return true
+}
+
+// setAllowOrigin writes the Access-Control-Allow-Origin and credentials headers.
+// Origins allowed only by "*" get a literal "*" without credentials; browsers
+// reject that combination, and echoing the origin instead would let any site
+// make credentialed requests.
+func (p *corsPolicy) setAllowOrigin(h http.Header, origin string, anyOnly bool) {
+	if anyOnly {
+		h.Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+	h.Set("Access-Control-Allow-Origin", origin)
+	if p.allowCredentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// CORS is a middleware that applies a cross-origin resource sharing policy.
+// Preflight OPTIONS requests are answered with 204 No Content and never reach
+// the next handler. Requests from disallowed origins are served normally but
+// without Access-Control-Allow-Origin, so the browser blocks the response.
+func CORS(config CORSConfig) func(http.Handler) http.Handler {
+	policy := newCORSPolicy(config)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				// Not a cross-origin request
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			// The response varies by origin even when it is rejected
+			h.Add("Vary", "Origin")
+			allowed, anyOnly := policy.originAllowed(origin)
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				h.Add("Vary", "Access-Control-Request-Method")
+				h.Add("Vary", "Access-Control-Request-Headers")
+				if allowed && policy.preflightAllowed(r) {
+					policy.setAllowOrigin(h, origin, anyOnly)
+					h.Set("Access-Control-Allow-Methods", policy.allowMethods)
+					h.Set("Access-Control-Allow-Headers", policy.allowHeaders)
+					if policy.maxAge != "" {
+						h.Set("Access-Control-Max-Age", policy.maxAge)
+					}
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if allowed {
+				policy.setAllowOrigin(h, origin, anyOnly)
+				if policy.exposeHeaders != "" {
+					h.Set("Access-Control-Expose-Headers", policy.exposeHeaders)
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newCORSTestHandler(config CORSConfig) (http.Handler, *bool) {
+	called := new(bool)
+	handler := CORS(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	return handler, called
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	handler, called := newCORSTestHandler(CORSConfig{
+		AllowedOrigins:   []string{"https://subcults.app"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	})
+
+	req := httptest.NewRequest(http.MethodOptions, "/scenes", nil)
+	req.Header.Set("Origin", "https://subcults.app")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPatch)
+	req.Header.Set("Access-Control-Request-Headers", "authorization, content-type")
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNoContent {
+		t.Errorf("expected status %d, got %d", http.StatusNoContent, rr.Code)
+	}
+	if *called {
+		t.Error("expected preflight not to reach next handler")
+	}
+
+	want := map[string]string{
+		"Access-Control-Allow-Origin":      "https://subcults.app",
+		"Access-Control-Allow-Methods":     "GET, POST, PATCH, DELETE",
+		"Access-Control-Allow-Headers":     "Authorization, Content-Type, X-Request-ID",
+		"Access-Control-Allow-Credentials": "true",
+		"Access-Control-Max-Age":           "600",
+	}
+	for header, value := range want {
+		if got := rr.Header().Get(header); got != value {
+			t.Errorf("expected %s %q, got %q", header, value, got)
+		}
+	}
+}
+
+func TestCORS_PreflightRejected(t *testing.T) {
+	tests := []struct {
+		name           string
+		origin         string
+		method         string
+		requestHeaders string
+	}{
+		{"disallowed origin", "https://evil.example", http.MethodGet, ""},
+		{"disallowed method", "https://subcults.app", http.MethodPut, ""},
+		{"disallowed header", "https://subcults.app", http.MethodPost, "X-Custom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, called := newCORSTestHandler(CORSConfig{
+				AllowedOrigins: []string{"https://subcults.app"},
+			})
+
+			req := httptest.NewRequest(http.MethodOptions, "/scenes", nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", tt.method)
+			if tt.requestHeaders != "" {
+				req.Header.Set("Access-Control-Request-Headers", tt.requestHeaders)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			// Rejection is signalled by omitting CORS headers, not by an error status
+			if rr.Code != http.StatusNoContent {
+				t.Errorf("expected status %d, got %d", http.StatusNoContent, rr.Code)
+			}
+			if *called {
+				t.Error("expected preflight not to reach next handler")
+			}
+			if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "" {
+				t.Errorf("expected no Access-Control-Allow-Origin, got %q", got)
+			}
+		})
+	}
+}
+
+func TestCORS_SimpleRequests(t *testing.T) {
+	tests := []struct {
+		name        string
+		origin      string
+		wantAllowed bool
+	}{
+		{"allowed origin", "https://subcults.app", true},
+		{"allowed origin case-insensitive", "HTTPS://Subcults.App", true},
+		{"disallowed origin", "https://evil.example", false},
+		{"disallowed scheme", "http://subcults.app", false},
+		{"no origin", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, called := newCORSTestHandler(CORSConfig{
+				AllowedOrigins: []string{"https://subcults.app"},
+				ExposedHeaders: []string{RequestIDHeader},
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/scenes", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			// Requests are always served; the browser enforces the policy
+			if rr.Code != http.StatusOK || !*called {
+				t.Fatalf("expected request to reach handler with 200, got %d (called=%v)", rr.Code, *called)
+			}
+
+			gotOrigin := rr.Header().Get("Access-Control-Allow-Origin")
+			if tt.wantAllowed {
+				if gotOrigin != tt.origin {
+					t.Errorf("expected Access-Control-Allow-Origin %q, got %q", tt.origin, gotOrigin)
+				}
+				if got := rr.Header().Get("Access-Control-Expose-Headers"); got != RequestIDHeader {
+					t.Errorf("expected Access-Control-Expose-Headers %q, got %q", RequestIDHeader, got)
+				}
+			} else if gotOrigin != "" {
+				t.Errorf("expected no Access-Control-Allow-Origin, got %q", gotOrigin)
+			}
+			if got := rr.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+				t.Errorf("expected no Access-Control-Allow-Credentials without AllowCredentials, got %q", got)
+			}
+		})
+	}
+}
+
+func TestCORS_WildcardOriginNeverGetsCredentials(t *testing.T) {
+	handler, _ := newCORSTestHandler(CORSConfig{
+		AllowedOrigins:   []string{"*"},
+		AllowCredentials: true,
+	})
+
+	for _, method := range []string{http.MethodGet, http.MethodOptions} {
+		t.Run(method, func(t *testing.T) {
+			req := httptest.NewRequest(method, "/scenes", nil)
+			req.Header.Set("Origin", "https://anything.example")
+			if method == http.MethodOptions {
+				req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+				t.Errorf("expected Access-Control-Allow-Origin *, got %q", got)
+			}
+			if got := rr.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+				t.Errorf("expected no Access-Control-Allow-Credentials, got %q", got)
+			}
+		})
+	}
+}
+
+func TestCORS_ListedOriginKeepsCredentialsAlongsideWildcard(t *testing.T) {
+	handler, _ := newCORSTestHandler(CORSConfig{
+		AllowedOrigins:   []string{"*", "https://subcults.app"},
+		AllowCredentials: true,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/scenes", nil)
+	req.Header.Set("Origin", "https://subcults.app")
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "https://subcults.app" {
+		t.Errorf("expected origin to be echoed, got %q", got)
+	}
+	if got := rr.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("expected Access-Control-Allow-Credentials true, got %q", got)
+	}
+	if got := rr.Header().Get("Vary"); got != "Origin" {
+		t.Errorf("expected Vary: Origin, got %q", got)
+	}
+}
+
+func TestOriginMatcher_Subdomain(t *testing.T) {
+	tests := []struct {
+		pattern string
+		origin  string
+		want    bool
+	}{
+		{"*.subcults.app", "https://www.subcults.app", true},
+		{"*.subcults.app", "http://www.subcults.app", true},
+		{"*.subcults.app", "https://a.b.subcults.app", true},
+		{"*.subcults.app", "https://subcults.app", false},
+		{"*.subcults.app", "https://evilsubcults.app", false},
+		{"*.subcults.app", "https://subcults.app.evil.example", false},
+		{"https://*.subcults.app", "https://www.subcults.app", true},
+		{"https://*.subcults.app", "http://www.subcults.app", false},
+		{"http://localhost:5173", "http://localhost:5173", true},
+		{"http://localhost:5173", "http://localhost:3000", false},
+		{"https://subcults.app", "not a url", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.origin, func(t *testing.T) {
+			policy := newCORSPolicy(CORSConfig{AllowedOrigins: []string{tt.pattern}})
+			if got, _ := policy.originAllowed(tt.origin); got != tt.want {
+				t.Errorf("originAllowed(%q) with pattern %q = %v, want %v", tt.origin, tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Idempotency header names.
+const (
+	// IdempotencyKeyHeader carries the client-chosen key for a retryable request.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set to "true" on responses replayed from the store.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// Idempotency defaults and limits.
+const (
+	// DefaultIdempotencyTTL is how long a completed response is kept for replay.
+	DefaultIdempotencyTTL = 24 * time.Hour
+	// MaxIdempotencyKeyLength bounds the Idempotency-Key header value.
+	MaxIdempotencyKeyLength = 255
+)
+
+// Error codes mirroring the api package.
+const (
+	errCodeValidation = "validation_error"
+	errCodeConflict   = "conflict"
+)
+
+// Idempotency store errors.
+var (
+	// ErrIdempotencyInProgress means another request with the key hasn't finished.
+	ErrIdempotencyInProgress = errors.New("request with this idempotency key is in progress")
+	// ErrIdempotencyKeyReused means the key was used for a different request.
+	ErrIdempotencyKeyReused = errors.New("idempotency key was used for a different request")
+)
+
+// IdempotentResponse is a stored response replayed for retried requests.
+type IdempotentResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// IdempotencyStore tracks idempotency keys and their responses.
+// This allows for different backends (in-memory, Redis, etc.).
+type IdempotencyStore interface {
+	// Begin reserves key for a request identified by fingerprint. It returns
+	// the stored response if the request already completed, or nil if the
+	// caller now holds the reservation and must call Complete or Release.
+	// Returns ErrIdempotencyInProgress if the key is reserved by an unfinished
+	// request, or ErrIdempotencyKeyReused if fingerprint doesn't match.
+	Begin(ctx context.Context, key, fingerprint string, ttl time.Duration) (*IdempotentResponse, error)
+
+	// Complete stores the response for a reserved key until ttl elapses.
+	Complete(ctx context.Context, key string, resp *IdempotentResponse, ttl time.Duration)
+
+	// Release drops a reservation so the request can be retried.
+	Release(ctx context.Context, key string)
+}
+
+// idempotencyEntry is a reserved or completed key.
+type idempotencyEntry struct {
+	fingerprint string
+	response    *IdempotentResponse // nil while the request is in progress
+	expiresAt   time.Time
+}
+
+// InMemoryIdempotencyStore implements IdempotencyStore using an in-memory map.
+// Thread-safe for concurrent access.
+type InMemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]*idempotencyEntry
+}
+
+// NewInMemoryIdempotencyStore creates a new in-memory idempotency store.
+func NewInMemoryIdempotencyStore() *InMemoryIdempotencyStore {
+	return &InMemoryIdempotencyStore{
+		entries: make(map[string]*idempotencyEntry),
+	}
+}
+
+// Begin reserves key or returns its stored response.
+// Implements the IdempotencyStore interface.
+func (s *InMemoryIdempotencyStore) Begin(ctx context.Context, key, fingerprint string, ttl time.Duration) (*IdempotentResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if entry, ok := s.entries[key]; ok && now.Before(entry.expiresAt) {
+		if entry.fingerprint != fingerprint {
+			return nil, ErrIdempotencyKeyReused
+		}
+		if entry.response == nil {
+			return nil, ErrIdempotencyInProgress
+		}
+		return entry.response, nil
+	}
+
+	s.entries[key] = &idempotencyEntry{
+		fingerprint: fingerprint,
+		expiresAt:   now.Add(ttl),
+	}
+	return nil, nil
+}
+
+// Complete stores the response for a reserved key.
+// Implements the IdempotencyStore interface.
+func (s *InMemoryIdempotencyStore) Complete(ctx context.Context, key string, resp *IdempotentResponse, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return
+	}
+	entry.response = resp
+	entry.expiresAt = time.Now().Add(ttl)
+}
+
+// Release drops a reservation.
+// Implements the IdempotencyStore interface.
+func (s *InMemoryIdempotencyStore) Release(ctx context.Context, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+}
+
+// Cleanup removes expired keys to prevent memory leaks.
+// This should be called periodically in production.
+func (s *InMemoryIdempotencyStore) Cleanup() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// idempotencyRecorder captures the response while writing it through.
+// Context updates are forwarded so an enclosing Logging middleware still
+// receives error codes set by handlers.
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+// WriteHeader captures the first status code written.
+func (ir *idempotencyRecorder) WriteHeader(code int) {
+	if !ir.wroteHeader {
+		ir.statusCode = code
+		ir.wroteHeader = true
+	}
+	ir.ResponseWriter.WriteHeader(code)
+}
+
+// Write captures the body, marking the response as started with an implicit 200 status.
+func (ir *idempotencyRecorder) Write(b []byte) (int, error) {
+	ir.wroteHeader = true
+	ir.body.Write(b)
+	return ir.ResponseWriter.Write(b)
+}
+
+// SetContext forwards context updates to the wrapped writer.
+func (ir *idempotencyRecorder) SetContext(ctx context.Context) {
+	UpdateResponseContext(ir.ResponseWriter, ctx)
+}
+
+// errorReader returns err once the preceding reader is exhausted, so a
+// body read failure reaches the handler unchanged.
+type errorReader struct{ err error }
+
+func (e errorReader) Read([]byte) (int, error) { return 0, e.err }
+
+// Idempotency is a middleware that makes create requests safe to retry.
+// When an authenticated request carries an Idempotency-Key header, the first
+// response is stored for ttl and replayed for later requests with the same
+// key, marked with Idempotent-Replayed: true. Keys are scoped per user DID,
+// so it must run after RequireAuth; requests without a DID or key pass through.
+//
+// A key reused with a different method, path, or body, or while the original
+// request is still running, is rejected with 409 Conflict. 5xx responses are
+// not stored, so the request can be retried with the same key. If the store
+// itself fails, requests proceed without idempotency.
+func Idempotency(store IdempotencyStore, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			idempotencyKey := r.Header.Get(IdempotencyKeyHeader)
+			did := GetUserDID(r.Context())
+			if idempotencyKey == "" || did == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(idempotencyKey) > MaxIdempotencyKeyLength {
+				writeJSONError(w, r, http.StatusBadRequest, errCodeValidation, "Idempotency-Key must be at most 255 characters")
+				return
+			}
+
+			// Read the body to fingerprint it, then hand the handler an identical body
+			var body []byte
+			if r.Body != nil && r.Body != http.NoBody {
+				read, err := io.ReadAll(r.Body)
+				if err != nil {
+					// Let the handler report the read failure, e.g. 413 from MaxBodyBytes
+					r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(read), errorReader{err}))
+					next.ServeHTTP(w, r)
+					return
+				}
+				body = read
+				r.Body = io.NopCloser(bytes.NewReader(body))
+			}
+
+			hash := sha256.New()
+			hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+			hash.Write(body)
+			fingerprint := hex.EncodeToString(hash.Sum(nil))
+			key := did + "\x00" + idempotencyKey
+
+			stored, err := store.Begin(r.Context(), key, fingerprint, ttl)
+			switch {
+			case errors.Is(err, ErrIdempotencyKeyReused):
+				writeJSONError(w, r, http.StatusConflict, errCodeConflict, "Idempotency-Key was already used for a different request")
+				return
+			case errors.Is(err, ErrIdempotencyInProgress):
+				writeJSONError(w, r, http.StatusConflict, errCodeConflict, "A request with this Idempotency-Key is still in progress")
+				return
+			case err != nil:
+				// Fail open: a store outage shouldn't block creates
+				slog.WarnContext(r.Context(), "idempotency store unavailable", "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if stored != nil {
+				for name, values := range stored.Header {
+					w.Header()[name] = append([]string(nil), values...)
+				}
+				w.Header().Set(IdempotentReplayedHeader, "true")
+				w.WriteHeader(stored.StatusCode)
+				_, _ = w.Write(stored.Body)
+				return
+			}
+
+			rec := &idempotencyRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+			defer func() {
+				// Release on panic or server error so the client can retry
+				if p := recover(); p != nil {
+					store.Release(r.Context(), key)
+					panic(p)
+				}
+				if rec.statusCode >= http.StatusInternalServerError {
+					store.Release(r.Context(), key)
+					return
+				}
+				store.Complete(r.Context(), key, &IdempotentResponse{
+					StatusCode: rec.statusCode,
+					Header:     w.Header().Clone(),
+					Body:       rec.body.Bytes(),
+				}, ttl)
+			}()
+			next.ServeHTTP(rec, r)
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newIdempotentCounter returns a handler wrapped in Idempotency that counts
+// calls and responds with status and a body naming the call number.
+func newIdempotentCounter(store IdempotencyStore, status int) (http.Handler, *atomic.Int32) {
+	var calls atomic.Int32
+	handler := Idempotency(store, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(map[string]int32{"call": n})
+	}))
+	return handler, &calls
+}
+
+// newIdempotentRequest builds a POST with an optional key and DID.
+func newIdempotentRequest(key, did, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	if did != "" {
+		req = req.WithContext(SetUserDID(req.Context(), did))
+	}
+	return req
+}
+
+func TestIdempotency_ReplaysResponse(t *testing.T) {
+	handler, calls := newIdempotentCounter(NewInMemoryIdempotencyStore(), http.StatusCreated)
+
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, newIdempotentRequest("key-1", "did:plc:alice", `{"title":"a"}`))
+	second := httptest.NewRecorder()
+	handler.ServeHTTP(second, newIdempotentRequest("key-1", "did:plc:alice", `{"title":"a"}`))
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected handler to run once, ran %d times", got)
+	}
+	if second.Code != http.StatusCreated {
+		t.Errorf("expected replayed status 201, got %d", second.Code)
+	}
+	if first.Body.String() != second.Body.String() {
+		t.Errorf("expected identical bodies, got %q and %q", first.Body.String(), second.Body.String())
+	}
+	if second.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("expected replayed Content-Type, got %q", second.Header().Get("Content-Type"))
+	}
+	if first.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Error("expected original response not to be marked as replayed")
+	}
+	if second.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Errorf("expected %s: true, got %q", IdempotentReplayedHeader, second.Header().Get(IdempotentReplayedHeader))
+	}
+}
+
+func TestIdempotency_KeysScopedPerUser(t *testing.T) {
+	handler, calls := newIdempotentCounter(NewInMemoryIdempotencyStore(), http.StatusCreated)
+
+	for _, did := range []string{"did:plc:alice", "did:plc:bob"} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newIdempotentRequest("shared-key", did, `{}`))
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected 201 for %s, got %d", did, w.Code)
+		}
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected each user's key to run the handler, ran %d times", got)
+	}
+}
+
+func TestIdempotency_DifferentBodyConflicts(t *testing.T) {
+	handler, calls := newIdempotentCounter(NewInMemoryIdempotencyStore(), http.StatusCreated)
+
+	handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("key-1", "did:plc:alice", `{"title":"a"}`))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newIdempotentRequest("key-1", "did:plc:alice", `{"title":"b"}`))
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", w.Code)
+	}
+	var errResp map[string]map[string]string
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp["error"]["code"] != errCodeConflict {
+		t.Errorf("expected error code %q, got %q", errCodeConflict, errResp["error"]["code"])
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected conflicting request not to reach the handler, ran %d times", got)
+	}
+}
+
+func TestIdempotency_InProgressConflicts(t *testing.T) {
+	store := NewInMemoryIdempotencyStore()
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	handler := Idempotency(store, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(entered)
+		<-release
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("key-1", "did:plc:alice", `{}`))
+	}()
+	<-entered
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newIdempotentRequest("key-1", "did:plc:alice", `{}`))
+	close(release)
+	<-done
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409 while the first request is running, got %d", w.Code)
+	}
+}
+
+func TestIdempotency_ServerErrorNotStored(t *testing.T) {
+	handler, calls := newIdempotentCounter(NewInMemoryIdempotencyStore(), http.StatusInternalServerError)
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newIdempotentRequest("key-1", "did:plc:alice", `{}`))
+		if w.Header().Get(IdempotentReplayedHeader) != "" {
+			t.Errorf("attempt %d: expected 5xx response not to be replayed", i+1)
+		}
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected retries after a 5xx to reach the handler, ran %d times", got)
+	}
+}
+
+func TestIdempotency_ClientErrorStored(t *testing.T) {
+	handler, calls := newIdempotentCounter(NewInMemoryIdempotencyStore(), http.StatusBadRequest)
+
+	handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("key-1", "did:plc:alice", `{}`))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newIdempotentRequest("key-1", "did:plc:alice", `{}`))
+
+	if w.Code != http.StatusBadRequest || w.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Errorf("expected replayed 400, got %d (replayed=%q)", w.Code, w.Header().Get(IdempotentReplayedHeader))
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected handler to run once, ran %d times", got)
+	}
+}
+
+func TestIdempotency_PassThrough(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		did  string
+	}{
+		{"no key", "", "did:plc:alice"},
+		{"no DID", "key-1", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, calls := newIdempotentCounter(NewInMemoryIdempotencyStore(), http.StatusCreated)
+			for i := 0; i < 2; i++ {
+				handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest(tt.key, tt.did, `{}`))
+			}
+			if got := calls.Load(); got != 2 {
+				t.Errorf("expected both requests to reach the handler, ran %d times", got)
+			}
+		})
+	}
+}
+
+func TestIdempotency_KeyTooLong(t *testing.T) {
+	handler, calls := newIdempotentCounter(NewInMemoryIdempotencyStore(), http.StatusCreated)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newIdempotentRequest(strings.Repeat("k", MaxIdempotencyKeyLength+1), "did:plc:alice", `{}`))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for oversized key, got %d", w.Code)
+	}
+	if calls.Load() != 0 {
+		t.Error("expected oversized key not to reach the handler")
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newIdempotentRequest(strings.Repeat("k", MaxIdempotencyKeyLength), "did:plc:alice", `{}`))
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected 201 for key at the limit, got %d", w.Code)
+	}
+}
+
+func TestIdempotency_BodyForwarded(t *testing.T) {
+	var got string
+	handler := Idempotency(NewInMemoryIdempotencyStore(), time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = string(body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("key-1", "did:plc:alice", `{"title":"a"}`))
+	if got != `{"title":"a"}` {
+		t.Errorf("expected handler to receive the original body, got %q", got)
+	}
+}
+
+func TestInMemoryIdempotencyStore_Cleanup(t *testing.T) {
+	store := NewInMemoryIdempotencyStore()
+	ctx := t.Context()
+
+	if _, err := store.Begin(ctx, "expired", "fp", -time.Second); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	if _, err := store.Begin(ctx, "live", "fp", time.Hour); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	store.Cleanup()
+
+	if _, ok := store.entries["expired"]; ok {
+		t.Error("expected expired key to be removed")
+	}
+	if _, ok := store.entries["live"]; !ok {
+		t.Error("expected live key to be kept")
+	}
+}
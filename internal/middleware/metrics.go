@@ -0,0 +1,200 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// HTTP metric names as constants for consistency.
+const (
+	MetricHTTPRequestDuration = "http_request_duration_seconds"
+	MetricHTTPRequestsTotal   = "http_requests_total"
+)
+
+// UnmatchedRoute is the route label for requests that match no registered
+// route template, so arbitrary paths can't create new label values.
+const UnmatchedRoute = "unmatched"
+
+// otherMethod is the method label for non-standard HTTP methods.
+const otherMethod = "OTHER"
+
+// knownMethods are the HTTP methods reported as their own label value.
+var knownMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// HTTPMetrics contains Prometheus metrics for HTTP handlers.
+// Requests are labeled by route template (e.g. "/scenes/{id}") rather than
+// raw path to keep label cardinality bounded. All operations are thread-safe.
+type HTTPMetrics struct {
+	requestDuration *prometheus.HistogramVec
+	requestsTotal   *prometheus.CounterVec
+	routes          [][]string
+}
+
+// NewHTTPMetrics creates and returns a new HTTPMetrics instance that labels
+// requests with the given route templates. A template segment wrapped in
+// braces, such as "{id}", matches any single non-empty path segment.
+// The metrics are not registered; call Register to register them with a registry.
+func NewHTTPMetrics(routes ...string) *HTTPMetrics {
+	m := &HTTPMetrics{
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    MetricHTTPRequestDuration,
+			Help:    "Histogram of HTTP request latency in seconds",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: MetricHTTPRequestsTotal,
+			Help: "Total number of HTTP requests by route, method, and status",
+		}, []string{"route", "method", "status"}),
+	}
+	for _, route := range routes {
+		m.routes = append(m.routes, splitPath(route))
+	}
+	return m
+}
+
+// Register registers all metrics with the given registry.
+// Returns an error if registration fails.
+func (m *HTTPMetrics) Register(reg prometheus.Registerer) error {
+	for _, c := range m.Collectors() {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Collectors returns all Prometheus collectors for testing.
+func (m *HTTPMetrics) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.requestDuration,
+		m.requestsTotal,
+	}
+}
+
+// Route returns the route template matching path, or UnmatchedRoute.
+// When several templates match, literal segments win over wildcards at the
+// first position they differ, so "/events/upcoming" beats "/events/{id}".
+func (m *HTTPMetrics) Route(path string) string {
+	segments := splitPath(path)
+
+	var best []string
+	for _, route := range m.routes {
+		if !routeMatches(route, segments) {
+			continue
+		}
+		if best == nil || moreSpecific(route, best) {
+			best = route
+		}
+	}
+	if best == nil {
+		return UnmatchedRoute
+	}
+	return "/" + strings.Join(best, "/")
+}
+
+// splitPath splits a path into segments, ignoring leading and trailing slashes.
+func splitPath(path string) []string {
+	trimmed := strings.Trim(path, "/")
+	if trimmed == "" {
+		return []string{}
+	}
+	return strings.Split(trimmed, "/")
+}
+
+// isWildcard reports whether a template segment matches any path segment.
+func isWildcard(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// routeMatches reports whether a route template matches the path segments.
+func routeMatches(route, segments []string) bool {
+	if len(route) != len(segments) {
+		return false
+	}
+	for i, segment := range route {
+		if segments[i] == "" {
+			return false
+		}
+		if !isWildcard(segment) && segment != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// moreSpecific reports whether route a should win over route b for the same path.
+func moreSpecific(a, b []string) bool {
+	for i := range a {
+		aWild, bWild := isWildcard(a[i]), isWildcard(b[i])
+		if aWild != bWild {
+			return bWild
+		}
+	}
+	return false
+}
+
+// statusRecorder captures the response status for metrics.
+// Context updates are forwarded so an enclosing Logging middleware still
+// receives error codes set by handlers.
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+}
+
+// WriteHeader captures the first status code written.
+func (sr *statusRecorder) WriteHeader(code int) {
+	if !sr.wroteHeader {
+		sr.statusCode = code
+		sr.wroteHeader = true
+	}
+	sr.ResponseWriter.WriteHeader(code)
+}
+
+// Write marks the response as started with an implicit 200 status.
+func (sr *statusRecorder) Write(b []byte) (int, error) {
+	sr.wroteHeader = true
+	return sr.ResponseWriter.Write(b)
+}
+
+// SetContext forwards context updates to the wrapped writer.
+func (sr *statusRecorder) SetContext(ctx context.Context) {
+	UpdateResponseContext(sr.ResponseWriter, ctx)
+}
+
+// Metrics is a middleware that records request latency and counts per route
+// template, method, and status code. The matched template is also passed to an
+// enclosing AccessLog middleware.
+func Metrics(m *HTTPMetrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			sr := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(sr, r)
+
+			route := m.Route(r.URL.Path)
+			setRoute(r.Context(), route)
+			method := r.Method
+			if !knownMethods[method] {
+				method = otherMethod
+			}
+
+			m.requestDuration.WithLabelValues(route, method).Observe(time.Since(start).Seconds())
+			m.requestsTotal.WithLabelValues(route, method, strconv.Itoa(sr.statusCode)).Inc()
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// findMetric returns the gathered metric in family name whose labels include
+// all of the given label values, or nil if none does.
+func findMetric(t *testing.T, reg *prometheus.Registry, name string, labels map[string]string) *dto.Metric {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather() returned error: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			matched := 0
+			for _, pair := range metric.GetLabel() {
+				if want, ok := labels[pair.GetName()]; ok && want == pair.GetValue() {
+					matched++
+				}
+			}
+			if matched == len(labels) {
+				return metric
+			}
+		}
+	}
+	return nil
+}
+
+func TestHTTPMetrics_Route(t *testing.T) {
+	m := NewHTTPMetrics("/scenes/{id}", "/scenes/{id}/posts", "/events/{id}", "/events/upcoming", "/health")
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/scenes/11111111-1111-4111-8111-111111111111", want: "/scenes/{id}"},
+		{path: "/scenes/abc/posts", want: "/scenes/{id}/posts"},
+		{path: "/scenes/abc/posts/", want: "/scenes/{id}/posts"},
+		{path: "/events/upcoming", want: "/events/upcoming"},
+		{path: "/events/some-event", want: "/events/{id}"},
+		{path: "/health", want: "/health"},
+		{path: "/scenes", want: UnmatchedRoute},
+		{path: "/scenes//posts", want: UnmatchedRoute},
+		{path: "/random/garbage/path", want: UnmatchedRoute},
+	}
+
+	for _, tt := range tests {
+		if got := m.Route(tt.path); got != tt.want {
+			t.Errorf("Route(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestMetrics_CountsByRouteAndStatus(t *testing.T) {
+	m := NewHTTPMetrics("/scenes/{id}")
+	reg := prometheus.NewRegistry()
+	if err := m.Register(reg); err != nil {
+		t.Fatalf("Register() returned error: %v", err)
+	}
+
+	handler := Metrics(m)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/scenes/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+
+	for _, path := range []string{"/scenes/a", "/scenes/b", "/scenes/missing"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	ok := findMetric(t, reg, MetricHTTPRequestsTotal, map[string]string{"route": "/scenes/{id}", "method": "GET", "status": "200"})
+	if ok == nil || ok.GetCounter().GetValue() != 2 {
+		t.Errorf("expected 2 requests with status 200, got %v", ok)
+	}
+	notFound := findMetric(t, reg, MetricHTTPRequestsTotal, map[string]string{"route": "/scenes/{id}", "method": "GET", "status": "404"})
+	if notFound == nil || notFound.GetCounter().GetValue() != 1 {
+		t.Errorf("expected 1 request with status 404, got %v", notFound)
+	}
+
+	// Raw paths never become label values
+	if raw := findMetric(t, reg, MetricHTTPRequestsTotal, map[string]string{"route": "/scenes/a"}); raw != nil {
+		t.Error("expected raw path not to be used as a route label")
+	}
+
+	duration := findMetric(t, reg, MetricHTTPRequestDuration, map[string]string{"route": "/scenes/{id}", "method": "GET"})
+	if duration == nil {
+		t.Fatal("expected duration histogram for route")
+	}
+	if got := duration.GetHistogram().GetSampleCount(); got != 3 {
+		t.Errorf("expected 3 duration observations, got %d", got)
+	}
+	if duration.GetHistogram().GetSampleSum() <= 0 {
+		t.Error("expected a positive observed duration")
+	}
+}
+
+func TestMetrics_NonStandardMethod(t *testing.T) {
+	m := NewHTTPMetrics()
+	reg := prometheus.NewRegistry()
+	if err := m.Register(reg); err != nil {
+		t.Fatalf("Register() returned error: %v", err)
+	}
+
+	handler := Metrics(m)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("BREW", "/coffee", nil))
+
+	metric := findMetric(t, reg, MetricHTTPRequestsTotal, map[string]string{"route": UnmatchedRoute, "method": otherMethod, "status": "405"})
+	if metric == nil || metric.GetCounter().GetValue() != 1 {
+		t.Errorf("expected unmatched route and OTHER method labels, got %v", metric)
+	}
+}
+
+func TestMetrics_ForwardsContextToLogging(t *testing.T) {
+	m := NewHTTPMetrics()
+
+	var captured *responseWriter
+	inner := Metrics(m)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		UpdateResponseContext(w, SetErrorCode(r.Context(), "not_found"))
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	outer := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured = newResponseWriter(w, r.Context())
+		inner.ServeHTTP(captured, r)
+	})
+	outer.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got := GetErrorCode(captured.Context()); got != "not_found" {
+		t.Errorf("expected error code to reach the outer writer, got %q", got)
+	}
+}
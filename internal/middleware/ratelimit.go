@@ -12,6 +12,9 @@ import (
 	"time"
 )
 
+// errCodeRateLimited mirrors api.ErrCodeRateLimited.
+const errCodeRateLimited = "rate_limited"
+
 // RateLimitConfig defines the rate limiting configuration.
 // Valid values:
 //   - RequestsPerWindow: must be > 0
@@ -197,15 +200,11 @@ func RateLimiter(store RateLimitStore, config RateLimitConfig, keyFunc KeyFunc)
 			allowed, retryAfter := store.Allow(r.Context(), key, config)
 
 			if !allowed {
-				// Set error code for logging middleware
-				ctx := SetErrorCode(r.Context(), "rate_limit_exceeded")
-				r = r.WithContext(ctx)
-
 				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
 				// X-RateLimit-Reset should be a Unix timestamp per API conventions
 				resetTime := time.Now().Add(time.Duration(retryAfter) * time.Second).Unix()
 				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(resetTime, 10))
-				http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+				writeJSONError(w, r, http.StatusTooManyRequests, errCodeRateLimited, "Too many requests")
 				return
 			}
 
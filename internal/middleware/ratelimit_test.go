@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -400,6 +401,40 @@ func TestRateLimiter_BlocksExcessiveTraffic(t *testing.T) {
 	}
 }
 
+func TestRateLimiter_ReturnsJSONError(t *testing.T) {
+	store := NewInMemoryRateLimitStore()
+	config := RateLimitConfig{
+		RequestsPerWindow: 1,
+		WindowDuration:    time.Minute,
+	}
+	handler := RequestID(RateLimiter(store, config, IPKeyFunc())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	var rr *httptest.ResponseRecorder
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		req.Header.Set(RequestIDHeader, "req-limit-123")
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+	}
+
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status %d, got %d", http.StatusTooManyRequests, rr.Code)
+	}
+	var body errorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if body.Error.Code != errCodeRateLimited {
+		t.Errorf("expected error code %q, got %q", errCodeRateLimited, body.Error.Code)
+	}
+	if body.RequestID != "req-limit-123" {
+		t.Errorf("expected request_id req-limit-123, got %q", body.RequestID)
+	}
+}
+
 func TestRateLimiter_ReturnsRetryAfterHeader(t *testing.T) {
 	store := NewInMemoryRateLimitStore()
 	config := RateLimitConfig{
@@ -5,6 +5,8 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/google/uuid"
 )
 
 func TestRequestID_GeneratesNewID(t *testing.T) {
@@ -37,6 +39,11 @@ func TestRequestID_GeneratesNewID(t *testing.T) {
 	if responseID != contextID {
 		t.Errorf("context ID %q doesn't match response header ID %q", contextID, responseID)
 	}
+
+	// Verify generated ID is a UUID
+	if _, err := uuid.Parse(responseID); err != nil {
+		t.Errorf("expected generated request ID to be a UUID, got %q: %v", responseID, err)
+	}
 }
 
 func TestRequestID_UsesExistingHeader(t *testing.T) {
@@ -108,6 +115,11 @@ func TestRequestID_RejectsInvalidHeader(t *testing.T) {
 					t.Error("expected request ID to be generated, got empty string")
 				}
 			}
+
+			// Response header must echo the effective ID, not the rejected header
+			if responseID := rr.Header().Get(RequestIDHeader); responseID != capturedID {
+				t.Errorf("expected response header %q, got %q", capturedID, responseID)
+			}
 		})
 	}
 }
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSecurityHeaders_Defaults(t *testing.T) {
+	handler := SecurityHeaders(SecurityHeadersConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	want := map[string]string{
+		"X-Content-Type-Options":  "nosniff",
+		"Content-Security-Policy": DefaultContentSecurityPolicy,
+		"Referrer-Policy":         DefaultReferrerPolicy,
+		"X-Frame-Options":         DefaultFrameOptions,
+	}
+	for name, value := range want {
+		if got := rr.Header().Get(name); got != value {
+			t.Errorf("expected %s %q, got %q", name, value, got)
+		}
+	}
+}
+
+func TestSecurityHeaders_ConfigOverrides(t *testing.T) {
+	handler := SecurityHeaders(SecurityHeadersConfig{
+		ReferrerPolicy: "strict-origin-when-cross-origin",
+		FrameOptions:   "SAMEORIGIN",
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got := rr.Header().Get("Referrer-Policy"); got != "strict-origin-when-cross-origin" {
+		t.Errorf("expected overridden Referrer-Policy, got %q", got)
+	}
+	if got := rr.Header().Get("X-Frame-Options"); got != "SAMEORIGIN" {
+		t.Errorf("expected overridden X-Frame-Options, got %q", got)
+	}
+	// Fields left empty keep their defaults
+	if got := rr.Header().Get("Content-Security-Policy"); got != DefaultContentSecurityPolicy {
+		t.Errorf("expected default Content-Security-Policy, got %q", got)
+	}
+}
+
+func TestSecurityHeaders_HandlerOverrideWins(t *testing.T) {
+	const handlerCSP = "default-src 'self'; img-src 'self' data:"
+
+	handler := SecurityHeaders(SecurityHeadersConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Security-Policy", handlerCSP)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got := rr.Header().Values("Content-Security-Policy"); len(got) != 1 || got[0] != handlerCSP {
+		t.Errorf("expected handler CSP %q, got %v", handlerCSP, got)
+	}
+}
+
+func TestSecurityHeaders_PreservesOuterHeaders(t *testing.T) {
+	// A header set by an outer middleware must not be clobbered
+	outer := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Frame-Options", "SAMEORIGIN")
+		SecurityHeaders(SecurityHeadersConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, r)
+	})
+
+	rr := httptest.NewRecorder()
+	outer.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got := rr.Header().Get("X-Frame-Options"); got != "SAMEORIGIN" {
+		t.Errorf("expected existing X-Frame-Options to be kept, got %q", got)
+	}
+}
@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultRequestTimeout is the default time a handler has to respond.
+const DefaultRequestTimeout = 10 * time.Second
+
+// errCodeTimeout mirrors api.ErrCodeTimeout.
+const errCodeTimeout = "timeout"
+
+// Timeout is a middleware that bounds how long a handler may run. The request
+// context carries a deadline d from now, which repositories and search honor
+// by returning the context error. If the handler has not returned when the
+// deadline passes, the client gets 503 Service Unavailable with the timeout
+// error code and anything the handler writes afterwards is discarded.
+//
+// Responses are buffered until the handler returns, so streaming routes must
+// not be wrapped. A panic in the handler is re-raised on the serving goroutine.
+// A duration of zero or less disables the timeout.
+func Timeout(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if d <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+
+			tw := &timeoutWriter{w: w, header: make(http.Header)}
+			done := make(chan struct{})
+			panicked := make(chan any, 1)
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicked <- p
+					}
+				}()
+				next.ServeHTTP(tw, r.WithContext(ctx))
+				close(done)
+			}()
+
+			select {
+			case p := <-panicked:
+				panic(p)
+			case <-done:
+				tw.flush()
+			case <-ctx.Done():
+				tw.mu.Lock()
+				tw.timedOut = true
+				tw.mu.Unlock()
+				// A canceled parent means the client went away; there is no one to answer
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					writeJSONError(w, r, http.StatusServiceUnavailable, errCodeTimeout, "Request timed out")
+				}
+			}
+		})
+	}
+}
+
+// timeoutWriter buffers a handler's response so it can be dropped if the
+// deadline passes first. Thread-safe via Mutex, since the handler keeps
+// running on its own goroutine after a timeout.
+type timeoutWriter struct {
+	mu       sync.Mutex
+	w        http.ResponseWriter
+	header   http.Header
+	buf      bytes.Buffer
+	status   int
+	timedOut bool
+}
+
+// Header returns the buffered response headers.
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+// Write buffers b, failing with http.ErrHandlerTimeout once timed out.
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if tw.status == 0 {
+		tw.status = http.StatusOK
+	}
+	return tw.buf.Write(b)
+}
+
+// WriteHeader records the status code; only the first call takes effect.
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut || tw.status != 0 {
+		return
+	}
+	tw.status = code
+}
+
+// SetContext forwards context updates to the underlying writer so the logging
+// middleware sees the handler's error code. Updates after a timeout are
+// dropped, since the logging middleware may already be reading the context.
+func (tw *timeoutWriter) SetContext(ctx context.Context) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return
+	}
+	UpdateResponseContext(tw.w, ctx)
+}
+
+// flush writes the buffered response to the underlying writer.
+func (tw *timeoutWriter) flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	dst := tw.w.Header()
+	for key, values := range tw.header {
+		dst[key] = values
+	}
+	if tw.status == 0 {
+		tw.status = http.StatusOK
+	}
+	tw.w.WriteHeader(tw.status)
+	_, _ = tw.w.Write(tw.buf.Bytes())
+}
@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeout_AbortsSlowHandler(t *testing.T) {
+	release := make(chan struct{})
+	handlerErr := make(chan error, 1)
+	handler := Timeout(20 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Deliberately slow: keeps running until after the middleware has responded
+		<-release
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte("too late"))
+		handlerErr <- err
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/events/search", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	close(release)
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+
+	var body struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if body.Error.Code != errCodeTimeout {
+		t.Errorf("expected error code %q, got %q", errCodeTimeout, body.Error.Code)
+	}
+	if body.Error.Message == "" {
+		t.Error("expected error message")
+	}
+
+	select {
+	case err := <-handlerErr:
+		if !errors.Is(err, http.ErrHandlerTimeout) {
+			t.Errorf("expected late write to fail with ErrHandlerTimeout, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("slow handler never finished")
+	}
+}
+
+func TestTimeout_PassesFastResponse(t *testing.T) {
+	var deadline time.Time
+	var hasDeadline bool
+	handler := Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, hasDeadline = r.Context().Deadline()
+		w.Header().Set("X-Test", "ok")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("created"))
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/events", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if !hasDeadline || deadline.After(time.Now().Add(time.Second)) {
+		t.Errorf("expected a deadline within 1s of the request, got %v (set: %v)", deadline, hasDeadline)
+	}
+	if rr.Code != http.StatusCreated {
+		t.Errorf("expected status %d, got %d", http.StatusCreated, rr.Code)
+	}
+	if got := rr.Header().Get("X-Test"); got != "ok" {
+		t.Errorf("expected X-Test header ok, got %q", got)
+	}
+	if got := rr.Body.String(); got != "created" {
+		t.Errorf("expected body created, got %q", got)
+	}
+}
+
+func TestTimeout_LogsTimeoutErrorCode(t *testing.T) {
+	var loggedCode string
+	release := make(chan struct{})
+	handlerDone := make(chan struct{})
+	handler := Timeout(20 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(handlerDone)
+		<-release
+		// Error codes set after the timeout must not override the 503's
+		UpdateResponseContext(w, SetErrorCode(r.Context(), "internal_error"))
+	}))
+	logging := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := newResponseWriter(w, r.Context())
+		handler.ServeHTTP(rw, r)
+		close(release)
+		<-handlerDone
+		loggedCode = GetErrorCode(rw.Context())
+	})
+
+	rr := httptest.NewRecorder()
+	logging.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/scenes/nearby", nil))
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+	if loggedCode != errCodeTimeout {
+		t.Errorf("expected logged error code %q, got %q", errCodeTimeout, loggedCode)
+	}
+}
+
+func TestTimeout_ClientCancelWritesNothing(t *testing.T) {
+	handler := Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodGet, "/events/search", nil).WithContext(ctx)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Body.Len() != 0 {
+		t.Errorf("expected no response for a canceled client, got %q", rr.Body.String())
+	}
+}
+
+func TestTimeout_RepanicsHandlerPanic(t *testing.T) {
+	handler := Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	defer func() {
+		if p := recover(); p != "boom" {
+			t.Errorf("expected handler panic to propagate, got %v", p)
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestTimeout_DisabledForNonPositiveDuration(t *testing.T) {
+	handler := Timeout(0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); ok {
+			t.Error("expected no deadline when the timeout is disabled")
+		}
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
@@ -0,0 +1,191 @@
+// Package middleware provides HTTP middleware components for the API server.
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// TokenBucketLimit defines the refill rate and capacity of a token bucket.
+type TokenBucketLimit struct {
+	// RequestsPerMinute is the sustained rate at which tokens are refilled.
+	RequestsPerMinute int
+	// Burst is the bucket capacity, i.e. the maximum number of requests
+	// that can be made back-to-back after the bucket has fully refilled.
+	Burst int
+}
+
+// refillPerSecond returns the number of tokens added to the bucket per second.
+func (l TokenBucketLimit) refillPerSecond() float64 {
+	return float64(l.RequestsPerMinute) / 60
+}
+
+// TokenBucketStore defines the interface for token bucket state storage.
+// This allows for different backends (in-memory, Redis, etc.).
+type TokenBucketStore interface {
+	// Take removes a token from the bucket identified by key.
+	// Returns true if a token was available. When no token is available,
+	// retryAfter is the time until the next token is refilled.
+	Take(ctx context.Context, key string, limit TokenBucketLimit) (allowed bool, retryAfter time.Duration)
+}
+
+// tokenBucket is the state of a single token bucket.
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// InMemoryTokenBucketStore implements TokenBucketStore using an in-memory map.
+// Thread-safe for concurrent access.
+type InMemoryTokenBucketStore struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	now     func() time.Time // injectable clock for tests
+}
+
+// NewInMemoryTokenBucketStore creates a new in-memory token bucket store.
+func NewInMemoryTokenBucketStore() *InMemoryTokenBucketStore {
+	return &InMemoryTokenBucketStore{
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// Take removes a token from the bucket for key, refilling it first based on
+// the time elapsed since the last request. New buckets start full.
+// Implements the TokenBucketStore interface.
+func (s *InMemoryTokenBucketStore) Take(ctx context.Context, key string, limit TokenBucketLimit) (bool, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	rate := limit.refillPerSecond()
+	capacity := float64(limit.Burst)
+
+	b, exists := s.buckets[key]
+	if !exists {
+		b = &tokenBucket{tokens: capacity, lastRefill: now}
+		s.buckets[key] = b
+	} else if elapsed := now.Sub(b.lastRefill); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+elapsed.Seconds()*rate)
+		b.lastRefill = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	// Time until the bucket refills to one whole token
+	wait := time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	return false, wait
+}
+
+// Cleanup removes buckets that have been idle long enough to refill completely.
+// A full bucket is equivalent to a missing one, so this never changes limiting
+// behavior. This should be called periodically in production.
+func (s *InMemoryTokenBucketStore) Cleanup(limit TokenBucketLimit) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	rate := limit.refillPerSecond()
+	for key, b := range s.buckets {
+		if b.tokens+now.Sub(b.lastRefill).Seconds()*rate >= float64(limit.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// RateLimitOptions configures the token bucket RateLimit middleware.
+type RateLimitOptions struct {
+	// Group namespaces bucket keys so route groups (e.g. "search", "rsvp")
+	// are limited independently even when they share a store.
+	Group string
+	// RequestsPerMinute is the sustained request rate per client.
+	// Must be > 0.
+	RequestsPerMinute int
+	// Burst is the number of requests a client may make back-to-back.
+	// Defaults to RequestsPerMinute when <= 0.
+	Burst int
+	// Store holds bucket state. Defaults to a new InMemoryTokenBucketStore.
+	Store TokenBucketStore
+	// KeyFunc identifies the client. Defaults to HashedUserKeyFunc.
+	KeyFunc KeyFunc
+}
+
+// Validate checks that the RateLimitOptions has valid values.
+// Returns an error if RequestsPerMinute <= 0.
+func (o RateLimitOptions) Validate() error {
+	if o.RequestsPerMinute <= 0 {
+		return fmt.Errorf("RequestsPerMinute must be > 0 (got %d)", o.RequestsPerMinute)
+	}
+	return nil
+}
+
+// HashedUserKeyFunc returns a KeyFunc that uses the authenticated user's DID
+// if available, falling back to a SHA-256 hash of the client IP so raw
+// addresses are never used as store keys.
+func HashedUserKeyFunc() KeyFunc {
+	ipFunc := IPKeyFunc()
+	return func(r *http.Request) string {
+		if did := GetUserDID(r.Context()); did != "" {
+			return "user:" + did
+		}
+		sum := sha256.Sum256([]byte(ipFunc(r)))
+		return "ip:" + hex.EncodeToString(sum[:])
+	}
+}
+
+// RateLimit is a middleware that limits request rates using a token bucket
+// per client and route group. It returns HTTP 429 Too Many Requests with a
+// Retry-After header when the client's bucket is empty.
+// It panics if opts is invalid, since that is a programming error at setup.
+func RateLimit(opts RateLimitOptions) func(http.Handler) http.Handler {
+	if err := opts.Validate(); err != nil {
+		panic("middleware.RateLimit: " + err.Error())
+	}
+
+	limit := TokenBucketLimit{
+		RequestsPerMinute: opts.RequestsPerMinute,
+		Burst:             opts.Burst,
+	}
+	if limit.Burst <= 0 {
+		limit.Burst = opts.RequestsPerMinute
+	}
+	store := opts.Store
+	if store == nil {
+		store = NewInMemoryTokenBucketStore()
+	}
+	keyFunc := opts.KeyFunc
+	if keyFunc == nil {
+		keyFunc = HashedUserKeyFunc()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := opts.Group + "|" + keyFunc(r)
+			allowed, retryAfter := store.Take(r.Context(), key, limit)
+
+			if !allowed {
+				// Round up so clients never retry before a token is available
+				seconds := int(math.Ceil(retryAfter.Seconds()))
+				if seconds <= 0 {
+					seconds = 1
+				}
+				w.Header().Set("Retry-After", strconv.Itoa(seconds))
+				writeJSONError(w, r, http.StatusTooManyRequests, errCodeRateLimited, "Too many requests")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for token bucket tests.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.t }
+
+func (c *fakeClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestTokenBucketStore() (*InMemoryTokenBucketStore, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	store := NewInMemoryTokenBucketStore()
+	store.now = clock.Now
+	return store, clock
+}
+
+func TestInMemoryTokenBucketStore_Refill(t *testing.T) {
+	store, clock := newTestTokenBucketStore()
+	ctx := context.Background()
+	// One token per second, up to 2 at once
+	limit := TokenBucketLimit{RequestsPerMinute: 60, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		if allowed, _ := store.Take(ctx, "client", limit); !allowed {
+			t.Fatalf("request %d: expected burst to be allowed", i+1)
+		}
+	}
+
+	allowed, retryAfter := store.Take(ctx, "client", limit)
+	if allowed {
+		t.Fatal("expected request to be blocked once burst is spent")
+	}
+	if retryAfter != time.Second {
+		t.Errorf("expected retryAfter 1s, got %s", retryAfter)
+	}
+
+	// Half a token refilled: still blocked, and retryAfter shrinks accordingly
+	clock.Advance(500 * time.Millisecond)
+	allowed, retryAfter = store.Take(ctx, "client", limit)
+	if allowed {
+		t.Fatal("expected request to be blocked with half a token")
+	}
+	if retryAfter != 500*time.Millisecond {
+		t.Errorf("expected retryAfter 500ms, got %s", retryAfter)
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if allowed, _ := store.Take(ctx, "client", limit); !allowed {
+		t.Error("expected request to be allowed after a full token refilled")
+	}
+}
+
+func TestInMemoryTokenBucketStore_BoundaryCrossing(t *testing.T) {
+	store, clock := newTestTokenBucketStore()
+	ctx := context.Background()
+	limit := TokenBucketLimit{RequestsPerMinute: 60, Burst: 1}
+
+	if allowed, _ := store.Take(ctx, "client", limit); !allowed {
+		t.Fatal("expected first request to be allowed")
+	}
+
+	// Just before the refill boundary
+	clock.Advance(999 * time.Millisecond)
+	if allowed, _ := store.Take(ctx, "client", limit); allowed {
+		t.Error("expected request just before refill boundary to be blocked")
+	}
+
+	// Exactly at the boundary
+	clock.Advance(time.Millisecond)
+	if allowed, _ := store.Take(ctx, "client", limit); !allowed {
+		t.Error("expected request at refill boundary to be allowed")
+	}
+}
+
+func TestInMemoryTokenBucketStore_RefillCappedAtBurst(t *testing.T) {
+	store, clock := newTestTokenBucketStore()
+	ctx := context.Background()
+	limit := TokenBucketLimit{RequestsPerMinute: 60, Burst: 3}
+
+	if allowed, _ := store.Take(ctx, "client", limit); !allowed {
+		t.Fatal("expected first request to be allowed")
+	}
+
+	// A long idle period must not accumulate more than Burst tokens
+	clock.Advance(time.Hour)
+	for i := 0; i < 3; i++ {
+		if allowed, _ := store.Take(ctx, "client", limit); !allowed {
+			t.Fatalf("request %d: expected to be allowed within burst", i+1)
+		}
+	}
+	if allowed, _ := store.Take(ctx, "client", limit); allowed {
+		t.Error("expected request beyond burst to be blocked")
+	}
+}
+
+func TestInMemoryTokenBucketStore_SeparateKeys(t *testing.T) {
+	store, _ := newTestTokenBucketStore()
+	ctx := context.Background()
+	limit := TokenBucketLimit{RequestsPerMinute: 60, Burst: 1}
+
+	if allowed, _ := store.Take(ctx, "client-a", limit); !allowed {
+		t.Fatal("client-a: expected first request to be allowed")
+	}
+	if allowed, _ := store.Take(ctx, "client-a", limit); allowed {
+		t.Error("client-a: expected second request to be blocked")
+	}
+
+	// client-b has its own bucket
+	if allowed, _ := store.Take(ctx, "client-b", limit); !allowed {
+		t.Error("client-b: expected first request to be allowed")
+	}
+}
+
+func TestInMemoryTokenBucketStore_Cleanup(t *testing.T) {
+	store, clock := newTestTokenBucketStore()
+	ctx := context.Background()
+	limit := TokenBucketLimit{RequestsPerMinute: 60, Burst: 2}
+
+	store.Take(ctx, "idle", limit)
+	clock.Advance(500 * time.Millisecond)
+	store.Take(ctx, "active", limit)
+
+	// idle has refilled after 1s; active needs until 1.5s
+	clock.Advance(500 * time.Millisecond)
+	store.Cleanup(limit)
+
+	if _, exists := store.buckets["idle"]; exists {
+		t.Error("expected refilled bucket to be removed")
+	}
+	if _, exists := store.buckets["active"]; !exists {
+		t.Error("expected partially drained bucket to be kept")
+	}
+}
+
+// recordingStore records the keys passed to Take and always allows.
+type recordingStore struct {
+	keys []string
+}
+
+func (s *recordingStore) Take(ctx context.Context, key string, limit TokenBucketLimit) (bool, time.Duration) {
+	s.keys = append(s.keys, key)
+	return true, 0
+}
+
+func TestHashedUserKeyFunc(t *testing.T) {
+	keyFunc := HashedUserKeyFunc()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "203.0.113.9:4000"
+	ipKey := keyFunc(req)
+	if !strings.HasPrefix(ipKey, "ip:") {
+		t.Errorf("expected ip: prefix, got %q", ipKey)
+	}
+	if strings.Contains(ipKey, "203.0.113.9") {
+		t.Errorf("expected IP to be hashed, got %q", ipKey)
+	}
+	if again := keyFunc(req); again != ipKey {
+		t.Errorf("expected stable hash, got %q then %q", ipKey, again)
+	}
+
+	req = req.WithContext(SetUserDID(req.Context(), "did:plc:alice"))
+	if got := keyFunc(req); got != "user:did:plc:alice" {
+		t.Errorf("expected user:did:plc:alice, got %q", got)
+	}
+}
+
+func TestRateLimit_ReturnsRetryAfter(t *testing.T) {
+	store, _ := newTestTokenBucketStore()
+	handler := RateLimit(RateLimitOptions{
+		Group:             "search",
+		RequestsPerMinute: 30, // one token every 2s
+		Burst:             1,
+		Store:             store,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for i, wantStatus := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		req := httptest.NewRequest(http.MethodGet, "/search", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != wantStatus {
+			t.Fatalf("request %d: got status %d, want %d", i+1, rr.Code, wantStatus)
+		}
+		if wantStatus == http.StatusTooManyRequests {
+			if got := rr.Header().Get("Retry-After"); got != "2" {
+				t.Errorf("expected Retry-After 2, got %q", got)
+			}
+		}
+	}
+}
+
+func TestRateLimit_SeparateBucketsPerGroup(t *testing.T) {
+	store, _ := newTestTokenBucketStore()
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	search := RateLimit(RateLimitOptions{Group: "search", RequestsPerMinute: 60, Burst: 1, Store: store})(ok)
+	rsvp := RateLimit(RateLimitOptions{Group: "rsvp", RequestsPerMinute: 60, Burst: 1, Store: store})(ok)
+
+	do := func(h http.Handler, did string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req = req.WithContext(SetUserDID(req.Context(), did))
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	if code := do(search, "did:plc:alice"); code != http.StatusOK {
+		t.Fatalf("search: got status %d, want %d", code, http.StatusOK)
+	}
+	if code := do(search, "did:plc:alice"); code != http.StatusTooManyRequests {
+		t.Errorf("search: got status %d, want %d", code, http.StatusTooManyRequests)
+	}
+	// Same user, different route group
+	if code := do(rsvp, "did:plc:alice"); code != http.StatusOK {
+		t.Errorf("rsvp: got status %d, want %d", code, http.StatusOK)
+	}
+	// Different user, same route group
+	if code := do(search, "did:plc:bob"); code != http.StatusOK {
+		t.Errorf("search as bob: got status %d, want %d", code, http.StatusOK)
+	}
+}
+
+func TestRateLimit_UsesPluggableStore(t *testing.T) {
+	store := &recordingStore{}
+	handler := RateLimit(RateLimitOptions{
+		Group:             "scenes",
+		RequestsPerMinute: 10,
+		Store:             store,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodPost, "/scenes", nil)
+	req = req.WithContext(SetUserDID(req.Context(), "did:plc:alice"))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if len(store.keys) != 1 || store.keys[0] != "scenes|user:did:plc:alice" {
+		t.Errorf("expected key scenes|user:did:plc:alice, got %v", store.keys)
+	}
+}
+
+func TestRateLimitOptions_Validate(t *testing.T) {
+	if err := (RateLimitOptions{RequestsPerMinute: 1}).Validate(); err != nil {
+		t.Errorf("expected valid options, got %v", err)
+	}
+	if err := (RateLimitOptions{RequestsPerMinute: 0}).Validate(); err == nil {
+		t.Error("expected error for RequestsPerMinute = 0")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected RateLimit to panic on invalid options")
+		}
+	}()
+	RateLimit(RateLimitOptions{})
+}
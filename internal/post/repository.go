@@ -1,133 +1,129 @@
-// Package post provides models and repository for managing posts
-// with AT Protocol record tracking for idempotent ingestion.
+// Package post provides models and repository for scene posts, short text
+// updates shared by scene members.
 package post
 
 import (
 	"errors"
+	"sort"
+	"strings"
 	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
+// MaxTextLength is the maximum post text length in characters (runes).
+const MaxTextLength = 2000
+
+// Post list pagination limits.
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
 // Common errors for post operations.
 var (
-	ErrPostNotFound = errors.New("post not found")
+	ErrPostNotFound  = errors.New("post not found")
+	ErrPostDeleted   = errors.New("post has been deleted")
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+	ErrEmptyText     = errors.New("post text is required")
+	ErrTextTooLong   = errors.New("post text must not exceed 2000 characters")
 )
 
-// Post represents a content post within scenes/events.
+// Post represents a text post within a scene.
 type Post struct {
-	ID        string    `json:"id"`
-	SceneID   *string   `json:"scene_id,omitempty"`
-	EventID   *string   `json:"event_id,omitempty"`
-	AuthorDID string    `json:"author_did"`
-	Text      string    `json:"text"`
-	
-	// AT Protocol record tracking
-	RecordDID  *string `json:"record_did,omitempty"`
-	RecordRKey *string `json:"record_rkey,omitempty"`
-	
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        string     `json:"id"`
+	SceneID   string     `json:"scene_id"`
+	AuthorDID string     `json:"author_did"`
+	Text      string     `json:"text"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
-// UpsertResult tracks statistics for upsert operations.
-type UpsertResult struct {
-	Inserted bool   // True if new record was inserted
-	ID       string // The UUID of the upserted record
+// NormalizeText strips control characters (other than newlines and tabs) and
+// surrounding whitespace from post text, then enforces the length limits.
+// Returns ErrEmptyText or ErrTextTooLong if the result is out of bounds.
+func NormalizeText(text string) (string, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, text)
+	cleaned = strings.TrimSpace(cleaned)
+
+	if cleaned == "" {
+		return "", ErrEmptyText
+	}
+	if utf8.RuneCountInString(cleaned) > MaxTextLength {
+		return "", ErrTextTooLong
+	}
+	return cleaned, nil
 }
 
 // PostRepository defines the interface for post data operations.
 type PostRepository interface {
-	// Upsert inserts a new post or updates existing one based on (record_did, record_rkey).
-	// Returns UpsertResult indicating whether insert or update occurred.
-	Upsert(post *Post) (*UpsertResult, error)
+	// Insert stores a new post, assigning its ID and creation time.
+	Insert(post *Post) error
 
 	// GetByID retrieves a post by its UUID.
+	// Returns ErrPostNotFound if the post doesn't exist, or ErrPostDeleted
+	// if it has been soft-deleted.
 	GetByID(id string) (*Post, error)
 
-	// GetByRecordKey retrieves a post by its AT Protocol record key.
-	GetByRecordKey(did, rkey string) (*Post, error)
+	// ListByScene retrieves non-deleted posts for a scene, newest first.
+	// Returns the page of posts and a cursor for the next page (empty if none).
+	// Returns ErrInvalidCursor if the cursor cannot be parsed.
+	ListByScene(sceneID string, limit int, cursor string) ([]*Post, string, error)
+
+	// Delete soft-deletes a post by setting deleted_at timestamp.
+	// Returns ErrPostNotFound if the post doesn't exist or is already deleted.
+	Delete(id string) error
 }
 
 // InMemoryPostRepository is an in-memory implementation of PostRepository.
 // Thread-safe via RWMutex.
 type InMemoryPostRepository struct {
 	mu    sync.RWMutex
-	posts map[string]*Post                    // UUID -> Post
-	keys  map[string]string                   // "did:rkey" -> UUID
+	posts map[string]*Post // UUID -> Post
 }
 
 // NewInMemoryPostRepository creates a new in-memory post repository.
 func NewInMemoryPostRepository() *InMemoryPostRepository {
 	return &InMemoryPostRepository{
 		posts: make(map[string]*Post),
-		keys:  make(map[string]string),
 	}
 }
 
-// makeKey creates a composite key from DID and rkey using a null byte separator to avoid collisions.
-// AT Protocol DIDs contain colons (e.g., "did:plc:abc123"), so using a null byte prevents
-// collisions like did="a:b" + rkey="c" vs did="a" + rkey="b:c" both producing "a:b:c".
-func makeKey(did, rkey string) string {
-	return did + "\x00" + rkey
+// copyPost returns a copy of p so callers cannot mutate stored state.
+func copyPost(p *Post) *Post {
+	postCopy := *p
+	if p.DeletedAt != nil {
+		deletedAt := *p.DeletedAt
+		postCopy.DeletedAt = &deletedAt
+	}
+	return &postCopy
 }
 
-// Upsert inserts a new post or updates existing one based on (record_did, record_rkey).
-func (r *InMemoryPostRepository) Upsert(post *Post) (*UpsertResult, error) {
+// Insert stores a new post, assigning its ID and creation time.
+func (r *InMemoryPostRepository) Insert(post *Post) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	now := time.Now()
-	var inserted bool
-	var id string
-
-	// Check if post exists by record key
-	if post.RecordDID != nil && post.RecordRKey != nil {
-		key := makeKey(*post.RecordDID, *post.RecordRKey)
-		existingID, exists := r.keys[key]
-		
-		if exists {
-			// Update existing post
-			existing := r.posts[existingID]
-			existing.SceneID = post.SceneID
-			existing.EventID = post.EventID
-			existing.AuthorDID = post.AuthorDID
-			existing.Text = post.Text
-			existing.UpdatedAt = now
-			inserted = false
-			id = existingID
-		} else {
-			// Insert new post
-			if post.ID == "" {
-				post.ID = uuid.New().String()
-			}
-			post.CreatedAt = now
-			post.UpdatedAt = now
-			
-			postCopy := *post
-			r.posts[post.ID] = &postCopy
-			r.keys[key] = post.ID
-			inserted = true
-			id = post.ID
-		}
-	} else {
-		// No record key, always insert new with new UUID
-		newID := uuid.New().String()
-		post.ID = newID
-		post.CreatedAt = now
-		post.UpdatedAt = now
-		
-		postCopy := *post
-		r.posts[newID] = &postCopy
-		inserted = true
-		id = newID
+	if post.ID == "" {
+		post.ID = uuid.New().String()
+	}
+	if post.CreatedAt.IsZero() {
+		post.CreatedAt = time.Now()
 	}
 
-	return &UpsertResult{
-		Inserted: inserted,
-		ID:       id,
-	}, nil
+	r.posts[post.ID] = copyPost(post)
+	return nil
 }
 
 // GetByID retrieves a post by its UUID.
@@ -135,27 +131,92 @@ func (r *InMemoryPostRepository) GetByID(id string) (*Post, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	post, ok := r.posts[id]
-	if !ok {
+	post, exists := r.posts[id]
+	if !exists {
 		return nil, ErrPostNotFound
 	}
-
-	postCopy := *post
-	return &postCopy, nil
+	if post.DeletedAt != nil {
+		return nil, ErrPostDeleted
+	}
+	return copyPost(post), nil
 }
 
-// GetByRecordKey retrieves a post by its AT Protocol record key.
-func (r *InMemoryPostRepository) GetByRecordKey(did, rkey string) (*Post, error) {
+// ListByScene retrieves non-deleted posts for a scene, newest first.
+// Cursor format is "RFC3339Nano|ID" of the last post on the previous page.
+func (r *InMemoryPostRepository) ListByScene(sceneID string, limit int, cursor string) ([]*Post, string, error) {
+	var cursorTime time.Time
+	var cursorID string
+	if cursor != "" {
+		parts := strings.SplitN(cursor, "|", 2)
+		if len(parts) != 2 {
+			return nil, "", ErrInvalidCursor
+		}
+		parsedTime, err := time.Parse(time.RFC3339Nano, parts[0])
+		if err != nil {
+			return nil, "", ErrInvalidCursor
+		}
+		cursorTime = parsedTime
+		cursorID = parts[1]
+	}
+
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	if limit > MaxListLimit {
+		limit = MaxListLimit
+	}
+
 	r.mu.RLock()
-	defer r.mu.RUnlock()
+	results := make([]*Post, 0)
+	for _, post := range r.posts {
+		if post.SceneID != sceneID || post.DeletedAt != nil {
+			continue
+		}
+		results = append(results, copyPost(post))
+	}
+	r.mu.RUnlock()
 
-	key := makeKey(did, rkey)
-	id, ok := r.keys[key]
-	if !ok {
-		return nil, ErrPostNotFound
+	// Sort by created_at descending, then by ID descending for stable ordering
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].CreatedAt.Equal(results[j].CreatedAt) {
+			return results[i].ID > results[j].ID
+		}
+		return results[i].CreatedAt.After(results[j].CreatedAt)
+	})
+
+	// Skip everything up to and including the cursor position
+	if cursor != "" {
+		start := len(results)
+		for i, post := range results {
+			if post.CreatedAt.Before(cursorTime) || (post.CreatedAt.Equal(cursorTime) && post.ID < cursorID) {
+				start = i
+				break
+			}
+		}
+		results = results[start:]
 	}
 
-	post := r.posts[id]
-	postCopy := *post
-	return &postCopy, nil
+	var nextCursor string
+	if len(results) > limit {
+		last := results[limit-1]
+		nextCursor = last.CreatedAt.Format(time.RFC3339Nano) + "|" + last.ID
+		results = results[:limit]
+	}
+
+	return results, nextCursor, nil
+}
+
+// Delete soft-deletes a post by setting deleted_at timestamp.
+func (r *InMemoryPostRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post, exists := r.posts[id]
+	if !exists || post.DeletedAt != nil {
+		return ErrPostNotFound
+	}
+
+	now := time.Now()
+	post.DeletedAt = &now
+	return nil
 }
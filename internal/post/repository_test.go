@@ -1,207 +1,141 @@
 package post
 
 import (
+	"errors"
+	"strings"
 	"testing"
+	"time"
 )
 
-func strPtr(s string) *string {
-	return &s
-}
-
-func TestPostRepository_Upsert_Insert(t *testing.T) {
-	repo := NewInMemoryPostRepository()
-	did := "did:example:alice"
-	rkey := "post123"
-
-	post := &Post{
-		AuthorDID:  did,
-		Text:       "Hello world",
-		RecordDID:  strPtr(did),
-		RecordRKey: strPtr(rkey),
-	}
-
-	result, err := repo.Upsert(post)
-	if err != nil {
-		t.Fatalf("Upsert failed: %v", err)
-	}
-
-	if !result.Inserted {
-		t.Error("Expected insert, got update")
-	}
-
-	if result.ID == "" {
-		t.Error("Expected non-empty ID")
-	}
-
-	// Verify we can retrieve it
-	retrieved, err := repo.GetByRecordKey(did, rkey)
-	if err != nil {
-		t.Fatalf("GetByRecordKey failed: %v", err)
-	}
-
-	if retrieved.Text != "Hello world" {
-		t.Errorf("Expected text 'Hello world', got %s", retrieved.Text)
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{name: "plain text", input: "Doors at 9", want: "Doors at 9"},
+		{name: "keeps newlines and tabs", input: "line one\n\tline two", want: "line one\n\tline two"},
+		{name: "strips control characters", input: "hi\x00the\x1bre\x7f", want: "hithere"},
+		{name: "trims whitespace", input: "  padded \n", want: "padded"},
+		{name: "empty", input: "", wantErr: ErrEmptyText},
+		{name: "only control characters", input: "\x00\x01 ", wantErr: ErrEmptyText},
+		{name: "at limit", input: strings.Repeat("é", MaxTextLength), want: strings.Repeat("é", MaxTextLength)},
+		{name: "over limit", input: strings.Repeat("a", MaxTextLength+1), wantErr: ErrTextTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeText(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
 	}
 }
 
-func TestPostRepository_Upsert_Update(t *testing.T) {
+func TestPostRepository_InsertAndGet(t *testing.T) {
 	repo := NewInMemoryPostRepository()
-	did := "did:example:alice"
-	rkey := "post123"
 
-	// First insert
-	post := &Post{
-		AuthorDID:  did,
-		Text:       "Hello world",
-		RecordDID:  strPtr(did),
-		RecordRKey: strPtr(rkey),
+	post := &Post{SceneID: "scene-1", AuthorDID: "did:plc:author", Text: "hello"}
+	if err := repo.Insert(post); err != nil {
+		t.Fatalf("Insert failed: %v", err)
 	}
-
-	result1, err := repo.Upsert(post)
-	if err != nil {
-		t.Fatalf("First upsert failed: %v", err)
-	}
-
-	if !result1.Inserted {
-		t.Error("Expected insert on first upsert")
+	if post.ID == "" {
+		t.Error("expected ID to be assigned")
 	}
-
-	// Second upsert with same record key
-	post2 := &Post{
-		AuthorDID:  did,
-		Text:       "Updated text",
-		RecordDID:  strPtr(did),
-		RecordRKey: strPtr(rkey),
+	if post.CreatedAt.IsZero() {
+		t.Error("expected CreatedAt to be set")
 	}
 
-	result2, err := repo.Upsert(post2)
+	retrieved, err := repo.GetByID(post.ID)
 	if err != nil {
-		t.Fatalf("Second upsert failed: %v", err)
-	}
-
-	if result2.Inserted {
-		t.Error("Expected update, got insert")
-	}
-
-	if result1.ID != result2.ID {
-		t.Errorf("Expected same ID, got %s and %s", result1.ID, result2.ID)
+		t.Fatalf("GetByID failed: %v", err)
 	}
-
-	// Verify update was persisted
-	retrieved, err := repo.GetByRecordKey(did, rkey)
-	if err != nil {
-		t.Fatalf("GetByRecordKey failed: %v", err)
+	if retrieved.Text != "hello" || retrieved.AuthorDID != "did:plc:author" {
+		t.Errorf("unexpected post: %+v", retrieved)
 	}
 
-	if retrieved.Text != "Updated text" {
-		t.Errorf("Expected updated text, got %s", retrieved.Text)
+	if _, err := repo.GetByID("missing"); !errors.Is(err, ErrPostNotFound) {
+		t.Errorf("expected ErrPostNotFound, got %v", err)
 	}
 }
 
-func TestPostRepository_Upsert_Idempotent(t *testing.T) {
+func TestPostRepository_Delete(t *testing.T) {
 	repo := NewInMemoryPostRepository()
-	did := "did:example:alice"
-	rkey := "post123"
 
-	post := &Post{
-		AuthorDID:  did,
-		Text:       "Same content",
-		RecordDID:  strPtr(did),
-		RecordRKey: strPtr(rkey),
+	post := &Post{SceneID: "scene-1", AuthorDID: "did:plc:author", Text: "hello"}
+	if err := repo.Insert(post); err != nil {
+		t.Fatalf("Insert failed: %v", err)
 	}
 
-	// First upsert
-	result1, err := repo.Upsert(post)
-	if err != nil {
-		t.Fatalf("First upsert failed: %v", err)
+	if err := repo.Delete(post.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
 	}
-
-	// Second upsert with same content
-	result2, err := repo.Upsert(post)
-	if err != nil {
-		t.Fatalf("Second upsert failed: %v", err)
+	if _, err := repo.GetByID(post.ID); !errors.Is(err, ErrPostDeleted) {
+		t.Errorf("expected ErrPostDeleted, got %v", err)
 	}
-
-	// Should update, not insert
-	if result2.Inserted {
-		t.Error("Expected update (idempotent), got insert")
+	if err := repo.Delete(post.ID); !errors.Is(err, ErrPostNotFound) {
+		t.Errorf("expected ErrPostNotFound deleting twice, got %v", err)
 	}
 
-	if result1.ID != result2.ID {
-		t.Error("Idempotent upserts should return same ID")
-	}
-}
-
-func TestPostRepository_Upsert_WithoutRecordKey(t *testing.T) {
-	repo := NewInMemoryPostRepository()
-
-	post := &Post{
-		AuthorDID: "did:example:alice",
-		Text:      "No record key",
-	}
-
-	result, err := repo.Upsert(post)
-	if err != nil {
-		t.Fatalf("Upsert failed: %v", err)
-	}
-
-	if !result.Inserted {
-		t.Error("Expected insert when no record key provided")
-	}
-
-	// Second upsert without record key should also insert
-	result2, err := repo.Upsert(post)
+	posts, _, err := repo.ListByScene("scene-1", 0, "")
 	if err != nil {
-		t.Fatalf("Second upsert failed: %v", err)
+		t.Fatalf("ListByScene failed: %v", err)
 	}
-
-	if !result2.Inserted {
-		t.Error("Expected insert when no record key provided")
-	}
-
-	// IDs should be different
-	if result.ID == result2.ID {
-		t.Error("Expected different IDs for separate inserts")
+	if len(posts) != 0 {
+		t.Errorf("expected deleted post to be excluded, got %d posts", len(posts))
 	}
 }
 
-func TestPostRepository_GetByRecordKey_NotFound(t *testing.T) {
+func TestPostRepository_ListByScene_Pagination(t *testing.T) {
 	repo := NewInMemoryPostRepository()
 
-	post, err := repo.GetByRecordKey("did:example:alice", "nonexistent")
-	if err != ErrPostNotFound {
-		t.Errorf("Expected ErrPostNotFound, got %v", err)
-	}
-
-	if post != nil {
-		t.Error("Expected nil post for non-existent record")
-	}
-}
-
-func TestPostRepository_GetByID_AfterUpsert(t *testing.T) {
-	repo := NewInMemoryPostRepository()
-	did := "did:example:alice"
-	rkey := "post123"
-
-	post := &Post{
-		AuthorDID:  did,
-		Text:       "Test post",
-		RecordDID:  strPtr(did),
-		RecordRKey: strPtr(rkey),
-	}
-
-	result, err := repo.Upsert(post)
-	if err != nil {
-		t.Fatalf("Upsert failed: %v", err)
-	}
-
-	// Retrieve by UUID
-	retrieved, err := repo.GetByID(result.ID)
-	if err != nil {
-		t.Fatalf("GetByID failed: %v", err)
-	}
-
-	if retrieved.Text != "Test post" {
-		t.Errorf("Expected text 'Test post', got %s", retrieved.Text)
+	base := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := repo.Insert(&Post{
+			SceneID:   "scene-1",
+			AuthorDID: "did:plc:author",
+			Text:      "post",
+			CreatedAt: base.Add(time.Duration(i) * time.Minute),
+		}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+	if err := repo.Insert(&Post{SceneID: "scene-2", AuthorDID: "did:plc:author", Text: "other"}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	var all []*Post
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatal("pagination did not terminate")
+		}
+		page, next, err := repo.ListByScene("scene-1", 2, cursor)
+		if err != nil {
+			t.Fatalf("ListByScene failed: %v", err)
+		}
+		all = append(all, page...)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	if len(all) != 5 {
+		t.Fatalf("expected 5 posts across pages, got %d", len(all))
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i].CreatedAt.After(all[i-1].CreatedAt) {
+			t.Errorf("expected newest first, post %d is newer than post %d", i, i-1)
+		}
+	}
+
+	if _, _, err := repo.ListByScene("scene-1", 2, "garbage"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
 	}
 }
@@ -0,0 +1,286 @@
+// Package report provides models and repository for user reports flagging
+// problematic scenes and events for moderator review.
+package report
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Reportable entity types.
+const (
+	EntityTypeScene = "scene"
+	EntityTypeEvent = "event"
+)
+
+// Report statuses. Reports start open and move to exactly one terminal status.
+const (
+	StatusOpen      = "open"
+	StatusResolved  = "resolved"  // Moderator took action
+	StatusDismissed = "dismissed" // Moderator found no violation
+)
+
+// MaxReasonLength is the maximum report reason length in characters (runes).
+const MaxReasonLength = 1000
+
+// Report list pagination limits.
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+// Common errors for report operations.
+var (
+	ErrReportNotFound    = errors.New("report not found")
+	ErrDuplicateReport   = errors.New("reporter already has an open report for this entity")
+	ErrInvalidTransition = errors.New("report status transition not allowed")
+	ErrInvalidStatus     = errors.New("invalid report status")
+	ErrInvalidEntityType = errors.New("entity type must be 'scene' or 'event'")
+	ErrInvalidCursor     = errors.New("invalid pagination cursor")
+	ErrEmptyReason       = errors.New("report reason is required")
+	ErrReasonTooLong     = errors.New("report reason must not exceed 1000 characters")
+)
+
+// Report represents a user's report of a scene or event.
+type Report struct {
+	ID          string     `json:"id"`
+	ReporterDID string     `json:"reporter_did"`
+	EntityType  string     `json:"entity_type"`
+	EntityID    string     `json:"entity_id"`
+	Reason      string     `json:"reason"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+	ResolvedBy  string     `json:"resolved_by,omitempty"` // Moderator DID
+}
+
+// ValidEntityType reports whether entityType can be reported.
+func ValidEntityType(entityType string) bool {
+	return entityType == EntityTypeScene || entityType == EntityTypeEvent
+}
+
+// ValidStatus reports whether status is a known report status.
+func ValidStatus(status string) bool {
+	return status == StatusOpen || status == StatusResolved || status == StatusDismissed
+}
+
+// CanTransition reports whether a report may move from one status to another.
+// Only open reports can change, and only to a terminal status.
+func CanTransition(from, to string) bool {
+	return from == StatusOpen && (to == StatusResolved || to == StatusDismissed)
+}
+
+// NormalizeReason strips control characters (other than newlines and tabs)
+// and surrounding whitespace from a report reason, then enforces the length
+// limits. Returns ErrEmptyReason or ErrReasonTooLong if the result is out of
+// bounds.
+func NormalizeReason(reason string) (string, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, reason)
+	cleaned = strings.TrimSpace(cleaned)
+
+	if cleaned == "" {
+		return "", ErrEmptyReason
+	}
+	if utf8.RuneCountInString(cleaned) > MaxReasonLength {
+		return "", ErrReasonTooLong
+	}
+	return cleaned, nil
+}
+
+// ReportRepository defines the interface for report data operations.
+type ReportRepository interface {
+	// Create stores a new open report, assigning its ID and creation time.
+	// Returns ErrDuplicateReport if the reporter already has an open report
+	// for the same entity, so each user can flag an entity at most once
+	// until a moderator acts on it.
+	Create(report *Report) error
+
+	// GetByID retrieves a report by its UUID.
+	// Returns ErrReportNotFound if the report doesn't exist.
+	GetByID(id string) (*Report, error)
+
+	// List retrieves reports newest first, optionally filtered by status
+	// (empty matches all). Returns the page of reports and a cursor for the
+	// next page (empty if none).
+	// Returns ErrInvalidCursor if the cursor cannot be parsed.
+	List(status string, limit int, cursor string) ([]*Report, string, error)
+
+	// Resolve moves an open report to a terminal status, recording the
+	// moderator's DID and the resolution time.
+	// Returns ErrInvalidStatus if status is not terminal, ErrReportNotFound
+	// if the report doesn't exist, or ErrInvalidTransition if it is no
+	// longer open.
+	Resolve(id, status, moderatorDID string) (*Report, error)
+}
+
+// InMemoryReportRepository is an in-memory implementation of ReportRepository.
+// Thread-safe via RWMutex.
+type InMemoryReportRepository struct {
+	mu      sync.RWMutex
+	reports map[string]*Report // UUID -> Report
+}
+
+// NewInMemoryReportRepository creates a new in-memory report repository.
+func NewInMemoryReportRepository() *InMemoryReportRepository {
+	return &InMemoryReportRepository{
+		reports: make(map[string]*Report),
+	}
+}
+
+// copyReport returns a copy of rep so callers cannot mutate stored state.
+func copyReport(rep *Report) *Report {
+	reportCopy := *rep
+	if rep.ResolvedAt != nil {
+		resolvedAt := *rep.ResolvedAt
+		reportCopy.ResolvedAt = &resolvedAt
+	}
+	return &reportCopy
+}
+
+// Create stores a new open report, assigning its ID and creation time.
+func (r *InMemoryReportRepository) Create(report *Report) error {
+	if !ValidEntityType(report.EntityType) {
+		return ErrInvalidEntityType
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.reports {
+		if existing.Status == StatusOpen &&
+			existing.ReporterDID == report.ReporterDID &&
+			existing.EntityType == report.EntityType &&
+			existing.EntityID == report.EntityID {
+			return ErrDuplicateReport
+		}
+	}
+
+	if report.ID == "" {
+		report.ID = uuid.New().String()
+	}
+	if report.CreatedAt.IsZero() {
+		report.CreatedAt = time.Now()
+	}
+	report.Status = StatusOpen
+	report.ResolvedAt = nil
+	report.ResolvedBy = ""
+
+	r.reports[report.ID] = copyReport(report)
+	return nil
+}
+
+// GetByID retrieves a report by its UUID.
+func (r *InMemoryReportRepository) GetByID(id string) (*Report, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	report, exists := r.reports[id]
+	if !exists {
+		return nil, ErrReportNotFound
+	}
+	return copyReport(report), nil
+}
+
+// List retrieves reports newest first, optionally filtered by status.
+// Cursor format is "RFC3339Nano|ID" of the last report on the previous page.
+func (r *InMemoryReportRepository) List(status string, limit int, cursor string) ([]*Report, string, error) {
+	var cursorTime time.Time
+	var cursorID string
+	if cursor != "" {
+		parts := strings.SplitN(cursor, "|", 2)
+		if len(parts) != 2 {
+			return nil, "", ErrInvalidCursor
+		}
+		parsedTime, err := time.Parse(time.RFC3339Nano, parts[0])
+		if err != nil {
+			return nil, "", ErrInvalidCursor
+		}
+		cursorTime = parsedTime
+		cursorID = parts[1]
+	}
+
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	if limit > MaxListLimit {
+		limit = MaxListLimit
+	}
+
+	r.mu.RLock()
+	results := make([]*Report, 0)
+	for _, report := range r.reports {
+		if status != "" && report.Status != status {
+			continue
+		}
+		results = append(results, copyReport(report))
+	}
+	r.mu.RUnlock()
+
+	// Sort by created_at descending, then by ID descending for stable ordering
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].CreatedAt.Equal(results[j].CreatedAt) {
+			return results[i].ID > results[j].ID
+		}
+		return results[i].CreatedAt.After(results[j].CreatedAt)
+	})
+
+	// Skip everything up to and including the cursor position
+	if cursor != "" {
+		start := len(results)
+		for i, report := range results {
+			if report.CreatedAt.Before(cursorTime) || (report.CreatedAt.Equal(cursorTime) && report.ID < cursorID) {
+				start = i
+				break
+			}
+		}
+		results = results[start:]
+	}
+
+	var nextCursor string
+	if len(results) > limit {
+		last := results[limit-1]
+		nextCursor = last.CreatedAt.Format(time.RFC3339Nano) + "|" + last.ID
+		results = results[:limit]
+	}
+
+	return results, nextCursor, nil
+}
+
+// Resolve moves an open report to a terminal status.
+func (r *InMemoryReportRepository) Resolve(id, status, moderatorDID string) (*Report, error) {
+	if status != StatusResolved && status != StatusDismissed {
+		return nil, ErrInvalidStatus
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report, exists := r.reports[id]
+	if !exists {
+		return nil, ErrReportNotFound
+	}
+	if !CanTransition(report.Status, status) {
+		return nil, ErrInvalidTransition
+	}
+
+	now := time.Now()
+	report.Status = status
+	report.ResolvedAt = &now
+	report.ResolvedBy = moderatorDID
+	return copyReport(report), nil
+}
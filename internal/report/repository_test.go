@@ -0,0 +1,160 @@
+package report
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNormalizeReason(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{name: "plain text", input: "Spam links", want: "Spam links"},
+		{name: "strips control characters", input: "sp\x00am\x1b", want: "spam"},
+		{name: "trims whitespace", input: "  padded \n", want: "padded"},
+		{name: "empty", input: " \t", wantErr: ErrEmptyReason},
+		{name: "over limit", input: strings.Repeat("a", MaxReasonLength+1), wantErr: ErrReasonTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeReason(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReportRepository_DuplicateOpenReport(t *testing.T) {
+	repo := NewInMemoryReportRepository()
+
+	first := &Report{ReporterDID: "did:plc:alice", EntityType: EntityTypeScene, EntityID: "scene-1", Reason: "spam"}
+	if err := repo.Create(first); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if first.ID == "" || first.Status != StatusOpen || first.CreatedAt.IsZero() {
+		t.Errorf("expected ID, open status, and creation time to be set, got %+v", first)
+	}
+
+	// Same reporter and entity while the first report is open
+	dup := &Report{ReporterDID: "did:plc:alice", EntityType: EntityTypeScene, EntityID: "scene-1", Reason: "still spam"}
+	if err := repo.Create(dup); !errors.Is(err, ErrDuplicateReport) {
+		t.Fatalf("expected ErrDuplicateReport, got %v", err)
+	}
+
+	// Other reporters and other entities are unaffected
+	for _, rep := range []*Report{
+		{ReporterDID: "did:plc:bob", EntityType: EntityTypeScene, EntityID: "scene-1", Reason: "spam"},
+		{ReporterDID: "did:plc:alice", EntityType: EntityTypeEvent, EntityID: "scene-1", Reason: "spam"},
+		{ReporterDID: "did:plc:alice", EntityType: EntityTypeScene, EntityID: "scene-2", Reason: "spam"},
+	} {
+		if err := repo.Create(rep); err != nil {
+			t.Errorf("expected report %+v to be accepted, got %v", rep, err)
+		}
+	}
+
+	// Once resolved, the reporter may flag the entity again
+	if _, err := repo.Resolve(first.ID, StatusDismissed, "did:plc:mod"); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if err := repo.Create(dup); err != nil {
+		t.Errorf("expected new report after resolution, got %v", err)
+	}
+}
+
+func TestReportRepository_CreateInvalidEntityType(t *testing.T) {
+	repo := NewInMemoryReportRepository()
+	err := repo.Create(&Report{ReporterDID: "did:plc:alice", EntityType: "user", EntityID: "u", Reason: "spam"})
+	if !errors.Is(err, ErrInvalidEntityType) {
+		t.Errorf("expected ErrInvalidEntityType, got %v", err)
+	}
+}
+
+func TestReportRepository_ResolveTransitions(t *testing.T) {
+	repo := NewInMemoryReportRepository()
+	rep := &Report{ReporterDID: "did:plc:alice", EntityType: EntityTypeEvent, EntityID: "event-1", Reason: "scam"}
+	if err := repo.Create(rep); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if _, err := repo.Resolve(rep.ID, StatusOpen, "did:plc:mod"); !errors.Is(err, ErrInvalidStatus) {
+		t.Errorf("expected ErrInvalidStatus for reopening, got %v", err)
+	}
+	if _, err := repo.Resolve("missing", StatusResolved, "did:plc:mod"); !errors.Is(err, ErrReportNotFound) {
+		t.Errorf("expected ErrReportNotFound, got %v", err)
+	}
+
+	resolved, err := repo.Resolve(rep.ID, StatusResolved, "did:plc:mod")
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if resolved.Status != StatusResolved || resolved.ResolvedBy != "did:plc:mod" || resolved.ResolvedAt == nil {
+		t.Errorf("expected resolution to be recorded, got %+v", resolved)
+	}
+
+	// Terminal statuses cannot change
+	if _, err := repo.Resolve(rep.ID, StatusDismissed, "did:plc:mod"); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("expected ErrInvalidTransition, got %v", err)
+	}
+	stored, _ := repo.GetByID(rep.ID)
+	if stored.Status != StatusResolved {
+		t.Errorf("expected status to stay resolved, got %s", stored.Status)
+	}
+}
+
+func TestReportRepository_ListPagination(t *testing.T) {
+	repo := NewInMemoryReportRepository()
+	base := time.Now()
+	for i := 0; i < 5; i++ {
+		rep := &Report{
+			ReporterDID: "did:plc:alice",
+			EntityType:  EntityTypeScene,
+			EntityID:    "scene-" + string(rune('a'+i)),
+			Reason:      "spam",
+			CreatedAt:   base.Add(time.Duration(i) * time.Minute),
+		}
+		if err := repo.Create(rep); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		if i == 0 {
+			if _, err := repo.Resolve(rep.ID, StatusResolved, "did:plc:mod"); err != nil {
+				t.Fatalf("Resolve failed: %v", err)
+			}
+		}
+	}
+
+	page, cursor, err := repo.List(StatusOpen, 3, "")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(page) != 3 || cursor == "" {
+		t.Fatalf("expected 3 reports and a cursor, got %d and %q", len(page), cursor)
+	}
+	if page[0].EntityID != "scene-e" {
+		t.Errorf("expected newest first, got %s", page[0].EntityID)
+	}
+
+	page, cursor, err = repo.List(StatusOpen, 3, cursor)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(page) != 1 || cursor != "" || page[0].EntityID != "scene-b" {
+		t.Errorf("expected last open report scene-b without cursor, got %d reports, cursor %q", len(page), cursor)
+	}
+
+	if all, _, _ := repo.List("", 10, ""); len(all) != 5 {
+		t.Errorf("expected 5 reports without status filter, got %d", len(all))
+	}
+	if _, _, err := repo.List("", 10, "garbage"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+}
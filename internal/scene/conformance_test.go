@@ -0,0 +1,26 @@
+package scene_test
+
+import (
+	"testing"
+
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/scene/scenetest"
+)
+
+func TestInMemorySceneRepository_Conformance(t *testing.T) {
+	scenetest.TestSceneRepository(t, func(t *testing.T) scene.SceneRepository {
+		return scene.NewInMemorySceneRepository()
+	})
+}
+
+func TestInMemoryEventRepository_Conformance(t *testing.T) {
+	scenetest.TestEventRepository(t, func(t *testing.T) scene.EventRepository {
+		return scene.NewInMemoryEventRepository()
+	})
+}
+
+func TestInMemoryRSVPRepository_Conformance(t *testing.T) {
+	scenetest.TestRSVPRepository(t, func(t *testing.T) scene.RSVPRepository {
+		return scene.NewInMemoryRSVPRepository()
+	})
+}
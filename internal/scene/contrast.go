@@ -0,0 +1,27 @@
+package scene
+
+import (
+	"fmt"
+
+	"github.com/onnwee/subcults/internal/color"
+)
+
+// MinTextContrast is the minimum WCAG AA contrast ratio required between a
+// palette's text and background colors.
+const MinTextContrast = 4.5
+
+// ContrastRatio returns the WCAG 2.1 contrast ratio between two hex colors
+// (#RGB or #RRGGBB).
+// The result ranges from 1 (identical) to 21 (black on white) and does not
+// depend on argument order. Returns an error if either color is malformed.
+func ContrastRatio(hexA, hexB string) (float64, error) {
+	a, err := color.ParseHexColor(hexA)
+	if err != nil {
+		return 0, fmt.Errorf("invalid color %q: %w", hexA, err)
+	}
+	b, err := color.ParseHexColor(hexB)
+	if err != nil {
+		return 0, fmt.Errorf("invalid color %q: %w", hexB, err)
+	}
+	return color.ContrastRatio(a, b), nil
+}
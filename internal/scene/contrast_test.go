@@ -0,0 +1,68 @@
+package scene
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/color"
+)
+
+func TestContrastRatio(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want float64
+	}{
+		{"black on white", "#000000", "#ffffff", 21},
+		{"white on black", "#FFFFFF", "#000000", 21},
+		{"identical colors", "#336699", "#336699", 1},
+		{"light gray on white", "#cccccc", "#ffffff", 1.61},
+		{"dark gray on white", "#595959", "#ffffff", 7.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ContrastRatio(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("ContrastRatio() error = %v", err)
+			}
+			if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("ContrastRatio() = %.2f, want %.2f", got, tt.want)
+			}
+
+			reversed, err := ContrastRatio(tt.b, tt.a)
+			if err != nil {
+				t.Fatalf("ContrastRatio() reversed error = %v", err)
+			}
+			if reversed != got {
+				t.Errorf("ContrastRatio() not symmetric: %v vs %v", got, reversed)
+			}
+		})
+	}
+}
+
+func TestContrastRatio_InvalidHex(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+	}{
+		{"first malformed", "#gggggg", "#ffffff"},
+		{"second malformed", "#000000", "fff"},
+		{"empty", "", "#ffffff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ratio, err := ContrastRatio(tt.a, tt.b)
+			if !errors.Is(err, color.ErrInvalidHexFormat) {
+				t.Errorf("ContrastRatio() error = %v, want ErrInvalidHexFormat", err)
+			}
+			if ratio != 0 {
+				t.Errorf("ContrastRatio() = %v, want 0 on error", ratio)
+			}
+		})
+	}
+}
@@ -2,7 +2,11 @@
 // with location privacy controls.
 package scene
 
-import "time"
+import (
+	"time"
+
+	"github.com/onnwee/subcults/internal/geo"
+)
 
 // Visibility modes for scenes
 const (
@@ -12,13 +16,13 @@ const (
 )
 
 // Point represents a geographic coordinate with latitude and longitude.
-type Point struct {
-	Lat float64 `json:"lat"`
-	Lng float64 `json:"lng"`
-}
+// It is an alias of geo.Point so scene coordinates can be passed directly
+// to geohash encoding and distance helpers.
+type Point = geo.Point
 
 // Palette represents the color scheme for a scene's visual identity.
-// All colors should be hex codes in format #RRGGBB.
+// All colors should be hex codes in format #RRGGBB; shorthand #RGB input is
+// expanded to the 6-digit form before storage.
 type Palette struct {
 	Primary    string `json:"primary"`
 	Secondary  string `json:"secondary"`
@@ -27,11 +31,27 @@ type Palette struct {
 	Text       string `json:"text"`
 }
 
+// Palette themes. The light palette is the one exposed through the legacy
+// single Palette field.
+const (
+	ThemeLight = "light"
+	ThemeDark  = "dark"
+)
+
+// ValidThemes lists the palette themes a scene may define.
+var ValidThemes = map[string]bool{
+	ThemeLight: true,
+	ThemeDark:  true,
+}
+
 // Scene represents a subcultural scene with optional precise location data.
 // The precise_point field is only persisted when allow_precise consent is true.
 type Scene struct {
 	ID            string   `json:"id"`
 	Name          string   `json:"name"`
+	// Slug is the URL-safe name, unique among the owner's non-deleted scenes.
+	// Assigned by the repository from Name when empty; see Slugify.
+	Slug          string   `json:"slug,omitempty"`
 	Description   string   `json:"description,omitempty"`
 	OwnerDID      string   `json:"owner_did"`           // Decentralized Identifier
 	AllowPrecise  bool     `json:"allow_precise"`
@@ -44,14 +64,21 @@ type Scene struct {
 	// Visibility mode for the scene. Valid values are "public", "private", or "unlisted".
 	// Enforced by database CHECK constraint.
 	Visibility    string     `json:"visibility,omitempty"`
-	Palette       *Palette   `json:"palette,omitempty"`    // Color scheme
+	Palette       *Palette   `json:"palette,omitempty"`    // Color scheme; same as the light theme palette
 	OwnerUserID   *string    `json:"owner_user_id,omitempty"` // FK to users table
+	// Palettes holds a palette per theme (ThemeLight, ThemeDark). Use
+	// SetPalette so Palette stays in sync with the light theme.
+	Palettes map[string]*Palette `json:"palettes,omitempty"`
 
 	// Timestamps
 	CreatedAt *time.Time `json:"created_at,omitempty"`
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 
+	// Version starts at 1 and is incremented by the repository on each
+	// successful write. Used for optimistic concurrency control.
+	Version int `json:"version"`
+
 	// AT Protocol record tracking
 	RecordDID  *string `json:"record_did,omitempty"`
 	RecordRKey *string `json:"record_rkey,omitempty"`
@@ -71,6 +98,8 @@ type Event struct {
 	Status        string     `json:"status,omitempty"` // scheduled, live, ended, cancelled
 	StartsAt      time.Time  `json:"starts_at"`
 	EndsAt        *time.Time `json:"ends_at,omitempty"`
+	Timezone      string     `json:"timezone,omitempty"` // IANA zone name for local display; empty means UTC
+	Capacity      int        `json:"capacity,omitempty"` // Max going RSVPs; 0 means unlimited
 	
 	// Timestamps
 	CreatedAt   *time.Time `json:"created_at,omitempty"`
@@ -87,6 +116,9 @@ type Event struct {
 	
 	// LiveKit streaming
 	StreamSessionID *string `json:"stream_session_id,omitempty"`
+
+	// Recurring events: occurrences expanded from one recurrence share a series ID
+	SeriesID *string `json:"series_id,omitempty"`
 }
 
 // EnforceLocationConsent clears PrecisePoint if AllowPrecise is false.
@@ -114,19 +146,61 @@ func (s *Scene) IsOwner(userDID string) bool {
 	return s.OwnerDID == userDID
 }
 
+// SetPalette sets the palette for a theme without touching the other themes.
+// Setting the light theme also sets the legacy Palette field.
+func (s *Scene) SetPalette(theme string, p *Palette) {
+	if s.Palettes == nil {
+		s.Palettes = make(map[string]*Palette)
+		// Scenes saved before themes existed only have the legacy field
+		if s.Palette != nil {
+			s.Palettes[ThemeLight] = s.Palette
+		}
+	}
+	s.Palettes[theme] = p
+	if theme == ThemeLight {
+		s.Palette = p
+	}
+}
+
+// PaletteFor returns the palette for a theme, or nil if the scene has none.
+// The light theme falls back to the legacy Palette field.
+func (s *Scene) PaletteFor(theme string) *Palette {
+	if p := s.Palettes[theme]; p != nil {
+		return p
+	}
+	if theme == ThemeLight {
+		return s.Palette
+	}
+	return nil
+}
+
 // RSVP represents a user's attendance intent for an event.
 type RSVP struct {
 	EventID string `json:"event_id"`
 	// UserID stores the user's DID (Decentralized Identifier), not a UUID or FK to a users table.
 	// This allows guest RSVPs and aligns with the database schema (see migration 000012 comment).
 	UserID    string     `json:"user_id"`
-	Status    string     `json:"status"` // "going" or "maybe"
+	Status    string     `json:"status"` // "going", "maybe", or "waitlisted"
 	CreatedAt *time.Time `json:"created_at,omitempty"`
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	// CheckedInAt records when an organizer checked the user in at the event.
+	// Nil until checked in; kept if the status later changes.
+	CheckedInAt *time.Time `json:"checked_in_at,omitempty"`
+}
+
+// RSVPChange records a single RSVP status transition.
+// FromStatus is empty for the RSVP that created the record.
+type RSVPChange struct {
+	EventID    string    `json:"event_id"`
+	UserID     string    `json:"user_id"`
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	ChangedAt  time.Time `json:"changed_at"`
 }
 
 // RSVPCounts represents aggregated RSVP counts by status.
 type RSVPCounts struct {
-	Going int `json:"going"`
-	Maybe int `json:"maybe"`
+	Going      int `json:"going"`
+	Maybe      int `json:"maybe"`
+	Waitlisted int `json:"waitlisted"`
 }
@@ -20,13 +20,13 @@ func TestPrivacy_SceneFetch_WithoutConsent(t *testing.T) {
 		PrecisePoint: &Point{Lat: 37.7749, Lng: -122.4194}, // San Francisco
 	}
 
-	err := repo.Insert(scene)
+	err := repo.Insert(t.Context(), scene)
 	if err != nil {
 		t.Fatalf("Insert() error = %v", err)
 	}
 
 	// Fetch the scene - simulating a public API fetch
-	fetched, err := repo.GetByID("scene-no-consent")
+	fetched, err := repo.GetByID(t.Context(), "scene-no-consent")
 	if err != nil {
 		t.Fatalf("GetByID() error = %v", err)
 	}
@@ -59,13 +59,13 @@ func TestPrivacy_SceneFetch_WithConsent(t *testing.T) {
 		PrecisePoint: &Point{Lat: expectedLat, Lng: expectedLng},
 	}
 
-	err := repo.Insert(scene)
+	err := repo.Insert(t.Context(), scene)
 	if err != nil {
 		t.Fatalf("Insert() error = %v", err)
 	}
 
 	// Fetch the scene
-	fetched, err := repo.GetByID("scene-with-consent")
+	fetched, err := repo.GetByID(t.Context(), "scene-with-consent")
 	if err != nil {
 		t.Fatalf("GetByID() error = %v", err)
 	}
@@ -102,13 +102,13 @@ func TestPrivacy_EventFetch_WithoutConsent(t *testing.T) {
 		PrecisePoint: &Point{Lat: 40.7128, Lng: -74.0060}, // New York
 	}
 
-	err := repo.Insert(event)
+	err := repo.Insert(t.Context(), event)
 	if err != nil {
 		t.Fatalf("Insert() error = %v", err)
 	}
 
 	// Fetch the event - simulating a public API fetch
-	fetched, err := repo.GetByID("event-no-consent")
+	fetched, err := repo.GetByID(t.Context(), "event-no-consent")
 	if err != nil {
 		t.Fatalf("GetByID() error = %v", err)
 	}
@@ -142,13 +142,13 @@ func TestPrivacy_EventFetch_WithConsent(t *testing.T) {
 		PrecisePoint: &Point{Lat: expectedLat, Lng: expectedLng},
 	}
 
-	err := repo.Insert(event)
+	err := repo.Insert(t.Context(), event)
 	if err != nil {
 		t.Fatalf("Insert() error = %v", err)
 	}
 
 	// Fetch the event
-	fetched, err := repo.GetByID("event-with-consent")
+	fetched, err := repo.GetByID(t.Context(), "event-with-consent")
 	if err != nil {
 		t.Fatalf("GetByID() error = %v", err)
 	}
@@ -230,13 +230,13 @@ func TestPrivacy_ConsentRevocation(t *testing.T) {
 		PrecisePoint: &Point{Lat: 51.5074, Lng: -0.1278}, // London
 	}
 
-	err := repo.Insert(scene)
+	err := repo.Insert(t.Context(), scene)
 	if err != nil {
 		t.Fatalf("Insert() error = %v", err)
 	}
 
 	// Verify precise point is stored
-	fetched, err := repo.GetByID("scene-consent-revoke")
+	fetched, err := repo.GetByID(t.Context(), "scene-consent-revoke")
 	if err != nil {
 		t.Fatalf("GetByID() error = %v", err)
 	}
@@ -252,13 +252,13 @@ func TestPrivacy_ConsentRevocation(t *testing.T) {
 		PrecisePoint: &Point{Lat: 51.5074, Lng: -0.1278}, // Still present in input
 	}
 
-	err = repo.Update(updatedScene)
+	err = repo.Update(t.Context(), updatedScene)
 	if err != nil {
 		t.Fatalf("Update() error = %v", err)
 	}
 
 	// Step 3: Verify precise point is removed from storage
-	reFetched, err := repo.GetByID("scene-consent-revoke")
+	reFetched, err := repo.GetByID(t.Context(), "scene-consent-revoke")
 	if err != nil {
 		t.Fatalf("GetByID() after update error = %v", err)
 	}
@@ -307,14 +307,14 @@ func TestPrivacy_MultipleScenes_MixedConsent(t *testing.T) {
 
 	// Insert all scenes
 	for _, s := range scenes {
-		if err := repo.Insert(s); err != nil {
+		if err := repo.Insert(t.Context(), s); err != nil {
 			t.Fatalf("Insert(%s) error = %v", s.ID, err)
 		}
 	}
 
 	// Verify each scene independently
 	for _, original := range scenes {
-		fetched, err := repo.GetByID(original.ID)
+		fetched, err := repo.GetByID(t.Context(), original.ID)
 		if err != nil {
 			t.Fatalf("GetByID(%s) error = %v", original.ID, err)
 		}
@@ -350,13 +350,13 @@ func TestPrivacy_Upsert_PreservesConsent(t *testing.T) {
 		RecordRKey:   strPtr(rkey),
 	}
 
-	result1, err := repo.Upsert(scene1)
+	result1, err := repo.Upsert(t.Context(), scene1)
 	if err != nil {
 		t.Fatalf("First Upsert() error = %v", err)
 	}
 
 	// Verify no precise point stored
-	fetched1, err := repo.GetByRecordKey(did, rkey)
+	fetched1, err := repo.GetByRecordKey(t.Context(), did, rkey)
 	if err != nil {
 		t.Fatalf("GetByRecordKey() error = %v", err)
 	}
@@ -373,7 +373,7 @@ func TestPrivacy_Upsert_PreservesConsent(t *testing.T) {
 		RecordRKey:   strPtr(rkey),
 	}
 
-	result2, err := repo.Upsert(scene2)
+	result2, err := repo.Upsert(t.Context(), scene2)
 	if err != nil {
 		t.Fatalf("Second Upsert() error = %v", err)
 	}
@@ -387,7 +387,7 @@ func TestPrivacy_Upsert_PreservesConsent(t *testing.T) {
 	}
 
 	// Verify precise point now stored
-	fetched2, err := repo.GetByRecordKey(did, rkey)
+	fetched2, err := repo.GetByRecordKey(t.Context(), did, rkey)
 	if err != nil {
 		t.Fatalf("GetByRecordKey() after consent grant error = %v", err)
 	}
@@ -404,13 +404,13 @@ func TestPrivacy_Upsert_PreservesConsent(t *testing.T) {
 		RecordRKey:   strPtr(rkey),
 	}
 
-	_, err = repo.Upsert(scene3)
+	_, err = repo.Upsert(t.Context(), scene3)
 	if err != nil {
 		t.Fatalf("Third Upsert() error = %v", err)
 	}
 
 	// Verify precise point removed
-	fetched3, err := repo.GetByRecordKey(did, rkey)
+	fetched3, err := repo.GetByRecordKey(t.Context(), did, rkey)
 	if err != nil {
 		t.Fatalf("GetByRecordKey() after consent revocation error = %v", err)
 	}
@@ -481,40 +481,26 @@ func TestPrivacy_EXIF_Placeholder(t *testing.T) {
 	t.Skip("EXIF stripping implemented in internal/image - integration with upload pipeline pending")
 }
 
-// TestPrivacy_LocationJitter_Placeholder is a placeholder test for location jitter functionality.
-// Location jitter is planned but not yet implemented.
-func TestPrivacy_LocationJitter_Placeholder(t *testing.T) {
-	t.Skip("Location jitter not yet implemented - tracked in Privacy & Safety Epic #6")
-
-	// When implemented, this test should:
-	// 1. Take a precise coordinate (e.g., 37.7749, -122.4194)
-	// 2. Apply deterministic jitter based on geohash
-	// 3. Verify jittered coordinate is within acceptable bounds (e.g., ±500m)
-	// 4. Verify jitter is deterministic (same input -> same output)
-	// 5. Verify jitter prevents triangulation attacks
-	// 6. Verify jitter is only applied for public display, not storage
-
-	// Example assertions when implemented:
-	// originalLat := 37.7749
-	// originalLng := -122.4194
-	// jitteredPoint := geo.ApplyJitter(originalLat, originalLng, geo.DefaultPrecision)
-	//
-	// // Jitter should move coordinates slightly
-	// if jitteredPoint.Lat == originalLat && jitteredPoint.Lng == originalLng {
-	//     t.Error("Jitter should modify coordinates")
-	// }
-	//
-	// // Jitter should be within bounds (e.g., ±500m ~= ±0.0045 degrees at equator)
-	// maxDelta := 0.01 // ~1.1 km, generous for testing
-	// latDelta := math.Abs(jitteredPoint.Lat - originalLat)
-	// lngDelta := math.Abs(jitteredPoint.Lng - originalLng)
-	// if latDelta > maxDelta || lngDelta > maxDelta {
-	//     t.Errorf("Jitter too large: latDelta=%f, lngDelta=%f, max=%f", latDelta, lngDelta, maxDelta)
-	// }
-	//
-	// // Jitter should be deterministic
-	// jitteredPoint2 := geo.ApplyJitter(originalLat, originalLng, geo.DefaultPrecision)
-	// if jitteredPoint.Lat != jitteredPoint2.Lat || jitteredPoint.Lng != jitteredPoint2.Lng {
-	//     t.Error("Jitter should be deterministic")
-	// }
+// TestPrivacy_LocationJitter verifies that location jitter obscures precise
+// coordinates within bounds and is deterministic for a given seed.
+func TestPrivacy_LocationJitter(t *testing.T) {
+	original := Point{Lat: 37.7749, Lng: -122.4194}
+	const radius = 500.0
+
+	jittered := geo.Jitter(original, radius, 42)
+
+	// Jitter should move coordinates slightly
+	if jittered == original {
+		t.Error("Jitter should modify coordinates")
+	}
+
+	// Jitter should stay within the configured radius
+	if d := geo.DistanceMeters(original, jittered); d > radius+0.01 {
+		t.Errorf("Jitter too large: moved %.2fm, max=%.0fm", d, radius)
+	}
+
+	// Jitter should be deterministic
+	if again := geo.Jitter(original, radius, 42); again != jittered {
+		t.Errorf("Jitter should be deterministic: first=%v, second=%v", jittered, again)
+	}
 }
@@ -0,0 +1,142 @@
+package scene
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Default purge job settings.
+const (
+	// DefaultPurgeInterval is the default interval between purge runs.
+	DefaultPurgeInterval = time.Hour
+	// DefaultPurgeRetention is how long soft-deleted scenes are kept before purging.
+	DefaultPurgeRetention = 30 * 24 * time.Hour
+)
+
+// PurgeJobConfig configures the soft-deleted scene purge job.
+type PurgeJobConfig struct {
+	// Interval is the duration between purge runs.
+	Interval time.Duration
+	// Retention is how long a scene stays soft-deleted before it is purged.
+	Retention time.Duration
+	// Logger for job activity.
+	Logger *slog.Logger
+}
+
+// PurgeJob periodically removes scenes that were soft-deleted longer ago
+// than the configured retention.
+type PurgeJob struct {
+	config PurgeJobConfig
+	repo   SceneRepository
+	now    func() time.Time
+
+	mu      sync.Mutex
+	running bool
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+}
+
+// NewPurgeJob creates a new purge job for the given scene repository.
+func NewPurgeJob(config PurgeJobConfig, repo SceneRepository) *PurgeJob {
+	if config.Interval == 0 {
+		config.Interval = DefaultPurgeInterval
+	}
+	if config.Retention == 0 {
+		config.Retention = DefaultPurgeRetention
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+
+	return &PurgeJob{
+		config: config,
+		repo:   repo,
+		now:    time.Now,
+	}
+}
+
+// Start begins the periodic purge job.
+// Returns immediately; the job runs in a background goroutine.
+func (j *PurgeJob) Start(ctx context.Context) error {
+	j.mu.Lock()
+	if j.running {
+		j.mu.Unlock()
+		return nil
+	}
+	j.running = true
+	j.stopCh = make(chan struct{})
+	j.doneCh = make(chan struct{})
+	j.mu.Unlock()
+
+	go j.run(ctx)
+	return nil
+}
+
+// Stop signals the purge job to stop and waits for it to finish.
+func (j *PurgeJob) Stop() {
+	j.mu.Lock()
+	if !j.running {
+		j.mu.Unlock()
+		return
+	}
+	stopCh := j.stopCh
+	doneCh := j.doneCh
+	j.mu.Unlock()
+
+	close(stopCh)
+	<-doneCh
+
+	j.mu.Lock()
+	j.running = false
+	j.mu.Unlock()
+}
+
+// IsRunning returns whether the job is currently running.
+func (j *PurgeJob) IsRunning() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.running
+}
+
+// run is the main loop for the purge job.
+func (j *PurgeJob) run(ctx context.Context) {
+	defer close(j.doneCh)
+
+	ticker := time.NewTicker(j.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.config.Logger.Info("scene purge job stopping due to context cancellation")
+			return
+		case <-j.stopCh:
+			j.config.Logger.Info("scene purge job stopping due to stop signal")
+			return
+		case <-ticker.C:
+			j.PurgeNow(ctx)
+		}
+	}
+}
+
+// PurgeNow immediately purges scenes deleted before the retention cutoff
+// without waiting for the ticker. Returns the number of scenes purged.
+func (j *PurgeJob) PurgeNow(ctx context.Context) int {
+	cutoff := j.now().Add(-j.config.Retention)
+	count, err := j.repo.Purge(ctx, cutoff)
+	if err != nil {
+		j.config.Logger.Error("failed to purge deleted scenes",
+			"cutoff", cutoff,
+			"error", err)
+		return 0
+	}
+
+	if count > 0 {
+		j.config.Logger.Info("purged deleted scenes",
+			"count", count,
+			"cutoff", cutoff)
+	}
+	return count
+}
@@ -0,0 +1,174 @@
+package scene
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// newPurgeFixture creates cascading repositories holding scene-old (deleted
+// 40 days ago), scene-recent (deleted an hour ago) and scene-live, each with
+// one event carrying one RSVP.
+func newPurgeFixture(t *testing.T) (*InMemorySceneRepository, *InMemoryEventRepository, *InMemoryRSVPRepository) {
+	t.Helper()
+	scenes := NewInMemorySceneRepository()
+	events := NewInMemoryEventRepository()
+	rsvps := NewInMemoryRSVPRepository()
+	scenes.SetCascade(events, rsvps)
+
+	now := time.Now()
+	deletedAt := map[string]*time.Time{
+		"scene-old":    timePtr(now.Add(-40 * 24 * time.Hour)),
+		"scene-recent": timePtr(now.Add(-time.Hour)),
+		"scene-live":   nil,
+	}
+	for id, at := range deletedAt {
+		if err := scenes.Insert(t.Context(), &Scene{ID: id, Name: id, OwnerDID: "did:plc:owner", DeletedAt: at}); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+		eventID := "event-" + id
+		if err := events.Insert(t.Context(), &Event{ID: eventID, SceneID: id, Title: "Event", StartsAt: now}); err != nil {
+			t.Fatalf("failed to insert event: %v", err)
+		}
+		if err := rsvps.Upsert(t.Context(), &RSVP{EventID: eventID, UserID: "did:plc:attendee", Status: "going"}); err != nil {
+			t.Fatalf("failed to upsert rsvp: %v", err)
+		}
+	}
+	return scenes, events, rsvps
+}
+
+func TestSceneRepository_Purge(t *testing.T) {
+	scenes, events, rsvps := newPurgeFixture(t)
+
+	count, err := scenes.Purge(t.Context(), time.Now().Add(-DefaultPurgeRetention))
+	if err != nil {
+		t.Fatalf("Purge failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 scene purged, got %d", count)
+	}
+
+	// Purged scene is gone entirely, not just deleted
+	if _, err := scenes.GetByID(t.Context(), "scene-old"); !errors.Is(err, ErrSceneNotFound) {
+		t.Errorf("expected ErrSceneNotFound for purged scene, got %v", err)
+	}
+	if err := scenes.Delete(t.Context(), "scene-old"); !errors.Is(err, ErrSceneNotFound) {
+		t.Errorf("expected ErrSceneNotFound deleting purged scene, got %v", err)
+	}
+
+	// Recently deleted scene keeps its tombstone
+	if _, err := scenes.GetByID(t.Context(), "scene-recent"); !errors.Is(err, ErrSceneDeleted) {
+		t.Errorf("expected ErrSceneDeleted for recently deleted scene, got %v", err)
+	}
+	if _, err := scenes.GetByID(t.Context(), "scene-live"); err != nil {
+		t.Errorf("expected live scene to remain, got %v", err)
+	}
+
+	// Cascade removes the purged scene's events, RSVPs and RSVP history
+	if _, err := events.GetByID(t.Context(), "event-scene-old"); !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("expected ErrEventNotFound for cascaded event, got %v", err)
+	}
+	if _, err := rsvps.GetByEventAndUser(t.Context(), "event-scene-old", "did:plc:attendee"); !errors.Is(err, ErrRSVPNotFound) {
+		t.Errorf("expected ErrRSVPNotFound for cascaded rsvp, got %v", err)
+	}
+	if history, _ := rsvps.History(t.Context(), "event-scene-old", "did:plc:attendee"); len(history) != 0 {
+		t.Errorf("expected cascaded rsvp history to be removed, got %d entries", len(history))
+	}
+
+	// Children of surviving scenes are untouched
+	for _, id := range []string{"event-scene-recent", "event-scene-live"} {
+		if _, err := events.GetByID(t.Context(), id); err != nil {
+			t.Errorf("expected event %s to remain, got %v", id, err)
+		}
+		if _, err := rsvps.GetByEventAndUser(t.Context(), id, "did:plc:attendee"); err != nil {
+			t.Errorf("expected rsvp for %s to remain, got %v", id, err)
+		}
+	}
+
+	// Purge is idempotent
+	count, err = scenes.Purge(t.Context(), time.Now().Add(-DefaultPurgeRetention))
+	if err != nil {
+		t.Fatalf("second Purge failed: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected 0 scenes purged on second run, got %d", count)
+	}
+}
+
+func TestSceneRepository_Purge_RemovesRecordKey(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+	did, rkey := "did:plc:owner", "scene-rkey"
+
+	result, err := repo.Upsert(t.Context(), &Scene{Name: "Keyed", OwnerDID: did, RecordDID: &did, RecordRKey: &rkey})
+	if err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if err := repo.Delete(t.Context(), result.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	// Without cascade repositories configured, only the scene is removed
+	count, err := repo.Purge(t.Context(), time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Purge failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 scene purged, got %d", count)
+	}
+	if _, err := repo.GetByRecordKey(t.Context(), did, rkey); !errors.Is(err, ErrSceneNotFound) {
+		t.Errorf("expected ErrSceneNotFound by record key, got %v", err)
+	}
+}
+
+func TestPurgeJob_PurgeNow(t *testing.T) {
+	scenes, _, _ := newPurgeFixture(t)
+	job := NewPurgeJob(PurgeJobConfig{
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}, scenes)
+
+	if count := job.PurgeNow(t.Context()); count != 1 {
+		t.Errorf("expected 1 scene purged with default retention, got %d", count)
+	}
+
+	// A shorter retention reaches the recently deleted scene
+	job.config.Retention = time.Minute
+	if count := job.PurgeNow(t.Context()); count != 1 {
+		t.Errorf("expected 1 scene purged with short retention, got %d", count)
+	}
+	if _, err := scenes.GetByID(t.Context(), "scene-live"); err != nil {
+		t.Errorf("expected live scene to survive purge, got %v", err)
+	}
+}
+
+func TestPurgeJob_StartStop(t *testing.T) {
+	scenes, _, _ := newPurgeFixture(t)
+	job := NewPurgeJob(PurgeJobConfig{
+		Interval: 10 * time.Millisecond,
+		Logger:   slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}, scenes)
+
+	if err := job.Start(t.Context()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if !job.IsRunning() {
+		t.Error("expected job to be running")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, err := scenes.GetByID(t.Context(), "scene-old"); errors.Is(err, ErrSceneNotFound) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected periodic run to purge scene-old")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	job.Stop()
+	if job.IsRunning() {
+		t.Error("expected job to be stopped")
+	}
+}
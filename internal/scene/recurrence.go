@@ -0,0 +1,110 @@
+package scene
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Recurrence frequencies.
+const (
+	RecurrenceWeekly  = "weekly"
+	RecurrenceMonthly = "monthly"
+)
+
+// MaxRecurrenceOccurrences caps how many events a single recurrence may expand into.
+const MaxRecurrenceOccurrences = 52
+
+// ErrInvalidRecurrence is returned when a recurrence rule cannot be expanded.
+var ErrInvalidRecurrence = errors.New("invalid recurrence")
+
+// Recurrence describes a repeating schedule for an event series.
+// Exactly one of Count or Until must be set.
+type Recurrence struct {
+	Freq     string     `json:"freq"`               // weekly or monthly
+	Interval int        `json:"interval,omitempty"` // Repeat every N periods; defaults to 1
+	Count    int        `json:"count,omitempty"`    // Total number of occurrences
+	Until    *time.Time `json:"until,omitempty"`    // Last allowed start time (inclusive)
+}
+
+// Occurrence is a single expanded instance of a recurring event.
+type Occurrence struct {
+	StartsAt time.Time
+	EndsAt   *time.Time
+}
+
+// Expand generates the occurrences of the recurrence starting at startsAt.
+// Each occurrence keeps the duration of the first one when endsAt is set.
+// Monthly steps use time.AddDate from the first start, so a start on the
+// 31st rolls into the following month when the target month is shorter.
+// Returns an error wrapping ErrInvalidRecurrence if the rule is malformed,
+// expands past MaxRecurrenceOccurrences, or produces overlapping occurrences.
+func (rec Recurrence) Expand(startsAt time.Time, endsAt *time.Time) ([]Occurrence, error) {
+	if rec.Freq != RecurrenceWeekly && rec.Freq != RecurrenceMonthly {
+		return nil, fmt.Errorf("%w: freq must be %q or %q", ErrInvalidRecurrence, RecurrenceWeekly, RecurrenceMonthly)
+	}
+
+	interval := rec.Interval
+	if interval == 0 {
+		interval = 1
+	}
+	if interval < 0 {
+		return nil, fmt.Errorf("%w: interval must be positive", ErrInvalidRecurrence)
+	}
+
+	if (rec.Count > 0) == (rec.Until != nil) {
+		return nil, fmt.Errorf("%w: exactly one of count or until is required", ErrInvalidRecurrence)
+	}
+	if rec.Count < 0 {
+		return nil, fmt.Errorf("%w: count must be positive", ErrInvalidRecurrence)
+	}
+	if rec.Count > MaxRecurrenceOccurrences {
+		return nil, fmt.Errorf("%w: count cannot exceed %d", ErrInvalidRecurrence, MaxRecurrenceOccurrences)
+	}
+	if rec.Until != nil && rec.Until.Before(startsAt) {
+		return nil, fmt.Errorf("%w: until must not be before the first start time", ErrInvalidRecurrence)
+	}
+
+	var duration time.Duration
+	if endsAt != nil {
+		duration = endsAt.Sub(startsAt)
+	}
+
+	occurrences := make([]Occurrence, 0)
+	for i := 0; ; i++ {
+		var start time.Time
+		if rec.Freq == RecurrenceWeekly {
+			start = startsAt.AddDate(0, 0, 7*interval*i)
+		} else {
+			start = startsAt.AddDate(0, interval*i, 0)
+		}
+
+		if rec.Count > 0 && i >= rec.Count {
+			break
+		}
+		if rec.Until != nil && start.After(*rec.Until) {
+			break
+		}
+		if len(occurrences) == MaxRecurrenceOccurrences {
+			return nil, fmt.Errorf("%w: recurrence expands to more than %d occurrences", ErrInvalidRecurrence, MaxRecurrenceOccurrences)
+		}
+
+		occ := Occurrence{StartsAt: start}
+		if endsAt != nil {
+			end := start.Add(duration)
+			occ.EndsAt = &end
+		}
+
+		if len(occurrences) > 0 {
+			prev := occurrences[len(occurrences)-1]
+			if prev.EndsAt != nil && prev.EndsAt.After(occ.StartsAt) {
+				return nil, fmt.Errorf("%w: occurrence ending %s overlaps the next start %s",
+					ErrInvalidRecurrence, prev.EndsAt.Format(time.RFC3339), occ.StartsAt.Format(time.RFC3339))
+			}
+		}
+
+		occurrences = append(occurrences, occ)
+	}
+
+	return occurrences, nil
+}
@@ -0,0 +1,124 @@
+package scene
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRecurrenceExpand_WeeklyCount(t *testing.T) {
+	start := time.Date(2030, 1, 4, 21, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+
+	occurrences, err := Recurrence{Freq: RecurrenceWeekly, Count: 4}.Expand(start, &end)
+	if err != nil {
+		t.Fatalf("Expand() error = %v", err)
+	}
+	if len(occurrences) != 4 {
+		t.Fatalf("expected 4 occurrences, got %d", len(occurrences))
+	}
+	for i, occ := range occurrences {
+		wantStart := start.AddDate(0, 0, 7*i)
+		if !occ.StartsAt.Equal(wantStart) {
+			t.Errorf("occurrence %d: starts_at = %v, want %v", i, occ.StartsAt, wantStart)
+		}
+		if occ.EndsAt == nil || !occ.EndsAt.Equal(wantStart.Add(3*time.Hour)) {
+			t.Errorf("occurrence %d: ends_at = %v, want %v", i, occ.EndsAt, wantStart.Add(3*time.Hour))
+		}
+	}
+}
+
+func TestRecurrenceExpand_IntervalAndUntil(t *testing.T) {
+	start := time.Date(2030, 1, 15, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		rec        Recurrence
+		wantStarts []time.Time
+	}{
+		{
+			name: "biweekly until inclusive",
+			rec:  Recurrence{Freq: RecurrenceWeekly, Interval: 2, Until: timePtr(start.AddDate(0, 0, 28))},
+			wantStarts: []time.Time{
+				start,
+				start.AddDate(0, 0, 14),
+				start.AddDate(0, 0, 28),
+			},
+		},
+		{
+			name: "monthly count",
+			rec:  Recurrence{Freq: RecurrenceMonthly, Count: 3},
+			wantStarts: []time.Time{
+				start,
+				time.Date(2030, 2, 15, 20, 0, 0, 0, time.UTC),
+				time.Date(2030, 3, 15, 20, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			occurrences, err := tt.rec.Expand(start, nil)
+			if err != nil {
+				t.Fatalf("Expand() error = %v", err)
+			}
+			if len(occurrences) != len(tt.wantStarts) {
+				t.Fatalf("expected %d occurrences, got %d", len(tt.wantStarts), len(occurrences))
+			}
+			for i, want := range tt.wantStarts {
+				if !occurrences[i].StartsAt.Equal(want) {
+					t.Errorf("occurrence %d: starts_at = %v, want %v", i, occurrences[i].StartsAt, want)
+				}
+				if occurrences[i].EndsAt != nil {
+					t.Errorf("occurrence %d: expected nil ends_at", i)
+				}
+			}
+		})
+	}
+}
+
+func TestRecurrenceExpand_Invalid(t *testing.T) {
+	start := time.Date(2030, 1, 4, 21, 0, 0, 0, time.UTC)
+	longEnd := start.Add(8 * 24 * time.Hour)
+
+	tests := []struct {
+		name   string
+		rec    Recurrence
+		endsAt *time.Time
+	}{
+		{"unknown freq", Recurrence{Freq: "daily", Count: 2}, nil},
+		{"neither count nor until", Recurrence{Freq: RecurrenceWeekly}, nil},
+		{"both count and until", Recurrence{Freq: RecurrenceWeekly, Count: 2, Until: timePtr(start.AddDate(0, 1, 0))}, nil},
+		{"negative interval", Recurrence{Freq: RecurrenceWeekly, Interval: -1, Count: 2}, nil},
+		{"negative count", Recurrence{Freq: RecurrenceWeekly, Count: -1, Until: nil}, nil},
+		{"count over cap", Recurrence{Freq: RecurrenceWeekly, Count: MaxRecurrenceOccurrences + 1}, nil},
+		{"until over cap", Recurrence{Freq: RecurrenceWeekly, Until: timePtr(start.AddDate(2, 0, 0))}, nil},
+		{"until before start", Recurrence{Freq: RecurrenceWeekly, Until: timePtr(start.Add(-time.Hour))}, nil},
+		{"overlapping occurrences", Recurrence{Freq: RecurrenceWeekly, Count: 2}, &longEnd},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.rec.Expand(start, tt.endsAt)
+			if !errors.Is(err, ErrInvalidRecurrence) {
+				t.Errorf("Expand() error = %v, want ErrInvalidRecurrence", err)
+			}
+		})
+	}
+}
+
+func TestRecurrenceExpand_CapBoundary(t *testing.T) {
+	start := time.Date(2030, 1, 4, 21, 0, 0, 0, time.UTC)
+
+	occurrences, err := Recurrence{Freq: RecurrenceWeekly, Count: MaxRecurrenceOccurrences}.Expand(start, nil)
+	if err != nil {
+		t.Fatalf("Expand() error = %v", err)
+	}
+	if len(occurrences) != MaxRecurrenceOccurrences {
+		t.Errorf("expected %d occurrences, got %d", MaxRecurrenceOccurrences, len(occurrences))
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
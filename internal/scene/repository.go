@@ -3,13 +3,16 @@
 package scene
 
 import (
+	"context"
 	"errors"
+	"math"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/geo"
 )
 
 // Common errors for scene and event operations.
@@ -19,8 +22,46 @@ var (
 	ErrEventNotFound      = errors.New("event not found")
 	ErrDuplicateSceneName = errors.New("scene name already exists for this owner")
 	ErrRSVPNotFound       = errors.New("rsvp not found")
+	ErrRSVPChangeTooSoon  = errors.New("rsvp status changed too recently")
+	ErrRSVPNotGoing       = errors.New("rsvp status is not going")
+	ErrInvalidCursor      = errors.New("invalid pagination cursor")
+	ErrVersionConflict    = errors.New("scene version conflict")
 )
 
+// Scene listing pagination limits.
+const (
+	DefaultSceneListLimit = 50
+	MaxSceneListLimit     = 100
+)
+
+// ListFilter specifies filtering and pagination options for listing scenes.
+type ListFilter struct {
+	Tag        string // Case-insensitive tag match; empty matches all
+	Visibility string // Exact visibility match; empty matches all
+	OwnerDID   string // Exact owner match; empty matches all
+
+	// ViewerDID is the requester's DID. Hidden scenes are only returned
+	// when ViewerDID matches the scene owner.
+	ViewerDID string
+
+	Limit  int    // Page size; defaults to DefaultSceneListLimit, capped at MaxSceneListLimit
+	Cursor string // Opaque cursor returned by a previous List call
+}
+
+// Event status filters accepted by EventFilter.Status.
+const (
+	EventStatusFilterScheduled = "scheduled" // Not cancelled, starts now or later
+	EventStatusFilterCancelled = "cancelled" // Cancelled events only
+	EventStatusFilterPast      = "past"      // Not cancelled, already started
+)
+
+// EventFilter specifies filtering options for listing a scene's events.
+type EventFilter struct {
+	Status string    // One of the EventStatusFilter values; empty matches all
+	From   time.Time // Inclusive lower bound on starts_at; zero means unbounded
+	To     time.Time // Inclusive upper bound on starts_at; zero means unbounded
+}
+
 // UpsertResult tracks statistics for upsert operations.
 type UpsertResult struct {
 	Inserted bool   // True if new record was inserted
@@ -29,104 +70,251 @@ type UpsertResult struct {
 
 // SceneRepository defines the interface for scene data operations.
 // All implementations must enforce location consent before persisting data.
+// Every method takes the caller's context and returns its error, without
+// touching storage, once the context is done.
 type SceneRepository interface {
 	// Insert stores a new scene, enforcing location consent.
 	// If allow_precise is false, precise_point will be set to NULL.
-	Insert(scene *Scene) error
+	Insert(ctx context.Context, scene *Scene) error
 
 	// Update modifies an existing scene, enforcing location consent.
 	// If allow_precise is false, precise_point will be set to NULL.
-	Update(scene *Scene) error
+	// If scene.Version is non-zero it must match the stored version, otherwise
+	// ErrVersionConflict is returned; a zero Version overwrites unconditionally.
+	// The stored version is incremented on success.
+	Update(ctx context.Context, scene *Scene) error
 
 	// Upsert inserts a new scene or updates existing one based on (record_did, record_rkey).
 	// Returns UpsertResult indicating whether insert or update occurred.
 	// Enforces location consent before persisting.
-	Upsert(scene *Scene) (*UpsertResult, error)
+	Upsert(ctx context.Context, scene *Scene) (*UpsertResult, error)
 
 	// GetByID retrieves a scene by its ID.
-	// Returns ErrSceneNotFound if scene doesn't exist or is soft-deleted.
-	GetByID(id string) (*Scene, error)
+	// Returns ErrSceneNotFound if scene doesn't exist, or ErrSceneDeleted if
+	// it is soft-deleted.
+	GetByID(ctx context.Context, id string) (*Scene, error)
 
 	// GetByRecordKey retrieves a scene by its AT Protocol record key.
-	GetByRecordKey(did, rkey string) (*Scene, error)
+	GetByRecordKey(ctx context.Context, did, rkey string) (*Scene, error)
 	
 	// Delete soft-deletes a scene by setting deleted_at timestamp.
-	// Returns ErrSceneNotFound if scene doesn't exist or is already deleted.
-	Delete(id string) error
+	// Returns ErrSceneNotFound if scene doesn't exist, or ErrSceneDeleted if
+	// it is already deleted.
+	Delete(ctx context.Context, id string) error
 	
 	// ExistsByOwnerAndName checks if a non-deleted scene with the given name
 	// exists for the specified owner. Used for duplicate name validation.
-	ExistsByOwnerAndName(ownerDID, name string, excludeID string) (bool, error)
+	// Soft-deleted scenes never match, so deleting a scene frees its name.
+	// A non-empty excludeID skips that scene, letting an update keep its own
+	// name or change only its case.
+	ExistsByOwnerAndName(ctx context.Context, ownerDID, name string, excludeID string) (bool, error)
 	
 	// ListByOwner retrieves all non-deleted scenes owned by the specified DID.
 	// Returns empty slice if no scenes found.
-	ListByOwner(ownerDID string) ([]*Scene, error)
+	ListByOwner(ctx context.Context, ownerDID string) ([]*Scene, error)
+
+	// GetBySlug retrieves an owner's non-deleted scene by its slug, matched
+	// case-insensitively. Returns ErrSceneNotFound if no scene carries the
+	// slug, or ErrSceneDeleted if only a soft-deleted one does.
+	GetBySlug(ctx context.Context, ownerDID, slug string) (*Scene, error)
+
+	// FindNearby returns non-deleted public scenes whose coarse geohash cell center
+	// lies within radiusMeters of center, ordered by distance ascending.
+	// Members-only and hidden scenes are never returned since the repository has
+	// no requester context. A limit of 0 or less returns all matches.
+	// Returns ctx's error if it is done before the scan completes.
+	FindNearby(ctx context.Context, center Point, radiusMeters float64, limit int) ([]*Scene, error)
+
+	// FindInBbox returns non-deleted public scenes whose coarse geohash cell
+	// center lies within the bounding box (edges inclusive), ordered by ID.
+	// As with FindNearby, members-only and hidden scenes are never returned,
+	// and ctx's error is returned if it is done before the scan completes.
+	FindInBbox(ctx context.Context, minLng, minLat, maxLng, maxLat float64) ([]*Scene, error)
+
+	// List retrieves non-deleted scenes matching the filter, newest first.
+	// Hidden scenes are excluded unless the viewer is the owner.
+	// Returns the page of scenes and a cursor for the next page (empty if none).
+	// Returns ErrInvalidCursor if the cursor cannot be parsed.
+	List(ctx context.Context, filter ListFilter) ([]*Scene, string, error)
+
+	// FindByTag retrieves non-deleted public scenes carrying the given tag,
+	// newest first. The tag is normalized before matching.
+	// A limit of 0 or less returns all matches.
+	FindByTag(ctx context.Context, tag string, limit int) ([]*Scene, error)
+
+	// FindByTags retrieves non-deleted public scenes carrying any of the given
+	// tags, or every one of them when matchAll is true, newest first. Tags are
+	// normalized before matching; an empty tag list applies no tag filter.
+	// A limit of 0 or less returns all matches.
+	FindByTags(ctx context.Context, tags []string, matchAll bool, limit int) ([]*Scene, error)
+
+	// Restore clears a scene's deleted_at timestamp.
+	// Returns ErrSceneNotFound if scene doesn't exist.
+	// Idempotent: returns nil if scene is not deleted.
+	Restore(ctx context.Context, id string) error
+
+	// Purge permanently removes scenes soft-deleted before olderThan, along
+	// with their events and those events' RSVPs. Purged IDs afterward return
+	// ErrSceneNotFound rather than ErrSceneDeleted.
+	// Returns the number of scenes removed.
+	Purge(ctx context.Context, olderThan time.Time) (int, error)
+
+	// Snapshot calls fn with a scene and its non-deleted events as of a
+	// single point in time; no write to either lands while fn runs, so fn
+	// can read related data consistently. fn must not call back into the
+	// repository. Returns ErrSceneNotFound or ErrSceneDeleted like GetByID,
+	// otherwise the error returned by fn.
+	Snapshot(ctx context.Context, id string, fn func(scene *Scene, events []*Event) error) error
 }
 
 // EventRepository defines the interface for event data operations.
 // All implementations must enforce location consent before persisting data.
+// As with SceneRepository, methods fail with the context's error once it is done.
 type EventRepository interface {
 	// Insert stores a new event, enforcing location consent.
 	// If allow_precise is false, precise_point will be set to NULL.
-	Insert(event *Event) error
+	Insert(ctx context.Context, event *Event) error
 
 	// Update modifies an existing event, enforcing location consent.
 	// If allow_precise is false, precise_point will be set to NULL.
-	Update(event *Event) error
+	Update(ctx context.Context, event *Event) error
 
 	// Upsert inserts a new event or updates existing one based on (record_did, record_rkey).
 	// Returns UpsertResult indicating whether insert or update occurred.
 	// Enforces location consent before persisting.
-	Upsert(event *Event) (*UpsertResult, error)
+	Upsert(ctx context.Context, event *Event) (*UpsertResult, error)
 
 	// GetByID retrieves an event by its ID.
-	GetByID(id string) (*Event, error)
+	// Returns ErrEventNotFound if event doesn't exist or is soft-deleted.
+	GetByID(ctx context.Context, id string) (*Event, error)
 
 	// GetByRecordKey retrieves an event by its AT Protocol record key.
-	GetByRecordKey(did, rkey string) (*Event, error)
+	GetByRecordKey(ctx context.Context, did, rkey string) (*Event, error)
 	
 	// Cancel marks an event as cancelled with an optional reason.
 	// Sets status to "cancelled", stores cancelled_at timestamp, and cancellation_reason.
 	// Returns ErrEventNotFound if event doesn't exist.
 	// Idempotent: returns nil if event is already cancelled.
-	Cancel(id string, reason *string) error
+	Cancel(ctx context.Context, id string, reason *string) error
 
 	// SearchByBboxAndTime searches for events within a bounding box and time range.
 	// Filters out cancelled events and applies pagination.
+	// Returns events sorted by starts_at ascending, or ctx's error if it is
+	// done before the search completes.
+	SearchByBboxAndTime(ctx context.Context, minLng, minLat, maxLng, maxLat float64, from, to time.Time, limit int, cursor string) ([]*Event, string, error)
+
+	// ListBySceneID retrieves non-deleted events for a scene matching the filter.
+	// Returns events sorted by starts_at ascending, then by ID.
+	ListBySceneID(ctx context.Context, sceneID string, filter EventFilter) ([]*Event, error)
+
+	// ListUpcomingNear retrieves non-deleted, non-cancelled events starting after
+	// the given time whose coarse geohash cell center lies within radiusMeters of center.
+	// Returns events sorted by starts_at ascending, then distance, then ID, along with
+	// a cursor for the next page (empty if none). A limit of 0 or less returns all matches.
+	// Returns ErrInvalidCursor if the cursor cannot be parsed, or ctx's error if
+	// it is done before the scan completes.
+	ListUpcomingNear(ctx context.Context, center Point, radiusMeters float64, after time.Time, limit int, cursor string) ([]*Event, string, error)
+
+	// ListBySeries retrieves non-deleted events sharing a series ID.
 	// Returns events sorted by starts_at ascending.
-	SearchByBboxAndTime(minLng, minLat, maxLng, maxLat float64, from, to time.Time, limit int, cursor string) ([]*Event, string, error)
+	ListBySeries(ctx context.Context, seriesID string) ([]*Event, error)
+
+	// Delete soft-deletes a single event. Unlike DeleteBySceneID, the event is
+	// not restored by RestoreBySceneID.
+	// Returns ErrEventNotFound if the event doesn't exist or is already deleted.
+	Delete(ctx context.Context, id string) error
+
+	// DeleteBySceneID soft-deletes all non-deleted events for a scene.
+	// Used when the scene itself is deleted. Returns the number of events deleted.
+	DeleteBySceneID(ctx context.Context, sceneID string) (int, error)
+
+	// RestoreBySceneID restores events soft-deleted by DeleteBySceneID for a scene.
+	// Returns the number of events restored.
+	RestoreBySceneID(ctx context.Context, sceneID string) (int, error)
 }
 
 // RSVPRepository defines the interface for RSVP data operations.
+// Methods fail with the context's error once it is done.
 type RSVPRepository interface {
 	// Upsert inserts or updates an RSVP for an event.
 	// Idempotent: if RSVP exists with same status, returns without error.
-	Upsert(rsvp *RSVP) error
+	Upsert(ctx context.Context, rsvp *RSVP) error
+
+	// UpsertWithCooldown is Upsert, but rejects a status change with
+	// ErrRSVPChangeTooSoon if the user's previous change for the event,
+	// including one before a Delete, happened less than cooldown ago.
+	// Same-status upserts are never rejected. The check and write are atomic.
+	UpsertWithCooldown(ctx context.Context, rsvp *RSVP, cooldown time.Duration) error
+
+	// UpsertWithCapacity is UpsertWithCooldown for an event that seats
+	// capacity attendees: a "going" RSVP from a user without a seat is stored
+	// as "waitlisted" once capacity users are going. A capacity of zero or less
+	// is unlimited. The seat check, cooldown check, and write are atomic, so
+	// concurrent calls never seat more than capacity. Returns the stored RSVP.
+	UpsertWithCapacity(ctx context.Context, rsvp *RSVP, capacity int, cooldown time.Duration) (*RSVP, error)
+
+	// PromoteWaitlisted moves the longest-waiting "waitlisted" RSVPs for an
+	// event to "going" until capacity users are going, atomically with respect
+	// to UpsertWithCapacity. Returns the number of RSVPs promoted.
+	PromoteWaitlisted(ctx context.Context, eventID string, capacity int) (int, error)
 
 	// Delete removes an RSVP for a user and event.
 	// Returns ErrRSVPNotFound if RSVP doesn't exist.
-	Delete(eventID, userID string) error
+	Delete(ctx context.Context, eventID, userID string) error
 
 	// GetByEventAndUser retrieves an RSVP for a specific user and event.
 	// Returns ErrRSVPNotFound if RSVP doesn't exist.
-	GetByEventAndUser(eventID, userID string) (*RSVP, error)
+	GetByEventAndUser(ctx context.Context, eventID, userID string) (*RSVP, error)
 
 	// GetCountsByEvent returns aggregated RSVP counts by status for an event.
-	GetCountsByEvent(eventID string) (*RSVPCounts, error)
+	// Each user is counted once, under their current status.
+	GetCountsByEvent(ctx context.Context, eventID string) (*RSVPCounts, error)
 	
 	// GetCountsForEvents returns a map of event IDs to their RSVP counts.
 	// This is a batch operation to avoid N+1 queries.
-	GetCountsForEvents(eventIDs []string) (map[string]*RSVPCounts, error)
+	GetCountsForEvents(ctx context.Context, eventIDs []string) (map[string]*RSVPCounts, error)
+
+	// ListByEvent returns RSVPs for an event, optionally filtered by status.
+	// An empty status returns all RSVPs. Results are sorted by created_at
+	// ascending, then by user ID.
+	ListByEvent(ctx context.Context, eventID string, status string) ([]*RSVP, error)
+
+	// ListByUser returns a user's RSVPs across all events, optionally
+	// filtered by status. An empty status returns all RSVPs. Results are
+	// sorted by created_at ascending, then by event ID.
+	ListByUser(ctx context.Context, userID string, status string) ([]*RSVP, error)
+
+	// History returns every status transition recorded for a user's RSVP
+	// to an event, oldest first. History is append-only and survives Delete.
+	History(ctx context.Context, eventID, userID string) ([]RSVPChange, error)
+
+	// CheckIn marks a user's going RSVP as checked in and returns it.
+	// Idempotent: checking in again keeps the original check-in time.
+	// Returns ErrRSVPNotFound if the RSVP doesn't exist and ErrRSVPNotGoing
+	// if its status isn't "going".
+	CheckIn(ctx context.Context, eventID, userID string) (*RSVP, error)
+
+	// GetCheckinCount returns the number of checked-in RSVPs for an event.
+	GetCheckinCount(ctx context.Context, eventID string) (int, error)
 }
 
 // InMemorySceneRepository is an in-memory implementation of SceneRepository.
-// Used for testing and development. Thread-safe via RWMutex.
+// Used for testing and development. Thread-safe via RWMutex: every method
+// reads or writes the maps under mu, and scenes are copied on the way in and
+// out so callers never share memory with stored scenes.
 type InMemorySceneRepository struct {
 	mu     sync.RWMutex
 	scenes map[string]*Scene
 	keys   map[string]string // "did:rkey" -> UUID
+
+	// Child repositories purged alongside scenes, mirroring ON DELETE CASCADE
+	events *InMemoryEventRepository
+	rsvps  *InMemoryRSVPRepository
 }
 
+// Compile-time check that InMemorySceneRepository satisfies SceneRepository.
+var _ SceneRepository = (*InMemorySceneRepository)(nil)
+
 // NewInMemorySceneRepository creates a new in-memory scene repository.
 func NewInMemorySceneRepository() *InMemorySceneRepository {
 	return &InMemorySceneRepository{
@@ -137,49 +325,81 @@ func NewInMemorySceneRepository() *InMemorySceneRepository {
 
 // Insert stores a new scene, enforcing location consent.
 // If allow_precise is false, precise_point will be set to NULL.
-func (r *InMemorySceneRepository) Insert(scene *Scene) error {
-	// Create a deep copy to avoid modifying the original
-	sceneCopy := *scene
-	if scene.PrecisePoint != nil {
-		pointCopy := *scene.PrecisePoint
-		sceneCopy.PrecisePoint = &pointCopy
+// A scene with both RecordDID and RecordRKey set is also indexed for
+// GetByRecordKey. A slug is assigned as described in assignSlugLocked.
+func (r *InMemorySceneRepository) Insert(ctx context.Context, scene *Scene) error {
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
+	// Create a deep copy to avoid modifying the original
+	sceneCopy := copyScene(scene)
+
 	// Enforce consent before storing - this is the critical privacy control
 	sceneCopy.EnforceLocationConsent()
+	sceneCopy.Version = 1
 
 	r.mu.Lock()
-	r.scenes[sceneCopy.ID] = &sceneCopy
+	r.assignSlugLocked(sceneCopy)
+	r.scenes[sceneCopy.ID] = sceneCopy
+	if sceneCopy.RecordDID != nil && sceneCopy.RecordRKey != nil {
+		r.keys[makeSceneKey(*sceneCopy.RecordDID, *sceneCopy.RecordRKey)] = sceneCopy.ID
+	}
 	r.mu.Unlock()
 	return nil
 }
 
 // Update modifies an existing scene, enforcing location consent.
 // If allow_precise is false, precise_point will be set to NULL.
-func (r *InMemorySceneRepository) Update(scene *Scene) error {
-	// Create a deep copy to avoid modifying the original
-	sceneCopy := *scene
-	if scene.PrecisePoint != nil {
-		pointCopy := *scene.PrecisePoint
-		sceneCopy.PrecisePoint = &pointCopy
+// Returns ErrVersionConflict if scene.Version is non-zero and differs from
+// the stored version. The stored version is incremented on success.
+// Renaming keeps the existing slug so shared links stay valid; the slug
+// is only suffixed if it collides under the scene's (possibly new) owner.
+func (r *InMemorySceneRepository) Update(ctx context.Context, scene *Scene) error {
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
+	// Create a deep copy to avoid modifying the original
+	sceneCopy := copyScene(scene)
+
 	// Enforce consent before storing - this is the critical privacy control
 	sceneCopy.EnforceLocationConsent()
 
 	r.mu.Lock()
-	r.scenes[sceneCopy.ID] = &sceneCopy
-	r.mu.Unlock()
+	defer r.mu.Unlock()
+
+	currentVersion := 0
+	if existing, ok := r.scenes[sceneCopy.ID]; ok {
+		currentVersion = existing.Version
+		if sceneCopy.Slug == "" {
+			sceneCopy.Slug = existing.Slug
+		}
+	}
+	if scene.Version != 0 && scene.Version != currentVersion {
+		return ErrVersionConflict
+	}
+	sceneCopy.Version = currentVersion + 1
+
+	r.assignSlugLocked(sceneCopy)
+	r.scenes[sceneCopy.ID] = sceneCopy
 	return nil
 }
 
 // GetByID retrieves a scene by its ID.
 // Returns ErrSceneNotFound if scene doesn't exist.
 // Returns ErrSceneDeleted if scene exists but is soft-deleted.
-func (r *InMemorySceneRepository) GetByID(id string) (*Scene, error) {
+func (r *InMemorySceneRepository) GetByID(ctx context.Context, id string) (*Scene, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Hold the lock while reading the stored scene: Delete and Restore
+	// update it in place
 	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	scene, ok := r.scenes[id]
-	r.mu.RUnlock()
 	if !ok {
 		return nil, ErrSceneNotFound
 	}
@@ -187,12 +407,7 @@ func (r *InMemorySceneRepository) GetByID(id string) (*Scene, error) {
 		return nil, ErrSceneDeleted
 	}
 	// Return a copy to avoid external modification
-	sceneCopy := *scene
-	if scene.PrecisePoint != nil {
-		pointCopy := *scene.PrecisePoint
-		sceneCopy.PrecisePoint = &pointCopy
-	}
-	return &sceneCopy, nil
+	return copyScene(scene), nil
 }
 
 // makeSceneKey creates a composite key from DID and rkey using a null byte separator to avoid collisions.
@@ -204,7 +419,11 @@ func makeSceneKey(did, rkey string) string {
 
 // Upsert inserts a new scene or updates existing one based on (record_did, record_rkey).
 // Enforces location consent before persisting.
-func (r *InMemorySceneRepository) Upsert(scene *Scene) (*UpsertResult, error) {
+func (r *InMemorySceneRepository) Upsert(ctx context.Context, scene *Scene) (*UpsertResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -212,11 +431,7 @@ func (r *InMemorySceneRepository) Upsert(scene *Scene) (*UpsertResult, error) {
 	var id string
 
 	// Create a deep copy to avoid modifying the original
-	sceneCopy := *scene
-	if scene.PrecisePoint != nil {
-		pointCopy := *scene.PrecisePoint
-		sceneCopy.PrecisePoint = &pointCopy
-	}
+	sceneCopy := copyScene(scene)
 
 	// Enforce consent before storing - this is the critical privacy control
 	sceneCopy.EnforceLocationConsent()
@@ -229,7 +444,12 @@ func (r *InMemorySceneRepository) Upsert(scene *Scene) (*UpsertResult, error) {
 		if exists {
 			// Update existing scene
 			sceneCopy.ID = existingID
-			r.scenes[existingID] = &sceneCopy
+			sceneCopy.Version = r.scenes[existingID].Version + 1
+			if sceneCopy.Slug == "" {
+				sceneCopy.Slug = r.scenes[existingID].Slug
+			}
+			r.assignSlugLocked(sceneCopy)
+			r.scenes[existingID] = sceneCopy
 			inserted = false
 			id = existingID
 		} else {
@@ -237,7 +457,9 @@ func (r *InMemorySceneRepository) Upsert(scene *Scene) (*UpsertResult, error) {
 			if sceneCopy.ID == "" {
 				sceneCopy.ID = uuid.New().String()
 			}
-			r.scenes[sceneCopy.ID] = &sceneCopy
+			sceneCopy.Version = 1
+			r.assignSlugLocked(sceneCopy)
+			r.scenes[sceneCopy.ID] = sceneCopy
 			r.keys[key] = sceneCopy.ID
 			inserted = true
 			id = sceneCopy.ID
@@ -246,7 +468,9 @@ func (r *InMemorySceneRepository) Upsert(scene *Scene) (*UpsertResult, error) {
 		// No record key, always insert new with new UUID
 		newID := uuid.New().String()
 		sceneCopy.ID = newID
-		r.scenes[newID] = &sceneCopy
+		sceneCopy.Version = 1
+		r.assignSlugLocked(sceneCopy)
+		r.scenes[newID] = sceneCopy
 		inserted = true
 		id = newID
 	}
@@ -258,7 +482,11 @@ func (r *InMemorySceneRepository) Upsert(scene *Scene) (*UpsertResult, error) {
 }
 
 // GetByRecordKey retrieves a scene by its AT Protocol record key.
-func (r *InMemorySceneRepository) GetByRecordKey(did, rkey string) (*Scene, error) {
+func (r *InMemorySceneRepository) GetByRecordKey(ctx context.Context, did, rkey string) (*Scene, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -268,19 +496,17 @@ func (r *InMemorySceneRepository) GetByRecordKey(did, rkey string) (*Scene, erro
 		return nil, ErrSceneNotFound
 	}
 
-	scene := r.scenes[id]
-	sceneCopy := *scene
-	if scene.PrecisePoint != nil {
-		pointCopy := *scene.PrecisePoint
-		sceneCopy.PrecisePoint = &pointCopy
-	}
-	return &sceneCopy, nil
+	return copyScene(r.scenes[id]), nil
 }
 
 // Delete soft-deletes a scene by setting deleted_at timestamp.
 // Returns ErrSceneNotFound if scene doesn't exist.
 // Returns ErrSceneDeleted if scene is already deleted.
-func (r *InMemorySceneRepository) Delete(id string) error {
+func (r *InMemorySceneRepository) Delete(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -297,12 +523,114 @@ func (r *InMemorySceneRepository) Delete(id string) error {
 	return nil
 }
 
+// Restore clears a scene's deleted_at timestamp.
+// Returns ErrSceneNotFound if scene doesn't exist.
+// Idempotent: returns nil if scene is not deleted.
+func (r *InMemorySceneRepository) Restore(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	scene, ok := r.scenes[id]
+	if !ok {
+		return ErrSceneNotFound
+	}
+	scene.DeletedAt = nil
+	// The slug may have been reused while the scene was deleted
+	r.assignSlugLocked(scene)
+	return nil
+}
+
+// SetCascade sets the event and RSVP repositories whose records are removed
+// when their scene is purged, mirroring the ON DELETE CASCADE foreign keys in
+// the database schema. Either may be nil.
+func (r *InMemorySceneRepository) SetCascade(events *InMemoryEventRepository, rsvps *InMemoryRSVPRepository) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = events
+	r.rsvps = rsvps
+}
+
+// Purge permanently removes scenes soft-deleted before olderThan, along with
+// their events and those events' RSVPs when SetCascade has been called.
+// Returns the number of scenes removed.
+func (r *InMemorySceneRepository) Purge(ctx context.Context, olderThan time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	purged := make(map[string]bool)
+	for id, scene := range r.scenes {
+		if scene.DeletedAt != nil && scene.DeletedAt.Before(olderThan) {
+			purged[id] = true
+			delete(r.scenes, id)
+		}
+	}
+	for key, id := range r.keys {
+		if purged[id] {
+			delete(r.keys, key)
+		}
+	}
+	events, rsvps := r.events, r.rsvps
+	r.mu.Unlock()
+
+	if len(purged) == 0 {
+		return 0, nil
+	}
+
+	// Cascade outside the scene lock to keep lock ordering simple
+	if events != nil {
+		eventIDs := events.purgeByScenes(purged)
+		if rsvps != nil {
+			rsvps.purgeByEvents(eventIDs)
+		}
+	}
+	return len(purged), nil
+}
+
+// Snapshot calls fn with a copy of the scene and its non-deleted events,
+// holding the scene lock and, when SetCascade has been called, the event
+// lock until fn returns. Without an event repository, events is empty.
+func (r *InMemorySceneRepository) Snapshot(ctx context.Context, id string, fn func(scene *Scene, events []*Event) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.scenes[id]
+	if !ok {
+		return ErrSceneNotFound
+	}
+	if stored.DeletedAt != nil {
+		return ErrSceneDeleted
+	}
+
+	events := make([]*Event, 0)
+	if r.events != nil {
+		// Purge never holds both locks, so taking them in this order is safe
+		r.events.mu.RLock()
+		defer r.events.mu.RUnlock()
+		events = r.events.listBySceneID(id, EventFilter{})
+	}
+	return fn(copyScene(stored), events)
+}
+
 // ExistsByOwnerAndName checks if a non-deleted scene with the given name
 // exists for the specified owner. Used for duplicate name validation.
 // Performs case-insensitive comparison to prevent names differing only by case.
 // excludeID allows checking for duplicates while excluding a specific scene
 // (useful when updating a scene's name).
-func (r *InMemorySceneRepository) ExistsByOwnerAndName(ownerDID, name string, excludeID string) (bool, error) {
+func (r *InMemorySceneRepository) ExistsByOwnerAndName(ctx context.Context, ownerDID, name string, excludeID string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -319,9 +647,71 @@ func (r *InMemorySceneRepository) ExistsByOwnerAndName(ownerDID, name string, ex
 	return false, nil
 }
 
+// GetBySlug retrieves an owner's non-deleted scene by slug, matched
+// case-insensitively. Returns ErrSceneDeleted if only a soft-deleted scene
+// carries the slug.
+func (r *InMemorySceneRepository) GetBySlug(ctx context.Context, ownerDID, slug string) (*Scene, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	slug = strings.ToLower(slug)
+	deleted := false
+	for _, scene := range r.scenes {
+		if scene.OwnerDID != ownerDID || scene.Slug != slug {
+			continue
+		}
+		if scene.DeletedAt != nil {
+			deleted = true
+			continue
+		}
+		return copyScene(scene), nil
+	}
+	if deleted {
+		return nil, ErrSceneDeleted
+	}
+	return nil, ErrSceneNotFound
+}
+
+// assignSlugLocked gives s a slug unique among its owner's other non-deleted
+// scenes. The slug is derived from the name when unset and normalized
+// otherwise; on collision a numeric suffix is appended ("my-scene-2").
+// Caller must hold the write lock.
+func (r *InMemorySceneRepository) assignSlugLocked(s *Scene) {
+	base := s.Slug
+	if base == "" {
+		base = s.Name
+	}
+	base = Slugify(base)
+
+	slug := base
+	for n := 2; r.slugTakenLocked(s.OwnerDID, slug, s.ID); n++ {
+		slug = suffixSlug(base, n)
+	}
+	s.Slug = slug
+}
+
+// slugTakenLocked reports whether another non-deleted scene of ownerDID
+// carries slug. Caller must hold at least a read lock.
+func (r *InMemorySceneRepository) slugTakenLocked(ownerDID, slug, excludeID string) bool {
+	for id, scene := range r.scenes {
+		if id != excludeID && scene.DeletedAt == nil && scene.OwnerDID == ownerDID && scene.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
+
 // ListByOwner retrieves all non-deleted scenes owned by the specified DID.
 // Returns empty slice if no scenes found.
-func (r *InMemorySceneRepository) ListByOwner(ownerDID string) ([]*Scene, error) {
+func (r *InMemorySceneRepository) ListByOwner(ctx context.Context, ownerDID string) ([]*Scene, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -329,31 +719,316 @@ func (r *InMemorySceneRepository) ListByOwner(ownerDID string) ([]*Scene, error)
 	for _, scene := range r.scenes {
 		if scene.DeletedAt == nil && scene.OwnerDID == ownerDID {
 			// Return a copy to avoid external modification
-			sceneCopy := *scene
-			if scene.PrecisePoint != nil {
-				pointCopy := *scene.PrecisePoint
-				sceneCopy.PrecisePoint = &pointCopy
-			}
-			result = append(result, &sceneCopy)
+			result = append(result, copyScene(scene))
 		}
 	}
 
 	return result, nil
 }
 
+// copyScene creates a deep copy of a scene to avoid external modification.
+// Tags and palettes are copied too so callers can't mutate stored scenes
+// through a shared slice, map, or pointer.
+func copyScene(scene *Scene) *Scene {
+	sceneCopy := *scene
+	if scene.PrecisePoint != nil {
+		pointCopy := *scene.PrecisePoint
+		sceneCopy.PrecisePoint = &pointCopy
+	}
+	if scene.Tags != nil {
+		sceneCopy.Tags = append([]string(nil), scene.Tags...)
+	}
+	if scene.Palette != nil {
+		paletteCopy := *scene.Palette
+		sceneCopy.Palette = &paletteCopy
+	}
+	if scene.Palettes != nil {
+		sceneCopy.Palettes = make(map[string]*Palette, len(scene.Palettes))
+		for theme, p := range scene.Palettes {
+			if p == nil {
+				continue
+			}
+			paletteCopy := *p
+			sceneCopy.Palettes[theme] = &paletteCopy
+		}
+	}
+	return &sceneCopy
+}
+
+// FindNearby returns non-deleted public scenes whose coarse geohash cell center
+// lies within radiusMeters of center, ordered by distance ascending.
+// Distance is measured from the coarse cell center, never the precise point,
+// so results cannot be used to triangulate venues.
+func (r *InMemorySceneRepository) FindNearby(ctx context.Context, center Point, radiusMeters float64, limit int) ([]*Scene, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type sceneDistance struct {
+		scene    *Scene
+		distance float64
+	}
+
+	matches := make([]sceneDistance, 0)
+	for _, scene := range r.scenes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if scene.DeletedAt != nil || scene.Visibility != VisibilityPublic {
+			continue
+		}
+
+		cellCenter, ok := geo.Decode(scene.CoarseGeohash)
+		if !ok {
+			continue
+		}
+
+		distance := geo.DistanceMeters(center, cellCenter)
+		if distance <= radiusMeters {
+			matches = append(matches, sceneDistance{scene: scene, distance: distance})
+		}
+	}
+
+	// Sort by distance ascending, then by ID for stable ordering
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance == matches[j].distance {
+			return matches[i].scene.ID < matches[j].scene.ID
+		}
+		return matches[i].distance < matches[j].distance
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	results := make([]*Scene, len(matches))
+	for i, m := range matches {
+		results[i] = copyScene(m.scene)
+	}
+	return results, nil
+}
+
+// FindInBbox returns non-deleted public scenes whose coarse geohash cell
+// center lies within the bounding box, ordered by ID.
+func (r *InMemorySceneRepository) FindInBbox(ctx context.Context, minLng, minLat, maxLng, maxLat float64) ([]*Scene, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]*Scene, 0)
+	for _, scene := range r.scenes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if scene.DeletedAt != nil || scene.Visibility != VisibilityPublic {
+			continue
+		}
+
+		cellCenter, ok := geo.Decode(scene.CoarseGeohash)
+		if !ok {
+			continue
+		}
+		if cellCenter.Lng < minLng || cellCenter.Lng > maxLng || cellCenter.Lat < minLat || cellCenter.Lat > maxLat {
+			continue
+		}
+		results = append(results, copyScene(scene))
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].ID < results[j].ID
+	})
+	return results, nil
+}
+
+// sceneCreatedAt returns the scene creation time, or the zero time if unset.
+func sceneCreatedAt(s *Scene) time.Time {
+	if s.CreatedAt == nil {
+		return time.Time{}
+	}
+	return *s.CreatedAt
+}
+
+// List retrieves non-deleted scenes matching the filter, newest first.
+// Hidden scenes are excluded unless the viewer is the owner.
+// Cursor format is "RFC3339Nano|ID" of the last scene on the previous page.
+func (r *InMemorySceneRepository) List(ctx context.Context, filter ListFilter) ([]*Scene, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	var cursorTime time.Time
+	var cursorID string
+	if filter.Cursor != "" {
+		parts := strings.SplitN(filter.Cursor, "|", 2)
+		if len(parts) != 2 {
+			return nil, "", ErrInvalidCursor
+		}
+		parsedTime, err := time.Parse(time.RFC3339Nano, parts[0])
+		if err != nil {
+			return nil, "", ErrInvalidCursor
+		}
+		cursorTime = parsedTime
+		cursorID = parts[1]
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultSceneListLimit
+	}
+	if limit > MaxSceneListLimit {
+		limit = MaxSceneListLimit
+	}
+
+	r.mu.RLock()
+	results := make([]*Scene, 0)
+	for _, scene := range r.scenes {
+		if scene.DeletedAt != nil {
+			continue
+		}
+		if scene.Visibility == VisibilityHidden && !scene.IsOwner(filter.ViewerDID) {
+			continue
+		}
+		if filter.Visibility != "" && scene.Visibility != filter.Visibility {
+			continue
+		}
+		if filter.OwnerDID != "" && scene.OwnerDID != filter.OwnerDID {
+			continue
+		}
+		if filter.Tag != "" && !hasTag(scene.Tags, filter.Tag) {
+			continue
+		}
+		results = append(results, copyScene(scene))
+	}
+	r.mu.RUnlock()
+
+	// Sort by created_at descending, then by ID descending for stable ordering
+	sort.Slice(results, func(i, j int) bool {
+		ti, tj := sceneCreatedAt(results[i]), sceneCreatedAt(results[j])
+		if ti.Equal(tj) {
+			return results[i].ID > results[j].ID
+		}
+		return ti.After(tj)
+	})
+
+	// Skip everything up to and including the cursor position
+	if filter.Cursor != "" {
+		start := len(results)
+		for i, scene := range results {
+			t := sceneCreatedAt(scene)
+			if t.Before(cursorTime) || (t.Equal(cursorTime) && scene.ID < cursorID) {
+				start = i
+				break
+			}
+		}
+		results = results[start:]
+	}
+
+	var nextCursor string
+	if len(results) > limit {
+		last := results[limit-1]
+		nextCursor = sceneCreatedAt(last).Format(time.RFC3339Nano) + "|" + last.ID
+		results = results[:limit]
+	}
+
+	return results, nextCursor, nil
+}
+
+// FindByTag retrieves non-deleted public scenes carrying the given tag, newest first.
+// The tag is normalized before matching.
+func (r *InMemorySceneRepository) FindByTag(ctx context.Context, tag string, limit int) ([]*Scene, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	normalized := NormalizeTags([]string{tag})
+	if len(normalized) == 0 {
+		return []*Scene{}, nil
+	}
+	return r.FindByTags(ctx, normalized, true, limit)
+}
+
+// FindByTags retrieves non-deleted public scenes carrying any (or, with
+// matchAll, every) given tag, newest first. Tags are normalized before
+// matching; an empty tag list applies no tag filter.
+func (r *InMemorySceneRepository) FindByTags(ctx context.Context, tags []string, matchAll bool, limit int) ([]*Scene, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	normalized := NormalizeTags(tags)
+
+	r.mu.RLock()
+	results := make([]*Scene, 0)
+	for _, scene := range r.scenes {
+		if scene.DeletedAt != nil || scene.Visibility != VisibilityPublic {
+			continue
+		}
+		if len(normalized) == 0 || matchesTags(scene.Tags, normalized, matchAll) {
+			results = append(results, copyScene(scene))
+		}
+	}
+	r.mu.RUnlock()
+
+	// Sort by created_at descending, then by ID descending for stable ordering
+	sort.Slice(results, func(i, j int) bool {
+		ti, tj := sceneCreatedAt(results[i]), sceneCreatedAt(results[j])
+		if ti.Equal(tj) {
+			return results[i].ID > results[j].ID
+		}
+		return ti.After(tj)
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// matchesTags reports whether sceneTags contains any of wanted, or all of
+// them when matchAll is true.
+func matchesTags(sceneTags, wanted []string, matchAll bool) bool {
+	for _, tag := range wanted {
+		found := hasTag(sceneTags, tag)
+		if found && !matchAll {
+			return true
+		}
+		if !found && matchAll {
+			return false
+		}
+	}
+	return matchAll
+}
+
+// hasTag reports whether tags contains tag, ignoring case and surrounding whitespace.
+func hasTag(tags []string, tag string) bool {
+	tag = strings.TrimSpace(tag)
+	for _, t := range tags {
+		if strings.EqualFold(strings.TrimSpace(t), tag) {
+			return true
+		}
+	}
+	return false
+}
+
 // InMemoryEventRepository is an in-memory implementation of EventRepository.
 // Used for testing and development. Thread-safe via RWMutex.
 type InMemoryEventRepository struct {
 	mu     sync.RWMutex
 	events map[string]*Event
 	keys   map[string]string // "did:rkey" -> UUID
+
+	// sceneDeleted holds IDs of events soft-deleted by DeleteBySceneID,
+	// so RestoreBySceneID leaves events deleted for other reasons alone
+	sceneDeleted map[string]bool
 }
 
+// Compile-time check that InMemoryEventRepository satisfies EventRepository.
+var _ EventRepository = (*InMemoryEventRepository)(nil)
+
 // NewInMemoryEventRepository creates a new in-memory event repository.
 func NewInMemoryEventRepository() *InMemoryEventRepository {
 	return &InMemoryEventRepository{
-		events: make(map[string]*Event),
-		keys:   make(map[string]string),
+		events:       make(map[string]*Event),
+		keys:         make(map[string]string),
+		sceneDeleted: make(map[string]bool),
 	}
 }
 
@@ -369,7 +1044,13 @@ func copyEvent(event *Event) *Event {
 
 // Insert stores a new event, enforcing location consent.
 // If allow_precise is false, precise_point will be set to NULL.
-func (r *InMemoryEventRepository) Insert(event *Event) error {
+// A event with both RecordDID and RecordRKey set is also indexed for
+// GetByRecordKey.
+func (r *InMemoryEventRepository) Insert(ctx context.Context, event *Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	// Create a deep copy to avoid modifying the original
 	eventCopy := *event
 	if event.PrecisePoint != nil {
@@ -382,13 +1063,20 @@ func (r *InMemoryEventRepository) Insert(event *Event) error {
 
 	r.mu.Lock()
 	r.events[eventCopy.ID] = &eventCopy
+	if eventCopy.RecordDID != nil && eventCopy.RecordRKey != nil {
+		r.keys[makeEventKey(*eventCopy.RecordDID, *eventCopy.RecordRKey)] = eventCopy.ID
+	}
 	r.mu.Unlock()
 	return nil
 }
 
 // Update modifies an existing event, enforcing location consent.
 // If allow_precise is false, precise_point will be set to NULL.
-func (r *InMemoryEventRepository) Update(event *Event) error {
+func (r *InMemoryEventRepository) Update(ctx context.Context, event *Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	// Create a deep copy to avoid modifying the original
 	eventCopy := *event
 	if event.PrecisePoint != nil {
@@ -406,11 +1094,19 @@ func (r *InMemoryEventRepository) Update(event *Event) error {
 }
 
 // GetByID retrieves an event by its ID.
-func (r *InMemoryEventRepository) GetByID(id string) (*Event, error) {
+// Returns ErrEventNotFound if event doesn't exist or is soft-deleted.
+func (r *InMemoryEventRepository) GetByID(ctx context.Context, id string) (*Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Hold the lock while reading the stored event: Delete and
+	// DeleteBySceneID update it in place
 	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	event, ok := r.events[id]
-	r.mu.RUnlock()
-	if !ok {
+	if !ok || event.DeletedAt != nil {
 		return nil, ErrEventNotFound
 	}
 	// Return a copy to avoid external modification
@@ -422,6 +1118,93 @@ func (r *InMemoryEventRepository) GetByID(id string) (*Event, error) {
 	return &eventCopy, nil
 }
 
+// Delete soft-deletes a single event.
+// Returns ErrEventNotFound if the event doesn't exist or is already deleted.
+func (r *InMemoryEventRepository) Delete(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event, ok := r.events[id]
+	if !ok || event.DeletedAt != nil {
+		return ErrEventNotFound
+	}
+	now := time.Now()
+	event.DeletedAt = &now
+	return nil
+}
+
+// DeleteBySceneID soft-deletes all non-deleted events for a scene.
+// Returns the number of events deleted.
+func (r *InMemoryEventRepository) DeleteBySceneID(ctx context.Context, sceneID string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	count := 0
+	for id, event := range r.events {
+		if event.SceneID != sceneID || event.DeletedAt != nil {
+			continue
+		}
+		deletedAt := now
+		event.DeletedAt = &deletedAt
+		r.sceneDeleted[id] = true
+		count++
+	}
+	return count, nil
+}
+
+// RestoreBySceneID restores events soft-deleted by DeleteBySceneID for a scene.
+// Returns the number of events restored.
+func (r *InMemoryEventRepository) RestoreBySceneID(ctx context.Context, sceneID string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for id, event := range r.events {
+		if event.SceneID != sceneID || !r.sceneDeleted[id] {
+			continue
+		}
+		event.DeletedAt = nil
+		delete(r.sceneDeleted, id)
+		count++
+	}
+	return count, nil
+}
+
+// purgeByScenes permanently removes all events, deleted or not, belonging to
+// the given scenes. Returns the set of removed event IDs.
+func (r *InMemoryEventRepository) purgeByScenes(sceneIDs map[string]bool) map[string]bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	purged := make(map[string]bool)
+	for id, event := range r.events {
+		if sceneIDs[event.SceneID] {
+			purged[id] = true
+			delete(r.events, id)
+			delete(r.sceneDeleted, id)
+		}
+	}
+	for key, id := range r.keys {
+		if purged[id] {
+			delete(r.keys, key)
+		}
+	}
+	return purged
+}
+
 // makeEventKey creates a composite key from DID and rkey using a null byte separator to avoid collisions.
 // AT Protocol DIDs contain colons (e.g., "did:plc:abc123"), so using a null byte prevents
 // collisions like did="a:b" + rkey="c" vs did="a" + rkey="b:c" both producing "a:b:c".
@@ -431,7 +1214,11 @@ func makeEventKey(did, rkey string) string {
 
 // Upsert inserts a new event or updates existing one based on (record_did, record_rkey).
 // Enforces location consent before persisting.
-func (r *InMemoryEventRepository) Upsert(event *Event) (*UpsertResult, error) {
+func (r *InMemoryEventRepository) Upsert(ctx context.Context, event *Event) (*UpsertResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -485,7 +1272,11 @@ func (r *InMemoryEventRepository) Upsert(event *Event) (*UpsertResult, error) {
 }
 
 // GetByRecordKey retrieves an event by its AT Protocol record key.
-func (r *InMemoryEventRepository) GetByRecordKey(did, rkey string) (*Event, error) {
+func (r *InMemoryEventRepository) GetByRecordKey(ctx context.Context, did, rkey string) (*Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -508,12 +1299,16 @@ func (r *InMemoryEventRepository) GetByRecordKey(did, rkey string) (*Event, erro
 // Sets status to "cancelled", stores cancelled_at timestamp, and cancellation_reason.
 // Returns ErrEventNotFound if event doesn't exist.
 // Idempotent: returns nil if event is already cancelled.
-func (r *InMemoryEventRepository) Cancel(id string, reason *string) error {
+func (r *InMemoryEventRepository) Cancel(ctx context.Context, id string, reason *string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	event, ok := r.events[id]
-	if !ok {
+	if !ok || event.DeletedAt != nil {
 		return ErrEventNotFound
 	}
 
@@ -535,7 +1330,7 @@ func (r *InMemoryEventRepository) Cancel(id string, reason *string) error {
 // SearchByBboxAndTime searches for events within a bounding box and time range.
 // Filters out cancelled events and applies pagination.
 // Returns events sorted by starts_at ascending.
-func (r *InMemoryEventRepository) SearchByBboxAndTime(minLng, minLat, maxLng, maxLat float64, from, to time.Time, limit int, cursor string) ([]*Event, string, error) {
+func (r *InMemoryEventRepository) SearchByBboxAndTime(ctx context.Context, minLng, minLat, maxLng, maxLat float64, from, to time.Time, limit int, cursor string) ([]*Event, string, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -561,6 +1356,9 @@ func (r *InMemoryEventRepository) SearchByBboxAndTime(minLng, minLat, maxLng, ma
 
 	// Collect matching events
 	for _, event := range r.events {
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
 		// Skip cancelled events
 		if event.Status == "cancelled" {
 			continue
@@ -635,17 +1433,233 @@ func (r *InMemoryEventRepository) SearchByBboxAndTime(minLng, minLat, maxLng, ma
 	return results, nextCursor, nil
 }
 
+// ListBySceneID retrieves non-deleted events for a scene matching the filter.
+// Returns events sorted by starts_at ascending, then by ID.
+func (r *InMemoryEventRepository) ListBySceneID(ctx context.Context, sceneID string, filter EventFilter) ([]*Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.listBySceneID(sceneID, filter), nil
+}
+
+// listBySceneID implements ListBySceneID. The caller must hold r.mu.
+func (r *InMemoryEventRepository) listBySceneID(sceneID string, filter EventFilter) []*Event {
+	now := time.Now()
+	results := make([]*Event, 0)
+	for _, event := range r.events {
+		if event.SceneID != sceneID || event.DeletedAt != nil {
+			continue
+		}
+		if !filter.From.IsZero() && event.StartsAt.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && event.StartsAt.After(filter.To) {
+			continue
+		}
+
+		cancelled := event.Status == "cancelled"
+		switch filter.Status {
+		case EventStatusFilterScheduled:
+			if cancelled || event.StartsAt.Before(now) {
+				continue
+			}
+		case EventStatusFilterCancelled:
+			if !cancelled {
+				continue
+			}
+		case EventStatusFilterPast:
+			if cancelled || !event.StartsAt.Before(now) {
+				continue
+			}
+		}
+
+		results = append(results, copyEvent(event))
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].StartsAt.Equal(results[j].StartsAt) {
+			return results[i].ID < results[j].ID
+		}
+		return results[i].StartsAt.Before(results[j].StartsAt)
+	})
+
+	return results
+}
+
+// ListBySeries retrieves non-deleted events sharing a series ID.
+// Returns events sorted by starts_at ascending, then by ID.
+func (r *InMemoryEventRepository) ListBySeries(ctx context.Context, seriesID string) ([]*Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]*Event, 0)
+	for _, event := range r.events {
+		if event.SeriesID == nil || *event.SeriesID != seriesID || event.DeletedAt != nil {
+			continue
+		}
+		results = append(results, copyEvent(event))
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].StartsAt.Equal(results[j].StartsAt) {
+			return results[i].ID < results[j].ID
+		}
+		return results[i].StartsAt.Before(results[j].StartsAt)
+	})
+
+	return results, nil
+}
+
+// EventCursor returns the pagination cursor for an event, in the
+// "RFC3339Nano|ID" format accepted by ListUpcomingNear.
+func EventCursor(event *Event) string {
+	return event.StartsAt.Format(time.RFC3339Nano) + "|" + event.ID
+}
+
+// ListUpcomingNear retrieves upcoming events near a point, ordered by starts_at,
+// then distance from center, then ID. Distance is measured to the center of the
+// event's coarse geohash cell so precise locations never influence results.
+// The cursor identifies the last event of the previous page by (starts_at, ID);
+// its distance is resolved from the stored event to keep ordering stable.
+func (r *InMemoryEventRepository) ListUpcomingNear(ctx context.Context, center Point, radiusMeters float64, after time.Time, limit int, cursor string) ([]*Event, string, error) {
+	var cursorTime time.Time
+	var cursorID string
+	if cursor != "" {
+		parts := strings.SplitN(cursor, "|", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, "", ErrInvalidCursor
+		}
+		parsedTime, err := time.Parse(time.RFC3339Nano, parts[0])
+		if err != nil {
+			return nil, "", ErrInvalidCursor
+		}
+		cursorTime = parsedTime
+		cursorID = parts[1]
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type eventDistance struct {
+		event    *Event
+		distance float64
+	}
+
+	// eventDistanceFrom returns the distance to the event's coarse cell center.
+	eventDistanceFrom := func(event *Event) (float64, bool) {
+		cellCenter, ok := geo.Decode(event.CoarseGeohash)
+		if !ok {
+			return 0, false
+		}
+		return geo.DistanceMeters(center, cellCenter), true
+	}
+
+	matches := make([]eventDistance, 0)
+	for _, event := range r.events {
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
+		if event.DeletedAt != nil || event.Status == "cancelled" || !event.StartsAt.After(after) {
+			continue
+		}
+		distance, ok := eventDistanceFrom(event)
+		if !ok || distance > radiusMeters {
+			continue
+		}
+		matches = append(matches, eventDistance{event: event, distance: distance})
+	}
+
+	less := func(aStart time.Time, aDist float64, aID string, bStart time.Time, bDist float64, bID string) bool {
+		if !aStart.Equal(bStart) {
+			return aStart.Before(bStart)
+		}
+		if aDist != bDist {
+			return aDist < bDist
+		}
+		return aID < bID
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		return less(a.event.StartsAt, a.distance, a.event.ID, b.event.StartsAt, b.distance, b.event.ID)
+	})
+
+	// Skip everything up to and including the cursor position
+	if cursor != "" {
+		// If the cursor event no longer exists, resume after its start time
+		cursorDistance := math.Inf(1)
+		if cursorEvent, ok := r.events[cursorID]; ok {
+			if d, ok := eventDistanceFrom(cursorEvent); ok {
+				cursorDistance = d
+			}
+		}
+		start := len(matches)
+		for i, m := range matches {
+			if less(cursorTime, cursorDistance, cursorID, m.event.StartsAt, m.distance, m.event.ID) {
+				start = i
+				break
+			}
+		}
+		matches = matches[start:]
+	}
+
+	var nextCursor string
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+		nextCursor = EventCursor(matches[limit-1].event)
+	}
+
+	results := make([]*Event, len(matches))
+	for i, m := range matches {
+		results[i] = copyEvent(m.event)
+	}
+	return results, nextCursor, nil
+}
+
 // InMemoryRSVPRepository is an in-memory implementation of RSVPRepository.
 // Used for testing and development. Thread-safe via RWMutex.
 type InMemoryRSVPRepository struct {
 	mu    sync.RWMutex
-	rsvps map[string]*RSVP // key: "eventID:userID"
+	rsvps   map[string]*RSVP        // key: "eventID:userID"
+	history map[string][]RSVPChange // key: "eventID:userID"
 }
 
+// Compile-time check that InMemoryRSVPRepository satisfies RSVPRepository.
+var _ RSVPRepository = (*InMemoryRSVPRepository)(nil)
+
 // NewInMemoryRSVPRepository creates a new in-memory RSVP repository.
 func NewInMemoryRSVPRepository() *InMemoryRSVPRepository {
 	return &InMemoryRSVPRepository{
-		rsvps: make(map[string]*RSVP),
+		rsvps:   make(map[string]*RSVP),
+		history: make(map[string][]RSVPChange),
+	}
+}
+
+// purgeByEvents permanently removes RSVPs and their history for the given events.
+func (r *InMemoryRSVPRepository) purgeByEvents(eventIDs map[string]bool) {
+	if len(eventIDs) == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, rsvp := range r.rsvps {
+		if eventIDs[rsvp.EventID] {
+			delete(r.rsvps, key)
+		}
+	}
+	for key, changes := range r.history {
+		if len(changes) > 0 && eventIDs[changes[0].EventID] {
+			delete(r.history, key)
+		}
 	}
 }
 
@@ -656,16 +1670,144 @@ func makeRSVPKey(eventID, userID string) string {
 
 // Upsert inserts or updates an RSVP for an event.
 // Idempotent: if RSVP exists with same status, returns without error.
-func (r *InMemoryRSVPRepository) Upsert(rsvp *RSVP) error {
+// A history entry is appended whenever the status changes.
+func (r *InMemoryRSVPRepository) Upsert(ctx context.Context, rsvp *RSVP) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.upsertLocked(makeRSVPKey(rsvp.EventID, rsvp.UserID), rsvp, time.Now())
+	return nil
+}
+
+// UpsertWithCooldown upserts an RSVP unless it would change the status
+// within cooldown of the last recorded change.
+// Returns ErrRSVPChangeTooSoon if the change is rejected.
+func (r *InMemoryRSVPRepository) UpsertWithCooldown(ctx context.Context, rsvp *RSVP, cooldown time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := makeRSVPKey(rsvp.EventID, rsvp.UserID)
+	now := time.Now()
+
+	// History survives Delete, so delete-and-recreate churn is throttled too
+	existing, exists := r.rsvps[key]
+	changing := !exists || existing.Status != rsvp.Status
+	if changing && cooldown > 0 {
+		if changes := r.history[key]; len(changes) > 0 && now.Sub(changes[len(changes)-1].ChangedAt) < cooldown {
+			return ErrRSVPChangeTooSoon
+		}
+	}
+
+	r.upsertLocked(key, rsvp, now)
+	return nil
+}
+
+// UpsertWithCapacity upserts an RSVP, waitlisting a new "going" RSVP once
+// the event is full. Users already going keep their seat.
+// Returns ErrRSVPChangeTooSoon if the resulting status change is rejected.
+func (r *InMemoryRSVPRepository) UpsertWithCapacity(ctx context.Context, rsvp *RSVP, capacity int, cooldown time.Duration) (*RSVP, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	key := makeRSVPKey(rsvp.EventID, rsvp.UserID)
 	now := time.Now()
 
+	existing, exists := r.rsvps[key]
+	status := rsvp.Status
+	if status == "going" && capacity > 0 && (!exists || existing.Status != "going") &&
+		r.goingCountLocked(rsvp.EventID) >= capacity {
+		status = "waitlisted"
+	}
+
+	changing := !exists || existing.Status != status
+	if changing && cooldown > 0 {
+		if changes := r.history[key]; len(changes) > 0 && now.Sub(changes[len(changes)-1].ChangedAt) < cooldown {
+			return nil, ErrRSVPChangeTooSoon
+		}
+	}
+
+	stored := *rsvp
+	stored.Status = status
+	r.upsertLocked(key, &stored, now)
+
+	rsvpCopy := *r.rsvps[key]
+	return &rsvpCopy, nil
+}
+
+// PromoteWaitlisted fills open seats from the waitlist, oldest RSVP first.
+func (r *InMemoryRSVPRepository) PromoteWaitlisted(ctx context.Context, eventID string, capacity int) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if capacity <= 0 {
+		return 0, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	openSeats := capacity - r.goingCountLocked(eventID)
+	if openSeats <= 0 {
+		return 0, nil
+	}
+
+	var waitlisted []*RSVP
+	for _, rsvp := range r.rsvps {
+		if rsvp.EventID == eventID && rsvp.Status == "waitlisted" {
+			waitlisted = append(waitlisted, rsvp)
+		}
+	}
+	sort.Slice(waitlisted, func(i, j int) bool {
+		ti, tj := rsvpCreatedAt(waitlisted[i]), rsvpCreatedAt(waitlisted[j])
+		if ti.Equal(tj) {
+			return waitlisted[i].UserID < waitlisted[j].UserID
+		}
+		return ti.Before(tj)
+	})
+
+	promoted := min(openSeats, len(waitlisted))
+	now := time.Now()
+	for _, rsvp := range waitlisted[:promoted] {
+		next := *rsvp
+		next.Status = "going"
+		r.upsertLocked(makeRSVPKey(eventID, rsvp.UserID), &next, now)
+	}
+	return promoted, nil
+}
+
+// goingCountLocked returns how many users are going to an event.
+// Caller must hold the lock.
+func (r *InMemoryRSVPRepository) goingCountLocked(eventID string) int {
+	count := 0
+	for _, rsvp := range r.rsvps {
+		if rsvp.EventID == eventID && rsvp.Status == "going" {
+			count++
+		}
+	}
+	return count
+}
+
+// upsertLocked inserts or updates the RSVP stored under key, recording
+// status changes in history. Caller must hold the write lock.
+func (r *InMemoryRSVPRepository) upsertLocked(key string, rsvp *RSVP, now time.Time) {
 	// Check if RSVP already exists
 	existing, exists := r.rsvps[key]
 	if exists {
+		if existing.Status != rsvp.Status {
+			r.appendHistory(key, rsvp, existing.Status, now)
+		}
 		// Update existing RSVP
 		existing.Status = rsvp.Status
 		existing.UpdatedAt = &now
@@ -675,14 +1817,44 @@ func (r *InMemoryRSVPRepository) Upsert(rsvp *RSVP) error {
 		rsvpCopy.CreatedAt = &now
 		rsvpCopy.UpdatedAt = &now
 		r.rsvps[key] = &rsvpCopy
+		r.appendHistory(key, rsvp, "", now)
 	}
+}
 
-	return nil
+// appendHistory records a status transition. Caller must hold the write lock.
+func (r *InMemoryRSVPRepository) appendHistory(key string, rsvp *RSVP, fromStatus string, at time.Time) {
+	r.history[key] = append(r.history[key], RSVPChange{
+		EventID:    rsvp.EventID,
+		UserID:     rsvp.UserID,
+		FromStatus: fromStatus,
+		ToStatus:   rsvp.Status,
+		ChangedAt:  at,
+	})
+}
+
+// History returns every status transition recorded for a user's RSVP
+// to an event, oldest first. Returns an empty slice if none exist.
+func (r *InMemoryRSVPRepository) History(ctx context.Context, eventID, userID string) ([]RSVPChange, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := r.history[makeRSVPKey(eventID, userID)]
+	results := make([]RSVPChange, len(entries))
+	copy(results, entries)
+	return results, nil
 }
 
 // Delete removes an RSVP for a user and event.
 // Returns ErrRSVPNotFound if RSVP doesn't exist.
-func (r *InMemoryRSVPRepository) Delete(eventID, userID string) error {
+func (r *InMemoryRSVPRepository) Delete(ctx context.Context, eventID, userID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -697,7 +1869,11 @@ func (r *InMemoryRSVPRepository) Delete(eventID, userID string) error {
 
 // GetByEventAndUser retrieves an RSVP for a specific user and event.
 // Returns ErrRSVPNotFound if RSVP doesn't exist.
-func (r *InMemoryRSVPRepository) GetByEventAndUser(eventID, userID string) (*RSVP, error) {
+func (r *InMemoryRSVPRepository) GetByEventAndUser(ctx context.Context, eventID, userID string) (*RSVP, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -712,33 +1888,142 @@ func (r *InMemoryRSVPRepository) GetByEventAndUser(eventID, userID string) (*RSV
 	return &rsvpCopy, nil
 }
 
+// CheckIn marks a user's going RSVP as checked in and returns it.
+// Returns ErrRSVPNotFound or ErrRSVPNotGoing if the RSVP can't be checked in.
+func (r *InMemoryRSVPRepository) CheckIn(ctx context.Context, eventID, userID string) (*RSVP, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rsvp, exists := r.rsvps[makeRSVPKey(eventID, userID)]
+	if !exists {
+		return nil, ErrRSVPNotFound
+	}
+	if rsvp.CheckedInAt == nil {
+		if rsvp.Status != "going" {
+			return nil, ErrRSVPNotGoing
+		}
+		now := time.Now()
+		rsvp.CheckedInAt = &now
+	}
+
+	rsvpCopy := *rsvp
+	return &rsvpCopy, nil
+}
+
+// GetCheckinCount returns the number of checked-in RSVPs for an event.
+func (r *InMemoryRSVPRepository) GetCheckinCount(ctx context.Context, eventID string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, rsvp := range r.rsvps {
+		if rsvp.EventID == eventID && rsvp.CheckedInAt != nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
 // GetCountsByEvent returns aggregated RSVP counts by status for an event.
-func (r *InMemoryRSVPRepository) GetCountsByEvent(eventID string) (*RSVPCounts, error) {
+// Counts are taken under the read lock, so a user whose status is changing
+// concurrently is counted exactly once, under either the old or new status.
+func (r *InMemoryRSVPRepository) GetCountsByEvent(ctx context.Context, eventID string) (*RSVPCounts, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	counts, err := r.GetCountsForEvents(ctx, []string{eventID})
+	if err != nil {
+		return nil, err
+	}
+	return counts[eventID], nil
+}
+
+// ListByEvent returns RSVPs for an event, optionally filtered by status.
+// An empty status returns all RSVPs. Results are sorted by created_at
+// ascending, then by user ID.
+func (r *InMemoryRSVPRepository) ListByEvent(ctx context.Context, eventID string, status string) ([]*RSVP, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	counts := &RSVPCounts{
-		Going: 0,
-		Maybe: 0,
+	results := make([]*RSVP, 0)
+	for _, rsvp := range r.rsvps {
+		if rsvp.EventID != eventID || (status != "" && rsvp.Status != status) {
+			continue
+		}
+		rsvpCopy := *rsvp
+		results = append(results, &rsvpCopy)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		ti, tj := rsvpCreatedAt(results[i]), rsvpCreatedAt(results[j])
+		if ti.Equal(tj) {
+			return results[i].UserID < results[j].UserID
+		}
+		return ti.Before(tj)
+	})
+
+	return results, nil
+}
+
+// ListByUser returns a user's RSVPs across all events, optionally filtered
+// by status. An empty status returns all RSVPs. Results are sorted by
+// created_at ascending, then by event ID.
+func (r *InMemoryRSVPRepository) ListByUser(ctx context.Context, userID string, status string) ([]*RSVP, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]*RSVP, 0)
 	for _, rsvp := range r.rsvps {
-		if rsvp.EventID == eventID {
-			switch rsvp.Status {
-			case "going":
-				counts.Going++
-			case "maybe":
-				counts.Maybe++
-			}
+		if rsvp.UserID != userID || (status != "" && rsvp.Status != status) {
+			continue
 		}
+		rsvpCopy := *rsvp
+		results = append(results, &rsvpCopy)
 	}
 
-	return counts, nil
+	sort.Slice(results, func(i, j int) bool {
+		ti, tj := rsvpCreatedAt(results[i]), rsvpCreatedAt(results[j])
+		if ti.Equal(tj) {
+			return results[i].EventID < results[j].EventID
+		}
+		return ti.Before(tj)
+	})
+
+	return results, nil
+}
+
+// rsvpCreatedAt returns the RSVP creation time, or the zero time if unset.
+func rsvpCreatedAt(rsvp *RSVP) time.Time {
+	if rsvp.CreatedAt == nil {
+		return time.Time{}
+	}
+	return *rsvp.CreatedAt
 }
 
 // GetCountsForEvents returns a map of event IDs to their RSVP counts.
 // This is a batch operation to avoid N+1 queries.
-func (r *InMemoryRSVPRepository) GetCountsForEvents(eventIDs []string) (map[string]*RSVPCounts, error) {
+func (r *InMemoryRSVPRepository) GetCountsForEvents(ctx context.Context, eventIDs []string) (map[string]*RSVPCounts, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -757,7 +2042,7 @@ func (r *InMemoryRSVPRepository) GetCountsForEvents(eventIDs []string) (map[stri
 		}
 	}
 
-	// Count RSVPs for each event
+	// Count RSVPs for each event; rsvps holds one entry per user and event
 	for _, rsvp := range r.rsvps {
 		if eventIDSet[rsvp.EventID] {
 			counts := result[rsvp.EventID]
@@ -766,6 +2051,8 @@ func (r *InMemoryRSVPRepository) GetCountsForEvents(eventIDs []string) (map[stri
 				counts.Going++
 			case "maybe":
 				counts.Maybe++
+			case "waitlisted":
+				counts.Waitlisted++
 			}
 		}
 	}
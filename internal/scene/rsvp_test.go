@@ -1,7 +1,11 @@
 package scene
 
 import (
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestRSVPRepository_Upsert_Create(t *testing.T) {
@@ -14,12 +18,12 @@ func TestRSVPRepository_Upsert_Create(t *testing.T) {
 	}
 
 	// Create new RSVP
-	if err := repo.Upsert(rsvp); err != nil {
+	if err := repo.Upsert(t.Context(), rsvp); err != nil {
 		t.Fatalf("Upsert failed: %v", err)
 	}
 
 	// Retrieve and verify
-	stored, err := repo.GetByEventAndUser("event-1", "user-1")
+	stored, err := repo.GetByEventAndUser(t.Context(), "event-1", "user-1")
 	if err != nil {
 		t.Fatalf("GetByEventAndUser failed: %v", err)
 	}
@@ -44,18 +48,18 @@ func TestRSVPRepository_Upsert_Update(t *testing.T) {
 		UserID:  "user-1",
 		Status:  "maybe",
 	}
-	if err := repo.Upsert(rsvp); err != nil {
+	if err := repo.Upsert(t.Context(), rsvp); err != nil {
 		t.Fatalf("Initial Upsert failed: %v", err)
 	}
 
 	// Update to "going" status
 	rsvp.Status = "going"
-	if err := repo.Upsert(rsvp); err != nil {
+	if err := repo.Upsert(t.Context(), rsvp); err != nil {
 		t.Fatalf("Update Upsert failed: %v", err)
 	}
 
 	// Verify status was updated
-	stored, err := repo.GetByEventAndUser("event-1", "user-1")
+	stored, err := repo.GetByEventAndUser(t.Context(), "event-1", "user-1")
 	if err != nil {
 		t.Fatalf("GetByEventAndUser failed: %v", err)
 	}
@@ -75,17 +79,17 @@ func TestRSVPRepository_Upsert_Idempotent(t *testing.T) {
 	}
 
 	// First upsert
-	if err := repo.Upsert(rsvp); err != nil {
+	if err := repo.Upsert(t.Context(), rsvp); err != nil {
 		t.Fatalf("First Upsert failed: %v", err)
 	}
 
 	// Second upsert with same status - should be idempotent
-	if err := repo.Upsert(rsvp); err != nil {
+	if err := repo.Upsert(t.Context(), rsvp); err != nil {
 		t.Fatalf("Second Upsert failed: %v", err)
 	}
 
 	// Verify only one RSVP exists
-	stored, err := repo.GetByEventAndUser("event-1", "user-1")
+	stored, err := repo.GetByEventAndUser(t.Context(), "event-1", "user-1")
 	if err != nil {
 		t.Fatalf("GetByEventAndUser failed: %v", err)
 	}
@@ -104,17 +108,17 @@ func TestRSVPRepository_Delete_Success(t *testing.T) {
 		UserID:  "user-1",
 		Status:  "going",
 	}
-	if err := repo.Upsert(rsvp); err != nil {
+	if err := repo.Upsert(t.Context(), rsvp); err != nil {
 		t.Fatalf("Upsert failed: %v", err)
 	}
 
 	// Delete RSVP
-	if err := repo.Delete("event-1", "user-1"); err != nil {
+	if err := repo.Delete(t.Context(), "event-1", "user-1"); err != nil {
 		t.Fatalf("Delete failed: %v", err)
 	}
 
 	// Verify RSVP is deleted
-	_, err := repo.GetByEventAndUser("event-1", "user-1")
+	_, err := repo.GetByEventAndUser(t.Context(), "event-1", "user-1")
 	if err != ErrRSVPNotFound {
 		t.Errorf("Expected ErrRSVPNotFound, got %v", err)
 	}
@@ -124,7 +128,7 @@ func TestRSVPRepository_Delete_NotFound(t *testing.T) {
 	repo := NewInMemoryRSVPRepository()
 
 	// Try to delete non-existent RSVP
-	err := repo.Delete("event-1", "user-1")
+	err := repo.Delete(t.Context(), "event-1", "user-1")
 	if err != ErrRSVPNotFound {
 		t.Errorf("Expected ErrRSVPNotFound, got %v", err)
 	}
@@ -134,7 +138,7 @@ func TestRSVPRepository_GetByEventAndUser_NotFound(t *testing.T) {
 	repo := NewInMemoryRSVPRepository()
 
 	// Try to get non-existent RSVP
-	_, err := repo.GetByEventAndUser("event-1", "user-1")
+	_, err := repo.GetByEventAndUser(t.Context(), "event-1", "user-1")
 	if err != ErrRSVPNotFound {
 		t.Errorf("Expected ErrRSVPNotFound, got %v", err)
 	}
@@ -144,7 +148,7 @@ func TestRSVPRepository_GetCountsByEvent_Empty(t *testing.T) {
 	repo := NewInMemoryRSVPRepository()
 
 	// Get counts for event with no RSVPs
-	counts, err := repo.GetCountsByEvent("event-1")
+	counts, err := repo.GetCountsByEvent(t.Context(), "event-1")
 	if err != nil {
 		t.Fatalf("GetCountsByEvent failed: %v", err)
 	}
@@ -172,13 +176,13 @@ func TestRSVPRepository_GetCountsByEvent_Multiple(t *testing.T) {
 	}
 
 	for _, rsvp := range rsvps {
-		if err := repo.Upsert(rsvp); err != nil {
+		if err := repo.Upsert(t.Context(), rsvp); err != nil {
 			t.Fatalf("Upsert failed: %v", err)
 		}
 	}
 
 	// Get counts for event-1
-	counts, err := repo.GetCountsByEvent("event-1")
+	counts, err := repo.GetCountsByEvent(t.Context(), "event-1")
 	if err != nil {
 		t.Fatalf("GetCountsByEvent failed: %v", err)
 	}
@@ -202,18 +206,18 @@ func TestRSVPRepository_GetCountsByEvent_AfterDelete(t *testing.T) {
 	}
 
 	for _, rsvp := range rsvps {
-		if err := repo.Upsert(rsvp); err != nil {
+		if err := repo.Upsert(t.Context(), rsvp); err != nil {
 			t.Fatalf("Upsert failed: %v", err)
 		}
 	}
 
 	// Delete one RSVP
-	if err := repo.Delete("event-1", "user-1"); err != nil {
+	if err := repo.Delete(t.Context(), "event-1", "user-1"); err != nil {
 		t.Fatalf("Delete failed: %v", err)
 	}
 
 	// Get counts - should reflect deletion
-	counts, err := repo.GetCountsByEvent("event-1")
+	counts, err := repo.GetCountsByEvent(t.Context(), "event-1")
 	if err != nil {
 		t.Fatalf("GetCountsByEvent failed: %v", err)
 	}
@@ -237,7 +241,7 @@ func TestRSVPRepository_GetCountsByEvent_AfterStatusChange(t *testing.T) {
 	}
 
 	for _, rsvp := range rsvps {
-		if err := repo.Upsert(rsvp); err != nil {
+		if err := repo.Upsert(t.Context(), rsvp); err != nil {
 			t.Fatalf("Upsert failed: %v", err)
 		}
 	}
@@ -248,12 +252,12 @@ func TestRSVPRepository_GetCountsByEvent_AfterStatusChange(t *testing.T) {
 		UserID:  "user-1",
 		Status:  "maybe",
 	}
-	if err := repo.Upsert(updatedRSVP); err != nil {
+	if err := repo.Upsert(t.Context(), updatedRSVP); err != nil {
 		t.Fatalf("Upsert failed: %v", err)
 	}
 
 	// Get counts - should reflect status change
-	counts, err := repo.GetCountsByEvent("event-1")
+	counts, err := repo.GetCountsByEvent(t.Context(), "event-1")
 	if err != nil {
 		t.Fatalf("GetCountsByEvent failed: %v", err)
 	}
@@ -265,3 +269,370 @@ func TestRSVPRepository_GetCountsByEvent_AfterStatusChange(t *testing.T) {
 		t.Errorf("Expected Maybe count 2 after status change, got %d", counts.Maybe)
 	}
 }
+
+func TestRSVPRepository_ListByEvent(t *testing.T) {
+	repo := NewInMemoryRSVPRepository()
+
+	rsvps := []*RSVP{
+		{EventID: "event-1", UserID: "user-b", Status: "going"},
+		{EventID: "event-1", UserID: "user-a", Status: "maybe"},
+		{EventID: "event-1", UserID: "user-c", Status: "going"},
+		{EventID: "event-2", UserID: "user-d", Status: "going"},
+	}
+	for _, rsvp := range rsvps {
+		if err := repo.Upsert(t.Context(), rsvp); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+	}
+
+	all, err := repo.ListByEvent(t.Context(), "event-1", "")
+	if err != nil {
+		t.Fatalf("ListByEvent failed: %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("Expected 3 RSVPs, got %d", len(all))
+	}
+	for _, rsvp := range all {
+		if rsvp.EventID != "event-1" {
+			t.Errorf("Expected only event-1 RSVPs, got %s", rsvp.EventID)
+		}
+	}
+
+	going, err := repo.ListByEvent(t.Context(), "event-1", "going")
+	if err != nil {
+		t.Fatalf("ListByEvent failed: %v", err)
+	}
+	if len(going) != 2 {
+		t.Fatalf("Expected 2 going RSVPs, got %d", len(going))
+	}
+	for _, rsvp := range going {
+		if rsvp.Status != "going" {
+			t.Errorf("Expected status going, got %s", rsvp.Status)
+		}
+	}
+
+	empty, err := repo.ListByEvent(t.Context(), "event-3", "")
+	if err != nil {
+		t.Fatalf("ListByEvent failed: %v", err)
+	}
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("Expected empty non-nil slice, got %v", empty)
+	}
+}
+
+func TestRSVPRepository_ListByUser(t *testing.T) {
+	repo := NewInMemoryRSVPRepository()
+
+	rsvps := []*RSVP{
+		{EventID: "event-2", UserID: "user-a", Status: "going"},
+		{EventID: "event-1", UserID: "user-a", Status: "maybe"},
+		{EventID: "event-3", UserID: "user-a", Status: "going"},
+		{EventID: "event-1", UserID: "user-b", Status: "going"},
+	}
+	for _, rsvp := range rsvps {
+		if err := repo.Upsert(t.Context(), rsvp); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+	}
+
+	all, err := repo.ListByUser(t.Context(), "user-a", "")
+	if err != nil {
+		t.Fatalf("ListByUser failed: %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("Expected 3 RSVPs, got %d", len(all))
+	}
+	for _, rsvp := range all {
+		if rsvp.UserID != "user-a" {
+			t.Errorf("Expected only user-a RSVPs, got %s", rsvp.UserID)
+		}
+	}
+
+	going, err := repo.ListByUser(t.Context(), "user-a", "going")
+	if err != nil {
+		t.Fatalf("ListByUser failed: %v", err)
+	}
+	if len(going) != 2 {
+		t.Fatalf("Expected 2 going RSVPs, got %d", len(going))
+	}
+	for _, rsvp := range going {
+		if rsvp.Status != "going" {
+			t.Errorf("Expected status going, got %s", rsvp.Status)
+		}
+	}
+
+	empty, err := repo.ListByUser(t.Context(), "user-c", "")
+	if err != nil {
+		t.Fatalf("ListByUser failed: %v", err)
+	}
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("Expected empty non-nil slice, got %v", empty)
+	}
+}
+
+func TestRSVPRepository_History(t *testing.T) {
+	repo := NewInMemoryRSVPRepository()
+
+	for _, status := range []string{"maybe", "going", "going", "not_going"} {
+		if err := repo.Upsert(t.Context(), &RSVP{EventID: "event-1", UserID: "user-1", Status: status}); err != nil {
+			t.Fatalf("Upsert(%s) failed: %v", status, err)
+		}
+	}
+
+	history, err := repo.History(t.Context(), "event-1", "user-1")
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+
+	// The repeated "going" upsert is idempotent and must not add a row
+	want := []struct{ from, to string }{
+		{"", "maybe"},
+		{"maybe", "going"},
+		{"going", "not_going"},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("Expected %d history rows, got %d: %+v", len(want), len(history), history)
+	}
+	for i, w := range want {
+		if history[i].FromStatus != w.from || history[i].ToStatus != w.to {
+			t.Errorf("Row %d: expected %q -> %q, got %q -> %q", i, w.from, w.to, history[i].FromStatus, history[i].ToStatus)
+		}
+		if i > 0 && history[i].ChangedAt.Before(history[i-1].ChangedAt) {
+			t.Errorf("Row %d: history not in chronological order", i)
+		}
+	}
+
+	// Latest state is still served by GetByEventAndUser
+	stored, err := repo.GetByEventAndUser(t.Context(), "event-1", "user-1")
+	if err != nil {
+		t.Fatalf("GetByEventAndUser failed: %v", err)
+	}
+	if stored.Status != "not_going" {
+		t.Errorf("Expected latest status 'not_going', got %s", stored.Status)
+	}
+
+	// History is kept after the RSVP is deleted
+	if err := repo.Delete(t.Context(), "event-1", "user-1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	history, err = repo.History(t.Context(), "event-1", "user-1")
+	if err != nil {
+		t.Fatalf("History after delete failed: %v", err)
+	}
+	if len(history) != 3 {
+		t.Errorf("Expected history to survive delete, got %d rows", len(history))
+	}
+
+	empty, err := repo.History(t.Context(), "event-1", "user-2")
+	if err != nil {
+		t.Fatalf("History for unknown user failed: %v", err)
+	}
+	if len(empty) != 0 {
+		t.Errorf("Expected no history for unknown user, got %d rows", len(empty))
+	}
+}
+
+func TestRSVPRepository_UpsertWithCooldown(t *testing.T) {
+	repo := NewInMemoryRSVPRepository()
+	rsvp := func(status string) *RSVP {
+		return &RSVP{EventID: "event-1", UserID: "user-1", Status: status}
+	}
+
+	if err := repo.UpsertWithCooldown(t.Context(), rsvp("going"), time.Hour); err != nil {
+		t.Fatalf("first RSVP should not be throttled: %v", err)
+	}
+	if err := repo.UpsertWithCooldown(t.Context(), rsvp("going"), time.Hour); err != nil {
+		t.Errorf("same-status upsert should not be throttled: %v", err)
+	}
+	if err := repo.UpsertWithCooldown(t.Context(), rsvp("maybe"), time.Hour); !errors.Is(err, ErrRSVPChangeTooSoon) {
+		t.Errorf("expected ErrRSVPChangeTooSoon, got %v", err)
+	}
+
+	// Deleting and re-creating is still a change within the cooldown
+	if err := repo.Delete(t.Context(), "event-1", "user-1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := repo.UpsertWithCooldown(t.Context(), rsvp("going"), time.Hour); !errors.Is(err, ErrRSVPChangeTooSoon) {
+		t.Errorf("expected re-create to be throttled, got %v", err)
+	}
+
+	// A zero cooldown behaves like Upsert
+	if err := repo.UpsertWithCooldown(t.Context(), rsvp("maybe"), 0); err != nil {
+		t.Errorf("zero cooldown should not throttle: %v", err)
+	}
+
+	history, err := repo.History(t.Context(), "event-1", "user-1")
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if len(history) != 2 {
+		t.Errorf("expected rejected changes to leave no history, got %d rows: %+v", len(history), history)
+	}
+}
+
+func TestRSVPRepository_ConcurrentStatusChurn(t *testing.T) {
+	repo := NewInMemoryRSVPRepository()
+	const users = 20
+	const togglesPerUser = 50
+	statuses := []string{"going", "maybe", "waitlisted"}
+
+	var wg sync.WaitGroup
+	var throttled sync.Map
+	for u := 0; u < users; u++ {
+		userID := fmt.Sprintf("user-%d", u)
+		for g := 0; g < 3; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < togglesPerUser; i++ {
+					status := statuses[(g+i)%len(statuses)]
+					err := repo.UpsertWithCooldown(t.Context(), &RSVP{EventID: "event-1", UserID: userID, Status: status}, time.Hour)
+					if errors.Is(err, ErrRSVPChangeTooSoon) {
+						throttled.Store(userID, true)
+					} else if err != nil {
+						t.Errorf("UpsertWithCooldown failed: %v", err)
+					}
+				}
+			}(g)
+		}
+	}
+
+	// Read counts while writers are running; a user must never be counted twice
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	for reading := true; reading; {
+		select {
+		case <-done:
+			reading = false
+		default:
+		}
+		counts, err := repo.GetCountsByEvent(t.Context(), "event-1")
+		if err != nil {
+			t.Fatalf("GetCountsByEvent failed: %v", err)
+		}
+		if total := counts.Going + counts.Maybe + counts.Waitlisted; total > users {
+			t.Fatalf("expected at most %d counted users, got %d: %+v", users, total, counts)
+		}
+	}
+
+	counts, err := repo.GetCountsByEvent(t.Context(), "event-1")
+	if err != nil {
+		t.Fatalf("GetCountsByEvent failed: %v", err)
+	}
+	if total := counts.Going + counts.Maybe + counts.Waitlisted; total != users {
+		t.Errorf("expected %d counted users, got %d: %+v", users, total, counts)
+	}
+
+	// With an hour-long cooldown each user keeps only their first status
+	for u := 0; u < users; u++ {
+		userID := fmt.Sprintf("user-%d", u)
+		history, err := repo.History(t.Context(), "event-1", userID)
+		if err != nil {
+			t.Fatalf("History failed: %v", err)
+		}
+		if len(history) != 1 {
+			t.Errorf("%s: expected 1 history row, got %d", userID, len(history))
+		}
+		if _, ok := throttled.Load(userID); !ok {
+			t.Errorf("%s: expected status churn to be throttled", userID)
+		}
+	}
+}
+
+func TestRSVPRepository_GetCountsForEvents_MatchesPerEventCounts(t *testing.T) {
+	repo := NewInMemoryRSVPRepository()
+	seeds := []struct{ eventID, userID, status string }{
+		{"event-1", "user-1", "going"},
+		{"event-1", "user-2", "maybe"},
+		{"event-1", "user-3", "waitlisted"},
+		{"event-2", "user-1", "going"},
+		{"event-2", "user-2", "going"},
+		{"other", "user-1", "maybe"},
+	}
+	for _, sd := range seeds {
+		if err := repo.Upsert(t.Context(), &RSVP{EventID: sd.eventID, UserID: sd.userID, Status: sd.status}); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+	}
+
+	eventIDs := []string{"event-1", "event-2", "no-rsvps"}
+	bulk, err := repo.GetCountsForEvents(t.Context(), eventIDs)
+	if err != nil {
+		t.Fatalf("GetCountsForEvents failed: %v", err)
+	}
+	if len(bulk) != len(eventIDs) {
+		t.Fatalf("expected an entry per requested event, got %v", bulk)
+	}
+	for _, id := range eventIDs {
+		single, err := repo.GetCountsByEvent(t.Context(), id)
+		if err != nil {
+			t.Fatalf("GetCountsByEvent(%s) failed: %v", id, err)
+		}
+		if bulk[id] == nil || *bulk[id] != *single {
+			t.Errorf("%s: bulk counts %+v differ from per-event counts %+v", id, bulk[id], single)
+		}
+	}
+	if *bulk["no-rsvps"] != (RSVPCounts{}) {
+		t.Errorf("expected zero counts for event without RSVPs, got %+v", bulk["no-rsvps"])
+	}
+}
+
+func TestRSVPRepository_CheckIn(t *testing.T) {
+	repo := NewInMemoryRSVPRepository()
+	for _, rsvp := range []*RSVP{
+		{EventID: "event-1", UserID: "user-1", Status: "going"},
+		{EventID: "event-1", UserID: "user-2", Status: "maybe"},
+		{EventID: "event-2", UserID: "user-1", Status: "going"},
+	} {
+		if err := repo.Upsert(t.Context(), rsvp); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+	}
+
+	first, err := repo.CheckIn(t.Context(), "event-1", "user-1")
+	if err != nil {
+		t.Fatalf("CheckIn failed: %v", err)
+	}
+	if first.CheckedInAt == nil {
+		t.Fatal("expected CheckedInAt to be set")
+	}
+	again, err := repo.CheckIn(t.Context(), "event-1", "user-1")
+	if err != nil {
+		t.Fatalf("repeated CheckIn failed: %v", err)
+	}
+	if !again.CheckedInAt.Equal(*first.CheckedInAt) {
+		t.Errorf("expected repeated check-in to keep %v, got %v", first.CheckedInAt, again.CheckedInAt)
+	}
+
+	if _, err := repo.CheckIn(t.Context(), "event-1", "user-2"); err != ErrRSVPNotGoing {
+		t.Errorf("expected ErrRSVPNotGoing for a maybe RSVP, got %v", err)
+	}
+	if _, err := repo.CheckIn(t.Context(), "event-1", "user-3"); err != ErrRSVPNotFound {
+		t.Errorf("expected ErrRSVPNotFound, got %v", err)
+	}
+
+	// Status changes after check-in keep the check-in
+	if err := repo.Upsert(t.Context(), &RSVP{EventID: "event-1", UserID: "user-1", Status: "maybe"}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	stored, err := repo.GetByEventAndUser(t.Context(), "event-1", "user-1")
+	if err != nil {
+		t.Fatalf("GetByEventAndUser failed: %v", err)
+	}
+	if stored.CheckedInAt == nil {
+		t.Error("expected check-in to survive a status change")
+	}
+
+	for eventID, want := range map[string]int{"event-1": 1, "event-2": 0} {
+		count, err := repo.GetCheckinCount(t.Context(), eventID)
+		if err != nil {
+			t.Fatalf("GetCheckinCount(%s) failed: %v", eventID, err)
+		}
+		if count != want {
+			t.Errorf("%s: expected %d check-ins, got %d", eventID, want, count)
+		}
+	}
+}
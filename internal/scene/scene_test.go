@@ -1,7 +1,13 @@
 package scene
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestScene_EnforceLocationConsent(t *testing.T) {
@@ -122,7 +128,7 @@ func TestInMemorySceneRepository_Insert_WithoutConsent(t *testing.T) {
 		PrecisePoint: &Point{Lat: 40.7128, Lng: -74.0060},
 	}
 
-	err := repo.Insert(scene)
+	err := repo.Insert(t.Context(), scene)
 	if err != nil {
 		t.Fatalf("Insert() error = %v", err)
 	}
@@ -133,7 +139,7 @@ func TestInMemorySceneRepository_Insert_WithoutConsent(t *testing.T) {
 	}
 
 	// Retrieve and verify stored scene has nil precise point
-	stored, err := repo.GetByID("scene-1")
+	stored, err := repo.GetByID(t.Context(), "scene-1")
 	if err != nil {
 		t.Fatalf("GetByID() error = %v", err)
 	}
@@ -156,13 +162,13 @@ func TestInMemorySceneRepository_Insert_WithConsent(t *testing.T) {
 		PrecisePoint: &Point{Lat: 40.7128, Lng: -74.0060},
 	}
 
-	err := repo.Insert(scene)
+	err := repo.Insert(t.Context(), scene)
 	if err != nil {
 		t.Fatalf("Insert() error = %v", err)
 	}
 
 	// Retrieve and verify stored scene has precise point
-	stored, err := repo.GetByID("scene-2")
+	stored, err := repo.GetByID(t.Context(), "scene-2")
 	if err != nil {
 		t.Fatalf("GetByID() error = %v", err)
 	}
@@ -187,7 +193,7 @@ func TestInMemorySceneRepository_Update_WithoutConsent(t *testing.T) {
 		AllowPrecise: true,
 		PrecisePoint: &Point{Lat: 40.7128, Lng: -74.0060},
 	}
-	_ = repo.Insert(scene)
+	_ = repo.Insert(t.Context(), scene)
 
 	// Now update removing consent but keeping precise point in input
 	updatedScene := &Scene{
@@ -197,13 +203,13 @@ func TestInMemorySceneRepository_Update_WithoutConsent(t *testing.T) {
 		PrecisePoint: &Point{Lat: 40.7128, Lng: -74.0060}, // Still has point in input
 	}
 
-	err := repo.Update(updatedScene)
+	err := repo.Update(t.Context(), updatedScene)
 	if err != nil {
 		t.Fatalf("Update() error = %v", err)
 	}
 
 	// Retrieve and verify stored scene has nil precise point
-	stored, err := repo.GetByID("scene-3")
+	stored, err := repo.GetByID(t.Context(), "scene-3")
 	if err != nil {
 		t.Fatalf("GetByID() error = %v", err)
 	}
@@ -227,7 +233,7 @@ func TestInMemoryEventRepository_Insert_WithoutConsent(t *testing.T) {
 		PrecisePoint: &Point{Lat: 40.7128, Lng: -74.0060},
 	}
 
-	err := repo.Insert(event)
+	err := repo.Insert(t.Context(), event)
 	if err != nil {
 		t.Fatalf("Insert() error = %v", err)
 	}
@@ -238,7 +244,7 @@ func TestInMemoryEventRepository_Insert_WithoutConsent(t *testing.T) {
 	}
 
 	// Retrieve and verify stored event has nil precise point
-	stored, err := repo.GetByID("event-1")
+	stored, err := repo.GetByID(t.Context(), "event-1")
 	if err != nil {
 		t.Fatalf("GetByID() error = %v", err)
 	}
@@ -262,13 +268,13 @@ func TestInMemoryEventRepository_Insert_WithConsent(t *testing.T) {
 		PrecisePoint: &Point{Lat: 40.7128, Lng: -74.0060},
 	}
 
-	err := repo.Insert(event)
+	err := repo.Insert(t.Context(), event)
 	if err != nil {
 		t.Fatalf("Insert() error = %v", err)
 	}
 
 	// Retrieve and verify stored event has precise point
-	stored, err := repo.GetByID("event-2")
+	stored, err := repo.GetByID(t.Context(), "event-2")
 	if err != nil {
 		t.Fatalf("GetByID() error = %v", err)
 	}
@@ -294,7 +300,7 @@ func TestInMemoryEventRepository_Update_WithoutConsent(t *testing.T) {
 		AllowPrecise: true,
 		PrecisePoint: &Point{Lat: 40.7128, Lng: -74.0060},
 	}
-	_ = repo.Insert(event)
+	_ = repo.Insert(t.Context(), event)
 
 	// Now update removing consent but keeping precise point in input
 	updatedEvent := &Event{
@@ -305,13 +311,13 @@ func TestInMemoryEventRepository_Update_WithoutConsent(t *testing.T) {
 		PrecisePoint: &Point{Lat: 40.7128, Lng: -74.0060}, // Still has point in input
 	}
 
-	err := repo.Update(updatedEvent)
+	err := repo.Update(t.Context(), updatedEvent)
 	if err != nil {
 		t.Fatalf("Update() error = %v", err)
 	}
 
 	// Retrieve and verify stored event has nil precise point
-	stored, err := repo.GetByID("event-3")
+	stored, err := repo.GetByID(t.Context(), "event-3")
 	if err != nil {
 		t.Fatalf("GetByID() error = %v", err)
 	}
@@ -326,7 +332,7 @@ func TestInMemoryEventRepository_Update_WithoutConsent(t *testing.T) {
 func TestInMemorySceneRepository_GetByID_ReturnsNilForNonexistent(t *testing.T) {
 	repo := NewInMemorySceneRepository()
 
-	stored, err := repo.GetByID("nonexistent")
+	stored, err := repo.GetByID(t.Context(), "nonexistent")
 	if err != ErrSceneNotFound {
 		t.Fatalf("GetByID() error = %v, want ErrSceneNotFound", err)
 	}
@@ -338,7 +344,7 @@ func TestInMemorySceneRepository_GetByID_ReturnsNilForNonexistent(t *testing.T)
 func TestInMemoryEventRepository_GetByID_ReturnsNilForNonexistent(t *testing.T) {
 	repo := NewInMemoryEventRepository()
 
-	stored, err := repo.GetByID("nonexistent")
+	stored, err := repo.GetByID(t.Context(), "nonexistent")
 	if err != ErrEventNotFound {
 		t.Fatalf("GetByID() error = %v, want ErrEventNotFound", err)
 	}
@@ -359,7 +365,7 @@ func TestInMemorySceneRepository_Insert_DeepCopyProtection(t *testing.T) {
 		PrecisePoint: originalPoint,
 	}
 
-	err := repo.Insert(scene)
+	err := repo.Insert(t.Context(), scene)
 	if err != nil {
 		t.Fatalf("Insert() error = %v", err)
 	}
@@ -369,7 +375,7 @@ func TestInMemorySceneRepository_Insert_DeepCopyProtection(t *testing.T) {
 	originalPoint.Lng = 0.0
 
 	// Retrieve and verify stored scene was NOT affected by the modification
-	stored, err := repo.GetByID("scene-deep-copy")
+	stored, err := repo.GetByID(t.Context(), "scene-deep-copy")
 	if err != nil {
 		t.Fatalf("GetByID() error = %v", err)
 	}
@@ -384,6 +390,151 @@ func TestInMemorySceneRepository_Insert_DeepCopyProtection(t *testing.T) {
 	}
 }
 
+func TestInMemorySceneRepository_TagsAndPaletteCopied(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+
+	scene := &Scene{
+		ID:      "scene-tags-copy",
+		Name:    "Test Scene",
+		Tags:    []string{"techno"},
+		Palette: &Palette{Primary: "#000000"},
+	}
+	scene.SetPalette(ThemeDark, &Palette{Primary: "#111111"})
+	if err := repo.Insert(t.Context(), scene); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	scene.Tags[0] = "jazz"
+	scene.Palette.Primary = "#ffffff"
+	scene.Palettes[ThemeDark].Primary = "#ffffff"
+
+	stored, err := repo.GetByID(t.Context(), "scene-tags-copy")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	stored.Tags[0] = "house"
+	stored.Palette.Primary = "#ff0000"
+	stored.Palettes[ThemeDark].Primary = "#ff0000"
+
+	again, err := repo.GetByID(t.Context(), "scene-tags-copy")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if again.Tags[0] != "techno" || again.Palette.Primary != "#000000" {
+		t.Errorf("expected stored tags and palette to be unaffected, got %v and %+v", again.Tags, again.Palette)
+	}
+	if again.Palettes[ThemeDark].Primary != "#111111" {
+		t.Errorf("expected stored dark palette to be unaffected, got %+v", again.Palettes[ThemeDark])
+	}
+}
+
+func TestScene_SetPalette(t *testing.T) {
+	light := &Palette{Primary: "#000000"}
+	dark := &Palette{Primary: "#ffffff"}
+
+	// A scene saved before themes existed only has the legacy palette
+	scene := &Scene{ID: "scene-themes", Palette: light}
+	if got := scene.PaletteFor(ThemeLight); got != light {
+		t.Errorf("expected legacy palette as the light theme, got %+v", got)
+	}
+
+	scene.SetPalette(ThemeDark, dark)
+	if scene.Palette != light || scene.PaletteFor(ThemeLight) != light {
+		t.Errorf("expected light palette kept after setting dark, got %+v and %+v", scene.Palette, scene.PaletteFor(ThemeLight))
+	}
+	if scene.PaletteFor(ThemeDark) != dark {
+		t.Errorf("expected dark palette, got %+v", scene.PaletteFor(ThemeDark))
+	}
+
+	replacement := &Palette{Primary: "#ff0000"}
+	scene.SetPalette(ThemeLight, replacement)
+	if scene.Palette != replacement || scene.PaletteFor(ThemeDark) != dark {
+		t.Errorf("expected only the light palette replaced, got light %+v dark %+v", scene.Palette, scene.PaletteFor(ThemeDark))
+	}
+}
+
+func TestInMemorySceneRepository_ConcurrentAccess(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+	const writers = 20
+	const scenesPerWriter = 50
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			ownerDID := fmt.Sprintf("did:plc:owner-%d", w)
+			for i := 0; i < scenesPerWriter; i++ {
+				id := fmt.Sprintf("scene-%d-%d", w, i)
+				if err := repo.Insert(t.Context(), &Scene{
+					ID:            id,
+					Name:          id,
+					OwnerDID:      ownerDID,
+					CoarseGeohash: "dr5regw",
+					Tags:          []string{"techno"},
+					Visibility:    VisibilityPublic,
+				}); err != nil {
+					t.Errorf("Insert failed: %v", err)
+					return
+				}
+				if _, err := repo.GetByID(t.Context(), id); err != nil {
+					t.Errorf("GetByID(%s) failed: %v", id, err)
+				}
+				if _, err := repo.ExistsByOwnerAndName(t.Context(), ownerDID, id, ""); err != nil {
+					t.Errorf("ExistsByOwnerAndName failed: %v", err)
+				}
+				// Every other scene is deleted and restored while readers scan
+				if i%2 == 0 {
+					if err := repo.Delete(t.Context(), id); err != nil {
+						t.Errorf("Delete(%s) failed: %v", id, err)
+					}
+					if err := repo.Restore(t.Context(), id); err != nil {
+						t.Errorf("Restore(%s) failed: %v", id, err)
+					}
+				}
+			}
+		}(w)
+	}
+
+	// Readers exercise the scanning methods while writers run
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func(r int) {
+			defer wg.Done()
+			for i := 0; i < scenesPerWriter; i++ {
+				// Scenes may not exist yet or be mid delete/restore
+				_, err := repo.GetByID(t.Context(), fmt.Sprintf("scene-%d-%d", r, i))
+				if err != nil && err != ErrSceneNotFound && err != ErrSceneDeleted {
+					t.Errorf("GetByID failed: %v", err)
+				}
+				if _, _, err := repo.List(t.Context(), ListFilter{Tag: "techno"}); err != nil {
+					t.Errorf("List failed: %v", err)
+				}
+				if _, err := repo.FindByTags(t.Context(), []string{"techno"}, true, 0); err != nil {
+					t.Errorf("FindByTags failed: %v", err)
+				}
+				if _, err := repo.FindNearby(context.Background(), Point{Lat: 40.7128, Lng: -74.0060}, 1000, 10); err != nil {
+					t.Errorf("FindNearby failed: %v", err)
+				}
+				if _, err := repo.ListByOwner(t.Context(), "did:plc:owner-0"); err != nil {
+					t.Errorf("ListByOwner failed: %v", err)
+				}
+			}
+		}(r)
+	}
+	wg.Wait()
+
+	// No writes were lost
+	for w := 0; w < writers; w++ {
+		owned, err := repo.ListByOwner(t.Context(), fmt.Sprintf("did:plc:owner-%d", w))
+		if err != nil {
+			t.Fatalf("ListByOwner failed: %v", err)
+		}
+		if len(owned) != scenesPerWriter {
+			t.Errorf("owner %d: expected %d scenes, got %d", w, scenesPerWriter, len(owned))
+		}
+	}
+}
+
 func TestInMemoryEventRepository_Insert_DeepCopyProtection(t *testing.T) {
 	repo := NewInMemoryEventRepository()
 
@@ -397,7 +548,7 @@ func TestInMemoryEventRepository_Insert_DeepCopyProtection(t *testing.T) {
 		PrecisePoint: originalPoint,
 	}
 
-	err := repo.Insert(event)
+	err := repo.Insert(t.Context(), event)
 	if err != nil {
 		t.Fatalf("Insert() error = %v", err)
 	}
@@ -407,7 +558,7 @@ func TestInMemoryEventRepository_Insert_DeepCopyProtection(t *testing.T) {
 	originalPoint.Lng = 0.0
 
 	// Retrieve and verify stored event was NOT affected by the modification
-	stored, err := repo.GetByID("event-deep-copy")
+	stored, err := repo.GetByID(t.Context(), "event-deep-copy")
 	if err != nil {
 		t.Fatalf("GetByID() error = %v", err)
 	}
@@ -439,7 +590,7 @@ RecordDID:   strPtr(did),
 RecordRKey:  strPtr(rkey),
 }
 
-result, err := repo.Upsert(scene)
+result, err := repo.Upsert(t.Context(), scene)
 if err != nil {
 t.Fatalf("Upsert failed: %v", err)
 }
@@ -453,7 +604,7 @@ t.Error("Expected non-empty ID")
 }
 
 // Verify we can retrieve it
-retrieved, err := repo.GetByRecordKey(did, rkey)
+retrieved, err := repo.GetByRecordKey(t.Context(), did, rkey)
 if err != nil {
 t.Fatalf("GetByRecordKey failed: %v", err)
 }
@@ -477,7 +628,7 @@ RecordDID:   strPtr(did),
 RecordRKey:  strPtr(rkey),
 }
 
-result1, err := repo.Upsert(scene)
+result1, err := repo.Upsert(t.Context(), scene)
 if err != nil {
 t.Fatalf("First upsert failed: %v", err)
 }
@@ -495,7 +646,7 @@ RecordDID:   strPtr(did),
 RecordRKey:  strPtr(rkey),
 }
 
-result2, err := repo.Upsert(scene2)
+result2, err := repo.Upsert(t.Context(), scene2)
 if err != nil {
 t.Fatalf("Second upsert failed: %v", err)
 }
@@ -509,7 +660,7 @@ t.Errorf("Expected same ID, got %s and %s", result1.ID, result2.ID)
 }
 
 // Verify update was persisted
-retrieved, err := repo.GetByRecordKey(did, rkey)
+retrieved, err := repo.GetByRecordKey(t.Context(), did, rkey)
 if err != nil {
 t.Fatalf("GetByRecordKey failed: %v", err)
 }
@@ -533,13 +684,13 @@ RecordDID:    strPtr(did),
 RecordRKey:   strPtr(rkey),
 }
 
-result, err := repo.Upsert(scene)
+result, err := repo.Upsert(t.Context(), scene)
 if err != nil {
 t.Fatalf("Upsert failed: %v", err)
 }
 
 // Retrieve and verify consent was enforced
-retrieved, err := repo.GetByRecordKey(did, rkey)
+retrieved, err := repo.GetByRecordKey(t.Context(), did, rkey)
 if err != nil {
 t.Fatalf("GetByRecordKey failed: %v", err)
 }
@@ -557,7 +708,7 @@ RecordDID:    strPtr(did),
 RecordRKey:   strPtr(rkey),
 }
 
-result2, err := repo.Upsert(scene2)
+result2, err := repo.Upsert(t.Context(), scene2)
 if err != nil {
 t.Fatalf("Second upsert failed: %v", err)
 }
@@ -571,7 +722,7 @@ t.Error("Expected same ID for update")
 }
 
 // Verify precise point is now preserved
-retrieved2, err := repo.GetByRecordKey(did, rkey)
+retrieved2, err := repo.GetByRecordKey(t.Context(), did, rkey)
 if err != nil {
 t.Fatalf("GetByRecordKey failed: %v", err)
 }
@@ -595,13 +746,13 @@ RecordRKey:  strPtr(rkey),
 }
 
 // First upsert
-result1, err := repo.Upsert(scene)
+result1, err := repo.Upsert(t.Context(), scene)
 if err != nil {
 t.Fatalf("First upsert failed: %v", err)
 }
 
 // Second upsert with same content
-result2, err := repo.Upsert(scene)
+result2, err := repo.Upsert(t.Context(), scene)
 if err != nil {
 t.Fatalf("Second upsert failed: %v", err)
 }
@@ -630,7 +781,7 @@ RecordDID:    strPtr(did),
 RecordRKey:   strPtr(rkey),
 }
 
-result, err := repo.Upsert(event)
+result, err := repo.Upsert(t.Context(), event)
 if err != nil {
 t.Fatalf("Upsert failed: %v", err)
 }
@@ -644,7 +795,7 @@ t.Error("Expected non-empty ID")
 }
 
 // Verify we can retrieve it
-retrieved, err := repo.GetByRecordKey(did, rkey)
+retrieved, err := repo.GetByRecordKey(t.Context(), did, rkey)
 if err != nil {
 t.Fatalf("GetByRecordKey failed: %v", err)
 }
@@ -669,7 +820,7 @@ RecordDID:    strPtr(did),
 RecordRKey:   strPtr(rkey),
 }
 
-result1, err := repo.Upsert(event)
+result1, err := repo.Upsert(t.Context(), event)
 if err != nil {
 t.Fatalf("First upsert failed: %v", err)
 }
@@ -688,7 +839,7 @@ RecordDID:    strPtr(did),
 RecordRKey:   strPtr(rkey),
 }
 
-result2, err := repo.Upsert(event2)
+result2, err := repo.Upsert(t.Context(), event2)
 if err != nil {
 t.Fatalf("Second upsert failed: %v", err)
 }
@@ -702,7 +853,7 @@ t.Errorf("Expected same ID, got %s and %s", result1.ID, result2.ID)
 }
 
 // Verify update was persisted
-retrieved, err := repo.GetByRecordKey(did, rkey)
+retrieved, err := repo.GetByRecordKey(t.Context(), did, rkey)
 if err != nil {
 t.Fatalf("GetByRecordKey failed: %v", err)
 }
@@ -731,13 +882,13 @@ RecordDID:    strPtr(did),
 RecordRKey:   strPtr(rkey),
 }
 
-result, err := repo.Upsert(event)
+result, err := repo.Upsert(t.Context(), event)
 if err != nil {
 t.Fatalf("Upsert failed: %v", err)
 }
 
 // Retrieve and verify consent was enforced
-retrieved, err := repo.GetByRecordKey(did, rkey)
+retrieved, err := repo.GetByRecordKey(t.Context(), did, rkey)
 if err != nil {
 t.Fatalf("GetByRecordKey failed: %v", err)
 }
@@ -756,7 +907,7 @@ RecordDID:    strPtr(did),
 RecordRKey:   strPtr(rkey),
 }
 
-result2, err := repo.Upsert(event2)
+result2, err := repo.Upsert(t.Context(), event2)
 if err != nil {
 t.Fatalf("Second upsert failed: %v", err)
 }
@@ -770,7 +921,7 @@ t.Error("Expected same ID for update")
 }
 
 // Verify precise point is now preserved
-retrieved2, err := repo.GetByRecordKey(did, rkey)
+retrieved2, err := repo.GetByRecordKey(t.Context(), did, rkey)
 if err != nil {
 t.Fatalf("GetByRecordKey failed: %v", err)
 }
@@ -810,18 +961,18 @@ AllowPrecise:  false,
 }
 
 // Insert scenes
-if err := repo.Insert(scene1); err != nil {
+if err := repo.Insert(t.Context(), scene1); err != nil {
 t.Fatalf("Insert scene1 failed: %v", err)
 }
-if err := repo.Insert(scene2); err != nil {
+if err := repo.Insert(t.Context(), scene2); err != nil {
 t.Fatalf("Insert scene2 failed: %v", err)
 }
-if err := repo.Insert(scene3); err != nil {
+if err := repo.Insert(t.Context(), scene3); err != nil {
 t.Fatalf("Insert scene3 failed: %v", err)
 }
 
 // Test: List scenes for owner1
-scenes, err := repo.ListByOwner(owner1)
+scenes, err := repo.ListByOwner(t.Context(), owner1)
 if err != nil {
 t.Fatalf("ListByOwner failed: %v", err)
 }
@@ -830,7 +981,7 @@ t.Errorf("Expected 2 scenes for owner1, got %d", len(scenes))
 }
 
 // Test: List scenes for owner2
-scenes, err = repo.ListByOwner(owner2)
+scenes, err = repo.ListByOwner(t.Context(), owner2)
 if err != nil {
 t.Fatalf("ListByOwner failed: %v", err)
 }
@@ -839,7 +990,7 @@ t.Errorf("Expected 1 scene for owner2, got %d", len(scenes))
 }
 
 // Test: List scenes for owner with no scenes
-scenes, err = repo.ListByOwner("did:plc:noowner")
+scenes, err = repo.ListByOwner(t.Context(), "did:plc:noowner")
 if err != nil {
 t.Fatalf("ListByOwner failed: %v", err)
 }
@@ -869,20 +1020,20 @@ AllowPrecise:  false,
 }
 
 // Insert scenes
-if err := repo.Insert(scene1); err != nil {
+if err := repo.Insert(t.Context(), scene1); err != nil {
 t.Fatalf("Insert scene1 failed: %v", err)
 }
-if err := repo.Insert(scene2); err != nil {
+if err := repo.Insert(t.Context(), scene2); err != nil {
 t.Fatalf("Insert scene2 failed: %v", err)
 }
 
 // Delete scene1
-if err := repo.Delete("scene-1"); err != nil {
+if err := repo.Delete(t.Context(), "scene-1"); err != nil {
 t.Fatalf("Delete failed: %v", err)
 }
 
 // List scenes - should only return non-deleted scene
-scenes, err := repo.ListByOwner(owner)
+scenes, err := repo.ListByOwner(t.Context(), owner)
 if err != nil {
 t.Fatalf("ListByOwner failed: %v", err)
 }
@@ -921,14 +1072,14 @@ rsvps2 := []*RSVP{
 
 // Insert all RSVPs
 for _, rsvp := range append(rsvps1, rsvps2...) {
-if err := repo.Upsert(rsvp); err != nil {
+if err := repo.Upsert(t.Context(), rsvp); err != nil {
 t.Fatalf("Upsert failed: %v", err)
 }
 }
 
 // Batch query
 eventIDs := []string{event1ID, event2ID, event3ID}
-countsMap, err := repo.GetCountsForEvents(eventIDs)
+countsMap, err := repo.GetCountsForEvents(t.Context(), eventIDs)
 if err != nil {
 t.Fatalf("GetCountsForEvents failed: %v", err)
 }
@@ -974,7 +1125,7 @@ t.Errorf("Event 3: expected 0 maybe, got %d", counts.Maybe)
 func TestRSVPRepository_GetCountsForEvents_EmptyInput(t *testing.T) {
 repo := NewInMemoryRSVPRepository()
 
-countsMap, err := repo.GetCountsForEvents([]string{})
+countsMap, err := repo.GetCountsForEvents(t.Context(), []string{})
 if err != nil {
 t.Fatalf("GetCountsForEvents failed: %v", err)
 }
@@ -983,3 +1134,506 @@ if len(countsMap) != 0 {
 t.Errorf("Expected empty map for empty input, got %d entries", len(countsMap))
 }
 }
+
+// TestSceneRepository_List_Filters tests tag, visibility, owner, and hidden filtering.
+func TestSceneRepository_List_Filters(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+
+	base := time.Now()
+	fixtures := []*Scene{
+		{ID: "techno-public", OwnerDID: "did:plc:a", Tags: []string{"Techno"}, Visibility: VisibilityPublic},
+		{ID: "jazz-public", OwnerDID: "did:plc:b", Tags: []string{"jazz"}, Visibility: VisibilityPublic},
+		{ID: "techno-private", OwnerDID: "did:plc:a", Tags: []string{"techno"}, Visibility: VisibilityMembersOnly},
+		{ID: "techno-hidden", OwnerDID: "did:plc:a", Tags: []string{"techno"}, Visibility: VisibilityHidden},
+		{ID: "techno-deleted", OwnerDID: "did:plc:a", Tags: []string{"techno"}, Visibility: VisibilityPublic},
+	}
+	for i, s := range fixtures {
+		createdAt := base.Add(time.Duration(i) * time.Minute)
+		s.Name = s.ID
+		s.CoarseGeohash = "dr5regw"
+		s.CreatedAt = &createdAt
+		if err := repo.Insert(t.Context(), s); err != nil {
+			t.Fatalf("Insert %s failed: %v", s.ID, err)
+		}
+	}
+	if err := repo.Delete(t.Context(), "techno-deleted"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter ListFilter
+		want   []string
+	}{
+		{"no filter excludes hidden and deleted", ListFilter{}, []string{"techno-private", "jazz-public", "techno-public"}},
+		{"owner sees own hidden scene", ListFilter{ViewerDID: "did:plc:a"}, []string{"techno-hidden", "techno-private", "jazz-public", "techno-public"}},
+		{"tag is case-insensitive", ListFilter{Tag: "TECHNO"}, []string{"techno-private", "techno-public"}},
+		{"visibility filter", ListFilter{Visibility: VisibilityPublic}, []string{"jazz-public", "techno-public"}},
+		{"owner filter", ListFilter{OwnerDID: "did:plc:b"}, []string{"jazz-public"}},
+		{"no matches", ListFilter{Tag: "ambient"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenes, cursor, err := repo.List(t.Context(), tt.filter)
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			if cursor != "" {
+				t.Errorf("Expected empty cursor, got %q", cursor)
+			}
+			if len(scenes) != len(tt.want) {
+				t.Fatalf("Expected %d scenes, got %d", len(tt.want), len(scenes))
+			}
+			for i, id := range tt.want {
+				if scenes[i].ID != id {
+					t.Errorf("Position %d: expected %s, got %s", i, id, scenes[i].ID)
+				}
+			}
+		})
+	}
+}
+
+// TestSceneRepository_List_Pagination tests cursor continuation across pages.
+func TestSceneRepository_List_Pagination(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+
+	base := time.Now()
+	for i := 0; i < 5; i++ {
+		createdAt := base.Add(time.Duration(i) * time.Minute)
+		s := &Scene{
+			ID:            fmt.Sprintf("scene-%d", i),
+			Name:          fmt.Sprintf("Scene %d", i),
+			OwnerDID:      "did:plc:owner",
+			CoarseGeohash: "dr5regw",
+			Visibility:    VisibilityPublic,
+			CreatedAt:     &createdAt,
+		}
+		if err := repo.Insert(t.Context(), s); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	var seen []string
+	cursor := ""
+	for page := 0; page < 5; page++ {
+		scenes, next, err := repo.List(t.Context(), ListFilter{Limit: 2, Cursor: cursor})
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		for _, s := range scenes {
+			seen = append(seen, s.ID)
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	want := []string{"scene-4", "scene-3", "scene-2", "scene-1", "scene-0"}
+	if len(seen) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, seen)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Errorf("Position %d: expected %s, got %s", i, want[i], seen[i])
+		}
+	}
+}
+
+// TestSceneRepository_List_InvalidCursor tests that malformed cursors are rejected.
+func TestSceneRepository_List_InvalidCursor(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+
+	for _, cursor := range []string{"garbage", "not-a-time|id"} {
+		if _, _, err := repo.List(t.Context(), ListFilter{Cursor: cursor}); err != ErrInvalidCursor {
+			t.Errorf("List(cursor=%q) error = %v, want ErrInvalidCursor", cursor, err)
+		}
+	}
+}
+
+// TestPalette_JSONRoundTrip verifies that all palette colors survive marshaling
+// as part of a stored scene.
+func TestPalette_JSONRoundTrip(t *testing.T) {
+	original := Scene{
+		ID:            "palette-scene",
+		Name:          "Palette Scene",
+		OwnerDID:      "did:plc:owner",
+		CoarseGeohash: "dr5regw",
+		Palette: &Palette{
+			Primary:    "#ff0000",
+			Secondary:  "#00ff00",
+			Accent:     "#0000ff",
+			Background: "#ffffff",
+			Text:       "#000000",
+		},
+	}
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded Scene
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if decoded.Palette == nil {
+		t.Fatal("Expected palette to survive round-trip")
+	}
+	if *decoded.Palette != *original.Palette {
+		t.Errorf("Palette mismatch after round-trip: got %+v, want %+v", *decoded.Palette, *original.Palette)
+	}
+
+	// Verify JSON field names match the handler contract
+	var raw struct {
+		Palette map[string]string `json:"palette"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Unmarshal raw palette failed: %v", err)
+	}
+	for _, field := range []string{"primary", "secondary", "accent", "background", "text"} {
+		if raw.Palette[field] == "" {
+			t.Errorf("Expected JSON field %q to be present", field)
+		}
+	}
+}
+
+func TestInMemoryEventRepository_DeleteAndRestoreBySceneID(t *testing.T) {
+	repo := NewInMemoryEventRepository()
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	events := []*Event{
+		{ID: "event-1", SceneID: "scene-1", Title: "One", StartsAt: now},
+		{ID: "event-2", SceneID: "scene-1", Title: "Two", StartsAt: now},
+		{ID: "event-gone", SceneID: "scene-1", Title: "Already deleted", StartsAt: now, DeletedAt: &earlier},
+		{ID: "event-other", SceneID: "scene-2", Title: "Other scene", StartsAt: now},
+	}
+	for _, e := range events {
+		if err := repo.Insert(t.Context(), e); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	count, err := repo.DeleteBySceneID(t.Context(), "scene-1")
+	if err != nil {
+		t.Fatalf("DeleteBySceneID failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 events deleted, got %d", count)
+	}
+	for _, id := range []string{"event-1", "event-2"} {
+		if _, err := repo.GetByID(t.Context(), id); err != ErrEventNotFound {
+			t.Errorf("expected ErrEventNotFound for %s, got %v", id, err)
+		}
+	}
+	if listed, _ := repo.ListBySceneID(t.Context(), "scene-1", EventFilter{}); len(listed) != 0 {
+		t.Errorf("expected no events listed for deleted scene, got %d", len(listed))
+	}
+	if _, err := repo.GetByID(t.Context(), "event-other"); err != nil {
+		t.Errorf("expected other scene's event to remain, got %v", err)
+	}
+
+	// Deleting again finds nothing left to delete
+	if count, _ := repo.DeleteBySceneID(t.Context(), "scene-1"); count != 0 {
+		t.Errorf("expected 0 events deleted on second call, got %d", count)
+	}
+
+	// Restore brings back only the events the cascade deleted
+	count, err = repo.RestoreBySceneID(t.Context(), "scene-1")
+	if err != nil {
+		t.Fatalf("RestoreBySceneID failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 events restored, got %d", count)
+	}
+	if listed, _ := repo.ListBySceneID(t.Context(), "scene-1", EventFilter{}); len(listed) != 2 {
+		t.Errorf("expected 2 events listed after restore, got %d", len(listed))
+	}
+	if _, err := repo.GetByID(t.Context(), "event-gone"); err != ErrEventNotFound {
+		t.Errorf("expected previously deleted event to stay deleted, got %v", err)
+	}
+}
+
+// TestInMemoryEventRepository_ConcurrentCascadeAndRead tests that reading
+// events while their scene is cascade-deleted and restored doesn't race.
+// Run with -race.
+func TestInMemoryEventRepository_ConcurrentCascadeAndRead(t *testing.T) {
+	repo := NewInMemoryEventRepository()
+	const eventCount = 20
+	for i := 0; i < eventCount; i++ {
+		if err := repo.Insert(t.Context(), &Event{ID: fmt.Sprintf("event-%d", i), SceneID: "scene-1", Title: "Show", StartsAt: time.Now()}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			if _, err := repo.DeleteBySceneID(t.Context(), "scene-1"); err != nil {
+				t.Errorf("DeleteBySceneID failed: %v", err)
+			}
+			if _, err := repo.RestoreBySceneID(t.Context(), "scene-1"); err != nil {
+				t.Errorf("RestoreBySceneID failed: %v", err)
+			}
+		}
+	}()
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				// Events may be mid delete/restore
+				_, err := repo.GetByID(t.Context(), fmt.Sprintf("event-%d", i%eventCount))
+				if err != nil && err != ErrEventNotFound {
+					t.Errorf("GetByID failed: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestInMemoryEventRepository_Delete(t *testing.T) {
+	repo := NewInMemoryEventRepository()
+	now := time.Now()
+	for _, e := range []*Event{
+		{ID: "event-1", SceneID: "scene-1", Title: "One", StartsAt: now},
+		{ID: "event-2", SceneID: "scene-1", Title: "Two", StartsAt: now},
+	} {
+		if err := repo.Insert(t.Context(), e); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	if err := repo.Delete(t.Context(), "event-1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := repo.GetByID(t.Context(), "event-1"); err != ErrEventNotFound {
+		t.Errorf("expected ErrEventNotFound after delete, got %v", err)
+	}
+	if err := repo.Delete(t.Context(), "event-1"); err != ErrEventNotFound {
+		t.Errorf("expected ErrEventNotFound on second delete, got %v", err)
+	}
+	if err := repo.Delete(t.Context(), "missing"); err != ErrEventNotFound {
+		t.Errorf("expected ErrEventNotFound for missing event, got %v", err)
+	}
+
+	// Restoring the scene only brings back cascade-deleted events
+	if _, err := repo.DeleteBySceneID(t.Context(), "scene-1"); err != nil {
+		t.Fatalf("DeleteBySceneID failed: %v", err)
+	}
+	if _, err := repo.RestoreBySceneID(t.Context(), "scene-1"); err != nil {
+		t.Fatalf("RestoreBySceneID failed: %v", err)
+	}
+	if _, err := repo.GetByID(t.Context(), "event-1"); err != ErrEventNotFound {
+		t.Errorf("expected individually deleted event to stay deleted, got %v", err)
+	}
+	if _, err := repo.GetByID(t.Context(), "event-2"); err != nil {
+		t.Errorf("expected cascade-deleted event to be restored, got %v", err)
+	}
+}
+
+func TestInMemorySceneRepository_Restore(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+	if err := repo.Insert(t.Context(), &Scene{ID: "scene-1", Name: "Scene", OwnerDID: "did:plc:owner"}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if err := repo.Delete(t.Context(), "scene-1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	if err := repo.Restore(t.Context(), "scene-1"); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if _, err := repo.GetByID(t.Context(), "scene-1"); err != nil {
+		t.Errorf("expected restored scene to be retrievable, got %v", err)
+	}
+
+	// Idempotent on a live scene
+	if err := repo.Restore(t.Context(), "scene-1"); err != nil {
+		t.Errorf("expected Restore on live scene to succeed, got %v", err)
+	}
+	if err := repo.Restore(t.Context(), "missing"); err != ErrSceneNotFound {
+		t.Errorf("expected ErrSceneNotFound, got %v", err)
+	}
+}
+
+func TestInMemorySceneRepository_Update_VersionConflict(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+	if err := repo.Insert(t.Context(), &Scene{ID: "scene-1", Name: "Scene", OwnerDID: "did:plc:owner"}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	first, _ := repo.GetByID(t.Context(), "scene-1")
+	second, _ := repo.GetByID(t.Context(), "scene-1")
+	if first.Version != 1 {
+		t.Fatalf("expected inserted version 1, got %d", first.Version)
+	}
+
+	first.Name = "First"
+	if err := repo.Update(t.Context(), first); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	second.Name = "Second"
+	if err := repo.Update(t.Context(), second); err != ErrVersionConflict {
+		t.Fatalf("expected ErrVersionConflict for stale write, got %v", err)
+	}
+
+	stored, _ := repo.GetByID(t.Context(), "scene-1")
+	if stored.Name != "First" || stored.Version != 2 {
+		t.Errorf("expected name First at version 2, got %s at version %d", stored.Name, stored.Version)
+	}
+
+	// A zero version overwrites unconditionally
+	if err := repo.Update(t.Context(), &Scene{ID: "scene-1", Name: "Blind", OwnerDID: "did:plc:owner"}); err != nil {
+		t.Fatalf("unconditional Update failed: %v", err)
+	}
+	stored, _ = repo.GetByID(t.Context(), "scene-1")
+	if stored.Version != 3 {
+		t.Errorf("expected version 3 after unconditional write, got %d", stored.Version)
+	}
+}
+
+func TestInMemorySceneRepository_Snapshot(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+	events := NewInMemoryEventRepository()
+	repo.SetCascade(events, nil)
+
+	if err := repo.Insert(t.Context(), &Scene{ID: "scene-1", Name: "Scene", OwnerDID: "did:plc:owner"}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	start := time.Now().Add(time.Hour)
+	for _, e := range []*Event{
+		{ID: "later", SceneID: "scene-1", StartsAt: start.Add(time.Hour)},
+		{ID: "sooner", SceneID: "scene-1", StartsAt: start},
+		{ID: "deleted", SceneID: "scene-1", StartsAt: start},
+		{ID: "other", SceneID: "scene-2", StartsAt: start},
+	} {
+		if err := events.Insert(t.Context(), e); err != nil {
+			t.Fatalf("Insert event failed: %v", err)
+		}
+	}
+	if err := events.Delete(t.Context(), "deleted"); err != nil {
+		t.Fatalf("Delete event failed: %v", err)
+	}
+
+	written := make(chan struct{})
+	err := repo.Snapshot(t.Context(), "scene-1", func(s *Scene, evts []*Event) error {
+		if s.ID != "scene-1" {
+			t.Errorf("expected scene-1, got %s", s.ID)
+		}
+		if len(evts) != 2 || evts[0].ID != "sooner" || evts[1].ID != "later" {
+			t.Errorf("expected events [sooner later], got %v", evts)
+		}
+
+		// Writes wait until fn returns
+		go func() {
+			_ = repo.Update(t.Context(), &Scene{ID: "scene-1", Name: "Renamed", OwnerDID: "did:plc:owner"})
+			close(written)
+		}()
+		select {
+		case <-written:
+			t.Error("expected Update to block during the snapshot")
+		case <-time.After(20 * time.Millisecond):
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	<-written
+
+	wantErr := errors.New("export failed")
+	if err := repo.Snapshot(t.Context(), "scene-1", func(*Scene, []*Event) error { return wantErr }); err != wantErr {
+		t.Errorf("expected fn error returned, got %v", err)
+	}
+	if err := repo.Snapshot(t.Context(), "missing", func(*Scene, []*Event) error { return nil }); err != ErrSceneNotFound {
+		t.Errorf("expected ErrSceneNotFound, got %v", err)
+	}
+	if err := repo.Delete(t.Context(), "scene-1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := repo.Snapshot(t.Context(), "scene-1", func(*Scene, []*Event) error { return nil }); err != ErrSceneDeleted {
+		t.Errorf("expected ErrSceneDeleted, got %v", err)
+	}
+}
+
+func TestInMemorySceneRepository_ExistsByOwnerAndName_Update(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+	for _, s := range []*Scene{
+		{ID: "live", Name: "Live Scene", OwnerDID: "did:plc:owner"},
+		{ID: "deleted", Name: "Deleted Scene", OwnerDID: "did:plc:owner"},
+		{ID: "renaming", Name: "My Scene", OwnerDID: "did:plc:owner"},
+		{ID: "other-owner", Name: "Theirs", OwnerDID: "did:plc:other"},
+	} {
+		if err := repo.Insert(t.Context(), s); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+	if err := repo.Delete(t.Context(), "deleted"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		newName string
+		want    bool
+	}{
+		{"collides with a live scene", "Live Scene", true},
+		{"collides with a live scene in another case", "LIVE SCENE", true},
+		{"collides with a deleted scene", "Deleted Scene", false},
+		{"keeps its own name", "My Scene", false},
+		{"re-cases its own name", "my scene", false},
+		{"matches another owner's scene", "Theirs", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exists, err := repo.ExistsByOwnerAndName(t.Context(), "did:plc:owner", tt.newName, "renaming")
+			if err != nil {
+				t.Fatalf("ExistsByOwnerAndName failed: %v", err)
+			}
+			if exists != tt.want {
+				t.Errorf("ExistsByOwnerAndName(%q) = %v, want %v", tt.newName, exists, tt.want)
+			}
+		})
+	}
+
+	// Excluding a scene doesn't hide a second live scene with the same name
+	if err := repo.Insert(t.Context(), &Scene{ID: "twin", Name: "My Scene", OwnerDID: "did:plc:owner"}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if exists, _ := repo.ExistsByOwnerAndName(t.Context(), "did:plc:owner", "My Scene", "renaming"); !exists {
+		t.Error("expected the other live scene with the name to be found")
+	}
+}
+
+// TestRepositories_CanceledContext tests that repository calls fail with the
+// context's error, leaving storage untouched, once the context is canceled.
+func TestRepositories_CanceledContext(t *testing.T) {
+	sceneRepo := NewInMemorySceneRepository()
+	eventRepo := NewInMemoryEventRepository()
+	rsvpRepo := NewInMemoryRSVPRepository()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := sceneRepo.Insert(ctx, &Scene{ID: "scene", Name: "Scene", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw"}); err != context.Canceled {
+		t.Errorf("scene Insert: expected context.Canceled, got %v", err)
+	}
+	if _, err := sceneRepo.GetByID(context.Background(), "scene"); err != ErrSceneNotFound {
+		t.Errorf("expected canceled insert not to store the scene, got %v", err)
+	}
+	if _, err := eventRepo.GetByID(ctx, "event"); err != context.Canceled {
+		t.Errorf("event GetByID: expected context.Canceled, got %v", err)
+	}
+	if err := rsvpRepo.Upsert(ctx, &RSVP{EventID: "event", UserID: "did:plc:user", Status: "going"}); err != context.Canceled {
+		t.Errorf("RSVP Upsert: expected context.Canceled, got %v", err)
+	}
+}
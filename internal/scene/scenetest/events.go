@@ -0,0 +1,333 @@
+package scenetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// TestEventRepository runs the EventRepository conformance suite.
+// newRepo must return a fresh, empty repository on every call.
+func TestEventRepository(t *testing.T, newRepo func(t *testing.T) scene.EventRepository) {
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	t.Run("InsertAndGetByID", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		if err := repo.Insert(ctx, newEvent("event-1", "scene-1", start)); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "event-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.SceneID != "scene-1" || !got.StartsAt.Equal(start) {
+			t.Errorf("GetByID() = scene %q at %v, want scene-1 at %v", got.SceneID, got.StartsAt, start)
+		}
+		if _, err := repo.GetByID(ctx, "missing"); !errors.Is(err, scene.ErrEventNotFound) {
+			t.Errorf("expected ErrEventNotFound, got %v", err)
+		}
+	})
+
+	t.Run("EnforcesLocationConsent", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		e := newEvent("event-1", "scene-1", start)
+		e.PrecisePoint = &scene.Point{Lat: 40.7128, Lng: -74.0060}
+		if err := repo.Insert(ctx, e); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+		got, err := repo.GetByID(ctx, "event-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.PrecisePoint != nil {
+			t.Errorf("Insert() stored PrecisePoint %+v without consent", got.PrecisePoint)
+		}
+
+		got.PrecisePoint = &scene.Point{Lat: 40.7128, Lng: -74.0060}
+		if err := repo.Update(ctx, got); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		got, err = repo.GetByID(ctx, "event-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.PrecisePoint != nil {
+			t.Errorf("Update() stored PrecisePoint %+v without consent", got.PrecisePoint)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		if err := repo.Insert(ctx, newEvent("event-1", "scene-1", start)); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+		if err := repo.Delete(ctx, "event-1"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.GetByID(ctx, "event-1"); !errors.Is(err, scene.ErrEventNotFound) {
+			t.Errorf("expected ErrEventNotFound after delete, got %v", err)
+		}
+		if err := repo.Delete(ctx, "event-1"); !errors.Is(err, scene.ErrEventNotFound) {
+			t.Errorf("expected ErrEventNotFound deleting twice, got %v", err)
+		}
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		if err := repo.Insert(ctx, newEvent("event-1", "scene-1", start)); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+		if err := repo.Cancel(ctx, "event-1", strPtr("venue flooded")); err != nil {
+			t.Fatalf("Cancel() error = %v", err)
+		}
+		got, err := repo.GetByID(ctx, "event-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Status != "cancelled" || got.CancelledAt == nil {
+			t.Fatalf("expected a cancelled event with cancelled_at, got status %q at %v", got.Status, got.CancelledAt)
+		}
+		if got.CancellationReason == nil || *got.CancellationReason != "venue flooded" {
+			t.Errorf("expected the cancellation reason to be stored, got %v", got.CancellationReason)
+		}
+
+		cancelledAt := *got.CancelledAt
+		if err := repo.Cancel(ctx, "event-1", nil); err != nil {
+			t.Errorf("expected cancelling twice to succeed, got %v", err)
+		}
+		got, err = repo.GetByID(ctx, "event-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if !got.CancelledAt.Equal(cancelledAt) {
+			t.Errorf("expected cancelling twice to keep cancelled_at %v, got %v", cancelledAt, got.CancelledAt)
+		}
+
+		if err := repo.Cancel(ctx, "missing", nil); !errors.Is(err, scene.ErrEventNotFound) {
+			t.Errorf("expected ErrEventNotFound cancelling a missing event, got %v", err)
+		}
+	})
+
+	t.Run("UpsertByRecordKey", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		e := newEvent("", "scene-1", start)
+		e.RecordDID = strPtr("did:plc:owner")
+		e.RecordRKey = strPtr("rkey-1")
+		first, err := repo.Upsert(ctx, e)
+		if err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+		if !first.Inserted || first.ID == "" {
+			t.Fatalf("expected first upsert to insert with an ID, got %+v", first)
+		}
+
+		e.Title = "Rescheduled"
+		second, err := repo.Upsert(ctx, e)
+		if err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+		if second.Inserted || second.ID != first.ID {
+			t.Errorf("expected second upsert to update %s, got %+v", first.ID, second)
+		}
+
+		got, err := repo.GetByRecordKey(ctx, "did:plc:owner", "rkey-1")
+		if err != nil {
+			t.Fatalf("GetByRecordKey() error = %v", err)
+		}
+		if got.ID != first.ID || got.Title != "Rescheduled" {
+			t.Errorf("GetByRecordKey() = %s %q, want %s %q", got.ID, got.Title, first.ID, "Rescheduled")
+		}
+		if _, err := repo.GetByRecordKey(ctx, "did:plc:owner", "missing"); !errors.Is(err, scene.ErrEventNotFound) {
+			t.Errorf("expected ErrEventNotFound for an unknown record key, got %v", err)
+		}
+	})
+
+	t.Run("ListBySceneID", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		for _, e := range []*scene.Event{
+			newEvent("event-late", "scene-1", start.Add(2*time.Hour)),
+			newEvent("event-early", "scene-1", start),
+			newEvent("event-deleted", "scene-1", start.Add(time.Hour)),
+			newEvent("event-other", "scene-2", start),
+		} {
+			if err := repo.Insert(ctx, e); err != nil {
+				t.Fatalf("Insert() error = %v", err)
+			}
+		}
+		if err := repo.Delete(ctx, "event-deleted"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		got, err := repo.ListBySceneID(ctx, "scene-1", scene.EventFilter{})
+		if err != nil {
+			t.Fatalf("ListBySceneID() error = %v", err)
+		}
+		if ids := eventIDs(got); len(ids) != 2 || ids[0] != "event-early" || ids[1] != "event-late" {
+			t.Errorf("expected [event-early event-late], got %v", ids)
+		}
+	})
+
+	t.Run("ListBySeries", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		for i, id := range []string{"event-2", "event-1", "event-3"} {
+			e := newEvent(id, "scene-1", start.Add(time.Duration(2-i)*time.Hour))
+			if id != "event-3" {
+				e.SeriesID = strPtr("series-1")
+			}
+			if err := repo.Insert(ctx, e); err != nil {
+				t.Fatalf("Insert() error = %v", err)
+			}
+		}
+
+		got, err := repo.ListBySeries(ctx, "series-1")
+		if err != nil {
+			t.Fatalf("ListBySeries() error = %v", err)
+		}
+		if ids := eventIDs(got); len(ids) != 2 || ids[0] != "event-1" || ids[1] != "event-2" {
+			t.Errorf("expected [event-1 event-2], got %v", ids)
+		}
+	})
+
+	t.Run("DeleteAndRestoreBySceneID", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		for _, id := range []string{"event-1", "event-2", "event-3"} {
+			if err := repo.Insert(ctx, newEvent(id, "scene-1", start)); err != nil {
+				t.Fatalf("Insert() error = %v", err)
+			}
+		}
+		if err := repo.Delete(ctx, "event-3"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		deleted, err := repo.DeleteBySceneID(ctx, "scene-1")
+		if err != nil {
+			t.Fatalf("DeleteBySceneID() error = %v", err)
+		}
+		if deleted != 2 {
+			t.Errorf("expected 2 events deleted, got %d", deleted)
+		}
+		restored, err := repo.RestoreBySceneID(ctx, "scene-1")
+		if err != nil {
+			t.Fatalf("RestoreBySceneID() error = %v", err)
+		}
+		if restored != 2 {
+			t.Errorf("expected 2 events restored, got %d", restored)
+		}
+		if _, err := repo.GetByID(ctx, "event-3"); !errors.Is(err, scene.ErrEventNotFound) {
+			t.Errorf("expected an individually deleted event to stay deleted, got %v", err)
+		}
+	})
+
+	t.Run("CanceledContext", func(t *testing.T) {
+		repo := newRepo(t)
+		if err := repo.Insert(t.Context(), newEvent("event-1", "scene-1", start)); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+		ctx := canceledContext(t)
+
+		calls := map[string]func(context.Context) error{
+			"Insert": func(ctx context.Context) error {
+				return repo.Insert(ctx, newEvent("event-2", "scene-1", start))
+			},
+			"Update": func(ctx context.Context) error {
+				return repo.Update(ctx, newEvent("event-1", "scene-2", start))
+			},
+			"Upsert": func(ctx context.Context) error {
+				_, err := repo.Upsert(ctx, newEvent("", "scene-1", start))
+				return err
+			},
+			"GetByID": func(ctx context.Context) error {
+				_, err := repo.GetByID(ctx, "event-1")
+				return err
+			},
+			"GetByRecordKey": func(ctx context.Context) error {
+				_, err := repo.GetByRecordKey(ctx, "did:plc:owner", "rkey-1")
+				return err
+			},
+			"Cancel": func(ctx context.Context) error {
+				return repo.Cancel(ctx, "event-1", nil)
+			},
+			"SearchByBboxAndTime": func(ctx context.Context) error {
+				_, _, err := repo.SearchByBboxAndTime(ctx, -180, -90, 180, 90, start.Add(-time.Hour), start.Add(time.Hour), 10, "")
+				return err
+			},
+			"ListBySceneID": func(ctx context.Context) error {
+				_, err := repo.ListBySceneID(ctx, "scene-1", scene.EventFilter{})
+				return err
+			},
+			"ListUpcomingNear": func(ctx context.Context) error {
+				_, _, err := repo.ListUpcomingNear(ctx, scene.Point{Lat: 40.7128, Lng: -74.0060}, 1000, time.Now(), 10, "")
+				return err
+			},
+			"ListBySeries": func(ctx context.Context) error {
+				_, err := repo.ListBySeries(ctx, "series-1")
+				return err
+			},
+			"Delete": func(ctx context.Context) error {
+				return repo.Delete(ctx, "event-1")
+			},
+			"DeleteBySceneID": func(ctx context.Context) error {
+				_, err := repo.DeleteBySceneID(ctx, "scene-1")
+				return err
+			},
+			"RestoreBySceneID": func(ctx context.Context) error {
+				_, err := repo.RestoreBySceneID(ctx, "scene-1")
+				return err
+			},
+		}
+		for name, call := range calls {
+			if err := call(ctx); !errors.Is(err, context.Canceled) {
+				t.Errorf("%s: expected context.Canceled, got %v", name, err)
+			}
+		}
+
+		got, err := repo.GetByID(t.Context(), "event-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.SceneID != "scene-1" || got.Status == "cancelled" {
+			t.Errorf("expected canceled calls to leave the event untouched, got scene %q with status %q", got.SceneID, got.Status)
+		}
+	})
+}
+
+// newEvent returns a scheduled event without precise location consent.
+func newEvent(id, sceneID string, startsAt time.Time) *scene.Event {
+	return &scene.Event{
+		ID:            id,
+		SceneID:       sceneID,
+		Title:         "Late Show",
+		CoarseGeohash: "dr5reg",
+		Status:        "scheduled",
+		StartsAt:      startsAt,
+	}
+}
+
+// eventIDs returns the IDs of events, for error messages.
+func eventIDs(events []*scene.Event) []string {
+	ids := make([]string, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+	}
+	return ids
+}
@@ -0,0 +1,339 @@
+package scenetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// TestRSVPRepository runs the RSVPRepository conformance suite.
+// newRepo must return a fresh, empty repository on every call.
+func TestRSVPRepository(t *testing.T, newRepo func(t *testing.T) scene.RSVPRepository) {
+	t.Run("UpsertAndGet", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		if err := repo.Upsert(ctx, newRSVP("event-1", "user-1", "maybe")); err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+		if err := repo.Upsert(ctx, newRSVP("event-1", "user-1", "going")); err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+
+		got, err := repo.GetByEventAndUser(ctx, "event-1", "user-1")
+		if err != nil {
+			t.Fatalf("GetByEventAndUser() error = %v", err)
+		}
+		if got.Status != "going" {
+			t.Errorf("expected status going, got %q", got.Status)
+		}
+		if _, err := repo.GetByEventAndUser(ctx, "event-1", "user-2"); !errors.Is(err, scene.ErrRSVPNotFound) {
+			t.Errorf("expected ErrRSVPNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		if err := repo.Upsert(ctx, newRSVP("event-1", "user-1", "going")); err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+		if err := repo.Delete(ctx, "event-1", "user-1"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.GetByEventAndUser(ctx, "event-1", "user-1"); !errors.Is(err, scene.ErrRSVPNotFound) {
+			t.Errorf("expected ErrRSVPNotFound after delete, got %v", err)
+		}
+		if err := repo.Delete(ctx, "event-1", "user-1"); !errors.Is(err, scene.ErrRSVPNotFound) {
+			t.Errorf("expected ErrRSVPNotFound deleting twice, got %v", err)
+		}
+	})
+
+	t.Run("Counts", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		for _, rsvp := range []*scene.RSVP{
+			newRSVP("event-1", "user-1", "going"),
+			newRSVP("event-1", "user-2", "going"),
+			newRSVP("event-1", "user-3", "maybe"),
+			newRSVP("event-1", "user-4", "waitlisted"),
+			newRSVP("event-2", "user-1", "maybe"),
+			// A changed status is counted once, under the new status
+			newRSVP("event-1", "user-2", "maybe"),
+		} {
+			if err := repo.Upsert(ctx, rsvp); err != nil {
+				t.Fatalf("Upsert() error = %v", err)
+			}
+		}
+
+		counts, err := repo.GetCountsByEvent(ctx, "event-1")
+		if err != nil {
+			t.Fatalf("GetCountsByEvent() error = %v", err)
+		}
+		if want := (scene.RSVPCounts{Going: 1, Maybe: 2, Waitlisted: 1}); *counts != want {
+			t.Errorf("GetCountsByEvent() = %+v, want %+v", *counts, want)
+		}
+
+		batch, err := repo.GetCountsForEvents(ctx, []string{"event-1", "event-2"})
+		if err != nil {
+			t.Fatalf("GetCountsForEvents() error = %v", err)
+		}
+		if batch["event-1"] == nil || *batch["event-1"] != *counts {
+			t.Errorf("expected batch counts for event-1 to match %+v, got %+v", *counts, batch["event-1"])
+		}
+		if batch["event-2"] == nil || batch["event-2"].Maybe != 1 {
+			t.Errorf("expected 1 maybe for event-2, got %+v", batch["event-2"])
+		}
+	})
+
+	t.Run("ListByEventAndUser", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		for _, rsvp := range []*scene.RSVP{
+			newRSVP("event-1", "user-1", "going"),
+			newRSVP("event-1", "user-2", "maybe"),
+			newRSVP("event-2", "user-1", "maybe"),
+		} {
+			if err := repo.Upsert(ctx, rsvp); err != nil {
+				t.Fatalf("Upsert() error = %v", err)
+			}
+		}
+
+		all, err := repo.ListByEvent(ctx, "event-1", "")
+		if err != nil {
+			t.Fatalf("ListByEvent() error = %v", err)
+		}
+		if len(all) != 2 {
+			t.Errorf("expected 2 RSVPs for event-1, got %d", len(all))
+		}
+		going, err := repo.ListByEvent(ctx, "event-1", "going")
+		if err != nil {
+			t.Fatalf("ListByEvent() error = %v", err)
+		}
+		if len(going) != 1 || going[0].UserID != "user-1" {
+			t.Errorf("expected only user-1 going, got %+v", going)
+		}
+
+		mine, err := repo.ListByUser(ctx, "user-1", "maybe")
+		if err != nil {
+			t.Fatalf("ListByUser() error = %v", err)
+		}
+		if len(mine) != 1 || mine[0].EventID != "event-2" {
+			t.Errorf("expected user-1's maybe for event-2, got %+v", mine)
+		}
+	})
+
+	t.Run("CooldownAndHistory", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		if err := repo.UpsertWithCooldown(ctx, newRSVP("event-1", "user-1", "going"), time.Hour); err != nil {
+			t.Fatalf("UpsertWithCooldown() error = %v", err)
+		}
+		if err := repo.UpsertWithCooldown(ctx, newRSVP("event-1", "user-1", "going"), time.Hour); err != nil {
+			t.Errorf("expected a same-status upsert to bypass the cooldown, got %v", err)
+		}
+		if err := repo.UpsertWithCooldown(ctx, newRSVP("event-1", "user-1", "maybe"), time.Hour); !errors.Is(err, scene.ErrRSVPChangeTooSoon) {
+			t.Errorf("expected ErrRSVPChangeTooSoon, got %v", err)
+		}
+
+		if err := repo.Delete(ctx, "event-1", "user-1"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if err := repo.UpsertWithCooldown(ctx, newRSVP("event-1", "user-1", "maybe"), time.Hour); !errors.Is(err, scene.ErrRSVPChangeTooSoon) {
+			t.Errorf("expected the cooldown to survive delete, got %v", err)
+		}
+		if err := repo.UpsertWithCooldown(ctx, newRSVP("event-1", "user-1", "maybe"), 0); err != nil {
+			t.Fatalf("UpsertWithCooldown() error = %v", err)
+		}
+
+		history, err := repo.History(ctx, "event-1", "user-1")
+		if err != nil {
+			t.Fatalf("History() error = %v", err)
+		}
+		if len(history) != 2 || history[0].ToStatus != "going" || history[1].ToStatus != "maybe" {
+			t.Errorf("expected going then maybe in history, got %+v", history)
+		}
+	})
+
+	t.Run("CapacityAndWaitlist", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		for _, tc := range []struct{ user, want string }{
+			{"user-1", "going"},
+			{"user-2", "going"},
+			{"user-3", "waitlisted"},
+			{"user-1", "going"}, // a seated user keeps the seat
+		} {
+			stored, err := repo.UpsertWithCapacity(ctx, newRSVP("event-1", tc.user, "going"), 2, 0)
+			if err != nil {
+				t.Fatalf("UpsertWithCapacity() error = %v", err)
+			}
+			if stored.Status != tc.want || stored.UserID != tc.user || stored.CreatedAt == nil {
+				t.Errorf("expected %s stored as %s, got %+v", tc.user, tc.want, stored)
+			}
+		}
+		if stored, err := repo.UpsertWithCapacity(ctx, newRSVP("event-2", "user-3", "going"), 0, 0); err != nil || stored.Status != "going" {
+			t.Errorf("expected zero capacity to be unlimited, got %+v, %v", stored, err)
+		}
+
+		if n, err := repo.PromoteWaitlisted(ctx, "event-1", 2); err != nil || n != 0 {
+			t.Errorf("expected no promotion while full, got %d, %v", n, err)
+		}
+		if _, err := repo.UpsertWithCapacity(ctx, newRSVP("event-1", "user-1", "maybe"), 2, 0); err != nil {
+			t.Fatalf("UpsertWithCapacity() error = %v", err)
+		}
+		n, err := repo.PromoteWaitlisted(ctx, "event-1", 2)
+		if err != nil {
+			t.Fatalf("PromoteWaitlisted() error = %v", err)
+		}
+		if n != 1 {
+			t.Errorf("expected 1 promotion, got %d", n)
+		}
+		got, err := repo.GetByEventAndUser(ctx, "event-1", "user-3")
+		if err != nil {
+			t.Fatalf("GetByEventAndUser() error = %v", err)
+		}
+		if got.Status != "going" {
+			t.Errorf("expected user-3 promoted to going, got %q", got.Status)
+		}
+
+		// The cooldown applies to the status actually stored
+		if _, err := repo.UpsertWithCapacity(ctx, newRSVP("event-1", "user-4", "going"), 2, time.Hour); err != nil {
+			t.Fatalf("UpsertWithCapacity() error = %v", err)
+		}
+		if _, err := repo.UpsertWithCapacity(ctx, newRSVP("event-1", "user-4", "going"), 2, time.Hour); err != nil {
+			t.Errorf("expected a repeated waitlisted upsert to bypass the cooldown, got %v", err)
+		}
+		if _, err := repo.UpsertWithCapacity(ctx, newRSVP("event-1", "user-4", "maybe"), 2, time.Hour); !errors.Is(err, scene.ErrRSVPChangeTooSoon) {
+			t.Errorf("expected ErrRSVPChangeTooSoon, got %v", err)
+		}
+	})
+
+	t.Run("CheckIn", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		if err := repo.Upsert(ctx, newRSVP("event-1", "user-1", "going")); err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+		if err := repo.Upsert(ctx, newRSVP("event-1", "user-2", "maybe")); err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+
+		first, err := repo.CheckIn(ctx, "event-1", "user-1")
+		if err != nil {
+			t.Fatalf("CheckIn() error = %v", err)
+		}
+		if first.CheckedInAt == nil {
+			t.Fatal("expected CheckIn() to set checked_in_at")
+		}
+		again, err := repo.CheckIn(ctx, "event-1", "user-1")
+		if err != nil {
+			t.Fatalf("CheckIn() error = %v", err)
+		}
+		if again.CheckedInAt == nil || !again.CheckedInAt.Equal(*first.CheckedInAt) {
+			t.Errorf("expected checking in again to keep %v, got %v", *first.CheckedInAt, again.CheckedInAt)
+		}
+
+		if _, err := repo.CheckIn(ctx, "event-1", "user-2"); !errors.Is(err, scene.ErrRSVPNotGoing) {
+			t.Errorf("expected ErrRSVPNotGoing, got %v", err)
+		}
+		if _, err := repo.CheckIn(ctx, "event-1", "user-3"); !errors.Is(err, scene.ErrRSVPNotFound) {
+			t.Errorf("expected ErrRSVPNotFound, got %v", err)
+		}
+
+		count, err := repo.GetCheckinCount(ctx, "event-1")
+		if err != nil {
+			t.Fatalf("GetCheckinCount() error = %v", err)
+		}
+		if count != 1 {
+			t.Errorf("expected 1 check-in, got %d", count)
+		}
+	})
+
+	t.Run("CanceledContext", func(t *testing.T) {
+		repo := newRepo(t)
+		if err := repo.Upsert(t.Context(), newRSVP("event-1", "user-1", "going")); err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+		ctx := canceledContext(t)
+
+		calls := map[string]func(context.Context) error{
+			"Upsert": func(ctx context.Context) error {
+				return repo.Upsert(ctx, newRSVP("event-1", "user-1", "maybe"))
+			},
+			"UpsertWithCooldown": func(ctx context.Context) error {
+				return repo.UpsertWithCooldown(ctx, newRSVP("event-1", "user-1", "maybe"), 0)
+			},
+			"UpsertWithCapacity": func(ctx context.Context) error {
+				_, err := repo.UpsertWithCapacity(ctx, newRSVP("event-1", "user-1", "maybe"), 1, 0)
+				return err
+			},
+			"PromoteWaitlisted": func(ctx context.Context) error {
+				_, err := repo.PromoteWaitlisted(ctx, "event-1", 1)
+				return err
+			},
+			"Delete": func(ctx context.Context) error {
+				return repo.Delete(ctx, "event-1", "user-1")
+			},
+			"GetByEventAndUser": func(ctx context.Context) error {
+				_, err := repo.GetByEventAndUser(ctx, "event-1", "user-1")
+				return err
+			},
+			"GetCountsByEvent": func(ctx context.Context) error {
+				_, err := repo.GetCountsByEvent(ctx, "event-1")
+				return err
+			},
+			"GetCountsForEvents": func(ctx context.Context) error {
+				_, err := repo.GetCountsForEvents(ctx, []string{"event-1"})
+				return err
+			},
+			"ListByEvent": func(ctx context.Context) error {
+				_, err := repo.ListByEvent(ctx, "event-1", "")
+				return err
+			},
+			"ListByUser": func(ctx context.Context) error {
+				_, err := repo.ListByUser(ctx, "user-1", "")
+				return err
+			},
+			"History": func(ctx context.Context) error {
+				_, err := repo.History(ctx, "event-1", "user-1")
+				return err
+			},
+			"CheckIn": func(ctx context.Context) error {
+				_, err := repo.CheckIn(ctx, "event-1", "user-1")
+				return err
+			},
+			"GetCheckinCount": func(ctx context.Context) error {
+				_, err := repo.GetCheckinCount(ctx, "event-1")
+				return err
+			},
+		}
+		for name, call := range calls {
+			if err := call(ctx); !errors.Is(err, context.Canceled) {
+				t.Errorf("%s: expected context.Canceled, got %v", name, err)
+			}
+		}
+
+		got, err := repo.GetByEventAndUser(t.Context(), "event-1", "user-1")
+		if err != nil {
+			t.Fatalf("GetByEventAndUser() error = %v", err)
+		}
+		if got.Status != "going" || got.CheckedInAt != nil {
+			t.Errorf("expected canceled calls to leave the RSVP untouched, got %+v", got)
+		}
+	})
+}
+
+// newRSVP returns an RSVP for userID to eventID with the given status.
+func newRSVP(eventID, userID, status string) *scene.RSVP {
+	return &scene.RSVP{EventID: eventID, UserID: userID, Status: status}
+}
@@ -0,0 +1,488 @@
+package scenetest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// TestSceneRepository runs the SceneRepository conformance suite.
+// newRepo must return a fresh, empty repository on every call.
+func TestSceneRepository(t *testing.T, newRepo func(t *testing.T) scene.SceneRepository) {
+	t.Run("InsertAndGetByID", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		if err := repo.Insert(ctx, newScene("scene-1", "did:plc:owner", "Night Market")); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "scene-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Name != "Night Market" || got.OwnerDID != "did:plc:owner" {
+			t.Errorf("GetByID() = %q owned by %q, want %q owned by %q", got.Name, got.OwnerDID, "Night Market", "did:plc:owner")
+		}
+		if got.Version != 1 {
+			t.Errorf("expected version 1 after insert, got %d", got.Version)
+		}
+		if got.Slug == "" {
+			t.Error("expected insert to assign a slug")
+		}
+	})
+
+	t.Run("GetByIDNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+
+		if _, err := repo.GetByID(t.Context(), "missing"); !errors.Is(err, scene.ErrSceneNotFound) {
+			t.Errorf("expected ErrSceneNotFound, got %v", err)
+		}
+	})
+
+	t.Run("EnforcesLocationConsent", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		s := newScene("scene-1", "did:plc:owner", "Basement Show")
+		s.PrecisePoint = &scene.Point{Lat: 40.7128, Lng: -74.0060}
+		if err := repo.Insert(ctx, s); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+		got, err := repo.GetByID(ctx, "scene-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.PrecisePoint != nil {
+			t.Errorf("Insert() stored PrecisePoint %+v without consent", got.PrecisePoint)
+		}
+
+		got.PrecisePoint = &scene.Point{Lat: 40.7128, Lng: -74.0060}
+		if err := repo.Update(ctx, got); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		got, err = repo.GetByID(ctx, "scene-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.PrecisePoint != nil {
+			t.Errorf("Update() stored PrecisePoint %+v without consent", got.PrecisePoint)
+		}
+	})
+
+	t.Run("ReturnsCopies", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		s := newScene("scene-1", "did:plc:owner", "Zine Swap")
+		if err := repo.Insert(ctx, s); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+		s.Name = "changed after insert"
+
+		got, err := repo.GetByID(ctx, "scene-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		got.Tags[0] = "changed after get"
+
+		again, err := repo.GetByID(ctx, "scene-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if again.Name != "Zine Swap" || again.Tags[0] != "punk" {
+			t.Errorf("stored scene shares memory with caller: name %q, tags %v", again.Name, again.Tags)
+		}
+	})
+
+	t.Run("UpdateVersionConflict", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		if err := repo.Insert(ctx, newScene("scene-1", "did:plc:owner", "Warehouse")); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+		stale, err := repo.GetByID(ctx, "scene-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+
+		fresh := *stale
+		fresh.Description = "first writer"
+		if err := repo.Update(ctx, &fresh); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		stale.Description = "second writer"
+		if err := repo.Update(ctx, stale); !errors.Is(err, scene.ErrVersionConflict) {
+			t.Errorf("expected ErrVersionConflict for a stale version, got %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "scene-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Version != 2 || got.Description != "first writer" {
+			t.Errorf("expected version 2 from the first writer, got version %d with %q", got.Version, got.Description)
+		}
+
+		got.Version = 0
+		got.Description = "unconditional"
+		if err := repo.Update(ctx, got); err != nil {
+			t.Errorf("expected a zero version to overwrite unconditionally, got %v", err)
+		}
+	})
+
+	t.Run("DeleteAndRestore", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		if err := repo.Insert(ctx, newScene("scene-1", "did:plc:owner", "Open Mic")); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+		if err := repo.Delete(ctx, "scene-1"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.GetByID(ctx, "scene-1"); !errors.Is(err, scene.ErrSceneDeleted) {
+			t.Errorf("expected ErrSceneDeleted after delete, got %v", err)
+		}
+		if err := repo.Delete(ctx, "scene-1"); !errors.Is(err, scene.ErrSceneDeleted) {
+			t.Errorf("expected ErrSceneDeleted deleting twice, got %v", err)
+		}
+		if err := repo.Delete(ctx, "missing"); !errors.Is(err, scene.ErrSceneNotFound) {
+			t.Errorf("expected ErrSceneNotFound deleting a missing scene, got %v", err)
+		}
+
+		if err := repo.Restore(ctx, "scene-1"); err != nil {
+			t.Fatalf("Restore() error = %v", err)
+		}
+		if _, err := repo.GetByID(ctx, "scene-1"); err != nil {
+			t.Errorf("expected restored scene to be readable, got %v", err)
+		}
+		if err := repo.Restore(ctx, "scene-1"); err != nil {
+			t.Errorf("expected restoring a live scene to be a no-op, got %v", err)
+		}
+		if err := repo.Restore(ctx, "missing"); !errors.Is(err, scene.ErrSceneNotFound) {
+			t.Errorf("expected ErrSceneNotFound restoring a missing scene, got %v", err)
+		}
+	})
+
+	t.Run("UpsertByRecordKey", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		s := newScene("", "did:plc:owner", "Record Fair")
+		s.RecordDID = strPtr("did:plc:owner")
+		s.RecordRKey = strPtr("rkey-1")
+		first, err := repo.Upsert(ctx, s)
+		if err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+		if !first.Inserted || first.ID == "" {
+			t.Fatalf("expected first upsert to insert with an ID, got %+v", first)
+		}
+
+		s.Name = "Record Fair II"
+		second, err := repo.Upsert(ctx, s)
+		if err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+		if second.Inserted || second.ID != first.ID {
+			t.Errorf("expected second upsert to update %s, got %+v", first.ID, second)
+		}
+
+		got, err := repo.GetByRecordKey(ctx, "did:plc:owner", "rkey-1")
+		if err != nil {
+			t.Fatalf("GetByRecordKey() error = %v", err)
+		}
+		if got.ID != first.ID || got.Name != "Record Fair II" {
+			t.Errorf("GetByRecordKey() = %s %q, want %s %q", got.ID, got.Name, first.ID, "Record Fair II")
+		}
+		if _, err := repo.GetByRecordKey(ctx, "did:plc:owner", "missing"); !errors.Is(err, scene.ErrSceneNotFound) {
+			t.Errorf("expected ErrSceneNotFound for an unknown record key, got %v", err)
+		}
+	})
+
+	t.Run("ExistsByOwnerAndName", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		if err := repo.Insert(ctx, newScene("scene-1", "did:plc:owner", "Noise Night")); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+
+		tests := []struct {
+			name      string
+			ownerDID  string
+			sceneName string
+			excludeID string
+			want      bool
+		}{
+			{"same name", "did:plc:owner", "Noise Night", "", true},
+			{"different case", "did:plc:owner", "NOISE NIGHT", "", true},
+			{"excluded scene", "did:plc:owner", "Noise Night", "scene-1", false},
+			{"other owner", "did:plc:other", "Noise Night", "", false},
+			{"other name", "did:plc:owner", "Quiet Night", "", false},
+		}
+		for _, tt := range tests {
+			got, err := repo.ExistsByOwnerAndName(ctx, tt.ownerDID, tt.sceneName, tt.excludeID)
+			if err != nil {
+				t.Fatalf("%s: ExistsByOwnerAndName() error = %v", tt.name, err)
+			}
+			if got != tt.want {
+				t.Errorf("%s: ExistsByOwnerAndName() = %v, want %v", tt.name, got, tt.want)
+			}
+		}
+
+		if err := repo.Delete(ctx, "scene-1"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		got, err := repo.ExistsByOwnerAndName(ctx, "did:plc:owner", "Noise Night", "")
+		if err != nil {
+			t.Fatalf("ExistsByOwnerAndName() error = %v", err)
+		}
+		if got {
+			t.Error("expected a soft-deleted scene to free its name")
+		}
+	})
+
+	t.Run("GetBySlug", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		if err := repo.Insert(ctx, newScene("scene-1", "did:plc:owner", "Tape Loop Club")); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+		stored, err := repo.GetByID(ctx, "scene-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+
+		got, err := repo.GetBySlug(ctx, "did:plc:owner", strings.ToUpper(stored.Slug))
+		if err != nil {
+			t.Fatalf("GetBySlug() error = %v", err)
+		}
+		if got.ID != "scene-1" {
+			t.Errorf("GetBySlug() = %s, want scene-1", got.ID)
+		}
+		if _, err := repo.GetBySlug(ctx, "did:plc:other", stored.Slug); !errors.Is(err, scene.ErrSceneNotFound) {
+			t.Errorf("expected ErrSceneNotFound for another owner's slug, got %v", err)
+		}
+
+		if err := repo.Delete(ctx, "scene-1"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.GetBySlug(ctx, "did:plc:owner", stored.Slug); !errors.Is(err, scene.ErrSceneDeleted) {
+			t.Errorf("expected ErrSceneDeleted for a deleted scene's slug, got %v", err)
+		}
+	})
+
+	t.Run("ListByOwner", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		for _, s := range []*scene.Scene{
+			newScene("scene-1", "did:plc:owner", "Kept"),
+			newScene("scene-2", "did:plc:owner", "Deleted"),
+			newScene("scene-3", "did:plc:other", "Not Mine"),
+		} {
+			if err := repo.Insert(ctx, s); err != nil {
+				t.Fatalf("Insert() error = %v", err)
+			}
+		}
+		if err := repo.Delete(ctx, "scene-2"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		got, err := repo.ListByOwner(ctx, "did:plc:owner")
+		if err != nil {
+			t.Fatalf("ListByOwner() error = %v", err)
+		}
+		if len(got) != 1 || got[0].ID != "scene-1" {
+			t.Errorf("expected only scene-1, got %v", sceneIDs(got))
+		}
+
+		none, err := repo.ListByOwner(ctx, "did:plc:nobody")
+		if err != nil {
+			t.Fatalf("ListByOwner() error = %v", err)
+		}
+		if len(none) != 0 {
+			t.Errorf("expected no scenes for an unknown owner, got %v", sceneIDs(none))
+		}
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		for _, id := range []string{"scene-1", "scene-2"} {
+			if err := repo.Insert(ctx, newScene(id, "did:plc:owner", id)); err != nil {
+				t.Fatalf("Insert() error = %v", err)
+			}
+		}
+		if err := repo.Delete(ctx, "scene-1"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		if n, err := repo.Purge(ctx, time.Now().Add(-time.Hour)); err != nil || n != 0 {
+			t.Errorf("expected a recent deletion to survive purge, got %d (err %v)", n, err)
+		}
+		n, err := repo.Purge(ctx, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Fatalf("Purge() error = %v", err)
+		}
+		if n != 1 {
+			t.Errorf("expected 1 scene purged, got %d", n)
+		}
+		if _, err := repo.GetByID(ctx, "scene-1"); !errors.Is(err, scene.ErrSceneNotFound) {
+			t.Errorf("expected ErrSceneNotFound after purge, got %v", err)
+		}
+		if _, err := repo.GetByID(ctx, "scene-2"); err != nil {
+			t.Errorf("expected purge to keep live scenes, got %v", err)
+		}
+	})
+
+	t.Run("Snapshot", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := t.Context()
+
+		if err := repo.Insert(ctx, newScene("scene-1", "did:plc:owner", "Listening Room")); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+
+		var seen string
+		if err := repo.Snapshot(ctx, "scene-1", func(s *scene.Scene, events []*scene.Event) error {
+			seen = s.Name
+			return nil
+		}); err != nil {
+			t.Fatalf("Snapshot() error = %v", err)
+		}
+		if seen != "Listening Room" {
+			t.Errorf("expected fn to see the scene, got %q", seen)
+		}
+
+		errStop := errors.New("stop")
+		if err := repo.Snapshot(ctx, "scene-1", func(*scene.Scene, []*scene.Event) error {
+			return errStop
+		}); !errors.Is(err, errStop) {
+			t.Errorf("expected fn's error, got %v", err)
+		}
+		if err := repo.Snapshot(ctx, "missing", func(*scene.Scene, []*scene.Event) error {
+			t.Error("fn called for a missing scene")
+			return nil
+		}); !errors.Is(err, scene.ErrSceneNotFound) {
+			t.Errorf("expected ErrSceneNotFound, got %v", err)
+		}
+	})
+
+	t.Run("CanceledContext", func(t *testing.T) {
+		repo := newRepo(t)
+		if err := repo.Insert(t.Context(), newScene("scene-1", "did:plc:owner", "Canceled")); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+		ctx := canceledContext(t)
+
+		calls := map[string]func(context.Context) error{
+			"Insert": func(ctx context.Context) error {
+				return repo.Insert(ctx, newScene("scene-2", "did:plc:owner", "Late"))
+			},
+			"Update": func(ctx context.Context) error {
+				return repo.Update(ctx, newScene("scene-1", "did:plc:owner", "Late"))
+			},
+			"Upsert": func(ctx context.Context) error {
+				_, err := repo.Upsert(ctx, newScene("", "did:plc:owner", "Late"))
+				return err
+			},
+			"GetByID": func(ctx context.Context) error {
+				_, err := repo.GetByID(ctx, "scene-1")
+				return err
+			},
+			"GetByRecordKey": func(ctx context.Context) error {
+				_, err := repo.GetByRecordKey(ctx, "did:plc:owner", "rkey-1")
+				return err
+			},
+			"Delete": func(ctx context.Context) error {
+				return repo.Delete(ctx, "scene-1")
+			},
+			"ExistsByOwnerAndName": func(ctx context.Context) error {
+				_, err := repo.ExistsByOwnerAndName(ctx, "did:plc:owner", "Canceled", "")
+				return err
+			},
+			"ListByOwner": func(ctx context.Context) error {
+				_, err := repo.ListByOwner(ctx, "did:plc:owner")
+				return err
+			},
+			"GetBySlug": func(ctx context.Context) error {
+				_, err := repo.GetBySlug(ctx, "did:plc:owner", "canceled")
+				return err
+			},
+			"FindNearby": func(ctx context.Context) error {
+				_, err := repo.FindNearby(ctx, scene.Point{Lat: 40.7128, Lng: -74.0060}, 1000, 10)
+				return err
+			},
+			"FindInBbox": func(ctx context.Context) error {
+				_, err := repo.FindInBbox(ctx, -180, -90, 180, 90)
+				return err
+			},
+			"List": func(ctx context.Context) error {
+				_, _, err := repo.List(ctx, scene.ListFilter{})
+				return err
+			},
+			"FindByTag": func(ctx context.Context) error {
+				_, err := repo.FindByTag(ctx, "punk", 10)
+				return err
+			},
+			"FindByTags": func(ctx context.Context) error {
+				_, err := repo.FindByTags(ctx, []string{"punk"}, false, 10)
+				return err
+			},
+			"Restore": func(ctx context.Context) error {
+				return repo.Restore(ctx, "scene-1")
+			},
+			"Purge": func(ctx context.Context) error {
+				_, err := repo.Purge(ctx, time.Now())
+				return err
+			},
+			"Snapshot": func(ctx context.Context) error {
+				return repo.Snapshot(ctx, "scene-1", func(*scene.Scene, []*scene.Event) error { return nil })
+			},
+		}
+		for name, call := range calls {
+			if err := call(ctx); !errors.Is(err, context.Canceled) {
+				t.Errorf("%s: expected context.Canceled, got %v", name, err)
+			}
+		}
+
+		got, err := repo.GetByID(t.Context(), "scene-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Name != "Canceled" || got.Version != 1 {
+			t.Errorf("expected canceled calls to leave the scene untouched, got %q at version %d", got.Name, got.Version)
+		}
+	})
+}
+
+// newScene returns a public scene without precise location consent.
+func newScene(id, ownerDID, name string) *scene.Scene {
+	return &scene.Scene{
+		ID:            id,
+		Name:          name,
+		OwnerDID:      ownerDID,
+		CoarseGeohash: "dr5reg",
+		Tags:          []string{"punk"},
+		Visibility:    scene.VisibilityPublic,
+	}
+}
+
+// sceneIDs returns the IDs of scenes, for error messages.
+func sceneIDs(scenes []*scene.Scene) []string {
+	ids := make([]string, len(scenes))
+	for i, s := range scenes {
+		ids[i] = s.ID
+	}
+	return ids
+}
@@ -0,0 +1,32 @@
+// Package scenetest provides conformance tests for implementations of the
+// scene package's repository interfaces. A new backend, such as Postgres,
+// passes the same suite as the in-memory repositories before it is wired
+// into the handlers:
+//
+//	func TestPostgresSceneRepository(t *testing.T) {
+//		scenetest.TestSceneRepository(t, func(t *testing.T) scene.SceneRepository {
+//			return newTestPostgresSceneRepository(t)
+//		})
+//	}
+//
+// The suites only check behavior documented on the interfaces. Each subtest
+// calls the factory for a fresh, empty repository.
+package scenetest
+
+import (
+	"context"
+	"testing"
+)
+
+// canceledContext returns a context that is already canceled.
+func canceledContext(t *testing.T) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	return ctx
+}
+
+// strPtr returns a pointer to s.
+func strPtr(s string) *string {
+	return &s
+}
@@ -1,11 +1,13 @@
 package scene
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/geo"
 )
 
 // TestSearchByBboxAndTime_Pagination tests that cursor pagination works correctly.
@@ -29,7 +31,7 @@ func TestSearchByBboxAndTime_Pagination(t *testing.T) {
 			CreatedAt:     &baseTime,
 			UpdatedAt:     &baseTime,
 		}
-		if err := repo.Insert(event); err != nil {
+		if err := repo.Insert(t.Context(), event); err != nil {
 			t.Fatalf("failed to insert event: %v", err)
 		}
 		events[i] = event
@@ -39,7 +41,7 @@ func TestSearchByBboxAndTime_Pagination(t *testing.T) {
 	to := baseTime.Add(6 * time.Hour)
 	
 	// Get first page (limit=2)
-	results1, cursor1, err := repo.SearchByBboxAndTime(-74.1, 40.6, -73.9, 40.8, from, to, 2, "")
+	results1, cursor1, err := repo.SearchByBboxAndTime(context.Background(), -74.1, 40.6, -73.9, 40.8, from, to, 2, "")
 	if err != nil {
 		t.Fatalf("failed to search: %v", err)
 	}
@@ -58,7 +60,7 @@ func TestSearchByBboxAndTime_Pagination(t *testing.T) {
 	}
 	
 	// Get second page with cursor
-	results2, cursor2, err := repo.SearchByBboxAndTime(-74.1, 40.6, -73.9, 40.8, from, to, 2, cursor1)
+	results2, cursor2, err := repo.SearchByBboxAndTime(context.Background(), -74.1, 40.6, -73.9, 40.8, from, to, 2, cursor1)
 	if err != nil {
 		t.Fatalf("failed to search with cursor: %v", err)
 	}
@@ -91,3 +93,327 @@ func TestSearchByBboxAndTime_Pagination(t *testing.T) {
 		}
 	}
 }
+
+// TestFindNearby_OrdersByDistance tests that nearby scenes are returned closest first.
+func TestFindNearby_OrdersByDistance(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+
+	// Cluster of scenes around lower Manhattan at known coordinates
+	center := Point{Lat: 40.7128, Lng: -74.0060}
+	fixtures := []struct {
+		id    string
+		point Point
+	}{
+		{"far", Point{Lat: 40.7580, Lng: -73.9855}},     // Times Square, ~5.4km
+		{"near", Point{Lat: 40.7138, Lng: -74.0070}},    // ~140m
+		{"mid", Point{Lat: 40.7306, Lng: -73.9866}},     // Union Square area, ~2.5km
+		{"outside", Point{Lat: 40.6413, Lng: -73.7781}}, // JFK, ~20km
+	}
+	for _, f := range fixtures {
+		s := &Scene{
+			ID:            f.id,
+			Name:          "Scene " + f.id,
+			OwnerDID:      "did:plc:owner",
+			CoarseGeohash: geo.Encode(f.point, 7),
+			Visibility:    VisibilityPublic,
+		}
+		if err := repo.Insert(t.Context(), s); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+	}
+
+	results, err := repo.FindNearby(context.Background(), center, 10_000, 10)
+	if err != nil {
+		t.Fatalf("FindNearby failed: %v", err)
+	}
+
+	want := []string{"near", "mid", "far"}
+	if len(results) != len(want) {
+		t.Fatalf("expected %d scenes, got %d", len(want), len(results))
+	}
+	for i, id := range want {
+		if results[i].ID != id {
+			t.Errorf("position %d: expected scene %q, got %q", i, id, results[i].ID)
+		}
+	}
+
+	// Limit truncates after ordering
+	limited, err := repo.FindNearby(context.Background(), center, 10_000, 2)
+	if err != nil {
+		t.Fatalf("FindNearby failed: %v", err)
+	}
+	if len(limited) != 2 || limited[0].ID != "near" || limited[1].ID != "mid" {
+		t.Errorf("expected [near mid] with limit 2, got %d results", len(limited))
+	}
+}
+
+// TestFindNearby_VisibilityAndDeletion tests that only public, non-deleted scenes are returned.
+func TestFindNearby_VisibilityAndDeletion(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+	center := Point{Lat: 40.7128, Lng: -74.0060}
+	hash := geo.Encode(center, 7)
+
+	fixtures := []struct {
+		id         string
+		visibility string
+	}{
+		{"public", VisibilityPublic},
+		{"members", VisibilityMembersOnly},
+		{"hidden", VisibilityHidden},
+		{"deleted", VisibilityPublic},
+	}
+	for _, f := range fixtures {
+		s := &Scene{
+			ID:            f.id,
+			Name:          "Scene " + f.id,
+			OwnerDID:      "did:plc:owner",
+			CoarseGeohash: hash,
+			Visibility:    f.visibility,
+		}
+		if err := repo.Insert(t.Context(), s); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+	}
+	if err := repo.Delete(t.Context(), "deleted"); err != nil {
+		t.Fatalf("failed to delete scene: %v", err)
+	}
+
+	results, err := repo.FindNearby(context.Background(), center, 1_000, 0)
+	if err != nil {
+		t.Fatalf("FindNearby failed: %v", err)
+	}
+
+	if len(results) != 1 || results[0].ID != "public" {
+		ids := make([]string, len(results))
+		for i, r := range results {
+			ids[i] = r.ID
+		}
+		t.Errorf("expected only [public], got %v", ids)
+	}
+}
+
+// TestFindNearby_UsesCoarseLocation tests that distance is computed from the coarse
+// cell rather than the precise point.
+func TestFindNearby_UsesCoarseLocation(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+	center := Point{Lat: 40.7128, Lng: -74.0060}
+
+	// Precise point is at the center, but the coarse cell is in London
+	s := &Scene{
+		ID:            "mismatched",
+		Name:          "Mismatched Scene",
+		OwnerDID:      "did:plc:owner",
+		AllowPrecise:  true,
+		PrecisePoint:  &center,
+		CoarseGeohash: "gcpvj0",
+		Visibility:    VisibilityPublic,
+	}
+	if err := repo.Insert(t.Context(), s); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	results, err := repo.FindNearby(context.Background(), center, 1_000, 10)
+	if err != nil {
+		t.Fatalf("FindNearby failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no results, got %d", len(results))
+	}
+}
+
+// TestFindInBbox tests that only public, non-deleted scenes whose coarse cell
+// center lies in the box are returned, ordered by ID.
+func TestFindInBbox(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+	fixtures := []struct {
+		id         string
+		point      Point
+		visibility string
+	}{
+		{"soho", Point{Lat: 40.7233, Lng: -74.0030}, VisibilityPublic},
+		{"battery", Point{Lat: 40.7033, Lng: -74.0170}, VisibilityPublic},
+		{"jfk", Point{Lat: 40.6413, Lng: -73.7781}, VisibilityPublic},
+		{"members", Point{Lat: 40.7128, Lng: -74.0060}, VisibilityMembersOnly},
+		{"hidden", Point{Lat: 40.7128, Lng: -74.0060}, VisibilityHidden},
+		{"deleted", Point{Lat: 40.7128, Lng: -74.0060}, VisibilityPublic},
+	}
+	for _, f := range fixtures {
+		s := &Scene{
+			ID:            f.id,
+			Name:          "Scene " + f.id,
+			OwnerDID:      "did:plc:owner",
+			CoarseGeohash: geo.Encode(f.point, 7),
+			Visibility:    f.visibility,
+		}
+		if err := repo.Insert(t.Context(), s); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+	}
+	if err := repo.Delete(t.Context(), "deleted"); err != nil {
+		t.Fatalf("failed to delete scene: %v", err)
+	}
+
+	// Lower Manhattan
+	results, err := repo.FindInBbox(context.Background(), -74.03, 40.70, -73.99, 40.73)
+	if err != nil {
+		t.Fatalf("FindInBbox failed: %v", err)
+	}
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	if len(ids) != 2 || ids[0] != "battery" || ids[1] != "soho" {
+		t.Errorf("expected [battery soho], got %v", ids)
+	}
+}
+
+// TestListBySceneID_Filters tests scene scoping, status filters, time windows, and ordering.
+func TestListBySceneID_Filters(t *testing.T) {
+	repo := NewInMemoryEventRepository()
+	now := time.Now()
+	deletedAt := now
+
+	seed := []*Event{
+		{ID: "future-2", SceneID: "scene-1", Status: "scheduled", StartsAt: now.Add(48 * time.Hour)},
+		{ID: "future-1", SceneID: "scene-1", Status: "scheduled", StartsAt: now.Add(24 * time.Hour)},
+		{ID: "past-1", SceneID: "scene-1", Status: "ended", StartsAt: now.Add(-24 * time.Hour)},
+		{ID: "cancelled-1", SceneID: "scene-1", Status: "cancelled", StartsAt: now.Add(12 * time.Hour)},
+		{ID: "deleted-1", SceneID: "scene-1", Status: "scheduled", StartsAt: now.Add(6 * time.Hour), DeletedAt: &deletedAt},
+		{ID: "other-scene", SceneID: "scene-2", Status: "scheduled", StartsAt: now.Add(24 * time.Hour)},
+	}
+	for _, e := range seed {
+		e.CoarseGeohash = "dr5regw"
+		if err := repo.Insert(t.Context(), e); err != nil {
+			t.Fatalf("failed to insert event: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		filter  EventFilter
+		wantIDs []string
+	}{
+		{"no filter", EventFilter{}, []string{"past-1", "cancelled-1", "future-1", "future-2"}},
+		{"scheduled", EventFilter{Status: EventStatusFilterScheduled}, []string{"future-1", "future-2"}},
+		{"cancelled", EventFilter{Status: EventStatusFilterCancelled}, []string{"cancelled-1"}},
+		{"past", EventFilter{Status: EventStatusFilterPast}, []string{"past-1"}},
+		{"from only", EventFilter{From: now}, []string{"cancelled-1", "future-1", "future-2"}},
+		{"to only", EventFilter{To: now.Add(24 * time.Hour)}, []string{"past-1", "cancelled-1", "future-1"}},
+		{"window with status", EventFilter{Status: EventStatusFilterScheduled, From: now, To: now.Add(36 * time.Hour)}, []string{"future-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.ListBySceneID(t.Context(), "scene-1", tt.filter)
+			if err != nil {
+				t.Fatalf("ListBySceneID() error = %v", err)
+			}
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("expected %d events, got %d", len(tt.wantIDs), len(got))
+			}
+			for i, id := range tt.wantIDs {
+				if got[i].ID != id {
+					t.Errorf("position %d: expected %s, got %s", i, id, got[i].ID)
+				}
+			}
+		})
+	}
+}
+
+// TestListUpcomingNear_OrderingAndFilters tests radius, time, and status filtering with start-then-distance ordering.
+func TestListUpcomingNear_OrderingAndFilters(t *testing.T) {
+	repo := NewInMemoryEventRepository()
+	now := time.Now()
+	center := Point{Lat: 40.7128, Lng: -74.0060}
+	near := geo.Encode(center, 7)
+	nearish := geo.Encode(Point{Lat: 40.7228, Lng: -74.0060}, 7) // ~1.1km north
+	far := geo.Encode(Point{Lat: 41.7128, Lng: -74.0060}, 7)     // ~111km north
+
+	seed := []*Event{
+		{ID: "b-tie-nearish", StartsAt: now.Add(2 * time.Hour), CoarseGeohash: nearish},
+		{ID: "c-tie-near", StartsAt: now.Add(2 * time.Hour), CoarseGeohash: near},
+		{ID: "a-first", StartsAt: now.Add(time.Hour), CoarseGeohash: nearish},
+		{ID: "d-last", StartsAt: now.Add(3 * time.Hour), CoarseGeohash: near},
+		{ID: "past", StartsAt: now.Add(-time.Hour), CoarseGeohash: near},
+		{ID: "cancelled", StartsAt: now.Add(time.Hour), CoarseGeohash: near, Status: "cancelled"},
+		{ID: "far", StartsAt: now.Add(time.Hour), CoarseGeohash: far},
+	}
+	for _, e := range seed {
+		e.SceneID = "scene-1"
+		if err := repo.Insert(t.Context(), e); err != nil {
+			t.Fatalf("failed to insert event: %v", err)
+		}
+	}
+
+	got, next, err := repo.ListUpcomingNear(context.Background(), center, 5000, now, 0, "")
+	if err != nil {
+		t.Fatalf("ListUpcomingNear() error = %v", err)
+	}
+	if next != "" {
+		t.Errorf("expected no next cursor without limit, got %q", next)
+	}
+	want := []string{"a-first", "c-tie-near", "b-tie-nearish", "d-last"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(got))
+	}
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Errorf("position %d: expected %s, got %s", i, id, got[i].ID)
+		}
+	}
+
+	// Page through two at a time and confirm the same order results
+	var paged []string
+	cursor := ""
+	for page := 0; page < 5; page++ {
+		events, nextCursor, err := repo.ListUpcomingNear(context.Background(), center, 5000, now, 2, cursor)
+		if err != nil {
+			t.Fatalf("ListUpcomingNear() page %d error = %v", page, err)
+		}
+		for _, e := range events {
+			paged = append(paged, e.ID)
+		}
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+	if fmt.Sprint(paged) != fmt.Sprint(want) {
+		t.Errorf("paged results = %v, want %v", paged, want)
+	}
+
+	if _, _, err := repo.ListUpcomingNear(context.Background(), center, 5000, now, 2, "not-a-cursor"); err != ErrInvalidCursor {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+// TestSearchMethods_HonorContext tests that geo and time scans stop with the
+// context's error once it is done.
+func TestSearchMethods_HonorContext(t *testing.T) {
+	sceneRepo := NewInMemorySceneRepository()
+	eventRepo := NewInMemoryEventRepository()
+	point := Point{Lat: 40.7128, Lng: -74.0060}
+	if err := sceneRepo.Insert(t.Context(), &Scene{ID: "scene", Name: "Scene", OwnerDID: "did:plc:owner", CoarseGeohash: geo.Encode(point, 7), Visibility: VisibilityPublic}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+	now := time.Now()
+	if err := eventRepo.Insert(t.Context(), &Event{ID: "event", SceneID: "scene", Title: "Event", CoarseGeohash: geo.Encode(point, 7), AllowPrecise: true, PrecisePoint: &point, StartsAt: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := sceneRepo.FindNearby(ctx, point, 1_000, 0); err != context.Canceled {
+		t.Errorf("FindNearby: expected context.Canceled, got %v", err)
+	}
+	if _, err := sceneRepo.FindInBbox(ctx, -74.1, 40.6, -73.9, 40.8); err != context.Canceled {
+		t.Errorf("FindInBbox: expected context.Canceled, got %v", err)
+	}
+	if _, _, err := eventRepo.SearchByBboxAndTime(ctx, -74.1, 40.6, -73.9, 40.8, now, now.Add(2*time.Hour), 10, ""); err != context.Canceled {
+		t.Errorf("SearchByBboxAndTime: expected context.Canceled, got %v", err)
+	}
+	if _, _, err := eventRepo.ListUpcomingNear(ctx, point, 1_000, now, 10, ""); err != context.Canceled {
+		t.Errorf("ListUpcomingNear: expected context.Canceled, got %v", err)
+	}
+}
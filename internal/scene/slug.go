@@ -0,0 +1,54 @@
+package scene
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+// MaxSlugLength caps generated slugs, collision suffixes included.
+const MaxSlugLength = 64
+
+// defaultSlug is used for names with no letters or digits to keep.
+const defaultSlug = "scene"
+
+// Slugify derives a URL slug from a scene name: entities are decoded,
+// letters are lowercased, runs of whitespace, hyphens, and underscores become
+// a single hyphen, and every other non-alphanumeric character is dropped.
+// Only ASCII letters and digits are kept, so slugs never need escaping.
+// Returns "scene" when nothing remains.
+func Slugify(name string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(html.UnescapeString(name)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+		case r == ' ' || r == '\t' || r == '\n' || r == '-' || r == '_':
+			pendingHyphen = true
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > MaxSlugLength {
+		slug = strings.TrimRight(slug[:MaxSlugLength], "-")
+	}
+	if slug == "" {
+		return defaultSlug
+	}
+	return slug
+}
+
+// suffixSlug returns base with a numeric collision suffix ("my-scene-2"),
+// shortening base so the result stays within MaxSlugLength.
+func suffixSlug(base string, n int) string {
+	suffix := "-" + strconv.Itoa(n)
+	if len(base)+len(suffix) > MaxSlugLength {
+		base = strings.TrimRight(base[:MaxSlugLength-len(suffix)], "-")
+	}
+	return base + suffix
+}
@@ -0,0 +1,135 @@
+package scene
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"spaces to hyphens", "My Scene", "my-scene"},
+		{"punctuation stripped", "Jazz & Blues: Live!", "jazz-blues-live"},
+		{"escaped entities decoded", "Bass &amp; Dub", "bass-dub"},
+		{"runs collapse", "  Deep -- House__Nights  ", "deep-house-nights"},
+		{"digits kept", "Room 101", "room-101"},
+		{"non-ASCII dropped", "Café Noir", "caf-noir"},
+		{"nothing left", "!!!", "scene"},
+		{"already a slug", "my-scene-2", "my-scene-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Slugify(tt.in); got != tt.want {
+				t.Errorf("Slugify(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlugify_Length(t *testing.T) {
+	slug := Slugify(strings.Repeat("ab ", 40))
+	if len(slug) > MaxSlugLength || strings.HasSuffix(slug, "-") {
+		t.Errorf("expected a trimmed slug of at most %d characters, got %q", MaxSlugLength, slug)
+	}
+
+	suffixed := suffixSlug(strings.Repeat("a", MaxSlugLength), 12)
+	if len(suffixed) != MaxSlugLength || !strings.HasSuffix(suffixed, "-12") {
+		t.Errorf("expected the suffix to fit within %d characters, got %q", MaxSlugLength, suffixed)
+	}
+}
+
+func TestInMemorySceneRepository_SlugCollisions(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+	insert := func(id, owner, name string) *Scene {
+		t.Helper()
+		if err := repo.Insert(t.Context(), &Scene{ID: id, Name: name, OwnerDID: owner, CoarseGeohash: "dr5regw"}); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+		s, err := repo.GetByID(t.Context(), id)
+		if err != nil {
+			t.Fatalf("failed to get scene: %v", err)
+		}
+		return s
+	}
+
+	if s := insert("a", "did:plc:alice", "My Scene"); s.Slug != "my-scene" {
+		t.Errorf("expected slug my-scene, got %q", s.Slug)
+	}
+	if s := insert("b", "did:plc:alice", "My Scene!"); s.Slug != "my-scene-2" {
+		t.Errorf("expected collision suffix my-scene-2, got %q", s.Slug)
+	}
+	if s := insert("c", "did:plc:alice", "my scene"); s.Slug != "my-scene-3" {
+		t.Errorf("expected collision suffix my-scene-3, got %q", s.Slug)
+	}
+	if s := insert("d", "did:plc:bob", "My Scene"); s.Slug != "my-scene" {
+		t.Errorf("expected slugs to be unique per owner only, got %q", s.Slug)
+	}
+
+	// Renaming keeps the slug so shared links stay valid
+	renamed, _ := repo.GetByID(t.Context(), "a")
+	renamed.Name = "Renamed Scene"
+	if err := repo.Update(t.Context(), renamed); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if s, _ := repo.GetByID(t.Context(), "a"); s.Slug != "my-scene" {
+		t.Errorf("expected rename to keep slug my-scene, got %q", s.Slug)
+	}
+
+	// Transferring to an owner who already uses the slug suffixes it
+	transferred, _ := repo.GetByID(t.Context(), "b")
+	transferred.OwnerDID = "did:plc:bob"
+	if err := repo.Update(t.Context(), transferred); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if s, _ := repo.GetByID(t.Context(), "b"); s.Slug != "my-scene-2" {
+		t.Errorf("expected my-scene-2 to stay free under the new owner, got %q", s.Slug)
+	}
+
+	// A deleted scene frees its slug, and restoring it re-suffixes on collision
+	if err := repo.Delete(t.Context(), "a"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if s := insert("e", "did:plc:alice", "My Scene"); s.Slug != "my-scene" {
+		t.Errorf("expected the deleted scene's slug to be reused, got %q", s.Slug)
+	}
+	if err := repo.Restore(t.Context(), "a"); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if s, _ := repo.GetByID(t.Context(), "a"); s.Slug != "my-scene-2" {
+		t.Errorf("expected the restored scene to get a free slug, got %q", s.Slug)
+	}
+}
+
+func TestInMemorySceneRepository_GetBySlug(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+	for _, s := range []*Scene{
+		{ID: "live", Name: "Live Scene", OwnerDID: "did:plc:alice", CoarseGeohash: "dr5regw"},
+		{ID: "gone", Name: "Gone Scene", OwnerDID: "did:plc:alice", CoarseGeohash: "dr5regw"},
+	} {
+		if err := repo.Insert(t.Context(), s); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+	}
+	deletedAt := time.Now()
+	gone, _ := repo.GetByID(t.Context(), "gone")
+	gone.DeletedAt = &deletedAt
+	if err := repo.Update(t.Context(), gone); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	s, err := repo.GetBySlug(t.Context(), "did:plc:alice", "Live-Scene")
+	if err != nil || s.ID != "live" {
+		t.Errorf("expected case-insensitive lookup to find live, got %+v, %v", s, err)
+	}
+	if _, err := repo.GetBySlug(t.Context(), "did:plc:bob", "live-scene"); err != ErrSceneNotFound {
+		t.Errorf("expected ErrSceneNotFound for another owner, got %v", err)
+	}
+	if _, err := repo.GetBySlug(t.Context(), "did:plc:alice", "gone-scene"); err != ErrSceneDeleted {
+		t.Errorf("expected ErrSceneDeleted, got %v", err)
+	}
+}
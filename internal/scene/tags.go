@@ -0,0 +1,52 @@
+package scene
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tag constraints for scenes.
+const (
+	MaxTags      = 10
+	MaxTagLength = 32
+)
+
+// Tag validation errors.
+var (
+	ErrTooManyTags = fmt.Errorf("too many tags (maximum %d)", MaxTags)
+	ErrTagTooLong  = fmt.Errorf("tag exceeds %d characters", MaxTagLength)
+)
+
+// NormalizeTags lowercases and trims each tag, drops empty values, and removes
+// duplicates while preserving first-seen order. Returns nil for nil input.
+func NormalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		t := strings.ToLower(strings.TrimSpace(tag))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		normalized = append(normalized, t)
+	}
+	return normalized
+}
+
+// ValidateTags checks normalized tags against the count and length limits.
+// Returns ErrTooManyTags or ErrTagTooLong (wrapped with the offending tag).
+func ValidateTags(tags []string) error {
+	if len(tags) > MaxTags {
+		return ErrTooManyTags
+	}
+	for _, tag := range tags {
+		if len([]rune(tag)) > MaxTagLength {
+			return fmt.Errorf("%w: %q", ErrTagTooLong, tag)
+		}
+	}
+	return nil
+}
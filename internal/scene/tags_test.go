@@ -0,0 +1,179 @@
+package scene
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{"nil input", nil, nil},
+		{"empty input", []string{}, []string{}},
+		{"lowercases", []string{"Techno", "HOUSE"}, []string{"techno", "house"}},
+		{"trims whitespace", []string{" techno ", "\tjazz\n"}, []string{"techno", "jazz"}},
+		{"dedupes across casing and whitespace", []string{"Techno", "techno", " techno "}, []string{"techno"}},
+		{"drops empties", []string{"", "  ", "jazz"}, []string{"jazz"}},
+		{"preserves first-seen order", []string{"jazz", "techno", "Jazz", "ambient"}, []string{"jazz", "techno", "ambient"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeTags(tt.input)
+			if (got == nil) != (tt.want == nil) {
+				t.Fatalf("NormalizeTags(%v) = %#v, want %#v", tt.input, got, tt.want)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("NormalizeTags(%v) = %v, want %v", tt.input, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("NormalizeTags(%v)[%d] = %q, want %q", tt.input, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	tenTags := make([]string, MaxTags)
+	for i := range tenTags {
+		tenTags[i] = fmt.Sprintf("tag%d", i)
+	}
+
+	tests := []struct {
+		name    string
+		tags    []string
+		wantErr error
+	}{
+		{"no tags", nil, nil},
+		{"at tag limit", tenTags, nil},
+		{"over tag limit", append(tenTags, "extra"), ErrTooManyTags},
+		{"at length limit", []string{strings.Repeat("a", MaxTagLength)}, nil},
+		{"over length limit", []string{strings.Repeat("a", MaxTagLength+1)}, ErrTagTooLong},
+		{"multibyte at length limit", []string{strings.Repeat("é", MaxTagLength)}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTags(tt.tags)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateTags() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSceneRepository_FindByTag(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+
+	fixtures := []*Scene{
+		{ID: "techno-1", Tags: []string{"techno"}, Visibility: VisibilityPublic},
+		{ID: "techno-2", Tags: []string{"house", "techno"}, Visibility: VisibilityPublic},
+		{ID: "jazz", Tags: []string{"jazz"}, Visibility: VisibilityPublic},
+		{ID: "techno-private", Tags: []string{"techno"}, Visibility: VisibilityMembersOnly},
+		{ID: "techno-deleted", Tags: []string{"techno"}, Visibility: VisibilityPublic},
+	}
+	for _, s := range fixtures {
+		s.Name = s.ID
+		s.OwnerDID = "did:plc:owner"
+		s.CoarseGeohash = "dr5regw"
+		if err := repo.Insert(t.Context(), s); err != nil {
+			t.Fatalf("Insert %s failed: %v", s.ID, err)
+		}
+	}
+	if err := repo.Delete(t.Context(), "techno-deleted"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	scenes, err := repo.FindByTag(t.Context(), " Techno ", 0)
+	if err != nil {
+		t.Fatalf("FindByTag failed: %v", err)
+	}
+	if len(scenes) != 2 {
+		t.Fatalf("Expected 2 scenes, got %d", len(scenes))
+	}
+	for _, s := range scenes {
+		if s.ID != "techno-1" && s.ID != "techno-2" {
+			t.Errorf("Unexpected scene %s in results", s.ID)
+		}
+	}
+
+	limited, err := repo.FindByTag(t.Context(), "techno", 1)
+	if err != nil {
+		t.Fatalf("FindByTag failed: %v", err)
+	}
+	if len(limited) != 1 {
+		t.Errorf("Expected 1 scene with limit, got %d", len(limited))
+	}
+
+	empty, err := repo.FindByTag(t.Context(), "  ", 0)
+	if err != nil {
+		t.Fatalf("FindByTag failed: %v", err)
+	}
+	if len(empty) != 0 {
+		t.Errorf("Expected no scenes for blank tag, got %d", len(empty))
+	}
+}
+
+func TestSceneRepository_FindByTags(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+
+	fixtures := []*Scene{
+		{ID: "techno", Tags: []string{"techno"}, Visibility: VisibilityPublic},
+		{ID: "techno-warehouse", Tags: []string{"warehouse", "techno"}, Visibility: VisibilityPublic},
+		{ID: "house", Tags: []string{"house"}, Visibility: VisibilityPublic},
+		{ID: "jazz", Tags: []string{"jazz"}, Visibility: VisibilityPublic},
+		{ID: "private", Tags: []string{"techno", "warehouse"}, Visibility: VisibilityMembersOnly},
+	}
+	for _, s := range fixtures {
+		s.Name = s.ID
+		s.OwnerDID = "did:plc:owner"
+		s.CoarseGeohash = "dr5regw"
+		if err := repo.Insert(t.Context(), s); err != nil {
+			t.Fatalf("Insert %s failed: %v", s.ID, err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		tags     []string
+		matchAll bool
+		want     map[string]bool
+	}{
+		{"all requires every tag", []string{" Techno", "WAREHOUSE "}, true, map[string]bool{"techno-warehouse": true}},
+		{"any matches a single overlap", []string{"techno", "house"}, false, map[string]bool{"techno": true, "techno-warehouse": true, "house": true}},
+		{"all with one tag", []string{"house"}, true, map[string]bool{"house": true}},
+		{"empty list applies no filter", nil, true, map[string]bool{"techno": true, "techno-warehouse": true, "house": true, "jazz": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenes, err := repo.FindByTags(t.Context(), tt.tags, tt.matchAll, 0)
+			if err != nil {
+				t.Fatalf("FindByTags failed: %v", err)
+			}
+			if len(scenes) != len(tt.want) {
+				t.Fatalf("Expected %d scenes, got %d", len(tt.want), len(scenes))
+			}
+			for _, s := range scenes {
+				if !tt.want[s.ID] {
+					t.Errorf("Unexpected scene %s in results", s.ID)
+				}
+			}
+		})
+	}
+
+	limited, err := repo.FindByTags(t.Context(), []string{"techno", "house"}, false, 2)
+	if err != nil {
+		t.Fatalf("FindByTags failed: %v", err)
+	}
+	if len(limited) != 2 {
+		t.Errorf("Expected 2 scenes with limit, got %d", len(limited))
+	}
+}
@@ -0,0 +1,154 @@
+package scene
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrTemplateNotFound is returned when an event template does not exist.
+var ErrTemplateNotFound = errors.New("template not found")
+
+// TemplateDatePlaceholder in a template title expands to the event's local
+// start date (YYYY-MM-DD in the event's time zone).
+const TemplateDatePlaceholder = "{date}"
+
+// Template holds default fields for events created in a scene, so owners can
+// reuse the same event structure. Events copy the defaults when created and
+// keep no reference to the template, so editing or deleting it never changes
+// existing events.
+type Template struct {
+	ID            string   `json:"id"`
+	SceneID       string   `json:"scene_id"`
+	Name          string   `json:"name"`  // Owner-facing label
+	Title         string   `json:"title"` // Title pattern; may contain TemplateDatePlaceholder
+	Description   string   `json:"description,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	CoarseGeohash string   `json:"coarse_geohash,omitempty"`
+	Capacity      int      `json:"capacity,omitempty"`         // Max going RSVPs; 0 means unlimited
+	DurationMins  int      `json:"duration_minutes,omitempty"` // Derives ends_at; 0 means no end time
+	Timezone      string   `json:"timezone,omitempty"`         // IANA zone name; empty means UTC
+
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// EventTitle expands the title pattern for an event starting at startsAt,
+// formatting the date in loc.
+func (t *Template) EventTitle(startsAt time.Time, loc *time.Location) string {
+	return strings.ReplaceAll(t.Title, TemplateDatePlaceholder, startsAt.In(loc).Format(time.DateOnly))
+}
+
+// Duration returns the default event length, or zero when unset.
+func (t *Template) Duration() time.Duration {
+	return time.Duration(t.DurationMins) * time.Minute
+}
+
+// TemplateRepository defines the interface for event template data operations.
+type TemplateRepository interface {
+	// Insert stores a new template.
+	Insert(template *Template) error
+
+	// GetByID retrieves a template by ID.
+	// Returns ErrTemplateNotFound if it doesn't exist.
+	GetByID(id string) (*Template, error)
+
+	// ListByScene retrieves a scene's templates, oldest first.
+	ListByScene(sceneID string) ([]*Template, error)
+
+	// Delete removes a template. Events created from it are unaffected.
+	// Returns ErrTemplateNotFound if it doesn't exist.
+	Delete(id string) error
+}
+
+// InMemoryTemplateRepository is an in-memory implementation of TemplateRepository.
+// Thread-safe via RWMutex.
+type InMemoryTemplateRepository struct {
+	mu        sync.RWMutex
+	templates map[string]*Template
+}
+
+// Compile-time check that InMemoryTemplateRepository satisfies TemplateRepository.
+var _ TemplateRepository = (*InMemoryTemplateRepository)(nil)
+
+// NewInMemoryTemplateRepository creates a new in-memory template repository.
+func NewInMemoryTemplateRepository() *InMemoryTemplateRepository {
+	return &InMemoryTemplateRepository{
+		templates: make(map[string]*Template),
+	}
+}
+
+// copyTemplate returns a deep copy so callers can't mutate stored templates.
+func copyTemplate(template *Template) *Template {
+	templateCopy := *template
+	if template.Tags != nil {
+		templateCopy.Tags = append([]string(nil), template.Tags...)
+	}
+	return &templateCopy
+}
+
+// Insert stores a new template.
+func (r *InMemoryTemplateRepository) Insert(template *Template) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.templates[template.ID] = copyTemplate(template)
+	return nil
+}
+
+// GetByID retrieves a template by ID.
+func (r *InMemoryTemplateRepository) GetByID(id string) (*Template, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	template, ok := r.templates[id]
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+	return copyTemplate(template), nil
+}
+
+// ListByScene retrieves a scene's templates, oldest first, ties broken by ID.
+func (r *InMemoryTemplateRepository) ListByScene(sceneID string) ([]*Template, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*Template, 0)
+	for _, template := range r.templates {
+		if template.SceneID == sceneID {
+			result = append(result, copyTemplate(template))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		ti, tj := templateCreatedAt(result[i]), templateCreatedAt(result[j])
+		if ti.Equal(tj) {
+			return result[i].ID < result[j].ID
+		}
+		return ti.Before(tj)
+	})
+
+	return result, nil
+}
+
+// templateCreatedAt returns the template's creation time, or the zero time if unset.
+func templateCreatedAt(template *Template) time.Time {
+	if template.CreatedAt == nil {
+		return time.Time{}
+	}
+	return *template.CreatedAt
+}
+
+// Delete removes a template.
+func (r *InMemoryTemplateRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.templates[id]; !ok {
+		return ErrTemplateNotFound
+	}
+	delete(r.templates, id)
+	return nil
+}
@@ -0,0 +1,70 @@
+package scene
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTemplate_EventTitle(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load zone: %v", err)
+	}
+	tmpl := &Template{Title: "Warehouse Night {date}"}
+	startsAt := time.Date(2026, 3, 6, 20, 0, 0, 0, time.UTC)
+
+	if got := tmpl.EventTitle(startsAt, time.UTC); got != "Warehouse Night 2026-03-06" {
+		t.Errorf("EventTitle() = %q, want UTC date", got)
+	}
+	// 20:00 UTC is already the next morning in Tokyo
+	if got := tmpl.EventTitle(startsAt, tokyo); got != "Warehouse Night 2026-03-07" {
+		t.Errorf("EventTitle() = %q, want local date", got)
+	}
+	if got := (&Template{Title: "Plain Title"}).EventTitle(startsAt, time.UTC); got != "Plain Title" {
+		t.Errorf("EventTitle() = %q, want title unchanged", got)
+	}
+}
+
+func TestInMemoryTemplateRepository(t *testing.T) {
+	repo := NewInMemoryTemplateRepository()
+	older := time.Now().Add(-time.Hour)
+	newer := time.Now()
+
+	for _, tmpl := range []*Template{
+		{ID: "b", SceneID: "scene-1", Name: "Newer", Title: "Night", CreatedAt: &newer},
+		{ID: "a", SceneID: "scene-1", Name: "Older", Title: "Night", Tags: []string{"techno"}, CreatedAt: &older},
+		{ID: "c", SceneID: "scene-2", Name: "Other", Title: "Night", CreatedAt: &older},
+	} {
+		if err := repo.Insert(tmpl); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+	}
+
+	templates, err := repo.ListByScene("scene-1")
+	if err != nil {
+		t.Fatalf("ListByScene() error = %v", err)
+	}
+	if len(templates) != 2 || templates[0].ID != "a" || templates[1].ID != "b" {
+		t.Fatalf("expected scene-1 templates oldest first [a b], got %+v", templates)
+	}
+
+	// Returned templates are copies
+	templates[0].Tags[0] = "mutated"
+	got, err := repo.GetByID("a")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Tags[0] != "techno" {
+		t.Errorf("expected stored tags to be unchanged, got %v", got.Tags)
+	}
+
+	if err := repo.Delete("a"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.GetByID("a"); err != ErrTemplateNotFound {
+		t.Errorf("expected ErrTemplateNotFound after delete, got %v", err)
+	}
+	if err := repo.Delete("a"); err != ErrTemplateNotFound {
+		t.Errorf("expected ErrTemplateNotFound deleting twice, got %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package scene
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidTimezone is returned for names that are not in the tz database.
+var ErrInvalidTimezone = errors.New("invalid timezone")
+
+// LoadTimezone resolves an IANA time zone name such as "America/New_York".
+// An empty name resolves to UTC. "Local" is rejected because it depends on
+// the server's configuration rather than the event.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	if name == "Local" {
+		return nil, ErrInvalidTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	return loc, nil
+}
+
+// Location returns the event's time zone, falling back to UTC when none is
+// set or the stored name is no longer known.
+func (e *Event) Location() *time.Location {
+	loc, err := LoadTimezone(e.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// InLocation converts StartsAt and EndsAt to the event's time zone so they
+// serialize with its local UTC offset. The instants are unchanged.
+func (e *Event) InLocation() *Event {
+	loc := e.Location()
+	e.StartsAt = e.StartsAt.In(loc)
+	if e.EndsAt != nil {
+		endsAt := e.EndsAt.In(loc)
+		e.EndsAt = &endsAt
+	}
+	return e
+}
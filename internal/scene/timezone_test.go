@@ -0,0 +1,59 @@
+package scene
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadTimezone(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"empty is UTC", "", "UTC", false},
+		{"IANA name", "America/New_York", "America/New_York", false},
+		{"UTC by name", "UTC", "UTC", false},
+		{"unknown zone", "Mars/Olympus_Mons", "", true},
+		{"server local zone", "Local", "", true},
+		{"path traversal", "../../etc/passwd", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := LoadTimezone(tt.in)
+			if tt.wantErr {
+				if err != ErrInvalidTimezone {
+					t.Errorf("LoadTimezone(%q) error = %v, want ErrInvalidTimezone", tt.in, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadTimezone(%q) error = %v", tt.in, err)
+			}
+			if loc.String() != tt.want {
+				t.Errorf("LoadTimezone(%q) = %s, want %s", tt.in, loc, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvent_InLocation(t *testing.T) {
+	startsAt := time.Date(2026, 7, 4, 0, 0, 0, 0, time.UTC)
+	endsAt := startsAt.Add(3 * time.Hour)
+	e := (&Event{StartsAt: startsAt, EndsAt: &endsAt, Timezone: "America/Los_Angeles"}).InLocation()
+
+	if !e.StartsAt.Equal(startsAt) || !e.EndsAt.Equal(endsAt) {
+		t.Fatalf("expected instants to be unchanged, got %v - %v", e.StartsAt, e.EndsAt)
+	}
+	if got := e.StartsAt.Format(time.RFC3339); got != "2026-07-03T17:00:00-07:00" {
+		t.Errorf("expected local wall-clock start, got %s", got)
+	}
+
+	// Unknown stored names fall back to UTC rather than failing
+	e = (&Event{StartsAt: startsAt, Timezone: "Nowhere/Atlantis"}).InLocation()
+	if e.StartsAt.Location() != time.UTC {
+		t.Errorf("expected UTC fallback, got %s", e.StartsAt.Location())
+	}
+}
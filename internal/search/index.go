@@ -0,0 +1,399 @@
+// Package search provides an in-memory full-text index over scenes and events.
+package search
+
+import (
+	"context"
+	"html"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// Result types identify which kind of entity a search hit refers to.
+const (
+	TypeScene = "scene"
+	TypeEvent = "event"
+)
+
+// Field weights applied to term occurrences. Titles and names dominate,
+// tags are strong categorical signals, and descriptions add context.
+const (
+	titleWeight       = 3.0
+	tagWeight         = 2.0
+	descriptionWeight = 1.0
+)
+
+// prefixMatchWeight scales the contribution of a token that only matches a
+// query term by prefix, so exact matches rank above partial ones.
+const prefixMatchWeight = 0.5
+
+// fuzzyMatchWeight scales the contribution of a token that only matches a
+// query term within the allowed edit distance, so typo matches rank below
+// exact and prefix ones.
+const fuzzyMatchWeight = 0.25
+
+// maxEditDistance caps fuzzy matching so short terms do not match unrelated
+// words.
+const maxEditDistance = 2
+
+// Matched fields name the indexed text a snippet is taken from.
+const (
+	FieldName        = "name"
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldTags        = "tags"
+)
+
+// Result is a ranked search hit. Snippet is an excerpt of MatchedField with
+// the matching words located by Highlights; it is built only from the indexed
+// public text, never from locations or non-public fields.
+type Result struct {
+	Type         string      `json:"type"`
+	ID           string      `json:"id"`
+	SceneID      string      `json:"scene_id"`
+	Score        float64     `json:"score"`
+	Snippet      string      `json:"snippet,omitempty"`
+	MatchedField string      `json:"matched_field,omitempty"`
+	Highlights   []Highlight `json:"highlights,omitempty"`
+}
+
+// docKey identifies an indexed entity.
+type docKey struct {
+	typ string
+	id  string
+}
+
+// document is the indexed form of a scene or event.
+type document struct {
+	sceneID string
+	terms   map[string]float64 // token -> accumulated field weight
+	fields  []field            // indexed text, in snippet preference order
+}
+
+// field is the decoded text of one indexed field, kept for snippets.
+type field struct {
+	name string
+	text string
+}
+
+// Indexer is an in-memory inverted index over scene and event text.
+// Only public, non-deleted scenes and the non-deleted events of those scenes
+// are searchable; hidden, members-only, and deleted entities are never returned.
+// Thread-safe via RWMutex.
+type Indexer struct {
+	mu       sync.RWMutex
+	docs     map[docKey]*document
+	postings map[string]map[docKey]float64 // token -> doc -> weight
+
+	// searchableScenes records which scenes currently allow their own and
+	// their events' entries to be returned.
+	searchableScenes map[string]bool
+}
+
+// NewIndexer creates an empty search index.
+func NewIndexer() *Indexer {
+	return &Indexer{
+		docs:             make(map[docKey]*document),
+		postings:         make(map[string]map[docKey]float64),
+		searchableScenes: make(map[string]bool),
+	}
+}
+
+// IndexScene adds or replaces a scene's entry, tokenizing its name,
+// description, and tags. Deleted or non-public scenes are removed from the
+// index, which also hides their events until the scene is searchable again.
+func (ix *Indexer) IndexScene(s *scene.Scene) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	key := docKey{typ: TypeScene, id: s.ID}
+	ix.removeLocked(key)
+
+	if s.DeletedAt != nil || s.Visibility != scene.VisibilityPublic {
+		delete(ix.searchableScenes, s.ID)
+		return
+	}
+	ix.searchableScenes[s.ID] = true
+
+	terms := make(map[string]float64)
+	addTerms(terms, s.Name, titleWeight)
+	addTerms(terms, s.Description, descriptionWeight)
+	for _, tag := range s.Tags {
+		addTerms(terms, tag, tagWeight)
+	}
+	ix.addLocked(key, &document{
+		sceneID: s.ID,
+		terms:   terms,
+		fields:  indexedFields(FieldName, s.Name, s.Description, s.Tags),
+	})
+}
+
+// IndexEvent adds or replaces an event's entry, tokenizing its title,
+// description, and tags. Deleted events are removed from the index. Events are
+// only returned while their scene is searchable.
+func (ix *Indexer) IndexEvent(e *scene.Event) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	key := docKey{typ: TypeEvent, id: e.ID}
+	ix.removeLocked(key)
+
+	if e.DeletedAt != nil {
+		return
+	}
+
+	terms := make(map[string]float64)
+	addTerms(terms, e.Title, titleWeight)
+	addTerms(terms, e.Description, descriptionWeight)
+	for _, tag := range e.Tags {
+		addTerms(terms, tag, tagWeight)
+	}
+	ix.addLocked(key, &document{
+		sceneID: e.SceneID,
+		terms:   terms,
+		fields:  indexedFields(FieldTitle, e.Title, e.Description, e.Tags),
+	})
+}
+
+// RemoveScene removes a scene's entry and hides its events.
+func (ix *Indexer) RemoveScene(id string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.removeLocked(docKey{typ: TypeScene, id: id})
+	delete(ix.searchableScenes, id)
+}
+
+// RemoveEvent removes an event's entry.
+func (ix *Indexer) RemoveEvent(id string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.removeLocked(docKey{typ: TypeEvent, id: id})
+}
+
+// Search returns entities matching every term in query, ranked by score
+// descending with ties broken by type and ID. Matching is case-insensitive,
+// and each query term also matches indexed tokens it is a prefix of, at a
+// reduced weight. A limit of zero or less returns all hits.
+//
+// Each hit carries a snippet of the field whose densest window covers the
+// most query terms. Returns ctx's error if it is done before matching
+// completes.
+func (ix *Indexer) Search(ctx context.Context, query string, limit int) ([]Result, error) {
+	return ix.search(ctx, query, limit, false)
+}
+
+// SearchFuzzy is like Search but also tolerates typos: a query term matches
+// indexed tokens within an edit distance that grows with the term's length,
+// up to maxEditDistance, at a weight below exact and prefix matches. Backs
+// fuzzy=true on GET /search (api.SearchHandlers); exact search stays the
+// fast default.
+func (ix *Indexer) SearchFuzzy(ctx context.Context, query string, limit int) ([]Result, error) {
+	return ix.search(ctx, query, limit, true)
+}
+
+func (ix *Indexer) search(ctx context.Context, query string, limit int, fuzzy bool) ([]Result, error) {
+	queryTerms := tokenize(query)
+	if len(queryTerms) == 0 {
+		return []Result{}, nil
+	}
+	queryTerms = uniqueTerms(queryTerms)
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	var scores map[docKey]float64
+	for _, term := range queryTerms {
+		termScores, err := ix.matchTermLocked(ctx, term, fuzzy)
+		if err != nil {
+			return nil, err
+		}
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+		// Every query term must match: keep only documents seen for all terms
+		for key, score := range scores {
+			termScore, ok := termScores[key]
+			if !ok {
+				delete(scores, key)
+				continue
+			}
+			scores[key] = score + termScore
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for key, score := range scores {
+		doc := ix.docs[key]
+		if !ix.searchableScenes[doc.sceneID] {
+			continue
+		}
+		results = append(results, Result{Type: key.typ, ID: key.id, SceneID: doc.sceneID, Score: score})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Type != results[j].Type {
+			return results[i].Type < results[j].Type
+		}
+		return results[i].ID < results[j].ID
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	for i := range results {
+		doc := ix.docs[docKey{typ: results[i].Type, id: results[i].ID}]
+		results[i].MatchedField, results[i].Snippet, results[i].Highlights = bestSnippet(doc.fields, queryTerms, fuzzy)
+	}
+	return results, nil
+}
+
+// matchTermLocked scores every document containing a token that matches
+// term, scanning the whole vocabulary. Returns ctx's error if it is done
+// mid-scan. Caller must hold at least a read lock.
+func (ix *Indexer) matchTermLocked(ctx context.Context, term string, fuzzy bool) (map[docKey]float64, error) {
+	scores := make(map[docKey]float64)
+	for token, docs := range ix.postings {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		multiplier := termMatch(token, term, fuzzy)
+		if multiplier == 0 {
+			continue
+		}
+		for key, weight := range docs {
+			scores[key] += weight * multiplier
+		}
+	}
+	return scores, nil
+}
+
+// termMatch returns the weight multiplier for token against a query term:
+// full for an exact match, reduced for a prefix match, and further reduced
+// for a fuzzy match when enabled. Zero means no match.
+func termMatch(token, term string, fuzzy bool) float64 {
+	switch {
+	case token == term:
+		return 1.0
+	case strings.HasPrefix(token, term):
+		return prefixMatchWeight
+	case fuzzy && withinEditDistance(token, term, allowedEdits(term)):
+		return fuzzyMatchWeight
+	}
+	return 0
+}
+
+// allowedEdits scales the fuzzy edit budget with term length: none for terms
+// of up to two characters, one for three or four, and maxEditDistance beyond.
+func allowedEdits(term string) int {
+	switch n := utf8.RuneCountInString(term); {
+	case n <= 2:
+		return 0
+	case n <= 4:
+		return 1
+	default:
+		return maxEditDistance
+	}
+}
+
+// withinEditDistance reports whether the Levenshtein distance between a and
+// b is at most limit, stopping early once every alignment exceeds it.
+func withinEditDistance(a, b string, limit int) bool {
+	if limit <= 0 {
+		return a == b
+	}
+	ra, rb := []rune(a), []rune(b)
+	if len(ra)-len(rb) > limit || len(rb)-len(ra) > limit {
+		return false
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return false
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)] <= limit
+}
+
+// addLocked stores doc under key and records its postings. Caller must hold the write lock.
+func (ix *Indexer) addLocked(key docKey, doc *document) {
+	ix.docs[key] = doc
+	for token, weight := range doc.terms {
+		docs, ok := ix.postings[token]
+		if !ok {
+			docs = make(map[docKey]float64)
+			ix.postings[token] = docs
+		}
+		docs[key] = weight
+	}
+}
+
+// removeLocked deletes key and its postings if present. Caller must hold the write lock.
+func (ix *Indexer) removeLocked(key docKey) {
+	doc, ok := ix.docs[key]
+	if !ok {
+		return
+	}
+	for token := range doc.terms {
+		delete(ix.postings[token], key)
+		if len(ix.postings[token]) == 0 {
+			delete(ix.postings, token)
+		}
+	}
+	delete(ix.docs, key)
+}
+
+// addTerms tokenizes text and accumulates weight for each token occurrence.
+func addTerms(terms map[string]float64, text string, weight float64) {
+	for _, token := range tokenize(text) {
+		terms[token] += weight
+	}
+}
+
+// tokenize splits text into lowercase tokens on any character that is not a
+// letter or digit. Stored scene names are HTML-escaped, so entities are
+// decoded first to avoid indexing fragments such as "amp".
+func tokenize(text string) []string {
+	text = strings.ToLower(html.UnescapeString(text))
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !isWordRune(r)
+	})
+}
+
+// uniqueTerms returns terms with duplicates removed, preserving order.
+func uniqueTerms(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	unique := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		unique = append(unique, term)
+	}
+	return unique
+}
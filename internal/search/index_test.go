@@ -0,0 +1,280 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// newTestIndexer builds an index with two public scenes, one hidden scene,
+// and events in the public and hidden scenes.
+func newTestIndexer(t *testing.T) *Indexer {
+	t.Helper()
+	ix := NewIndexer()
+
+	ix.IndexScene(&scene.Scene{
+		ID:          "jazz",
+		Name:        "Underground Jazz Club",
+		Description: "Late night sessions in the basement",
+		Tags:        []string{"jazz", "live-music"},
+		Visibility:  scene.VisibilityPublic,
+	})
+	ix.IndexScene(&scene.Scene{
+		ID:          "techno",
+		Name:        "Warehouse Techno",
+		Description: "Late night techno and jazz-inflected house",
+		Tags:        []string{"electronic"},
+		Visibility:  scene.VisibilityPublic,
+	})
+	ix.IndexScene(&scene.Scene{
+		ID:         "secret",
+		Name:       "Secret Jazz Loft",
+		Tags:       []string{"jazz"},
+		Visibility: scene.VisibilityHidden,
+	})
+
+	ix.IndexEvent(&scene.Event{
+		ID:          "jam",
+		SceneID:     "jazz",
+		Title:       "Monday Jam Session",
+		Description: "Bring your instrument",
+		Tags:        []string{"open-mic"},
+	})
+	ix.IndexEvent(&scene.Event{
+		ID:      "secret-show",
+		SceneID: "secret",
+		Title:   "Secret Jazz Show",
+	})
+	return ix
+}
+
+// runSearch runs Search, or SearchFuzzy when fuzzy is set, failing the test on error.
+func runSearch(t *testing.T, ix *Indexer, query string, limit int, fuzzy bool) []Result {
+	t.Helper()
+	searchFn := ix.Search
+	if fuzzy {
+		searchFn = ix.SearchFuzzy
+	}
+	results, err := searchFn(context.Background(), query, limit)
+	if err != nil {
+		t.Fatalf("search for %q failed: %v", query, err)
+	}
+	return results
+}
+
+// resultIDs returns the IDs of results in order.
+func resultIDs(results []Result) []string {
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestIndexer_Search(t *testing.T) {
+	ix := newTestIndexer(t)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"name match ranks above description", "jazz", []string{"jazz", "techno"}},
+		{"multi-term requires every term", "late night jazz", []string{"jazz", "techno"}},
+		{"multi-term narrows results", "warehouse jazz", []string{"techno"}},
+		{"tag match", "electronic", []string{"techno"}},
+		{"hyphenated tag match", "live music", []string{"jazz"}},
+		{"event title", "jam session", []string{"jam"}},
+		{"event tag", "mic", []string{"jam"}},
+		{"case folding", "WAREHOUSE", []string{"techno"}},
+		{"prefix match", "tech", []string{"techno"}},
+		{"exact match ranks above prefix", "session", []string{"jam", "jazz"}},
+		{"no match", "polka", []string{}},
+		{"empty query", "  ", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resultIDs(runSearch(t, ix, tt.query, 0, false))
+			if !equalIDs(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexer_SearchLimit(t *testing.T) {
+	ix := newTestIndexer(t)
+
+	results := runSearch(t, ix, "jazz", 1, false)
+	if len(results) != 1 || results[0].ID != "jazz" {
+		t.Errorf("expected only top hit 'jazz', got %v", resultIDs(results))
+	}
+}
+
+func TestIndexer_HiddenAndDeletedNeverReturned(t *testing.T) {
+	ix := newTestIndexer(t)
+
+	for _, r := range runSearch(t, ix, "secret", 0, false) {
+		t.Errorf("expected no hits for hidden scene or its events, got %s %s", r.Type, r.ID)
+	}
+
+	// Making a scene members-only hides it and its events
+	ix.IndexScene(&scene.Scene{ID: "jazz", Name: "Underground Jazz Club", Visibility: scene.VisibilityMembersOnly})
+	if got := resultIDs(runSearch(t, ix, "jam", 0, false)); len(got) != 0 {
+		t.Errorf("expected events of members-only scene to be hidden, got %v", got)
+	}
+
+	// Restoring public visibility makes its events searchable again
+	ix.IndexScene(&scene.Scene{ID: "jazz", Name: "Underground Jazz Club", Visibility: scene.VisibilityPublic})
+	if got := resultIDs(runSearch(t, ix, "jam", 0, false)); !equalIDs(got, []string{"jam"}) {
+		t.Errorf("expected event to reappear, got %v", got)
+	}
+
+	// Soft-deleted scenes are removed
+	deletedAt := time.Now()
+	ix.IndexScene(&scene.Scene{ID: "techno", Name: "Warehouse Techno", Visibility: scene.VisibilityPublic, DeletedAt: &deletedAt})
+	if got := resultIDs(runSearch(t, ix, "warehouse", 0, false)); len(got) != 0 {
+		t.Errorf("expected deleted scene to be excluded, got %v", got)
+	}
+
+	// Soft-deleted events are removed
+	ix.IndexEvent(&scene.Event{ID: "jam", SceneID: "jazz", Title: "Monday Jam Session", DeletedAt: &deletedAt})
+	if got := resultIDs(runSearch(t, ix, "jam", 0, false)); len(got) != 0 {
+		t.Errorf("expected deleted event to be excluded, got %v", got)
+	}
+}
+
+func TestIndexer_ReindexReplacesTerms(t *testing.T) {
+	ix := newTestIndexer(t)
+
+	ix.IndexScene(&scene.Scene{ID: "techno", Name: "Warehouse Ambient", Visibility: scene.VisibilityPublic})
+
+	if got := resultIDs(runSearch(t, ix, "techno", 0, false)); len(got) != 0 {
+		t.Errorf("expected old terms to be dropped, got %v", got)
+	}
+	if got := resultIDs(runSearch(t, ix, "ambient", 0, false)); !equalIDs(got, []string{"techno"}) {
+		t.Errorf("expected new terms to match, got %v", got)
+	}
+}
+
+func TestIndexer_Remove(t *testing.T) {
+	ix := newTestIndexer(t)
+
+	ix.RemoveEvent("jam")
+	if got := resultIDs(runSearch(t, ix, "jam", 0, false)); len(got) != 0 {
+		t.Errorf("expected removed event to be excluded, got %v", got)
+	}
+
+	ix.IndexEvent(&scene.Event{ID: "jam", SceneID: "jazz", Title: "Monday Jam Session"})
+	ix.RemoveScene("jazz")
+	if got := resultIDs(runSearch(t, ix, "jazz", 0, false)); !equalIDs(got, []string{"techno"}) {
+		t.Errorf("expected removed scene to be excluded, got %v", got)
+	}
+	if got := resultIDs(runSearch(t, ix, "jam", 0, false)); len(got) != 0 {
+		t.Errorf("expected events of removed scene to be excluded, got %v", got)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	got := tokenize("Jazz &amp; Blues: LIVE-music, 2024!")
+	want := []string{"jazz", "blues", "live", "music", "2024"}
+	if !equalIDs(got, want) {
+		t.Errorf("tokenize() = %v, want %v", got, want)
+	}
+}
+
+func TestIndexer_SearchFuzzy(t *testing.T) {
+	ix := newTestIndexer(t)
+
+	tests := []struct {
+		name      string
+		query     string
+		wantExact []string
+		wantFuzzy []string
+	}{
+		{"one-edit typo", "warehuse", []string{}, []string{"techno"}},
+		{"two-edit typo", "tekno", []string{}, []string{"techno"}},
+		{"typo in multi-term query", "jam sesion", []string{}, []string{"jam"}},
+		{"three-letter terms allow one edit", "jaz", []string{"jazz", "techno"}, []string{"jazz", "jam", "techno"}},
+		{"two-letter terms need exact matches", "jm", []string{}, []string{}},
+		{"three edits is too many", "wrhuse", []string{}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resultIDs(runSearch(t, ix, tt.query, 0, false)); !equalIDs(got, tt.wantExact) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.wantExact)
+			}
+			if got := resultIDs(runSearch(t, ix, tt.query, 0, true)); !equalIDs(got, tt.wantFuzzy) {
+				t.Errorf("SearchFuzzy(%q) = %v, want %v", tt.query, got, tt.wantFuzzy)
+			}
+		})
+	}
+}
+
+func TestIndexer_SearchFuzzyRanksBelowExact(t *testing.T) {
+	ix := NewIndexer()
+	ix.IndexScene(&scene.Scene{ID: "exact", Name: "Techno Nights", Visibility: scene.VisibilityPublic})
+	ix.IndexScene(&scene.Scene{ID: "typo", Name: "Tecno Nights", Visibility: scene.VisibilityPublic})
+
+	results := runSearch(t, ix, "techno", 0, true)
+	if got := resultIDs(results); !equalIDs(got, []string{"exact", "typo"}) {
+		t.Fatalf("expected exact match ranked first, got %v", got)
+	}
+	if results[1].Score >= results[0].Score {
+		t.Errorf("expected fuzzy score %v below exact score %v", results[1].Score, results[0].Score)
+	}
+	if words := highlighted(results[1]); !equalIDs(words, []string{"Tecno"}) {
+		t.Errorf("expected the fuzzy match highlighted, got %v", words)
+	}
+}
+
+func TestWithinEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		limit int
+		want  bool
+	}{
+		{"techno", "techno", 0, true},
+		{"techno", "tecno", 0, false},
+		{"techno", "tecno", 1, true},
+		{"techno", "tekno", 1, false},
+		{"techno", "tekno", 2, true},
+		{"techno", "te", 2, false},
+		{"café", "cafe", 1, true},
+	}
+
+	for _, tt := range tests {
+		if got := withinEditDistance(tt.a, tt.b, tt.limit); got != tt.want {
+			t.Errorf("withinEditDistance(%q, %q, %d) = %v, want %v", tt.a, tt.b, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestIndexer_SearchHonorsContext(t *testing.T) {
+	ix := newTestIndexer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ix.Search(ctx, "jazz", 0); err != context.Canceled {
+		t.Errorf("Search: expected context.Canceled, got %v", err)
+	}
+	if _, err := ix.SearchFuzzy(ctx, "jaz", 0); err != context.Canceled {
+		t.Errorf("SearchFuzzy: expected context.Canceled, got %v", err)
+	}
+}
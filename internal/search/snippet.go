@@ -0,0 +1,187 @@
+package search
+
+import (
+	"html"
+	"math/bits"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxSnippetRunes bounds the decoded length of a snippet, excluding ellipses.
+const maxSnippetRunes = 160
+
+// ellipsis marks text trimmed from either end of a snippet.
+const ellipsis = "…"
+
+// Highlight locates a matching word in a snippet as a half-open range of
+// character (Unicode code point) offsets.
+type Highlight struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// match is a word in a field that matches one or more query terms.
+type match struct {
+	start, end int    // rune offsets into the field text
+	terms      uint64 // bit i is set when the word matches query term i
+}
+
+// indexedFields returns the decoded text of an entity's indexed fields in
+// snippet preference order: title, description, then tags.
+func indexedFields(titleField, title, description string, tags []string) []field {
+	fields := []field{{name: titleField, text: html.UnescapeString(title)}}
+	if description != "" {
+		fields = append(fields, field{name: FieldDescription, text: html.UnescapeString(description)})
+	}
+	if len(tags) > 0 {
+		decoded := make([]string, len(tags))
+		for i, tag := range tags {
+			decoded[i] = html.UnescapeString(tag)
+		}
+		fields = append(fields, field{name: FieldTags, text: strings.Join(decoded, ", ")})
+	}
+	return fields
+}
+
+// bestSnippet picks the field whose densest window covers the most distinct
+// query terms, preferring more matching words and then earlier fields, and
+// returns its name with a snippet of that window. Words are matched against
+// the decoded text as in search, fuzzily when fuzzy is set, but snippets are
+// HTML-escaped like the stored fields, and highlight offsets count the
+// escaped text.
+func bestSnippet(fields []field, queryTerms []string, fuzzy bool) (string, string, []Highlight) {
+	bestField, bestCovered, bestCount := -1, 0, 0
+	var bestText []rune
+	var bestMatches []match
+	var bestLo, bestHi int
+	for i, f := range fields {
+		text := []rune(f.text)
+		matches := findMatches(text, queryTerms, fuzzy)
+		if len(matches) == 0 {
+			continue
+		}
+		lo, hi, covered := densestWindow(matches)
+		if bestField < 0 || covered > bestCovered || (covered == bestCovered && hi-lo > bestCount) {
+			bestField, bestCovered, bestCount = i, covered, hi-lo
+			bestText, bestMatches, bestLo, bestHi = text, matches, lo, hi
+		}
+	}
+	if bestField < 0 {
+		return "", "", nil
+	}
+	snippet, highlights := buildSnippet(bestText, bestMatches, bestLo, bestHi)
+	return fields[bestField].name, snippet, highlights
+}
+
+// findMatches returns the words of text that match any query term, in order.
+func findMatches(text []rune, queryTerms []string, fuzzy bool) []match {
+	var matches []match
+	for start := 0; start < len(text); {
+		if !isWordRune(text[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(text) && isWordRune(text[end]) {
+			end++
+		}
+
+		token := strings.ToLower(string(text[start:end]))
+		var terms uint64
+		for i, term := range queryTerms {
+			if i < 64 && termMatch(token, term, fuzzy) > 0 {
+				terms |= 1 << i
+			}
+		}
+		if terms != 0 {
+			matches = append(matches, match{start: start, end: end, terms: terms})
+		}
+		start = end
+	}
+	return matches
+}
+
+// densestWindow returns the half-open range of matches that fits in a
+// snippet and covers the most distinct query terms, preferring more matches
+// and then the earliest window, along with the number of terms covered.
+func densestWindow(matches []match) (lo, hi, covered int) {
+	lo, hi, covered = 0, 1, bits.OnesCount64(matches[0].terms)
+	for i := range matches {
+		var terms uint64
+		for j := i; j < len(matches); j++ {
+			if j > i && matches[j].end-matches[i].start > maxSnippetRunes {
+				break
+			}
+			terms |= matches[j].terms
+			n := bits.OnesCount64(terms)
+			if n > covered || (n == covered && j+1-i > hi-lo) {
+				lo, hi, covered = i, j+1, n
+			}
+		}
+	}
+	return lo, hi, covered
+}
+
+// buildSnippet cuts text down to at most maxSnippetRunes around the window
+// spanned by matches[lo:hi], without splitting words, escapes it, and
+// locates every match that falls inside the result.
+func buildSnippet(text []rune, matches []match, lo, hi int) (string, []Highlight) {
+	first, last := matches[lo].start, matches[hi-1].end
+
+	start, end := 0, len(text)
+	if len(text) > maxSnippetRunes {
+		// Center the window in the available context
+		start = max(0, first-(maxSnippetRunes-(last-first))/2)
+		end = min(len(text), start+maxSnippetRunes)
+		start = min(start, max(0, end-maxSnippetRunes))
+		end = max(end, last)
+
+		for start > 0 && start < first && isWordRune(text[start-1]) {
+			start++
+		}
+		for end < len(text) && end > last && isWordRune(text[end]) {
+			end--
+		}
+	}
+	for start < first && unicode.IsSpace(text[start]) {
+		start++
+	}
+	for end > last && unicode.IsSpace(text[end-1]) {
+		end--
+	}
+
+	var b strings.Builder
+	runes := 0
+	write := func(s string) {
+		b.WriteString(s)
+		runes += utf8.RuneCountInString(s)
+	}
+	if start > 0 {
+		write(ellipsis)
+	}
+	// Escape the text between matches so offsets count the escaped form
+	var highlights []Highlight
+	pos := start
+	for _, m := range matches {
+		if m.start < start || m.end > end {
+			continue
+		}
+		write(html.EscapeString(string(text[pos:m.start])))
+		h := Highlight{Start: runes}
+		write(html.EscapeString(string(text[m.start:m.end])))
+		h.End = runes
+		highlights = append(highlights, h)
+		pos = m.end
+	}
+	write(html.EscapeString(string(text[pos:end])))
+	if end < len(text) {
+		write(ellipsis)
+	}
+	return b.String(), highlights
+}
+
+// isWordRune reports whether r is part of a token, matching tokenize.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,139 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// highlighted returns the highlighted words of a result's snippet.
+func highlighted(r Result) []string {
+	snippet := []rune(r.Snippet)
+	words := make([]string, len(r.Highlights))
+	for i, h := range r.Highlights {
+		words[i] = string(snippet[h.Start:h.End])
+	}
+	return words
+}
+
+func TestIndexer_SearchSnippets(t *testing.T) {
+	ix := newTestIndexer(t)
+
+	tests := []struct {
+		name      string
+		query     string
+		wantID    string
+		wantField string
+		wantWords []string
+	}{
+		{"name match", "warehouse", "techno", FieldName, []string{"Warehouse"}},
+		{"description covers more terms", "late techno", "techno", FieldDescription, []string{"Late", "techno"}},
+		{"prefix match", "base", "jazz", FieldDescription, []string{"basement"}},
+		{"tag match", "electronic", "techno", FieldTags, []string{"electronic"}},
+		{"event title", "jam", "jam", FieldTitle, []string{"Jam"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *Result
+			for _, r := range runSearch(t, ix, tt.query, 0, false) {
+				if r.ID == tt.wantID {
+					got = &r
+				}
+			}
+			if got == nil {
+				t.Fatalf("expected Search(%q) to return %s", tt.query, tt.wantID)
+			}
+			if got.MatchedField != tt.wantField {
+				t.Errorf("expected matched field %q, got %q", tt.wantField, got.MatchedField)
+			}
+			if words := highlighted(*got); !equalIDs(words, tt.wantWords) {
+				t.Errorf("expected highlights %v in %q, got %v", tt.wantWords, got.Snippet, words)
+			}
+		})
+	}
+}
+
+func TestIndexer_SearchSnippetOffsets(t *testing.T) {
+	ix := NewIndexer()
+	ix.IndexScene(&scene.Scene{
+		ID:          "dub",
+		Name:        "Dub Collective",
+		Description: "Bass &amp; dub every Friday",
+		Visibility:  scene.VisibilityPublic,
+	})
+
+	results := runSearch(t, ix, "bass friday", 0, false)
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	r := results[0]
+	if r.MatchedField != FieldDescription {
+		t.Errorf("expected matched field %q, got %q", FieldDescription, r.MatchedField)
+	}
+	if r.Snippet != "Bass &amp; dub every Friday" {
+		t.Errorf("expected the escaped description as snippet, got %q", r.Snippet)
+	}
+	want := []Highlight{{Start: 0, End: 4}, {Start: 21, End: 27}}
+	if len(r.Highlights) != len(want) {
+		t.Fatalf("expected highlights %v, got %v", want, r.Highlights)
+	}
+	for i := range want {
+		if r.Highlights[i] != want[i] {
+			t.Errorf("expected highlight %d at %v, got %v", i, want[i], r.Highlights[i])
+		}
+	}
+}
+
+func TestIndexer_SearchSnippetDensestWindow(t *testing.T) {
+	filler := strings.Repeat("lorem ipsum dolor ", 20)
+	ix := NewIndexer()
+	ix.IndexScene(&scene.Scene{
+		ID:          "long",
+		Name:        "Long Description",
+		Description: "Techno night. " + filler + "Warehouse techno until dawn. " + filler,
+		Visibility:  scene.VisibilityPublic,
+	})
+
+	results := runSearch(t, ix, "warehouse techno", 0, false)
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	r := results[0]
+	if r.MatchedField != FieldDescription {
+		t.Errorf("expected matched field %q, got %q", FieldDescription, r.MatchedField)
+	}
+	if words := highlighted(r); !equalIDs(words, []string{"Warehouse", "techno"}) {
+		t.Errorf("expected the window holding both terms, got %v in %q", words, r.Snippet)
+	}
+	if !strings.HasPrefix(r.Snippet, ellipsis) || !strings.HasSuffix(r.Snippet, ellipsis) {
+		t.Errorf("expected a trimmed snippet, got %q", r.Snippet)
+	}
+	if n := len([]rune(r.Snippet)); n > maxSnippetRunes+2*len([]rune(ellipsis)) {
+		t.Errorf("expected snippet of at most %d characters, got %d", maxSnippetRunes, n)
+	}
+	for _, word := range strings.Fields(strings.Trim(r.Snippet, ellipsis)) {
+		if !strings.Contains(filler+"Warehouse techno until dawn.", word) {
+			t.Errorf("expected whole words only, got %q in %q", word, r.Snippet)
+		}
+	}
+}
+
+func TestIndexer_SearchSnippetsOmitHiddenScenes(t *testing.T) {
+	ix := newTestIndexer(t)
+	ix.IndexScene(&scene.Scene{
+		ID:           "loft",
+		Name:         "Loft Sessions",
+		Description:  "Rooftop loft parties",
+		AllowPrecise: true,
+		PrecisePoint: &scene.Point{Lat: 40.7128, Lng: -74.0060},
+		Visibility:   scene.VisibilityHidden,
+	})
+
+	for _, query := range []string{"secret", "loft", "rooftop"} {
+		for _, r := range runSearch(t, ix, query, 0, false) {
+			t.Errorf("expected no snippet for hidden scenes, got %s %s: %q", r.Type, r.ID, r.Snippet)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Package server provides HTTP server bootstrap with graceful shutdown, so
+// in-flight requests complete before the process exits.
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Default server settings.
+const (
+	// DefaultShutdownTimeout bounds how long shutdown waits for in-flight requests.
+	DefaultShutdownTimeout = 10 * time.Second
+	// DefaultReadTimeout is the maximum duration for reading a request.
+	DefaultReadTimeout = 15 * time.Second
+	// DefaultWriteTimeout is the maximum duration before timing out a response write.
+	DefaultWriteTimeout = 15 * time.Second
+	// DefaultIdleTimeout is how long keep-alive connections stay open between requests.
+	DefaultIdleTimeout = 60 * time.Second
+)
+
+// Config configures the HTTP server.
+type Config struct {
+	// ShutdownTimeout bounds how long shutdown waits for in-flight requests
+	// and OnShutdown hooks.
+	ShutdownTimeout time.Duration
+	// ReadTimeout, WriteTimeout and IdleTimeout are passed to http.Server.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// OnShutdown hooks run after the server has drained, sharing the shutdown
+	// deadline, e.g. indexer.Client.Shutdown. They run in order.
+	OnShutdown []func(ctx context.Context) error
+	// Logger for server lifecycle events.
+	Logger *slog.Logger
+}
+
+// withDefaults fills in zero-valued settings.
+func (c Config) withDefaults() Config {
+	if c.ShutdownTimeout == 0 {
+		c.ShutdownTimeout = DefaultShutdownTimeout
+	}
+	if c.ReadTimeout == 0 {
+		c.ReadTimeout = DefaultReadTimeout
+	}
+	if c.WriteTimeout == 0 {
+		c.WriteTimeout = DefaultWriteTimeout
+	}
+	if c.IdleTimeout == 0 {
+		c.IdleTimeout = DefaultIdleTimeout
+	}
+	if c.Logger == nil {
+		c.Logger = slog.Default()
+	}
+	return c
+}
+
+// Run serves handler on addr with default settings until ctx is cancelled,
+// then shuts down gracefully. See RunWithConfig.
+func Run(ctx context.Context, handler http.Handler, addr string) error {
+	return RunWithConfig(ctx, handler, addr, Config{})
+}
+
+// RunWithConfig listens on addr and serves handler until ctx is cancelled,
+// then shuts down gracefully. See Serve.
+func RunWithConfig(ctx context.Context, handler http.Handler, addr string, config Config) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return Serve(ctx, ln, handler, config)
+}
+
+// Serve serves handler on ln until ctx is cancelled. It then stops accepting
+// connections, waits up to ShutdownTimeout for in-flight requests to
+// complete, and runs the OnShutdown hooks.
+//
+// Returns nil after a clean shutdown. Returns the serve error if the server
+// fails before ctx is cancelled, or the joined shutdown and hook errors if
+// draining did not complete in time.
+func Serve(ctx context.Context, ln net.Listener, handler http.Handler, config Config) error {
+	config = config.withDefaults()
+
+	srv := &http.Server{
+		Handler:      handler,
+		ReadTimeout:  config.ReadTimeout,
+		WriteTimeout: config.WriteTimeout,
+		IdleTimeout:  config.IdleTimeout,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		config.Logger.Info("starting server", "addr", ln.Addr().String())
+		serveErr <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	config.Logger.Info("shutting down server", "timeout", config.ShutdownTimeout)
+
+	// The parent context is already cancelled, so the deadline starts fresh
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		config.Logger.Error("server forced to shutdown", "error", err)
+		errs = append(errs, err)
+	}
+	for _, hook := range config.OnShutdown {
+		if err := hook(shutdownCtx); err != nil {
+			config.Logger.Error("shutdown hook failed", "error", err)
+			errs = append(errs, err)
+		}
+	}
+
+	// Serve returns ErrServerClosed as soon as Shutdown is called
+	if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		errs = append(errs, err)
+	}
+
+	config.Logger.Info("server stopped")
+	return errors.Join(errs...)
+}
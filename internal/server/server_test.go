@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func newTestConfig() Config {
+	return Config{
+		ShutdownTimeout: 2 * time.Second,
+		Logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+}
+
+// startServer runs Serve on a random local port in the background and
+// returns its base URL and a channel receiving Serve's result.
+func startServer(t *testing.T, ctx context.Context, handler http.Handler, config Config) (string, <-chan error) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(ctx, ln, handler, config)
+	}()
+	return "http://" + ln.Addr().String(), done
+}
+
+func TestServe_DrainsInFlightRequest(t *testing.T) {
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("done"))
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	var hookCalled bool
+	config := newTestConfig()
+	config.OnShutdown = []func(context.Context) error{
+		func(ctx context.Context) error {
+			hookCalled = true
+			return nil
+		},
+	}
+	baseURL, done := startServer(t, ctx, handler, config)
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	resultCh := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(baseURL + "/slow")
+		if err != nil {
+			resultCh <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		resultCh <- result{status: resp.StatusCode, body: string(body), err: err}
+	}()
+
+	<-started
+	cancel()
+
+	res := <-resultCh
+	if res.err != nil {
+		t.Fatalf("in-flight request failed: %v", res.err)
+	}
+	if res.status != http.StatusOK || res.body != "done" {
+		t.Errorf("expected 200 'done', got %d %q", res.status, res.body)
+	}
+
+	if err := <-done; err != nil {
+		t.Errorf("expected clean shutdown, got %v", err)
+	}
+	if !hookCalled {
+		t.Error("expected shutdown hook to run")
+	}
+
+	// The listener is closed once Serve returns
+	if _, err := http.Get(baseURL + "/slow"); err == nil {
+		t.Error("expected new requests to fail after shutdown")
+	}
+}
+
+func TestServe_ShutdownTimeout(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	config := newTestConfig()
+	config.ShutdownTimeout = 50 * time.Millisecond
+	baseURL, done := startServer(t, ctx, handler, config)
+
+	go func() {
+		resp, err := http.Get(baseURL + "/stuck")
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+
+	<-started
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestRunWithConfig_ListenError(t *testing.T) {
+	if err := RunWithConfig(t.Context(), http.NotFoundHandler(), "invalid-address", newTestConfig()); err == nil {
+		t.Error("expected listen error for invalid address")
+	}
+}
@@ -1,5 +1,6 @@
 // Package trust provides trust score computation for scenes based on
-// membership and alliance relationships.
+// membership and alliance relationships, and for users based on their
+// participation history.
 package trust
 
 import (
@@ -0,0 +1,102 @@
+package trust
+
+import "time"
+
+// ScoreInputs holds the per-user signals used to compute a user trust score.
+type ScoreInputs struct {
+	// MembershipAge is how long the user has held their oldest active membership.
+	MembershipAge time.Duration
+	// ActiveMemberships is the number of scenes the user is an active member of.
+	ActiveMemberships int
+	// RSVPFollowThrough is the fraction of "going" RSVPs the user attended (0.0-1.0).
+	RSVPFollowThrough float64
+	// AllianceCount is the number of active alliances across the user's scenes.
+	AllianceCount int
+}
+
+// Weights controls how much each signal contributes to Score and the point at
+// which each unbounded signal saturates to full credit. Weights are relative;
+// they do not need to sum to 1.
+type Weights struct {
+	MembershipAge     float64
+	ActiveMemberships float64
+	RSVPFollowThrough float64
+	AllianceCount     float64
+
+	MembershipAgeSaturation     time.Duration
+	ActiveMembershipsSaturation int
+	AllianceCountSaturation     int
+}
+
+// DefaultWeights favors RSVP follow-through and membership age, the signals
+// hardest for a new account to fake.
+var DefaultWeights = Weights{
+	MembershipAge:     0.3,
+	ActiveMemberships: 0.2,
+	RSVPFollowThrough: 0.35,
+	AllianceCount:     0.15,
+
+	MembershipAgeSaturation:     365 * 24 * time.Hour,
+	ActiveMembershipsSaturation: 5,
+	AllianceCountSaturation:     5,
+}
+
+// Score computes a user trust score in [0, 1] using DefaultWeights.
+func Score(inputs ScoreInputs) float64 {
+	return ScoreWithWeights(inputs, DefaultWeights)
+}
+
+// ScoreWithWeights computes a user trust score in [0, 1] as the weighted
+// average of each signal normalized to [0, 1]:
+//
+//	score = sum(weight_i * signal_i) / sum(weight_i)
+//
+// Unbounded signals grow linearly until their saturation point. Negative
+// weights are treated as zero; if every weight is zero the score is 0.0.
+func ScoreWithWeights(inputs ScoreInputs, w Weights) float64 {
+	signals := []struct {
+		weight float64
+		value  float64
+	}{
+		{w.MembershipAge, saturate(inputs.MembershipAge.Hours(), w.MembershipAgeSaturation.Hours())},
+		{w.ActiveMemberships, saturate(float64(inputs.ActiveMemberships), float64(w.ActiveMembershipsSaturation))},
+		{w.RSVPFollowThrough, clamp01(inputs.RSVPFollowThrough)},
+		{w.AllianceCount, saturate(float64(inputs.AllianceCount), float64(w.AllianceCountSaturation))},
+	}
+
+	var weighted, total float64
+	for _, s := range signals {
+		if s.weight <= 0 {
+			continue
+		}
+		weighted += s.weight * s.value
+		total += s.weight
+	}
+	if total == 0 {
+		return 0.0
+	}
+	return clamp01(weighted / total)
+}
+
+// saturate maps value linearly onto [0, 1], reaching 1 at limit.
+// A non-positive limit gives full credit for any positive value.
+func saturate(value, limit float64) float64 {
+	if value <= 0 {
+		return 0.0
+	}
+	if limit <= 0 {
+		return 1.0
+	}
+	return clamp01(value / limit)
+}
+
+// clamp01 bounds v to [0, 1].
+func clamp01(v float64) float64 {
+	if v < 0 {
+		return 0.0
+	}
+	if v > 1 {
+		return 1.0
+	}
+	return v
+}
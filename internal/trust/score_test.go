@@ -0,0 +1,136 @@
+package trust
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestScore_EstablishedMemberOutranksNewAccount(t *testing.T) {
+	established := ScoreInputs{
+		MembershipAge:     2 * 365 * 24 * time.Hour,
+		ActiveMemberships: 4,
+		RSVPFollowThrough: 0.9,
+		AllianceCount:     3,
+	}
+	brandNew := ScoreInputs{
+		MembershipAge:     time.Hour,
+		ActiveMemberships: 1,
+		RSVPFollowThrough: 0,
+		AllianceCount:     0,
+	}
+
+	established1, established2 := Score(established), Score(established)
+	if established1 != established2 {
+		t.Fatalf("Score is not deterministic: %v vs %v", established1, established2)
+	}
+
+	if Score(established) <= Score(brandNew) {
+		t.Errorf("expected established member (%v) to outrank new account (%v)", Score(established), Score(brandNew))
+	}
+}
+
+func TestScoreWithWeights(t *testing.T) {
+	year := 365 * 24 * time.Hour
+
+	tests := []struct {
+		name    string
+		inputs  ScoreInputs
+		weights Weights
+		want    float64
+	}{
+		{
+			name:    "zero inputs score zero",
+			inputs:  ScoreInputs{},
+			weights: DefaultWeights,
+			want:    0.0,
+		},
+		{
+			name: "saturated inputs score one",
+			inputs: ScoreInputs{
+				MembershipAge:     year,
+				ActiveMemberships: 5,
+				RSVPFollowThrough: 1.0,
+				AllianceCount:     5,
+			},
+			weights: DefaultWeights,
+			want:    1.0,
+		},
+		{
+			name: "inputs beyond saturation stay bounded",
+			inputs: ScoreInputs{
+				MembershipAge:     10 * year,
+				ActiveMemberships: 100,
+				RSVPFollowThrough: 3.0,
+				AllianceCount:     100,
+			},
+			weights: DefaultWeights,
+			want:    1.0,
+		},
+		{
+			name:    "negative follow-through clamps to zero",
+			inputs:  ScoreInputs{RSVPFollowThrough: -1},
+			weights: Weights{RSVPFollowThrough: 1},
+			want:    0.0,
+		},
+		{
+			name: "single weighted signal",
+			inputs: ScoreInputs{
+				MembershipAge:     year / 2,
+				RSVPFollowThrough: 1.0,
+			},
+			weights: Weights{MembershipAge: 1, MembershipAgeSaturation: year},
+			want:    0.5,
+		},
+		{
+			name: "weights are relative",
+			inputs: ScoreInputs{
+				RSVPFollowThrough: 1.0,
+			},
+			// 3 * 1.0 / (3 + 1) = 0.75
+			weights: Weights{RSVPFollowThrough: 3, AllianceCount: 1, AllianceCountSaturation: 5},
+			want:    0.75,
+		},
+		{
+			name:    "all zero weights score zero",
+			inputs:  ScoreInputs{RSVPFollowThrough: 1.0},
+			weights: Weights{},
+			want:    0.0,
+		},
+		{
+			name:    "negative weights are ignored",
+			inputs:  ScoreInputs{RSVPFollowThrough: 1.0},
+			weights: Weights{RSVPFollowThrough: 1, AllianceCount: -5},
+			want:    1.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScoreWithWeights(tt.inputs, tt.weights)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ScoreWithWeights() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreWithWeights_TuningChangesRanking(t *testing.T) {
+	veteran := ScoreInputs{MembershipAge: 365 * 24 * time.Hour, RSVPFollowThrough: 0.2}
+	reliable := ScoreInputs{MembershipAge: 24 * time.Hour, RSVPFollowThrough: 1.0}
+
+	ageHeavy := DefaultWeights
+	ageHeavy.MembershipAge = 1
+	ageHeavy.RSVPFollowThrough = 0.1
+
+	followHeavy := DefaultWeights
+	followHeavy.MembershipAge = 0.1
+	followHeavy.RSVPFollowThrough = 1
+
+	if ScoreWithWeights(veteran, ageHeavy) <= ScoreWithWeights(reliable, ageHeavy) {
+		t.Error("expected veteran to rank first with age-heavy weights")
+	}
+	if ScoreWithWeights(reliable, followHeavy) <= ScoreWithWeights(veteran, followHeavy) {
+		t.Error("expected reliable attendee to rank first with follow-through-heavy weights")
+	}
+}
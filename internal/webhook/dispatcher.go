@@ -0,0 +1,281 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Default dispatcher settings.
+const (
+	DefaultMaxAttempts = 5
+	DefaultBaseDelay   = time.Second
+	DefaultMaxDelay    = time.Minute
+	DefaultTimeout     = 10 * time.Second
+)
+
+// userAgent identifies webhook deliveries to receivers.
+const userAgent = "Subcults-Webhooks/1.0"
+
+// maxBackoffShift caps the exponent so the backoff can't overflow.
+const maxBackoffShift = 30
+
+// ErrShuttingDown is returned by Dispatch after Shutdown has been called.
+var ErrShuttingDown = errors.New("webhook dispatcher is shutting down")
+
+// Config configures a Dispatcher. Zero values fall back to the defaults.
+type Config struct {
+	// MaxAttempts is the number of delivery attempts before a delivery is
+	// dead-lettered.
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry; each further retry
+	// doubles it, up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Timeout bounds each delivery attempt.
+	Timeout time.Duration
+	// HTTPClient sends deliveries. Optional; defaults to a client with Timeout.
+	HTTPClient *http.Client
+	// DeadLetters records deliveries that exhausted their retries. Optional;
+	// failures are always logged.
+	DeadLetters DeadLetterLog
+	// Logger for delivery failures.
+	Logger *slog.Logger
+}
+
+// withDefaults fills in zero-valued settings.
+func (c Config) withDefaults() Config {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = DefaultMaxAttempts
+	}
+	if c.BaseDelay <= 0 {
+		c.BaseDelay = DefaultBaseDelay
+	}
+	if c.MaxDelay < c.BaseDelay {
+		c.MaxDelay = max(DefaultMaxDelay, c.BaseDelay)
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultTimeout
+	}
+	if c.HTTPClient == nil {
+		c.HTTPClient = &http.Client{Timeout: c.Timeout}
+	}
+	if c.Logger == nil {
+		c.Logger = slog.Default()
+	}
+	return c
+}
+
+// Dispatcher fans events out to matching subscriptions. Deliveries run in
+// the background with exponential backoff, so Dispatch never blocks on
+// receivers. All methods are safe for concurrent use.
+type Dispatcher struct {
+	subs   SubscriptionRepository
+	config Config
+
+	// ctx is cancelled when Shutdown's deadline passes, aborting retries
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// mu orders inflight.Add before Shutdown's Wait
+	mu           sync.Mutex
+	shuttingDown bool
+	inflight     sync.WaitGroup
+}
+
+// NewDispatcher creates a Dispatcher delivering to subscriptions from subs.
+func NewDispatcher(subs SubscriptionRepository, config Config) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		subs:   subs,
+		config: config.withDefaults(),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Dispatch builds the payload for evt and starts a background delivery to
+// each matching subscription. It returns once deliveries are queued, and
+// deliveries continue after the triggering request completes.
+func (d *Dispatcher) Dispatch(evt Event) error {
+	if !ValidEventType(evt.Type) {
+		return ErrInvalidEventType
+	}
+
+	subs, err := d.subs.ListMatching(evt)
+	if err != nil {
+		return fmt.Errorf("list webhook subscriptions: %w", err)
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(evt.Data)
+	if err != nil {
+		return fmt.Errorf("marshal webhook data: %w", err)
+	}
+	payload := Payload{
+		ID:        uuid.New().String(),
+		Type:      evt.Type,
+		SceneID:   evt.SceneID,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal webhook payload: %w", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.shuttingDown {
+		return ErrShuttingDown
+	}
+	for _, sub := range subs {
+		d.inflight.Add(1)
+		go func(sub *Subscription) {
+			defer d.inflight.Done()
+			d.deliver(sub, payload, body)
+		}(sub)
+	}
+	return nil
+}
+
+// Shutdown stops accepting events and waits for in-flight deliveries,
+// including their retries. If ctx expires first, pending retries are
+// abandoned and ctx's error is returned. Suitable as a server.Config
+// OnShutdown hook.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.mu.Lock()
+	d.shuttingDown = true
+	d.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		d.inflight.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		d.cancel()
+		return nil
+	case <-ctx.Done():
+		d.cancel()
+		<-drained
+		return ctx.Err()
+	}
+}
+
+// deliver POSTs body to sub until it succeeds, fails permanently, or runs
+// out of attempts, then dead-letters failures.
+func (d *Dispatcher) deliver(sub *Subscription, payload Payload, body []byte) {
+	var lastErr error
+	attempts := 0
+	for attempts < d.config.MaxAttempts {
+		if attempts > 0 {
+			select {
+			case <-d.ctx.Done():
+				d.config.Logger.Warn("webhook delivery abandoned at shutdown",
+					"subscription_id", sub.ID, "delivery_id", payload.ID, "attempts", attempts)
+				return
+			case <-time.After(d.backoff(attempts)):
+			}
+		}
+
+		attempts++
+		retryable, err := d.attempt(sub, payload, body)
+		if err == nil {
+			return
+		}
+		lastErr = err
+		d.config.Logger.Warn("webhook delivery attempt failed",
+			"subscription_id", sub.ID, "delivery_id", payload.ID, "attempt", attempts, "error", err)
+		if !retryable {
+			break
+		}
+	}
+
+	d.config.Logger.Error("webhook delivery failed",
+		"subscription_id", sub.ID, "delivery_id", payload.ID, "attempts", attempts, "error", lastErr)
+	if d.config.DeadLetters == nil {
+		return
+	}
+	dl := DeadLetter{
+		SubscriptionID: sub.ID,
+		URL:            sub.URL,
+		DeliveryID:     payload.ID,
+		EventType:      payload.Type,
+		Body:           body,
+		Attempts:       attempts,
+		LastError:      lastErr.Error(),
+		FailedAt:       time.Now(),
+	}
+	if err := d.config.DeadLetters.Record(dl); err != nil {
+		d.config.Logger.Error("failed to record webhook dead letter",
+			"subscription_id", sub.ID, "delivery_id", payload.ID, "error", err)
+	}
+}
+
+// attempt sends one signed delivery. It reports whether a failure is worth
+// retrying: network errors, timeouts, 408, 429 and 5xx responses are; other
+// client errors are not.
+func (d *Dispatcher) attempt(sub *Subscription, payload Payload, body []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(d.ctx, d.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	// Sign every attempt with a fresh timestamp so retries pass replay checks
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderEvent, payload.Type)
+	req.Header.Set(HeaderDelivery, payload.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, timestamp, body))
+
+	resp, err := d.config.HTTPClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	// Drain so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("webhook endpoint returned status %d", resp.StatusCode)
+	retryable := resp.StatusCode >= 500 ||
+		resp.StatusCode == http.StatusRequestTimeout ||
+		resp.StatusCode == http.StatusTooManyRequests
+	return retryable, err
+}
+
+// backoff returns the wait before the retry following the given number of
+// attempts: BaseDelay * 2^(attempts-1), capped at MaxDelay.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	shift := attempts - 1
+	if shift > maxBackoffShift {
+		shift = maxBackoffShift
+	}
+	delay := d.config.BaseDelay << uint(shift)
+	if delay <= 0 || delay > d.config.MaxDelay {
+		return d.config.MaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,267 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestDispatcher returns a dispatcher with fast retries and a dead-letter log.
+func newTestDispatcher(t *testing.T, subs SubscriptionRepository) (*Dispatcher, *InMemoryDeadLetterLog) {
+	t.Helper()
+	deadLetters := NewInMemoryDeadLetterLog()
+	d := NewDispatcher(subs, Config{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+		Timeout:     time.Second,
+		DeadLetters: deadLetters,
+		Logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	return d, deadLetters
+}
+
+// subscribe registers url for sceneID and returns the subscription.
+func subscribe(t *testing.T, subs *InMemorySubscriptionRepository, url, sceneID string, events ...string) *Subscription {
+	t.Helper()
+	sub := &Subscription{URL: url, Secret: "s3cret", SceneID: sceneID, Events: events}
+	if err := subs.Insert(sub); err != nil {
+		t.Fatalf("failed to insert subscription: %v", err)
+	}
+	return sub
+}
+
+func TestDispatcher_SignedDelivery(t *testing.T) {
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	deliveries := make(chan received, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		deliveries <- received{header: r.Header.Clone(), body: body}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	subs := NewInMemorySubscriptionRepository()
+	subscribe(t, subs, server.URL, "")
+	d, deadLetters := newTestDispatcher(t, subs)
+
+	err := d.Dispatch(Event{Type: EventCreated, SceneID: "scene-1", Public: true, Data: map[string]string{"id": "event-1"}})
+	if err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if err := d.Shutdown(t.Context()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	got := <-deliveries
+	if err := Verify("s3cret", got.header.Get(HeaderTimestamp), got.header.Get(HeaderSignature), got.body, DefaultTolerance, time.Now()); err != nil {
+		t.Errorf("expected valid signature, got %v", err)
+	}
+	if got.header.Get(HeaderEvent) != EventCreated {
+		t.Errorf("expected %s header %q, got %q", HeaderEvent, EventCreated, got.header.Get(HeaderEvent))
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(got.body, &payload); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if payload.Type != EventCreated || payload.SceneID != "scene-1" || string(payload.Data) != `{"id":"event-1"}` {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+	if payload.ID == "" || got.header.Get(HeaderDelivery) != payload.ID {
+		t.Errorf("expected delivery header to match payload ID %q, got %q", payload.ID, got.header.Get(HeaderDelivery))
+	}
+	if len(deadLetters.Entries()) != 0 {
+		t.Errorf("expected no dead letters, got %d", len(deadLetters.Entries()))
+	}
+}
+
+func TestDispatcher_RetriesFlakyEndpoint(t *testing.T) {
+	var calls atomic.Int32
+	var mu sync.Mutex
+	var signatures []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		signatures = append(signatures, r.Header.Get(HeaderSignature))
+		mu.Unlock()
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	subs := NewInMemorySubscriptionRepository()
+	subscribe(t, subs, server.URL, "scene-1")
+	d, deadLetters := newTestDispatcher(t, subs)
+
+	if err := d.Dispatch(Event{Type: SceneUpdated, SceneID: "scene-1"}); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if err := d.Shutdown(t.Context()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+	for i, sig := range signatures {
+		if sig == "" {
+			t.Errorf("attempt %d was unsigned", i+1)
+		}
+	}
+	if len(deadLetters.Entries()) != 0 {
+		t.Errorf("expected delivery to succeed without dead letters, got %d", len(deadLetters.Entries()))
+	}
+}
+
+func TestDispatcher_DeadLettersAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	subs := NewInMemorySubscriptionRepository()
+	sub := subscribe(t, subs, server.URL, "", EventCancelled)
+	d, deadLetters := newTestDispatcher(t, subs)
+
+	if err := d.Dispatch(Event{Type: EventCancelled, SceneID: "scene-1", Public: true}); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if err := d.Shutdown(t.Context()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+	entries := deadLetters.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d", len(entries))
+	}
+	if entries[0].SubscriptionID != sub.ID || entries[0].Attempts != 3 || entries[0].EventType != EventCancelled {
+		t.Errorf("unexpected dead letter: %+v", entries[0])
+	}
+}
+
+func TestDispatcher_ClientErrorNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+
+	subs := NewInMemorySubscriptionRepository()
+	subscribe(t, subs, server.URL, "scene-1")
+	d, deadLetters := newTestDispatcher(t, subs)
+
+	if err := d.Dispatch(Event{Type: SceneUpdated, SceneID: "scene-1"}); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if err := d.Shutdown(t.Context()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected a single attempt for a 410 response, got %d", got)
+	}
+	if entries := deadLetters.Entries(); len(entries) != 1 || entries[0].Attempts != 1 {
+		t.Errorf("expected one dead letter after 1 attempt, got %+v", entries)
+	}
+}
+
+func TestDispatcher_ShutdownAbandonsRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	subs := NewInMemorySubscriptionRepository()
+	subscribe(t, subs, server.URL, "scene-1")
+	d := NewDispatcher(subs, Config{
+		MaxAttempts: 10,
+		BaseDelay:   time.Hour,
+		Logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+
+	if err := d.Dispatch(Event{Type: SceneUpdated, SceneID: "scene-1"}); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	if err := d.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if err := d.Dispatch(Event{Type: SceneUpdated, SceneID: "scene-1"}); !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("expected ErrShuttingDown after shutdown, got %v", err)
+	}
+}
+
+func TestSubscription_Matches(t *testing.T) {
+	tests := []struct {
+		name string
+		sub  Subscription
+		evt  Event
+		want bool
+	}{
+		{"global receives public", Subscription{}, Event{Type: EventCreated, SceneID: "a", Public: true}, true},
+		{"global skips non-public", Subscription{}, Event{Type: EventCreated, SceneID: "a"}, false},
+		{"scoped receives non-public", Subscription{SceneID: "a"}, Event{Type: EventCreated, SceneID: "a"}, true},
+		{"scoped skips other scenes", Subscription{SceneID: "a"}, Event{Type: EventCreated, SceneID: "b", Public: true}, false},
+		{"event filter match", Subscription{Events: []string{EventCancelled}}, Event{Type: EventCancelled, Public: true}, true},
+		{"event filter miss", Subscription{Events: []string{EventCancelled}}, Event{Type: SceneUpdated, Public: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sub.Matches(tt.evt); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemorySubscriptionRepository_InsertValidation(t *testing.T) {
+	subs := NewInMemorySubscriptionRepository()
+
+	tests := []struct {
+		name    string
+		sub     Subscription
+		wantErr error
+	}{
+		{"valid", Subscription{URL: "https://example.com/hook", Secret: "s"}, nil},
+		{"relative URL", Subscription{URL: "/hook", Secret: "s"}, ErrInvalidURL},
+		{"unsupported scheme", Subscription{URL: "ftp://example.com", Secret: "s"}, ErrInvalidURL},
+		{"empty secret", Subscription{URL: "https://example.com/hook", Secret: " "}, ErrEmptySecret},
+		{"unknown event", Subscription{URL: "https://example.com/hook", Secret: "s", Events: []string{"scene.exploded"}}, ErrInvalidEventType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := tt.sub
+			if err := subs.Insert(&sub); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Insert() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := subs.Delete("missing"); !errors.Is(err, ErrSubscriptionNotFound) {
+		t.Errorf("expected ErrSubscriptionNotFound, got %v", err)
+	}
+}
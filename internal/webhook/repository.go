@@ -0,0 +1,199 @@
+package webhook
+
+import (
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Subscription errors.
+var (
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrInvalidURL           = errors.New("webhook URL must be an absolute http or https URL")
+	ErrEmptySecret          = errors.New("webhook secret is required")
+	ErrInvalidEventType     = errors.New("unknown webhook event type")
+)
+
+// Subscription is an endpoint registered to receive webhook deliveries.
+type Subscription struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// Secret signs deliveries; it is never serialized.
+	Secret string `json:"-"`
+	// SceneID scopes the subscription to one scene's changes, including
+	// non-public ones. Empty receives changes to every public scene.
+	SceneID string `json:"scene_id,omitempty"`
+	// Events lists the event types delivered. Empty receives all types.
+	Events    []string  `json:"events,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Matches reports whether the subscription should receive evt.
+func (s *Subscription) Matches(evt Event) bool {
+	if s.SceneID == "" {
+		if !evt.Public {
+			return false
+		}
+	} else if s.SceneID != evt.SceneID {
+		return false
+	}
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, t := range s.Events {
+		if t == evt.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks the subscription's URL, secret and event types.
+func (s *Subscription) validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+	if strings.TrimSpace(s.Secret) == "" {
+		return ErrEmptySecret
+	}
+	for _, t := range s.Events {
+		if !ValidEventType(t) {
+			return ErrInvalidEventType
+		}
+	}
+	return nil
+}
+
+// SubscriptionRepository stores webhook subscriptions.
+type SubscriptionRepository interface {
+	// Insert validates and stores a new subscription, assigning its ID and
+	// CreatedAt. Returns ErrInvalidURL, ErrEmptySecret or ErrInvalidEventType
+	// for invalid subscriptions.
+	Insert(sub *Subscription) error
+
+	// Delete removes a subscription.
+	// Returns ErrSubscriptionNotFound if it doesn't exist.
+	Delete(id string) error
+
+	// ListMatching returns the subscriptions that should receive evt.
+	ListMatching(evt Event) ([]*Subscription, error)
+}
+
+// InMemorySubscriptionRepository is an in-memory SubscriptionRepository.
+// Thread-safe via RWMutex.
+type InMemorySubscriptionRepository struct {
+	mu   sync.RWMutex
+	subs map[string]*Subscription
+}
+
+// NewInMemorySubscriptionRepository creates a new in-memory subscription repository.
+func NewInMemorySubscriptionRepository() *InMemorySubscriptionRepository {
+	return &InMemorySubscriptionRepository{
+		subs: make(map[string]*Subscription),
+	}
+}
+
+// Insert validates and stores a new subscription.
+func (r *InMemorySubscriptionRepository) Insert(sub *Subscription) error {
+	if err := sub.validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sub.ID = uuid.New().String()
+	sub.CreatedAt = time.Now()
+	stored := *sub
+	stored.Events = append([]string(nil), sub.Events...)
+	r.subs[stored.ID] = &stored
+	return nil
+}
+
+// Delete removes a subscription.
+func (r *InMemorySubscriptionRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.subs[id]; !ok {
+		return ErrSubscriptionNotFound
+	}
+	delete(r.subs, id)
+	return nil
+}
+
+// ListMatching returns copies of the subscriptions that should receive evt,
+// oldest first.
+func (r *InMemorySubscriptionRepository) ListMatching(evt Event) ([]*Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := make([]*Subscription, 0)
+	for _, sub := range r.subs {
+		if !sub.Matches(evt) {
+			continue
+		}
+		subCopy := *sub
+		subCopy.Events = append([]string(nil), sub.Events...)
+		matches = append(matches, &subCopy)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].CreatedAt.Equal(matches[j].CreatedAt) {
+			return matches[i].ID < matches[j].ID
+		}
+		return matches[i].CreatedAt.Before(matches[j].CreatedAt)
+	})
+	return matches, nil
+}
+
+// DeadLetter records a delivery that failed after all retry attempts.
+type DeadLetter struct {
+	SubscriptionID string    `json:"subscription_id"`
+	URL            string    `json:"url"`
+	DeliveryID     string    `json:"delivery_id"`
+	EventType      string    `json:"event_type"`
+	Body           []byte    `json:"body"`
+	Attempts       int       `json:"attempts"`
+	LastError      string    `json:"last_error"`
+	FailedAt       time.Time `json:"failed_at"`
+}
+
+// DeadLetterLog stores deliveries that exhausted their retries so they can
+// be inspected or replayed.
+type DeadLetterLog interface {
+	// Record stores a failed delivery.
+	Record(dl DeadLetter) error
+}
+
+// InMemoryDeadLetterLog is an in-memory DeadLetterLog.
+// Thread-safe via RWMutex.
+type InMemoryDeadLetterLog struct {
+	mu      sync.RWMutex
+	entries []DeadLetter
+}
+
+// NewInMemoryDeadLetterLog creates a new in-memory dead-letter log.
+func NewInMemoryDeadLetterLog() *InMemoryDeadLetterLog {
+	return &InMemoryDeadLetterLog{}
+}
+
+// Record stores a failed delivery.
+func (l *InMemoryDeadLetterLog) Record(dl DeadLetter) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, dl)
+	return nil
+}
+
+// Entries returns the recorded failures, oldest first.
+func (l *InMemoryDeadLetterLog) Entries() []DeadLetter {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return append([]DeadLetter(nil), l.entries...)
+}
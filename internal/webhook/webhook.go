@@ -0,0 +1,112 @@
+// Package webhook delivers signed JSON notifications about scene and event
+// changes to integrator endpoints such as chat bots and mailing lists.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event types delivered to subscriptions.
+const (
+	EventCreated   = "event.created"
+	EventCancelled = "event.cancelled"
+	SceneUpdated   = "scene.updated"
+)
+
+// validEventTypes lists the event types subscriptions may register for.
+var validEventTypes = map[string]bool{
+	EventCreated:   true,
+	EventCancelled: true,
+	SceneUpdated:   true,
+}
+
+// ValidEventType reports whether t is a known event type.
+func ValidEventType(t string) bool {
+	return validEventTypes[t]
+}
+
+// Delivery headers.
+const (
+	HeaderEvent     = "X-Subcults-Event"
+	HeaderDelivery  = "X-Subcults-Delivery"
+	HeaderTimestamp = "X-Subcults-Timestamp"
+	HeaderSignature = "X-Subcults-Signature"
+)
+
+// signaturePrefix identifies the signing scheme in HeaderSignature.
+const signaturePrefix = "sha256="
+
+// DefaultTolerance is how old a signed timestamp may be before Verify treats
+// the delivery as a replay.
+const DefaultTolerance = 5 * time.Minute
+
+// Signature verification errors.
+var (
+	ErrMissingSignature = errors.New("missing webhook signature or timestamp")
+	ErrInvalidTimestamp = errors.New("invalid webhook timestamp")
+	ErrTimestampExpired = errors.New("webhook timestamp outside tolerance")
+	ErrInvalidSignature = errors.New("webhook signature mismatch")
+)
+
+// Event is a change notification to fan out to subscriptions.
+type Event struct {
+	// Type is one of the event type constants.
+	Type string
+	// SceneID is the scene the change belongs to.
+	SceneID string
+	// Public marks changes to publicly visible scenes. Only public changes
+	// reach subscriptions that aren't scoped to a scene.
+	Public bool
+	// Data is marshaled as the payload's data field. Callers must strip
+	// precise locations before dispatching.
+	Data any
+}
+
+// Payload is the JSON body POSTed to subscription endpoints.
+type Payload struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	SceneID   string          `json:"scene_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Sign returns the HeaderSignature value for body sent at timestamp (Unix
+// seconds). The HMAC-SHA256 covers "timestamp.body" so a captured delivery
+// can't be replayed with a fresh timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a delivery's HeaderTimestamp and HeaderSignature values
+// against body. Timestamps further than tolerance from now, in either
+// direction, are rejected as replays.
+func Verify(secret, timestampHeader, signatureHeader string, body []byte, tolerance time.Duration, now time.Time) error {
+	if timestampHeader == "" || signatureHeader == "" {
+		return ErrMissingSignature
+	}
+	timestamp, err := strconv.ParseInt(strings.TrimSpace(timestampHeader), 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	age := now.Sub(time.Unix(timestamp, 0))
+	if age > tolerance || age < -tolerance {
+		return ErrTimestampExpired
+	}
+	expected := Sign(secret, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(strings.TrimSpace(signatureHeader))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
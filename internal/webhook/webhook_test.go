@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"type":"event.created"}`)
+	timestamp := int64(1700000000)
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte("1700000000." + string(body)))
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if got := Sign("s3cret", timestamp, body); got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+	if Sign("other", timestamp, body) == want {
+		t.Error("expected different secrets to produce different signatures")
+	}
+	if Sign("s3cret", timestamp+1, body) == want {
+		t.Error("expected the timestamp to be covered by the signature")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"type":"scene.updated"}`)
+	now := time.Unix(1700000000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := Sign("s3cret", now.Unix(), body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		signature string
+		body      []byte
+		now       time.Time
+		wantErr   error
+	}{
+		{"valid", "s3cret", timestamp, signature, body, now, nil},
+		{"within tolerance", "s3cret", timestamp, signature, body, now.Add(DefaultTolerance), nil},
+		{"missing signature", "s3cret", timestamp, "", body, now, ErrMissingSignature},
+		{"missing timestamp", "s3cret", "", signature, body, now, ErrMissingSignature},
+		{"malformed timestamp", "s3cret", "yesterday", signature, body, now, ErrInvalidTimestamp},
+		{"replayed", "s3cret", timestamp, signature, body, now.Add(DefaultTolerance + time.Second), ErrTimestampExpired},
+		{"from the future", "s3cret", timestamp, signature, body, now.Add(-DefaultTolerance - time.Second), ErrTimestampExpired},
+		{"wrong secret", "other", timestamp, signature, body, now, ErrInvalidSignature},
+		{"tampered body", "s3cret", timestamp, signature, []byte(`{"type":"event.cancelled"}`), now, ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.timestamp, tt.signature, tt.body, DefaultTolerance, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
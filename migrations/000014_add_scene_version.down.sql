@@ -0,0 +1,4 @@
+-- Remove version column from scenes table
+
+ALTER TABLE scenes
+    DROP COLUMN IF EXISTS version;
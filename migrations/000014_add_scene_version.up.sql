@@ -0,0 +1,7 @@
+-- Add version column to scenes table for optimistic concurrency control
+-- Exposed to clients as an ETag; conditional updates must match the current version
+
+ALTER TABLE scenes
+    ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN scenes.version IS 'Incremented on each successful write; compared against If-Match on update';
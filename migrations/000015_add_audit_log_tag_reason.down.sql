@@ -0,0 +1,7 @@
+-- Remove tag and reason columns from audit_logs
+
+DROP INDEX IF EXISTS idx_audit_logs_tag;
+
+ALTER TABLE audit_logs
+    DROP COLUMN IF EXISTS reason,
+    DROP COLUMN IF EXISTS tag;
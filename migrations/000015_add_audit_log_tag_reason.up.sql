@@ -0,0 +1,11 @@
+-- Add tag and reason columns to audit_logs for privileged moderation actions
+-- Admin actions are tagged 'admin' and must record the admin's stated reason
+
+ALTER TABLE audit_logs
+    ADD COLUMN tag VARCHAR(50),
+    ADD COLUMN reason TEXT;
+
+CREATE INDEX idx_audit_logs_tag ON audit_logs(tag, created_at DESC) WHERE tag IS NOT NULL;
+
+COMMENT ON COLUMN audit_logs.tag IS 'Marks entries written by privileged actors (admin)';
+COMMENT ON COLUMN audit_logs.reason IS 'Reason given for a moderation action';
@@ -0,0 +1,3 @@
+-- Rollback: Remove reports table
+
+DROP TABLE IF EXISTS reports;
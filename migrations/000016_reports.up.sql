@@ -0,0 +1,36 @@
+-- Migration: Add reports table for user flagging of scenes and events
+-- Adds: reports table with a partial unique index allowing one open report per reporter per entity
+
+-- Step 1: Create reports table
+CREATE TABLE IF NOT EXISTS reports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    reporter_did VARCHAR(255) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ,
+    resolved_by VARCHAR(255)
+);
+
+-- Step 2: Add CHECK constraints for valid values
+ALTER TABLE reports ADD CONSTRAINT chk_report_entity_type
+    CHECK (entity_type IN ('scene', 'event'));
+ALTER TABLE reports ADD CONSTRAINT chk_report_status
+    CHECK (status IN ('open', 'resolved', 'dismissed'));
+ALTER TABLE reports ADD CONSTRAINT chk_report_reason_length
+    CHECK (char_length(reason) BETWEEN 1 AND 1000);
+
+-- Step 3: Add indexes
+-- One open report per reporter per entity; resolved reports don't count
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reports_open_unique
+    ON reports(reporter_did, entity_type, entity_id) WHERE status = 'open';
+
+-- Moderator queue, newest first
+CREATE INDEX IF NOT EXISTS idx_reports_status_created ON reports(status, created_at DESC, id DESC);
+
+-- Step 4: Add table and column comments
+COMMENT ON TABLE reports IS 'User reports flagging scenes and events for moderator review';
+COMMENT ON COLUMN reports.status IS 'Report status (open, resolved, dismissed); only open reports can change';
+COMMENT ON COLUMN reports.resolved_by IS 'DID of the moderator who resolved the report';
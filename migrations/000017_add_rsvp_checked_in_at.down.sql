@@ -0,0 +1,4 @@
+-- Remove checked_in_at column from event_rsvps
+
+ALTER TABLE event_rsvps
+    DROP COLUMN IF EXISTS checked_in_at;
@@ -0,0 +1,7 @@
+-- Add checked_in_at column to event_rsvps for event check-in tracking
+-- Set by the scene owner or an admin during the event window; NULL until checked in
+
+ALTER TABLE event_rsvps
+    ADD COLUMN checked_in_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN event_rsvps.checked_in_at IS 'When an organizer checked the attendee in; only going RSVPs can be checked in';
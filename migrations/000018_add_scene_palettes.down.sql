@@ -0,0 +1,4 @@
+-- Remove palettes column from scenes; the light theme remains in palette
+
+ALTER TABLE scenes
+    DROP COLUMN IF EXISTS palettes;
@@ -0,0 +1,12 @@
+-- Add palettes JSONB column to scenes for per-theme palettes
+-- Keys are theme names ("light", "dark"); the legacy palette column holds the light theme
+
+ALTER TABLE scenes
+    ADD COLUMN palettes JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- Existing single palettes become the light theme
+UPDATE scenes
+SET palettes = jsonb_build_object('light', palette)
+WHERE palette IS NOT NULL AND palette <> '{}'::jsonb;
+
+COMMENT ON COLUMN scenes.palettes IS 'JSONB map of theme name to color palette; palettes->''light'' mirrors palette';
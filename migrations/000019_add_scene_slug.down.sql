@@ -0,0 +1,6 @@
+-- Remove slug column and its index from scenes
+
+DROP INDEX IF EXISTS idx_scenes_owner_slug;
+
+ALTER TABLE scenes
+    DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,9 @@
+-- Add slug column to scenes for human-readable links
+-- Derived from the name on create; unique per owner among non-deleted scenes
+
+ALTER TABLE scenes
+    ADD COLUMN slug VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX idx_scenes_owner_slug ON scenes(owner_did, slug) WHERE deleted_at IS NULL AND slug <> '';
+
+COMMENT ON COLUMN scenes.slug IS 'Lowercase URL slug, unique per owner among non-deleted scenes; kept on rename';
@@ -0,0 +1,4 @@
+-- Remove timezone column from events
+
+ALTER TABLE events
+    DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,7 @@
+-- Add timezone column to events for local wall-clock display
+-- IANA zone name (e.g. America/New_York); empty means UTC. starts_at/ends_at stay absolute instants
+
+ALTER TABLE events
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN events.timezone IS 'IANA time zone name the event is scheduled in; empty means UTC';
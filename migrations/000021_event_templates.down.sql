@@ -0,0 +1,3 @@
+-- Rollback: Remove event_templates table
+
+DROP TABLE IF EXISTS event_templates;
@@ -0,0 +1,32 @@
+-- Migration: Add event_templates table for reusable per-scene event defaults
+-- Adds: event_templates table; events copy template fields on creation and keep no reference
+
+-- Step 1: Create event_templates table
+CREATE TABLE IF NOT EXISTS event_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    scene_id UUID NOT NULL REFERENCES scenes(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    tags TEXT[],
+    coarse_geohash VARCHAR(20),
+    capacity INTEGER NOT NULL DEFAULT 0,
+    duration_minutes INTEGER NOT NULL DEFAULT 0,
+    timezone VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Step 2: Add CHECK constraints for valid values
+ALTER TABLE event_templates ADD CONSTRAINT chk_event_template_capacity
+    CHECK (capacity >= 0);
+ALTER TABLE event_templates ADD CONSTRAINT chk_event_template_duration
+    CHECK (duration_minutes >= 0);
+
+-- Step 3: Add indexes
+CREATE INDEX IF NOT EXISTS idx_event_templates_scene ON event_templates(scene_id, created_at);
+
+-- Step 4: Add table and column comments
+COMMENT ON TABLE event_templates IS 'Owner-managed default fields for creating events in a scene';
+COMMENT ON COLUMN event_templates.title IS 'Title pattern; {date} expands to the event start date in its time zone';
+COMMENT ON COLUMN event_templates.duration_minutes IS 'Default event length used to derive ends_at; 0 means no end time';